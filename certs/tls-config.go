@@ -0,0 +1,281 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/rjeczalik/notify"
+)
+
+// CipherPolicy is a named set of TLS versions, cipher suites and curves.
+type CipherPolicy string
+
+// Supported cipher policies.
+const (
+	// CipherPolicyModern allows TLS 1.3 only.
+	CipherPolicyModern CipherPolicy = "modern"
+
+	// CipherPolicyIntermediate allows TLS 1.2 with forward secret AEAD
+	// cipher suites and TLS 1.3. It is the default policy.
+	CipherPolicyIntermediate CipherPolicy = "intermediate"
+
+	// CipherPolicyFIPS allows TLS 1.2 and TLS 1.3 restricted to the
+	// cipher suites and curves approved by NIST SP 800-52r2. The TLS 1.3
+	// cipher suites are not configurable, they are restricted to the
+	// approved AES-GCM suites only if the Go FIPS 140-3 mode is enabled.
+	CipherPolicyFIPS CipherPolicy = "fips"
+)
+
+// cipherPolicy is the TLS configuration of a CipherPolicy.
+type cipherPolicy struct {
+	minVersion   uint16
+	cipherSuites []uint16
+	curves       []tls.CurveID
+}
+
+var cipherPolicies = map[CipherPolicy]cipherPolicy{
+	CipherPolicyModern: {
+		minVersion: tls.VersionTLS13,
+		curves:     []tls.CurveID{tls.X25519, tls.CurveP256, tls.CurveP384},
+	},
+	CipherPolicyIntermediate: {
+		minVersion: tls.VersionTLS12,
+		cipherSuites: []uint16{
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+		},
+		curves: []tls.CurveID{tls.X25519, tls.CurveP256, tls.CurveP384},
+	},
+	CipherPolicyFIPS: {
+		minVersion: tls.VersionTLS12,
+		cipherSuites: []uint16{
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+		},
+		curves: []tls.CurveID{tls.CurveP256, tls.CurveP384},
+	},
+}
+
+// TLSOptions are the options of NewTLSConfig.
+type TLSOptions struct {
+	// MinVersion is the minimum TLS version. If zero, the minimum version
+	// of the cipher policy is used. It must not be lower than the minimum
+	// version of the cipher policy.
+	MinVersion uint16
+
+	// CipherPolicy is the cipher policy. If empty, CipherPolicyIntermediate
+	// is used.
+	CipherPolicy CipherPolicy
+
+	// ClientAuth is the client authentication mode.
+	ClientAuth tls.ClientAuthType
+
+	// ClientCAs is the path of a file or directory containing the PEM
+	// encoded CA certificates verifying client certificates. Unlike
+	// GetRootCAs, the system root CAs are not included. It is required
+	// if ClientAuth verifies client certificates.
+	//
+	// The CA certificates are reloaded whenever the path changes and
+	// whenever the certificates of the Manager are reloaded.
+	ClientCAs string
+
+	// NextProtos is the list of supported ALPN protocols.
+	NextProtos []string
+}
+
+// NewTLSConfig returns a server TLS configuration serving the certificates
+// of manager, as selected by Manager.GetCertificate, with the given options.
+//
+// The client CA certificates are watched until the context of manager is
+// canceled.
+func NewTLSConfig(manager *Manager, opts TLSOptions) (*tls.Config, error) {
+	if manager == nil {
+		return nil, errors.New("certs: manager must not be nil")
+	}
+	if opts.CipherPolicy == "" {
+		opts.CipherPolicy = CipherPolicyIntermediate
+	}
+	policy, ok := cipherPolicies[opts.CipherPolicy]
+	if !ok {
+		return nil, fmt.Errorf("certs: unknown cipher policy '%s'", opts.CipherPolicy)
+	}
+	if opts.MinVersion == 0 {
+		opts.MinVersion = policy.minVersion
+	}
+	if opts.MinVersion < policy.minVersion || opts.MinVersion > tls.VersionTLS13 {
+		return nil, fmt.Errorf("certs: TLS version '%s' is not supported by cipher policy '%s'", tls.VersionName(opts.MinVersion), opts.CipherPolicy)
+	}
+	if opts.ClientAuth < tls.NoClientCert || opts.ClientAuth > tls.RequireAndVerifyClientCert {
+		return nil, fmt.Errorf("certs: invalid client auth mode '%d'", opts.ClientAuth)
+	}
+	verifiesClientCerts := opts.ClientAuth == tls.VerifyClientCertIfGiven || opts.ClientAuth == tls.RequireAndVerifyClientCert
+	if verifiesClientCerts && opts.ClientCAs == "" {
+		return nil, fmt.Errorf("certs: client auth mode '%s' requires client CA certificates", opts.ClientAuth)
+	}
+
+	config := &tls.Config{
+		MinVersion:       opts.MinVersion,
+		CipherSuites:     slices.Clone(policy.cipherSuites),
+		CurvePreferences: slices.Clone(policy.curves),
+		ClientAuth:       opts.ClientAuth,
+		NextProtos:       slices.Clone(opts.NextProtos),
+		GetCertificate:   manager.GetCertificate,
+	}
+	if opts.ClientCAs == "" {
+		return config, nil
+	}
+
+	clientCAs, err := loadCertPool(opts.ClientCAs)
+	if err != nil {
+		return nil, err
+	}
+	config.ClientCAs = clientCAs
+
+	// The client CAs of a tls.Config must not be modified once it is in
+	// use, hence each reload stores a new config served to new connections.
+	var current atomic.Pointer[tls.Config]
+	current.Store(config.Clone())
+	reload := func() {
+		pool, err := loadCertPool(opts.ClientCAs)
+		if err != nil {
+			return // Keep the previous CA certificates.
+		}
+		c := config.Clone()
+		c.ClientCAs = pool
+		current.Store(c)
+	}
+	if err = manager.watchClientCAs(opts.ClientCAs, reload); err != nil {
+		return nil, err
+	}
+	config.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		return current.Load(), nil
+	}
+	return config, nil
+}
+
+// watchClientCAs calls reload whenever the file or directory at path
+// changes, or the certificates of m are reloaded, until m is done.
+func (m *Manager) watchClientCAs(path string, reload func()) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	isLink, err := isSymlink(path)
+	if err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	reloadCh := m.reloader()
+	if isLink || isk8s {
+		// Symlinks, as mounted by Kubernetes, are replaced rather than
+		// written, hence they are reloaded periodically.
+		go func(duration time.Duration) {
+			t := time.NewTicker(duration)
+			defer t.Stop()
+			for {
+				select {
+				case <-m.done:
+					return
+				case <-t.C:
+				case <-reloadCh:
+				}
+				reload()
+			}
+		}(m.duration)
+		return nil
+	}
+
+	events := make(chan notify.EventInfo, 1)
+	if err = notify.Watch(watchPath(path), events, eventWrite...); err != nil {
+		return err
+	}
+	go func() {
+		defer notify.Stop(events)
+		for {
+			select {
+			case <-m.done:
+				return
+			case <-events:
+			case <-reloadCh:
+			}
+			reload()
+		}
+	}()
+	return nil
+}
+
+// watchPath returns the directory to watch for changes of the file or
+// directory at path.
+func watchPath(path string) string {
+	if st, err := os.Stat(path); err == nil && st.IsDir() {
+		return path
+	}
+	return filepath.Dir(path)
+}
+
+// loadCertPool returns a certificate pool containing the PEM encoded
+// certificates of the file at path, or of the top-level files of the
+// directory at path.
+func loadCertPool(path string) (*x509.CertPool, error) {
+	st, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !st.IsDir() {
+		bytes, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if !pool.AppendCertsFromPEM(bytes) {
+			return nil, fmt.Errorf("certs: '%s' does not contain a valid X.509 PEM-encoded certificate", path)
+		}
+		return pool, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		bytes, err := os.ReadFile(filepath.Join(path, entry.Name()))
+		if err == nil { // ignore files which are not readable.
+			pool.AppendCertsFromPEM(bytes)
+		}
+	}
+	return pool, nil
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package certs_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/pkg/v3/certs"
+)
+
+func TestNewTLSConfig(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	manager, err := certs.NewManager(ctx, "public.crt", "private.key", tls.LoadX509KeyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		opts         certs.TLSOptions
+		minVersion   uint16
+		cipherSuites []uint16
+		curves       []tls.CurveID
+		expectErr    bool
+	}{
+		{
+			opts:       certs.TLSOptions{},
+			minVersion: tls.VersionTLS12,
+			cipherSuites: []uint16{
+				tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+				tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+				tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+				tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+				tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+				tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+			},
+			curves: []tls.CurveID{tls.X25519, tls.CurveP256, tls.CurveP384},
+		},
+		{
+			opts:       certs.TLSOptions{CipherPolicy: certs.CipherPolicyModern},
+			minVersion: tls.VersionTLS13,
+			curves:     []tls.CurveID{tls.X25519, tls.CurveP256, tls.CurveP384},
+		},
+		{
+			opts:       certs.TLSOptions{CipherPolicy: certs.CipherPolicyFIPS},
+			minVersion: tls.VersionTLS12,
+			cipherSuites: []uint16{
+				tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+				tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+				tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+				tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+			},
+			curves: []tls.CurveID{tls.CurveP256, tls.CurveP384},
+		},
+		{
+			opts:       certs.TLSOptions{CipherPolicy: certs.CipherPolicyFIPS, MinVersion: tls.VersionTLS13},
+			minVersion: tls.VersionTLS13,
+			cipherSuites: []uint16{
+				tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+				tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+				tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+				tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+			},
+			curves: []tls.CurveID{tls.CurveP256, tls.CurveP384},
+		},
+		{opts: certs.TLSOptions{CipherPolicy: certs.CipherPolicyFIPS, MinVersion: tls.VersionTLS11}, expectErr: true},
+		{opts: certs.TLSOptions{CipherPolicy: certs.CipherPolicyModern, MinVersion: tls.VersionTLS12}, expectErr: true},
+		{opts: certs.TLSOptions{CipherPolicy: "legacy"}, expectErr: true},
+		{opts: certs.TLSOptions{ClientAuth: tls.RequireAndVerifyClientCert}, expectErr: true},
+		{opts: certs.TLSOptions{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: "nonexistent-dir"}, expectErr: true},
+	}
+
+	for i, testCase := range testCases {
+		config, err := certs.NewTLSConfig(manager, testCase.opts)
+		if expectErr := err != nil; expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		if config.MinVersion != testCase.minVersion {
+			t.Fatalf("case %v: min version: expected: %v, got: %v", i+1, testCase.minVersion, config.MinVersion)
+		}
+		if !reflect.DeepEqual(config.CipherSuites, testCase.cipherSuites) {
+			t.Fatalf("case %v: cipher suites: expected: %v, got: %v", i+1, testCase.cipherSuites, config.CipherSuites)
+		}
+		if !reflect.DeepEqual(config.CurvePreferences, testCase.curves) {
+			t.Fatalf("case %v: curves: expected: %v, got: %v", i+1, testCase.curves, config.CurvePreferences)
+		}
+		cert, err := config.GetCertificate(&tls.ClientHelloInfo{})
+		if err != nil || cert == nil {
+			t.Fatalf("case %v: expected a certificate, got: %v", i+1, err)
+		}
+	}
+}
+
+func TestNewTLSConfigClientCAs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	manager, err := certs.NewManager(ctx, "public.crt", "private.key", tls.LoadX509KeyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	originalCA, err := os.ReadFile("original-public.crt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	newCA, err := os.ReadFile("new-public.crt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err = os.WriteFile(filepath.Join(dir, "original.crt"), originalCA, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := certs.NewTLSConfig(manager, certs.TLSOptions{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  dir,
+		NextProtos: []string{"h2", "http/1.1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(config.NextProtos, []string{"h2", "http/1.1"}) {
+		t.Fatalf("expected: %v, got: %v", []string{"h2", "http/1.1"}, config.NextProtos)
+	}
+
+	expected := x509.NewCertPool()
+	expected.AppendCertsFromPEM(originalCA)
+	clientConfig, err := config.GetConfigForClient(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !clientConfig.ClientCAs.Equal(expected) {
+		t.Fatal("expected the original client CA")
+	}
+
+	// Adding a CA certificate does not require a restart.
+	if err = os.WriteFile(filepath.Join(dir, "new.crt"), newCA, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	manager.ReloadCerts()
+	expected.AppendCertsFromPEM(newCA)
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		clientConfig, err = config.GetConfigForClient(&tls.ClientHelloInfo{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if clientConfig.ClientCAs.Equal(expected) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the reloaded client CAs")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if clientConfig.ClientAuth != tls.RequireAndVerifyClientCert || clientConfig.GetCertificate == nil {
+		t.Fatal("expected the reloaded config to keep the options")
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package certs
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/minio/pkg/v3/wildcard"
+)
+
+// PeerClockSkew is the tolerated clock skew when checking the validity
+// period of a peer certificate.
+const PeerClockSkew = 5 * time.Minute
+
+// VerifyPeerSPIFFE returns a tls.Config.VerifyPeerCertificate callback
+// accepting peers whose certificate carries a SPIFFE ID, i.e. a
+// 'spiffe://' URI SAN, within expectedTrustDomain.
+//
+// The callback only inspects the verified peer certificate, so it is
+// unaffected by local certificates being reloaded by a Manager.
+func VerifyPeerSPIFFE(expectedTrustDomain string) func(rawCerts [][]byte, chains [][]*x509.Certificate) error {
+	expectedTrustDomain = strings.ToLower(expectedTrustDomain)
+	return func(rawCerts [][]byte, chains [][]*x509.Certificate) error {
+		cert, err := peerCertificate(rawCerts, chains)
+		if err != nil {
+			return err
+		}
+
+		var spiffeID string
+		for _, uri := range cert.URIs {
+			if !strings.EqualFold(uri.Scheme, "spiffe") {
+				continue
+			}
+			if spiffeID != "" {
+				return errors.New("certs: peer certificate must not contain more than one SPIFFE ID")
+			}
+			spiffeID = uri.String()
+			if strings.ToLower(uri.Host) != expectedTrustDomain {
+				return fmt.Errorf("certs: peer SPIFFE ID '%s' is not in trust domain '%s'", spiffeID, expectedTrustDomain)
+			}
+			if uri.User != nil || uri.RawQuery != "" || uri.Fragment != "" || uri.Port() != "" {
+				return fmt.Errorf("certs: peer SPIFFE ID '%s' is malformed", spiffeID)
+			}
+		}
+		if spiffeID == "" {
+			return errors.New("certs: peer certificate does not contain a SPIFFE ID")
+		}
+		return nil
+	}
+}
+
+// VerifyPeerDNSPattern returns a tls.Config.VerifyPeerCertificate callback
+// accepting peers whose certificate has at least one DNS SAN matching
+// pattern, e.g. '*.minio.svc.cluster.local'. The pattern is matched case
+// insensitive using the wildcard package, where '*' matches any sequence
+// of characters including '.'.
+//
+// The callback only inspects the verified peer certificate, so it is
+// unaffected by local certificates being reloaded by a Manager.
+func VerifyPeerDNSPattern(pattern string) func(rawCerts [][]byte, chains [][]*x509.Certificate) error {
+	pattern = strings.ToLower(pattern)
+	return func(rawCerts [][]byte, chains [][]*x509.Certificate) error {
+		cert, err := peerCertificate(rawCerts, chains)
+		if err != nil {
+			return err
+		}
+
+		if len(cert.DNSNames) == 0 {
+			return errors.New("certs: peer certificate does not contain any DNS SANs")
+		}
+		for _, name := range cert.DNSNames {
+			if wildcard.Match(pattern, strings.ToLower(name)) {
+				return nil
+			}
+		}
+		return fmt.Errorf("certs: peer DNS SANs %v do not match '%s'", cert.DNSNames, pattern)
+	}
+}
+
+// peerCertificate returns the leaf certificate of the verified chain of
+// the peer and checks its validity period allowing for PeerClockSkew. The
+// unverified rawCerts are not trusted, hence peers without a verified
+// chain, e.g. if the tls.Config skips verification, are rejected.
+func peerCertificate(_ [][]byte, chains [][]*x509.Certificate) (*x509.Certificate, error) {
+	if len(chains) == 0 || len(chains[0]) == 0 {
+		return nil, errors.New("certs: no verified peer certificate")
+	}
+	cert := chains[0][0]
+
+	now := time.Now()
+	if now.Add(PeerClockSkew).Before(cert.NotBefore) {
+		return nil, fmt.Errorf("certs: peer certificate is not valid before %s", cert.NotBefore.UTC().Format(time.RFC3339))
+	}
+	if now.Add(-PeerClockSkew).After(cert.NotAfter) {
+		return nil, fmt.Errorf("certs: peer certificate expired at %s", cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+	return cert, nil
+}
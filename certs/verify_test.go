@@ -0,0 +1,166 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package certs_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/minio/pkg/v3/certs"
+)
+
+func generatePeerCert(t *testing.T, notBefore, notAfter time.Time, dnsNames []string, uris ...string) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "peer"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		DNSNames:     dnsNames,
+	}
+	for _, s := range uris {
+		u, err := url.Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		template.URIs = append(template.URIs, u)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+// verifiedChains - returns the certificates of rawCerts as verified chains,
+// as crypto/tls passes them once the peer certificate is verified, nil if
+// they cannot be parsed.
+func verifiedChains(rawCerts [][]byte) [][]*x509.Certificate {
+	var chains [][]*x509.Certificate
+	for _, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return nil
+		}
+		chains = append(chains, []*x509.Certificate{cert})
+	}
+	return chains
+}
+
+func TestVerifyPeerSPIFFE(t *testing.T) {
+	now := time.Now()
+	valid := func(uris ...string) []byte {
+		return generatePeerCert(t, now.Add(-time.Hour), now.Add(time.Hour), nil, uris...)
+	}
+
+	testCases := []struct {
+		rawCerts  [][]byte
+		expectErr bool
+	}{
+		{[][]byte{valid("spiffe://cluster.local/ns/minio/sa/minio")}, false},
+		{[][]byte{valid("spiffe://Cluster.Local/ns/minio/sa/minio")}, false},
+		{[][]byte{valid("https://example.com", "spiffe://cluster.local/minio")}, false},
+		{[][]byte{valid("spiffe://example.org/ns/minio/sa/minio")}, true},
+		{[][]byte{valid("spiffe://cluster.local/a", "spiffe://cluster.local/b")}, true},
+		{[][]byte{valid("https://cluster.local/minio")}, true},
+		{[][]byte{valid()}, true},
+		{nil, true},
+		{[][]byte{[]byte("not a certificate")}, true},
+		// Expired, but within the tolerated clock skew.
+		{[][]byte{generatePeerCert(t, now.Add(-time.Hour), now.Add(-time.Minute), nil, "spiffe://cluster.local/minio")}, false},
+		// Expired.
+		{[][]byte{generatePeerCert(t, now.Add(-time.Hour), now.Add(-certs.PeerClockSkew-time.Minute), nil, "spiffe://cluster.local/minio")}, true},
+		// Not yet valid.
+		{[][]byte{generatePeerCert(t, now.Add(certs.PeerClockSkew+time.Minute), now.Add(time.Hour), nil, "spiffe://cluster.local/minio")}, true},
+	}
+
+	verify := certs.VerifyPeerSPIFFE("cluster.local")
+	for i, testCase := range testCases {
+		err := verify(testCase.rawCerts, verifiedChains(testCase.rawCerts))
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v (%v)", i+1, testCase.expectErr, expectErr, err)
+		}
+	}
+}
+
+func TestVerifyPeerDNSPattern(t *testing.T) {
+	now := time.Now()
+	valid := func(dnsNames ...string) []byte {
+		return generatePeerCert(t, now.Add(-time.Hour), now.Add(time.Hour), dnsNames)
+	}
+
+	testCases := []struct {
+		rawCerts  [][]byte
+		expectErr bool
+	}{
+		{[][]byte{valid("minio-0.minio.svc.cluster.local")}, false},
+		{[][]byte{valid("localhost", "MINIO-1.minio.svc.cluster.local")}, false},
+		{[][]byte{valid("minio-0.minio.svc.example.org")}, true},
+		{[][]byte{valid()}, true},
+		{[][]byte{generatePeerCert(t, now.Add(-2*time.Hour), now.Add(-time.Hour), []string{"minio-0.minio.svc.cluster.local"})}, true},
+	}
+
+	verify := certs.VerifyPeerDNSPattern("*.minio.svc.cluster.local")
+	for i, testCase := range testCases {
+		err := verify(testCase.rawCerts, verifiedChains(testCase.rawCerts))
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v (%v)", i+1, testCase.expectErr, expectErr, err)
+		}
+	}
+
+	// Verified chains take precedence over raw certificates.
+	leaf, err := x509.ParseCertificate(valid("minio-0.minio.svc.cluster.local"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = verify([][]byte{valid("example.org")}, [][]*x509.Certificate{{leaf}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestVerifyPeerUnverified(t *testing.T) {
+	now := time.Now()
+	// Self-signed certificates with the expected identity, as sent by a
+	// peer whose certificate chain is not verified.
+	rawCerts := [][]byte{generatePeerCert(t, now.Add(-time.Hour), now.Add(time.Hour), []string{"minio-0.minio.svc.cluster.local"}, "spiffe://cluster.local/minio")}
+
+	if err := certs.VerifyPeerSPIFFE("cluster.local")(rawCerts, nil); err == nil {
+		t.Fatal("expected error for unverified peer certificate")
+	}
+	if err := certs.VerifyPeerDNSPattern("*.minio.svc.cluster.local")(rawCerts, nil); err == nil {
+		t.Fatal("expected error for unverified peer certificate")
+	}
+}
@@ -0,0 +1,362 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package console
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-isatty"
+)
+
+const (
+	// progressBarWidth - width of the bar of progress lines on terminals.
+	progressBarWidth = 30
+
+	// progressSampleInterval - interval between two samples of the rate.
+	progressSampleInterval = time.Second
+
+	// progressAlpha - weight of the latest sample in the rate, which is an
+	// exponentially weighted moving average of the samples.
+	progressAlpha = 0.3
+)
+
+// ProgressOptions - options of a ProgressManager.
+type ProgressOptions struct {
+	// JSON makes the manager write a ProgressEvent per line instead of
+	// rendering bars, e.g. for the --json flag of mc.
+	JSON bool
+
+	// Interval is the minimum interval between two renders of the bars,
+	// 100ms on terminals and 10s otherwise if zero.
+	Interval time.Duration
+}
+
+// ProgressEvent - progress of a bar, as written in JSON mode.
+type ProgressEvent struct {
+	Label   string  `json:"label"`
+	Current int64   `json:"current"`
+	Total   int64   `json:"total,omitempty"`
+	Percent float64 `json:"percent,omitempty"`
+	Rate    float64 `json:"bytesPerSecond"`
+	ETA     float64 `json:"etaSeconds,omitempty"`
+	Done    bool    `json:"done,omitempty"`
+}
+
+// ProgressManager renders concurrent progress bars. On terminals it owns
+// the last lines of the output, one per bar, which are redrawn in place.
+// Otherwise each bar is reported on a plain text line, or as a JSON
+// ProgressEvent, at every interval and once it is finished. It is safe for
+// concurrent use.
+type ProgressManager struct {
+	mu       sync.Mutex
+	w        io.Writer
+	json     bool
+	tty      bool
+	interval time.Duration
+	now      func() time.Time
+
+	bars       []*Progress
+	drawn      int // lines drawn on the terminal
+	lastRender time.Time
+}
+
+// NewProgressManager - returns a manager rendering progress bars to w,
+// which is treated as a terminal if it is one.
+func NewProgressManager(w io.Writer, opts ProgressOptions) *ProgressManager {
+	f, ok := w.(*os.File)
+	m := &ProgressManager{
+		w:        w,
+		json:     opts.JSON,
+		tty:      ok && !opts.JSON && isatty.IsTerminal(f.Fd()),
+		interval: opts.Interval,
+		now:      time.Now,
+	}
+	if m.interval <= 0 {
+		m.interval = 10 * time.Second
+		if m.tty {
+			m.interval = 100 * time.Millisecond
+		}
+	}
+	return m
+}
+
+var (
+	defaultProgressOnce    sync.Once
+	defaultProgressManager *ProgressManager
+)
+
+// NewProgress - returns a new progress bar of total bytes, 0 if unknown,
+// rendered to the standard output.
+func NewProgress(total int64, label string) *Progress {
+	defaultProgressOnce.Do(func() {
+		defaultProgressManager = NewProgressManager(os.Stdout, ProgressOptions{})
+	})
+	return defaultProgressManager.NewProgress(total, label)
+}
+
+// NewProgress - returns a new progress bar of total bytes, 0 if unknown,
+// rendered by the manager.
+func (m *ProgressManager) NewProgress(total int64, label string) *Progress {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	p := &Progress{m: m, label: label, total: total, start: now, sampleTime: now}
+	m.bars = append(m.bars, p)
+	if m.tty {
+		m.render(m.bars)
+	}
+	return p
+}
+
+// Println - prints a message above the progress bars, which would
+// otherwise overwrite it on terminals.
+func (m *ProgressManager) Println(a ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	privateMutex.Lock()
+	if m.tty && m.drawn > 0 {
+		fmt.Fprintf(m.w, "\033[%dA\033[J", m.drawn)
+		m.drawn = 0
+	}
+	fmt.Fprintln(m.w, a...)
+	privateMutex.Unlock()
+
+	if m.tty {
+		m.render(m.bars)
+	}
+}
+
+// update - renders the bars if the interval elapsed, or p right away if
+// it is finished. The manager must be locked.
+func (m *ProgressManager) update(p *Progress) {
+	now := m.now()
+	switch {
+	case p.done && m.tty:
+		m.render(m.bars)
+	case p.done:
+		m.render([]*Progress{p})
+	case now.Sub(m.lastRender) >= m.interval:
+		m.render(m.bars)
+		m.lastRender = now
+	}
+
+	if !p.done {
+		return
+	}
+	var active []*Progress
+	for _, bar := range m.bars {
+		if !bar.done {
+			active = append(active, bar)
+		}
+	}
+	switch {
+	case len(active) == 0:
+		// The next bars are drawn below the finished ones.
+		m.bars, m.drawn = nil, 0
+	case !m.tty:
+		m.bars = active
+	}
+	// On terminals, finished bars keep their line while others are running.
+}
+
+// render - writes bars. The manager must be locked.
+func (m *ProgressManager) render(bars []*Progress) {
+	privateMutex.Lock()
+	defer privateMutex.Unlock()
+
+	var b strings.Builder
+	switch {
+	case m.json:
+		for _, p := range bars {
+			data, err := json.Marshal(p.event())
+			if err != nil {
+				continue
+			}
+			b.Write(data)
+			b.WriteByte('\n')
+		}
+	case m.tty:
+		if m.drawn > 0 {
+			fmt.Fprintf(&b, "\033[%dA", m.drawn)
+		}
+		for _, p := range bars {
+			b.WriteString("\r\033[K")
+			b.WriteString(p.line(true))
+			b.WriteByte('\n')
+		}
+		m.drawn = len(bars)
+	default:
+		for _, p := range bars {
+			b.WriteString(p.line(false))
+			b.WriteByte('\n')
+		}
+	}
+	io.WriteString(m.w, b.String())
+}
+
+// Progress - a progress bar, see ProgressManager.
+type Progress struct {
+	m     *ProgressManager
+	label string
+
+	total   int64
+	current int64
+	start   time.Time
+	end     time.Time
+	done    bool
+
+	// Rate samples.
+	sampleTime    time.Time
+	sampleCurrent int64
+	sampled       bool
+	rate          float64
+}
+
+// Add - adds n bytes to the progress.
+func (p *Progress) Add(n int64) {
+	p.m.mu.Lock()
+	defer p.m.mu.Unlock()
+
+	if p.done {
+		return
+	}
+	p.current += n
+	p.sample(p.m.now())
+	p.m.update(p)
+}
+
+// SetTotal - sets the total number of bytes, 0 if unknown.
+func (p *Progress) SetTotal(total int64) {
+	p.m.mu.Lock()
+	defer p.m.mu.Unlock()
+
+	p.total = total
+}
+
+// Finish - marks the progress as done and renders it a last time.
+func (p *Progress) Finish() {
+	p.m.mu.Lock()
+	defer p.m.mu.Unlock()
+
+	if p.done {
+		return
+	}
+	p.done, p.end = true, p.m.now()
+	p.m.update(p)
+}
+
+// sample - updates the rate if the sample interval elapsed.
+func (p *Progress) sample(now time.Time) {
+	elapsed := now.Sub(p.sampleTime)
+	if elapsed < progressSampleInterval {
+		return
+	}
+	rate := float64(p.current-p.sampleCurrent) / elapsed.Seconds()
+	if p.sampled {
+		rate = progressAlpha*rate + (1-progressAlpha)*p.rate
+	}
+	p.rate, p.sampled = rate, true
+	p.sampleTime, p.sampleCurrent = now, p.current
+}
+
+// currentRate - returns the rate in bytes per second, the average rate
+// once the progress is done.
+func (p *Progress) currentRate() float64 {
+	if p.done {
+		if elapsed := p.end.Sub(p.start); elapsed > 0 {
+			return float64(p.current) / elapsed.Seconds()
+		}
+	}
+	return p.rate
+}
+
+// eta - returns the estimated remaining time, 0 if unknown.
+func (p *Progress) eta(rate float64) time.Duration {
+	if p.done || p.total <= 0 || rate <= 0 || p.current >= p.total {
+		return 0
+	}
+	return time.Duration(float64(p.total-p.current) / rate * float64(time.Second)).Round(time.Second)
+}
+
+func (p *Progress) percent() float64 {
+	if p.total <= 0 {
+		return 0
+	}
+	return min(float64(p.current)*100/float64(p.total), 100)
+}
+
+func (p *Progress) event() ProgressEvent {
+	rate := p.currentRate()
+	return ProgressEvent{
+		Label:   p.label,
+		Current: p.current,
+		Total:   p.total,
+		Percent: p.percent(),
+		Rate:    rate,
+		ETA:     p.eta(rate).Seconds(),
+		Done:    p.done,
+	}
+}
+
+// line - returns the progress line, with a bar for terminals.
+func (p *Progress) line(bar bool) string {
+	rate := p.currentRate()
+
+	var b strings.Builder
+	b.WriteString(p.label)
+	if p.total > 0 {
+		percent := p.percent()
+		if bar {
+			filled := int(percent * progressBarWidth / 100)
+			fmt.Fprintf(&b, " [%s%s]", strings.Repeat("=", filled), strings.Repeat(" ", progressBarWidth-filled))
+		}
+		fmt.Fprintf(&b, " %3.0f%% %s / %s", percent, formatBytes(float64(p.current)), formatBytes(float64(p.total)))
+	} else {
+		fmt.Fprintf(&b, " %s", formatBytes(float64(p.current)))
+	}
+	fmt.Fprintf(&b, " %s/s", formatBytes(rate))
+	switch {
+	case p.done:
+		fmt.Fprintf(&b, " done in %v", p.end.Sub(p.start).Round(time.Second))
+	case p.eta(rate) > 0:
+		fmt.Fprintf(&b, " ETA %v", p.eta(rate))
+	}
+	return b.String()
+}
+
+// formatBytes - returns n bytes in IEC units, e.g. "1.5 MiB".
+func formatBytes(n float64) string {
+	const units = "KMGTPE"
+	if n < 1024 {
+		return fmt.Sprintf("%.0f B", n)
+	}
+	i := -1
+	for n >= 1024 && i < len(units)-1 {
+		n /= 1024
+		i++
+	}
+	return fmt.Sprintf("%.1f %ciB", n, units[i])
+}
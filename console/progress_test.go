@@ -0,0 +1,190 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package console
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const mib = 1 << 20
+
+// newTestProgressManager - returns a manager writing to a buffer with a clock advanced
+// by the returned function.
+func newTestProgressManager(opts ProgressOptions, tty bool) (*ProgressManager, *bytes.Buffer, func(time.Duration)) {
+	var buf bytes.Buffer
+	m := NewProgressManager(&buf, opts)
+	m.tty = tty
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+	return m, &buf, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestProgressPlain(t *testing.T) {
+	m, buf, advance := newTestProgressManager(ProgressOptions{Interval: 10 * time.Second}, false)
+
+	p := m.NewProgress(100*mib, "copy")
+	p.Add(10 * mib)
+	advance(time.Second)
+	p.Add(10 * mib)
+	advance(9 * time.Second)
+	p.Add(10 * mib)
+	p.Finish()
+	p.Add(10 * mib)
+
+	expected := []string{
+		"copy  10% 10.0 MiB / 100.0 MiB 0 B/s",
+		"copy  30% 30.0 MiB / 100.0 MiB 14.3 MiB/s ETA 5s",
+		"copy  30% 30.0 MiB / 100.0 MiB 3.0 MiB/s done in 10s",
+	}
+	if result := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"); !reflect.DeepEqual(result, expected) {
+		t.Fatalf("expected: %q, got: %q", expected, result)
+	}
+}
+
+func TestProgressTerminal(t *testing.T) {
+	m, buf, advance := newTestProgressManager(ProgressOptions{}, true)
+
+	a := m.NewProgress(4*mib, "a")
+	b := m.NewProgress(0, "b")
+	buf.Reset()
+
+	advance(time.Second)
+	a.Add(mib)
+	expected := "\033[2A" +
+		"\r\033[Ka [=======                       ]  25% 1.0 MiB / 4.0 MiB 1.0 MiB/s ETA 3s\n" +
+		"\r\033[Kb 0 B 0 B/s\n"
+	if buf.String() != expected {
+		t.Fatalf("expected: %q, got: %q", expected, buf.String())
+	}
+
+	// Renders are throttled.
+	buf.Reset()
+	b.Add(512)
+	if buf.Len() != 0 {
+		t.Fatalf("unexpected render: %q", buf.String())
+	}
+
+	// Messages are printed above the bars.
+	m.Println("warning")
+	expected = "\033[2A\033[Jwarning\n" +
+		"\r\033[Ka [=======                       ]  25% 1.0 MiB / 4.0 MiB 1.0 MiB/s ETA 3s\n" +
+		"\r\033[Kb 512 B 512 B/s\n"
+	if buf.String() != expected {
+		t.Fatalf("expected: %q, got: %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	a.Add(3 * mib)
+	a.Finish()
+	b.Finish()
+	expected = "\033[2A" +
+		"\r\033[Ka [==============================] 100% 4.0 MiB / 4.0 MiB 4.0 MiB/s done in 1s\n" +
+		"\r\033[Kb 512 B 512 B/s done in 1s\n"
+	if !strings.HasSuffix(buf.String(), expected) {
+		t.Fatalf("expected: %q, got: %q", expected, buf.String())
+	}
+
+	// New bars are drawn below the finished ones.
+	buf.Reset()
+	m.NewProgress(0, "c")
+	if expected = "\r\033[Kc 0 B 0 B/s\n"; buf.String() != expected {
+		t.Fatalf("expected: %q, got: %q", expected, buf.String())
+	}
+}
+
+func TestProgressJSON(t *testing.T) {
+	m, buf, advance := newTestProgressManager(ProgressOptions{JSON: true, Interval: time.Second}, false)
+
+	p := m.NewProgress(0, "mirror")
+	p.SetTotal(8 * mib)
+	advance(2 * time.Second)
+	p.Add(2 * mib)
+	advance(2 * time.Second)
+	p.Add(2 * mib)
+	p.Finish()
+
+	var events []ProgressEvent
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		var event ProgressEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("unexpected error: %v: %q", err, line)
+		}
+		events = append(events, event)
+	}
+	expected := []ProgressEvent{
+		{Label: "mirror", Current: 2 * mib, Total: 8 * mib, Percent: 25, Rate: mib, ETA: 6},
+		{Label: "mirror", Current: 4 * mib, Total: 8 * mib, Percent: 50, Rate: mib, ETA: 4},
+		{Label: "mirror", Current: 4 * mib, Total: 8 * mib, Percent: 50, Rate: mib, Done: true},
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Fatalf("expected: %+v, got: %+v", expected, events)
+	}
+}
+
+func TestProgressConcurrent(t *testing.T) {
+	m, _, _ := newTestProgressManager(ProgressOptions{}, true)
+
+	var wg sync.WaitGroup
+	bars := make([]*Progress, 4)
+	for i := range bars {
+		bars[i] = m.NewProgress(1000, "bar")
+		wg.Add(1)
+		go func(p *Progress) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				p.Add(10)
+			}
+			p.Finish()
+		}(bars[i])
+	}
+	wg.Wait()
+
+	for i, p := range bars {
+		if p.current != 1000 || !p.done {
+			t.Fatalf("bar %v: expected: 1000 bytes done, got: %v, %v", i+1, p.current, p.done)
+		}
+	}
+	if len(m.bars) != 0 || m.drawn != 0 {
+		t.Fatalf("expected no bars left, got: %v, %v", len(m.bars), m.drawn)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	testCases := []struct {
+		n        float64
+		expected string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{1536 * mib, "1.5 GiB"},
+		{1 << 62, "4.0 EiB"},
+	}
+
+	for i, testCase := range testCases {
+		if result := formatBytes(testCase.n); result != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package table renders aligned text tables, measuring cells by their
+// display width so that columns line up with double-width (e.g. CJK)
+// runes, combining characters and ANSI colored cells, and exports them as
+// CSV or TSV.
+package table
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// ellipsis marks truncated cells.
+const ellipsis = "…"
+
+// ansiReset resets colors after a truncated colored cell.
+const ansiReset = "\x1b[0m"
+
+// ansiEscape matches ANSI escape sequences, i.e. CSI sequences such as
+// colors and OSC sequences such as hyperlinks.
+var ansiEscape = regexp.MustCompile(`\x1b(\[[0-9;?]*[ -/]*[@-~]|\][^\x07\x1b]*(\x07|\x1b\\))`)
+
+// widthCondition measures runes of ambiguous East Asian width as narrow,
+// independent of the locale, so that output does not depend on the
+// environment.
+var widthCondition = &runewidth.Condition{StrictEmojiNeutral: true}
+
+// numericCell matches numbers, optionally followed by a size unit or a
+// percent sign, e.g. "1,024", "-3.5", "12 KiB" or "99%".
+var numericCell = regexp.MustCompile(`^[-+]?[0-9][0-9,]*(\.[0-9]+)?( ?([KMGTPE]i?B|B|%))?$`)
+
+// Options for Table.Render.
+type Options struct {
+	// MaxWidth is the maximum display width of a rendered line, the widest
+	// columns are truncated with an ellipsis to fit. No limit if zero.
+	MaxWidth int
+
+	// Separator between columns, two spaces if empty.
+	Separator string
+
+	// NoHeader omits the header line.
+	NoHeader bool
+}
+
+// Table - rows of cells with a header.
+type Table struct {
+	headers []string
+	rows    [][]string
+}
+
+// NewTable - returns an empty table with the given column headers.
+func NewTable(headers ...string) *Table {
+	return &Table{headers: headers}
+}
+
+// AddRow - appends a row. Rows may have fewer cells than there are
+// headers, additional cells add columns without header.
+func (t *Table) AddRow(cells ...string) {
+	t.rows = append(t.rows, cells)
+}
+
+// numCols - returns the number of columns of the table.
+func (t *Table) numCols() int {
+	n := len(t.headers)
+	for _, row := range t.rows {
+		n = max(n, len(row))
+	}
+	return n
+}
+
+// cell - returns the cell of row at col, empty if the row is shorter.
+func cell(row []string, col int) string {
+	if col < len(row) {
+		return row[col]
+	}
+	return ""
+}
+
+// StripANSI - returns s without ANSI escape sequences.
+func StripANSI(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+	return ansiEscape.ReplaceAllString(s, "")
+}
+
+// DisplayWidth - returns the number of terminal cells s occupies, ANSI
+// escape sequences and combining characters take no space and wide runes
+// take two cells.
+func DisplayWidth(s string) int {
+	return widthCondition.StringWidth(StripANSI(s))
+}
+
+// truncate - returns s truncated to width display cells, ending with an
+// ellipsis if it was truncated. Escape sequences are kept and colors are
+// reset after a truncated colored cell.
+func truncate(s string, width int) string {
+	if DisplayWidth(s) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	var colored bool
+	remain := width - widthCondition.StringWidth(ellipsis)
+	state := -1
+	for s != "" {
+		if loc := ansiEscape.FindStringIndex(s); loc != nil && loc[0] == 0 {
+			b.WriteString(s[:loc[1]])
+			s = s[loc[1]:]
+			colored = true
+			continue
+		}
+
+		var cluster string
+		cluster, s, _, state = uniseg.FirstGraphemeClusterInString(s, state)
+		w := widthCondition.StringWidth(cluster)
+		if w > remain {
+			break
+		}
+		b.WriteString(cluster)
+		remain -= w
+	}
+	b.WriteString(ellipsis)
+	if colored {
+		b.WriteString(ansiReset)
+	}
+	return b.String()
+}
+
+// isNumericColumn - returns whether all non-empty cells of the column are
+// numbers, the header is not considered.
+func (t *Table) isNumericColumn(col int) bool {
+	var found bool
+	for _, row := range t.rows {
+		c := strings.TrimSpace(StripANSI(cell(row, col)))
+		if c == "" {
+			continue
+		}
+		if !numericCell.MatchString(c) {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// columnWidths - returns the display width of each column, shrinking the
+// widest columns until a line fits maxWidth.
+func (t *Table) columnWidths(opts Options, sep string) []int {
+	numCols := t.numCols()
+	widths := make([]int, numCols)
+	for col := range widths {
+		if !opts.NoHeader {
+			widths[col] = DisplayWidth(cell(t.headers, col))
+		}
+		for _, row := range t.rows {
+			widths[col] = max(widths[col], DisplayWidth(cell(row, col)))
+		}
+	}
+	if opts.MaxWidth <= 0 || numCols == 0 {
+		return widths
+	}
+
+	total := DisplayWidth(sep) * (numCols - 1)
+	for _, w := range widths {
+		total += w
+	}
+	for total > opts.MaxWidth {
+		widest := 0
+		for col, w := range widths {
+			if w > widths[widest] {
+				widest = col
+			}
+		}
+		if widths[widest] <= 1 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+	return widths
+}
+
+// Render - writes the table with aligned columns to w. Numeric columns
+// are right aligned, other columns left aligned.
+func (t *Table) Render(w io.Writer, opts Options) error {
+	sep := opts.Separator
+	if sep == "" {
+		sep = "  "
+	}
+
+	numCols := t.numCols()
+	widths := t.columnWidths(opts, sep)
+	alignRight := make([]bool, numCols)
+	for col := range alignRight {
+		alignRight[col] = t.isNumericColumn(col)
+	}
+
+	bw := bufio.NewWriter(w)
+	writeLine := func(row []string) {
+		var line strings.Builder
+		for col := 0; col < numCols; col++ {
+			if col > 0 {
+				line.WriteString(sep)
+			}
+			c := truncate(cell(row, col), widths[col])
+			padding := strings.Repeat(" ", widths[col]-DisplayWidth(c))
+			if alignRight[col] {
+				line.WriteString(padding)
+				line.WriteString(c)
+			} else {
+				line.WriteString(c)
+				line.WriteString(padding)
+			}
+		}
+		bw.WriteString(strings.TrimRight(line.String(), " "))
+		bw.WriteByte('\n')
+	}
+
+	if !opts.NoHeader {
+		writeLine(t.headers)
+	}
+	for _, row := range t.rows {
+		writeLine(row)
+	}
+	return bw.Flush()
+}
+
+// RenderCSV - writes the table as comma separated values to w, with ANSI
+// escape sequences removed.
+func (t *Table) RenderCSV(w io.Writer) error {
+	return t.renderDelimited(w, ',')
+}
+
+// RenderTSV - writes the table as tab separated values to w, with ANSI
+// escape sequences removed.
+func (t *Table) RenderTSV(w io.Writer) error {
+	return t.renderDelimited(w, '\t')
+}
+
+func (t *Table) renderDelimited(w io.Writer, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+
+	numCols := t.numCols()
+	record := make([]string, numCols)
+	write := func(row []string) error {
+		for col := range record {
+			record[col] = StripANSI(cell(row, col))
+		}
+		return cw.Write(record)
+	}
+
+	if err := write(t.headers); err != nil {
+		return err
+	}
+	for _, row := range t.rows {
+		if err := write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package table
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// testTable - a listing with colored, CJK and combining character cells.
+func testTable() *Table {
+	t := NewTable("Name", "Size", "Modified")
+	t.AddRow("\x1b[32mreport.pdf\x1b[0m", "1.5 MiB", "2024-01-02")
+	t.AddRow("日本語のファイル.txt", "12 KiB", "2024-03-04")
+	t.AddRow("café/", "0", "2024-05-06")
+	t.AddRow("\x1b[1;34mdocuments/\x1b[0m", "", "2024-07-08")
+	return t
+}
+
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	golden := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, expected) {
+		t.Fatalf("%s: expected:\n%s\ngot:\n%s", name, expected, got)
+	}
+}
+
+func TestRender(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts Options
+	}{
+		{"render", Options{}},
+		{"render-maxwidth", Options{MaxWidth: 30}},
+		{"render-noheader", Options{NoHeader: true, Separator: " | "}},
+	}
+
+	for _, testCase := range testCases {
+		var buf bytes.Buffer
+		if err := testTable().Render(&buf, testCase.opts); err != nil {
+			t.Fatalf("%s: unexpected error: %v", testCase.name, err)
+		}
+		checkGolden(t, testCase.name, buf.Bytes())
+
+		if testCase.opts.MaxWidth == 0 {
+			continue
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+			if w := DisplayWidth(line); w > testCase.opts.MaxWidth {
+				t.Fatalf("%s: line %q: expected width <= %v, got: %v", testCase.name, line, testCase.opts.MaxWidth, w)
+			}
+		}
+	}
+}
+
+func TestRenderDelimited(t *testing.T) {
+	tbl := testTable()
+	tbl.AddRow("a,b \"quoted\"", "1")
+
+	var csvBuf, tsvBuf bytes.Buffer
+	if err := tbl.RenderCSV(&csvBuf); err != nil {
+		t.Fatal(err)
+	}
+	if err := tbl.RenderTSV(&tsvBuf); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "render-csv", csvBuf.Bytes())
+	checkGolden(t, "render-tsv", tsvBuf.Bytes())
+}
+
+func TestDisplayWidth(t *testing.T) {
+	testCases := []struct {
+		s        string
+		expected int
+	}{
+		{"", 0},
+		{"abc", 3},
+		{"\x1b[31mabc\x1b[0m", 3},
+		{"\x1b]8;;https://min.io\x1b\\link\x1b]8;;\x1b\\", 4},
+		{"café", 4},
+		{"日本", 4},
+		{"👍🏽", 2},
+	}
+
+	for i, testCase := range testCases {
+		if got := DisplayWidth(testCase.s); got != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	testCases := []struct {
+		s        string
+		width    int
+		expected string
+	}{
+		{"abcdef", 6, "abcdef"},
+		{"abcdef", 4, "abc…"},
+		{"abcdef", 1, "…"},
+		{"abcdef", 0, ""},
+		{"日本語", 4, "日…"},
+		{"日本語", 5, "日本…"},
+		{"cafés", 5, "cafés"},
+		{"cafés", 4, "caf…"},
+		{"\x1b[31mabcdef\x1b[0m", 4, "\x1b[31mabc…\x1b[0m"},
+		{"👍🏽👍🏽", 3, "👍🏽…"},
+	}
+
+	for i, testCase := range testCases {
+		got := truncate(testCase.s, testCase.width)
+		if got != testCase.expected {
+			t.Fatalf("case %v: expected: %q, got: %q", i+1, testCase.expected, got)
+		}
+		if w := DisplayWidth(got); w > testCase.width {
+			t.Fatalf("case %v: expected width <= %v, got: %v", i+1, testCase.width, w)
+		}
+	}
+}
+
+func TestNumericColumn(t *testing.T) {
+	tbl := NewTable("A", "B", "C", "D")
+	tbl.AddRow("1,024", "10", "x", "")
+	tbl.AddRow("-3.5", "99%", "1", "")
+	tbl.AddRow("12 KiB", "", "2", "")
+
+	for col, expected := range []bool{true, true, false, false} {
+		if got := tbl.isNumericColumn(col); got != expected {
+			t.Fatalf("column %v: expected: %v, got: %v", col, expected, got)
+		}
+	}
+}
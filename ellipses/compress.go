@@ -0,0 +1,171 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ellipses
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// hostRange - consecutive hosts differing only in a decimal number
+// between prefix and suffix.
+type hostRange struct {
+	prefix, suffix string
+	// width of zero padded numbers, 0 if numbers are not padded.
+	width      int
+	start, end uint64
+}
+
+// format - returns n as written in the hosts of the range.
+func (r hostRange) format(n uint64) string {
+	if r.width > 0 {
+		return fmt.Sprintf("%0*d", r.width, n)
+	}
+	return strconv.FormatUint(n, 10)
+}
+
+// extend - extends the range by host if it is the next host of the range.
+func (r *hostRange) extend(host string) bool {
+	if len(host) <= len(r.prefix)+len(r.suffix) ||
+		!strings.HasPrefix(host, r.prefix) || !strings.HasSuffix(host, r.suffix) {
+		return false
+	}
+	if r.end >= ^uint64(0)-1 {
+		// Ranges ending at the maximum value cannot be expanded.
+		return false
+	}
+	next := r.format(r.end + 1)
+	if r.width > 0 && len(next) > r.width {
+		// The number no longer fits the padding.
+		return false
+	}
+	if host[len(r.prefix):len(host)-len(r.suffix)] != next {
+		return false
+	}
+	r.end++
+	return true
+}
+
+// String - returns the ellipses pattern of the range.
+func (r hostRange) String() string {
+	return r.prefix + openBraces + r.format(r.start) + ellipses + r.format(r.end) + closeBraces + r.suffix
+}
+
+// hostSegments - splits host into alternating runs of decimal digits and
+// other characters.
+func hostSegments(host string) []string {
+	var segments []string
+	start := 0
+	for i := 1; i <= len(host); i++ {
+		if i == len(host) || isDigit(host[i]) != isDigit(host[i-1]) {
+			segments = append(segments, host[start:i])
+			start = i
+		}
+	}
+	return segments
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// isPadded - returns whether the decimal number s is zero padded.
+func isPadded(s string) bool {
+	return len(s) > 1 && s[0] == '0'
+}
+
+// newHostRange - returns the range of host and next if they differ only
+// in a single decimal number, and next's number follows host's with the
+// same zero padding.
+func newHostRange(host, next string) (hostRange, bool) {
+	if strings.ContainsAny(host, openBraces+closeBraces) {
+		return hostRange{}, false
+	}
+
+	segments, nextSegments := hostSegments(host), hostSegments(next)
+	if len(segments) != len(nextSegments) {
+		return hostRange{}, false
+	}
+	varying := -1
+	for i := range segments {
+		if segments[i] == nextSegments[i] {
+			continue
+		}
+		if varying >= 0 || !isDigit(segments[i][0]) || !isDigit(nextSegments[i][0]) {
+			return hostRange{}, false
+		}
+		varying = i
+	}
+	if varying < 0 {
+		return hostRange{}, false
+	}
+
+	s := segments[varying]
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return hostRange{}, false
+	}
+	r := hostRange{
+		prefix: strings.Join(segments[:varying], ""),
+		suffix: strings.Join(segments[varying+1:], ""),
+		start:  n,
+		end:    n,
+	}
+	if isPadded(s) || isPadded(nextSegments[varying]) {
+		r.width = len(s)
+	}
+	if !r.extend(next) {
+		return hostRange{}, false
+	}
+	return r, true
+}
+
+// CompressHostPatterns - returns hosts with runs of consecutive hosts,
+// which differ only in an increasing decimal number, replaced by an
+// ellipses pattern, e.g. "node1.example.com" to "node32.example.com"
+// become "node{1...32}.example.com". Zero padding is kept, e.g.
+// "node{01...32}", hosts not part of a run of at least two hosts are
+// returned as is.
+//
+// Runs are detected in the given order, so that expanding the returned
+// patterns with FindEllipsesPatterns yields hosts again. If hosts differ
+// in more than one number only one of them is compressed, the one
+// differing between the first two hosts of a run, e.g. "rack1-node1" to
+// "rack2-node4" in this order become "rack1-node{1...4}" and
+// "rack2-node{1...4}".
+func CompressHostPatterns(hosts []string) []string {
+	var patterns []string
+	for i := 0; i < len(hosts); i++ {
+		if i+1 == len(hosts) {
+			patterns = append(patterns, hosts[i])
+			break
+		}
+		r, ok := newHostRange(hosts[i], hosts[i+1])
+		if !ok {
+			patterns = append(patterns, hosts[i])
+			continue
+		}
+		i++
+		for i+1 < len(hosts) && r.extend(hosts[i+1]) {
+			i++
+		}
+		patterns = append(patterns, r.String())
+	}
+	return patterns
+}
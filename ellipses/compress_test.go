@@ -0,0 +1,166 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ellipses
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// expandHostPatterns - expands the ellipses patterns in patterns, other
+// entries are kept as is.
+func expandHostPatterns(t *testing.T, patterns []string) []string {
+	t.Helper()
+	var hosts []string
+	for _, pattern := range patterns {
+		if !HasEllipses(pattern) {
+			hosts = append(hosts, pattern)
+			continue
+		}
+		argPatterns, err := FindEllipsesPatterns(pattern)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", pattern, err)
+		}
+		for _, labels := range argPatterns.Expand() {
+			hosts = append(hosts, strings.Join(labels, ""))
+		}
+	}
+	return hosts
+}
+
+func TestCompressHostPatterns(t *testing.T) {
+	testCases := []struct {
+		hosts    []string
+		expected []string
+	}{
+		{nil, nil},
+		{[]string{"node1"}, []string{"node1"}},
+		{[]string{"node1", "node2", "node3"}, []string{"node{1...3}"}},
+		{[]string{"node1.example.com", "node2.example.com"}, []string{"node{1...2}.example.com"}},
+		// Maximal ranges.
+		{[]string{"node1", "node2", "node4", "node5", "node6"}, []string{"node{1...2}", "node{4...6}"}},
+		{[]string{"node8", "node9", "node10", "node11"}, []string{"node{8...11}"}},
+		// Zero padding.
+		{[]string{"node08", "node09", "node10"}, []string{"node{08...10}"}},
+		{[]string{"node098", "node099", "node100"}, []string{"node{098...100}"}},
+		{[]string{"node98", "node99", "node100"}, []string{"node{98...100}"}},
+		{[]string{"node09", "node10", "node11"}, []string{"node{09...11}"}},
+		{[]string{"node98", "node99", "node0100"}, []string{"node{98...99}", "node0100"}},
+		{[]string{"node99", "node100", "node0101"}, []string{"node{99...100}", "node0101"}},
+		{[]string{"node9", "node010"}, []string{"node9", "node010"}},
+		{[]string{"node09", "node010"}, []string{"node09", "node010"}},
+		// Non-conforming entries.
+		{[]string{"node1", "minio", "node2", "node3"}, []string{"node1", "minio", "node{2...3}"}},
+		{[]string{"node2", "node1"}, []string{"node2", "node1"}},
+		{[]string{"node1", "node1"}, []string{"node1", "node1"}},
+		{[]string{"nodea", "nodeb"}, []string{"nodea", "nodeb"}},
+		{[]string{"node1", "node2x"}, []string{"node1", "node2x"}},
+		// Multiple numbers.
+		{
+			[]string{"rack1-node1", "rack1-node2", "rack2-node1", "rack2-node2"},
+			[]string{"rack1-node{1...2}", "rack2-node{1...2}"},
+		},
+		{
+			[]string{"rack1-node1", "rack2-node1", "rack1-node2", "rack2-node2"},
+			[]string{"rack{1...2}-node1", "rack{1...2}-node2"},
+		},
+		{
+			[]string{"http://node1:9000/disk1", "http://node2:9000/disk1", "http://node3:9000/disk1"},
+			[]string{"http://node{1...3}:9000/disk1"},
+		},
+		{[]string{"18446744073709551613", "18446744073709551614"}, []string{"{18446744073709551613...18446744073709551614}"}},
+		{[]string{"18446744073709551614", "18446744073709551615"}, []string{"18446744073709551614", "18446744073709551615"}},
+		{[]string{"18446744073709551615", "18446744073709551616"}, []string{"18446744073709551615", "18446744073709551616"}},
+	}
+
+	for i, testCase := range testCases {
+		got := CompressHostPatterns(testCase.hosts)
+		if !reflect.DeepEqual(got, testCase.expected) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, got)
+		}
+		if expanded := expandHostPatterns(t, got); !reflect.DeepEqual(expanded, testCase.hosts) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.hosts, expanded)
+		}
+	}
+}
+
+func TestCompressHostPatternsRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	number := func() string {
+		start := r.Intn(120)
+		end := start + r.Intn(20)
+		if r.Intn(2) == 0 {
+			return fmt.Sprintf("{%d...%d}", start, end)
+		}
+		width := len(fmt.Sprint(end)) + r.Intn(2)
+		return fmt.Sprintf("{%0*d...%0*d}", width, start, width, end)
+	}
+	literals := []string{"", "node", "-", ".example.com", "rack", ":9000/disk", "http://", "x1y"}
+	literal := func() string {
+		return literals[r.Intn(len(literals))]
+	}
+
+	for i := 0; i < 1000; i++ {
+		var patterns []string
+		for j := 1 + r.Intn(3); j > 0; j-- {
+			pattern := literal() + number() + literal()
+			if r.Intn(2) == 0 {
+				pattern += "-" + number() + literal()
+			}
+			patterns = append(patterns, pattern)
+		}
+		if r.Intn(4) == 0 {
+			patterns = append(patterns, "minio")
+		}
+
+		hosts := expandHostPatterns(t, patterns)
+		compressed := CompressHostPatterns(hosts)
+		if got := expandHostPatterns(t, compressed); !reflect.DeepEqual(got, hosts) {
+			t.Fatalf("case %v: %v: compressed to %v, expected: %v, got: %v", i+1, patterns, compressed, hosts, got)
+		}
+		if len(compressed) > len(hosts) {
+			t.Fatalf("case %v: %v: expected at most %v patterns, got: %v", i+1, patterns, len(hosts), compressed)
+		}
+	}
+}
+
+func TestCompressHostPatternsShuffled(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		var hosts []string
+		for j := r.Intn(20); j > 0; j-- {
+			n := r.Intn(12)
+			switch r.Intn(3) {
+			case 0:
+				hosts = append(hosts, fmt.Sprintf("node%d", n))
+			case 1:
+				hosts = append(hosts, fmt.Sprintf("node%02d.dc%d", n, r.Intn(2)))
+			default:
+				hosts = append(hosts, fmt.Sprintf("%d", n))
+			}
+		}
+
+		compressed := CompressHostPatterns(hosts)
+		if got := expandHostPatterns(t, compressed); !reflect.DeepEqual(got, hosts) {
+			t.Fatalf("case %v: %v: compressed to %v, got: %v", i+1, hosts, compressed, got)
+		}
+	}
+}
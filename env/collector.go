@@ -0,0 +1,144 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package env
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrMissing is reported by Collector for required environment variables
+// which are unset or empty.
+var ErrMissing = errors.New("required variable is not set")
+
+// VarError - error of a missing or invalid environment variable.
+type VarError struct {
+	Key   string
+	Value string // Invalid value, empty if the variable is missing
+	Err   error
+}
+
+func (e *VarError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("%s: %v", e.Key, e.Err)
+	}
+	return fmt.Sprintf("%s: invalid value '%s': %v", e.Key, e.Value, e.Err)
+}
+
+func (e *VarError) Unwrap() error { return e.Err }
+
+// Collector reads environment variables, typically while a program
+// initializes, and collects the errors of all missing and invalid
+// variables so that they are reported at once by Err rather than one at a
+// time. As for Get, variables set to an empty value are unset. The zero
+// Collector is ready to use, it must not be used concurrently.
+type Collector struct {
+	errs []error
+}
+
+// lookup - returns the value of key as Get does and false if it could not
+// be resolved, resolution errors of web environment values are collected.
+func (c *Collector) lookup(key string) (string, bool) {
+	privateMutex.RLock()
+	off := envOff
+	privateMutex.RUnlock()
+	if off {
+		return "", true
+	}
+
+	v, _, _, err := LookupEnv(key)
+	if v = strings.TrimSpace(v); v == "" && err != nil {
+		c.errs = append(c.errs, &VarError{Key: key, Err: err})
+		return "", false
+	}
+	return v, true
+}
+
+// Require returns the value of key, a missing variable is collected as
+// ErrMissing. A variable which could not be resolved is only reported
+// once.
+func (c *Collector) Require(key string) string {
+	v, ok := c.lookup(key)
+	if v == "" && ok {
+		c.errs = append(c.errs, &VarError{Key: key, Err: ErrMissing})
+	}
+	return v
+}
+
+// Optional returns the value of key, defaultValue if it is not set.
+func (c *Collector) Optional(key, defaultValue string) string {
+	if v, _ := c.lookup(key); v != "" {
+		return v
+	}
+	return strings.TrimSpace(defaultValue)
+}
+
+// Int returns the integer value of key as GetInt does, defaultValue if it
+// is not set or invalid. Invalid values are collected.
+func (c *Collector) Int(key string, defaultValue int) int {
+	v, _ := c.lookup(key)
+	if v == "" {
+		return defaultValue
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		c.errs = append(c.errs, &VarError{Key: key, Value: v, Err: err})
+		return defaultValue
+	}
+	return i
+}
+
+// Duration returns the duration value of key as GetDuration does,
+// defaultValue if it is not set or invalid. Invalid values are collected.
+func (c *Collector) Duration(key string, defaultValue time.Duration) time.Duration {
+	v, _ := c.lookup(key)
+	if v == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		c.errs = append(c.errs, &VarError{Key: key, Value: v, Err: err})
+		return defaultValue
+	}
+	return d
+}
+
+// Bool returns the boolean value of key as GetBool does, defaultValue if
+// it is not set or invalid. Invalid values are collected.
+func (c *Collector) Bool(key string, defaultValue bool) bool {
+	v, _ := c.lookup(key)
+	if v == "" {
+		return defaultValue
+	}
+	b, err := parseBool(v)
+	if err != nil {
+		c.errs = append(c.errs, &VarError{Key: key, Value: v, Err: err})
+		return defaultValue
+	}
+	return b
+}
+
+// Err returns an error listing all missing and invalid variables
+// encountered so far, nil if there is none. The error wraps a *VarError
+// for each of them.
+func (c *Collector) Err() error {
+	return errors.Join(c.errs...)
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package env
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCollector(t *testing.T) {
+	t.Setenv("_TEST_ENDPOINT", "https://minio:9000")
+	t.Setenv("_TEST_ACCESS_KEY", "")
+	t.Setenv("_TEST_WORKERS", "8")
+	t.Setenv("_TEST_TIMEOUT", "5 minutes")
+	t.Setenv("_TEST_TLS", "on")
+
+	var c Collector
+	endpoint := c.Require("_TEST_ENDPOINT")
+	accessKey := c.Require("_TEST_ACCESS_KEY")
+	secretKey := c.Require("_TEST_SECRET_KEY")
+	region := c.Optional("_TEST_REGION", "us-east-1")
+	workers := c.Int("_TEST_WORKERS", 1)
+	timeout := c.Duration("_TEST_TIMEOUT", time.Minute)
+	tls := c.Bool("_TEST_TLS", false)
+	bucket := c.Require("_TEST_BUCKET")
+
+	if endpoint != "https://minio:9000" || accessKey != "" || secretKey != "" || region != "us-east-1" ||
+		workers != 8 || timeout != time.Minute || !tls || bucket != "" {
+		t.Fatalf("unexpected values: %v, %v, %v, %v, %v, %v, %v, %v", endpoint, accessKey, secretKey, region, workers, timeout, tls, bucket)
+	}
+
+	err := c.Err()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, key := range []string{"_TEST_ACCESS_KEY", "_TEST_SECRET_KEY", "_TEST_BUCKET", "_TEST_TIMEOUT: invalid value '5 minutes'"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Fatalf("expected %v to be reported, got: %v", key, err)
+		}
+	}
+	if strings.Contains(err.Error(), "_TEST_ENDPOINT") || strings.Contains(err.Error(), "_TEST_REGION") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !errors.Is(err, ErrMissing) {
+		t.Fatalf("expected: %v, got: %v", ErrMissing, err)
+	}
+	var verr *VarError
+	if !errors.As(err, &verr) || verr.Key != "_TEST_ACCESS_KEY" {
+		t.Fatalf("expected a VarError of _TEST_ACCESS_KEY, got: %v", verr)
+	}
+	if n := len(strings.Split(err.Error(), "\n")); n != 4 {
+		t.Fatalf("expected: 4 errors, got: %v", n)
+	}
+
+	var ok Collector
+	ok.Require("_TEST_ENDPOINT")
+	if err := ok.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCollectorUnresolved(t *testing.T) {
+	t.Setenv("_TEST_SECRET_KEY", "env://%zz")
+
+	var c Collector
+	if v := c.Require("_TEST_SECRET_KEY"); v != "" {
+		t.Fatalf("expected: %v, got: %v", "", v)
+	}
+	err := c.Err()
+	if err == nil || errors.Is(err, ErrMissing) {
+		t.Fatalf("expected a resolution error, got: %v", err)
+	}
+	// Reported once, not as missing, too.
+	if n := len(strings.Split(err.Error(), "\n")); n != 1 {
+		t.Fatalf("expected: 1 error, got: %v", n)
+	}
+}
+
+func TestGetBool(t *testing.T) {
+	testCases := []struct {
+		value     string
+		expected  bool
+		expectErr bool
+	}{
+		{"", true, false},
+		{"on", true, false},
+		{"OFF", false, false},
+		{"true", true, false},
+		{"0", false, false},
+		{"enabled", true, true},
+	}
+
+	for i, testCase := range testCases {
+		t.Setenv("_TEST_ENV", testCase.value)
+		v, err := GetBool("_TEST_ENV", true)
+		if (err != nil) != testCase.expectErr {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if err == nil && v != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, v)
+		}
+	}
+}
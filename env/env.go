@@ -99,6 +99,27 @@ func GetDuration(key string, defaultValue time.Duration) (time.Duration, error)
 	return time.ParseDuration(v)
 }
 
+// GetBool returns a boolean if found in the environment and returns the
+// default value otherwise. "on" and "off" are accepted in addition to the
+// values accepted by strconv.ParseBool.
+func GetBool(key string, defaultValue bool) (bool, error) {
+	v := Get(key, "")
+	if v == "" {
+		return defaultValue, nil
+	}
+	return parseBool(v)
+}
+
+func parseBool(v string) (bool, error) {
+	switch strings.ToLower(v) {
+	case "on":
+		return true, nil
+	case "off":
+		return false, nil
+	}
+	return strconv.ParseBool(v)
+}
+
 // List all envs with a given prefix.
 func List(prefix string) (envs []string) {
 	for _, env := range Environ() {
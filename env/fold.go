@@ -0,0 +1,131 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package env
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCaseFoldInterval is the default duration after which the
+// case-folded index of the environment is rebuilt.
+const DefaultCaseFoldInterval = time.Minute
+
+// caseFold resolves environment variables whose name differs from the
+// looked up key only in case. The index maps the upper-cased name to the
+// value of the matching variable. If several variables fold to the same
+// name, the one whose name sorts first byte-wise wins, i.e. upper case
+// names take precedence, independent of the order of os.Environ().
+type caseFold struct {
+	mu       sync.Mutex
+	enabled  bool
+	interval time.Duration
+	built    time.Time
+	index    map[string]caseFoldEntry
+}
+
+type caseFoldEntry struct {
+	name  string
+	value string
+}
+
+var globalCaseFold = caseFold{
+	enabled:  runtime.GOOS == "windows",
+	interval: DefaultCaseFoldInterval,
+}
+
+// SetCaseInsensitive turns case-insensitive lookup of environment
+// variables on or off. When on and a key is not found as is, it is
+// resolved against the environment ignoring case. It is on by default on
+// Windows, where environment variable names are case-insensitive.
+func SetCaseInsensitive(on bool) {
+	globalCaseFold.mu.Lock()
+	defer globalCaseFold.mu.Unlock()
+
+	globalCaseFold.enabled = on
+	globalCaseFold.index = nil
+}
+
+// SetCaseFoldInterval sets the duration after which the case-folded
+// index of the environment is rebuilt, a non-positive interval keeps the
+// index until ResetCaseFold is called.
+func SetCaseFoldInterval(interval time.Duration) {
+	globalCaseFold.mu.Lock()
+	defer globalCaseFold.mu.Unlock()
+
+	globalCaseFold.interval = interval
+}
+
+// ResetCaseFold drops the case-folded index of the environment, it is
+// rebuilt on the next case-insensitive lookup.
+func ResetCaseFold() {
+	globalCaseFold.mu.Lock()
+	defer globalCaseFold.mu.Unlock()
+
+	globalCaseFold.index = nil
+}
+
+// lookup returns the value of the variable matching key ignoring case,
+// ok is false if there is none or case-insensitive lookup is off.
+func (c *caseFold) lookup(key string) (value string, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.enabled {
+		return "", false
+	}
+
+	now := time.Now()
+	if c.index == nil || (c.interval > 0 && now.Sub(c.built) >= c.interval) {
+		c.index = buildCaseFoldIndex(os.Environ())
+		c.built = now
+	}
+
+	e, ok := c.index[strings.ToUpper(key)]
+	return e.value, ok
+}
+
+func buildCaseFoldIndex(environ []string) map[string]caseFoldEntry {
+	index := make(map[string]caseFoldEntry, len(environ))
+	for _, kv := range environ {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || name == "" {
+			// Skip malformed entries and the "=C:=C:\" style
+			// entries of Windows.
+			continue
+		}
+		folded := strings.ToUpper(name)
+		if e, ok := index[folded]; ok && e.name < name {
+			continue
+		}
+		index[folded] = caseFoldEntry{name: name, value: value}
+	}
+	return index
+}
+
+// lookupEnv is os.LookupEnv falling back to a case-insensitive lookup if
+// enabled. A variable which is set but empty is found as such.
+func lookupEnv(key string) (string, bool) {
+	if v, ok := os.LookupEnv(key); ok {
+		return v, true
+	}
+	return globalCaseFold.lookup(key)
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package env
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func setCaseInsensitive(t *testing.T) {
+	SetCaseInsensitive(true)
+	t.Cleanup(func() {
+		SetCaseInsensitive(runtime.GOOS == "windows")
+		SetCaseFoldInterval(DefaultCaseFoldInterval)
+	})
+}
+
+func TestCaseInsensitiveLookup(t *testing.T) {
+	t.Setenv("Minio_Fold_Root_User", "minio")
+	t.Setenv("Minio_Fold_Empty", "")
+	t.Setenv("MINIO_FOLD_EXACT", "upper")
+	t.Setenv("minio_fold_exact", "lower")
+
+	SetCaseInsensitive(false)
+	if v := Get("MINIO_FOLD_ROOT_USER", "default"); v != "default" {
+		t.Fatalf("expected: default, got: %v", v)
+	}
+
+	setCaseInsensitive(t)
+	testCases := []struct {
+		key           string
+		expectedValue string
+		expectedOk    bool
+	}{
+		{"MINIO_FOLD_ROOT_USER", "minio", true},
+		{"minio_fold_root_user", "minio", true},
+		{"Minio_Fold_Root_User", "minio", true},
+		// Set but empty variables are found.
+		{"MINIO_FOLD_EMPTY", "", true},
+		// Exact matches take precedence.
+		{"MINIO_FOLD_EXACT", "upper", true},
+		{"minio_fold_exact", "lower", true},
+		// Otherwise the name which sorts first wins.
+		{"Minio_Fold_Exact", "upper", true},
+		{"MINIO_FOLD_MISSING", "", false},
+	}
+
+	for i, testCase := range testCases {
+		v, ok := lookupEnv(testCase.key)
+		if v != testCase.expectedValue || ok != testCase.expectedOk {
+			t.Fatalf("case %v: expected: %v, %v, got: %v, %v", i+1, testCase.expectedValue, testCase.expectedOk, v, ok)
+		}
+	}
+
+	if v := Get("MINIO_FOLD_ROOT_USER", "default"); v != "minio" {
+		t.Fatalf("expected: minio, got: %v", v)
+	}
+	if v := Get("MINIO_FOLD_EMPTY", "default"); v != "default" {
+		t.Fatalf("expected: default, got: %v", v)
+	}
+	if !IsSet("minio_fold_root_user") {
+		t.Fatal("expected minio_fold_root_user to be set")
+	}
+}
+
+func TestCaseFoldIndexPrecedence(t *testing.T) {
+	environ := []string{"Minio_Dup=b", "MINIO_DUP=a", "minio_dup=c", "=C:=C:\\", "MALFORMED"}
+	reversed := []string{"minio_dup=c", "MINIO_DUP=a", "Minio_Dup=b"}
+
+	for i, env := range [][]string{environ, reversed} {
+		index := buildCaseFoldIndex(env)
+		if e := index["MINIO_DUP"]; e.name != "MINIO_DUP" || e.value != "a" {
+			t.Fatalf("case %v: expected: MINIO_DUP=a, got: %v=%v", i+1, e.name, e.value)
+		}
+		if len(index) != 1 {
+			t.Fatalf("case %v: expected 1 entry, got: %v", i+1, index)
+		}
+	}
+}
+
+func TestCaseFoldRefresh(t *testing.T) {
+	setCaseInsensitive(t)
+	SetCaseFoldInterval(0)
+
+	if _, ok := lookupEnv("MINIO_FOLD_LATE"); ok {
+		t.Fatal("expected MINIO_FOLD_LATE to be unset")
+	}
+
+	// The index is cached until reset.
+	t.Setenv("Minio_Fold_Late", "late")
+	if _, ok := lookupEnv("MINIO_FOLD_LATE"); ok {
+		t.Fatal("expected cached index")
+	}
+	ResetCaseFold()
+	if v, ok := lookupEnv("MINIO_FOLD_LATE"); !ok || v != "late" {
+		t.Fatalf("expected: late, got: %v, %v", v, ok)
+	}
+
+	// Or until the interval elapsed.
+	SetCaseFoldInterval(10 * time.Millisecond)
+	ResetCaseFold()
+	lookupEnv("MINIO_FOLD_LATE")
+	t.Setenv("Minio_Fold_Later", "later")
+	time.Sleep(20 * time.Millisecond)
+	if v, ok := lookupEnv("MINIO_FOLD_LATER"); !ok || v != "later" {
+		t.Fatalf("expected: later, got: %v, %v", v, ok)
+	}
+}
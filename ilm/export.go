@@ -0,0 +1,486 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package ilm provides helpers around bucket lifecycle (ILM) configurations.
+package ilm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+)
+
+// Rule status values.
+const (
+	StatusEnabled  = "Enabled"
+	StatusDisabled = "Disabled"
+)
+
+// ExportedTag - object tag of a rule filter.
+type ExportedTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ExportedFilter - rule filter, flattened so that a single tag or
+// prefix and the equivalent 'And' form export identically.
+type ExportedFilter struct {
+	Prefix                *string       `json:"prefix,omitempty"`
+	Tags                  []ExportedTag `json:"tags,omitempty"`
+	ObjectSizeLessThan    *int64        `json:"object_size_less_than,omitempty"`
+	ObjectSizeGreaterThan *int64        `json:"object_size_greater_than,omitempty"`
+}
+
+// ExportedExpiration - current version expiration of a rule.
+type ExportedExpiration struct {
+	Days                      *int       `json:"days,omitempty"`
+	Date                      *time.Time `json:"date,omitempty"`
+	ExpiredObjectDeleteMarker bool       `json:"expired_object_delete_marker,omitempty"`
+	ExpiredObjectAllVersions  bool       `json:"expired_object_all_versions,omitempty"`
+}
+
+// ExportedTransition - current version transition of a rule.
+type ExportedTransition struct {
+	Days         *int       `json:"days,omitempty"`
+	Date         *time.Time `json:"date,omitempty"`
+	StorageClass string     `json:"storage_class"`
+}
+
+// ExportedNoncurrentVersionExpiration - noncurrent version expiration of a rule.
+type ExportedNoncurrentVersionExpiration struct {
+	NoncurrentDays          *int `json:"noncurrent_days,omitempty"`
+	NewerNoncurrentVersions *int `json:"newer_noncurrent_versions,omitempty"`
+}
+
+// ExportedNoncurrentVersionTransition - noncurrent version transition of a rule.
+type ExportedNoncurrentVersionTransition struct {
+	NoncurrentDays          *int   `json:"noncurrent_days,omitempty"`
+	NewerNoncurrentVersions *int   `json:"newer_noncurrent_versions,omitempty"`
+	StorageClass            string `json:"storage_class"`
+}
+
+// ExportedAllVersionsExpiration - expiration of all versions of an object.
+type ExportedAllVersionsExpiration struct {
+	Days         *int `json:"days,omitempty"`
+	DeleteMarker bool `json:"delete_marker,omitempty"`
+}
+
+// ExportedRule - lifecycle rule with stable field names suitable for
+// infrastructure as code tools and JSON schema validation. Unlike
+// lifecycle.Rule every optional number and date is a pointer, so an
+// unset value is distinguishable from zero.
+type ExportedRule struct {
+	ID                                 string                               `json:"id"`
+	Status                             string                               `json:"status"`
+	Filter                             *ExportedFilter                      `json:"filter,omitempty"`
+	Expiration                         *ExportedExpiration                  `json:"expiration,omitempty"`
+	Transition                         *ExportedTransition                  `json:"transition,omitempty"`
+	NoncurrentVersionExpiration        *ExportedNoncurrentVersionExpiration `json:"noncurrent_version_expiration,omitempty"`
+	NoncurrentVersionTransition        *ExportedNoncurrentVersionTransition `json:"noncurrent_version_transition,omitempty"`
+	AbortIncompleteMultipartUploadDays *int                                 `json:"abort_incomplete_multipart_upload_days,omitempty"`
+	DelMarkerExpirationDays            *int                                 `json:"del_marker_expiration_days,omitempty"`
+	AllVersionsExpiration              *ExportedAllVersionsExpiration       `json:"all_versions_expiration,omitempty"`
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func timePtr(t time.Time) *time.Time {
+	t = t.UTC()
+	return &t
+}
+
+func exportFilter(rule lifecycle.Rule) *ExportedFilter {
+	f := rule.RuleFilter
+	prefix := rule.Prefix
+	if f.Prefix != "" {
+		prefix = f.Prefix
+	}
+	if f.And.Prefix != "" {
+		prefix = f.And.Prefix
+	}
+
+	var tags []ExportedTag
+	if !f.Tag.IsEmpty() {
+		tags = append(tags, ExportedTag{Key: f.Tag.Key, Value: f.Tag.Value})
+	}
+	for _, tag := range f.And.Tags {
+		if !tag.IsEmpty() {
+			tags = append(tags, ExportedTag{Key: tag.Key, Value: tag.Value})
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Key != tags[j].Key {
+			return tags[i].Key < tags[j].Key
+		}
+		return tags[i].Value < tags[j].Value
+	})
+
+	lessThan := f.ObjectSizeLessThan
+	if f.And.ObjectSizeLessThan != 0 {
+		lessThan = f.And.ObjectSizeLessThan
+	}
+	greaterThan := f.ObjectSizeGreaterThan
+	if f.And.ObjectSizeGreaterThan != 0 {
+		greaterThan = f.And.ObjectSizeGreaterThan
+	}
+
+	if prefix == "" && len(tags) == 0 && lessThan == 0 && greaterThan == 0 {
+		return nil
+	}
+
+	ef := &ExportedFilter{Tags: tags}
+	if prefix != "" {
+		ef.Prefix = &prefix
+	}
+	if lessThan != 0 {
+		ef.ObjectSizeLessThan = int64Ptr(lessThan)
+	}
+	if greaterThan != 0 {
+		ef.ObjectSizeGreaterThan = int64Ptr(greaterThan)
+	}
+	return ef
+}
+
+// ExportRule converts rule into its exported form.
+func ExportRule(rule lifecycle.Rule) ExportedRule {
+	er := ExportedRule{
+		ID:     rule.ID,
+		Status: rule.Status,
+		Filter: exportFilter(rule),
+	}
+
+	if exp := rule.Expiration; !exp.IsNull() {
+		er.Expiration = &ExportedExpiration{
+			ExpiredObjectDeleteMarker: exp.DeleteMarker.IsEnabled(),
+			ExpiredObjectAllVersions:  exp.DeleteAll.IsEnabled(),
+		}
+		if !exp.IsDaysNull() {
+			er.Expiration.Days = intPtr(int(exp.Days))
+		}
+		if !exp.IsDateNull() {
+			er.Expiration.Date = timePtr(exp.Date.Time)
+		}
+	}
+
+	// A transition with a storage class but without a date transitions
+	// after Days, which may legitimately be zero.
+	if tr := rule.Transition; !tr.IsNull() {
+		er.Transition = &ExportedTransition{StorageClass: tr.StorageClass}
+		if !tr.IsDateNull() {
+			er.Transition.Date = timePtr(tr.Date.Time)
+		} else {
+			er.Transition.Days = intPtr(int(tr.Days))
+		}
+	}
+
+	if nve := rule.NoncurrentVersionExpiration; !nve.IsDaysNull() || nve.NewerNoncurrentVersions != 0 {
+		er.NoncurrentVersionExpiration = &ExportedNoncurrentVersionExpiration{}
+		if !nve.IsDaysNull() {
+			er.NoncurrentVersionExpiration.NoncurrentDays = intPtr(int(nve.NoncurrentDays))
+		}
+		if nve.NewerNoncurrentVersions != 0 {
+			er.NoncurrentVersionExpiration.NewerNoncurrentVersions = intPtr(nve.NewerNoncurrentVersions)
+		}
+	}
+
+	if nvt := rule.NoncurrentVersionTransition; !nvt.IsStorageClassEmpty() {
+		er.NoncurrentVersionTransition = &ExportedNoncurrentVersionTransition{
+			NoncurrentDays: intPtr(int(nvt.NoncurrentDays)),
+			StorageClass:   nvt.StorageClass,
+		}
+		if nvt.NewerNoncurrentVersions != 0 {
+			er.NoncurrentVersionTransition.NewerNoncurrentVersions = intPtr(nvt.NewerNoncurrentVersions)
+		}
+	}
+
+	if abort := rule.AbortIncompleteMultipartUpload; !abort.IsDaysNull() {
+		er.AbortIncompleteMultipartUploadDays = intPtr(int(abort.DaysAfterInitiation))
+	}
+
+	if dme := rule.DelMarkerExpiration; !dme.IsNull() {
+		er.DelMarkerExpirationDays = intPtr(dme.Days)
+	}
+
+	if ave := rule.AllVersionsExpiration; !ave.IsNull() {
+		er.AllVersionsExpiration = &ExportedAllVersionsExpiration{
+			Days:         intPtr(ave.Days),
+			DeleteMarker: ave.DeleteMarker.IsEnabled(),
+		}
+	}
+
+	return er
+}
+
+// ExportRules converts all rules of cfg into their exported form, sorted
+// by rule ID so that semantically identical configurations export
+// identically.
+func ExportRules(cfg lifecycle.Configuration) []ExportedRule {
+	rules := make([]ExportedRule, 0, len(cfg.Rules))
+	for _, rule := range cfg.Rules {
+		rules = append(rules, ExportRule(rule))
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].ID < rules[j].ID
+	})
+	return rules
+}
+
+func importFilter(ef *ExportedFilter) (lifecycle.Filter, error) {
+	var f lifecycle.Filter
+	if ef == nil {
+		return f, nil
+	}
+
+	var prefix string
+	if ef.Prefix != nil {
+		prefix = *ef.Prefix
+	}
+	var lessThan, greaterThan int64
+	if ef.ObjectSizeLessThan != nil {
+		if lessThan = *ef.ObjectSizeLessThan; lessThan <= 0 {
+			return f, errors.New("object_size_less_than must be positive")
+		}
+	}
+	if ef.ObjectSizeGreaterThan != nil {
+		if greaterThan = *ef.ObjectSizeGreaterThan; greaterThan < 0 {
+			return f, errors.New("object_size_greater_than must not be negative")
+		}
+	}
+
+	var tags []lifecycle.Tag
+	seen := make(map[string]struct{}, len(ef.Tags))
+	for _, tag := range ef.Tags {
+		if tag.Key == "" {
+			return f, errors.New("tag key must not be empty")
+		}
+		if _, ok := seen[tag.Key]; ok {
+			return f, fmt.Errorf("duplicate tag key '%s'", tag.Key)
+		}
+		seen[tag.Key] = struct{}{}
+		tags = append(tags, lifecycle.Tag{Key: tag.Key, Value: tag.Value})
+	}
+
+	var conds int
+	for _, set := range []bool{prefix != "", lessThan != 0, greaterThan != 0} {
+		if set {
+			conds++
+		}
+	}
+	conds += len(tags)
+
+	switch {
+	case conds > 1:
+		f.And = lifecycle.And{
+			Prefix:                prefix,
+			Tags:                  tags,
+			ObjectSizeLessThan:    lessThan,
+			ObjectSizeGreaterThan: greaterThan,
+		}
+	case len(tags) == 1:
+		f.Tag = tags[0]
+	default:
+		f.Prefix = prefix
+		f.ObjectSizeLessThan = lessThan
+		f.ObjectSizeGreaterThan = greaterThan
+	}
+	return f, nil
+}
+
+func nonNegative(name string, v *int) (int, error) {
+	if v == nil {
+		return 0, nil
+	}
+	if *v < 0 {
+		return 0, fmt.Errorf("%s must not be negative", name)
+	}
+	return *v, nil
+}
+
+// ImportRule converts an exported rule back into a lifecycle.Rule,
+// validating it on the way.
+func ImportRule(er ExportedRule) (lifecycle.Rule, error) {
+	rule := lifecycle.Rule{
+		ID:     er.ID,
+		Status: er.Status,
+	}
+
+	if er.ID == "" {
+		return rule, errors.New("rule id must not be empty")
+	}
+	if len(er.ID) > 255 {
+		return rule, fmt.Errorf("rule '%s': id must not be longer than 255 characters", er.ID)
+	}
+	if er.Status != StatusEnabled && er.Status != StatusDisabled {
+		return rule, fmt.Errorf("rule '%s': invalid status '%s'", er.ID, er.Status)
+	}
+
+	wrap := func(err error) error {
+		return fmt.Errorf("rule '%s': %w", er.ID, err)
+	}
+
+	var err error
+	if rule.RuleFilter, err = importFilter(er.Filter); err != nil {
+		return rule, wrap(err)
+	}
+
+	if exp := er.Expiration; exp != nil {
+		if exp.Days != nil && exp.Date != nil {
+			return rule, wrap(errors.New("expiration days and date are mutually exclusive"))
+		}
+		days, err := nonNegative("expiration days", exp.Days)
+		if err != nil {
+			return rule, wrap(err)
+		}
+		if exp.Days != nil && days == 0 {
+			return rule, wrap(errors.New("expiration days must be positive"))
+		}
+		rule.Expiration = lifecycle.Expiration{
+			Days:         lifecycle.ExpirationDays(days),
+			DeleteMarker: lifecycle.ExpireDeleteMarker(exp.ExpiredObjectDeleteMarker),
+			DeleteAll:    lifecycle.ExpirationBoolean(exp.ExpiredObjectAllVersions),
+		}
+		if exp.Date != nil {
+			rule.Expiration.Date = lifecycle.ExpirationDate{Time: exp.Date.UTC()}
+		}
+		if rule.Expiration.IsNull() {
+			return rule, wrap(errors.New("expiration must specify days, date or delete marker expiration"))
+		}
+	}
+
+	if tr := er.Transition; tr != nil {
+		if tr.StorageClass == "" {
+			return rule, wrap(errors.New("transition storage class must not be empty"))
+		}
+		if tr.Days != nil && tr.Date != nil {
+			return rule, wrap(errors.New("transition days and date are mutually exclusive"))
+		}
+		days, err := nonNegative("transition days", tr.Days)
+		if err != nil {
+			return rule, wrap(err)
+		}
+		rule.Transition = lifecycle.Transition{
+			Days:         lifecycle.ExpirationDays(days),
+			StorageClass: tr.StorageClass,
+		}
+		if tr.Date != nil {
+			rule.Transition.Date = lifecycle.ExpirationDate{Time: tr.Date.UTC()}
+		}
+	}
+
+	if nve := er.NoncurrentVersionExpiration; nve != nil {
+		days, err := nonNegative("noncurrent version expiration days", nve.NoncurrentDays)
+		if err != nil {
+			return rule, wrap(err)
+		}
+		versions, err := nonNegative("newer noncurrent versions", nve.NewerNoncurrentVersions)
+		if err != nil {
+			return rule, wrap(err)
+		}
+		if days == 0 && versions == 0 {
+			return rule, wrap(errors.New("noncurrent version expiration must specify days or newer noncurrent versions"))
+		}
+		rule.NoncurrentVersionExpiration = lifecycle.NoncurrentVersionExpiration{
+			NoncurrentDays:          lifecycle.ExpirationDays(days),
+			NewerNoncurrentVersions: versions,
+		}
+	}
+
+	if nvt := er.NoncurrentVersionTransition; nvt != nil {
+		if nvt.StorageClass == "" {
+			return rule, wrap(errors.New("noncurrent version transition storage class must not be empty"))
+		}
+		days, err := nonNegative("noncurrent version transition days", nvt.NoncurrentDays)
+		if err != nil {
+			return rule, wrap(err)
+		}
+		versions, err := nonNegative("newer noncurrent versions", nvt.NewerNoncurrentVersions)
+		if err != nil {
+			return rule, wrap(err)
+		}
+		rule.NoncurrentVersionTransition = lifecycle.NoncurrentVersionTransition{
+			NoncurrentDays:          lifecycle.ExpirationDays(days),
+			NewerNoncurrentVersions: versions,
+			StorageClass:            nvt.StorageClass,
+		}
+	}
+
+	if er.AbortIncompleteMultipartUploadDays != nil {
+		if *er.AbortIncompleteMultipartUploadDays <= 0 {
+			return rule, wrap(errors.New("abort incomplete multipart upload days must be positive"))
+		}
+		rule.AbortIncompleteMultipartUpload.DaysAfterInitiation = lifecycle.ExpirationDays(*er.AbortIncompleteMultipartUploadDays)
+	}
+
+	if er.DelMarkerExpirationDays != nil {
+		if *er.DelMarkerExpirationDays <= 0 {
+			return rule, wrap(errors.New("delete marker expiration days must be positive"))
+		}
+		rule.DelMarkerExpiration.Days = *er.DelMarkerExpirationDays
+	}
+
+	if ave := er.AllVersionsExpiration; ave != nil {
+		if ave.Days == nil || *ave.Days <= 0 {
+			return rule, wrap(errors.New("all versions expiration days must be positive"))
+		}
+		rule.AllVersionsExpiration = lifecycle.AllVersionsExpiration{
+			Days:         *ave.Days,
+			DeleteMarker: lifecycle.ExpireDeleteMarker(ave.DeleteMarker),
+		}
+	}
+
+	if er.Expiration == nil && er.Transition == nil &&
+		er.NoncurrentVersionExpiration == nil && er.NoncurrentVersionTransition == nil &&
+		er.AbortIncompleteMultipartUploadDays == nil && er.DelMarkerExpirationDays == nil &&
+		er.AllVersionsExpiration == nil {
+		return rule, wrap(errors.New("at least one action must be specified"))
+	}
+
+	return rule, nil
+}
+
+// ImportRules is the inverse of ExportRules, it validates all rules and
+// returns the corresponding lifecycle configuration.
+func ImportRules(rules []ExportedRule) (lifecycle.Configuration, error) {
+	var cfg lifecycle.Configuration
+	if len(rules) == 0 {
+		return cfg, errors.New("lifecycle configuration must have at least one rule")
+	}
+
+	ids := make(map[string]struct{}, len(rules))
+	for _, er := range rules {
+		if _, ok := ids[er.ID]; ok {
+			return lifecycle.Configuration{}, fmt.Errorf("duplicate rule id '%s'", er.ID)
+		}
+		ids[er.ID] = struct{}{}
+
+		rule, err := ImportRule(er)
+		if err != nil {
+			return lifecycle.Configuration{}, err
+		}
+		cfg.Rules = append(cfg.Rules, rule)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ilm
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+)
+
+func TestExportImportRoundTrip(t *testing.T) {
+	date := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []lifecycle.Configuration{
+		{Rules: []lifecycle.Rule{{
+			ID:         "expire",
+			Status:     StatusEnabled,
+			RuleFilter: lifecycle.Filter{Prefix: "logs/"},
+			Expiration: lifecycle.Expiration{Days: 30},
+		}}},
+		{Rules: []lifecycle.Rule{{
+			ID:     "transition",
+			Status: StatusDisabled,
+			RuleFilter: lifecycle.Filter{And: lifecycle.And{
+				Prefix: "data/",
+				Tags:   []lifecycle.Tag{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}},
+			}},
+			Transition:                     lifecycle.Transition{StorageClass: "WARM"},
+			AbortIncompleteMultipartUpload: lifecycle.AbortIncompleteMultipartUpload{DaysAfterInitiation: 7},
+		}}},
+		{Rules: []lifecycle.Rule{{
+			ID:                          "noncurrent",
+			Status:                      StatusEnabled,
+			RuleFilter:                  lifecycle.Filter{Tag: lifecycle.Tag{Key: "k", Value: "v"}},
+			Expiration:                  lifecycle.Expiration{Date: lifecycle.ExpirationDate{Time: date}},
+			NoncurrentVersionExpiration: lifecycle.NoncurrentVersionExpiration{NoncurrentDays: 10, NewerNoncurrentVersions: 3},
+			NoncurrentVersionTransition: lifecycle.NoncurrentVersionTransition{NoncurrentDays: 2, StorageClass: "COLD"},
+			DelMarkerExpiration:         lifecycle.DelMarkerExpiration{Days: 5},
+		}}},
+		{Rules: []lifecycle.Rule{{
+			ID:                    "all-versions",
+			Status:                StatusEnabled,
+			RuleFilter:            lifecycle.Filter{ObjectSizeGreaterThan: 1024},
+			AllVersionsExpiration: lifecycle.AllVersionsExpiration{Days: 90, DeleteMarker: true},
+		}}},
+	}
+
+	for i, testCase := range testCases {
+		cfg, err := ImportRules(ExportRules(testCase))
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(cfg, testCase) {
+			t.Fatalf("case %v: expected: %+v, got: %+v", i+1, testCase, cfg)
+		}
+	}
+}
+
+func TestExportRulesNormalized(t *testing.T) {
+	cfg1 := lifecycle.Configuration{Rules: []lifecycle.Rule{
+		{
+			ID:     "b",
+			Status: StatusEnabled,
+			RuleFilter: lifecycle.Filter{And: lifecycle.And{
+				Tags: []lifecycle.Tag{{Key: "y", Value: "2"}, {Key: "x", Value: "1"}},
+			}},
+			Expiration: lifecycle.Expiration{Days: 1},
+		},
+		{
+			ID:         "a",
+			Status:     StatusEnabled,
+			Prefix:     "logs/",
+			Expiration: lifecycle.Expiration{Days: 1},
+		},
+	}}
+	cfg2 := lifecycle.Configuration{Rules: []lifecycle.Rule{
+		{
+			ID:         "a",
+			Status:     StatusEnabled,
+			RuleFilter: lifecycle.Filter{Prefix: "logs/"},
+			Expiration: lifecycle.Expiration{Days: 1},
+		},
+		{
+			ID:     "b",
+			Status: StatusEnabled,
+			RuleFilter: lifecycle.Filter{And: lifecycle.And{
+				Tags: []lifecycle.Tag{{Key: "x", Value: "1"}, {Key: "y", Value: "2"}},
+			}},
+			Expiration: lifecycle.Expiration{Days: 1},
+		},
+	}}
+
+	data1, err := json.Marshal(ExportRules(cfg1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data2, err := json.Marshal(ExportRules(cfg2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data1) != string(data2) {
+		t.Fatalf("expected identical export, got: %s and %s", data1, data2)
+	}
+}
+
+func TestExportTransitionZeroDays(t *testing.T) {
+	rules := ExportRules(lifecycle.Configuration{Rules: []lifecycle.Rule{{
+		ID:         "transition",
+		Status:     StatusEnabled,
+		Transition: lifecycle.Transition{StorageClass: "WARM"},
+	}}})
+
+	data, err := json.Marshal(rules[0].Transition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"days":0,"storage_class":"WARM"}`; string(data) != expected {
+		t.Fatalf("expected: %v, got: %v", expected, string(data))
+	}
+}
+
+func TestImportRulesErrors(t *testing.T) {
+	days := 1
+	negative := -1
+	testCases := []struct {
+		rules     []ExportedRule
+		expectErr bool
+	}{
+		{[]ExportedRule{{ID: "a", Status: StatusEnabled, Expiration: &ExportedExpiration{Days: &days}}}, false},
+		{nil, true},
+		{[]ExportedRule{{Status: StatusEnabled, Expiration: &ExportedExpiration{Days: &days}}}, true},
+		{[]ExportedRule{{ID: "a", Status: "enabled", Expiration: &ExportedExpiration{Days: &days}}}, true},
+		{[]ExportedRule{{ID: "a", Status: StatusEnabled}}, true},
+		{[]ExportedRule{{ID: "a", Status: StatusEnabled, Expiration: &ExportedExpiration{Days: &negative}}}, true},
+		{[]ExportedRule{{ID: "a", Status: StatusEnabled, Transition: &ExportedTransition{Days: &days}}}, true},
+		{[]ExportedRule{
+			{ID: "a", Status: StatusEnabled, Expiration: &ExportedExpiration{Days: &days}},
+			{ID: "a", Status: StatusEnabled, Expiration: &ExportedExpiration{Days: &days}},
+		}, true},
+		{[]ExportedRule{{
+			ID:         "a",
+			Status:     StatusEnabled,
+			Filter:     &ExportedFilter{Tags: []ExportedTag{{Key: "k", Value: "1"}, {Key: "k", Value: "2"}}},
+			Expiration: &ExportedExpiration{Days: &days},
+		}}, true},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ImportRules(testCase.rules)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v (%v)", i+1, testCase.expectErr, expectErr, err)
+		}
+	}
+}
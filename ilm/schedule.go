@@ -0,0 +1,224 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ilm
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+)
+
+// Action - lifecycle action applied to an object version.
+type Action string
+
+// Lifecycle actions.
+const (
+	ActionExpire               Action = "expire"
+	ActionTransition           Action = "transition"
+	ActionExpireNoncurrent     Action = "expire-noncurrent"
+	ActionTransitionNoncurrent Action = "transition-noncurrent"
+	ActionExpireDeleteMarker   Action = "expire-delete-marker"
+	ActionExpireAllVersions    Action = "expire-all-versions"
+)
+
+// isExpiry - returns whether the action deletes the object version.
+func (a Action) isExpiry() bool {
+	return a != ActionTransition && a != ActionTransitionNoncurrent
+}
+
+// ObjectAttrs - attributes of an object version evaluated against
+// lifecycle rules.
+type ObjectAttrs struct {
+	Name         string
+	Size         int64
+	ModTime      time.Time
+	UserTags     map[string]string
+	IsLatest     bool
+	DeleteMarker bool
+
+	// SuccessorModTime - modification time of the next version, i.e. the
+	// time this version became noncurrent.
+	SuccessorModTime time.Time
+
+	// Transitioned - the version was already transitioned.
+	Transitioned bool
+}
+
+// Event - lifecycle action due for an object version.
+type Event struct {
+	Action       Action
+	RuleID       string
+	Due          time.Time
+	StorageClass string // Target of transitions
+}
+
+// ExpectedExpiryTime returns the time an object modified at modTime
+// expires after days. As in S3, the expiry is rounded to the midnight UTC
+// following modTime plus days, e.g. an object modified on 2025-06-27 at
+// 10:30 UTC expires after 3 days on 2025-07-01 at 00:00 UTC. With zero days
+// the object expires immediately, at modTime.
+func ExpectedExpiryTime(modTime time.Time, days int) time.Time {
+	if days <= 0 {
+		return modTime.UTC()
+	}
+	t := modTime.UTC().Add(time.Duration(days+1) * 24 * time.Hour)
+	return t.Truncate(24 * time.Hour)
+}
+
+// ExpectedTransitionTime returns the time an object modified at modTime
+// transitions after days, rounded as by ExpectedExpiryTime. With zero
+// days the object transitions immediately, at modTime.
+func ExpectedTransitionTime(modTime time.Time, days int) time.Time {
+	return ExpectedExpiryTime(modTime, days)
+}
+
+// matchFilter - returns whether obj is selected by the filter of rule.
+func matchFilter(rule lifecycle.Rule, obj ObjectAttrs) bool {
+	f := exportFilter(rule)
+	if f == nil {
+		return true
+	}
+	if f.Prefix != nil && !strings.HasPrefix(obj.Name, *f.Prefix) {
+		return false
+	}
+	for _, tag := range f.Tags {
+		if v, ok := obj.UserTags[tag.Key]; !ok || v != tag.Value {
+			return false
+		}
+	}
+	if f.ObjectSizeLessThan != nil && obj.Size >= *f.ObjectSizeLessThan {
+		return false
+	}
+	if f.ObjectSizeGreaterThan != nil && obj.Size <= *f.ObjectSizeGreaterThan {
+		return false
+	}
+	return true
+}
+
+// ruleEvents - returns the actions of rule applying to obj.
+func ruleEvents(rule lifecycle.Rule, obj ObjectAttrs) []Event {
+	if rule.Status != StatusEnabled || !matchFilter(rule, obj) {
+		return nil
+	}
+
+	var events []Event
+	add := func(action Action, due time.Time, storageClass string) {
+		events = append(events, Event{Action: action, RuleID: rule.ID, Due: due.UTC(), StorageClass: storageClass})
+	}
+
+	switch {
+	case obj.IsLatest && obj.DeleteMarker:
+		if dme := rule.DelMarkerExpiration; !dme.IsNull() {
+			add(ActionExpireDeleteMarker, ExpectedExpiryTime(obj.ModTime, dme.Days), "")
+		}
+	case obj.IsLatest:
+		if exp := rule.Expiration; !exp.IsDateNull() {
+			add(ActionExpire, exp.Date.Time, "")
+		} else if !exp.IsDaysNull() {
+			add(ActionExpire, ExpectedExpiryTime(obj.ModTime, int(exp.Days)), "")
+		}
+		if ave := rule.AllVersionsExpiration; !ave.IsNull() {
+			add(ActionExpireAllVersions, ExpectedExpiryTime(obj.ModTime, ave.Days), "")
+		}
+		if tr := rule.Transition; !tr.IsNull() && !obj.Transitioned {
+			if !tr.IsDateNull() {
+				add(ActionTransition, tr.Date.Time, tr.StorageClass)
+			} else {
+				add(ActionTransition, ExpectedTransitionTime(obj.ModTime, int(tr.Days)), tr.StorageClass)
+			}
+		}
+	default:
+		// Noncurrent versions age from the time they became noncurrent.
+		if nve := rule.NoncurrentVersionExpiration; !nve.IsDaysNull() {
+			add(ActionExpireNoncurrent, ExpectedExpiryTime(obj.SuccessorModTime, int(nve.NoncurrentDays)), "")
+		}
+		if nvt := rule.NoncurrentVersionTransition; !nvt.IsStorageClassEmpty() && !obj.DeleteMarker && !obj.Transitioned {
+			add(ActionTransitionNoncurrent, ExpectedTransitionTime(obj.SuccessorModTime, int(nvt.NoncurrentDays)), nvt.StorageClass)
+		}
+	}
+	return events
+}
+
+// NextRuleEvent returns the earliest lifecycle action of the enabled
+// rules selecting obj, or false if no rule applies to obj. Actions which
+// are overdue, e.g. those of date based rules whose date has passed, are
+// due at now. If an expiry and a transition are due at the same time, the
+// expiry is returned.
+//
+// NewerNoncurrentVersions limits are not taken into account since they
+// depend on the other versions of the object, the returned event is the
+// earliest time the action may happen.
+func NextRuleEvent(rules []lifecycle.Rule, obj ObjectAttrs, now time.Time) (Event, bool) {
+	var next Event
+	var found bool
+	for _, rule := range rules {
+		for _, event := range ruleEvents(rule, obj) {
+			if event.Due.Before(now) {
+				event.Due = now.UTC()
+			}
+			if !found || event.Due.Before(next.Due) ||
+				(event.Due.Equal(next.Due) && event.Action.isExpiry() && !next.Action.isExpiry()) {
+				next, found = event, true
+			}
+		}
+	}
+	return next, found
+}
+
+// Humanize returns a description of the event relative to now, e.g.
+// "expires in 3 days (2025-07-01 00:00 UTC)".
+func (e Event) Humanize(now time.Time) string {
+	var verb string
+	switch e.Action {
+	case ActionTransition, ActionTransitionNoncurrent:
+		verb = "transitions to " + e.StorageClass
+	case ActionExpireAllVersions:
+		verb = "expires with all versions"
+	default:
+		verb = "expires"
+	}
+
+	when := "now"
+	if d := e.Due.Sub(now); d > 0 {
+		when = "in " + humanizeDuration(d)
+	}
+	return fmt.Sprintf("%s %s (%s)", verb, when, e.Due.UTC().Format("2006-01-02 15:04 MST"))
+}
+
+// humanizeDuration - returns d in the largest whole unit, rounded down.
+func humanizeDuration(d time.Duration) string {
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return "1 " + unit
+		}
+		return fmt.Sprintf("%d %ss", n, unit)
+	}
+
+	switch {
+	case d >= 24*time.Hour:
+		return plural(int(d/(24*time.Hour)), "day")
+	case d >= time.Hour:
+		return plural(int(d/time.Hour), "hour")
+	case d >= time.Minute:
+		return plural(int(d/time.Minute), "minute")
+	default:
+		return "less than a minute"
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ilm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+)
+
+func TestExpectedExpiryTime(t *testing.T) {
+	pst := time.FixedZone("PST", -8*60*60)
+	testCases := []struct {
+		modTime  time.Time
+		days     int
+		expected time.Time
+	}{
+		{time.Date(2025, 6, 27, 10, 30, 0, 0, time.UTC), 3, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2025, 6, 27, 0, 0, 0, 0, time.UTC), 3, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2025, 6, 27, 23, 59, 59, 999999999, time.UTC), 3, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2025, 6, 28, 0, 0, 0, 1, time.UTC), 3, time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2025, 6, 27, 10, 30, 0, 0, time.UTC), 1, time.Date(2025, 6, 29, 0, 0, 0, 0, time.UTC)},
+		// Rounded to midnight UTC, not midnight in the zone of modTime.
+		{time.Date(2025, 6, 27, 20, 0, 0, 0, pst), 1, time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)},
+		// Across a leap day.
+		{time.Date(2024, 2, 28, 12, 0, 0, 0, time.UTC), 1, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		// Zero days expire immediately.
+		{time.Date(2025, 6, 27, 10, 30, 0, 0, time.UTC), 0, time.Date(2025, 6, 27, 10, 30, 0, 0, time.UTC)},
+		{time.Date(2025, 6, 27, 20, 0, 0, 0, pst), 0, time.Date(2025, 6, 28, 4, 0, 0, 0, time.UTC)},
+	}
+
+	for i, testCase := range testCases {
+		if result := ExpectedExpiryTime(testCase.modTime, testCase.days); !result.Equal(testCase.expected) || result.Location() != time.UTC {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+		if result := ExpectedTransitionTime(testCase.modTime, testCase.days); !result.Equal(testCase.expected) {
+			t.Fatalf("case %v: transition: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+	}
+}
+
+func TestNextRuleEvent(t *testing.T) {
+	modTime := time.Date(2025, 6, 27, 10, 30, 0, 0, time.UTC)
+	now := time.Date(2025, 6, 28, 12, 0, 0, 0, time.UTC)
+	date := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	current := ObjectAttrs{Name: "logs/a.log", Size: 100, ModTime: modTime, IsLatest: true, UserTags: map[string]string{"class": "temp"}}
+	noncurrent := ObjectAttrs{Name: "logs/a.log", Size: 100, ModTime: modTime, SuccessorModTime: now}
+
+	expireDays := lifecycle.Rule{ID: "expire-days", Status: StatusEnabled, Expiration: lifecycle.Expiration{Days: 3}}
+	expireDate := lifecycle.Rule{ID: "expire-date", Status: StatusEnabled, Expiration: lifecycle.Expiration{Date: lifecycle.ExpirationDate{Time: date}}}
+	transitionDays := lifecycle.Rule{ID: "transition-days", Status: StatusEnabled, Transition: lifecycle.Transition{Days: 3, StorageClass: "WARM"}}
+	transitionNow := lifecycle.Rule{ID: "transition-now", Status: StatusEnabled, Transition: lifecycle.Transition{StorageClass: "WARM"}}
+	disabled := expireDays
+	disabled.ID, disabled.Status, disabled.Expiration.Days = "disabled", StatusDisabled, 1
+	otherPrefix := expireDays
+	otherPrefix.ID, otherPrefix.RuleFilter, otherPrefix.Expiration.Days = "other-prefix", lifecycle.Filter{Prefix: "data/"}, 1
+	tagged := expireDays
+	tagged.ID, tagged.Expiration.Days = "tagged", 2
+	tagged.RuleFilter = lifecycle.Filter{And: lifecycle.And{Prefix: "logs/", Tags: []lifecycle.Tag{{Key: "class", Value: "temp"}}}}
+	small := expireDays
+	small.ID, small.RuleFilter, small.Expiration.Days = "small", lifecycle.Filter{ObjectSizeLessThan: 100}, 1
+	noncurrentExpire := lifecycle.Rule{ID: "noncurrent", Status: StatusEnabled, NoncurrentVersionExpiration: lifecycle.NoncurrentVersionExpiration{NoncurrentDays: 1}}
+	pastDate := lifecycle.Rule{ID: "past-date", Status: StatusEnabled, Expiration: lifecycle.Expiration{Date: lifecycle.ExpirationDate{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}}}
+	delMarker := lifecycle.Rule{ID: "del-marker", Status: StatusEnabled, DelMarkerExpiration: lifecycle.DelMarkerExpiration{Days: 1}}
+
+	testCases := []struct {
+		rules    []lifecycle.Rule
+		obj      ObjectAttrs
+		expected Event
+		found    bool
+	}{
+		{nil, current, Event{}, false},
+		{[]lifecycle.Rule{expireDays}, current, Event{Action: ActionExpire, RuleID: "expire-days", Due: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)}, true},
+		{[]lifecycle.Rule{expireDate, expireDays}, current, Event{Action: ActionExpire, RuleID: "expire-days", Due: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)}, true},
+		{[]lifecycle.Rule{expireDate}, current, Event{Action: ActionExpire, RuleID: "expire-date", Due: date}, true},
+		{[]lifecycle.Rule{transitionDays, expireDate}, current, Event{Action: ActionTransition, RuleID: "transition-days", Due: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), StorageClass: "WARM"}, true},
+		// Expiry wins over a transition due at the same time.
+		{[]lifecycle.Rule{transitionDays, expireDays}, current, Event{Action: ActionExpire, RuleID: "expire-days", Due: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)}, true},
+		// Zero days transitions are overdue.
+		{[]lifecycle.Rule{transitionNow, expireDays}, current, Event{Action: ActionTransition, RuleID: "transition-now", Due: now, StorageClass: "WARM"}, true},
+		{[]lifecycle.Rule{transitionNow}, ObjectAttrs{Name: "logs/a.log", ModTime: modTime, IsLatest: true, Transitioned: true}, Event{}, false},
+		{[]lifecycle.Rule{pastDate, expireDays}, current, Event{Action: ActionExpire, RuleID: "past-date", Due: now}, true},
+		{[]lifecycle.Rule{disabled, otherPrefix, small, expireDays}, current, Event{Action: ActionExpire, RuleID: "expire-days", Due: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)}, true},
+		{[]lifecycle.Rule{expireDays, tagged}, current, Event{Action: ActionExpire, RuleID: "tagged", Due: time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)}, true},
+		{[]lifecycle.Rule{tagged}, ObjectAttrs{Name: "logs/a.log", ModTime: modTime, IsLatest: true}, Event{}, false},
+		// Noncurrent versions age from their successor.
+		{[]lifecycle.Rule{expireDays, noncurrentExpire}, noncurrent, Event{Action: ActionExpireNoncurrent, RuleID: "noncurrent", Due: time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)}, true},
+		{[]lifecycle.Rule{expireDays, delMarker}, ObjectAttrs{Name: "logs/a.log", ModTime: modTime, IsLatest: true, DeleteMarker: true}, Event{Action: ActionExpireDeleteMarker, RuleID: "del-marker", Due: time.Date(2025, 6, 29, 0, 0, 0, 0, time.UTC)}, true},
+	}
+
+	for i, testCase := range testCases {
+		event, found := NextRuleEvent(testCase.rules, testCase.obj, now)
+		if found != testCase.found {
+			t.Fatalf("case %v: found: expected: %v, got: %v", i+1, testCase.found, found)
+		}
+		if event.Action != testCase.expected.Action || event.RuleID != testCase.expected.RuleID ||
+			!event.Due.Equal(testCase.expected.Due) || event.StorageClass != testCase.expected.StorageClass {
+			t.Fatalf("case %v: expected: %+v, got: %+v", i+1, testCase.expected, event)
+		}
+	}
+}
+
+func TestEventHumanize(t *testing.T) {
+	now := time.Date(2025, 6, 28, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		event    Event
+		expected string
+	}{
+		{Event{Action: ActionExpire, Due: time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)}, "expires in 3 days (2025-07-01 12:00 UTC)"},
+		{Event{Action: ActionExpire, Due: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)}, "expires in 2 days (2025-07-01 00:00 UTC)"},
+		{Event{Action: ActionExpireNoncurrent, Due: time.Date(2025, 6, 29, 12, 0, 0, 0, time.UTC)}, "expires in 1 day (2025-06-29 12:00 UTC)"},
+		{Event{Action: ActionTransition, StorageClass: "WARM", Due: time.Date(2025, 6, 29, 0, 0, 0, 0, time.UTC)}, "transitions to WARM in 12 hours (2025-06-29 00:00 UTC)"},
+		{Event{Action: ActionExpireAllVersions, Due: now.Add(90 * time.Second)}, "expires with all versions in 1 minute (2025-06-28 12:01 UTC)"},
+		{Event{Action: ActionExpireDeleteMarker, Due: now.Add(time.Second)}, "expires in less than a minute (2025-06-28 12:00 UTC)"},
+		{Event{Action: ActionExpire, Due: now}, "expires now (2025-06-28 12:00 UTC)"},
+		{Event{Action: ActionExpire, Due: now.Add(-time.Hour)}, "expires now (2025-06-28 11:00 UTC)"},
+	}
+
+	for i, testCase := range testCases {
+		if result := testCase.event.Humanize(now); result != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ldap
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/pkg/v3/env"
+)
+
+// EnvPrefix is the prefix of the LDAP configuration environment variables
+// documented for MinIO, e.g. MINIO_IDENTITY_LDAP_SERVER_ADDR.
+const EnvPrefix = "MINIO_IDENTITY_LDAP"
+
+// Suffixes of the LDAP configuration environment variables.
+const (
+	EnvEnable             = "_ENABLE"
+	EnvServerAddr         = "_SERVER_ADDR"
+	EnvSRVRecordName      = "_SRV_RECORD_NAME"
+	EnvTLSSkipVerify      = "_TLS_SKIP_VERIFY"
+	EnvServerInsecure     = "_SERVER_INSECURE"
+	EnvServerStartTLS     = "_SERVER_STARTTLS"
+	EnvLookupBindDN       = "_LOOKUP_BIND_DN"
+	EnvLookupBindPassword = "_LOOKUP_BIND_PASSWORD"
+	EnvUserDNSearchBaseDN = "_USER_DN_SEARCH_BASE_DN"
+	EnvUserDNSearchFilter = "_USER_DN_SEARCH_FILTER"
+	EnvUserDNAttributes   = "_USER_DN_ATTRIBUTES"
+	EnvGroupSearchBaseDN  = "_GROUP_SEARCH_BASE_DN"
+	EnvGroupSearchFilter  = "_GROUP_SEARCH_FILTER"
+	EnvGroupNameAttribute = "_GROUP_NAME_ATTRIBUTE"
+	EnvGroupNameFormat    = "_GROUP_NAME_FORMAT"
+	EnvSRVRefreshInterval = "_SRV_REFRESH_INTERVAL"
+
+	EnvEnumerationPageSize   = "_ENUMERATION_PAGE_SIZE"
+	EnvEnumerationSearchRate = "_ENUMERATION_SEARCH_RATE"
+)
+
+// redactedLookupBindSecret replaces the lookup bind password in redacted
+// configs.
+const redactedLookupBindSecret = "*REDACTED*"
+
+// ParseConfigFromEnv builds a Config from the LDAP environment variables
+// starting with prefix, EnvPrefix if empty. As everywhere in MinIO, a
+// variable which is set but empty is treated as unset. LDAP is enabled if
+// a server address is set, unless the enable variable is off.
+func ParseConfigFromEnv(prefix string) (Config, error) {
+	if prefix == "" {
+		prefix = EnvPrefix
+	}
+	prefix = strings.TrimSuffix(prefix, "_")
+
+	l := Config{
+		ServerAddr:               env.Get(prefix+EnvServerAddr, ""),
+		SRVRecordName:            env.Get(prefix+EnvSRVRecordName, ""),
+		LookupBindDN:             env.Get(prefix+EnvLookupBindDN, ""),
+		LookupBindPassword:       env.Get(prefix+EnvLookupBindPassword, ""),
+		UserDNSearchBaseDistName: env.Get(prefix+EnvUserDNSearchBaseDN, ""),
+		UserDNSearchFilter:       env.Get(prefix+EnvUserDNSearchFilter, ""),
+		UserDNAttributes:         env.Get(prefix+EnvUserDNAttributes, ""),
+		GroupSearchBaseDistName:  env.Get(prefix+EnvGroupSearchBaseDN, ""),
+		GroupSearchFilter:        env.Get(prefix+EnvGroupSearchFilter, ""),
+		GroupNameAttribute:       env.Get(prefix+EnvGroupNameAttribute, ""),
+		GroupNameFormat:          env.Get(prefix+EnvGroupNameFormat, ""),
+	}
+
+	enabled, err := parseBoolEnv(prefix+EnvEnable, true)
+	if err != nil {
+		return Config{}, err
+	}
+	l.Enabled = enabled && l.ServerAddr != ""
+
+	if l.ServerInsecure, err = parseBoolEnv(prefix+EnvServerInsecure, false); err != nil {
+		return Config{}, err
+	}
+	if l.ServerStartTLS, err = parseBoolEnv(prefix+EnvServerStartTLS, false); err != nil {
+		return Config{}, err
+	}
+	tlsSkipVerify, err := parseBoolEnv(prefix+EnvTLSSkipVerify, false)
+	if err != nil {
+		return Config{}, err
+	}
+	l.TLS = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: tlsSkipVerify,
+	}
+
+	if l.SRVRefreshInterval, err = env.GetDuration(prefix+EnvSRVRefreshInterval, 0); err != nil {
+		return Config{}, fmt.Errorf("Invalid value for %s: %w", prefix+EnvSRVRefreshInterval, err)
+	}
+
+	if v := env.Get(prefix+EnvEnumerationPageSize, ""); v != "" {
+		pageSize, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return Config{}, fmt.Errorf("Invalid value for %s: %w", prefix+EnvEnumerationPageSize, err)
+		}
+		l.EnumerationPageSize = uint32(pageSize)
+	}
+	if v := env.Get(prefix+EnvEnumerationSearchRate, ""); v != "" {
+		if l.EnumerationSearchRate, err = parseSearchRate(v); err != nil {
+			return Config{}, fmt.Errorf("Invalid value for %s: %w", prefix+EnvEnumerationSearchRate, err)
+		}
+	}
+	return l, nil
+}
+
+// parseSearchRate parses an enumeration search rate, a non-negative
+// number of searches per second.
+func parseSearchRate(v string) (float64, error) {
+	rate, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, err
+	}
+	if rate < 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+		return 0, fmt.Errorf("'%s' is not a non-negative number", v)
+	}
+	return rate, nil
+}
+
+// parseBoolEnv parses the boolean environment variable key, accepting
+// "on" and "off" besides the values of strconv.ParseBool.
+func parseBoolEnv(key string, defaultValue bool) (bool, error) {
+	switch v := strings.ToLower(env.Get(key, "")); v {
+	case "":
+		return defaultValue, nil
+	case "on":
+		return true, nil
+	case "off":
+		return false, nil
+	default:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return false, fmt.Errorf("Invalid value for %s: '%s' is not a boolean", key, v)
+		}
+		return b, nil
+	}
+}
+
+// Redacted returns a copy of the config with the lookup bind password
+// masked, also wherever it was mistakenly copied into another setting.
+func (l *Config) Redacted() Config {
+	cloned := l.Clone()
+	secret := cloned.LookupBindPassword
+	if secret == "" {
+		return cloned
+	}
+
+	for _, field := range []*string{
+		&cloned.ServerAddr,
+		&cloned.SRVRecordName,
+		&cloned.LookupBindDN,
+		&cloned.UserDNSearchBaseDistName,
+		&cloned.UserDNSearchFilter,
+		&cloned.UserDNAttributes,
+		&cloned.GroupSearchBaseDistName,
+		&cloned.GroupSearchFilter,
+		&cloned.GroupNameAttribute,
+	} {
+		*field = strings.ReplaceAll(*field, secret, redactedLookupBindSecret)
+	}
+	cloned.LookupBindPassword = redactedLookupBindSecret
+	return cloned
+}
+
+// configJSON is the JSON representation of Config.
+type configJSON struct {
+	Enabled            bool   `json:"enabled"`
+	ServerAddr         string `json:"serverAddr,omitempty"`
+	SRVRecordName      string `json:"srvRecordName,omitempty"`
+	SRVRefreshInterval string `json:"srvRefreshInterval,omitempty"`
+	ServerInsecure     bool   `json:"serverInsecure,omitempty"`
+	ServerStartTLS     bool   `json:"serverStartTLS,omitempty"`
+	TLSSkipVerify      bool   `json:"tlsSkipVerify,omitempty"`
+	LookupBindDN       string `json:"lookupBindDN,omitempty"`
+	LookupBindPassword string `json:"lookupBindPassword,omitempty"`
+	UserDNSearchBaseDN string `json:"userDNSearchBaseDN,omitempty"`
+	UserDNSearchFilter string `json:"userDNSearchFilter,omitempty"`
+	UserDNAttributes   string `json:"userDNAttributes,omitempty"`
+	GroupSearchBaseDN  string `json:"groupSearchBaseDN,omitempty"`
+	GroupSearchFilter  string `json:"groupSearchFilter,omitempty"`
+	GroupNameAttribute string `json:"groupNameAttribute,omitempty"`
+	GroupNameFormat    string `json:"groupNameFormat,omitempty"`
+
+	EnumerationPageSize   uint32  `json:"enumerationPageSize,omitempty"`
+	EnumerationSearchRate float64 `json:"enumerationSearchRate,omitempty"`
+}
+
+// MarshalJSON encodes the config with the lookup bind password redacted,
+// use MarshalWithSecrets to include it. The TLS client config and the SRV
+// resolver are not encoded, apart from whether TLS verification is
+// skipped.
+func (l Config) MarshalJSON() ([]byte, error) {
+	redacted := l.Redacted()
+	return json.Marshal(redacted.toJSON())
+}
+
+// MarshalWithSecrets encodes the config including the lookup bind password.
+func (l Config) MarshalWithSecrets() ([]byte, error) {
+	return json.Marshal(l.toJSON())
+}
+
+func (l *Config) toJSON() configJSON {
+	c := configJSON{
+		Enabled:            l.Enabled,
+		ServerAddr:         l.ServerAddr,
+		SRVRecordName:      l.SRVRecordName,
+		ServerInsecure:     l.ServerInsecure,
+		ServerStartTLS:     l.ServerStartTLS,
+		TLSSkipVerify:      l.TLS != nil && l.TLS.InsecureSkipVerify,
+		LookupBindDN:       l.LookupBindDN,
+		LookupBindPassword: l.LookupBindPassword,
+		UserDNSearchBaseDN: l.UserDNSearchBaseDistName,
+		UserDNSearchFilter: l.UserDNSearchFilter,
+		UserDNAttributes:   l.UserDNAttributes,
+		GroupSearchBaseDN:  l.GroupSearchBaseDistName,
+		GroupSearchFilter:  l.GroupSearchFilter,
+		GroupNameAttribute: l.GroupNameAttribute,
+		GroupNameFormat:    l.GroupNameFormat,
+
+		EnumerationPageSize:   l.EnumerationPageSize,
+		EnumerationSearchRate: l.EnumerationSearchRate,
+	}
+	if l.SRVRefreshInterval > 0 {
+		c.SRVRefreshInterval = l.SRVRefreshInterval.String()
+	}
+	return c
+}
+
+// UnmarshalJSON decodes a config encoded by MarshalWithSecrets. A redacted
+// lookup bind password, as encoded by MarshalJSON, is rejected.
+func (l *Config) UnmarshalJSON(data []byte) error {
+	var c configJSON
+	if err := json.Unmarshal(data, &c); err != nil {
+		return err
+	}
+	if c.LookupBindPassword == redactedLookupBindSecret {
+		return errors.New("LDAP lookup bind password is redacted")
+	}
+
+	*l = Config{
+		Enabled:                  c.Enabled,
+		ServerAddr:               c.ServerAddr,
+		SRVRecordName:            c.SRVRecordName,
+		ServerInsecure:           c.ServerInsecure,
+		ServerStartTLS:           c.ServerStartTLS,
+		LookupBindDN:             c.LookupBindDN,
+		LookupBindPassword:       c.LookupBindPassword,
+		UserDNSearchBaseDistName: c.UserDNSearchBaseDN,
+		UserDNSearchFilter:       c.UserDNSearchFilter,
+		UserDNAttributes:         c.UserDNAttributes,
+		GroupSearchBaseDistName:  c.GroupSearchBaseDN,
+		GroupSearchFilter:        c.GroupSearchFilter,
+		GroupNameAttribute:       c.GroupNameAttribute,
+		GroupNameFormat:          c.GroupNameFormat,
+		EnumerationPageSize:      c.EnumerationPageSize,
+		EnumerationSearchRate:    c.EnumerationSearchRate,
+		TLS: &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: c.TLSSkipVerify,
+		},
+	}
+	if c.SRVRefreshInterval != "" {
+		d, err := time.ParseDuration(c.SRVRefreshInterval)
+		if err != nil {
+			return fmt.Errorf("Invalid SRV refresh interval: %w", err)
+		}
+		l.SRVRefreshInterval = d
+	}
+	if c.EnumerationSearchRate < 0 {
+		return fmt.Errorf("Invalid enumeration search rate: %v is negative", c.EnumerationSearchRate)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ldap
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseConfigFromEnv(t *testing.T) {
+	t.Setenv("MINIO_IDENTITY_LDAP_SERVER_ADDR", "ldap.example.com:636")
+	t.Setenv("MINIO_IDENTITY_LDAP_SRV_RECORD_NAME", "ldaps")
+	t.Setenv("MINIO_IDENTITY_LDAP_TLS_SKIP_VERIFY", "on")
+	t.Setenv("MINIO_IDENTITY_LDAP_SERVER_INSECURE", "true")
+	t.Setenv("MINIO_IDENTITY_LDAP_SERVER_STARTTLS", "off")
+	t.Setenv("MINIO_IDENTITY_LDAP_LOOKUP_BIND_DN", "cn=admin,dc=example,dc=com")
+	t.Setenv("MINIO_IDENTITY_LDAP_LOOKUP_BIND_PASSWORD", "s3cr3t")
+	t.Setenv("MINIO_IDENTITY_LDAP_USER_DN_SEARCH_BASE_DN", "ou=people,dc=example,dc=com")
+	t.Setenv("MINIO_IDENTITY_LDAP_USER_DN_SEARCH_FILTER", "(uid=%s)")
+	t.Setenv("MINIO_IDENTITY_LDAP_USER_DN_ATTRIBUTES", "mail,cn")
+	t.Setenv("MINIO_IDENTITY_LDAP_GROUP_SEARCH_BASE_DN", "ou=groups,dc=example,dc=com")
+	t.Setenv("MINIO_IDENTITY_LDAP_GROUP_SEARCH_FILTER", "(member=%d)")
+	t.Setenv("MINIO_IDENTITY_LDAP_SRV_REFRESH_INTERVAL", "1m")
+	t.Setenv("MINIO_IDENTITY_LDAP_ENUMERATION_PAGE_SIZE", "100")
+	t.Setenv("MINIO_IDENTITY_LDAP_ENUMERATION_SEARCH_RATE", "2.5")
+	// Set but empty is unset.
+	t.Setenv("MINIO_IDENTITY_LDAP_ENABLE", "")
+
+	l, err := ParseConfigFromEnv("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		env      string
+		got      interface{}
+		expected interface{}
+	}{
+		{"ENABLE", l.Enabled, true},
+		{"SERVER_ADDR", l.ServerAddr, "ldap.example.com:636"},
+		{"SRV_RECORD_NAME", l.SRVRecordName, "ldaps"},
+		{"TLS_SKIP_VERIFY", l.TLS.InsecureSkipVerify, true},
+		{"SERVER_INSECURE", l.ServerInsecure, true},
+		{"SERVER_STARTTLS", l.ServerStartTLS, false},
+		{"LOOKUP_BIND_DN", l.LookupBindDN, "cn=admin,dc=example,dc=com"},
+		{"LOOKUP_BIND_PASSWORD", l.LookupBindPassword, "s3cr3t"},
+		{"USER_DN_SEARCH_BASE_DN", l.UserDNSearchBaseDistName, "ou=people,dc=example,dc=com"},
+		{"USER_DN_SEARCH_FILTER", l.UserDNSearchFilter, "(uid=%s)"},
+		{"USER_DN_ATTRIBUTES", l.UserDNAttributes, "mail,cn"},
+		{"GROUP_SEARCH_BASE_DN", l.GroupSearchBaseDistName, "ou=groups,dc=example,dc=com"},
+		{"GROUP_SEARCH_FILTER", l.GroupSearchFilter, "(member=%d)"},
+		{"SRV_REFRESH_INTERVAL", l.SRVRefreshInterval, time.Minute},
+		{"ENUMERATION_PAGE_SIZE", l.EnumerationPageSize, uint32(100)},
+		{"ENUMERATION_SEARCH_RATE", l.EnumerationSearchRate, 2.5},
+	}
+	for _, testCase := range testCases {
+		if testCase.got != testCase.expected {
+			t.Errorf("MINIO_IDENTITY_LDAP_%s: expected %v, got %v", testCase.env, testCase.expected, testCase.got)
+		}
+	}
+
+	t.Setenv("MINIO_IDENTITY_LDAP_ENABLE", "off")
+	if l, err = ParseConfigFromEnv(EnvPrefix); err != nil || l.Enabled {
+		t.Fatalf("Expected LDAP to be disabled, got %v (err: %v)", l.Enabled, err)
+	}
+
+	t.Setenv("MINIO_IDENTITY_LDAP_ENABLE", "")
+	t.Setenv("MINIO_IDENTITY_LDAP_SERVER_ADDR", "")
+	if l, err = ParseConfigFromEnv(EnvPrefix); err != nil || l.Enabled {
+		t.Fatalf("Expected LDAP without server address to be disabled, got %v (err: %v)", l.Enabled, err)
+	}
+
+	t.Setenv("MINIO_IDENTITY_LDAP_SERVER_INSECURE", "maybe")
+	if _, err = ParseConfigFromEnv(EnvPrefix); err == nil {
+		t.Fatal("Expected invalid boolean to fail")
+	}
+	t.Setenv("MINIO_IDENTITY_LDAP_SERVER_INSECURE", "")
+
+	for key, value := range map[string]string{
+		"MINIO_IDENTITY_LDAP_ENUMERATION_PAGE_SIZE":   "-1",
+		"MINIO_IDENTITY_LDAP_ENUMERATION_SEARCH_RATE": "-2",
+	} {
+		t.Setenv(key, value)
+		if _, err = ParseConfigFromEnv(EnvPrefix); err == nil {
+			t.Fatalf("Expected invalid %s to fail", key)
+		}
+		t.Setenv(key, "")
+	}
+}
+
+func TestConfigJSON(t *testing.T) {
+	l := Config{
+		Enabled:               true,
+		ServerAddr:            "ldap.example.com:636",
+		SRVRefreshInterval:    time.Minute,
+		LookupBindDN:          "cn=admin,dc=example,dc=com",
+		LookupBindPassword:    "s3cr3t",
+		UserDNSearchFilter:    "(uid=%s)",
+		GroupNameFormat:       GroupNameFormatDN,
+		EnumerationPageSize:   100,
+		EnumerationSearchRate: 2.5,
+	}
+
+	data, err := l.MarshalWithSecrets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Config
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	decoded.TLS = nil
+	if !reflect.DeepEqual(decoded, l) {
+		t.Fatalf("Expected %#v, got %#v", l, decoded)
+	}
+
+	if err = json.Unmarshal([]byte(`{"enabled": true, "enumerationSearchRate": -1}`), &decoded); err == nil {
+		t.Fatal("Expected negative search rate to fail")
+	}
+}
+
+func TestConfigRedacted(t *testing.T) {
+	const secret = "s3cr3t"
+	l := Config{
+		Enabled: true,
+		// Password pasted into the address by mistake.
+		ServerAddr:         "ldap://admin:" + secret + "@ldap.example.com:636",
+		LookupBindDN:       "cn=admin,dc=example,dc=com",
+		LookupBindPassword: secret,
+		UserDNSearchFilter: "(uid=%s)",
+	}
+
+	redacted := l.Redacted()
+	if l.LookupBindPassword != secret {
+		t.Fatal("Redacted must not modify the config")
+	}
+	if redacted.LookupBindPassword == secret || strings.Contains(redacted.ServerAddr, secret) {
+		t.Fatalf("Expected secret to be redacted, got %#v", redacted)
+	}
+
+	for _, v := range []interface{}{l, &l} {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), secret) {
+			t.Fatalf("Expected secret to be redacted, got %s", data)
+		}
+
+		var decoded Config
+		if err = json.Unmarshal(data, &decoded); err == nil {
+			t.Fatal("Expected redacted config to fail to decode")
+		}
+	}
+
+	data, err := l.MarshalWithSecrets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Config
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.LookupBindPassword != secret || decoded.ServerAddr != l.ServerAddr {
+		t.Fatalf("Expected %#v, got %#v", l, decoded)
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ldap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	ldap "github.com/go-ldap/ldap/v3"
+)
+
+// DefaultEnumerationPageSize is the number of entries requested per search
+// by EnumerateUsers and EnumerateGroups if Config.EnumerationPageSize is
+// zero.
+const DefaultEnumerationPageSize = 500
+
+// Searcher performs LDAP searches, such as *ldap.Conn.
+type Searcher interface {
+	Search(searchRequest *ldap.SearchRequest) (*ldap.SearchResult, error)
+}
+
+// UserEntry is a user found by EnumerateUsers. The attributes are those of
+// UserDNAttributes.
+type UserEntry struct {
+	// Normalized DN of the user.
+	NormDN string
+	// Actual DN of the user.
+	ActualDN string
+
+	// Attributes of the user.
+	Attributes map[string][]string
+}
+
+// GroupEntry is a group found by EnumerateGroups.
+type GroupEntry struct {
+	// Normalized DN of the group.
+	NormDN string
+	// Actual DN of the group.
+	ActualDN string
+
+	// Names are the values of GroupNameAttribute in group name mode.
+	Names []string
+}
+
+// EnumerateUsers calls fn for each user under the user DN search base DNs
+// matching the user DN search filter, with "*" as username, and the LDAP
+// filter extraFilter if not empty. conn is assumed to be using the lookup
+// bind service account.
+//
+// Results are requested in pages of EnumerationPageSize entries, at most
+// EnumerationSearchRate pages per second, so that only one page is held in
+// memory. Users under several overlapping base DNs are reported once. The
+// enumeration stops at the first error returned by fn, which is returned,
+// or when ctx is canceled.
+func (l *Config) EnumerateUsers(ctx context.Context, conn Searcher, extraFilter string, fn func(UserEntry) error) error {
+	if l.UserDNSearchFilter == "" {
+		return errors.New("User DN search filter is not configured")
+	}
+	filter, err := enumerationFilter(l.UserDNSearchFilter, extraFilter)
+	if err != nil {
+		return err
+	}
+	attrs := noAttrsSpec
+	if len(l.userDNAttributesList) > 0 {
+		attrs = l.userDNAttributesList
+	}
+
+	return l.enumerate(ctx, conn, l.userDNSearchBaseDistNames, filter, attrs, func(entry *ldap.Entry, normDN string) error {
+		attrs := make(map[string][]string, len(entry.Attributes))
+		for _, attr := range entry.Attributes {
+			attrs[attr.Name] = attr.Values
+		}
+		return fn(UserEntry{NormDN: normDN, ActualDN: entry.DN, Attributes: attrs})
+	})
+}
+
+// EnumerateGroups calls fn for each group under the group search base DNs
+// matching the group search filter, with "*" as username and user DN, and
+// the LDAP filter extraFilter if not empty. For instance, groups matching
+// "(&(objectclass=groupOfNames)(member=%d))" are groups with at least one
+// member. conn is assumed to be using the lookup bind service account.
+//
+// Results are paged and deduplicated as by EnumerateUsers.
+func (l *Config) EnumerateGroups(ctx context.Context, conn Searcher, extraFilter string, fn func(GroupEntry) error) error {
+	if l.GroupSearchFilter == "" {
+		return errors.New("Group search filter is not configured")
+	}
+	filter, err := enumerationFilter(strings.ReplaceAll(l.GroupSearchFilter, "%d", "*"), extraFilter)
+	if err != nil {
+		return err
+	}
+	attrs := noAttrsSpec
+	nameMode := l.groupNameMode()
+	if nameMode {
+		attrs = []string{strings.TrimSpace(l.GroupNameAttribute)}
+	}
+
+	return l.enumerate(ctx, conn, l.groupSearchBaseDistNames, filter, attrs, func(entry *ldap.Entry, normDN string) error {
+		group := GroupEntry{NormDN: normDN, ActualDN: entry.DN}
+		if nameMode {
+			group.Names = dedupGroupNames(entry.GetEqualFoldAttributeValues(attrs[0]))
+		}
+		return fn(group)
+	})
+}
+
+// enumerationFilter returns the search filter matching all entries of
+// filter, a user DN or group search filter, and extraFilter.
+func enumerationFilter(filter, extraFilter string) (string, error) {
+	filter = strings.ReplaceAll(filter, "%s", "*")
+	if extraFilter != "" {
+		if err := compileFilter(extraFilter); err != nil {
+			return "", fmt.Errorf("Invalid extra filter %s: %w", extraFilter, err)
+		}
+		filter = "(&" + filter + extraFilter + ")"
+	}
+	return filter, nil
+}
+
+// enumerate calls fn for each entry matching filter under the base DNs,
+// page by page. Entries under several base DNs are reported for the first
+// one only.
+func (l *Config) enumerate(ctx context.Context, conn Searcher, baseDNs []BaseDNInfo, filter string, attrs []string, fn func(entry *ldap.Entry, normDN string) error) error {
+	pageSize := l.EnumerationPageSize
+	if pageSize == 0 {
+		pageSize = DefaultEnumerationPageSize
+	}
+	var interval time.Duration
+	if l.EnumerationSearchRate > 0 {
+		interval = time.Duration(float64(time.Second) / l.EnumerationSearchRate)
+	}
+	var lastSearch time.Time
+
+	for i, baseDN := range baseDNs {
+		paging := ldap.NewControlPaging(pageSize)
+		for {
+			if interval > 0 && !lastSearch.IsZero() {
+				if err := sleepContext(ctx, time.Until(lastSearch.Add(interval))); err != nil {
+					abandonPaging(conn, baseDN, filter, paging)
+					return err
+				}
+			}
+			if err := ctx.Err(); err != nil {
+				abandonPaging(conn, baseDN, filter, paging)
+				return err
+			}
+
+			searchRequest := ldap.NewSearchRequest(
+				baseDN.ServerDN,
+				ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+				filter,
+				attrs,
+				[]ldap.Control{paging},
+			)
+			lastSearch = time.Now()
+			searchResult, err := conn.Search(searchRequest)
+			if err != nil {
+				// For a search, if the base DN does not exist, we get a 32 error code.
+				// Ref: https://ldap.com/ldap-result-code-reference/
+				if ldap.IsErrorWithCode(err, 32) {
+					return fmt.Errorf("Base DN (%s) for enumeration does not exist: %w", baseDN.ServerDN, err)
+				}
+				return fmt.Errorf("LDAP client: %w", err)
+			}
+
+			// The cookie of the next page, empty after the last page.
+			var cookie []byte
+			if response, ok := ldap.FindControl(searchResult.Controls, ldap.ControlTypePaging).(*ldap.ControlPaging); ok {
+				cookie = response.Cookie
+			}
+			paging.SetCookie(cookie)
+
+			for _, entry := range searchResult.Entries {
+				parsedDN, err := ldap.ParseDN(entry.DN)
+				if err != nil {
+					abandonPaging(conn, baseDN, filter, paging)
+					return fmt.Errorf("DN (%s) parse failure: %w", entry.DN, err)
+				}
+				if underAny(baseDNs[:i], parsedDN) {
+					// Already reported under a previous base DN.
+					continue
+				}
+				if err := fn(entry, parsedDN.String()); err != nil {
+					abandonPaging(conn, baseDN, filter, paging)
+					return err
+				}
+			}
+			if len(cookie) == 0 {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// underAny returns whether dn is one of the base DNs or under one of them.
+func underAny(baseDNs []BaseDNInfo, dn *ldap.DN) bool {
+	for _, baseDN := range baseDNs {
+		if baseDN.Parsed != nil && (baseDN.Parsed.EqualFold(dn) || baseDN.Parsed.AncestorOfFold(dn)) {
+			return true
+		}
+	}
+	return false
+}
+
+// abandonPaging releases the server resources of a paged search which is
+// not read to the end, by requesting a page of size zero. Errors are
+// ignored.
+func abandonPaging(conn Searcher, baseDN BaseDNInfo, filter string, paging *ldap.ControlPaging) {
+	if len(paging.Cookie) == 0 {
+		return
+	}
+	abandon := ldap.NewControlPaging(0)
+	abandon.SetCookie(paging.Cookie)
+	conn.Search(ldap.NewSearchRequest(
+		baseDN.ServerDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter,
+		noAttrsSpec,
+		[]ldap.Control{abandon},
+	))
+}
+
+// sleepContext waits for d or until ctx is canceled.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ldap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	ldap "github.com/go-ldap/ldap/v3"
+)
+
+// fakeDirectory is a Searcher paging through its entries. Filters are not
+// evaluated, except for "(department=...)".
+type fakeDirectory struct {
+	entries  []*ldap.Entry
+	filters  []string
+	searches int
+	abandons int
+}
+
+func (d *fakeDirectory) Search(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	paging, ok := ldap.FindControl(req.Controls, ldap.ControlTypePaging).(*ldap.ControlPaging)
+	if !ok {
+		return nil, errors.New("expected a paged search")
+	}
+	if paging.PagingSize == 0 {
+		d.abandons++
+		return &ldap.SearchResult{}, nil
+	}
+	d.searches++
+	d.filters = append(d.filters, req.Filter)
+
+	base, err := ldap.ParseDN(req.BaseDN)
+	if err != nil {
+		return nil, err
+	}
+	var department string
+	if _, after, ok := strings.Cut(req.Filter, "(department="); ok {
+		department, _, _ = strings.Cut(after, ")")
+	}
+	var matching []*ldap.Entry
+	for _, entry := range d.entries {
+		dn, _ := ldap.ParseDN(entry.DN)
+		if !base.AncestorOfFold(dn) {
+			continue
+		}
+		if department != "" && entry.GetAttributeValue("department") != department {
+			continue
+		}
+		matching = append(matching, entry)
+	}
+
+	offset := 0
+	if len(paging.Cookie) > 0 {
+		offset, _ = strconv.Atoi(string(paging.Cookie))
+	}
+	end := min(offset+int(paging.PagingSize), len(matching))
+	response := ldap.NewControlPaging(paging.PagingSize)
+	if end < len(matching) {
+		response.SetCookie([]byte(strconv.Itoa(end)))
+	}
+	return &ldap.SearchResult{Entries: matching[offset:end], Controls: []ldap.Control{response}}, nil
+}
+
+// newFakeDirectory returns a directory of users under ou=people and
+// ou=contractors, every third user being in the eng department, and of
+// groups under ou=groups.
+func newFakeDirectory(people, contractors, groups int) *fakeDirectory {
+	d := &fakeDirectory{}
+	add := func(n int, format string, attrs func(i int) map[string][]string) {
+		for i := 0; i < n; i++ {
+			d.entries = append(d.entries, ldap.NewEntry(fmt.Sprintf(format, i), attrs(i)))
+		}
+	}
+	user := func(i int) map[string][]string {
+		department := "sales"
+		if i%3 == 0 {
+			department = "eng"
+		}
+		return map[string][]string{"mail": {fmt.Sprintf("user%d@min.io", i)}, "department": {department}}
+	}
+	add(people, "uid=user%d,ou=people,dc=min,dc=io", user)
+	add(contractors, "uid=contractor%d,ou=contractors,dc=min,dc=io", user)
+	add(groups, "cn=group%d,ou=groups,dc=min,dc=io", func(i int) map[string][]string {
+		return map[string][]string{"cn": {fmt.Sprintf("group%d", i)}}
+	})
+	return d
+}
+
+func mustBaseDNs(t *testing.T, dns ...string) []BaseDNInfo {
+	t.Helper()
+	var res []BaseDNInfo
+	for _, dn := range dns {
+		parsed, err := ldap.ParseDN(dn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res = append(res, BaseDNInfo{Original: dn, ServerDN: dn, Parsed: parsed})
+	}
+	return res
+}
+
+func TestEnumerateUsers(t *testing.T) {
+	testCases := []struct {
+		baseDNs          []string
+		extraFilter      string
+		expectedUsers    int
+		expectedSearches int
+		expectedFilter   string
+	}{
+		{[]string{"ou=people,dc=min,dc=io", "ou=contractors,dc=min,dc=io"}, "", 3000, 6, "(uid=*)"},
+		{[]string{"ou=people,dc=min,dc=io", "ou=contractors,dc=min,dc=io"}, "(department=eng)", 1001, 3, "(&(uid=*)(department=eng))"},
+		// Overlapping base DNs report users once.
+		{[]string{"ou=people,dc=min,dc=io", "dc=min,dc=io"}, "", 3000, 10, "(uid=*)"},
+		{[]string{"ou=nobody,dc=min,dc=io"}, "", 0, 1, "(uid=*)"},
+	}
+
+	for i, testCase := range testCases {
+		d := newFakeDirectory(2000, 1000, 0)
+		l := Config{
+			UserDNSearchFilter:        "(uid=%s)",
+			userDNSearchBaseDistNames: mustBaseDNs(t, testCase.baseDNs...),
+			userDNAttributesList:      []string{"mail"},
+		}
+
+		seen := map[string]bool{}
+		err := l.EnumerateUsers(context.Background(), d, testCase.extraFilter, func(user UserEntry) error {
+			if seen[user.NormDN] {
+				return fmt.Errorf("%s reported twice", user.NormDN)
+			}
+			seen[user.NormDN] = true
+			if len(user.Attributes["mail"]) != 1 {
+				return fmt.Errorf("%s: unexpected attributes: %v", user.NormDN, user.Attributes)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if len(seen) != testCase.expectedUsers {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedUsers, len(seen))
+		}
+		if d.searches != testCase.expectedSearches || d.abandons != 0 {
+			t.Fatalf("case %v: expected: %v searches, got: %v, %v abandoned", i+1, testCase.expectedSearches, d.searches, d.abandons)
+		}
+		if d.filters[0] != testCase.expectedFilter {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedFilter, d.filters[0])
+		}
+	}
+}
+
+func TestEnumerateUsersStop(t *testing.T) {
+	l := Config{
+		UserDNSearchFilter:        "(uid=%s)",
+		userDNSearchBaseDistNames: mustBaseDNs(t, "ou=people,dc=min,dc=io", "ou=contractors,dc=min,dc=io"),
+	}
+
+	// fn errors stop the enumeration and abandon the paged search.
+	d := newFakeDirectory(2000, 1000, 0)
+	errStop := errors.New("stop")
+	var n int
+	err := l.EnumerateUsers(context.Background(), d, "", func(UserEntry) error {
+		if n++; n == 700 {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) || d.searches != 2 || d.abandons != 1 {
+		t.Fatalf("expected: %v after 2 searches, got: %v after %v, %v abandoned", errStop, err, d.searches, d.abandons)
+	}
+
+	// Canceling ctx stops the enumeration before the next page.
+	d = newFakeDirectory(2000, 1000, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	err = l.EnumerateUsers(ctx, d, "", func(UserEntry) error {
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || d.searches != 1 || d.abandons != 1 {
+		t.Fatalf("expected: %v after 1 search, got: %v after %v, %v abandoned", context.Canceled, err, d.searches, d.abandons)
+	}
+
+	// Invalid extra filters are rejected.
+	if err = l.EnumerateUsers(context.Background(), d, "(department=eng", func(UserEntry) error { return nil }); err == nil {
+		t.Fatalf("expected an invalid filter error")
+	}
+}
+
+func TestEnumerateRateLimit(t *testing.T) {
+	l := Config{
+		UserDNSearchFilter:        "(uid=%s)",
+		userDNSearchBaseDistNames: mustBaseDNs(t, "ou=people,dc=min,dc=io", "ou=contractors,dc=min,dc=io"),
+		EnumerationPageSize:       100,
+		EnumerationSearchRate:     200,
+	}
+	d := newFakeDirectory(500, 500, 0)
+
+	start := time.Now()
+	if err := l.EnumerateUsers(context.Background(), d, "", func(UserEntry) error { return nil }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 10 searches, 5ms apart.
+	if elapsed := time.Since(start); d.searches != 10 || elapsed < 45*time.Millisecond {
+		t.Fatalf("expected: 10 searches in at least 45ms, got: %v in %v", d.searches, elapsed)
+	}
+}
+
+func TestEnumerateGroups(t *testing.T) {
+	testCases := []struct {
+		format   string
+		expected GroupEntry
+	}{
+		{"", GroupEntry{NormDN: "cn=group7,ou=groups,dc=min,dc=io", ActualDN: "cn=group7,ou=groups,dc=min,dc=io"}},
+		{GroupNameFormatName, GroupEntry{NormDN: "cn=group7,ou=groups,dc=min,dc=io", ActualDN: "cn=group7,ou=groups,dc=min,dc=io", Names: []string{"group7"}}},
+	}
+
+	for i, testCase := range testCases {
+		d := newFakeDirectory(10, 0, 1200)
+		l := Config{
+			GroupSearchFilter:        "(&(objectclass=groupOfNames)(member=%d))",
+			groupSearchBaseDistNames: mustBaseDNs(t, "ou=groups,dc=min,dc=io"),
+			GroupNameFormat:          testCase.format,
+			GroupNameAttribute:       "cn",
+		}
+		var groups []GroupEntry
+		err := l.EnumerateGroups(context.Background(), d, "", func(group GroupEntry) error {
+			groups = append(groups, group)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if len(groups) != 1200 || d.searches != 3 {
+			t.Fatalf("case %v: expected: 1200 groups in 3 searches, got: %v in %v", i+1, len(groups), d.searches)
+		}
+		if !reflect.DeepEqual(groups[7], testCase.expected) {
+			t.Fatalf("case %v: expected: %+v, got: %+v", i+1, testCase.expected, groups[7])
+		}
+		if expected := "(&(objectclass=groupOfNames)(member=*))"; d.filters[0] != expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, expected, d.filters[0])
+		}
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"slices"
 	"strings"
 	"time"
 
@@ -62,6 +63,15 @@ type Config struct {
 	ServerStartTLS bool        // allows using StartTLS connection to LDAP server
 	TLS            *tls.Config // TLS client config
 
+	// SRV records are cached for the TTL reported by SRVResolver, or
+	// SRVRefreshInterval (DefaultSRVRefreshInterval if zero) when the
+	// resolver does not report one, per resolver and record name.
+	// SRVResolver defaults to the system DNS resolver, lookups of
+	// resolvers which are not comparable, e.g. not pointers, are not
+	// cached.
+	SRVRefreshInterval time.Duration
+	SRVResolver        SRVResolver
+
 	// Lookup bind LDAP service account
 	LookupBindDN       string
 	LookupBindPassword string
@@ -82,6 +92,30 @@ type Config struct {
 	// this is a computed value from GroupSearchBaseDistName
 	groupSearchBaseDistNames []BaseDNInfo
 	GroupSearchFilter        string
+
+	// Group name mode: when GroupNameFormat is GroupNameFormatName, group
+	// searches return the values of GroupNameAttribute of each group, e.g.
+	// "cn" or "msDS-PrincipalName", instead of the group DN. Defaults to
+	// GroupNameFormatDN.
+	GroupNameAttribute string
+	GroupNameFormat    string
+
+	// Enumeration parameters of EnumerateUsers and EnumerateGroups: the
+	// number of entries per search, DefaultEnumerationPageSize if zero,
+	// and the maximum number of searches per second, unlimited if zero.
+	EnumerationPageSize   uint32
+	EnumerationSearchRate float64
+}
+
+// Group name formats of Config.GroupNameFormat.
+const (
+	GroupNameFormatDN   = "dn"
+	GroupNameFormatName = "name"
+)
+
+// groupNameMode returns whether groups are reported by name rather than DN.
+func (l *Config) groupNameMode() bool {
+	return strings.EqualFold(strings.TrimSpace(l.GroupNameFormat), GroupNameFormatName)
 }
 
 // Clone creates a copy of the config.
@@ -111,26 +145,26 @@ func (l *Config) connect(ldapAddr string) (ldapConn *ldap.Conn, err error) {
 	return ldapConn, err
 }
 
-// Connect connect to ldap server.
+// Connect connect to ldap server. TLS errors are wrapped, so that their
+// cause can be diagnosed, except for StartTLS handshake errors.
 func (l *Config) Connect() (ldapConn *ldap.Conn, err error) {
+	return l.connectWith(l.connect)
+}
+
+// connectWith connects to the configured server, or to the servers
+// discovered through the SRV record, using connect.
+func (l *Config) connectWith(connect func(ldapAddr string) (*ldap.Conn, error)) (ldapConn *ldap.Conn, err error) {
 	if l == nil || !l.Enabled {
 		return nil, errors.New("LDAP is not configured")
 	}
 
-	var srvService, srvProto, srvName string
 	switch l.SRVRecordName {
-	case "on":
-		srvName = l.ServerAddr
-	case "ldap", "ldaps":
-		srvService = l.SRVRecordName
-		srvProto = "tcp"
-		srvName = l.ServerAddr
-	case "":
+	case "", "on", "ldap", "ldaps":
 	default:
 		return nil, errors.New("Invalid SRV Record Name parameter")
-
 	}
 
+	srvService, srvProto, srvName, _ := l.srvQuery()
 	if srvName == "" {
 		// No SRV Record lookup case.
 		ldapAddr := l.ServerAddr
@@ -145,37 +179,67 @@ func (l *Config) Connect() (ldapConn *ldap.Conn, err error) {
 			}
 		}
 
-		return l.connect(ldapAddr)
+		return connect(ldapAddr)
 	}
 
 	// SRV Record lookup is enabled.
-	_, addrs, err := net.LookupSRV(srvService, srvProto, srvName)
+	return l.connectSRV(srvService, srvProto, srvName, connect)
+}
+
+// connectSRV connects to the servers discovered through the SRV record. If
+// none of the cached servers could be connected to, the SRV record is
+// resolved again and the connection retried if the servers changed.
+func (l *Config) connectSRV(service, proto, name string, connect func(ldapAddr string) (*ldap.Conn, error)) (*ldap.Conn, error) {
+	addrs, err := l.lookupSRV(service, proto, name, false)
 	if err != nil {
 		return nil, fmt.Errorf("DNS SRV Record lookup error: %w", err)
 	}
 
-	var errs []error
+	ldapConn, err := connectAny(addrs, connect)
+	if err == nil {
+		return ldapConn, nil
+	}
+
+	refreshed, rerr := l.lookupSRV(service, proto, name, true)
+	if rerr != nil || slices.EqualFunc(addrs, refreshed, func(a, b *net.SRV) bool { return *a == *b }) {
+		return nil, err
+	}
+	return connectAny(refreshed, connect)
+}
 
-	// Return a connection to the first server to which we could connect.
-	for _, addr := range addrs {
-		ldapAddr := fmt.Sprintf("%s:%d", addr.Target, addr.Port)
+// connectAny returns a connection to the first server to which we could
+// connect, servers are tried in the order required by RFC 2782.
+func connectAny(addrs []*net.SRV, connect func(ldapAddr string) (*ldap.Conn, error)) (*ldap.Conn, error) {
+	var errMsgs []string
+	var errs []error
+	for _, addr := range orderSRV(addrs) {
+		ldapAddr := srvAddr(addr)
 
-		ldapConn, err = l.connect(ldapAddr)
+		ldapConn, err := connect(ldapAddr)
 		if err == nil {
 			return ldapConn, nil
 		}
+		errMsgs = append(errMsgs, fmt.Sprintf("Connect err to %s - %v", ldapAddr, err))
 		errs = append(errs, err)
 	}
 
 	// If none of the servers could connect, we all the errors.
-	var errMsgs []string
-	for i, e := range errs {
-		errMsgs = append(errMsgs, fmt.Sprintf("Connect err to %s:%d - %v", addrs[i].Target, addrs[i].Port, e))
+	return nil, &connectAnyError{
+		msg:  fmt.Sprintf("Could not connect to any LDAP server: %s", strings.Join(errMsgs, "; ")),
+		errs: errs,
 	}
-	err = fmt.Errorf("Could not connect to any LDAP server: %s", strings.Join(errMsgs, "; "))
-	return nil, err
 }
 
+// connectAnyError is returned by connectAny, it wraps the error of each
+// server.
+type connectAnyError struct {
+	msg  string
+	errs []error
+}
+
+func (e *connectAnyError) Error() string   { return e.msg }
+func (e *connectAnyError) Unwrap() []error { return e.errs }
+
 // LookupBind connects to LDAP server using the bind user credentials.
 func (l *Config) LookupBind(conn *ldap.Conn) error {
 	var err error
@@ -285,8 +349,16 @@ func (l *Config) LookupUsername(conn *ldap.Conn, username string) (*DNSearchResu
 	return &foundDistNames[0], nil
 }
 
-// SearchForUserGroups finds the groups of the user.
+// SearchForUserGroups finds the groups of the user. The groups are returned
+// as normalized DNs, or in group name mode as the deduplicated values of the
+// group name attribute.
 func (l *Config) SearchForUserGroups(conn *ldap.Conn, username, bindDN string) ([]string, error) {
+	attrs := noAttrsSpec
+	nameMode := l.groupNameMode()
+	if nameMode {
+		attrs = []string{strings.TrimSpace(l.GroupNameAttribute)}
+	}
+
 	// User groups lookup.
 	var groups []string
 	if l.GroupSearchFilter != "" {
@@ -297,26 +369,34 @@ func (l *Config) SearchForUserGroups(conn *ldap.Conn, username, bindDN string) (
 				groupSearchBase.ServerDN,
 				ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
 				filter,
-				noAttrsSpec,
+				attrs,
 				nil,
 			)
 
-			var newGroups []string
-			newGroups, err := getGroups(conn, searchRequest)
+			entries, err := searchGroups(conn, searchRequest)
 			if err != nil {
 				errRet := fmt.Errorf("Error finding groups of %s: %w", bindDN, err)
 				return nil, errRet
 			}
 
+			var newGroups []string
+			if nameMode {
+				newGroups = groupNames(entries, attrs[0])
+			} else if newGroups, err = groupDNs(entries); err != nil {
+				return nil, fmt.Errorf("Error finding groups of %s: %w", bindDN, err)
+			}
 			groups = append(groups, newGroups...)
 		}
 	}
 
+	if nameMode {
+		// Groups under different base DNs may share a name.
+		groups = dedupGroupNames(groups)
+	}
 	return groups, nil
 }
 
-func getGroups(conn *ldap.Conn, sreq *ldap.SearchRequest) ([]string, error) {
-	var groups []string
+func searchGroups(conn *ldap.Conn, sreq *ldap.SearchRequest) ([]*ldap.Entry, error) {
 	sres, err := conn.Search(sreq)
 	if err != nil {
 		// For a search, if the base DN does not exist, we get a 32 error code.
@@ -327,9 +407,13 @@ func getGroups(conn *ldap.Conn, sreq *ldap.SearchRequest) ([]string, error) {
 		}
 		return nil, fmt.Errorf("LDAP client: %w", err)
 	}
-	for _, entry := range sres.Entries {
-		// We only queried one attribute,
-		// so we only look up the first one.
+	return sres.Entries, nil
+}
+
+// groupDNs returns the normalized DNs of the group entries.
+func groupDNs(entries []*ldap.Entry) ([]string, error) {
+	var groups []string
+	for _, entry := range entries {
 		normalizedDN, err := NormalizeDN(entry.DN)
 		if err != nil {
 			return nil, err
@@ -339,6 +423,35 @@ func getGroups(conn *ldap.Conn, sreq *ldap.SearchRequest) ([]string, error) {
 	return groups, nil
 }
 
+// groupNames returns the values of attr of the group entries, trimmed and
+// deduplicated. All values of a multi-valued attribute are returned, groups
+// without the attribute are skipped.
+func groupNames(entries []*ldap.Entry, attr string) []string {
+	var groups []string
+	for _, entry := range entries {
+		groups = append(groups, entry.GetEqualFoldAttributeValues(attr)...)
+	}
+	return dedupGroupNames(groups)
+}
+
+// dedupGroupNames trims the names and removes empty and duplicate ones.
+// As LDAP matches names case-insensitively, names differing only in case
+// are duplicates, the first one is kept.
+func dedupGroupNames(names []string) []string {
+	var res []string
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if _, ok := seen[key]; ok || name == "" {
+			continue
+		}
+		seen[key] = struct{}{}
+		res = append(res, name)
+	}
+	return res
+}
+
 // LookupDN looks a given DN and returns its normalized form along with any
 // requested attributes. It only performs a base object search to check if the
 // DN exists. If the DN does not exist on the server, it returns a nil result
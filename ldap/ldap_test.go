@@ -0,0 +1,85 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ldap
+
+import (
+	"reflect"
+	"testing"
+
+	ldap "github.com/go-ldap/ldap/v3"
+)
+
+func TestGroupNames(t *testing.T) {
+	testCases := []struct {
+		entries  []*ldap.Entry
+		expected []string
+	}{
+		{nil, nil},
+		{
+			[]*ldap.Entry{
+				ldap.NewEntry("cn=admins,ou=groups,dc=min,dc=io", map[string][]string{"cn": {"admins"}}),
+				ldap.NewEntry("cn=devs,ou=groups,dc=min,dc=io", map[string][]string{"CN": {" devs "}}),
+			},
+			[]string{"admins", "devs"},
+		},
+		// Multi-valued attributes report all their values.
+		{
+			[]*ldap.Entry{
+				ldap.NewEntry("cn=ops,ou=groups,dc=min,dc=io", map[string][]string{"cn": {"ops", "operations"}}),
+			},
+			[]string{"ops", "operations"},
+		},
+		// Groups without the attribute are skipped, duplicates removed.
+		{
+			[]*ldap.Entry{
+				ldap.NewEntry("cn=ops,ou=groups,dc=min,dc=io", map[string][]string{"cn": {"ops"}}),
+				ldap.NewEntry("cn=nameless,ou=groups,dc=min,dc=io", map[string][]string{"description": {"no name"}}),
+				ldap.NewEntry("cn=ops,ou=legacy,dc=min,dc=io", map[string][]string{"cn": {"OPS", ""}}),
+			},
+			[]string{"ops"},
+		},
+	}
+
+	for i, testCase := range testCases {
+		if result := groupNames(testCase.entries, "cn"); !reflect.DeepEqual(result, testCase.expected) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+	}
+}
+
+func TestValidateGroupNameFormat(t *testing.T) {
+	testCases := []struct {
+		format, attr string
+		expected     Result
+	}{
+		{"", "", ConfigOk},
+		{"dn", "", ConfigOk},
+		{"name", "cn", ConfigOk},
+		{"NAME", "msDS-PrincipalName", ConfigOk},
+		{"name", "", GroupSearchParamsMisconfigured},
+		{"name", "1.2.3", GroupSearchParamsMisconfigured},
+		{"uid", "cn", GroupSearchParamsMisconfigured},
+	}
+
+	for i, testCase := range testCases {
+		l := Config{GroupNameFormat: testCase.format, GroupNameAttribute: testCase.attr}
+		if result := l.validateGroupNameFormat(); result.Result != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, result.Result)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ldap
+
+import (
+	"context"
+	"math/rand/v2"
+	"net"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultSRVRefreshInterval is the duration for which SRV records are
+// cached when neither the resolver reports a TTL nor
+// Config.SRVRefreshInterval is set.
+const DefaultSRVRefreshInterval = 5 * time.Minute
+
+// SRVResolver resolves DNS SRV records. A positive ttl bounds how long the
+// returned records are cached.
+type SRVResolver interface {
+	LookupSRV(ctx context.Context, service, proto, name string) (addrs []*net.SRV, ttl time.Duration, err error)
+}
+
+// netSRVResolver is the default SRVResolver. The standard library does not
+// expose record TTLs, so SRV records are cached for the refresh interval.
+type netSRVResolver struct{}
+
+func (netSRVResolver) LookupSRV(ctx context.Context, service, proto, name string) ([]*net.SRV, time.Duration, error) {
+	_, addrs, err := net.DefaultResolver.LookupSRV(ctx, service, proto, name)
+	return addrs, 0, err
+}
+
+type srvCacheEntry struct {
+	addrs   []*net.SRV
+	expires time.Time
+}
+
+// srvKey identifies a cached SRV lookup by the resolver and the queried
+// name, so that configs with different resolvers do not share answers.
+type srvKey struct {
+	resolver SRVResolver
+	name     string
+}
+
+// srvCache caches SRV lookups of all configs.
+type srvCache struct {
+	mu      sync.Mutex
+	entries map[srvKey]srvCacheEntry
+}
+
+var srvRecordCache = srvCache{entries: make(map[srvKey]srvCacheEntry)}
+
+// srvCacheKey returns the cache key of the lookup with resolver, ok is
+// false if resolver cannot be used as a map key, lookups are then not
+// cached.
+func srvCacheKey(resolver SRVResolver, service, proto, name string) (key srvKey, ok bool) {
+	if !reflect.TypeOf(resolver).Comparable() {
+		return key, false
+	}
+	return srvKey{resolver: resolver, name: "_" + service + "._" + proto + "." + name}, true
+}
+
+func (c *srvCache) get(key srvKey, now time.Time) ([]*net.SRV, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || now.After(e.expires) {
+		return nil, false
+	}
+	return e.addrs, true
+}
+
+func (c *srvCache) set(key srvKey, addrs []*net.SRV, expires time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = srvCacheEntry{addrs: addrs, expires: expires}
+}
+
+func (c *srvCache) invalidate(key srvKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// srvQuery returns the SRV query for the config, ok is false if SRV
+// lookup is not enabled.
+func (l *Config) srvQuery() (service, proto, name string, ok bool) {
+	switch l.SRVRecordName {
+	case "on":
+		return "", "", l.ServerAddr, true
+	case "ldap", "ldaps":
+		return l.SRVRecordName, "tcp", l.ServerAddr, true
+	}
+	return "", "", "", false
+}
+
+// srvResolver returns the SRV resolver of the config.
+func (l *Config) srvResolver() SRVResolver {
+	if l.SRVResolver == nil {
+		return netSRVResolver{}
+	}
+	return l.SRVResolver
+}
+
+// lookupSRV returns the SRV records of the config, served from cache
+// unless refresh is set or the cached records expired.
+func (l *Config) lookupSRV(service, proto, name string, refresh bool) ([]*net.SRV, error) {
+	resolver := l.srvResolver()
+	key, cached := srvCacheKey(resolver, service, proto, name)
+	now := time.Now()
+	if cached && !refresh {
+		if addrs, ok := srvRecordCache.get(key, now); ok {
+			return addrs, nil
+		}
+	}
+
+	addrs, ttl, err := resolver.LookupSRV(context.Background(), service, proto, name)
+	if err != nil {
+		if cached {
+			srvRecordCache.invalidate(key)
+		}
+		return nil, err
+	}
+
+	if ttl <= 0 {
+		ttl = l.SRVRefreshInterval
+	}
+	if ttl <= 0 {
+		ttl = DefaultSRVRefreshInterval
+	}
+	if cached {
+		srvRecordCache.set(key, addrs, now.Add(ttl))
+	}
+	return addrs, nil
+}
+
+// ResolvedServers returns the LDAP servers currently discovered through
+// the SRV record, if SRV lookup is enabled, ordered by priority.
+func (l *Config) ResolvedServers() []string {
+	service, proto, name, ok := l.srvQuery()
+	if !ok {
+		return nil
+	}
+	key, ok := srvCacheKey(l.srvResolver(), service, proto, name)
+	if !ok {
+		return nil
+	}
+	addrs, ok := srvRecordCache.get(key, time.Now())
+	if !ok {
+		return nil
+	}
+
+	addrs = slices.Clone(addrs)
+	slices.SortStableFunc(addrs, func(a, b *net.SRV) int {
+		return int(a.Priority) - int(b.Priority)
+	})
+	servers := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		servers = append(servers, srvAddr(addr))
+	}
+	return servers
+}
+
+func srvAddr(addr *net.SRV) string {
+	return net.JoinHostPort(strings.TrimSuffix(addr.Target, "."), strconv.Itoa(int(addr.Port)))
+}
+
+// orderSRV returns addrs in the order in which servers must be contacted as
+// per RFC 2782: by ascending priority and, within the same priority, by a
+// weighted random selection.
+func orderSRV(addrs []*net.SRV) []*net.SRV {
+	sorted := slices.Clone(addrs)
+	slices.SortStableFunc(sorted, func(a, b *net.SRV) int {
+		return int(a.Priority) - int(b.Priority)
+	})
+
+	ordered := make([]*net.SRV, 0, len(sorted))
+	for i := 0; i < len(sorted); {
+		j := i
+		for j < len(sorted) && sorted[j].Priority == sorted[i].Priority {
+			j++
+		}
+		ordered = append(ordered, orderByWeight(sorted[i:j])...)
+		i = j
+	}
+	return ordered
+}
+
+// orderByWeight orders records of the same priority by weighted random
+// selection, records with weight 0 are contacted last.
+func orderByWeight(addrs []*net.SRV) []*net.SRV {
+	remaining := slices.Clone(addrs)
+	ordered := make([]*net.SRV, 0, len(remaining))
+	for len(remaining) > 0 {
+		var sum int
+		for _, addr := range remaining {
+			sum += int(addr.Weight)
+		}
+		if sum == 0 {
+			// Only records with weight 0 are left, keep them as is.
+			ordered = append(ordered, remaining...)
+			break
+		}
+
+		n := rand.IntN(sum)
+		var running int
+		for i, addr := range remaining {
+			running += int(addr.Weight)
+			if running > n {
+				ordered = append(ordered, addr)
+				remaining = slices.Delete(remaining, i, i+1)
+				break
+			}
+		}
+	}
+	return ordered
+}
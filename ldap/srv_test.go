@@ -0,0 +1,262 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ldap
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	ldap "github.com/go-ldap/ldap/v3"
+)
+
+// testSRVResolver returns the next record set on each lookup, the last
+// one is repeated.
+type testSRVResolver struct {
+	mu      sync.Mutex
+	results [][]*net.SRV
+	ttl     time.Duration
+	lookups int
+}
+
+func (r *testSRVResolver) LookupSRV(_ context.Context, _, _, _ string) ([]*net.SRV, time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := min(r.lookups, len(r.results)-1)
+	r.lookups++
+	return r.results[i], r.ttl, nil
+}
+
+func (r *testSRVResolver) Lookups() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lookups
+}
+
+func TestOrderSRV(t *testing.T) {
+	addrs := []*net.SRV{
+		{Target: "backup.example.com.", Port: 389, Priority: 20, Weight: 100},
+		{Target: "light.example.com.", Port: 389, Priority: 10, Weight: 1},
+		{Target: "heavy.example.com.", Port: 389, Priority: 10, Weight: 3},
+	}
+
+	const runs = 4000
+	var heavyFirst int
+	for i := 0; i < runs; i++ {
+		ordered := orderSRV(addrs)
+		if len(ordered) != len(addrs) {
+			t.Fatalf("expected %d records, got: %d", len(addrs), len(ordered))
+		}
+		if ordered[0].Priority != 10 || ordered[1].Priority != 10 || ordered[2].Target != "backup.example.com." {
+			t.Fatalf("records not ordered by priority: %v, %v, %v", ordered[0], ordered[1], ordered[2])
+		}
+		if ordered[0].Target == "heavy.example.com." {
+			heavyFirst++
+		}
+	}
+
+	// Expected ratio is 3/4.
+	if ratio := float64(heavyFirst) / runs; ratio < 0.68 || ratio > 0.82 {
+		t.Fatalf("expected heavy record to be selected first in ~75%% of runs, got: %.2f", ratio)
+	}
+
+	// Zero weight records are selected last.
+	zero := []*net.SRV{
+		{Target: "zero.example.com.", Port: 389, Priority: 10, Weight: 0},
+		{Target: "heavy.example.com.", Port: 389, Priority: 10, Weight: 1000},
+	}
+	var zeroFirst int
+	for i := 0; i < runs; i++ {
+		if orderSRV(zero)[0].Target == "zero.example.com." {
+			zeroFirst++
+		}
+	}
+	if zeroFirst > 0 {
+		t.Fatalf("zero weight record selected first in %d of %d runs", zeroFirst, runs)
+	}
+}
+
+func TestConnectSRVRefresh(t *testing.T) {
+	resolver := &testSRVResolver{
+		results: [][]*net.SRV{
+			{
+				{Target: "dead-1.example.com.", Port: 636, Priority: 10, Weight: 1},
+				{Target: "dead-2.example.com.", Port: 636, Priority: 20, Weight: 1},
+			},
+			{
+				{Target: "alive-2.example.com.", Port: 636, Priority: 20, Weight: 1},
+				{Target: "alive-1.example.com.", Port: 636, Priority: 10, Weight: 1},
+			},
+		},
+		ttl: time.Hour,
+	}
+	l := Config{
+		Enabled:       true,
+		ServerAddr:    "refresh.example.com",
+		SRVRecordName: "ldaps",
+		SRVResolver:   resolver,
+	}
+
+	var dialed []string
+	connect := func(addr string) (*ldap.Conn, error) {
+		dialed = append(dialed, addr)
+		if addr == "alive-1.example.com:636" {
+			return nil, nil
+		}
+		return nil, errors.New("connection refused")
+	}
+
+	if _, err := l.connectSRV("ldaps", "tcp", l.ServerAddr, connect); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedDialed := []string{"dead-1.example.com:636", "dead-2.example.com:636", "alive-1.example.com:636"}
+	if !reflect.DeepEqual(dialed, expectedDialed) {
+		t.Fatalf("expected: %v, got: %v", expectedDialed, dialed)
+	}
+	if lookups := resolver.Lookups(); lookups != 2 {
+		t.Fatalf("expected 2 lookups, got: %d", lookups)
+	}
+
+	// Subsequent connections are served from the cache.
+	dialed = nil
+	if _, err := l.connectSRV("ldaps", "tcp", l.ServerAddr, connect); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lookups := resolver.Lookups(); lookups != 2 {
+		t.Fatalf("expected 2 lookups, got: %d", lookups)
+	}
+
+	expectedServers := []string{"alive-1.example.com:636", "alive-2.example.com:636"}
+	if servers := l.ResolvedServers(); !reflect.DeepEqual(servers, expectedServers) {
+		t.Fatalf("expected: %v, got: %v", expectedServers, servers)
+	}
+
+	// Unchanged records are not retried.
+	dialed = nil
+	failing := func(addr string) (*ldap.Conn, error) {
+		dialed = append(dialed, addr)
+		return nil, errors.New("connection refused")
+	}
+	if _, err := l.connectSRV("ldaps", "tcp", l.ServerAddr, failing); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if len(dialed) != 2 {
+		t.Fatalf("expected 2 connection attempts, got: %v", dialed)
+	}
+}
+
+func TestLookupSRVTTL(t *testing.T) {
+	resolver := &testSRVResolver{
+		results: [][]*net.SRV{
+			{{Target: "ldap-1.example.com.", Port: 389, Priority: 10, Weight: 1}},
+			{{Target: "ldap-2.example.com.", Port: 389, Priority: 10, Weight: 1}},
+		},
+	}
+	l := Config{
+		ServerAddr:         "ttl.example.com",
+		SRVRecordName:      "on",
+		SRVResolver:        resolver,
+		SRVRefreshInterval: 50 * time.Millisecond,
+	}
+
+	for i, expectedServers := range [][]string{
+		{"ldap-1.example.com:389"},
+		{"ldap-1.example.com:389"},
+	} {
+		if _, err := l.lookupSRV("", "", l.ServerAddr, false); err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if servers := l.ResolvedServers(); !reflect.DeepEqual(servers, expectedServers) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, expectedServers, servers)
+		}
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if servers := l.ResolvedServers(); servers != nil {
+		t.Fatalf("expected expired records, got: %v", servers)
+	}
+	if _, err := l.lookupSRV("", "", l.ServerAddr, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedServers := []string{"ldap-2.example.com:389"}
+	if servers := l.ResolvedServers(); !reflect.DeepEqual(servers, expectedServers) {
+		t.Fatalf("expected: %v, got: %v", expectedServers, servers)
+	}
+}
+
+func TestValidateResolvedServers(t *testing.T) {
+	l := Config{
+		Enabled:        true,
+		ServerAddr:     "validate.example.com",
+		SRVRecordName:  "ldap",
+		ServerInsecure: true,
+		SRVResolver: &testSRVResolver{
+			results: [][]*net.SRV{{{Target: "127.0.0.1", Port: 1, Priority: 10, Weight: 1}}},
+			ttl:     time.Hour,
+		},
+	}
+
+	v := l.Validate()
+	if v.Result != ConnectivityError {
+		t.Fatalf("expected: %v, got: %v", ConnectivityError, v.Result)
+	}
+	expectedServers := []string{"127.0.0.1:1"}
+	if !reflect.DeepEqual(v.ResolvedServers, expectedServers) {
+		t.Fatalf("expected: %v, got: %v", expectedServers, v.ResolvedServers)
+	}
+}
+
+func TestLookupSRVPerResolver(t *testing.T) {
+	newConfig := func(target string) Config {
+		return Config{
+			Enabled:       true,
+			ServerAddr:    "shared.example.com",
+			SRVRecordName: "ldap",
+			SRVResolver: &testSRVResolver{
+				results: [][]*net.SRV{{{Target: target, Port: 389, Priority: 10, Weight: 1}}},
+				ttl:     time.Hour,
+			},
+		}
+	}
+	a, b := newConfig("a.example.com."), newConfig("b.example.com.")
+
+	for _, testCase := range []struct {
+		l        Config
+		expected string
+	}{
+		{a, "a.example.com"},
+		{b, "b.example.com"},
+		{a, "a.example.com"},
+	} {
+		addrs, err := testCase.l.lookupSRV("ldap", "tcp", testCase.l.ServerAddr, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(addrs) != 1 || srvAddr(addrs[0]) != testCase.expected+":389" {
+			t.Fatalf("expected: %v, got: %v", testCase.expected, addrs)
+		}
+	}
+	if lookups := a.SRVResolver.(*testSRVResolver).Lookups(); lookups != 1 {
+		t.Fatalf("expected cached records, got %d lookups", lookups)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ldap
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	ldap "github.com/go-ldap/ldap/v3"
+)
+
+// alertProtocolVersion is the TLS alert sent by servers not supporting
+// any of the TLS versions offered by the client.
+const alertProtocolVersion tls.AlertError = 70
+
+// certificateNames returns the names a certificate is valid for.
+func certificateNames(cert *x509.Certificate) string {
+	var names []string
+	names = append(names, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		names = append(names, ip.String())
+	}
+	if len(names) == 0 && cert.Subject.CommonName != "" {
+		// Not used for verification, but reported for clarity.
+		names = append(names, cert.Subject.CommonName+" (common name only)")
+	}
+	if len(names) == 0 {
+		return "no names"
+	}
+	return strings.Join(names, ", ")
+}
+
+// diagnoseTLSError returns the validation detail and suggestion for TLS
+// errors of a connection to the LDAP server, ok is false if err is not a
+// TLS error with a known cause.
+func diagnoseTLSError(err error) (detail, suggestion string, ok bool) {
+	var hostnameErr x509.HostnameError
+	var authorityErr x509.UnknownAuthorityError
+	var invalidErr x509.CertificateInvalidError
+	var recordErr tls.RecordHeaderError
+	var alertErr tls.AlertError
+
+	switch {
+	case errors.As(err, &hostnameErr):
+		names := certificateNames(hostnameErr.Certificate)
+		return fmt.Sprintf("LDAP server's TLS certificate is not valid for %s", hostnameErr.Host),
+			fmt.Sprintf("The server presented a certificate for %s; configure the server address to match or add a SAN for %s to the server certificate", names, hostnameErr.Host),
+			true
+	case errors.As(err, &authorityErr):
+		issuer := "an unknown issuer"
+		if authorityErr.Cert != nil {
+			issuer = fmt.Sprintf("'%s'", authorityErr.Cert.Issuer)
+		}
+		return "LDAP server's TLS certificate is signed by an unknown authority",
+			fmt.Sprintf("The server presented a certificate issued by %s; add the CA certificate of the issuer to the CAs trusted by MinIO", issuer),
+			true
+	case errors.As(err, &invalidErr):
+		cert := invalidErr.Cert
+		if invalidErr.Reason == x509.Expired && cert != nil {
+			if now := time.Now(); now.Before(cert.NotBefore) {
+				return "LDAP server's TLS certificate is not valid yet",
+					fmt.Sprintf("The server presented a certificate valid from %s; check the clock of the MinIO and LDAP servers", cert.NotBefore.UTC().Format(time.RFC3339)),
+					true
+			}
+			return "LDAP server's TLS certificate has expired",
+				fmt.Sprintf("The server presented a certificate which expired at %s; renew the certificate of the LDAP server", cert.NotAfter.UTC().Format(time.RFC3339)),
+				true
+		}
+		return fmt.Sprintf("LDAP server's TLS certificate is invalid: %v", invalidErr),
+			"Check the certificate of the LDAP server, e.g. its key usages and the constraints of its issuers",
+			true
+	case errors.As(err, &recordErr):
+		return "LDAP server did not respond with TLS",
+			`The server address may be a plain text LDAP port, e.g. 389: configure the LDAPS port, e.g. 636, or enable StartTLS`,
+			true
+	case errors.As(err, &alertErr) && alertErr == alertProtocolVersion:
+		return "LDAP server does not support the TLS versions accepted by MinIO",
+			"Enable TLS 1.2 or later on the LDAP server",
+			true
+	}
+	return "", "", false
+}
+
+// connectivityError returns the validation of a failed connection to the
+// LDAP server, with a specific detail and suggestion for TLS errors.
+func connectivityError(err error, suggestion string) Validation {
+	v := Validation{
+		Result:     ConnectivityError,
+		Detail:     fmt.Sprintf("Could not connect to LDAP server: %v", err),
+		ErrCause:   err,
+		Suggestion: suggestion,
+	}
+	if detail, suggestion, ok := diagnoseTLSError(err); ok {
+		v.Detail = fmt.Sprintf("Could not connect to LDAP server: %s", detail)
+		v.Suggestion = suggestion
+	}
+	return v
+}
+
+// FetchServerCertificates connects to the LDAP server, or the first server
+// discovered through the SRV record which can be connected to, without
+// verifying its certificate, and returns the certificate chain presented
+// by the server. It is a diagnostic only, connections to the server must
+// use Connect.
+func (l *Config) FetchServerCertificates() ([]*x509.Certificate, error) {
+	if l != nil && l.ServerInsecure && !l.ServerStartTLS {
+		return nil, errors.New("TLS is not enabled")
+	}
+
+	var certs []*x509.Certificate
+	_, err := l.connectWith(func(ldapAddr string) (*ldap.Conn, error) {
+		var config *tls.Config
+		if l.TLS != nil {
+			config = l.TLS.Clone()
+		} else {
+			config = &tls.Config{}
+		}
+		config.InsecureSkipVerify = true
+		config.VerifyPeerCertificate = nil
+		config.VerifyConnection = nil
+
+		var state tls.ConnectionState
+		if l.ServerStartTLS {
+			conn, err := ldap.Dial("tcp", ldapAddr)
+			if err != nil {
+				return nil, err
+			}
+			defer conn.Close()
+			conn.SetTimeout(30 * time.Second)
+			if err = conn.StartTLS(config); err != nil {
+				return nil, err
+			}
+			state, _ = conn.TLSConnectionState()
+		} else {
+			dialer := &net.Dialer{Timeout: 30 * time.Second}
+			conn, err := tls.DialWithDialer(dialer, "tcp", ldapAddr, config)
+			if err != nil {
+				return nil, err
+			}
+			defer conn.Close()
+			state = conn.ConnectionState()
+		}
+		certs = state.PeerCertificates
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return certs, nil
+}
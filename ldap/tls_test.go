@@ -0,0 +1,231 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ldap
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	ldap "github.com/go-ldap/ldap/v3"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test LDAP CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testCA{cert: cert, key: key}
+}
+
+func (ca testCA) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+// issue returns a server certificate for names, valid from notBefore to
+// notAfter.
+func (ca testCA) issue(t *testing.T, notBefore, notAfter time.Time, names ...string) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: names[0]},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, name := range names {
+		if ip := net.ParseIP(name); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, name)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der, ca.cert.Raw}, PrivateKey: key}
+}
+
+// startTestServer starts a server handling each connection with handle,
+// and returns its address.
+func startTestServer(t *testing.T, handle func(net.Conn)) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				handle(conn)
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+// startTLSServer starts a server completing the TLS handshake using cert.
+func startTLSServer(t *testing.T, cert tls.Certificate) string {
+	config := &tls.Config{Certificates: []tls.Certificate{cert}}
+	return startTestServer(t, func(conn net.Conn) {
+		tls.Server(conn, config).Handshake()
+	})
+}
+
+func TestValidateTLSErrors(t *testing.T) {
+	ca := newTestCA(t)
+	now := time.Now()
+
+	badHostname := startTLSServer(t, ca.issue(t, now.Add(-time.Hour), now.Add(time.Hour), "ldap.example.com"))
+	expired := startTLSServer(t, ca.issue(t, now.Add(-2*time.Hour), now.Add(-time.Hour), "127.0.0.1"))
+	valid := startTLSServer(t, ca.issue(t, now.Add(-time.Hour), now.Add(time.Hour), "127.0.0.1"))
+	plainText := startTestServer(t, func(conn net.Conn) {
+		// An LDAP notice of disconnection, as sent by servers to clients
+		// talking TLS to the plain text port.
+		conn.Write([]byte{0x30, 0x84, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00})
+	})
+
+	testCases := []struct {
+		addr               string
+		roots              *x509.CertPool
+		expectedDetail     string
+		expectedSuggestion string
+	}{
+		{badHostname, ca.pool(), "certificate is not valid for 127.0.0.1", "presented a certificate for ldap.example.com; configure the server address to match or add a SAN"},
+		{expired, ca.pool(), "certificate has expired", "renew the certificate"},
+		{valid, x509.NewCertPool(), "signed by an unknown authority", "issued by 'CN=Test LDAP CA'"},
+		{plainText, ca.pool(), "did not respond with TLS", "plain text LDAP port"},
+	}
+
+	for i, testCase := range testCases {
+		l := Config{
+			Enabled:    true,
+			ServerAddr: testCase.addr,
+			TLS:        &tls.Config{RootCAs: testCase.roots, MinVersion: tls.VersionTLS12},
+		}
+		v := l.Validate()
+		if v.Result != ConnectivityError {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, ConnectivityError, v.FormatError())
+		}
+		if !strings.Contains(v.Detail, testCase.expectedDetail) {
+			t.Fatalf("case %v: expected detail: %v, got: %v", i+1, testCase.expectedDetail, v.Detail)
+		}
+		if !strings.Contains(v.Suggestion, testCase.expectedSuggestion) {
+			t.Fatalf("case %v: expected suggestion: %v, got: %v", i+1, testCase.expectedSuggestion, v.Suggestion)
+		}
+		if v.ErrCause == nil {
+			t.Fatalf("case %v: expected the error cause", i+1)
+		}
+	}
+
+	// Other errors are not diagnosed.
+	if _, _, ok := diagnoseTLSError(errors.New("connection refused")); ok {
+		t.Fatal("expected no diagnosis")
+	}
+	// Errors of servers discovered through SRV records are diagnosed.
+	l := Config{Enabled: true, ServerAddr: badHostname, TLS: &tls.Config{RootCAs: ca.pool()}}
+	_, err := connectAny([]*net.SRV{{Target: "a.example.com.", Port: 636}, {Target: "b.example.com.", Port: 636}},
+		func(ldapAddr string) (*ldap.Conn, error) {
+			if ldapAddr == "a.example.com:636" {
+				return nil, errors.New("connection refused")
+			}
+			return l.connect(badHostname)
+		})
+	if _, suggestion, ok := diagnoseTLSError(err); !ok || !strings.Contains(suggestion, "ldap.example.com") {
+		t.Fatalf("expected the hostname error to be diagnosed, got: %v", err)
+	}
+}
+
+func TestFetchServerCertificates(t *testing.T) {
+	ca := newTestCA(t)
+	now := time.Now()
+	expired := ca.issue(t, now.Add(-2*time.Hour), now.Add(-time.Hour), "ldap.example.com")
+	addr := startTLSServer(t, expired)
+
+	// The chain is reported although the certificate is neither trusted,
+	// valid for the address, nor valid at all.
+	l := Config{
+		Enabled:    true,
+		ServerAddr: addr,
+		TLS:        &tls.Config{RootCAs: x509.NewCertPool(), MinVersion: tls.VersionTLS12},
+	}
+	certs, err := l.FetchServerCertificates()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(certs) != 2 || certs[0].DNSNames[0] != "ldap.example.com" || !certs[1].Equal(ca.cert) {
+		t.Fatalf("expected the presented chain, got: %v certificates", len(certs))
+	}
+	if l.TLS.InsecureSkipVerify {
+		t.Fatal("expected the TLS config not to be changed")
+	}
+	if _, err = l.Connect(); err == nil {
+		t.Fatal("expected connections to be verified")
+	}
+
+	l.ServerInsecure = true
+	if _, err = l.FetchServerCertificates(); err == nil {
+		t.Fatal("expected an error without TLS")
+	}
+}
@@ -39,16 +39,28 @@ const (
 	GroupSearchParamsMisconfigured Result = "Group Search Parameters Misconfigured"
 	UserDNLookupError              Result = "User DN Lookup Error"
 	GroupMembershipsLookupError    Result = "Group Memberships Lookup Error"
+
+	// ValidationSkipped is reported by ValidateFull for checks which were
+	// not run as a check they depend on failed.
+	ValidationSkipped Result = "Validation Skipped"
 )
 
 // Validation returns feedback on the configuration. The `Suggestion` field
 // needs to be "printed" for friendly display (it can contain escaped newlines
 // `\n`).
 type Validation struct {
+	// Section of the configuration the result is for, only set by
+	// ValidateFull.
+	Section string
+
 	Result     Result
 	Detail     string
 	Suggestion string
 	ErrCause   error
+
+	// ResolvedServers lists the LDAP servers discovered through the SRV
+	// record, if SRV lookup is enabled.
+	ResolvedServers []string
 }
 
 // Error instance for Validation.
@@ -74,6 +86,9 @@ func (v Validation) FormatError() string {
 	if v.ErrCause != nil {
 		messages = append(messages, fmt.Sprintf("Due to: %s", v.ErrCause.Error()))
 	}
+	if len(v.ResolvedServers) > 0 {
+		messages = append(messages, fmt.Sprintf("Resolved servers: %s", strings.Join(v.ResolvedServers, ", ")))
+	}
 	return strings.Join(messages, "\n")
 }
 
@@ -85,13 +100,25 @@ func (v Validation) IsOk() bool {
 // UserLookupResult returns the DN found for the test user and their group
 // memberships.
 type UserLookupResult struct {
-	DN                 string
-	DNAttributes       map[string][]string
+	DN           string
+	DNAttributes map[string][]string
+
+	// GroupDNMemberships lists the groups in the format given by
+	// GroupNameFormat: group DNs, or group names in group name mode.
 	GroupDNMemberships []string
+	GroupNameFormat    string
 }
 
 var validSRVRecordNames = set.CreateStringSet("ldap", "ldaps", "on")
 
+// Validation sections reported by ValidateFull.
+const (
+	SectionConnection  = "Connection"
+	SectionLookupBind  = "Lookup Bind"
+	SectionUserSearch  = "User Search"
+	SectionGroupSearch = "Group Search"
+)
+
 // Validate validates the LDAP configuration. It can be called with any subset
 // of configuration parameters provided by the user - it will return
 // information on what needs to be done to fix the problem if any.
@@ -100,11 +127,150 @@ var validSRVRecordNames = set.CreateStringSet("ldap", "ldaps", "on")
 // GroupSearchBaseDistNames fields of the Config - however this an idempotent
 // operation. This is done to support configuration validation in Console/mc and
 // for tests.
+//
+// When SRV lookup is enabled, the discovered LDAP servers are reported in
+// the ResolvedServers field of the result.
+//
+// Only the first failure is returned, see ValidateFull to get all of them.
 func (l *Config) Validate() Validation {
+	v := Validation{Result: ConfigOk}
+	for _, r := range l.ValidateFull() {
+		if !r.IsOk() && r.Result != ValidationSkipped {
+			v = r
+			break
+		}
+	}
 	if !l.Enabled {
-		return Validation{Result: ConfigOk, Detail: "Config is not enabled"}
+		v = Validation{Result: ConfigOk, Detail: "Config is not enabled"}
+	}
+	v.Section = ""
+	v.ResolvedServers = l.ResolvedServers()
+	return v
+}
+
+// ValidateFull validates the LDAP configuration like Validate, but runs
+// all checks and returns a result for each of them, in the order Validate
+// runs them, so that tooling can display a checklist. Each base DN is
+// checked separately. Checks depending on a failed check, e.g. base DN
+// lookups on a failed connection, are reported with the ValidationSkipped
+// result.
+func (l *Config) ValidateFull() []Validation {
+	if !l.Enabled {
+		return []Validation{{Section: SectionConnection, Result: ConfigOk, Detail: "Config is not enabled"}}
+	}
+
+	var results []Validation
+	add := func(section string, v Validation) bool {
+		v.Section = section
+		results = append(results, v)
+		return v.IsOk()
+	}
+	skip := func(section, detail, prerequisite string) {
+		add(section, Validation{
+			Result: ValidationSkipped,
+			Detail: fmt.Sprintf("%s: skipped as %s failed", detail, prerequisite),
+		})
 	}
 
+	// Connection.
+	var conn *ldap.Conn
+	if add(SectionConnection, l.validateConnectionParams()) {
+		var v Validation
+		conn, v = l.validateConnectivity()
+		v.ResolvedServers = l.ResolvedServers()
+		add(SectionConnection, v)
+	} else {
+		skip(SectionConnection, "Connecting to LDAP server", "connection parameters validation")
+	}
+	if conn != nil {
+		defer conn.Close()
+	}
+
+	// Lookup bind.
+	bound := false
+	switch {
+	case l.LookupBindDN == "":
+		add(SectionLookupBind, Validation{
+			Result:     LookupBindError,
+			Detail:     "Lookup Bind UserDN not specified",
+			Suggestion: "Specify LDAP service account credentials for performing lookups.",
+		})
+	case conn == nil:
+		skip(SectionLookupBind, "Lookup Bind", "connecting to LDAP server")
+	default:
+		bound = add(SectionLookupBind, l.validateLookupBind(conn))
+	}
+	if !bound {
+		// Lookups require a bound connection.
+		conn = nil
+	}
+
+	// User search parameters.
+	var ok bool
+	l.userDNSearchBaseDistNames, ok = validateBaseDNs(conn, splitAndTrim(l.UserDNSearchBaseDistName, dnDelimiter),
+		UserSearchParamsMisconfigured, "UserDN search base", func(v Validation) { add(SectionUserSearch, v) })
+	if len(splitAndTrim(l.UserDNSearchBaseDistName, dnDelimiter)) == 0 {
+		add(SectionUserSearch, Validation{
+			Result:     UserSearchParamsMisconfigured,
+			Detail:     "UserDN search base is empty",
+			Suggestion: "Set the UserDN search base to the DN of the directory subtree where users are present",
+		})
+	} else if ok {
+		add(SectionUserSearch, validateDNOverlaps(l.userDNSearchBaseDistNames, UserSearchParamsMisconfigured, "User Search Base DN"))
+	} else {
+		skip(SectionUserSearch, "Checking User Search Base DNs for overlaps", "user search base DN lookup")
+	}
+
+	userDNAttributes := splitAndTrim(l.UserDNAttributes, attrDelimiter)
+	if len(userDNAttributes) > 0 {
+		// Check that the attributes are valid.
+		if err := validateAttributes(userDNAttributes); err != nil {
+			add(SectionUserSearch, Validation{
+				Result:     UserSearchParamsMisconfigured,
+				Detail:     fmt.Sprintf("UserDN attributes `%s` are invalid: %v", l.UserDNAttributes, err),
+				Suggestion: "Ensure that the attribute names are valid LDAP short names of attributes (not OIDs)",
+			})
+		} else {
+			l.userDNAttributesList = userDNAttributes
+			add(SectionUserSearch, Validation{Result: ConfigOk, Detail: "UserDN attributes are valid"})
+		}
+	} else {
+		l.userDNAttributesList = nil
+	}
+
+	add(SectionUserSearch, l.validateUserDNSearchFilter())
+
+	// If group lookup is not configured, it's ok.
+	if l.GroupSearchBaseDistName == "" && l.GroupSearchFilter == "" {
+		l.groupSearchBaseDistNames = nil
+		return results
+	}
+
+	// Group search parameters.
+	l.groupSearchBaseDistNames, ok = validateBaseDNs(conn, splitAndTrim(l.GroupSearchBaseDistName, dnDelimiter),
+		GroupSearchParamsMisconfigured, "Group Search Base DN", func(v Validation) { add(SectionGroupSearch, v) })
+	if len(splitAndTrim(l.GroupSearchBaseDistName, dnDelimiter)) == 0 {
+		add(SectionGroupSearch, Validation{
+			Result: GroupSearchParamsMisconfigured,
+			Detail: "Group Search Base DN is required.",
+			Suggestion: `Since you entered a value for the Group Search Filter - enter a value for the Group Search Base DN too:
+    Enter this value as the DN of the subtree where groups will be found.`,
+		})
+	} else if ok {
+		add(SectionGroupSearch, validateDNOverlaps(l.groupSearchBaseDistNames, GroupSearchParamsMisconfigured, "Group Search Base DN"))
+	} else {
+		skip(SectionGroupSearch, "Checking Group Search Base DNs for overlaps", "group search base DN lookup")
+	}
+
+	add(SectionGroupSearch, l.validateGroupSearchFilter())
+	if l.GroupNameFormat != "" || l.GroupNameAttribute != "" {
+		add(SectionGroupSearch, l.validateGroupNameFormat())
+	}
+
+	return results
+}
+
+func (l *Config) validateConnectionParams() Validation {
 	if l.ServerAddr == "" {
 		return Validation{
 			Result:     ConnectionParamMisconfigured,
@@ -122,29 +288,23 @@ func (l *Config) Validate() Validation {
 		}
 	}
 
+	return Validation{Result: ConfigOk, Detail: "Connection parameters are valid"}
+}
+
+func (l *Config) validateConnectivity() (*ldap.Conn, Validation) {
 	conn, err := l.Connect()
 	if err != nil {
-		return Validation{
-			Result:   ConnectivityError,
-			Detail:   fmt.Sprintf("Could not connect to LDAP server: %v", err),
-			ErrCause: err,
-			Suggestion: `Check:
+		return nil, connectivityError(err, `Check:
     (1) server address
     (2) TLS parameters,
     (3) LDAP server's TLS certificate is trusted by MinIO (when using TLS - highly recommended)
     (4) SRV Record lookup if given, and
-    (5) LDAP service is up and reachable`,
-		}
+    (5) LDAP service is up and reachable`)
 	}
-	defer conn.Close()
+	return conn, Validation{Result: ConfigOk, Detail: "Connected to LDAP server"}
+}
 
-	if l.LookupBindDN == "" {
-		return Validation{
-			Result:     LookupBindError,
-			Detail:     "Lookup Bind UserDN not specified",
-			Suggestion: "Specify LDAP service account credentials for performing lookups.",
-		}
-	}
+func (l *Config) validateLookupBind(conn *ldap.Conn) Validation {
 	if err := l.LookupBind(conn); err != nil {
 		return Validation{
 			Result:     LookupBindError,
@@ -153,47 +313,57 @@ func (l *Config) Validate() Validation {
 			Suggestion: "Check LDAP Lookup Bind user credentials and if user is allowed to login",
 		}
 	}
+	return Validation{Result: ConfigOk, Detail: "Bound as LDAP Lookup Bind user"}
+}
 
-	// Validate User Lookup parameters
-	userBaseDNList := splitAndTrim(l.UserDNSearchBaseDistName, dnDelimiter)
-	l.userDNSearchBaseDistNames, err = validateAndParseBaseDNList(conn, userBaseDNList)
-	if err != nil {
-		return Validation{
-			Result:     UserSearchParamsMisconfigured,
-			Detail:     fmt.Sprintf("UserDN search base DN failed to validate/parse: %v", err),
-			Suggestion: "Set the UserDN search base to a valid DN - e.g. as returned by an LDAP search",
+// validateBaseDNs looks up each base DN and reports a result for it, or
+// reports each of them as skipped if conn is nil. It returns the parsed
+// base DNs and whether all of them were found.
+func validateBaseDNs(conn *ldap.Conn, baseDNList []string, result Result, name string, report func(Validation)) ([]BaseDNInfo, bool) {
+	var res []BaseDNInfo
+	ok := true
+	for _, dn := range baseDNList {
+		if conn == nil {
+			report(Validation{
+				Result: ValidationSkipped,
+				Detail: fmt.Sprintf("%s `%s` lookup: skipped as lookup bind failed", name, dn),
+			})
+			ok = false
+			continue
 		}
-	}
-	if len(l.userDNSearchBaseDistNames) == 0 {
-		return Validation{
-			Result:     UserSearchParamsMisconfigured,
-			Detail:     "UserDN search base is empty",
-			Suggestion: "Set the UserDN search base to the DN of the directory subtree where users are present",
+		info, err := validateAndParseBaseDN(conn, dn)
+		if err != nil {
+			report(Validation{
+				Result:     result,
+				Detail:     fmt.Sprintf("%s failed to validate/parse: %v", name, err),
+				Suggestion: fmt.Sprintf("Set the %s to a valid DN - e.g. as returned by an LDAP search", name),
+			})
+			ok = false
+			continue
 		}
+		report(Validation{Result: ConfigOk, Detail: fmt.Sprintf("%s `%s` found", name, dn)})
+		res = append(res, info)
+	}
+	if !ok {
+		return nil, false
 	}
+	return res, true
+}
 
-	// Validate that BaseDNs represent non-overlapping subtrees.
-	if ancestor, descendant := checkForDNOverlaps(l.userDNSearchBaseDistNames); ancestor != "" {
+// validateDNOverlaps validates that base DNs represent non-overlapping
+// subtrees.
+func validateDNOverlaps(s []BaseDNInfo, result Result, name string) Validation {
+	if ancestor, descendant := checkForDNOverlaps(s); ancestor != "" {
 		return Validation{
-			Result:     UserSearchParamsMisconfigured,
-			Detail:     fmt.Sprintf("User Search Base DN `%s` is an ancestor of `%s`", ancestor, descendant),
+			Result:     result,
+			Detail:     fmt.Sprintf("%s `%s` is an ancestor of `%s`", name, ancestor, descendant),
 			Suggestion: "No two base DNs may overlap - please remove one of them",
 		}
 	}
+	return Validation{Result: ConfigOk, Detail: fmt.Sprintf("%ss do not overlap", name)}
+}
 
-	userDNAttributes := splitAndTrim(l.UserDNAttributes, attrDelimiter)
-	if len(userDNAttributes) > 0 {
-		// Check that the attributes are valid.
-		if err := validateAttributes(userDNAttributes); err != nil {
-			return Validation{
-				Result:     UserSearchParamsMisconfigured,
-				Detail:     fmt.Sprintf("UserDN attributes `%s` are invalid: %v", l.UserDNAttributes, err),
-				Suggestion: "Ensure that the attribute names are valid LDAP short names of attributes (not OIDs)",
-			}
-		}
-	}
-	l.userDNAttributesList = userDNAttributes
-
+func (l *Config) validateUserDNSearchFilter() Validation {
 	if l.UserDNSearchFilter == "" {
 		return Validation{
 			Result: UserSearchParamsMisconfigured,
@@ -231,74 +401,74 @@ func (l *Config) Validate() Validation {
 		}
 	}
 
-	// If group lookup is not configured, it's ok.
-	if l.GroupSearchBaseDistName != "" || l.GroupSearchFilter != "" {
-
-		// Validate Group Search parameters.
-		groupBaseDNList := splitAndTrim(l.GroupSearchBaseDistName, dnDelimiter)
-		l.groupSearchBaseDistNames, err = validateAndParseBaseDNList(conn, groupBaseDNList)
-		if err != nil {
-			return Validation{
-				Result:     GroupSearchParamsMisconfigured,
-				Detail:     fmt.Sprintf("Group Search Base DN failed to parse: %v", err),
-				Suggestion: "Set the Group Search Base DN to a valid DN - e.g. as returned by an LDAP search",
-			}
-		}
-		if len(l.groupSearchBaseDistNames) == 0 {
-			return Validation{
-				Result: GroupSearchParamsMisconfigured,
-				Detail: "Group Search Base DN is required.",
-				Suggestion: `Since you entered a value for the Group Search Filter - enter a value for the Group Search Base DN too:
-    Enter this value as the DN of the subtree where groups will be found.`,
-			}
-		}
-
-		// Validate that BaseDNs represent non-overlapping subtrees.
-		if ancestor, descendant := checkForDNOverlaps(l.groupSearchBaseDistNames); ancestor != "" {
-			return Validation{
-				Result:     GroupSearchParamsMisconfigured,
-				Detail:     fmt.Sprintf("Group Search Base DN `%s` is an ancestor of `%s`", ancestor, descendant),
-				Suggestion: "No two base DNs may overlap - please remove one of them",
-			}
-		}
+	return Validation{Result: ConfigOk, Detail: "User DN search filter is valid"}
+}
 
-		if l.GroupSearchFilter == "" {
-			return Validation{
-				Result: GroupSearchParamsMisconfigured,
-				Detail: "Group Search Filter is required.",
-				Suggestion: `Since you entered a value for the Group Search Base DN - enter a value for the Group Search Filter too. This is a template where, before the query is sent to the server:
+func (l *Config) validateGroupSearchFilter() Validation {
+	if l.GroupSearchFilter == "" {
+		return Validation{
+			Result: GroupSearchParamsMisconfigured,
+			Detail: "Group Search Filter is required.",
+			Suggestion: `Since you entered a value for the Group Search Base DN - enter a value for the Group Search Filter too. This is a template where, before the query is sent to the server:
     "%s" is replaced with the login username;
     "%d" is replaced with the DN of the login user.
     For example: "(&(objectclass=groupOfNames)(memberUid=%s))"`,
-			}
 		}
+	}
 
-		if !strings.Contains(l.GroupSearchFilter, "%d") && !strings.Contains(l.GroupSearchFilter, "%s") {
-			return Validation{
-				Result: GroupSearchParamsMisconfigured,
-				Detail: `GroupSearchFilter must contain at least one of "%s" or "%d"`,
-				Suggestion: `During group membership lookup the group search filter template is used:
+	if !strings.Contains(l.GroupSearchFilter, "%d") && !strings.Contains(l.GroupSearchFilter, "%s") {
+		return Validation{
+			Result: GroupSearchParamsMisconfigured,
+			Detail: `GroupSearchFilter must contain at least one of "%s" or "%d"`,
+			Suggestion: `During group membership lookup the group search filter template is used:
     "%s" gets replaced by the given username, and
     "%d" gets replaced by the user's DN.
     Either one is needed to find only groups that the user is a member of.
     Enter an LDAP search filter template using at least one of these.`,
-			}
 		}
+	}
 
-		// Check that the LDAP filter compiles.
-		if err := compileFilter(l.GroupSearchFilter); err != nil {
-			return Validation{
-				Result:     GroupSearchParamsMisconfigured,
-				Detail:     fmt.Sprintf("Group DN search filter `%s` failed to compile: %v", l.GroupSearchFilter, err),
-				Suggestion: `Ensure that the Group DN search filter is valid`,
-			}
+	// Check that the LDAP filter compiles.
+	if err := compileFilter(l.GroupSearchFilter); err != nil {
+		return Validation{
+			Result:     GroupSearchParamsMisconfigured,
+			Detail:     fmt.Sprintf("Group DN search filter `%s` failed to compile: %v", l.GroupSearchFilter, err),
+			Suggestion: `Ensure that the Group DN search filter is valid`,
 		}
-
 	}
 
-	return Validation{
-		Result: ConfigOk,
+	return Validation{Result: ConfigOk, Detail: "Group search filter is valid"}
+}
+
+func (l *Config) validateGroupNameFormat() Validation {
+	format := strings.ToLower(strings.TrimSpace(l.GroupNameFormat))
+	if format != "" && format != GroupNameFormatDN && format != GroupNameFormatName {
+		return Validation{
+			Result:     GroupSearchParamsMisconfigured,
+			Detail:     fmt.Sprintf("Group name format `%s` is invalid", l.GroupNameFormat),
+			Suggestion: fmt.Sprintf(`Set the group name format to "%s" (default) or "%s"`, GroupNameFormatDN, GroupNameFormatName),
+		}
+	}
+	attr := strings.TrimSpace(l.GroupNameAttribute)
+	if format != GroupNameFormatName {
+		return Validation{Result: ConfigOk, Detail: "Groups are reported by DN"}
+	}
+	if attr == "" {
+		return Validation{
+			Result: GroupSearchParamsMisconfigured,
+			Detail: "Group name attribute is required by the group name format",
+			Suggestion: `Set the group name attribute to the attribute holding the group names,
+    for example "cn" or "msDS-PrincipalName"`,
+		}
 	}
+	if err := validateAttributes([]string{attr}); err != nil {
+		return Validation{
+			Result:     GroupSearchParamsMisconfigured,
+			Detail:     fmt.Sprintf("Group name attribute `%s` is invalid: %v", l.GroupNameAttribute, err),
+			Suggestion: "Ensure that the attribute name is a valid LDAP short name of an attribute (not an OID)",
+		}
+	}
+	return Validation{Result: ConfigOk, Detail: fmt.Sprintf("Groups are reported by the `%s` attribute", attr)}
 }
 
 // ValidateLookup takes a test username and performs user and group lookup (if
@@ -319,15 +489,10 @@ func (l *Config) ValidateLookup(testUsername string) (*UserLookupResult, Validat
 
 	conn, err := l.Connect()
 	if err != nil {
-		return nil, Validation{
-			Result:   ConnectivityError,
-			Detail:   fmt.Sprintf("Could not connect to LDAP server: %v", err),
-			ErrCause: err,
-			Suggestion: `Check:
+		return nil, connectivityError(err, `Check:
     (1) server address
     (2) TLS parameters, and
-    (3) LDAP server's TLS certificate is trusted by MinIO (when using TLS - highly recommended)`,
-		}
+    (3) LDAP server's TLS certificate is trusted by MinIO (when using TLS - highly recommended)`)
 	}
 	defer conn.Close()
 
@@ -365,14 +530,22 @@ func (l *Config) ValidateLookup(testUsername string) (*UserLookupResult, Validat
 		}
 	}
 
-	return &UserLookupResult{
-			DN:                 dnResult.NormDN,
-			DNAttributes:       dnResult.Attributes,
-			GroupDNMemberships: groups,
-		}, Validation{
-			Result: ConfigOk,
-			Detail: "User lookup done.",
-		}
+	result := &UserLookupResult{
+		DN:                 dnResult.NormDN,
+		DNAttributes:       dnResult.Attributes,
+		GroupDNMemberships: groups,
+		GroupNameFormat:    GroupNameFormatDN,
+	}
+	detail := "User lookup done."
+	if l.groupNameMode() {
+		result.GroupNameFormat = GroupNameFormatName
+		detail = fmt.Sprintf("User lookup done, groups are reported by the `%s` attribute.",
+			strings.TrimSpace(l.GroupNameAttribute))
+	}
+	return result, Validation{
+		Result: ConfigOk,
+		Detail: detail,
+	}
 }
 
 // Splits on given delimiter, trims leading/trailing whitespace and removes
@@ -389,25 +562,21 @@ func splitAndTrim(s, sep string) (res []string) {
 	return
 }
 
-// Validates that the given DNs are present in the LDAP server.
-func validateAndParseBaseDNList(conn *ldap.Conn, baseDNList []string) ([]BaseDNInfo, error) {
-	var res []BaseDNInfo
-	for _, dn := range baseDNList {
-		lookupResult, err := LookupDN(conn, dn, nil)
-		if err != nil {
-			return nil, fmt.Errorf("Base DN `%s` lookup failed: %w", dn, err)
-		}
-		if lookupResult == nil {
-			return nil, fmt.Errorf("Base DN `%s` not found in the LDAP server", dn)
-		}
-		serverDN := lookupResult.NormDN
-		parsed, err := ldap.ParseDN(serverDN)
-		if err != nil {
-			return nil, fmt.Errorf("Unexpectedly failed to parse DN `%s`: %w", serverDN, err)
-		}
-		res = append(res, BaseDNInfo{Original: dn, ServerDN: serverDN, Parsed: parsed})
+// Validates that the given DN is present in the LDAP server.
+func validateAndParseBaseDN(conn *ldap.Conn, dn string) (BaseDNInfo, error) {
+	lookupResult, err := LookupDN(conn, dn, nil)
+	if err != nil {
+		return BaseDNInfo{}, fmt.Errorf("Base DN `%s` lookup failed: %w", dn, err)
+	}
+	if lookupResult == nil {
+		return BaseDNInfo{}, fmt.Errorf("Base DN `%s` not found in the LDAP server", dn)
+	}
+	serverDN := lookupResult.NormDN
+	parsed, err := ldap.ParseDN(serverDN)
+	if err != nil {
+		return BaseDNInfo{}, fmt.Errorf("Unexpectedly failed to parse DN `%s`: %w", serverDN, err)
 	}
-	return res, nil
+	return BaseDNInfo{Original: dn, ServerDN: serverDN, Parsed: parsed}, nil
 }
 
 var validAttributeRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]*$`)
@@ -20,6 +20,7 @@ package ldap
 import (
 	"fmt"
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/minio/minio-go/v7/pkg/set"
@@ -295,3 +296,214 @@ func TestConfigValidator(t *testing.T) {
 		}
 	}
 }
+
+type reportEntry struct {
+	section string
+	result  Result
+}
+
+func reportShape(report []Validation) []reportEntry {
+	shape := make([]reportEntry, 0, len(report))
+	for _, v := range report {
+		shape = append(shape, reportEntry{v.Section, v.Result})
+	}
+	return shape
+}
+
+func TestConfigValidateFullOffline(t *testing.T) {
+	testCases := []struct {
+		cfg      Config
+		expected []reportEntry
+	}{
+		{
+			cfg:      Config{},
+			expected: []reportEntry{{SectionConnection, ConfigOk}},
+		},
+		{
+			cfg: Config{
+				Enabled:                  true,
+				UserDNSearchBaseDistName: "ou=people,dc=min,dc=io;ou=admins,dc=min,dc=io",
+				UserDNAttributes:         "mail,1.2.3",
+				UserDNSearchFilter:       "(uid=%d)",
+				GroupSearchBaseDistName:  "ou=groups,dc=min,dc=io",
+			},
+			expected: []reportEntry{
+				{SectionConnection, ConnectionParamMisconfigured},
+				{SectionConnection, ValidationSkipped},
+				{SectionLookupBind, LookupBindError},
+				{SectionUserSearch, ValidationSkipped},
+				{SectionUserSearch, ValidationSkipped},
+				{SectionUserSearch, ValidationSkipped},
+				{SectionUserSearch, UserSearchParamsMisconfigured},
+				{SectionUserSearch, UserSearchParamsMisconfigured},
+				{SectionGroupSearch, ValidationSkipped},
+				{SectionGroupSearch, ValidationSkipped},
+				{SectionGroupSearch, GroupSearchParamsMisconfigured},
+			},
+		},
+		{
+			cfg: Config{
+				Enabled:            true,
+				ServerAddr:         "localhost:389",
+				SRVRecordName:      "thingy",
+				LookupBindDN:       "cn=admin,dc=min,dc=io",
+				UserDNSearchFilter: "(uid=%s)",
+				GroupSearchFilter:  "(member=%d)",
+			},
+			expected: []reportEntry{
+				{SectionConnection, ConnectionParamMisconfigured},
+				{SectionConnection, ValidationSkipped},
+				{SectionLookupBind, ValidationSkipped},
+				{SectionUserSearch, UserSearchParamsMisconfigured},
+				{SectionUserSearch, ConfigOk},
+				{SectionGroupSearch, GroupSearchParamsMisconfigured},
+				{SectionGroupSearch, ConfigOk},
+			},
+		},
+		{
+			// Group name mode requires the group name attribute.
+			cfg: Config{
+				Enabled:            true,
+				ServerAddr:         "localhost:389",
+				SRVRecordName:      "thingy",
+				LookupBindDN:       "cn=admin,dc=min,dc=io",
+				UserDNSearchFilter: "(uid=%s)",
+				GroupSearchFilter:  "(member=%d)",
+				GroupNameFormat:    "Name",
+			},
+			expected: []reportEntry{
+				{SectionConnection, ConnectionParamMisconfigured},
+				{SectionConnection, ValidationSkipped},
+				{SectionLookupBind, ValidationSkipped},
+				{SectionUserSearch, UserSearchParamsMisconfigured},
+				{SectionUserSearch, ConfigOk},
+				{SectionGroupSearch, GroupSearchParamsMisconfigured},
+				{SectionGroupSearch, ConfigOk},
+				{SectionGroupSearch, GroupSearchParamsMisconfigured},
+			},
+		},
+	}
+
+	for i, testCase := range testCases {
+		report := testCase.cfg.ValidateFull()
+		if got := reportShape(report); !reflect.DeepEqual(got, testCase.expected) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, got)
+		}
+		// Validate reports the first failure of the full report.
+		expected := Validation{Result: ConfigOk}
+		for _, v := range report {
+			if !v.IsOk() && v.Result != ValidationSkipped {
+				expected = v
+				break
+			}
+		}
+		if got := testCase.cfg.Validate(); got.Result != expected.Result || (!got.IsOk() && got.Detail != expected.Detail) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, expected.Detail, got.Detail)
+		}
+	}
+}
+
+func TestConfigValidateFull(t *testing.T) {
+	ldapServer := os.Getenv(EnvTestLDAPServer)
+	if ldapServer == "" {
+		t.Logf("Skipping test as %s is not set", EnvTestLDAPServer)
+		t.Skip()
+	}
+	newConfig := func() Config {
+		v := Config{Enabled: true}
+		v.ServerAddr = ldapServer
+		v.ServerInsecure = true
+		v.LookupBindDN = "cn=admin,dc=min,dc=io"
+		v.LookupBindPassword = "admin"
+		v.UserDNSearchFilter = "(uid=%s)"
+		v.UserDNSearchBaseDistName = "ou=people,ou=swengg,dc=min,dc=io"
+		v.GroupSearchBaseDistName = "ou=swengg,dc=min,dc=io"
+		v.GroupSearchFilter = "(&(objectclass=groupofnames)(member=%d))"
+		return v
+	}
+	testCases := []struct {
+		cfg      Config
+		expected []reportEntry
+	}{
+		{
+			cfg: newConfig(),
+			expected: []reportEntry{
+				{SectionConnection, ConfigOk},
+				{SectionConnection, ConfigOk},
+				{SectionLookupBind, ConfigOk},
+				{SectionUserSearch, ConfigOk},
+				{SectionUserSearch, ConfigOk},
+				{SectionUserSearch, ConfigOk},
+				{SectionGroupSearch, ConfigOk},
+				{SectionGroupSearch, ConfigOk},
+				{SectionGroupSearch, ConfigOk},
+			},
+		},
+		{
+			// Base DN lookups are skipped if the lookup bind fails.
+			cfg: func() Config {
+				v := newConfig()
+				v.LookupBindPassword = "admin1"
+				return v
+			}(),
+			expected: []reportEntry{
+				{SectionConnection, ConfigOk},
+				{SectionConnection, ConfigOk},
+				{SectionLookupBind, LookupBindError},
+				{SectionUserSearch, ValidationSkipped},
+				{SectionUserSearch, ValidationSkipped},
+				{SectionUserSearch, ConfigOk},
+				{SectionGroupSearch, ValidationSkipped},
+				{SectionGroupSearch, ValidationSkipped},
+				{SectionGroupSearch, ConfigOk},
+			},
+		},
+		{
+			// All failures are reported, each base DN separately.
+			cfg: func() Config {
+				v := newConfig()
+				v.UserDNSearchBaseDistName = "ou=people,ou=swengg,dc=min,dc=io;ou=missing,dc=min,dc=io"
+				v.UserDNSearchFilter = "(uid=%s"
+				v.GroupSearchFilter = "(member=x)"
+				return v
+			}(),
+			expected: []reportEntry{
+				{SectionConnection, ConfigOk},
+				{SectionConnection, ConfigOk},
+				{SectionLookupBind, ConfigOk},
+				{SectionUserSearch, ConfigOk},
+				{SectionUserSearch, UserSearchParamsMisconfigured},
+				{SectionUserSearch, ValidationSkipped},
+				{SectionUserSearch, UserSearchParamsMisconfigured},
+				{SectionGroupSearch, ConfigOk},
+				{SectionGroupSearch, ConfigOk},
+				{SectionGroupSearch, GroupSearchParamsMisconfigured},
+			},
+		},
+		{
+			cfg: func() Config {
+				v := newConfig()
+				v.UserDNSearchBaseDistName = "dc=min,dc=io;ou=people,ou=swengg,dc=min,dc=io"
+				return v
+			}(),
+			expected: []reportEntry{
+				{SectionConnection, ConfigOk},
+				{SectionConnection, ConfigOk},
+				{SectionLookupBind, ConfigOk},
+				{SectionUserSearch, ConfigOk},
+				{SectionUserSearch, ConfigOk},
+				{SectionUserSearch, UserSearchParamsMisconfigured},
+				{SectionUserSearch, ConfigOk},
+				{SectionGroupSearch, ConfigOk},
+				{SectionGroupSearch, ConfigOk},
+				{SectionGroupSearch, ConfigOk},
+			},
+		},
+	}
+
+	for i, testCase := range testCases {
+		if got := reportShape(testCase.cfg.ValidateFull()); !reflect.DeepEqual(got, testCase.expected) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, got)
+		}
+	}
+}
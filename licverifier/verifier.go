@@ -19,12 +19,15 @@
 package licverifier
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"os"
+	"sync/atomic"
 	"time"
 
 	"github.com/lestrrat-go/jwx/v2/jwa"
@@ -36,6 +39,34 @@ import (
 // LicenseVerifier needs an ECDSA public key in PEM format for initialization.
 type LicenseVerifier struct {
 	keySet jwk.Set
+	now    func() time.Time
+
+	denylist       atomic.Pointer[denylist]
+	denylistMaxAge atomic.Int64
+}
+
+// DefaultDenylistMaxAge is the maximum age of a denylist accepted by
+// SetDenylist, unless changed with SetDenylistMaxAge.
+const DefaultDenylistMaxAge = 30 * 24 * time.Hour
+
+// ErrStaleDenylist is returned by SetDenylist for a denylist older than
+// the maximum age, or than the denylist in use.
+var ErrStaleDenylist = errors.New("stale license denylist")
+
+// ErrRevoked is returned by Verify for a license present in the denylist.
+type ErrRevoked struct {
+	ID        string    // License ID or JWT ID of the revoked license
+	RevokedAt time.Time // Time of revocation
+}
+
+func (e ErrRevoked) Error() string {
+	return fmt.Sprintf("license %s was revoked at %s", e.ID, e.RevokedAt.UTC().Format(time.RFC3339))
+}
+
+// denylist holds the revoked license IDs and JWT IDs.
+type denylist struct {
+	issuedAt time.Time
+	revoked  map[string]time.Time
 }
 
 // LicenseInfo holds customer metadata present in the license key.
@@ -67,6 +98,11 @@ const (
 	trial        = "trial"
 )
 
+// denylist JSON field names
+const (
+	revoked = "revoked"
+)
+
 // parse PEM encoded PKCS1 or PKCS8 public key
 func parseECPublicKeyFromPEM(key []byte) (*ecdsa.PublicKey, error) {
 	var err error
@@ -110,9 +146,12 @@ func NewLicenseVerifier(pemBytes []byte) (*LicenseVerifier, error) {
 	key.Set(jwk.AlgorithmKey, jwa.ES384)
 	keyset := jwk.NewSet()
 	keyset.AddKey(key)
-	return &LicenseVerifier{
+	lv := &LicenseVerifier{
 		keySet: keyset,
-	}, nil
+		now:    time.Now,
+	}
+	lv.denylistMaxAge.Store(int64(DefaultDenylistMaxAge))
+	return lv, nil
 }
 
 // toLicenseInfo extracts LicenseInfo from claims. It returns an error if any of
@@ -183,5 +222,91 @@ func (lv *LicenseVerifier) Verify(license string, options ...jwt.ParseOption) (L
 		return LicenseInfo{}, fmt.Errorf("failed to verify license: %s", err)
 	}
 
-	return toLicenseInfo(license, token)
+	licInfo, err := toLicenseInfo(license, token)
+	if err != nil {
+		return LicenseInfo{}, err
+	}
+	if dl := lv.denylist.Load(); dl != nil {
+		for _, id := range []string{licInfo.LicenseID, token.JwtID()} {
+			if revokedAt, ok := dl.revoked[id]; ok && id != "" {
+				return LicenseInfo{}, ErrRevoked{ID: id, RevokedAt: revokedAt}
+			}
+		}
+	}
+	return licInfo, nil
+}
+
+// toDenylist extracts the denylist from claims. The revoked claim maps
+// revoked license IDs and JWT IDs to their revocation time, as for
+// {"iat": 1700000000, "revoked": {"<lid>": 1690000000}}.
+func toDenylist(token jwt.Token) (*denylist, error) {
+	if token.IssuedAt().IsZero() {
+		return nil, errors.New("Invalid issuedAt in denylist claims")
+	}
+	claim, ok := token.PrivateClaims()[revoked].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("Invalid revoked in denylist claims")
+	}
+
+	dl := &denylist{
+		issuedAt: token.IssuedAt(),
+		revoked:  make(map[string]time.Time, len(claim)),
+	}
+	for id, v := range claim {
+		revokedAt, ok := v.(float64)
+		if !ok || id == "" {
+			return nil, fmt.Errorf("Invalid revoked license %q in denylist claims", id)
+		}
+		dl.revoked[id] = time.Unix(int64(revokedAt), 0)
+	}
+	return dl, nil
+}
+
+// SetDenylist verifies the denylist of revoked licenses in data, a JWT
+// signed by the same keys as the licenses, and uses it for all subsequent
+// Verify calls. A denylist older than the maximum age, or than the
+// denylist in use, is rejected with ErrStaleDenylist. It is safe to call
+// SetDenylist concurrently with Verify.
+func (lv *LicenseVerifier) SetDenylist(data []byte) error {
+	now := lv.now()
+	token, err := jwt.Parse(bytes.TrimSpace(data),
+		jwt.WithKeySet(lv.keySet, jws.WithUseDefault(true)),
+		jwt.WithValidate(true),
+		jwt.WithClock(jwt.ClockFunc(func() time.Time { return now })),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to verify license denylist: %s", err)
+	}
+	dl, err := toDenylist(token)
+	if err != nil {
+		return err
+	}
+
+	if maxAge := time.Duration(lv.denylistMaxAge.Load()); maxAge > 0 && now.Sub(dl.issuedAt) > maxAge {
+		return fmt.Errorf("%w: issued at %s", ErrStaleDenylist, dl.issuedAt.UTC().Format(time.RFC3339))
+	}
+	for {
+		current := lv.denylist.Load()
+		if current != nil && dl.issuedAt.Before(current.issuedAt) {
+			return fmt.Errorf("%w: issued before the denylist in use", ErrStaleDenylist)
+		}
+		if lv.denylist.CompareAndSwap(current, dl) {
+			return nil
+		}
+	}
+}
+
+// LoadDenylist calls SetDenylist with the content of the file at path.
+func (lv *LicenseVerifier) LoadDenylist(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return lv.SetDenylist(data)
+}
+
+// SetDenylistMaxAge sets the maximum age of a denylist accepted by
+// SetDenylist, DefaultDenylistMaxAge by default. Zero disables the check.
+func (lv *LicenseVerifier) SetDenylistMaxAge(maxAge time.Duration) {
+	lv.denylistMaxAge.Store(int64(maxAge))
 }
@@ -18,10 +18,19 @@
 package licverifier
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwt"
 )
 
@@ -89,6 +98,195 @@ mr/cKCUyBL7rcAvg0zNq1vcSrUSGlAmY3SEDCu3GOKnjG/U4E7+p957ocWSV+mQU
 	}
 }
 
+// newTestSigner returns a verifier of the tokens signed by the returned
+// function, whose clock is fixed at now.
+func newTestSigner(t *testing.T, now time.Time) (*LicenseVerifier, func(claims map[string]interface{}) []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lv, err := NewLicenseVerifier(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lv.now = func() time.Time { return now }
+
+	sign := func(claims map[string]interface{}) []byte {
+		token := jwt.New()
+		for k, v := range claims {
+			if err := token.Set(k, v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		signed, err := jwt.Sign(token, jwt.WithKey(jwa.ES384, key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+	return lv, sign
+}
+
+func TestLicenseDenylist(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	lv, sign := newTestSigner(t, now)
+	clock := jwt.WithClock(jwt.ClockFunc(func() time.Time { return now }))
+
+	license := func(lid, jti string) string {
+		return string(sign(map[string]interface{}{
+			jwt.SubjectKey:    "admin@example.com",
+			jwt.IssuedAtKey:   now.Add(-24 * time.Hour),
+			jwt.ExpirationKey: now.Add(365 * 24 * time.Hour),
+			jwt.JwtIDKey:      jti,
+			licenseID:         lid,
+			accountID:         1,
+			organization:      "Example Inc.",
+			capacity:          10,
+			plan:              "ENTERPRISE",
+		}))
+	}
+	denylistAt := func(iat time.Time, revokedIDs ...string) []byte {
+		ids := map[string]interface{}{}
+		for _, id := range revokedIDs {
+			ids[id] = iat.Add(-time.Hour).Unix()
+		}
+		return sign(map[string]interface{}{jwt.IssuedAtKey: iat, revoked: ids})
+	}
+
+	lic1, lic2 := license("lid-1", "jti-1"), license("lid-2", "jti-2")
+	for _, lic := range []string{lic1, lic2} {
+		if _, err := lv.Verify(lic, clock); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// Unknown IDs revoke nothing.
+	if err := lv.SetDenylist(denylistAt(now.Add(-3*time.Hour), "lid-3", "jti-3")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := lv.Verify(lic1, clock); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Licenses are revoked by license ID as well as JWT ID.
+	if err := lv.SetDenylist(denylistAt(now.Add(-2*time.Hour), "lid-1", "jti-2")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, testCase := range []struct {
+		lic string
+		id  string
+	}{{lic1, "lid-1"}, {lic2, "jti-2"}} {
+		var revokedErr ErrRevoked
+		if _, err := lv.Verify(testCase.lic, clock); !errors.As(err, &revokedErr) {
+			t.Fatalf("expected: ErrRevoked, got: %v", err)
+		}
+		if expected := (ErrRevoked{ID: testCase.id, RevokedAt: now.Add(-3 * time.Hour)}); !revokedErr.RevokedAt.Equal(expected.RevokedAt) || revokedErr.ID != expected.ID {
+			t.Fatalf("expected: %v, got: %v", expected, revokedErr)
+		}
+	}
+
+	// Rotation to a newer denylist, loaded from a file.
+	path := filepath.Join(t.TempDir(), "denylist.jwt")
+	if err := os.WriteFile(path, append(denylistAt(now.Add(-time.Hour), "lid-2"), '\n'), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := lv.LoadDenylist(path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := lv.Verify(lic1, clock); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := lv.Verify(lic2, clock); !errors.As(err, &ErrRevoked{}) {
+		t.Fatalf("expected: ErrRevoked, got: %v", err)
+	}
+
+	// Older denylists are rejected and the current one is kept.
+	for i, data := range [][]byte{
+		denylistAt(now.Add(-2 * time.Hour)),
+		denylistAt(now.Add(-DefaultDenylistMaxAge - time.Second)),
+	} {
+		if err := lv.SetDenylist(data); !errors.Is(err, ErrStaleDenylist) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, ErrStaleDenylist, err)
+		}
+	}
+	if _, err := lv.Verify(lic2, clock); !errors.As(err, &ErrRevoked{}) {
+		t.Fatalf("expected: ErrRevoked, got: %v", err)
+	}
+
+	// Denylists must be signed by the trusted keys and licenses are no
+	// denylists.
+	_, otherSign := newTestSigner(t, now)
+	for i, data := range [][]byte{
+		otherSign(map[string]interface{}{jwt.IssuedAtKey: now, revoked: map[string]interface{}{}}),
+		[]byte(lic1),
+		sign(map[string]interface{}{revoked: map[string]interface{}{}}),
+		sign(map[string]interface{}{jwt.IssuedAtKey: now, revoked: map[string]interface{}{"lid-1": "yesterday"}}),
+		[]byte("not a token"),
+	} {
+		if err := lv.SetDenylist(data); err == nil || errors.Is(err, ErrStaleDenylist) {
+			t.Fatalf("case %v: expected invalid denylist error, got: %v", i+1, err)
+		}
+	}
+
+	// The maximum age can be disabled.
+	lv.SetDenylistMaxAge(0)
+	lv.denylist.Store(nil)
+	if err := lv.SetDenylist(denylistAt(now.Add(-2 * DefaultDenylistMaxAge))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := lv.Verify(lic2, clock); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// TestLicenseDenylistConcurrent revokes a license while it is verified.
+func TestLicenseDenylistConcurrent(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	lv, sign := newTestSigner(t, now)
+	clock := jwt.WithClock(jwt.ClockFunc(func() time.Time { return now }))
+
+	lic := string(sign(map[string]interface{}{
+		jwt.IssuedAtKey: now,
+		licenseID:       "lid-1",
+		accountID:       1,
+		organization:    "Example Inc.",
+		capacity:        10,
+		plan:            "ENTERPRISE",
+	}))
+
+	done := make(chan error)
+	go func() {
+		var revokedSeen bool
+		for i := 0; i < 200; i++ {
+			_, err := lv.Verify(lic, clock)
+			switch {
+			case errors.As(err, &ErrRevoked{}):
+				revokedSeen = true
+			case err != nil:
+				done <- err
+				return
+			case revokedSeen:
+				done <- errors.New("license verified after it was revoked")
+				return
+			}
+		}
+		done <- nil
+	}()
+	if err := lv.SetDenylist(sign(map[string]interface{}{jwt.IssuedAtKey: now, revoked: map[string]interface{}{"lid-1": now.Unix()}})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := lv.Verify(lic, clock); !errors.As(err, &ErrRevoked{}) {
+		t.Fatalf("expected: ErrRevoked, got: %v", err)
+	}
+}
+
 // Example creates a LicenseVerifier using the ECDSA public key in pemBytes. It
 // uses the Verify method of the LicenseVerifier to verify and extract the
 // claims present in the license key.
@@ -0,0 +1,114 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package net
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/minio/pkg/v3/wildcard"
+)
+
+// domainLabels - returns the lower cased labels of the domain name s,
+// without the port and trailing dot of fully qualified names.
+func domainLabels(s string) []string {
+	s, _ = splitHostPort(s)
+	s = strings.TrimSuffix(strings.ToLower(s), ".")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ".")
+}
+
+// matchDomain - returns the number of leading labels of host before
+// domain, or -1 if host is not domain or one of its subdomains. Each
+// label of domain may be a wildcard pattern matching a single label of
+// host, e.g. "*.example.com" or "minio-*.example.com".
+func matchDomain(host, domain []string) int {
+	n := len(host) - len(domain)
+	if len(domain) == 0 || n < 0 {
+		return -1
+	}
+	for i, label := range domain {
+		if !wildcard.Match(label, host[n+i]) {
+			return -1
+		}
+	}
+	return n
+}
+
+// ExtractBucketObject - returns the bucket and the object addressed by
+// the S3 request URL u, served by the given domains. Requests to a
+// subdomain of a domain are virtual-host style, e.g.
+// "http://mybucket.minio.example.com/myobject", the bucket is the
+// subdomain and may contain dots. Other requests, including requests to
+// IP addresses and unknown hosts, are path style, e.g.
+// "http://minio.example.com/mybucket/myobject", and pathStyle is set.
+//
+// Domains are matched case insensitively, ignoring ports, and each of
+// their labels may be a wildcard pattern matching a single label of the
+// host. If several domains match, the longest one is used. Bucket and
+// object are unescaped, the object is empty for requests to the bucket
+// itself, as for listings, and both are empty for requests to the service
+// root in path style.
+func ExtractBucketObject(u *url.URL, domains []string) (bucket, object string, pathStyle bool, err error) {
+	if u == nil {
+		return "", "", false, errors.New("url must not be nil")
+	}
+
+	escapedPath := strings.TrimPrefix(u.EscapedPath(), "/")
+	pathStyle = true
+	if host, _ := splitHostPort(u.Host); net.ParseIP(host) == nil {
+		hostLabels := domainLabels(host)
+		n := -1
+		for _, domain := range domains {
+			labels := domainLabels(domain)
+			// Prefer the longest domain, i.e. the shortest bucket.
+			if m := matchDomain(hostLabels, labels); m >= 0 && (n < 0 || m < n) {
+				n = m
+			}
+		}
+		if n > 0 {
+			if slices.Contains(hostLabels[:n], "") {
+				return "", "", false, fmt.Errorf("invalid bucket name in host %q", u.Host)
+			}
+			bucket = strings.Join(hostLabels[:n], ".")
+			pathStyle = false
+		}
+	}
+
+	if pathStyle {
+		var escapedBucket string
+		escapedBucket, escapedPath, _ = strings.Cut(escapedPath, "/")
+		if bucket, err = url.PathUnescape(escapedBucket); err != nil {
+			return "", "", false, fmt.Errorf("invalid bucket name in path %q: %w", u.EscapedPath(), err)
+		}
+		if strings.Contains(bucket, "/") {
+			return "", "", false, fmt.Errorf("invalid bucket name in path %q", u.EscapedPath())
+		}
+	}
+
+	if object, err = url.PathUnescape(escapedPath); err != nil {
+		return "", "", false, fmt.Errorf("invalid object name in path %q: %w", u.EscapedPath(), err)
+	}
+	return bucket, object, pathStyle, nil
+}
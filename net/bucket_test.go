@@ -0,0 +1,108 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package net
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestExtractBucketObject(t *testing.T) {
+	domains := []string{"minio.example.com", "s3.*.example.org"}
+	testCases := []struct {
+		url       string
+		domains   []string
+		bucket    string
+		object    string
+		pathStyle bool
+		expectErr bool
+	}{
+		// Path style.
+		{"http://minio.example.com/mybucket/myobject", domains, "mybucket", "myobject", true, false},
+		{"http://minio.example.com:9000/mybucket/my/object", domains, "mybucket", "my/object", true, false},
+		{"http://minio.example.com/mybucket", domains, "mybucket", "", true, false},
+		{"http://minio.example.com/mybucket/", domains, "mybucket", "", true, false},
+		{"http://minio.example.com/", domains, "", "", true, false},
+		{"http://minio.example.com", domains, "", "", true, false},
+		{"http://minio.example.com/my.bucket/myobject", domains, "my.bucket", "myobject", true, false},
+		{"http://minio.example.com/mybucket/mydir/", domains, "mybucket", "mydir/", true, false},
+		{"http://minio.example.com/mybucket//myobject", domains, "mybucket", "/myobject", true, false},
+		{"http://MINIO.Example.COM./mybucket/myobject", domains, "mybucket", "myobject", true, false},
+		// Unknown hosts and IP addresses are path style.
+		{"http://localhost:9000/mybucket/myobject", domains, "mybucket", "myobject", true, false},
+		{"http://mybucket.example.com/myobject", domains, "myobject", "", true, false},
+		{"http://10.0.0.1:9000/mybucket/myobject", domains, "mybucket", "myobject", true, false},
+		{"http://[::1]:9000/mybucket/myobject", domains, "mybucket", "myobject", true, false},
+		{"http://mybucket.minio.example.com/myobject", nil, "myobject", "", true, false},
+		{"/mybucket/myobject", domains, "mybucket", "myobject", true, false},
+		// Virtual-host style.
+		{"http://mybucket.minio.example.com/myobject", domains, "mybucket", "myobject", false, false},
+		{"http://mybucket.minio.example.com:9000/my/object", domains, "mybucket", "my/object", false, false},
+		{"http://mybucket.minio.example.com/", domains, "mybucket", "", false, false},
+		{"http://mybucket.minio.example.com", domains, "mybucket", "", false, false},
+		{"http://mybucket.minio.example.com/mydir/", domains, "mybucket", "mydir/", false, false},
+		{"http://MyBucket.MINIO.example.com/MyObject", domains, "mybucket", "MyObject", false, false},
+		{"http://mybucket.minio.example.com./myobject", domains, "mybucket", "myobject", false, false},
+		{"http://my.bucket.minio.example.com/myobject", domains, "my.bucket", "myobject", false, false},
+		{"http://my.bucket.minio.example.com/mybucket/myobject", domains, "my.bucket", "mybucket/myobject", false, false},
+		// Bucket name equal to a domain label.
+		{"http://minio.minio.example.com/myobject", domains, "minio", "myobject", false, false},
+		{"http://example.minio.example.com/", domains, "example", "", false, false},
+		{"http://minio.example.com/minio/example", domains, "minio", "example", true, false},
+		// Domains with ports.
+		{"http://mybucket.minio.example.com:9000/myobject", []string{"minio.example.com:9000"}, "mybucket", "myobject", false, false},
+		{"http://mybucket.minio.example.com/myobject", []string{"MINIO.EXAMPLE.COM."}, "mybucket", "myobject", false, false},
+		// Wildcard domains match a single label.
+		{"http://mybucket.s3.us-east-1.example.org/myobject", domains, "mybucket", "myobject", false, false},
+		{"http://my.bucket.s3.us-east-1.example.org/myobject", domains, "my.bucket", "myobject", false, false},
+		{"http://s3.us-east-1.example.org/mybucket/myobject", domains, "mybucket", "myobject", true, false},
+		{"http://s3.example.org/mybucket/myobject", domains, "mybucket", "myobject", true, false},
+		{"http://mybucket.minio-1.example.com/myobject", []string{"minio-*.example.com"}, "mybucket", "myobject", false, false},
+		// The longest domain is used.
+		{"http://mybucket.minio.example.com/myobject", []string{"example.com", "minio.example.com"}, "mybucket", "myobject", false, false},
+		{"http://mybucket.other.example.com/myobject", []string{"example.com", "minio.example.com"}, "mybucket.other", "myobject", false, false},
+		// Encoded names are unescaped once.
+		{"http://minio.example.com/mybucket/my%20object", domains, "mybucket", "my object", true, false},
+		{"http://minio.example.com/mybucket/my%2Fobject", domains, "mybucket", "my/object", true, false},
+		{"http://minio.example.com/mybucket/my%2525object", domains, "mybucket", "my%25object", true, false},
+		{"http://minio.example.com/mybucket/my+object", domains, "mybucket", "my+object", true, false},
+		{"http://mybucket.minio.example.com/%E2%82%AC%3Fx", domains, "mybucket", "€?x", false, false},
+		{"http://minio.example.com/my%2Fbucket/myobject", domains, "", "", false, true},
+		// Invalid bucket names.
+		{"http://my..bucket.minio.example.com/myobject", domains, "", "", false, true},
+		{"http://.minio.example.com/myobject", domains, "", "", false, true},
+	}
+
+	for i, testCase := range testCases {
+		u, err := url.Parse(testCase.url)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		bucket, object, pathStyle, err := ExtractBucketObject(u, testCase.domains)
+		if expectErr := err != nil; expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if bucket != testCase.bucket || object != testCase.object || pathStyle != testCase.pathStyle {
+			t.Fatalf("case %v: expected: %q %q %v, got: %q %q %v", i+1, testCase.bucket, testCase.object, testCase.pathStyle, bucket, object, pathStyle)
+		}
+	}
+
+	if _, _, _, err := ExtractBucketObject(nil, domains); err == nil {
+		t.Fatalf("expected error for nil url")
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"encoding/json"
 	"errors"
 	"net"
+	"net/netip"
+	"net/url"
 	"regexp"
 	"strings"
 )
@@ -45,12 +47,24 @@ func (host Host) String() string {
 		return host.Name
 	}
 
-	return net.JoinHostPort(host.Name, host.Port.String())
+	return JoinHostPort(host.Name, host.Port.String())
 }
 
-// Equal - checks whether given host is equal or not.
+// Equal - checks whether given host is equal or not. Host names are
+// compared case-insensitively and IP addresses in their normalized
+// form, i.e. '::1' is equal to '0:0:0:0:0:0:0:1'.
 func (host Host) Equal(compHost Host) bool {
-	return host.String() == compHost.String()
+	if host.IsPortSet != compHost.IsPortSet || host.Port != compHost.Port {
+		return false
+	}
+
+	ip, err1 := netip.ParseAddr(host.Name)
+	compIP, err2 := netip.ParseAddr(compHost.Name)
+	if err1 == nil && err2 == nil {
+		return ip.Unmap() == compIP.Unmap()
+	}
+
+	return strings.EqualFold(host.Name, compHost.Name)
 }
 
 // MarshalJSON - converts Host into JSON data
@@ -124,13 +138,11 @@ func ParseHost(s string) (*Host, error) {
 
 	var port Port
 	var isPortSet bool
-	host, portStr, err := net.SplitHostPort(s)
+	host, portStr, hasPort, err := splitAddr(s)
 	if err != nil {
-		if !strings.Contains(err.Error(), "missing port in address") {
-			return nil, err
-		}
-		host = s
-	} else {
+		return nil, err
+	}
+	if hasPort {
 		if port, err = ParsePort(portStr); err != nil {
 			return nil, err
 		}
@@ -138,13 +150,6 @@ func ParseHost(s string) (*Host, error) {
 		isPortSet = true
 	}
 
-	if host != "" {
-		host, err = trimIPv6(host)
-		if err != nil {
-			return nil, err
-		}
-	}
-
 	// IPv6 requires a link-local address on every network interface.
 	// `%interface` should be preserved.
 	trimmedHost := host
@@ -165,14 +170,101 @@ func ParseHost(s string) (*Host, error) {
 	}, nil
 }
 
-// IPv6 can be embedded with square brackets.
-func trimIPv6(host string) (string, error) {
-	// `missing ']' in host` error is already handled in `SplitHostPort`
-	if host[len(host)-1] == ']' {
-		if host[0] != '[' {
-			return "", errors.New("missing '[' in host")
+// SplitHostPort splits addr into host and port. Unlike net.SplitHostPort
+// the port is optional and addr may be one of 'host', 'host:port', '[v6]',
+// '[v6]:port' or a full URL such as 'https://host:port/path'. Square
+// brackets are removed from IPv6 addresses and IPv6 zone identifiers such
+// as '%eth0' are preserved. For URLs without an explicit port the default
+// port of the 'http' and 'https' schemes is returned.
+func SplitHostPort(addr string) (host, port string, err error) {
+	if strings.Contains(addr, "://") {
+		u, err := url.Parse(addr)
+		if err != nil {
+			return "", "", err
+		}
+		if u.Host == "" {
+			return "", "", errors.New("missing host in address")
+		}
+		host, port, _, err = splitAddr(u.Host)
+		if err != nil {
+			return "", "", err
+		}
+		if port == "" {
+			switch strings.ToLower(u.Scheme) {
+			case "http":
+				port = "80"
+			case "https":
+				port = "443"
+			}
+		}
+		return host, port, nil
+	}
+
+	host, port, _, err = splitAddr(addr)
+	return host, port, err
+}
+
+// SplitHostPortWithDefault is like SplitHostPort but returns defaultPort
+// when addr does not specify a port.
+func SplitHostPortWithDefault(addr, defaultPort string) (host, port string, err error) {
+	host, port, err = SplitHostPort(addr)
+	if err != nil {
+		return "", "", err
+	}
+	if port == "" {
+		port = defaultPort
+	}
+	return host, port, nil
+}
+
+// JoinHostPort combines host and port into an address of the form
+// 'host:port'. IPv6 addresses are enclosed in square brackets, also when
+// port is empty, in which case the port is omitted.
+func JoinHostPort(host, port string) string {
+	if strings.IndexByte(host, ':') >= 0 && !strings.HasPrefix(host, "[") {
+		host = "[" + host + "]"
+	}
+	if port == "" {
+		return host
+	}
+	return host + ":" + port
+}
+
+// splitAddr splits addr of the form 'host', 'host:port', '[v6]' or
+// '[v6]:port' into host and port, hasPort reports whether a port
+// separator was present.
+func splitAddr(addr string) (host, port string, hasPort bool, err error) {
+	if strings.HasPrefix(addr, "[") {
+		end := strings.IndexByte(addr, ']')
+		if end < 0 {
+			return "", "", false, errors.New("missing ']' in address")
+		}
+		host = addr[1:end]
+		switch rest := addr[end+1:]; {
+		case rest == "":
+		case rest[0] == ':':
+			port, hasPort = rest[1:], true
+		default:
+			return "", "", false, errors.New("unexpected characters after ']' in address")
+		}
+	} else {
+		switch strings.Count(addr, ":") {
+		case 0:
+			host = addr
+		case 1:
+			host, port, _ = strings.Cut(addr, ":")
+			hasPort = true
+		default:
+			return "", "", false, errors.New("too many colons in address")
 		}
-		return host[1:][:len(host)-2], nil
 	}
-	return host, nil
+
+	if strings.ContainsAny(host, "[]") {
+		return "", "", false, errors.New("unexpected '[' or ']' in host")
+	}
+	if hasPort && port == "" {
+		return "", "", false, errors.New("missing port in address")
+	}
+
+	return host, port, hasPort, nil
 }
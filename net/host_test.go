@@ -19,6 +19,7 @@ package net
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -73,6 +74,14 @@ func TestHostEqual(t *testing.T) {
 		{Host{"", 0, true}, Host{"", 0, true}, true},
 		{Host{"play", 9000, false}, Host{"play", 9000, false}, true},
 		{Host{"play", 9000, true}, Host{"play", 9000, true}, true},
+		{Host{"Play.MIN.io", 9000, true}, Host{"play.min.io", 9000, true}, true},
+		{Host{"play.min.io", 9000, true}, Host{"play.min.io", 9001, true}, false},
+		{Host{"::1", 9000, true}, Host{"0:0:0:0:0:0:0:1", 9000, true}, true},
+		{Host{"::1", 0, false}, Host{"0:0:0:0:0:0:0:1", 0, false}, true},
+		{Host{"::ffff:127.0.0.1", 0, false}, Host{"127.0.0.1", 0, false}, true},
+		{Host{"FE80::1%eth0", 0, false}, Host{"fe80::1%eth0", 0, false}, true},
+		{Host{"fe80::1%eth0", 0, false}, Host{"fe80::1%eth1", 0, false}, false},
+		{Host{"127.0.0.1", 0, false}, Host{"127.0.0.2", 0, false}, false},
 	}
 
 	for i, testCase := range testCases {
@@ -102,6 +111,8 @@ func TestHostMarshalJSON(t *testing.T) {
 		{Host{"12play", 0, false}, []byte(`"12play"`), false},
 		{Host{"play-minio-io", 0, false}, []byte(`"play-minio-io"`), false},
 		{Host{"play--min.io", 0, false}, []byte(`"play--min.io"`), false},
+		{Host{"::1", 0, false}, []byte(`"::1"`), false},
+		{Host{"fe80::1%eth0", 9000, true}, []byte(`"[fe80::1%eth0]:9000"`), false},
 	}
 
 	for i, testCase := range testCases {
@@ -227,30 +238,113 @@ func TestParseHost(t *testing.T) {
 	}
 }
 
-func TestTrimIPv6(t *testing.T) {
+func TestSplitHostPort(t *testing.T) {
 	testCases := []struct {
-		IP         string
-		expectedIP string
-		expectErr  bool
+		addr         string
+		expectedHost string
+		expectedPort string
+		expectErr    bool
 	}{
-		{"[fe80::8097:76eb:b397:e067%wlp2s0]", "fe80::8097:76eb:b397:e067%wlp2s0", false},
-		{"fe80::8097:76eb:b397:e067%wlp2s0]", "fe80::8097:76eb:b397:e067%wlp2s0", true},
-		{"[fe80::8097:76eb:b397:e067%wlp2s0]]", "fe80::8097:76eb:b397:e067%wlp2s0]", false},
-		{"[[fe80::8097:76eb:b397:e067%wlp2s0]]", "[fe80::8097:76eb:b397:e067%wlp2s0]", false},
+		{"play", "play", "", false},
+		{"play:9000", "play", "9000", false},
+		{"play.min.io:https", "play.min.io", "https", false},
+		{":9000", "", "9000", false},
+		{"147.75.201.93", "147.75.201.93", "", false},
+		{"147.75.201.93:9000", "147.75.201.93", "9000", false},
+		{"[::1]", "::1", "", false},
+		{"[::1]:9000", "::1", "9000", false},
+		{"[fe80::8097:76eb:b397:e067%wlp2s0]", "fe80::8097:76eb:b397:e067%wlp2s0", "", false},
+		{"[fe80::1%eth0]:9000", "fe80::1%eth0", "9000", false},
+		{"http://play.min.io", "play.min.io", "80", false},
+		{"https://play.min.io", "play.min.io", "443", false},
+		{"https://play.min.io:9000/bucket/object", "play.min.io", "9000", false},
+		{"http://[::1]:9000", "::1", "9000", false},
+		{"http://[fe80::1%25eth0]:9000", "fe80::1%eth0", "9000", false},
+		{"ftp://play.min.io", "play.min.io", "", false},
+		{"play:", "", "", true},
+		{"play::", "", "", true},
+		{"::1", "", "", true},
+		{"fe80::1%eth0", "", "", true},
+		{"[::1", "", "", true},
+		{"[::1]9000", "", "", true},
+		{"[[::1]]", "", "", true},
+		{"::1]", "", "", true},
+		{"http://", "", "", true},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.addr, func(t *testing.T) {
+			host, port, err := SplitHostPort(testCase.addr)
+			expectErr := (err != nil)
+
+			if expectErr != testCase.expectErr {
+				t.Fatalf("error: expected: %v, got: %v", testCase.expectErr, err)
+			}
+
+			if !testCase.expectErr {
+				if host != testCase.expectedHost {
+					t.Errorf("host: expected: %v, got: %v", testCase.expectedHost, host)
+				}
+				if port != testCase.expectedPort {
+					t.Errorf("port: expected: %v, got: %v", testCase.expectedPort, port)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitHostPortWithDefault(t *testing.T) {
+	testCases := []struct {
+		addr         string
+		expectedHost string
+		expectedPort string
+	}{
+		{"play", "play", "9000"},
+		{"play:443", "play", "443"},
+		{"[::1]", "::1", "9000"},
+		{"https://play", "play", "443"},
 	}
 
 	for i, testCase := range testCases {
-		ip, err := trimIPv6(testCase.IP)
-		expectErr := (err != nil)
+		host, port, err := SplitHostPortWithDefault(testCase.addr, "9000")
+		if err != nil {
+			t.Fatalf("test %v: unexpected error: %v", i+1, err)
+		}
+		if host != testCase.expectedHost || port != testCase.expectedPort {
+			t.Fatalf("test %v: expected: %v %v, got: %v %v", i+1, testCase.expectedHost, testCase.expectedPort, host, port)
+		}
+	}
+}
 
-		if expectErr != testCase.expectErr {
-			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+func TestJoinHostPort(t *testing.T) {
+	testCases := []struct {
+		host         string
+		port         string
+		expectedAddr string
+	}{
+		{"play", "9000", "play:9000"},
+		{"play", "", "play"},
+		{"", "9000", ":9000"},
+		{"::1", "9000", "[::1]:9000"},
+		{"::1", "", "[::1]"},
+		{"[::1]", "9000", "[::1]:9000"},
+		{"fe80::1%eth0", "9000", "[fe80::1%eth0]:9000"},
+	}
+
+	for i, testCase := range testCases {
+		addr := JoinHostPort(testCase.host, testCase.port)
+		if addr != testCase.expectedAddr {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedAddr, addr)
 		}
 
-		if !testCase.expectErr {
-			if ip != testCase.expectedIP {
-				t.Fatalf("test %v: IP: expected: %#v, got: %#v", i+1, testCase.expectedIP, ip)
-			}
+		// Must round-trip through SplitHostPort.
+		host, port, err := SplitHostPort(addr)
+		if err != nil {
+			t.Fatalf("test %v: unexpected error: %v", i+1, err)
+		}
+		if host != strings.Trim(testCase.host, "[]") || port != testCase.port {
+			t.Fatalf("test %v: expected: %v %v, got: %v %v", i+1, testCase.host, testCase.port, host, port)
 		}
 	}
 }
@@ -0,0 +1,465 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package net
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Default timeouts of ProbeEndpoint phases.
+const (
+	DefaultProbeDNSTimeout     = 5 * time.Second
+	DefaultProbeConnectTimeout = 5 * time.Second
+	DefaultProbeTLSTimeout     = 10 * time.Second
+	DefaultProbeHTTPTimeout    = 10 * time.Second
+)
+
+// ProbeOptions - options of ProbeEndpoint, zero timeouts use the defaults.
+type ProbeOptions struct {
+	DNSTimeout     time.Duration
+	ConnectTimeout time.Duration
+	TLSTimeout     time.Duration
+	HTTPTimeout    time.Duration
+
+	// RootCAs - CAs verifying the server certificate, the system CAs if
+	// nil.
+	RootCAs *x509.CertPool
+
+	// InsecureCollect - collect the certificate chain and check the health
+	// path even if the server certificate is not trusted.
+	InsecureCollect bool
+
+	// HealthPath - path requested with HEAD after the TLS handshake, e.g.
+	// "/minio/health/live". No HTTP request is sent if empty.
+	HealthPath string
+
+	// Resolver - resolver of the endpoint host, such as a CachingResolver,
+	// net.DefaultResolver if nil.
+	Resolver Resolver
+
+	// Proxy - returns the proxy of a request, http.ProxyFromEnvironment
+	// if nil. Connections are made through the proxy if it returns one.
+	Proxy func(*http.Request) (*url.URL, error)
+}
+
+// ProbeResult - result of ProbeEndpoint, phases which were not run are
+// nil. Each phase reports its own error.
+type ProbeResult struct {
+	URL     string         `json:"url"`
+	Proxy   string         `json:"proxy,omitempty"`
+	DNS     *ProbeDNS      `json:"dns,omitempty"`
+	Connect []ProbeConnect `json:"connect,omitempty"`
+	TLS     *ProbeTLS      `json:"tls,omitempty"`
+	HTTP    *ProbeHTTP     `json:"http,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
+// ProbeDNS - DNS resolution of the endpoint host.
+type ProbeDNS struct {
+	Host      string        `json:"host"`
+	Addresses []string      `json:"addresses,omitempty"` // A and AAAA records
+	Duration  time.Duration `json:"duration"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// ProbeConnect - TCP connection to an address of the endpoint, or to the
+// proxy.
+type ProbeConnect struct {
+	Address  string        `json:"address"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// ProbeTLS - TLS handshake with the endpoint.
+type ProbeTLS struct {
+	Version      string             `json:"version,omitempty"`
+	CipherSuite  string             `json:"cipherSuite,omitempty"`
+	ServerName   string             `json:"serverName"`
+	Verified     bool               `json:"verified"`
+	VerifyError  string             `json:"verifyError,omitempty"`
+	Certificates []ProbeCertificate `json:"certificates,omitempty"`
+	Duration     time.Duration      `json:"duration"`
+	Error        string             `json:"error,omitempty"`
+}
+
+// ProbeCertificate - certificate of the chain presented by the endpoint,
+// the leaf first.
+type ProbeCertificate struct {
+	Subject   string        `json:"subject"`
+	Issuer    string        `json:"issuer"`
+	DNSNames  []string      `json:"dnsNames,omitempty"`
+	IPs       []string      `json:"ips,omitempty"`
+	NotBefore time.Time     `json:"notBefore"`
+	NotAfter  time.Time     `json:"notAfter"`
+	ExpiresIn time.Duration `json:"expiresIn"` // Negative if expired
+	SHA256    string        `json:"sha256"`
+}
+
+// ProbeHTTP - HEAD request of the health path.
+type ProbeHTTP struct {
+	Path       string        `json:"path"`
+	StatusCode int           `json:"statusCode,omitempty"`
+	Status     string        `json:"status,omitempty"`
+	Duration   time.Duration `json:"duration"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// errString - returns the message of err, empty if nil.
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// ProbeEndpoint checks whether the http or https endpoint u is reachable.
+// It resolves the host, connects to each of its addresses, performs the
+// TLS handshake for https endpoints and, if opts.HealthPath is set,
+// requests it with HEAD. Each phase is bounded by its timeout and by ctx,
+// later phases are skipped once a phase failed.
+//
+// If a proxy is configured for u, the connection and the TLS handshake
+// are made through the proxy, which must be an http or https proxy.
+func ProbeEndpoint(ctx context.Context, u URL, opts ProbeOptions) ProbeResult {
+	opts = opts.withDefaults()
+	result := ProbeResult{URL: u.String()}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		result.Error = fmt.Sprintf("unsupported scheme '%s'", u.Scheme)
+		return result
+	}
+	if u.Hostname() == "" {
+		result.Error = "host must not be empty"
+		return result
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	target := net.JoinHostPort(u.Hostname(), port)
+
+	stdURL := url.URL(u)
+	proxyURL, err := opts.Proxy(&http.Request{Method: http.MethodHead, URL: &stdURL, Header: http.Header{}})
+	if err != nil {
+		result.Error = fmt.Sprintf("invalid proxy: %v", err)
+		return result
+	}
+	if proxyURL != nil {
+		result.Proxy = proxyURL.Redacted()
+	}
+
+	result.DNS = probeDNS(ctx, u.Hostname(), opts)
+	if result.DNS.Error != "" && proxyURL == nil {
+		return result
+	}
+
+	var conn net.Conn
+	if proxyURL != nil {
+		var probe ProbeConnect
+		conn, probe = probeConnectProxy(ctx, proxyURL, target, opts)
+		result.Connect = append(result.Connect, probe)
+	} else {
+		for _, addr := range result.DNS.Addresses {
+			c, probe := probeConnect(ctx, net.JoinHostPort(addr, port), opts)
+			result.Connect = append(result.Connect, probe)
+			// The first reachable address is used for the TLS handshake.
+			if c != nil && conn == nil {
+				conn = c
+			} else if c != nil {
+				c.Close()
+			}
+		}
+	}
+	if conn == nil {
+		return result
+	}
+	defer conn.Close()
+
+	if u.Scheme == "https" {
+		// Untrusted certificates fail the handshake unless
+		// opts.InsecureCollect is set.
+		result.TLS = probeTLS(ctx, conn, u.Hostname(), opts)
+		if result.TLS.Error != "" {
+			return result
+		}
+	}
+
+	if opts.HealthPath != "" {
+		result.HTTP = probeHTTP(ctx, stdURL, opts)
+	}
+	return result
+}
+
+func (opts ProbeOptions) withDefaults() ProbeOptions {
+	if opts.DNSTimeout <= 0 {
+		opts.DNSTimeout = DefaultProbeDNSTimeout
+	}
+	if opts.ConnectTimeout <= 0 {
+		opts.ConnectTimeout = DefaultProbeConnectTimeout
+	}
+	if opts.TLSTimeout <= 0 {
+		opts.TLSTimeout = DefaultProbeTLSTimeout
+	}
+	if opts.HTTPTimeout <= 0 {
+		opts.HTTPTimeout = DefaultProbeHTTPTimeout
+	}
+	if opts.Resolver == nil {
+		opts.Resolver = net.DefaultResolver
+	}
+	if opts.Proxy == nil {
+		opts.Proxy = http.ProxyFromEnvironment
+	}
+	return opts
+}
+
+// probeDNS - resolves the A and AAAA records of host, IP addresses are
+// used as is.
+func probeDNS(ctx context.Context, host string, opts ProbeOptions) *ProbeDNS {
+	result := &ProbeDNS{Host: host}
+	if ip := net.ParseIP(host); ip != nil {
+		result.Addresses = []string{ip.String()}
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.DNSTimeout)
+	defer cancel()
+
+	start := time.Now()
+	addrs, err := opts.Resolver.LookupIPAddr(ctx, host)
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	for _, addr := range addrs {
+		result.Addresses = append(result.Addresses, addr.String())
+	}
+	return result
+}
+
+// probeConnect - connects to addr, the connection is nil on errors.
+func probeConnect(ctx context.Context, addr string, opts ProbeOptions) (net.Conn, ProbeConnect) {
+	ctx, cancel := context.WithTimeout(ctx, opts.ConnectTimeout)
+	defer cancel()
+
+	result := ProbeConnect{Address: addr}
+	start := time.Now()
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	result.Duration = time.Since(start)
+	result.Error = errString(err)
+	return conn, result
+}
+
+// probeConnectProxy - connects to target through the http or https proxy,
+// the connection is nil on errors.
+func probeConnectProxy(ctx context.Context, proxyURL *url.URL, target string, opts ProbeOptions) (net.Conn, ProbeConnect) {
+	var proxyPort string
+	switch proxyURL.Scheme {
+	case "http":
+		proxyPort = "80"
+	case "https":
+		proxyPort = "443"
+	default:
+		return nil, ProbeConnect{Address: proxyURL.Host, Error: fmt.Sprintf("unsupported proxy scheme '%s'", proxyURL.Scheme)}
+	}
+	if port := proxyURL.Port(); port != "" {
+		proxyPort = port
+	}
+	proxyAddr := net.JoinHostPort(proxyURL.Hostname(), proxyPort)
+
+	conn, result := probeConnect(ctx, proxyAddr, opts)
+	if conn == nil {
+		return nil, result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.ConnectTimeout)
+	defer cancel()
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	start := time.Now()
+	err := func() error {
+		if proxyURL.Scheme == "https" {
+			tlsConn := tls.Client(conn, &tls.Config{ServerName: proxyURL.Hostname(), RootCAs: opts.RootCAs})
+			if err := tlsConn.HandshakeContext(ctx); err != nil {
+				return err
+			}
+			conn = tlsConn
+		}
+
+		req := &http.Request{
+			Method: http.MethodConnect,
+			URL:    &url.URL{Opaque: target},
+			Host:   target,
+			Header: http.Header{},
+		}
+		if user := proxyURL.User; user != nil {
+			password, _ := user.Password()
+			req.SetBasicAuth(user.Username(), password)
+			req.Header.Set("Proxy-Authorization", req.Header.Get("Authorization"))
+			req.Header.Del("Authorization")
+		}
+		if err := req.Write(conn); err != nil {
+			return err
+		}
+		resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("proxy CONNECT failed: %s", resp.Status)
+		}
+		return nil
+	}()
+	result.Duration += time.Since(start)
+	if err != nil {
+		conn.Close()
+		result.Error = err.Error()
+		return nil, result
+	}
+	return conn, result
+}
+
+// probeTLS - performs the TLS handshake on conn and verifies the server
+// certificate. The certificate chain is collected if it is trusted or if
+// opts.InsecureCollect is set.
+func probeTLS(ctx context.Context, conn net.Conn, serverName string, opts ProbeOptions) *ProbeTLS {
+	result := &ProbeTLS{ServerName: serverName}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.TLSTimeout)
+	defer cancel()
+
+	// The handshake does not verify the certificate, so that untrusted
+	// chains can be reported. It is verified below.
+	tlsConn := tls.Client(conn, &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: true,
+	})
+	start := time.Now()
+	err := tlsConn.HandshakeContext(ctx)
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	state := tlsConn.ConnectionState()
+	result.Version = tls.VersionName(state.Version)
+	result.CipherSuite = tls.CipherSuiteName(state.CipherSuite)
+
+	if err = verifyChain(state.PeerCertificates, serverName, opts.RootCAs); err != nil {
+		result.VerifyError = err.Error()
+		if !opts.InsecureCollect {
+			result.Error = err.Error()
+			return result
+		}
+	} else {
+		result.Verified = true
+	}
+
+	now := time.Now()
+	for _, cert := range state.PeerCertificates {
+		sum := sha256.Sum256(cert.Raw)
+		c := ProbeCertificate{
+			Subject:   cert.Subject.String(),
+			Issuer:    cert.Issuer.String(),
+			DNSNames:  cert.DNSNames,
+			NotBefore: cert.NotBefore.UTC(),
+			NotAfter:  cert.NotAfter.UTC(),
+			ExpiresIn: cert.NotAfter.Sub(now),
+			SHA256:    hex.EncodeToString(sum[:]),
+		}
+		for _, ip := range cert.IPAddresses {
+			c.IPs = append(c.IPs, ip.String())
+		}
+		result.Certificates = append(result.Certificates, c)
+	}
+	return result
+}
+
+// verifyChain - verifies the certificate chain presented for serverName.
+func verifyChain(certs []*x509.Certificate, serverName string, roots *x509.CertPool) error {
+	if len(certs) == 0 {
+		return errors.New("no certificate presented")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		DNSName:       strings.Trim(serverName, "[]"),
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	return err
+}
+
+// probeHTTP - requests the health path of u with HEAD.
+func probeHTTP(ctx context.Context, u url.URL, opts ProbeOptions) *ProbeHTTP {
+	result := &ProbeHTTP{Path: opts.HealthPath}
+
+	u.Path = opts.HealthPath
+	u.RawPath, u.RawQuery, u.Fragment = "", "", ""
+	ctx, cancel := context.WithTimeout(ctx, opts.HTTPTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, u.String(), nil)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	transport := &http.Transport{
+		Proxy:       opts.Proxy,
+		DialContext: resolvingDialContext(opts.Resolver, nil),
+		TLSClientConfig: &tls.Config{
+			RootCAs:            opts.RootCAs,
+			InsecureSkipVerify: opts.InsecureCollect,
+		},
+		DisableKeepAlives: true,
+	}
+	defer transport.CloseIdleConnections()
+
+	start := time.Now()
+	resp, err := transport.RoundTrip(req)
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	resp.Body.Close()
+	result.StatusCode = resp.StatusCode
+	result.Status = resp.Status
+	return result
+}
@@ -0,0 +1,284 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package net
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// noProxy - ignores proxies configured in the environment.
+func noProxy(*http.Request) (*url.URL, error) { return nil, nil }
+
+// newTestTLSServer - returns a TLS server with a self-signed certificate
+// for 127.0.0.1 and ::1 expiring after validity.
+func newTestTLSServer(t *testing.T, listener net.Listener, validity time.Duration) (*httptest.Server, *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "probe-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(validity),
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead && r.URL.Path == "/minio/health/live" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	if listener != nil {
+		server.Listener.Close()
+		server.Listener = listener
+	}
+	// Probes close connections after failed verifications.
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server, cert
+}
+
+func mustParseURL(t *testing.T, s string) URL {
+	t.Helper()
+	u, err := ParseURL(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *u
+}
+
+func TestProbeEndpointTLS(t *testing.T) {
+	server, cert := newTestTLSServer(t, nil, 2*time.Hour)
+	u := mustParseURL(t, server.URL)
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+
+	// Untrusted certificates are not collected by default.
+	result := ProbeEndpoint(context.Background(), u, ProbeOptions{HealthPath: "/minio/health/live", Proxy: noProxy})
+	if result.DNS == nil || !reflect.DeepEqual(result.DNS.Addresses, []string{"127.0.0.1"}) {
+		t.Fatalf("expected: [127.0.0.1], got: %+v", result.DNS)
+	}
+	if len(result.Connect) != 1 || result.Connect[0].Error != "" {
+		t.Fatalf("unexpected connect result: %+v", result.Connect)
+	}
+	if result.TLS == nil || result.TLS.Error == "" || result.TLS.Verified || len(result.TLS.Certificates) != 0 {
+		t.Fatalf("expected an untrusted certificate error, got: %+v", result.TLS)
+	}
+	if result.HTTP != nil {
+		t.Fatalf("expected no HTTP probe, got: %+v", result.HTTP)
+	}
+
+	// InsecureCollect collects the chain of untrusted certificates.
+	result = ProbeEndpoint(context.Background(), u, ProbeOptions{HealthPath: "/minio/health/live", InsecureCollect: true, Proxy: noProxy})
+	if result.TLS == nil || result.TLS.Error != "" || result.TLS.Verified || result.TLS.VerifyError == "" {
+		t.Fatalf("unexpected TLS result: %+v", result.TLS)
+	}
+	if len(result.TLS.Certificates) != 1 {
+		t.Fatalf("expected: 1 certificate, got: %+v", result.TLS.Certificates)
+	}
+	c := result.TLS.Certificates[0]
+	if !c.NotAfter.Equal(cert.NotAfter) || c.ExpiresIn <= time.Hour || c.ExpiresIn > 2*time.Hour {
+		t.Fatalf("unexpected expiry: %v, %v", c.NotAfter, c.ExpiresIn)
+	}
+	if c.Subject != "CN=probe-test" || !reflect.DeepEqual(c.IPs, []string{"127.0.0.1", "::1"}) || len(c.SHA256) != 64 {
+		t.Fatalf("unexpected certificate: %+v", c)
+	}
+	if result.HTTP == nil || result.HTTP.StatusCode != http.StatusOK {
+		t.Fatalf("expected: %v, got: %+v", http.StatusOK, result.HTTP)
+	}
+
+	// Trusted certificates.
+	result = ProbeEndpoint(context.Background(), u, ProbeOptions{HealthPath: "/minio/health/ready", RootCAs: roots, Proxy: noProxy})
+	if result.TLS == nil || !result.TLS.Verified || result.TLS.Version == "" || len(result.TLS.Certificates) != 1 {
+		t.Fatalf("unexpected TLS result: %+v", result.TLS)
+	}
+	if result.HTTP == nil || result.HTTP.StatusCode != http.StatusServiceUnavailable || result.HTTP.Path != "/minio/health/ready" {
+		t.Fatalf("expected: %v, got: %+v", http.StatusServiceUnavailable, result.HTTP)
+	}
+
+	// The result is JSON friendly.
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded ProbeResult
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, result) {
+		t.Fatalf("expected: %+v, got: %+v", result, decoded)
+	}
+}
+
+func TestProbeEndpointExpired(t *testing.T) {
+	server, cert := newTestTLSServer(t, nil, -time.Minute)
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+
+	result := ProbeEndpoint(context.Background(), mustParseURL(t, server.URL), ProbeOptions{RootCAs: roots, InsecureCollect: true, Proxy: noProxy})
+	if result.TLS == nil || result.TLS.Verified || len(result.TLS.Certificates) != 1 || result.TLS.Certificates[0].ExpiresIn >= 0 {
+		t.Fatalf("expected an expired certificate, got: %+v", result.TLS)
+	}
+}
+
+func TestProbeEndpointIPv6(t *testing.T) {
+	listener, err := net.Listen("tcp", "[::1]:0")
+	if err != nil {
+		t.Skip("IPv6 is not available")
+	}
+	server, cert := newTestTLSServer(t, listener, time.Hour)
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+
+	result := ProbeEndpoint(context.Background(), mustParseURL(t, server.URL), ProbeOptions{RootCAs: roots, HealthPath: "/minio/health/live", Proxy: noProxy})
+	if result.DNS == nil || !reflect.DeepEqual(result.DNS.Addresses, []string{"::1"}) {
+		t.Fatalf("expected: [::1], got: %+v", result.DNS)
+	}
+	if result.TLS == nil || !result.TLS.Verified {
+		t.Fatalf("unexpected TLS result: %+v", result.TLS)
+	}
+	if result.HTTP == nil || result.HTTP.StatusCode != http.StatusOK {
+		t.Fatalf("expected: %v, got: %+v", http.StatusOK, result.HTTP)
+	}
+}
+
+func TestProbeEndpointProxy(t *testing.T) {
+	server, cert := newTestTLSServer(t, nil, time.Hour)
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+
+	var connects atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		connects.Add(1)
+		dst, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer dst.Close()
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+		go io.Copy(dst, conn)
+		io.Copy(conn, dst)
+	}))
+	defer proxy.Close()
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := ProbeEndpoint(context.Background(), mustParseURL(t, server.URL), ProbeOptions{
+		RootCAs:    roots,
+		HealthPath: "/minio/health/live",
+		Proxy:      http.ProxyURL(proxyURL),
+	})
+	if result.Proxy != proxy.URL {
+		t.Fatalf("expected: %v, got: %v", proxy.URL, result.Proxy)
+	}
+	if len(result.Connect) != 1 || result.Connect[0].Address != proxyURL.Host || result.Connect[0].Error != "" {
+		t.Fatalf("unexpected connect result: %+v", result.Connect)
+	}
+	if result.TLS == nil || !result.TLS.Verified {
+		t.Fatalf("unexpected TLS result: %+v", result.TLS)
+	}
+	if result.HTTP == nil || result.HTTP.StatusCode != http.StatusOK {
+		t.Fatalf("expected: %v, got: %+v", http.StatusOK, result.HTTP)
+	}
+	if n := connects.Load(); n != 2 {
+		t.Fatalf("expected: 2 proxied connections, got: %v", n)
+	}
+}
+
+func TestProbeEndpointErrors(t *testing.T) {
+	// A port nothing listens on.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	testCases := []struct {
+		url          string
+		expectErr    bool
+		expectDNS    bool
+		expectRefuse bool
+	}{
+		{"ftp://127.0.0.1:21", true, false, false},
+		{"https://" + addr, false, false, true},
+		{"http://nonexistent.invalid:9000", false, true, false},
+	}
+
+	for i, testCase := range testCases {
+		result := ProbeEndpoint(context.Background(), mustParseURL(t, testCase.url), ProbeOptions{DNSTimeout: time.Second, Proxy: noProxy})
+		if (result.Error != "") != testCase.expectErr {
+			t.Fatalf("case %v: unexpected error: %v", i+1, result.Error)
+		}
+		if testCase.expectDNS && (result.DNS == nil || result.DNS.Error == "" || result.Connect != nil) {
+			t.Fatalf("case %v: expected a DNS error, got: %+v", i+1, result)
+		}
+		if testCase.expectRefuse && (len(result.Connect) != 1 || result.Connect[0].Error == "" || result.TLS != nil) {
+			t.Fatalf("case %v: expected a connect error, got: %+v", i+1, result)
+		}
+	}
+}
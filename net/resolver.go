@@ -0,0 +1,313 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package net
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Resolver - resolves host names, such as *net.Resolver and
+// *CachingResolver.
+type Resolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// TTLResolver - Resolver which returns the TTL of its answers. The TTL of
+// the answers of CachingResolver is CachingResolverOptions.DefaultTTL if
+// its upstream resolver is not a TTLResolver, *net.Resolver does not
+// expose TTLs.
+type TTLResolver interface {
+	Resolver
+	LookupIPAddrTTL(ctx context.Context, host string) ([]net.IPAddr, time.Duration, error)
+}
+
+// Default TTLs of CachingResolver.
+const (
+	DefaultResolverMinTTL      = time.Second
+	DefaultResolverMaxTTL      = 5 * time.Minute
+	DefaultResolverTTL         = 30 * time.Second
+	DefaultResolverNegativeTTL = 5 * time.Second
+)
+
+// CachingResolverOptions - options of NewCachingResolver, zero values use
+// the defaults.
+type CachingResolverOptions struct {
+	// Resolver - upstream resolver, net.DefaultResolver if nil.
+	Resolver Resolver
+
+	// MinTTL and MaxTTL clamp the TTLs of the upstream answers.
+	MinTTL time.Duration
+	MaxTTL time.Duration
+
+	// DefaultTTL - TTL of the answers of upstream resolvers which are not
+	// TTLResolvers, clamped as well.
+	DefaultTTL time.Duration
+
+	// NegativeTTL - TTL of failed lookups, such as unknown hosts. Lookups
+	// canceled by their context are not cached.
+	NegativeTTL time.Duration
+}
+
+// CachingResolverStats - counters of a CachingResolver.
+type CachingResolverStats struct {
+	Hits         uint64 `json:"hits"`         // Answered from the cache
+	NegativeHits uint64 `json:"negativeHits"` // Errors answered from the cache
+	Misses       uint64 `json:"misses"`       // Not or no longer cached
+	Lookups      uint64 `json:"lookups"`      // Upstream lookups
+	Shared       uint64 `json:"shared"`       // Misses sharing a pending upstream lookup
+	Entries      int    `json:"entries"`      // Cached hosts
+}
+
+// resolverEntry - cached answer of a host.
+type resolverEntry struct {
+	addrs   []net.IPAddr
+	err     error
+	expires time.Time
+}
+
+// CachingResolver - Resolver caching the answers of an upstream resolver
+// until their TTL expires. Concurrent lookups of a host which is not
+// cached share a single upstream lookup.
+type CachingResolver struct {
+	opts  CachingResolverOptions
+	group singleflight.Group
+	now   func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]resolverEntry
+
+	hits, negativeHits, misses, lookups, shared atomic.Uint64
+}
+
+// NewCachingResolver - returns a CachingResolver with opts.
+func NewCachingResolver(opts CachingResolverOptions) *CachingResolver {
+	if opts.Resolver == nil {
+		opts.Resolver = net.DefaultResolver
+	}
+	if opts.MinTTL <= 0 {
+		opts.MinTTL = DefaultResolverMinTTL
+	}
+	if opts.MaxTTL <= 0 {
+		opts.MaxTTL = DefaultResolverMaxTTL
+	}
+	if opts.MaxTTL < opts.MinTTL {
+		opts.MaxTTL = opts.MinTTL
+	}
+	if opts.DefaultTTL <= 0 {
+		opts.DefaultTTL = DefaultResolverTTL
+	}
+	if opts.NegativeTTL <= 0 {
+		opts.NegativeTTL = DefaultResolverNegativeTTL
+	}
+	return &CachingResolver{
+		opts:    opts,
+		now:     time.Now,
+		entries: map[string]resolverEntry{},
+	}
+}
+
+// LookupIPAddr - returns the IP addresses of host, IP addresses are
+// returned as is.
+func (r *CachingResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	if ip, zone, ok := parseIPZone(host); ok {
+		return []net.IPAddr{{IP: ip, Zone: zone}}, nil
+	}
+
+	r.mu.Lock()
+	entry, ok := r.entries[host]
+	r.mu.Unlock()
+	if ok && r.now().Before(entry.expires) {
+		if entry.err != nil {
+			r.negativeHits.Add(1)
+			return nil, entry.err
+		}
+		r.hits.Add(1)
+		return cloneIPAddrs(entry.addrs), nil
+	}
+	r.misses.Add(1)
+
+	// The upstream lookup is shared by the callers, it must not be
+	// canceled if the caller starting it gives up.
+	ch := r.group.DoChan(host, func() (interface{}, error) {
+		r.lookups.Add(1)
+		return r.lookup(context.WithoutCancel(ctx), host)
+	})
+	select {
+	case res := <-ch:
+		if res.Shared {
+			r.shared.Add(1)
+		}
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return cloneIPAddrs(res.Val.([]net.IPAddr)), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// LookupHost - returns the addresses of host, IP addresses are returned
+// as is.
+func (r *CachingResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	addrs, err := r.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	hosts := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		hosts = append(hosts, addr.String())
+	}
+	return hosts, nil
+}
+
+// lookup - looks up host upstream and caches the answer.
+func (r *CachingResolver) lookup(ctx context.Context, host string) ([]net.IPAddr, error) {
+	var (
+		addrs []net.IPAddr
+		ttl   time.Duration
+		err   error
+	)
+	if resolver, ok := r.opts.Resolver.(TTLResolver); ok {
+		addrs, ttl, err = resolver.LookupIPAddrTTL(ctx, host)
+	} else {
+		addrs, err = r.opts.Resolver.LookupIPAddr(ctx, host)
+		ttl = r.opts.DefaultTTL
+	}
+
+	switch {
+	case err != nil:
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return nil, err
+		}
+		ttl = r.opts.NegativeTTL
+	case len(addrs) == 0:
+		err = &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		ttl = r.opts.NegativeTTL
+	default:
+		ttl = min(max(ttl, r.opts.MinTTL), r.opts.MaxTTL)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	// Expired entries are removed on writes, the cache never holds more
+	// hosts than were looked up during the maximum TTL.
+	for h, entry := range r.entries {
+		if !now.Before(entry.expires) {
+			delete(r.entries, h)
+		}
+	}
+	r.entries[host] = resolverEntry{addrs: cloneIPAddrs(addrs), err: err, expires: now.Add(ttl)}
+	return addrs, err
+}
+
+// Stats - returns the counters of r.
+func (r *CachingResolver) Stats() CachingResolverStats {
+	r.mu.Lock()
+	entries := len(r.entries)
+	r.mu.Unlock()
+	return CachingResolverStats{
+		Hits:         r.hits.Load(),
+		NegativeHits: r.negativeHits.Load(),
+		Misses:       r.misses.Load(),
+		Lookups:      r.lookups.Load(),
+		Shared:       r.shared.Load(),
+		Entries:      entries,
+	}
+}
+
+// Flush - removes all cached answers.
+func (r *CachingResolver) Flush() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clear(r.entries)
+}
+
+// DialContext - returns a function, for http.Transport.DialContext,
+// which resolves the host of its address with r and dials its IP
+// addresses in order with dial until one connects. dial is
+// (&net.Dialer{}).DialContext if nil.
+func (r *CachingResolver) DialContext(dial func(ctx context.Context, network, addr string) (net.Conn, error)) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return resolvingDialContext(r, dial)
+}
+
+// resolvingDialContext - see CachingResolver.DialContext.
+func resolvingDialContext(resolver Resolver, dial func(ctx context.Context, network, addr string) (net.Conn, error)) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		addrs, err := resolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		var firstErr error
+		for _, ip := range addrs {
+			conn, err := dial(ctx, network, net.JoinHostPort(ip.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			if ctx.Err() != nil {
+				break
+			}
+		}
+		if firstErr == nil {
+			firstErr = &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+		return nil, firstErr
+	}
+}
+
+// parseIPZone - parses s as an IP address with an optional IPv6 zone,
+// such as "fe80::1%eth0".
+func parseIPZone(s string) (net.IP, string, bool) {
+	addr, zone, _ := strings.Cut(s, "%")
+	ip := net.ParseIP(addr)
+	if ip == nil || (zone != "" && ip.To4() != nil) {
+		return nil, "", false
+	}
+	return ip, zone, true
+}
+
+func cloneIPAddrs(addrs []net.IPAddr) []net.IPAddr {
+	if addrs == nil {
+		return nil
+	}
+	clone := make([]net.IPAddr, len(addrs))
+	for i, addr := range addrs {
+		clone[i] = net.IPAddr{IP: append(net.IP(nil), addr.IP...), Zone: addr.Zone}
+	}
+	return clone
+}
@@ -0,0 +1,339 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package net
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeResolver - TTLResolver answering from a map, lookups block until
+// release is closed if set.
+type fakeResolver struct {
+	hosts   map[string][]string
+	ttl     time.Duration
+	release chan struct{}
+	lookups atomic.Int64
+}
+
+func (f *fakeResolver) LookupIPAddrTTL(ctx context.Context, host string) ([]net.IPAddr, time.Duration, error) {
+	f.lookups.Add(1)
+	if f.release != nil {
+		select {
+		case <-f.release:
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		}
+	}
+	ips, ok := f.hosts[host]
+	if !ok {
+		return nil, 0, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	var addrs []net.IPAddr
+	for _, ip := range ips {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+	return addrs, f.ttl, nil
+}
+
+func (f *fakeResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	addrs, _, err := f.LookupIPAddrTTL(ctx, host)
+	return addrs, err
+}
+
+func (f *fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	addrs, err := f.LookupIPAddr(ctx, host)
+	var hosts []string
+	for _, addr := range addrs {
+		hosts = append(hosts, addr.String())
+	}
+	return hosts, err
+}
+
+// fakeClock - returns a time advanced by the tests.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestCachingResolver(upstream Resolver, opts CachingResolverOptions) (*CachingResolver, *fakeClock) {
+	opts.Resolver = upstream
+	r := NewCachingResolver(opts)
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	r.now = clock.Now
+	return r, clock
+}
+
+func TestCachingResolverTTL(t *testing.T) {
+	testCases := []struct {
+		upstreamTTL time.Duration
+		minTTL      time.Duration
+		maxTTL      time.Duration
+		expectedTTL time.Duration
+	}{
+		{10 * time.Second, 0, 0, 10 * time.Second},
+		{0, 0, 0, DefaultResolverMinTTL},
+		{time.Hour, 0, 0, DefaultResolverMaxTTL},
+		{10 * time.Second, time.Minute, 0, time.Minute},
+		{10 * time.Second, 0, 5 * time.Second, 5 * time.Second},
+		{10 * time.Second, time.Minute, time.Second, time.Minute},
+	}
+
+	for i, testCase := range testCases {
+		upstream := &fakeResolver{hosts: map[string][]string{"minio.test": {"10.0.0.1", "10.0.0.2"}}, ttl: testCase.upstreamTTL}
+		r, clock := newTestCachingResolver(upstream, CachingResolverOptions{MinTTL: testCase.minTTL, MaxTTL: testCase.maxTTL})
+
+		for _, advance := range []time.Duration{0, testCase.expectedTTL - time.Millisecond} {
+			clock.Advance(advance)
+			hosts, err := r.LookupHost(context.Background(), "minio.test")
+			if err != nil {
+				t.Fatalf("case %v: unexpected error: %v", i+1, err)
+			}
+			if expected := []string{"10.0.0.1", "10.0.0.2"}; !reflect.DeepEqual(hosts, expected) {
+				t.Fatalf("case %v: expected: %v, got: %v", i+1, expected, hosts)
+			}
+		}
+		if n := upstream.lookups.Load(); n != 1 {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, 1, n)
+		}
+
+		clock.Advance(time.Millisecond)
+		if _, err := r.LookupIPAddr(context.Background(), "minio.test"); err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if n := upstream.lookups.Load(); n != 2 {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, 2, n)
+		}
+
+		stats := r.Stats()
+		expected := CachingResolverStats{Hits: 1, Misses: 2, Lookups: 2, Entries: 1}
+		if stats != expected {
+			t.Fatalf("case %v: expected: %+v, got: %+v", i+1, expected, stats)
+		}
+	}
+}
+
+func TestCachingResolverDefaultTTL(t *testing.T) {
+	// *net.Resolver does not return TTLs, the answers of upstream
+	// resolvers which are not TTLResolvers expire after DefaultTTL.
+	upstream := struct{ Resolver }{&fakeResolver{hosts: map[string][]string{"minio.test": {"10.0.0.1"}}}}
+	r, clock := newTestCachingResolver(upstream, CachingResolverOptions{DefaultTTL: 20 * time.Second})
+
+	testCases := []struct {
+		advance  time.Duration
+		expected uint64
+	}{
+		{0, 1},
+		{19 * time.Second, 1},
+		{time.Second, 2},
+	}
+	for i, testCase := range testCases {
+		clock.Advance(testCase.advance)
+		if _, err := r.LookupIPAddr(context.Background(), "minio.test"); err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if lookups := r.Stats().Lookups; lookups != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, lookups)
+		}
+	}
+}
+
+func TestCachingResolverNegative(t *testing.T) {
+	upstream := &fakeResolver{hosts: map[string][]string{}, ttl: time.Minute}
+	r, clock := newTestCachingResolver(upstream, CachingResolverOptions{NegativeTTL: 2 * time.Second})
+
+	testCases := []struct {
+		advance         time.Duration
+		expectedLookups int64
+	}{
+		{0, 1},
+		{time.Second, 1},
+		{time.Second, 2},
+	}
+	for i, testCase := range testCases {
+		clock.Advance(testCase.advance)
+		_, err := r.LookupHost(context.Background(), "unknown.test")
+		var dnsErr *net.DNSError
+		if !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+			t.Fatalf("case %v: expected a not found error, got: %v", i+1, err)
+		}
+		if n := upstream.lookups.Load(); n != testCase.expectedLookups {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedLookups, n)
+		}
+	}
+	if stats := r.Stats(); stats.NegativeHits != 1 {
+		t.Fatalf("expected: %v, got: %v", 1, stats.NegativeHits)
+	}
+
+	// IP addresses are not looked up.
+	for i, host := range []string{"192.168.1.1", "::1", "fe80::1%eth0"} {
+		addrs, err := r.LookupIPAddr(context.Background(), host)
+		if err != nil || len(addrs) != 1 || addrs[0].String() != host {
+			t.Fatalf("case %v: expected: %v, got: %v, %v", i+1, host, addrs, err)
+		}
+	}
+	if n := upstream.lookups.Load(); n != 2 {
+		t.Fatalf("expected: %v, got: %v", 2, n)
+	}
+}
+
+func TestCachingResolverSingleflight(t *testing.T) {
+	const callers = 16
+	upstream := &fakeResolver{hosts: map[string][]string{"minio.test": {"10.0.0.1"}}, ttl: time.Minute, release: make(chan struct{})}
+	r, _ := newTestCachingResolver(upstream, CachingResolverOptions{})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			addrs, err := r.LookupIPAddr(context.Background(), "minio.test")
+			if err == nil && (len(addrs) != 1 || addrs[0].String() != "10.0.0.1") {
+				err = errors.New("unexpected addresses")
+			}
+			errs <- err
+		}()
+	}
+
+	// Wait for all callers to miss the cache and join the pending lookup.
+	deadline := time.Now().Add(5 * time.Second)
+	for r.Stats().Misses < callers && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(upstream.release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	stats := r.Stats()
+	if n := upstream.lookups.Load(); n != 1 || stats.Lookups != 1 || stats.Shared != callers {
+		t.Fatalf("expected: 1 lookup shared by %v callers, got: %v, %+v", callers, n, stats)
+	}
+}
+
+func TestCachingResolverCanceled(t *testing.T) {
+	upstream := &fakeResolver{hosts: map[string][]string{"minio.test": {"10.0.0.1"}}, ttl: time.Minute, release: make(chan struct{})}
+	r, _ := newTestCachingResolver(upstream, CachingResolverOptions{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := r.LookupIPAddr(ctx, "minio.test"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected: %v, got: %v", context.DeadlineExceeded, err)
+	}
+
+	// The lookup goes on for other callers.
+	close(upstream.release)
+	addrs, err := r.LookupIPAddr(context.Background(), "minio.test")
+	if err != nil || len(addrs) != 1 {
+		t.Fatalf("unexpected result: %v, %v", addrs, err)
+	}
+	if n := upstream.lookups.Load(); n != 1 {
+		t.Fatalf("expected: %v, got: %v", 1, n)
+	}
+}
+
+func TestCachingResolverDialContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 192.0.2.1 (TEST-NET-1) fails, the next address is dialed.
+	upstream := &fakeResolver{hosts: map[string][]string{"minio.test": {"192.0.2.1", "127.0.0.1"}}, ttl: time.Minute}
+	r, _ := newTestCachingResolver(upstream, CachingResolverOptions{})
+	var dialed []string
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed = append(dialed, addr)
+		if host, _, _ := net.SplitHostPort(addr); host == "192.0.2.1" {
+			return nil, errors.New("unreachable")
+		}
+		var d net.Dialer
+		return d.DialContext(ctx, network, addr)
+	}
+
+	client := &http.Client{Transport: &http.Transport{DialContext: r.DialContext(dial)}}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get("http://" + net.JoinHostPort("minio.test", port))
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		resp.Body.Close()
+		client.CloseIdleConnections()
+	}
+
+	expected := []string{"192.0.2.1:" + port, "127.0.0.1:" + port, "192.0.2.1:" + port, "127.0.0.1:" + port}
+	if !reflect.DeepEqual(dialed, expected) {
+		t.Fatalf("expected: %v, got: %v", expected, dialed)
+	}
+	if n := upstream.lookups.Load(); n != 1 {
+		t.Fatalf("expected: %v, got: %v", 1, n)
+	}
+}
+
+func TestProbeEndpointResolver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upstream := &fakeResolver{hosts: map[string][]string{"minio.test": {"127.0.0.1"}}, ttl: time.Minute}
+	r, _ := newTestCachingResolver(upstream, CachingResolverOptions{})
+	u := mustParseURL(t, "http://"+net.JoinHostPort("minio.test", port))
+	for i := 0; i < 2; i++ {
+		result := ProbeEndpoint(context.Background(), u, ProbeOptions{Resolver: r, Proxy: noProxy, HealthPath: "/"})
+		if result.DNS == nil || !reflect.DeepEqual(result.DNS.Addresses, []string{"127.0.0.1"}) {
+			t.Fatalf("case %v: unexpected DNS result: %+v", i+1, result.DNS)
+		}
+		if result.HTTP == nil || result.HTTP.StatusCode != http.StatusOK {
+			t.Fatalf("case %v: unexpected HTTP result: %+v", i+1, result.HTTP)
+		}
+	}
+	if n := upstream.lookups.Load(); n != 1 {
+		t.Fatalf("expected: %v, got: %v", 1, n)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package net
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Defaults of TransportOptions.
+const (
+	DefaultTransportDialTimeout           = 10 * time.Second
+	DefaultTransportKeepAlive             = 15 * time.Second
+	DefaultTransportTLSHandshakeTimeout   = 10 * time.Second
+	DefaultTransportResponseHeaderTimeout = time.Minute
+	DefaultTransportExpectContinueTimeout = 10 * time.Second
+	DefaultTransportIdleConnTimeout       = 90 * time.Second
+	DefaultTransportMaxIdleConns          = 1024
+	DefaultTransportMaxIdleConnsPerHost   = 256
+	DefaultTransportBufferSize            = 32 << 10
+)
+
+// TransportOptions - options of NewTransport. Zero values use the
+// defaults, negative timeouts disable the timeout.
+type TransportOptions struct {
+	DialTimeout time.Duration
+
+	// KeepAlive - interval of the TCP keepalive probes of connections.
+	KeepAlive time.Duration
+
+	TLSHandshakeTimeout time.Duration
+
+	// ResponseHeaderTimeout - time to wait for the response headers once
+	// the request, including its body, is written.
+	ResponseHeaderTimeout time.Duration
+
+	ExpectContinueTimeout time.Duration
+
+	// IdleConnTimeout - time after which idle connections are closed.
+	IdleConnTimeout time.Duration
+
+	// MaxIdleConns and MaxIdleConnsPerHost - number of idle connections
+	// kept for reuse, in total and per host. Go defaults to 2 idle
+	// connections per host, which closes and reopens connections under
+	// concurrent requests to a host.
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+
+	// MaxConnsPerHost - limit of the connections per host, including
+	// active ones, unlimited if zero.
+	MaxConnsPerHost int
+
+	// DisableHTTP2 - use HTTP/1.1 only, which performs better for
+	// concurrent large uploads than multiplexing them over a single
+	// HTTP/2 connection.
+	DisableHTTP2 bool
+
+	// TLSConfig - TLS configuration of the transport, it is cloned.
+	TLSConfig *tls.Config
+
+	// Resolver - resolver of hosts, such as a CachingResolver, the system
+	// resolver if nil.
+	Resolver Resolver
+
+	// TCPConfig - socket options of the connections, if not nil.
+	TCPConfig *TCPConfig
+
+	// Proxy - returns the proxy of a request, http.ProxyFromEnvironment
+	// if nil.
+	Proxy func(*http.Request) (*url.URL, error)
+}
+
+// timeout - returns d, or def if d is zero, or 0 if d is negative.
+func timeout(d, def time.Duration) time.Duration {
+	switch {
+	case d < 0:
+		return 0
+	case d == 0:
+		return def
+	}
+	return d
+}
+
+// NewTransport - returns an HTTP transport with the recommended defaults
+// of MinIO clients, configured by opts. Response bodies are not
+// transparently decompressed, objects are transferred as stored.
+func NewTransport(opts TransportOptions) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   timeout(opts.DialTimeout, DefaultTransportDialTimeout),
+		KeepAlive: timeout(opts.KeepAlive, DefaultTransportKeepAlive),
+	}
+	if opts.KeepAlive < 0 {
+		dialer.KeepAlive = -1
+	}
+	if opts.TCPConfig != nil {
+		dialer.Control = opts.TCPConfig.Clone().Control
+	}
+	dial := dialer.DialContext
+	if opts.Resolver != nil {
+		dial = resolvingDialContext(opts.Resolver, dialer.DialContext)
+	}
+
+	proxy := opts.Proxy
+	if proxy == nil {
+		proxy = http.ProxyFromEnvironment
+	}
+	maxIdleConns := opts.MaxIdleConns
+	if maxIdleConns <= 0 {
+		maxIdleConns = DefaultTransportMaxIdleConns
+	}
+	maxIdleConnsPerHost := opts.MaxIdleConnsPerHost
+	if maxIdleConnsPerHost <= 0 {
+		maxIdleConnsPerHost = DefaultTransportMaxIdleConnsPerHost
+	}
+
+	transport := &http.Transport{
+		Proxy:                 proxy,
+		DialContext:           dial,
+		TLSClientConfig:       opts.TLSConfig.Clone(),
+		TLSHandshakeTimeout:   timeout(opts.TLSHandshakeTimeout, DefaultTransportTLSHandshakeTimeout),
+		ResponseHeaderTimeout: timeout(opts.ResponseHeaderTimeout, DefaultTransportResponseHeaderTimeout),
+		ExpectContinueTimeout: timeout(opts.ExpectContinueTimeout, DefaultTransportExpectContinueTimeout),
+		IdleConnTimeout:       timeout(opts.IdleConnTimeout, DefaultTransportIdleConnTimeout),
+		MaxIdleConns:          maxIdleConns,
+		MaxIdleConnsPerHost:   maxIdleConnsPerHost,
+		MaxConnsPerHost:       opts.MaxConnsPerHost,
+		WriteBufferSize:       DefaultTransportBufferSize,
+		ReadBufferSize:        DefaultTransportBufferSize,
+		DisableCompression:    true,
+		ForceAttemptHTTP2:     !opts.DisableHTTP2,
+	}
+	if opts.DisableHTTP2 {
+		// A non-nil empty map disables HTTP/2.
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+	return transport
+}
+
+// CloneWithTLS - returns a copy of transport using a clone of tlsConfig,
+// e.g. to connect to hosts with other CAs or client certificates. The
+// connections of transport are not shared.
+func CloneWithTLS(transport *http.Transport, tlsConfig *tls.Config) *http.Transport {
+	clone := transport.Clone()
+	clone.TLSClientConfig = tlsConfig.Clone()
+	return clone
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package net
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewTransportOptions(t *testing.T) {
+	transport := NewTransport(TransportOptions{})
+	if transport.TLSHandshakeTimeout != DefaultTransportTLSHandshakeTimeout ||
+		transport.ResponseHeaderTimeout != DefaultTransportResponseHeaderTimeout ||
+		transport.ExpectContinueTimeout != DefaultTransportExpectContinueTimeout ||
+		transport.IdleConnTimeout != DefaultTransportIdleConnTimeout ||
+		transport.MaxIdleConns != DefaultTransportMaxIdleConns ||
+		transport.MaxIdleConnsPerHost != DefaultTransportMaxIdleConnsPerHost ||
+		transport.MaxConnsPerHost != 0 ||
+		!transport.DisableCompression || !transport.ForceAttemptHTTP2 ||
+		transport.TLSNextProto != nil || transport.Proxy == nil {
+		t.Fatalf("unexpected defaults: %+v", transport)
+	}
+
+	tlsConfig := &tls.Config{ServerName: "minio.test"}
+	transport = NewTransport(TransportOptions{
+		TLSHandshakeTimeout:   time.Second,
+		ResponseHeaderTimeout: -1,
+		ExpectContinueTimeout: 2 * time.Second,
+		IdleConnTimeout:       3 * time.Second,
+		MaxIdleConns:          10,
+		MaxIdleConnsPerHost:   5,
+		MaxConnsPerHost:       20,
+		DisableHTTP2:          true,
+		TLSConfig:             tlsConfig,
+	})
+	if transport.TLSHandshakeTimeout != time.Second ||
+		transport.ResponseHeaderTimeout != 0 ||
+		transport.ExpectContinueTimeout != 2*time.Second ||
+		transport.IdleConnTimeout != 3*time.Second ||
+		transport.MaxIdleConns != 10 ||
+		transport.MaxIdleConnsPerHost != 5 ||
+		transport.MaxConnsPerHost != 20 ||
+		transport.ForceAttemptHTTP2 || transport.TLSNextProto == nil {
+		t.Fatalf("unexpected options: %+v", transport)
+	}
+	if transport.TLSClientConfig == tlsConfig || transport.TLSClientConfig.ServerName != tlsConfig.ServerName {
+		t.Fatalf("expected a clone of: %v, got: %v", tlsConfig, transport.TLSClientConfig)
+	}
+}
+
+func TestNewTransportResponseHeaderTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := &http.Client{Transport: NewTransport(TransportOptions{ResponseHeaderTimeout: 50 * time.Millisecond, Proxy: noProxy})}
+	_, err := client.Get(server.URL)
+	if err == nil || !strings.Contains(err.Error(), "timeout awaiting response headers") {
+		t.Fatalf("expected a response header timeout, got: %v", err)
+	}
+}
+
+func TestNewTransportTLSHandshakeTimeout(t *testing.T) {
+	// The listener accepts connections but never answers the handshake.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		var conns []net.Conn
+		defer func() {
+			for _, conn := range conns {
+				conn.Close()
+			}
+		}()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conns = append(conns, conn)
+		}
+	}()
+
+	client := &http.Client{Transport: NewTransport(TransportOptions{TLSHandshakeTimeout: 50 * time.Millisecond, Proxy: noProxy})}
+	_, err = client.Get("https://" + listener.Addr().String())
+	if err == nil || !strings.Contains(err.Error(), "TLS handshake timeout") {
+		t.Fatalf("expected a TLS handshake timeout, got: %v", err)
+	}
+}
+
+func TestNewTransportHTTP2(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, r.Proto)
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+	tlsConfig := server.Client().Transport.(*http.Transport).TLSClientConfig
+
+	testCases := []struct {
+		transport     *http.Transport
+		expectedProto string
+	}{
+		{NewTransport(TransportOptions{TLSConfig: tlsConfig, Proxy: noProxy}), "HTTP/2.0"},
+		{NewTransport(TransportOptions{TLSConfig: tlsConfig, Proxy: noProxy, DisableHTTP2: true}), "HTTP/1.1"},
+		{CloneWithTLS(NewTransport(TransportOptions{Proxy: noProxy}), tlsConfig), "HTTP/2.0"},
+		{CloneWithTLS(NewTransport(TransportOptions{Proxy: noProxy, DisableHTTP2: true}), tlsConfig), "HTTP/1.1"},
+	}
+
+	for i, testCase := range testCases {
+		resp, err := (&http.Client{Transport: testCase.transport}).Get(server.URL)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		testCase.transport.CloseIdleConnections()
+		if string(body) != testCase.expectedProto {
+			t.Fatalf("case %v: expected: %v, got: %s", i+1, testCase.expectedProto, body)
+		}
+	}
+}
+
+func TestCloneWithTLS(t *testing.T) {
+	tlsConfig := &tls.Config{ServerName: "a.minio.test"}
+	transport := NewTransport(TransportOptions{TLSConfig: tlsConfig, MaxConnsPerHost: 8})
+	other := &tls.Config{ServerName: "b.minio.test"}
+	clone := CloneWithTLS(transport, other)
+
+	if clone == transport || clone.TLSClientConfig == other || clone.TLSClientConfig.ServerName != other.ServerName {
+		t.Fatalf("expected a clone using: %v, got: %v", other, clone.TLSClientConfig)
+	}
+	if transport.TLSClientConfig.ServerName != tlsConfig.ServerName {
+		t.Fatalf("expected: %v, got: %v", tlsConfig.ServerName, transport.TLSClientConfig.ServerName)
+	}
+	if clone.MaxConnsPerHost != 8 || clone.DialContext == nil || !clone.DisableCompression {
+		t.Fatalf("expected the options of: %+v, got: %+v", transport, clone)
+	}
+}
+
+func TestNewTransportResolver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upstream := &fakeResolver{hosts: map[string][]string{"minio.test": {"127.0.0.1"}}, ttl: time.Minute}
+	r, _ := newTestCachingResolver(upstream, CachingResolverOptions{})
+	transport := NewTransport(TransportOptions{Resolver: r, Proxy: noProxy})
+	resp, err := (&http.Client{Transport: transport}).Get("http://" + net.JoinHostPort("minio.test", port))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if n := upstream.lookups.Load(); n != 1 {
+		t.Fatalf("expected: %v, got: %v", 1, n)
+	}
+}
+
+// BenchmarkTransport - compares concurrent small requests to a host with
+// the default Go transport, which keeps 2 idle connections per host, and
+// NewTransport.
+func BenchmarkTransport(b *testing.B) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	transports := []struct {
+		name      string
+		transport *http.Transport
+	}{
+		{"default", http.DefaultTransport.(*http.Transport).Clone()},
+		{"tuned", NewTransport(TransportOptions{Proxy: noProxy})},
+	}
+	for _, tt := range transports {
+		b.Run(tt.name, func(b *testing.B) {
+			client := &http.Client{Transport: tt.transport}
+			defer tt.transport.CloseIdleConnections()
+			b.SetParallelism(8)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					resp, err := client.Get(server.URL)
+					if err != nil {
+						b.Error(err)
+						return
+					}
+					io.Copy(io.Discard, resp.Body)
+					resp.Body.Close()
+				}
+			})
+		})
+	}
+}
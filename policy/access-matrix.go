@@ -0,0 +1,178 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/minio/pkg/v3/policy/condition"
+	"github.com/minio/pkg/v3/wildcard"
+)
+
+// BucketAccess - summary of the access granted by a policy on a bucket.
+type BucketAccess struct {
+	CanList   bool
+	CanRead   bool
+	CanWrite  bool
+	CanDelete bool
+
+	// Partial is set if access is granted, or denied, only for some
+	// prefixes of the bucket, e.g. by a resource "bucket/home/*" or a
+	// s3:prefix condition. The capabilities then report whether access
+	// is granted for any prefix.
+	Partial bool
+}
+
+// accessMatrixActions - actions checked for the capabilities of
+// BucketAccess, in the same order.
+var accessMatrixActions = [...]Action{
+	ListBucketAction,
+	GetObjectAction,
+	PutObjectAction,
+	DeleteObjectAction,
+}
+
+// coverage - how much of a bucket a statement applies to.
+type coverage int
+
+const (
+	coverageNone coverage = iota
+	coveragePartial
+	coverageFull
+)
+
+// accessState - coverage of the allow and deny statements applying to an
+// action on a bucket.
+type accessState struct {
+	allow, deny coverage
+}
+
+// resolve - returns whether access is granted, and whether only for some
+// prefixes.
+func (s accessState) resolve() (allowed, partial bool) {
+	switch {
+	case s.deny == coverageFull:
+		return false, false
+	case s.allow == coverageFull:
+		return true, s.deny == coveragePartial
+	case s.allow == coveragePartial:
+		return true, true
+	}
+	return false, false
+}
+
+// AccessMatrix - returns for each bucket whether policy p allows to list
+// the bucket and to read, write and delete its objects. Statements are
+// walked only once, for all buckets and capabilities. Entries without
+// Partial set agree with evaluating p.IsAllowed for the bucket and any
+// object in it.
+func AccessMatrix(p Policy, buckets []string, conditionValues map[string][]string) map[string]BucketAccess {
+	states := make([][len(accessMatrixActions)]accessState, len(buckets))
+
+	_, hasPrefix := conditionValues[condition.S3Prefix.Name()]
+	var pat bytes.Buffer
+	var patterns []string
+	for _, statement := range p.Statements {
+		// Policy variables do not depend on the bucket, substitute them
+		// once per statement.
+		patterns = patterns[:0]
+		for resource := range statement.Resources {
+			if !resource.isS3() {
+				continue
+			}
+			pat.Reset()
+			resource.substitute(&pat, conditionValues)
+			patterns = append(patterns, pat.String())
+		}
+
+		conditionsOK := statement.Conditions.Evaluate(conditionValues)
+		prefixScoped := !hasPrefix && statement.Conditions.Keys().Match(condition.S3Prefix.ToKey())
+
+		for i, action := range accessMatrixActions {
+			if !statement.matchAction(action) {
+				continue
+			}
+			for j, bucket := range buckets {
+				var c coverage
+				switch {
+				case action == ListBucketAction && prefixScoped:
+					// Listing depends on the requested prefix.
+					c = min(bucketCoverage(patterns, bucket), coveragePartial)
+				case !conditionsOK:
+					continue
+				case action == ListBucketAction:
+					c = bucketCoverage(patterns, bucket)
+				default:
+					c = objectCoverage(patterns, bucket)
+				}
+
+				state := &states[j][i]
+				switch statement.Effect {
+				case Deny:
+					state.deny = max(state.deny, c)
+				case Allow:
+					state.allow = max(state.allow, c)
+				}
+			}
+		}
+	}
+
+	matrix := make(map[string]BucketAccess, len(buckets))
+	for j, bucket := range buckets {
+		var access BucketAccess
+		var partial [len(accessMatrixActions)]bool
+		access.CanList, partial[0] = states[j][0].resolve()
+		access.CanRead, partial[1] = states[j][1].resolve()
+		access.CanWrite, partial[2] = states[j][2].resolve()
+		access.CanDelete, partial[3] = states[j][3].resolve()
+		access.Partial = partial[0] || partial[1] || partial[2] || partial[3]
+		matrix[bucket] = access
+	}
+	return matrix
+}
+
+// bucketCoverage - returns whether any of the resource patterns matches
+// the bucket itself.
+func bucketCoverage(patterns []string, bucket string) coverage {
+	for _, pattern := range patterns {
+		if matchPattern(pattern, bucket+"/") {
+			return coverageFull
+		}
+	}
+	return coverageNone
+}
+
+// objectCoverage - returns whether the resource patterns match all, some
+// or none of the objects of the bucket. A pattern matches all objects if
+// it ends with '*' and matches the bucket with an empty object name, e.g.
+// "bucket/*" or "buck*", and may match some objects if a prefix of it
+// matches the bucket followed by '/', e.g. "bucket/home/*".
+func objectCoverage(patterns []string, bucket string) coverage {
+	c := coverageNone
+	prefix := bucket + "/"
+	for _, pattern := range patterns {
+		switch {
+		case strings.HasSuffix(pattern, "*") && wildcard.Match(pattern, prefix):
+			return coverageFull
+		case wildcard.MatchAsPatternPrefix(pattern, prefix):
+			c = coveragePartial
+		}
+	}
+	return c
+}
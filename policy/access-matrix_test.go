@@ -0,0 +1,157 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func TestAccessMatrix(t *testing.T) {
+	testCases := []struct {
+		policy   string
+		expected BucketAccess
+	}{
+		// Read only.
+		{`{"Version": "2012-10-17", "Statement": [
+			{"Effect": "Allow", "Action": ["s3:ListBucket", "s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket", "arn:aws:s3:::mybucket/*"]}
+		]}`, BucketAccess{CanList: true, CanRead: true}},
+		// Wildcard bucket.
+		{`{"Version": "2012-10-17", "Statement": [
+			{"Effect": "Allow", "Action": "s3:*", "Resource": "arn:aws:s3:::my*"}
+		]}`, BucketAccess{CanList: true, CanRead: true, CanWrite: true, CanDelete: true}},
+		// Prefix scoped.
+		{`{"Version": "2012-10-17", "Statement": [
+			{"Effect": "Allow", "Action": "s3:ListBucket", "Resource": "arn:aws:s3:::mybucket", "Condition": {"StringLike": {"s3:prefix": "home/*"}}},
+			{"Effect": "Allow", "Action": "s3:PutObject", "Resource": "arn:aws:s3:::mybucket/home/*"}
+		]}`, BucketAccess{CanList: true, CanWrite: true, Partial: true}},
+		// Denied for some prefix only.
+		{`{"Version": "2012-10-17", "Statement": [
+			{"Effect": "Allow", "Action": "s3:*Object", "Resource": "arn:aws:s3:::mybucket/*"},
+			{"Effect": "Deny", "Action": "s3:DeleteObject", "Resource": "arn:aws:s3:::mybucket/locked/*"}
+		]}`, BucketAccess{CanRead: true, CanWrite: true, CanDelete: true, Partial: true}},
+		// Denied for all objects.
+		{`{"Version": "2012-10-17", "Statement": [
+			{"Effect": "Allow", "Action": "s3:*Object", "Resource": "arn:aws:s3:::mybucket/*"},
+			{"Effect": "Deny", "Action": "s3:DeleteObject", "Resource": "arn:aws:s3:::*"}
+		]}`, BucketAccess{CanRead: true, CanWrite: true}},
+		// Policy variables and conditions.
+		{`{"Version": "2012-10-17", "Statement": [
+			{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::${aws:username}/*"},
+			{"Effect": "Allow", "Action": "s3:PutObject", "Resource": "arn:aws:s3:::mybucket/*", "Condition": {"StringEquals": {"aws:username": "other"}}}
+		]}`, BucketAccess{CanRead: true}},
+		// Other bucket.
+		{`{"Version": "2012-10-17", "Statement": [
+			{"Effect": "Allow", "Action": "s3:*", "Resource": "arn:aws:s3:::otherbucket/*"}
+		]}`, BucketAccess{}},
+	}
+
+	for i, testCase := range testCases {
+		p, err := ParseConfig(strings.NewReader(testCase.policy))
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+
+		matrix := AccessMatrix(*p, []string{"mybucket"}, map[string][]string{"username": {"mybucket"}})
+		if access := matrix["mybucket"]; access != testCase.expected {
+			t.Fatalf("case %v: expected: %+v, got: %+v", i+1, testCase.expected, access)
+		}
+	}
+}
+
+func TestAccessMatrixIsAllowed(t *testing.T) {
+	actions := []Action{ListBucketAction, GetObjectAction, PutObjectAction, DeleteObjectAction, "s3:*", "s3:*Object", "s3:Get*"}
+	resources := []string{"*", "b1", "b1/*", "b*", "b*/*", "b1/home/*", "b2/*", "b?/*", "${aws:username}/*", "${aws:username}", "b3/a?c/*"}
+	buckets := []string{"b1", "b2", "b3", "bb", "other"}
+	objects := []string{"x", "home/file", "a/b/c", "abc/d"}
+	conditionValues := map[string][]string{"username": {"b3"}}
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	pick := func(n int) int { return rng.IntN(n) }
+
+	var compared int
+	for n := 0; n < 500; n++ {
+		var statements []string
+		for s := 0; s < 1+pick(4); s++ {
+			effect := "Allow"
+			if pick(4) == 0 {
+				effect = "Deny"
+			}
+			var stmtActions, stmtResources []string
+			for a := 0; a < 1+pick(2); a++ {
+				stmtActions = append(stmtActions, fmt.Sprintf("%q", actions[pick(len(actions))]))
+			}
+			for r := 0; r < 1+pick(2); r++ {
+				stmtResources = append(stmtResources, fmt.Sprintf("%q", ResourceARNPrefix+resources[pick(len(resources))]))
+			}
+			var cond string
+			switch pick(5) {
+			case 0:
+				cond = `, "Condition": {"StringEquals": {"aws:username": "b3"}}`
+			case 1:
+				cond = `, "Condition": {"StringEquals": {"aws:username": "nobody"}}`
+			}
+			statements = append(statements, fmt.Sprintf(`{"Effect": %q, "Action": [%s], "Resource": [%s]%s}`,
+				effect, strings.Join(stmtActions, ","), strings.Join(stmtResources, ","), cond))
+		}
+		data := fmt.Sprintf(`{"Version": "2012-10-17", "Statement": [%s]}`, strings.Join(statements, ","))
+		p, err := ParseConfig(strings.NewReader(data))
+		if err != nil {
+			// Generated object actions without object resources are invalid.
+			continue
+		}
+
+		matrix := AccessMatrix(*p, buckets, conditionValues)
+		for _, bucket := range buckets {
+			access := matrix[bucket]
+			if access.Partial {
+				continue
+			}
+			compared++
+
+			isAllowed := func(action Action, object string) bool {
+				return p.IsAllowed(Args{
+					AccountName:     "b3",
+					Action:          action,
+					BucketName:      bucket,
+					ObjectName:      object,
+					ConditionValues: conditionValues,
+				})
+			}
+			if expected := isAllowed(ListBucketAction, ""); expected != access.CanList {
+				t.Fatalf("%s: bucket %s: list: expected: %v, got: %v", data, bucket, expected, access.CanList)
+			}
+			for _, object := range objects {
+				for action, got := range map[Action]bool{
+					GetObjectAction:    access.CanRead,
+					PutObjectAction:    access.CanWrite,
+					DeleteObjectAction: access.CanDelete,
+				} {
+					if expected := isAllowed(action, object); expected != got {
+						t.Fatalf("%s: bucket %s: %s %s: expected: %v, got: %v", data, bucket, action, object, expected, got)
+					}
+				}
+			}
+		}
+	}
+	if compared < 500 {
+		t.Fatalf("expected at least 500 comparisons, got %v", compared)
+	}
+}
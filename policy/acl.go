@@ -0,0 +1,264 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+// MinIO buckets always behave like S3 buckets with the BucketOwnerEnforced
+// object ownership setting, i.e. ACLs are disabled. The ACL actions are
+// only supported so that policies exported from AWS parse.
+var aclActions = map[Action]struct{}{
+	GetBucketACLAction:        {},
+	PutBucketACLAction:        {},
+	GetObjectACLAction:        {},
+	PutObjectACLAction:        {},
+	GetObjectVersionACLAction: {},
+	PutObjectVersionACLAction: {},
+}
+
+var aclActionsAllowed atomic.Bool
+
+// SetACLActionsAllowed sets the outcome of evaluating an ACL action, such
+// as s3:PutObjectAcl, against a bucket policy. ACL actions are no-ops, so
+// the statements of the policy are not consulted. ACL actions are denied
+// by default.
+func SetACLActionsAllowed(allowed bool) {
+	aclActionsAllowed.Store(allowed)
+}
+
+// isACLAction - returns whether the action is an ACL action.
+func (action Action) isACLAction() bool {
+	_, ok := aclActions[action]
+	return ok
+}
+
+// ErrUnsupportedACL - the ACL has no bucket policy equivalent.
+var ErrUnsupportedACL = errors.New("unsupported ACL")
+
+// Canned ACLs of the x-amz-acl header.
+const (
+	CannedACLPrivate                = "private"
+	CannedACLPublicRead             = "public-read"
+	CannedACLPublicReadWrite        = "public-read-write"
+	CannedACLAuthenticatedRead      = "authenticated-read"
+	CannedACLBucketOwnerRead        = "bucket-owner-read"
+	CannedACLBucketOwnerFullControl = "bucket-owner-full-control"
+)
+
+// GranteeType - type of the grantee of an ACL grant.
+type GranteeType string
+
+// Grantee types.
+const (
+	GranteeCanonicalUser GranteeType = "CanonicalUser"
+	GranteeGroup         GranteeType = "Group"
+)
+
+// Group URIs of predefined ACL grantee groups.
+const (
+	GroupAllUsers           = "http://acs.amazonaws.com/groups/global/AllUsers"
+	GroupAuthenticatedUsers = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+)
+
+// ACLPermission - permission granted by an ACL grant.
+type ACLPermission string
+
+// ACL permissions.
+const (
+	ACLPermissionRead        ACLPermission = "READ"
+	ACLPermissionWrite       ACLPermission = "WRITE"
+	ACLPermissionReadACP     ACLPermission = "READ_ACP"
+	ACLPermissionWriteACP    ACLPermission = "WRITE_ACP"
+	ACLPermissionFullControl ACLPermission = "FULL_CONTROL"
+)
+
+// Grant - a bucket ACL grant, as in an AccessControlPolicy document or a
+// x-amz-grant-* header.
+type Grant struct {
+	GranteeType GranteeType
+	// ID of a canonical user grantee.
+	ID string
+	// URI of a group grantee.
+	URI        string
+	Permission ACLPermission
+}
+
+// aclPermissionActions - the bucket and object actions a bucket ACL
+// permission is equivalent to.
+var aclPermissionActions = map[ACLPermission]struct {
+	bucket, object []Action
+}{
+	ACLPermissionRead: {
+		bucket: []Action{ListBucketAction, ListBucketVersionsAction, ListBucketMultipartUploadsAction},
+		object: []Action{GetObjectAction, GetObjectVersionAction},
+	},
+	ACLPermissionWrite: {
+		object: []Action{PutObjectAction, DeleteObjectAction},
+	},
+	ACLPermissionReadACP: {
+		bucket: []Action{GetBucketACLAction},
+	},
+	ACLPermissionWriteACP: {
+		bucket: []Action{PutBucketACLAction},
+	},
+}
+
+// authenticatedPrincipal stands for the AuthenticatedUsers group, which
+// has no principal of its own.
+const authenticatedPrincipal = GroupAuthenticatedUsers
+
+// grantPrincipal - returns the principal of the grantee of grant.
+func grantPrincipal(grant Grant) (string, error) {
+	switch grant.GranteeType {
+	case GranteeCanonicalUser:
+		if grant.ID != "" {
+			return grant.ID, nil
+		}
+	case GranteeGroup:
+		switch grant.URI {
+		case GroupAllUsers:
+			return "*", nil
+		case GroupAuthenticatedUsers:
+			return authenticatedPrincipal, nil
+		}
+	}
+	return "", Errorf("%w grantee %v '%v%v'", ErrUnsupportedACL, grant.GranteeType, grant.ID, grant.URI)
+}
+
+// FromCannedACL - returns the bucket policy equivalent to the canned ACL
+// acl of bucket. Canned ACLs granting access to the bucket owner only
+// return a policy without statements, since the owner is always allowed.
+func FromCannedACL(acl string, bucket string) (BucketPolicy, error) {
+	var grants []Grant
+	switch acl {
+	case CannedACLPrivate, CannedACLBucketOwnerRead, CannedACLBucketOwnerFullControl:
+	case CannedACLPublicRead:
+		grants = []Grant{{GranteeType: GranteeGroup, URI: GroupAllUsers, Permission: ACLPermissionRead}}
+	case CannedACLPublicReadWrite:
+		grants = []Grant{
+			{GranteeType: GranteeGroup, URI: GroupAllUsers, Permission: ACLPermissionRead},
+			{GranteeType: GranteeGroup, URI: GroupAllUsers, Permission: ACLPermissionWrite},
+		}
+	case CannedACLAuthenticatedRead:
+		grants = []Grant{{GranteeType: GranteeGroup, URI: GroupAuthenticatedUsers, Permission: ACLPermissionRead}}
+	default:
+		return BucketPolicy{}, Errorf("%w '%v'", ErrUnsupportedACL, acl)
+	}
+	return FromGrants(grants, bucket)
+}
+
+// FromGrants - returns the bucket policy equivalent to the ACL grants of
+// bucket. Grantees with the same permissions share statements, which
+// allow the bucket actions on the bucket and the object actions on its
+// objects. The AllUsers group maps to the principal "*" and the
+// AuthenticatedUsers group to the principal "*" with the condition that
+// aws:principaltype is not "Anonymous".
+func FromGrants(grants []Grant, bucket string) (BucketPolicy, error) {
+	permissions := make(map[string]map[ACLPermission]struct{})
+	for _, grant := range grants {
+		if _, ok := aclPermissionActions[grant.Permission]; !ok && grant.Permission != ACLPermissionFullControl {
+			return BucketPolicy{}, Errorf("%w permission '%v'", ErrUnsupportedACL, grant.Permission)
+		}
+		principal, err := grantPrincipal(grant)
+		if err != nil {
+			return BucketPolicy{}, err
+		}
+		if permissions[principal] == nil {
+			permissions[principal] = make(map[ACLPermission]struct{})
+		}
+		if grant.Permission == ACLPermissionFullControl {
+			for permission := range aclPermissionActions {
+				permissions[principal][permission] = struct{}{}
+			}
+			continue
+		}
+		permissions[principal][grant.Permission] = struct{}{}
+	}
+
+	// Group principals by their permissions.
+	principals := make(map[string][]string)
+	for principal, perms := range permissions {
+		var names []string
+		for permission := range perms {
+			names = append(names, string(permission))
+		}
+		sort.Strings(names)
+		key := strings.Join(names, ",")
+		principals[key] = append(principals[key], principal)
+	}
+	keys := make([]string, 0, len(principals))
+	for key := range principals {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	policy := BucketPolicy{Version: DefaultVersion}
+	for _, key := range keys {
+		bucketActions, objectActions := NewActionSet(), NewActionSet()
+		for _, permission := range strings.Split(key, ",") {
+			actions := aclPermissionActions[ACLPermission(permission)]
+			for _, action := range actions.bucket {
+				bucketActions.Add(action)
+			}
+			for _, action := range actions.object {
+				objectActions.Add(action)
+			}
+		}
+
+		appendStatements := func(principal Principal, conditions condition.Functions) {
+			if !bucketActions.IsEmpty() {
+				policy.Statements = append(policy.Statements, NewBPStatement("", Allow, principal,
+					bucketActions, NewResourceSet(NewResource(bucket)), conditions))
+			}
+			if !objectActions.IsEmpty() {
+				policy.Statements = append(policy.Statements, NewBPStatement("", Allow, principal,
+					objectActions, NewResourceSet(NewResource(bucket+"/*")), conditions))
+			}
+		}
+
+		var authenticated bool
+		grantees := principals[key][:0]
+		for _, principal := range principals[key] {
+			if principal == authenticatedPrincipal {
+				authenticated = true
+				continue
+			}
+			grantees = append(grantees, principal)
+		}
+		if len(grantees) > 0 {
+			appendStatements(NewPrincipal(grantees...), condition.NewFunctions())
+		}
+		if authenticated {
+			// All principals but anonymous ones.
+			f, _ := condition.NewStringNotEqualsFunc("", condition.AWSPrincipalType.ToKey(), "Anonymous")
+			appendStatements(NewPrincipal("*"), condition.NewFunctions(f))
+		}
+	}
+
+	if err := policy.Validate(bucket); err != nil {
+		return BucketPolicy{}, err
+	}
+	return policy, nil
+}
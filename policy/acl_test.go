@@ -0,0 +1,229 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBucketPolicyACL(t *testing.T) {
+	// Standard AWS policy requiring uploads to grant the bucket owner
+	// full control of objects.
+	requireOwnerFullControl := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "RequireBucketOwnerFullControl",
+      "Effect": "Deny",
+      "Principal": "*",
+      "Action": "s3:PutObject",
+      "Resource": "arn:aws:s3:::mybucket/*",
+      "Condition": {
+        "StringNotEquals": {
+          "s3:x-amz-acl": "bucket-owner-full-control"
+        }
+      }
+    },
+    {
+      "Effect": "Allow",
+      "Principal": {"AWS": ["*"]},
+      "Action": ["s3:PutObject", "s3:PutObjectAcl", "s3:GetObject"],
+      "Resource": "arn:aws:s3:::mybucket/*"
+    },
+    {
+      "Effect": "Allow",
+      "Principal": "*",
+      "Action": "s3:CreateBucket",
+      "Resource": "arn:aws:s3:::mybucket",
+      "Condition": {
+        "StringEquals": {
+          "s3:x-amz-object-ownership": "BucketOwnerEnforced"
+        }
+      }
+    },
+    {
+      "Effect": "Allow",
+      "Principal": "*",
+      "Action": "s3:GetBucketAcl",
+      "Resource": "arn:aws:s3:::mybucket"
+    }
+  ]
+}`
+
+	policy, err := ParseBucketPolicyConfig(strings.NewReader(requireOwnerFullControl), "mybucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedWarnings := []string{
+		"statement 'RequireBucketOwnerFullControl': condition key 's3:x-amz-acl' only has a value if the request sends an ACL, which clients of owner enforced buckets usually do not",
+		"statement 2: action 's3:PutObjectAcl' is ignored, ACLs are not supported on owner enforced buckets",
+		"statement 4: action 's3:GetBucketAcl' is ignored, ACLs are not supported on owner enforced buckets",
+	}
+	if warnings := policy.Lint(); !reflect.DeepEqual(warnings, expectedWarnings) {
+		t.Fatalf("expected: %q, got: %q", expectedWarnings, warnings)
+	}
+
+	defer SetACLActionsAllowed(false)
+
+	testCases := []struct {
+		action          Action
+		conditionValues map[string][]string
+		aclAllowed      bool
+		expectedResult  bool
+	}{
+		{PutObjectAction, map[string][]string{"x-amz-acl": {"bucket-owner-full-control"}}, false, true},
+		{PutObjectAction, map[string][]string{"x-amz-acl": {"public-read"}}, false, false},
+		// Clients of owner enforced buckets do not send an ACL.
+		{PutObjectAction, map[string][]string{}, false, false},
+		{GetObjectAction, map[string][]string{}, false, true},
+		// ACL actions are not evaluated against the policy.
+		{PutObjectACLAction, map[string][]string{}, false, false},
+		{PutObjectACLAction, map[string][]string{}, true, true},
+		{GetObjectACLAction, map[string][]string{}, true, true},
+	}
+
+	for i, testCase := range testCases {
+		SetACLActionsAllowed(testCase.aclAllowed)
+		result := policy.IsAllowed(BucketPolicyArgs{
+			AccountName:     "Q3AM3UQ867SPQQA43P2F",
+			Action:          testCase.action,
+			BucketName:      "mybucket",
+			ConditionValues: testCase.conditionValues,
+			ObjectName:      "myobject",
+		})
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestFromCannedACL(t *testing.T) {
+	type access struct {
+		list, read, write bool
+	}
+	testCases := []struct {
+		acl           string
+		anonymous     access
+		authenticated access
+	}{
+		{CannedACLPrivate, access{}, access{}},
+		{CannedACLBucketOwnerFullControl, access{}, access{}},
+		{CannedACLPublicRead, access{true, true, false}, access{true, true, false}},
+		{CannedACLPublicReadWrite, access{true, true, true}, access{true, true, true}},
+		{CannedACLAuthenticatedRead, access{}, access{true, true, false}},
+	}
+
+	for i, testCase := range testCases {
+		p, err := FromCannedACL(testCase.acl, "mybucket")
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if err = p.Validate("mybucket"); err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+
+		for _, principal := range []struct {
+			account       string
+			principalType string
+			isOwner       bool
+			expected      access
+		}{
+			{"", "Anonymous", false, testCase.anonymous},
+			{"alice", "User", false, testCase.authenticated},
+			{"owner", "User", true, access{true, true, true}},
+		} {
+			isAllowed := func(action Action, bucket, object string) bool {
+				return p.IsAllowed(BucketPolicyArgs{
+					AccountName:     principal.account,
+					IsOwner:         principal.isOwner,
+					Action:          action,
+					BucketName:      bucket,
+					ObjectName:      object,
+					ConditionValues: map[string][]string{"principaltype": {principal.principalType}},
+				})
+			}
+			got := access{
+				list:  isAllowed(ListBucketAction, "mybucket", ""),
+				read:  isAllowed(GetObjectAction, "mybucket", "a/b"),
+				write: isAllowed(PutObjectAction, "mybucket", "a/b") && isAllowed(DeleteObjectAction, "mybucket", "a/b"),
+			}
+			if got != principal.expected {
+				t.Fatalf("case %v: account %q: expected: %+v, got: %+v", i+1, principal.account, principal.expected, got)
+			}
+			if !principal.isOwner && (isAllowed(GetObjectAction, "otherbucket", "a/b") || isAllowed(PutBucketPolicyAction, "mybucket", "")) {
+				t.Fatalf("case %v: account %q: unexpected access", i+1, principal.account)
+			}
+		}
+	}
+
+	for _, acl := range []string{"", "log-delivery-write", "Public-Read"} {
+		if _, err := FromCannedACL(acl, "mybucket"); !errors.Is(err, ErrUnsupportedACL) {
+			t.Fatalf("%q: expected: %v, got: %v", acl, ErrUnsupportedACL, err)
+		}
+	}
+}
+
+func TestFromGrants(t *testing.T) {
+	p, err := FromGrants([]Grant{
+		{GranteeType: GranteeCanonicalUser, ID: "alice", Permission: ACLPermissionFullControl},
+		{GranteeType: GranteeCanonicalUser, ID: "bob", Permission: ACLPermissionRead},
+		{GranteeType: GranteeCanonicalUser, ID: "carol", Permission: ACLPermissionRead},
+		{GranteeType: GranteeCanonicalUser, ID: "carol", Permission: ACLPermissionReadACP},
+		{GranteeType: GranteeGroup, URI: GroupAllUsers, Permission: ACLPermissionRead},
+		{GranteeType: GranteeGroup, URI: GroupAllUsers, Permission: ACLPermissionReadACP},
+		{GranteeType: GranteeGroup, URI: GroupAuthenticatedUsers, Permission: ACLPermissionWrite},
+	}, "mybucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `{"Version":"2012-10-17","Statement":[` +
+		`{"Effect":"Allow","Principal":{"AWS":["bob"]},"Action":["s3:ListBucket","s3:ListBucketMultipartUploads","s3:ListBucketVersions"],"Resource":["arn:aws:s3:::mybucket"]},` +
+		`{"Effect":"Allow","Principal":{"AWS":["bob"]},"Action":["s3:GetObject","s3:GetObjectVersion"],"Resource":["arn:aws:s3:::mybucket/*"]},` +
+		`{"Effect":"Allow","Principal":{"AWS":["*","carol"]},"Action":["s3:GetBucketAcl","s3:ListBucket","s3:ListBucketMultipartUploads","s3:ListBucketVersions"],"Resource":["arn:aws:s3:::mybucket"]},` +
+		`{"Effect":"Allow","Principal":{"AWS":["*","carol"]},"Action":["s3:GetObject","s3:GetObjectVersion"],"Resource":["arn:aws:s3:::mybucket/*"]},` +
+		`{"Effect":"Allow","Principal":{"AWS":["alice"]},"Action":["s3:GetBucketAcl","s3:ListBucket","s3:ListBucketMultipartUploads","s3:ListBucketVersions","s3:PutBucketAcl"],"Resource":["arn:aws:s3:::mybucket"]},` +
+		`{"Effect":"Allow","Principal":{"AWS":["alice"]},"Action":["s3:DeleteObject","s3:GetObject","s3:GetObjectVersion","s3:PutObject"],"Resource":["arn:aws:s3:::mybucket/*"]},` +
+		`{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:DeleteObject","s3:PutObject"],"Resource":["arn:aws:s3:::mybucket/*"],"Condition":{"StringNotEquals":{"aws:principaltype":["Anonymous"]}}}]}`
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != expected {
+		t.Fatalf("expected: %s, got: %s", expected, data)
+	}
+
+	testCases := []Grant{
+		{GranteeType: GranteeCanonicalUser, ID: "alice", Permission: "WRITE_ALL"},
+		{GranteeType: GranteeCanonicalUser, ID: "alice", Permission: ""},
+		{GranteeType: GranteeCanonicalUser, Permission: ACLPermissionRead},
+		{GranteeType: GranteeGroup, URI: "http://acs.amazonaws.com/groups/s3/LogDelivery", Permission: ACLPermissionWrite},
+		{GranteeType: "AmazonCustomerByEmail", ID: "alice@example.com", Permission: ACLPermissionRead},
+	}
+	for i, grant := range testCases {
+		if _, err := FromGrants([]Grant{grant}, "mybucket"); !errors.Is(err, ErrUnsupportedACL) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, ErrUnsupportedACL, err)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+//go:generate go test -run ^TestGeneratedActionFiles$ -update-generated .
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// actionService - service of actions, with the name of the TypeScript type
+// of its actions.
+type actionService struct {
+	service   string
+	typeName  string
+	constName string
+	title     string
+}
+
+// actionServices - services of the built-in actions, in the order of the
+// exported definitions.
+var actionServices = []actionService{
+	{"s3", "S3Action", "s3Actions", "S3"},
+	{"admin", "AdminAction", "adminActions", "Admin"},
+	{"kms", "KMSAction", "kmsActions", "KMS"},
+	{"sts", "STSAction", "stsActions", "STS"},
+	{"*", "WildcardAction", "wildcardActions", "Wildcard"},
+}
+
+// actionsByService - returns the documented actions by service, sorted,
+// and whether actions registered by RegisterAction are included. The "*"
+// action, of all services, is of the "*" service.
+func actionsByService(registered bool) map[string][]Action {
+	byService := make(map[string][]Action, len(actionServices))
+	for a := range actionDocs {
+		if _, ok := registeredActions[a]; ok && !registered {
+			continue
+		}
+		service := a.namespace()
+		if service == "" {
+			service = "*"
+		}
+		byService[service] = append(byService[service], a)
+	}
+	for _, actions := range byService {
+		sort.Slice(actions, func(i, j int) bool { return actions[i] < actions[j] })
+	}
+	return byService
+}
+
+// writeActionNamesGo - writes the Go source of BuiltinActionNames to w.
+func writeActionNamesGo(w io.Writer) error {
+	byService := actionsByService(false)
+
+	var b bytes.Buffer
+	b.WriteString(`// Code generated by "go generate"; DO NOT EDIT.
+
+package policy
+
+// BuiltinActionNames - names of the built-in actions, including the
+// wildcard families, by service, sorted. The "*" action is of the "*"
+// service. Actions registered by RegisterAction are not included.
+var BuiltinActionNames = map[string][]Action{
+`)
+	for _, s := range actionServices {
+		fmt.Fprintf(&b, "%q: {\n", s.service)
+		for _, a := range byService[s.service] {
+			fmt.Fprintf(&b, "%q,\n", a)
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// tsTypeName - returns the name of the TypeScript type of the actions of
+// service, e.g. "FooBarAction" for "foo-bar".
+func tsTypeName(service string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(service, func(r rune) bool { return r == '-' || r == '_' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	b.WriteString("Action")
+	return b.String()
+}
+
+// tsString - returns s as TypeScript string literal.
+func tsString(s string) string {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// ExportTypeScriptDefinitions - writes TypeScript definitions of all
+// supported actions to w: a constant object per service, mapping the
+// actions to their description, see ActionMetadata, its type of action
+// names, e.g. S3Action, and the union Action of these types. Actions
+// registered by RegisterAction are included, the definitions generated in
+// this package are of the built-in actions only.
+func ExportTypeScriptDefinitions(w io.Writer) error {
+	return writeTypeScriptDefinitions(w, actionsByService(true))
+}
+
+func writeTypeScriptDefinitions(w io.Writer, byService map[string][]Action) error {
+	var b strings.Builder
+	b.WriteString("// Code generated by github.com/minio/pkg/v3/policy; DO NOT EDIT.\n")
+
+	// Services of registered actions follow, sorted.
+	services := slices.Clone(actionServices)
+	var others []string
+	for service := range byService {
+		if !slices.ContainsFunc(actionServices, func(s actionService) bool { return s.service == service }) {
+			others = append(others, service)
+		}
+	}
+	sort.Strings(others)
+	for _, service := range others {
+		typeName := tsTypeName(service)
+		constName := strings.ToLower(typeName[:1]) + typeName[1:] + "s"
+		services = append(services, actionService{service, typeName, constName, service})
+	}
+
+	var typeNames []string
+	for _, s := range services {
+		actions := byService[s.service]
+		if len(actions) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n// %s - %s actions and their descriptions.\n", s.constName, s.title)
+		fmt.Fprintf(&b, "export const %s = {\n", s.constName)
+		for _, a := range actions {
+			fmt.Fprintf(&b, "  %s: %s,\n", tsString(string(a)), tsString(actionDocs[a].description))
+		}
+		b.WriteString("} as const;\n")
+		fmt.Fprintf(&b, "\nexport type %s = keyof typeof %s;\n", s.typeName, s.constName)
+		typeNames = append(typeNames, s.typeName)
+	}
+	fmt.Fprintf(&b, "\nexport type Action = %s;\n", strings.Join(typeNames, " | "))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
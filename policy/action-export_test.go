@@ -0,0 +1,123 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGenerated = flag.Bool("update-generated", false, "update the generated action files")
+
+// Generated action files, see go:generate in action-export.go.
+const (
+	actionNamesGoFile = "action-names_generated.go"
+	actionNamesTSFile = "export/actions.ts"
+)
+
+func TestGeneratedActionFiles(t *testing.T) {
+	var goSrc, tsSrc bytes.Buffer
+	if err := writeActionNamesGo(&goSrc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := writeTypeScriptDefinitions(&tsSrc, actionsByService(false)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, f := range []struct {
+		name string
+		data []byte
+	}{
+		{actionNamesGoFile, goSrc.Bytes()},
+		{actionNamesTSFile, tsSrc.Bytes()},
+	} {
+		if *updateGenerated {
+			if err := os.MkdirAll(filepath.Dir(f.name), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(f.name, f.data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		data, err := os.ReadFile(f.name)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !bytes.Equal(data, f.data) {
+			t.Fatalf("%v is not up to date, run go generate in the policy package", f.name)
+		}
+	}
+}
+
+func TestBuiltinActionNames(t *testing.T) {
+	var n int
+	for service, actions := range BuiltinActionNames {
+		for _, a := range actions {
+			if a.namespace() != strings.TrimPrefix(service, "*") {
+				t.Fatalf("%v: expected service %v", a, service)
+			}
+			if _, ok := actionDocs[a]; !ok {
+				t.Fatalf("%v: expected a supported action", a)
+			}
+			n++
+		}
+	}
+	if expected := len(actionDocs) - len(registeredActions); n != expected {
+		t.Fatalf("expected: %v actions, got: %v", expected, n)
+	}
+	for _, a := range []Action{GetObjectAction, AllActions, Action(AllAdminActions), KMSCreateKeyAction, AssumeRoleWithWebIdentityAction} {
+		found := false
+		for _, b := range BuiltinActionNames[a.namespace()] {
+			found = found || a == b
+		}
+		if !found {
+			t.Fatalf("expected %v to be a built-in action", a)
+		}
+	}
+}
+
+func TestExportTypeScriptDefinitions(t *testing.T) {
+	var b strings.Builder
+	if err := ExportTypeScriptDefinitions(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ts := b.String()
+
+	for _, s := range []string{
+		`export const s3Actions = {`,
+		`  "s3:GetObject": "` + actionDocs[GetObjectAction].description + `",`,
+		`export type KMSAction = keyof typeof kmsActions;`,
+		`export const wildcardActions = {
+  "*": "All actions",
+} as const;`,
+		// Registered actions, see register_test.go.
+		`  "admin:FooBar": "Foo bar",`,
+		`export const fooActions = {`,
+		`  "foo:Bar": `,
+		`export type Action = S3Action | AdminAction | KMSAction | STSAction | WildcardAction | FooAction;`,
+	} {
+		if !strings.Contains(ts, s) {
+			t.Fatalf("expected: %v in:\n%v", s, ts)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+)
+
+// ActionResourceType - type of the resources an action applies to.
+type ActionResourceType string
+
+const (
+	// ActionResourceBucket - the action applies to buckets.
+	ActionResourceBucket ActionResourceType = "bucket"
+
+	// ActionResourceObject - the action applies to objects.
+	ActionResourceObject ActionResourceType = "object"
+
+	// ActionResourceNone - the action does not apply to resources, as
+	// admin, KMS and STS actions.
+	ActionResourceNone ActionResourceType = "none"
+)
+
+// ActionMeta - documentation of an action.
+type ActionMeta struct {
+	Action           Action             `json:"action"`
+	Description      string             `json:"description"`
+	ResourceType     ActionResourceType `json:"resourceType"`
+	IsMinIOExtension bool               `json:"minioExtension"`
+	AWSDocURL        string             `json:"awsDocURL,omitempty"`
+}
+
+// actionDoc - entry of the action documentation table.
+type actionDoc struct {
+	description  string
+	resourceType ActionResourceType
+	extension    bool
+}
+
+// AWS references of the actions which are not MinIO extensions.
+const (
+	awsS3ActionsURL  = "https://docs.aws.amazon.com/service-authorization/latest/reference/list_amazons3.html"
+	awsSTSActionsURL = "https://docs.aws.amazon.com/service-authorization/latest/reference/list_awssecuritytokenservice.html"
+)
+
+// actionDocs - documentation of all supported actions, see
+// TestActionMetadataExhaustive. Actions registered by RegisterAction are
+// added with their ActionOptions.
+var actionDocs = map[Action]actionDoc{
+	AbortMultipartUploadAction:             {"Abort a multipart upload", ActionResourceObject, false},
+	CreateBucketAction:                     {"Create a bucket", ActionResourceBucket, false},
+	DeleteBucketAction:                     {"Delete an empty bucket", ActionResourceBucket, false},
+	ForceDeleteBucketAction:                {"Delete a bucket along with its objects", ActionResourceBucket, true},
+	DeleteBucketPolicyAction:               {"Delete the bucket policy", ActionResourceBucket, false},
+	DeleteBucketCorsAction:                 {"Delete the bucket CORS configuration", ActionResourceBucket, false},
+	DeleteObjectAction:                     {"Delete an object", ActionResourceObject, false},
+	GetBucketLocationAction:                {"Get the region of a bucket", ActionResourceBucket, false},
+	GetBucketNotificationAction:            {"Get the bucket notification configuration", ActionResourceBucket, false},
+	GetBucketPolicyAction:                  {"Get the bucket policy", ActionResourceBucket, false},
+	GetBucketCorsAction:                    {"Get the bucket CORS configuration", ActionResourceBucket, false},
+	GetObjectAction:                        {"Read an object and its metadata", ActionResourceObject, false},
+	HeadBucketAction:                       {"Check whether a bucket exists, unused by MinIO", ActionResourceBucket, false},
+	ListAllMyBucketsAction:                 {"List all buckets", ActionResourceNone, false},
+	ListBucketAction:                       {"List the objects of a bucket", ActionResourceBucket, false},
+	GetBucketPolicyStatusAction:            {"Get whether the bucket policy makes a bucket public", ActionResourceBucket, false},
+	ListBucketVersionsAction:               {"List the object versions of a bucket", ActionResourceBucket, false},
+	ListBucketMultipartUploadsAction:       {"List the multipart uploads in progress of a bucket", ActionResourceBucket, false},
+	ListenNotificationAction:               {"Listen to the events of all buckets", ActionResourceBucket, true},
+	ListenBucketNotificationAction:         {"Listen to the events of a bucket", ActionResourceBucket, true},
+	ListMultipartUploadPartsAction:         {"List the uploaded parts of a multipart upload", ActionResourceObject, false},
+	PutBucketLifecycleAction:               {"Set the bucket lifecycle configuration", ActionResourceBucket, false},
+	GetBucketLifecycleAction:               {"Get the bucket lifecycle configuration", ActionResourceBucket, false},
+	PutBucketNotificationAction:            {"Set the bucket notification configuration", ActionResourceBucket, false},
+	PutBucketPolicyAction:                  {"Set the bucket policy", ActionResourceBucket, false},
+	PutBucketCorsAction:                    {"Set the bucket CORS configuration", ActionResourceBucket, false},
+	PutObjectAction:                        {"Upload an object", ActionResourceObject, false},
+	BypassGovernanceRetentionAction:        {"Bypass governance mode retention", ActionResourceObject, false},
+	PutObjectRetentionAction:               {"Set the retention of an object", ActionResourceObject, false},
+	GetObjectRetentionAction:               {"Get the retention of an object", ActionResourceObject, false},
+	GetObjectLegalHoldAction:               {"Get the legal hold of an object", ActionResourceObject, false},
+	PutObjectLegalHoldAction:               {"Set the legal hold of an object", ActionResourceObject, false},
+	GetBucketObjectLockConfigurationAction: {"Get the bucket object lock configuration", ActionResourceBucket, false},
+	PutBucketObjectLockConfigurationAction: {"Set the bucket object lock configuration", ActionResourceBucket, false},
+	GetBucketTaggingAction:                 {"Get the tags of a bucket", ActionResourceBucket, false},
+	PutBucketTaggingAction:                 {"Set the tags of a bucket", ActionResourceBucket, false},
+	GetObjectVersionAction:                 {"Read a specific version of an object", ActionResourceObject, false},
+	GetObjectAttributesAction:              {"Get the attributes of an object", ActionResourceObject, false},
+	GetObjectVersionAttributesAction:       {"Get the attributes of a specific version of an object", ActionResourceObject, false},
+	GetObjectVersionTaggingAction:          {"Get the tags of a specific version of an object", ActionResourceObject, false},
+	DeleteObjectVersionAction:              {"Delete a specific version of an object", ActionResourceObject, false},
+	DeleteObjectVersionTaggingAction:       {"Delete the tags of a specific version of an object", ActionResourceObject, false},
+	PutObjectVersionTaggingAction:          {"Set the tags of a specific version of an object", ActionResourceObject, false},
+	GetObjectTaggingAction:                 {"Get the tags of an object", ActionResourceObject, false},
+	PutObjectTaggingAction:                 {"Set the tags of an object", ActionResourceObject, false},
+	DeleteObjectTaggingAction:              {"Delete the tags of an object", ActionResourceObject, false},
+	PutBucketEncryptionAction:              {"Set the bucket default encryption configuration", ActionResourceBucket, false},
+	GetBucketEncryptionAction:              {"Get the bucket default encryption configuration", ActionResourceBucket, false},
+	PutBucketVersioningAction:              {"Set the versioning state of a bucket", ActionResourceBucket, false},
+	GetBucketVersioningAction:              {"Get the versioning state of a bucket", ActionResourceBucket, false},
+	GetReplicationConfigurationAction:      {"Get the bucket replication configuration", ActionResourceBucket, false},
+	PutReplicationConfigurationAction:      {"Set the bucket replication configuration", ActionResourceBucket, false},
+	ReplicateObjectAction:                  {"Replicate objects to a bucket", ActionResourceObject, false},
+	ReplicateDeleteAction:                  {"Replicate deletes to a bucket", ActionResourceObject, false},
+	ReplicateTagsAction:                    {"Replicate object tags to a bucket", ActionResourceObject, false},
+	GetObjectVersionForReplicationAction:   {"Read object versions for replication", ActionResourceObject, false},
+	RestoreObjectAction:                    {"Restore a transitioned object", ActionResourceObject, false},
+	ResetBucketReplicationStateAction:      {"Reset the replication state of a bucket to replicate existing objects again", ActionResourceObject, true},
+	PutObjectFanOutAction:                  {"Upload an object to multiple keys at once", ActionResourceObject, true},
+	GetBucketACLAction:                     {"Get the ACL of a bucket, ACLs are ignored by MinIO", ActionResourceBucket, false},
+	PutBucketACLAction:                     {"Set the ACL of a bucket, ACLs are ignored by MinIO", ActionResourceBucket, false},
+	GetObjectACLAction:                     {"Get the ACL of an object, ACLs are ignored by MinIO", ActionResourceObject, false},
+	PutObjectACLAction:                     {"Set the ACL of an object, ACLs are ignored by MinIO", ActionResourceObject, false},
+	GetObjectVersionACLAction:              {"Get the ACL of a specific version of an object", ActionResourceObject, false},
+	PutObjectVersionACLAction:              {"Set the ACL of a specific version of an object", ActionResourceObject, false},
+	AllActions:                             {"All S3 actions", ActionResourceObject, false},
+
+	HealAdminAction:                  {"Heal buckets and objects", ActionResourceNone, true},
+	DecommissionAdminAction:          {"Decommission server pools", ActionResourceNone, true},
+	DecommissionStatusAdminAction:    {"Get the decommissioning status of server pools", ActionResourceNone, true},
+	RebalanceAdminAction:             {"Rebalance server pools", ActionResourceNone, true},
+	RebalanceStatusAdminAction:       {"Get the rebalancing status of server pools", ActionResourceNone, true},
+	StorageInfoAdminAction:           {"Get storage information", ActionResourceNone, true},
+	PrometheusAdminAction:            {"Get Prometheus metrics", ActionResourceNone, true},
+	DataUsageInfoAdminAction:         {"Get data usage information", ActionResourceNone, true},
+	TopLocksAdminAction:              {"List the oldest locks", ActionResourceNone, true},
+	ProfilingAdminAction:             {"Profile servers", ActionResourceNone, true},
+	TraceAdminAction:                 {"Trace server calls", ActionResourceNone, true},
+	ConsoleLogAdminAction:            {"Stream server logs", ActionResourceNone, true},
+	KMSCreateKeyAdminAction:          {"Create a KMS master key", ActionResourceNone, true},
+	KMSKeyStatusAdminAction:          {"Get the status of a KMS key", ActionResourceNone, true},
+	KMSImportKeyAdminAction:          {"Import a KMS master key", ActionResourceNone, true},
+	KMSExportKeyAdminAction:          {"Export a KMS master key", ActionResourceNone, true},
+	ServerInfoAdminAction:            {"Get server information", ActionResourceNone, true},
+	HealthInfoAdminAction:            {"Get cluster health information", ActionResourceNone, true},
+	LicenseInfoAdminAction:           {"Get license information", ActionResourceNone, true},
+	InspectDataAction:                {"Download raw files of the backend", ActionResourceNone, true},
+	ForceUnlockAdminAction:           {"Force unlock locks", ActionResourceNone, true},
+	BandwidthMonitorAction:           {"Monitor replication bandwidth", ActionResourceNone, true},
+	ServerUpdateAdminAction:          {"Update the server binaries", ActionResourceNone, true},
+	ServiceRestartAdminAction:        {"Restart servers", ActionResourceNone, true},
+	ServiceStopAdminAction:           {"Stop servers", ActionResourceNone, true},
+	ServiceFreezeAdminAction:         {"Freeze and unfreeze S3 API calls", ActionResourceNone, true},
+	ConfigUpdateAdminAction:          {"Manage the server configuration", ActionResourceNone, true},
+	CreateUserAdminAction:            {"Create users", ActionResourceNone, true},
+	DeleteUserAdminAction:            {"Delete users", ActionResourceNone, true},
+	ListUsersAdminAction:             {"List users", ActionResourceNone, true},
+	EnableUserAdminAction:            {"Enable users", ActionResourceNone, true},
+	DisableUserAdminAction:           {"Disable users", ActionResourceNone, true},
+	GetUserAdminAction:               {"Get user information", ActionResourceNone, true},
+	SiteReplicationAddAction:         {"Add sites to site replication", ActionResourceNone, true},
+	SiteReplicationDisableAction:     {"Disable site replication", ActionResourceNone, true},
+	SiteReplicationRemoveAction:      {"Remove sites from site replication", ActionResourceNone, true},
+	SiteReplicationResyncAction:      {"Resync data to a replicated site", ActionResourceNone, true},
+	SiteReplicationInfoAction:        {"Get site replication information", ActionResourceNone, true},
+	SiteReplicationOperationAction:   {"Apply site replication changes of peer sites", ActionResourceNone, true},
+	CreateServiceAccountAdminAction:  {"Create service accounts", ActionResourceNone, true},
+	UpdateServiceAccountAdminAction:  {"Update service accounts", ActionResourceNone, true},
+	RemoveServiceAccountAdminAction:  {"Remove service accounts", ActionResourceNone, true},
+	ListServiceAccountsAdminAction:   {"List service accounts", ActionResourceNone, true},
+	ListTemporaryAccountsAdminAction: {"List temporary accounts", ActionResourceNone, true},
+	AddUserToGroupAdminAction:        {"Add users to groups", ActionResourceNone, true},
+	RemoveUserFromGroupAdminAction:   {"Remove users from groups", ActionResourceNone, true},
+	GetGroupAdminAction:              {"Get group information", ActionResourceNone, true},
+	ListGroupsAdminAction:            {"List groups", ActionResourceNone, true},
+	EnableGroupAdminAction:           {"Enable groups", ActionResourceNone, true},
+	DisableGroupAdminAction:          {"Disable groups", ActionResourceNone, true},
+	CreatePolicyAdminAction:          {"Create policies", ActionResourceNone, true},
+	DeletePolicyAdminAction:          {"Delete policies", ActionResourceNone, true},
+	GetPolicyAdminAction:             {"Get policies", ActionResourceNone, true},
+	AttachPolicyAdminAction:          {"Attach policies to users and groups", ActionResourceNone, true},
+	UpdatePolicyAssociationAction:    {"Attach and detach policies of users and groups", ActionResourceNone, true},
+	ListUserPoliciesAdminAction:      {"List the policies of users", ActionResourceNone, true},
+	SetBucketQuotaAdminAction:        {"Set bucket quotas", ActionResourceNone, true},
+	GetBucketQuotaAdminAction:        {"Get bucket quotas", ActionResourceNone, true},
+	SetBucketTargetAction:            {"Set bucket replication targets", ActionResourceNone, true},
+	GetBucketTargetAction:            {"Get bucket replication targets", ActionResourceNone, true},
+	ReplicationDiff:                  {"List the unreplicated objects of a bucket", ActionResourceNone, true},
+	ImportBucketMetadataAction:       {"Import bucket metadata", ActionResourceNone, true},
+	ExportBucketMetadataAction:       {"Export bucket metadata", ActionResourceNone, true},
+	SetTierAction:                    {"Add and edit remote tiers", ActionResourceNone, true},
+	ListTierAction:                   {"List remote tiers", ActionResourceNone, true},
+	RemoveTierAction:                 {"Remove remote tiers", ActionResourceNone, true},
+	VerifyTierAction:                 {"Verify the credentials of remote tiers", ActionResourceNone, true},
+	TierStatsAction:                  {"Get remote tier statistics", ActionResourceNone, true},
+	AllTierActions:                   {"All remote tier actions", ActionResourceNone, true},
+	ExportIAMAction:                  {"Export IAM data", ActionResourceNone, true},
+	ImportIAMAction:                  {"Import IAM data", ActionResourceNone, true},
+	ListBatchJobsAction:              {"List batch jobs", ActionResourceNone, true},
+	DescribeBatchJobAction:           {"Get the definition of batch jobs", ActionResourceNone, true},
+	StartBatchJobAction:              {"Start batch jobs", ActionResourceNone, true},
+	CancelBatchJobAction:             {"Cancel batch jobs", ActionResourceNone, true},
+	BatchJobStatusAction:             {"Get the status of batch jobs", ActionResourceNone, true},
+	AllBatchJobActions:               {"All batch job actions", ActionResourceNone, true},
+	ListIDPConfigAction:              {"List identity provider configurations", ActionResourceNone, true},
+	GetIDPConfigAction:               {"Get identity provider configurations", ActionResourceNone, true},
+	SetIDPConfigAction:               {"Add and edit identity provider configurations", ActionResourceNone, true},
+	DeleteIDPConfigAction:            {"Delete identity provider configurations", ActionResourceNone, true},
+	AllIDPConfigActions:              {"All identity provider configuration actions", ActionResourceNone, true},
+	AllAdminActions:                  {"All admin actions", ActionResourceNone, true},
+
+	KMSCreateKeyAction:            {"Create KMS keys", ActionResourceNone, true},
+	KMSDeleteKeyAction:            {"Delete KMS keys", ActionResourceNone, true},
+	KMSListKeysAction:             {"List KMS keys", ActionResourceNone, true},
+	KMSImportKeyAction:            {"Import KMS keys", ActionResourceNone, true},
+	KMSDescribePolicyAction:       {"Describe KMS policies", ActionResourceNone, true},
+	KMSAssignPolicyAction:         {"Assign KMS policies to identities", ActionResourceNone, true},
+	KMSDeletePolicyAction:         {"Delete KMS policies", ActionResourceNone, true},
+	KMSSetPolicyAction:            {"Create and update KMS policies", ActionResourceNone, true},
+	KMSGetPolicyAction:            {"Get KMS policies", ActionResourceNone, true},
+	KMSListPoliciesAction:         {"List KMS policies", ActionResourceNone, true},
+	KMSDescribeIdentityAction:     {"Describe KMS identities", ActionResourceNone, true},
+	KMSDescribeSelfIdentityAction: {"Describe the own KMS identity", ActionResourceNone, true},
+	KMSDeleteIdentityAction:       {"Delete KMS identities", ActionResourceNone, true},
+	KMSListIdentitiesAction:       {"List KMS identities", ActionResourceNone, true},
+	KMSKeyStatusAction:            {"Get the status of KMS keys", ActionResourceNone, true},
+	KMSStatusAction:               {"Get the KMS status", ActionResourceNone, true},
+	KMSAPIAction:                  {"List the KMS API endpoints", ActionResourceNone, true},
+	KMSMetricsAction:              {"Get KMS metrics", ActionResourceNone, true},
+	KMSVersionAction:              {"Get the KMS version", ActionResourceNone, true},
+	KMSAuditLogAction:             {"Stream the KMS audit log", ActionResourceNone, true},
+	KMSErrorLogAction:             {"Stream the KMS error log", ActionResourceNone, true},
+	AllKMSActions:                 {"All KMS actions", ActionResourceNone, true},
+
+	AssumeRoleWithWebIdentityAction: {"Get temporary credentials for a web identity token", ActionResourceNone, false},
+	TagSessionAction:                {"Pass session tags to temporary credentials", ActionResourceNone, false},
+	AllSTSActions:                   {"All actions", ActionResourceNone, false},
+}
+
+// ActionMetadata - returns the documentation of action, false if action
+// is not a supported action.
+func ActionMetadata(a Action) (ActionMeta, bool) {
+	doc, ok := actionDocs[a]
+	if !ok {
+		return ActionMeta{}, false
+	}
+
+	meta := ActionMeta{
+		Action:           a,
+		Description:      doc.description,
+		ResourceType:     doc.resourceType,
+		IsMinIOExtension: doc.extension,
+	}
+	if !doc.extension && a != AllSTSActions {
+		switch a.namespace() {
+		case "s3":
+			meta.AWSDocURL = awsS3ActionsURL
+		case "sts":
+			meta.AWSDocURL = awsSTSActionsURL
+		}
+	}
+	return meta, true
+}
+
+// AllActionMetadata - returns the documentation of all supported actions
+// sorted by action.
+func AllActionMetadata() []ActionMeta {
+	metas := make([]ActionMeta, 0, len(actionDocs))
+	for a := range actionDocs {
+		meta, _ := ActionMetadata(a)
+		metas = append(metas, meta)
+	}
+	sort.Slice(metas, func(i, j int) bool {
+		return metas[i].Action < metas[j].Action
+	})
+	return metas
+}
+
+// ExportActionReferenceJSON - writes the documentation of all supported
+// actions to w as an indented JSON object of the form
+// {"version": 1, "actions": [...]}. The actions are sorted, hence the
+// output only changes along with the supported actions.
+func ExportActionReferenceJSON(w io.Writer) error {
+	reference := struct {
+		Version int          `json:"version"`
+		Actions []ActionMeta `json:"actions"`
+	}{
+		Version: 1,
+		Actions: AllActionMetadata(),
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(reference)
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestActionMetadataExhaustive fails if a supported action has no
+// documentation, add new actions to actionDocs along with the supported
+// actions.
+func TestActionMetadataExhaustive(t *testing.T) {
+	supported := map[Action]struct{}{}
+	for a := range supportedActions {
+		supported[a] = struct{}{}
+	}
+	for a := range supportedAdminActions {
+		supported[Action(a)] = struct{}{}
+	}
+	for a := range supportedKMSActions {
+		supported[Action(a)] = struct{}{}
+	}
+	for a := range supportedSTSActions {
+		supported[Action(a)] = struct{}{}
+	}
+
+	for a := range supported {
+		meta, ok := ActionMetadata(a)
+		if !ok {
+			t.Fatalf("%v: missing action metadata", a)
+		}
+		if meta.Action != a || meta.Description == "" {
+			t.Fatalf("%v: incomplete action metadata: %+v", a, meta)
+		}
+		if meta.IsMinIOExtension && meta.AWSDocURL != "" {
+			t.Fatalf("%v: unexpected AWS documentation of a MinIO extension", a)
+		}
+
+		var expected ActionResourceType
+		switch {
+		case a.ignoresResources(), a.namespace() == "kms", a == ListAllMyBucketsAction, a == AllSTSActions:
+			expected = ActionResourceNone
+		case a.IsObjectAction():
+			expected = ActionResourceObject
+		default:
+			expected = ActionResourceBucket
+		}
+		if meta.ResourceType != expected {
+			t.Fatalf("%v: expected: %v, got: %v", a, expected, meta.ResourceType)
+		}
+	}
+	for a := range actionDocs {
+		if _, ok := supported[a]; !ok {
+			t.Fatalf("%v: metadata of an unsupported action", a)
+		}
+	}
+}
+
+func TestActionMetadata(t *testing.T) {
+	testCases := []struct {
+		action   Action
+		expected ActionMeta
+		found    bool
+	}{
+		{GetObjectAction, ActionMeta{GetObjectAction, "Read an object and its metadata", ActionResourceObject, false, awsS3ActionsURL}, true},
+		{ListBucketAction, ActionMeta{ListBucketAction, "List the objects of a bucket", ActionResourceBucket, false, awsS3ActionsURL}, true},
+		{PutObjectFanOutAction, ActionMeta{PutObjectFanOutAction, "Upload an object to multiple keys at once", ActionResourceObject, true, ""}, true},
+		{ServerInfoAdminAction, ActionMeta{ServerInfoAdminAction, "Get server information", ActionResourceNone, true, ""}, true},
+		{TagSessionAction, ActionMeta{TagSessionAction, "Pass session tags to temporary credentials", ActionResourceNone, false, awsSTSActionsURL}, true},
+		// Registered actions are MinIO extensions.
+		{fooBarAction, ActionMeta{fooBarAction, "Bar an object", ActionResourceObject, true, ""}, true},
+		{fooListAction, ActionMeta{fooListAction, "List foo", ActionResourceBucket, true, ""}, true},
+		{adminFooAction, ActionMeta{adminFooAction, "Foo bar", ActionResourceNone, true, ""}, true},
+		{"s3:Get*", ActionMeta{}, false},
+		{"s3:Unknown", ActionMeta{}, false},
+	}
+
+	for i, testCase := range testCases {
+		meta, found := ActionMetadata(testCase.action)
+		if found != testCase.found || meta != testCase.expected {
+			t.Fatalf("case %v: expected: %+v, %v, got: %+v, %v", i+1, testCase.expected, testCase.found, meta, found)
+		}
+	}
+}
+
+func TestExportActionReferenceJSON(t *testing.T) {
+	var b1, b2 bytes.Buffer
+	if err := ExportActionReferenceJSON(&b1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ExportActionReferenceJSON(&b2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(b1.Bytes(), b2.Bytes()) {
+		t.Fatalf("expected a stable reference")
+	}
+
+	var reference struct {
+		Version int          `json:"version"`
+		Actions []ActionMeta `json:"actions"`
+	}
+	if err := json.Unmarshal(b1.Bytes(), &reference); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reference.Version != 1 || !reflect.DeepEqual(reference.Actions, AllActionMetadata()) {
+		t.Fatalf("unexpected reference: %s", b1.String())
+	}
+	for i := 1; i < len(reference.Actions); i++ {
+		if reference.Actions[i-1].Action >= reference.Actions[i].Action {
+			t.Fatalf("expected sorted actions, got: %v, %v", reference.Actions[i-1].Action, reference.Actions[i].Action)
+		}
+	}
+}
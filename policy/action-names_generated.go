@@ -0,0 +1,193 @@
+// Code generated by "go generate"; DO NOT EDIT.
+
+package policy
+
+// BuiltinActionNames - names of the built-in actions, including the
+// wildcard families, by service, sorted. The "*" action is of the "*"
+// service. Actions registered by RegisterAction are not included.
+var BuiltinActionNames = map[string][]Action{
+	"s3": {
+		"s3:*",
+		"s3:AbortMultipartUpload",
+		"s3:BypassGovernanceRetention",
+		"s3:CreateBucket",
+		"s3:DeleteBucket",
+		"s3:DeleteBucketCors",
+		"s3:DeleteBucketPolicy",
+		"s3:DeleteObject",
+		"s3:DeleteObjectTagging",
+		"s3:DeleteObjectVersion",
+		"s3:DeleteObjectVersionTagging",
+		"s3:ForceDeleteBucket",
+		"s3:GetBucketAcl",
+		"s3:GetBucketCors",
+		"s3:GetBucketLocation",
+		"s3:GetBucketNotification",
+		"s3:GetBucketObjectLockConfiguration",
+		"s3:GetBucketPolicy",
+		"s3:GetBucketPolicyStatus",
+		"s3:GetBucketTagging",
+		"s3:GetBucketVersioning",
+		"s3:GetEncryptionConfiguration",
+		"s3:GetLifecycleConfiguration",
+		"s3:GetObject",
+		"s3:GetObjectAcl",
+		"s3:GetObjectAttributes",
+		"s3:GetObjectLegalHold",
+		"s3:GetObjectRetention",
+		"s3:GetObjectTagging",
+		"s3:GetObjectVersion",
+		"s3:GetObjectVersionAcl",
+		"s3:GetObjectVersionAttributes",
+		"s3:GetObjectVersionForReplication",
+		"s3:GetObjectVersionTagging",
+		"s3:GetReplicationConfiguration",
+		"s3:HeadBucket",
+		"s3:ListAllMyBuckets",
+		"s3:ListBucket",
+		"s3:ListBucketMultipartUploads",
+		"s3:ListBucketVersions",
+		"s3:ListMultipartUploadParts",
+		"s3:ListenBucketNotification",
+		"s3:ListenNotification",
+		"s3:PutBucketAcl",
+		"s3:PutBucketCors",
+		"s3:PutBucketNotification",
+		"s3:PutBucketObjectLockConfiguration",
+		"s3:PutBucketPolicy",
+		"s3:PutBucketTagging",
+		"s3:PutBucketVersioning",
+		"s3:PutEncryptionConfiguration",
+		"s3:PutLifecycleConfiguration",
+		"s3:PutObject",
+		"s3:PutObjectAcl",
+		"s3:PutObjectFanOut",
+		"s3:PutObjectLegalHold",
+		"s3:PutObjectRetention",
+		"s3:PutObjectTagging",
+		"s3:PutObjectVersionAcl",
+		"s3:PutObjectVersionTagging",
+		"s3:PutReplicationConfiguration",
+		"s3:ReplicateDelete",
+		"s3:ReplicateObject",
+		"s3:ReplicateTags",
+		"s3:ResetBucketReplicationState",
+		"s3:RestoreObject",
+	},
+	"admin": {
+		"admin:*",
+		"admin:*BatchJob*",
+		"admin:*IDPConfig",
+		"admin:*Tier*",
+		"admin:AddUserToGroup",
+		"admin:AttachUserOrGroupPolicy",
+		"admin:BandwidthMonitor",
+		"admin:BatchJobStatus",
+		"admin:CancelBatchJob",
+		"admin:ConfigUpdate",
+		"admin:ConsoleLog",
+		"admin:CreatePolicy",
+		"admin:CreateServiceAccount",
+		"admin:CreateUser",
+		"admin:DataUsageInfo",
+		"admin:Decommission",
+		"admin:DecommissionStatus",
+		"admin:DeleteIDPConfig",
+		"admin:DeletePolicy",
+		"admin:DeleteUser",
+		"admin:DescribeBatchJob",
+		"admin:DisableGroup",
+		"admin:DisableUser",
+		"admin:EnableGroup",
+		"admin:EnableUser",
+		"admin:ExportBucketMetadata",
+		"admin:ExportIAM",
+		"admin:ForceUnlock",
+		"admin:GetBucketQuota",
+		"admin:GetBucketTarget",
+		"admin:GetGroup",
+		"admin:GetIDPConfig",
+		"admin:GetPolicy",
+		"admin:GetUser",
+		"admin:Heal",
+		"admin:ImportBucketMetadata",
+		"admin:ImportIAM",
+		"admin:InspectData",
+		"admin:KMSCreateKey",
+		"admin:KMSExportKey",
+		"admin:KMSImportKey",
+		"admin:KMSKeyStatus",
+		"admin:LicenseInfo",
+		"admin:ListBatchJobs",
+		"admin:ListGroups",
+		"admin:ListIDPConfig",
+		"admin:ListServiceAccounts",
+		"admin:ListTemporaryAccounts",
+		"admin:ListTier",
+		"admin:ListUserPolicies",
+		"admin:ListUsers",
+		"admin:OBDInfo",
+		"admin:Profiling",
+		"admin:Prometheus",
+		"admin:Rebalance",
+		"admin:RebalanceStatus",
+		"admin:RemoveServiceAccount",
+		"admin:RemoveTier",
+		"admin:RemoveUserFromGroup",
+		"admin:ReplicationDiff",
+		"admin:ServerInfo",
+		"admin:ServerTrace",
+		"admin:ServerUpdate",
+		"admin:ServiceFreeze",
+		"admin:ServiceRestart",
+		"admin:ServiceStop",
+		"admin:SetBucketQuota",
+		"admin:SetBucketTarget",
+		"admin:SetIDPConfig",
+		"admin:SetTier",
+		"admin:SiteReplicationAdd",
+		"admin:SiteReplicationDisable",
+		"admin:SiteReplicationInfo",
+		"admin:SiteReplicationOperation",
+		"admin:SiteReplicationRemove",
+		"admin:SiteReplicationResync",
+		"admin:StartBatchJob",
+		"admin:StorageInfo",
+		"admin:TierStats",
+		"admin:TopLocksInfo",
+		"admin:UpdatePolicyAssociation",
+		"admin:UpdateServiceAccount",
+		"admin:VerifyTier",
+	},
+	"kms": {
+		"kms:*",
+		"kms:API",
+		"kms:AssignPolicy",
+		"kms:AuditLog",
+		"kms:CreateKey",
+		"kms:DeleteIdentity",
+		"kms:DeleteKey",
+		"kms:DeletePolicy",
+		"kms:DescribeIdentity",
+		"kms:DescribePolicy",
+		"kms:DescribeSelfIdentity",
+		"kms:ErrorLog",
+		"kms:GetPolicy",
+		"kms:ImportKey",
+		"kms:KeyStatus",
+		"kms:ListIdentities",
+		"kms:ListKeys",
+		"kms:ListPolicies",
+		"kms:Metrics",
+		"kms:SetPolicy",
+		"kms:Status",
+		"kms:Version",
+	},
+	"sts": {
+		"sts:AssumeRoleWithWebIdentity",
+		"sts:TagSession",
+	},
+	"*": {
+		"*",
+	},
+}
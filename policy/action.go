@@ -18,6 +18,8 @@
 package policy
 
 import (
+	"strings"
+
 	"github.com/minio/pkg/v3/policy/condition"
 	"github.com/minio/pkg/v3/wildcard"
 )
@@ -206,6 +208,28 @@ const (
 	// PutObjectFanOutAction - PutObject like API action but allows PostUpload() fan-out.
 	PutObjectFanOutAction = "s3:PutObjectFanOut"
 
+	// GetBucketACLAction - GetBucketAcl Rest API action. ACLs are ignored by MinIO,
+	// see SetACLActionsAllowed.
+	GetBucketACLAction = "s3:GetBucketAcl"
+
+	// PutBucketACLAction - PutBucketAcl Rest API action. ACLs are ignored by MinIO,
+	// see SetACLActionsAllowed.
+	PutBucketACLAction = "s3:PutBucketAcl"
+
+	// GetObjectACLAction - GetObjectAcl Rest API action. ACLs are ignored by MinIO,
+	// see SetACLActionsAllowed.
+	GetObjectACLAction = "s3:GetObjectAcl"
+
+	// PutObjectACLAction - PutObjectAcl Rest API action. ACLs are ignored by MinIO,
+	// see SetACLActionsAllowed.
+	PutObjectACLAction = "s3:PutObjectAcl"
+
+	// GetObjectVersionACLAction - GetObjectAcl Rest API action with a version ID.
+	GetObjectVersionACLAction = "s3:GetObjectVersionAcl"
+
+	// PutObjectVersionACLAction - PutObjectAcl Rest API action with a version ID.
+	PutObjectVersionACLAction = "s3:PutObjectVersionAcl"
+
 	// AllActions - all API actions
 	AllActions = "s3:*"
 )
@@ -271,6 +295,12 @@ var supportedActions = map[Action]struct{}{
 	RestoreObjectAction:                    {},
 	ResetBucketReplicationStateAction:      {},
 	PutObjectFanOutAction:                  {},
+	GetBucketACLAction:                     {},
+	PutBucketACLAction:                     {},
+	GetObjectACLAction:                     {},
+	PutObjectACLAction:                     {},
+	GetObjectVersionACLAction:              {},
+	PutObjectVersionACLAction:              {},
 	AllActions:                             {},
 }
 
@@ -304,10 +334,18 @@ var supportedObjectActions = map[Action]struct{}{
 	PutObjectFanOutAction:                {},
 	GetObjectAttributesAction:            {},
 	GetObjectVersionAttributesAction:     {},
+	GetObjectACLAction:                   {},
+	PutObjectACLAction:                   {},
+	GetObjectVersionACLAction:            {},
+	PutObjectVersionACLAction:            {},
 }
 
-// IsObjectAction - returns whether action is object type or not.
+// IsObjectAction - returns whether action is object type or not, i.e.
+// whether it is or matches one of supportedObjectActions.
 func (action Action) IsObjectAction() bool {
+	if _, ok := supportedObjectActions[action]; ok {
+		return true
+	}
 	for supAction := range supportedObjectActions {
 		if action.Match(supAction) {
 			return true
@@ -316,11 +354,99 @@ func (action Action) IsObjectAction() bool {
 	return false
 }
 
-// Match - matches action name with action patter.
+// isObjectOnlyAction - returns whether action is an object action without
+// wildcards, which never applies to a bucket resource.
+func (action Action) isObjectOnlyAction() bool {
+	_, ok := supportedObjectActions[action]
+	return ok && action != AllActions
+}
+
+// List of the supported account level actions.
+var supportedAccountLevelActions = map[Action]struct{}{
+	ListAllMyBucketsAction: {},
+}
+
+// IsAccountLevel - returns whether action applies to the account rather
+// than to a bucket or an object, e.g. ListAllMyBuckets. The bucket and
+// object names of requests of account level actions are not matched,
+// statements apply to them if any of their resources matches the account,
+// i.e. has only '*' in its bucket and object segments, such as
+// "arn:aws:s3:::*", "arn:aws:s3:::*/" and "arn:aws:s3:::*/*". Other
+// resources, including bucket patterns such as "arn:aws:s3:::?" or
+// "arn:aws:s3:::my*", never match. Resources do not apply to admin and STS
+// actions at all.
+func (action Action) IsAccountLevel() bool {
+	_, ok := supportedAccountLevelActions[action]
+	return ok
+}
+
+// namespace - returns the service prefix of the action, e.g. "s3" for
+// "s3:GetObject", empty for "*" which applies to all services.
+func (action Action) namespace() string {
+	service, _, ok := strings.Cut(string(action), ":")
+	if !ok {
+		return ""
+	}
+	return service
+}
+
+// ignoresResources - returns whether statement resources do not apply to
+// the action, as for admin and STS actions.
+func (action Action) ignoresResources() bool {
+	switch action.namespace() {
+	case "admin", "sts":
+		return true
+	}
+	return false
+}
+
+// Match - checks whether the action a, e.g. "s3:GetObject", matches the
+// receiver as a pattern, e.g. "s3:Get*". Patterns are always the receiver,
+// as policy actions match request actions.
 func (action Action) Match(a Action) bool {
 	return wildcard.Match(string(action), string(a))
 }
 
+// isValid - checks if action is valid as per mode.
+func (action Action) isValid(mode ActionValidation) bool {
+	switch mode {
+	case ActionValidationStrict:
+		if _, ok := supportedActions[action]; ok {
+			return true
+		}
+		prefix, ok := strings.CutSuffix(string(action), "*")
+		if !ok || strings.ContainsAny(prefix, "*?") {
+			return false
+		}
+		return action.IsValid()
+	case ActionValidationPermissive:
+		if action.IsValid() {
+			return true
+		}
+		service, name, ok := strings.Cut(string(action), ":")
+		return ok && isActionToken(service) && isActionToken(name)
+	default:
+		return action.IsValid()
+	}
+}
+
+// isActionToken - checks if s is a valid service or action name, i.e. is
+// not empty and consists of letters, digits, '-', '_', '*' and '?' only.
+func isActionToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '*', r == '?':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // IsValid - checks if action is valid or not.
 func (action Action) IsValid() bool {
 	for supAction := range supportedActions {
@@ -369,7 +495,11 @@ func createActionConditionKeyMap() ActionConditionKeyMap {
 
 		AbortMultipartUploadAction: condition.NewKeySet(commonKeys...),
 
-		CreateBucketAction: condition.NewKeySet(commonKeys...),
+		CreateBucketAction: condition.NewKeySet(
+			append([]condition.Key{
+				condition.S3XAmzACL.ToKey(),
+				condition.S3XAmzObjectOwnership.ToKey(),
+			}, commonKeys...)...),
 
 		DeleteObjectAction: condition.NewKeySet(
 			append([]condition.Key{
@@ -429,6 +559,8 @@ func createActionConditionKeyMap() ActionConditionKeyMap {
 		PutObjectAction: condition.NewKeySet(
 			append([]condition.Key{
 				condition.S3XAmzCopySource.ToKey(),
+				condition.S3XAmzCopySourceVersionID.ToKey(),
+				condition.S3XAmzACL.ToKey(),
 				condition.S3XAmzServerSideEncryption.ToKey(),
 				condition.S3XAmzServerSideEncryptionCustomerAlgorithm.ToKey(),
 				condition.S3XAmzServerSideEncryptionAwsKmsKeyID.ToKey(),
@@ -562,5 +694,31 @@ func createActionConditionKeyMap() ActionConditionKeyMap {
 		RestoreObjectAction:               condition.NewKeySet(commonKeys...),
 		ResetBucketReplicationStateAction: condition.NewKeySet(commonKeys...),
 		PutObjectFanOutAction:             condition.NewKeySet(commonKeys...),
+
+		GetBucketACLAction: condition.NewKeySet(commonKeys...),
+		PutBucketACLAction: condition.NewKeySet(
+			append([]condition.Key{
+				condition.S3XAmzACL.ToKey(),
+			}, commonKeys...)...),
+		GetObjectACLAction: condition.NewKeySet(
+			append([]condition.Key{
+				condition.ExistingObjectTag.ToKey(),
+			}, commonKeys...)...),
+		PutObjectACLAction: condition.NewKeySet(
+			append([]condition.Key{
+				condition.S3XAmzACL.ToKey(),
+				condition.ExistingObjectTag.ToKey(),
+			}, commonKeys...)...),
+		GetObjectVersionACLAction: condition.NewKeySet(
+			append([]condition.Key{
+				condition.S3VersionID.ToKey(),
+				condition.ExistingObjectTag.ToKey(),
+			}, commonKeys...)...),
+		PutObjectVersionACLAction: condition.NewKeySet(
+			append([]condition.Key{
+				condition.S3XAmzACL.ToKey(),
+				condition.S3VersionID.ToKey(),
+				condition.ExistingObjectTag.ToKey(),
+			}, commonKeys...)...),
 	}
 }
@@ -18,6 +18,9 @@
 package policy
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -32,6 +35,9 @@ func TestActionIsObjectAction(t *testing.T) {
 		{ListMultipartUploadPartsAction, true},
 		{PutObjectAction, true},
 		{CreateBucketAction, false},
+		{ListBucketMultipartUploadsAction, false},
+		{"s3:ListMultipart*", true},
+		{AllActions, true},
 	}
 
 	for i, testCase := range testCases {
@@ -61,3 +67,47 @@ func TestActionIsValid(t *testing.T) {
 		}
 	}
 }
+
+func TestObjectActionResourceValidation(t *testing.T) {
+	testCases := []struct {
+		action    Action
+		resource  string
+		expectErr bool
+	}{
+		{ListMultipartUploadPartsAction, "mybucket", true},
+		{ListMultipartUploadPartsAction, "mybucket/*", false},
+		{AbortMultipartUploadAction, "mybucket", true},
+		{AbortMultipartUploadAction, "mybucket/*", false},
+		{ListBucketMultipartUploadsAction, "mybucket", false},
+		{GetObjectAction, "mybucket", true},
+		{GetObjectAction, "mybucket/myobject", false},
+	}
+
+	for i, testCase := range testCases {
+		data := fmt.Sprintf(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": ["%v"], "Resource": ["arn:aws:s3:::%v"]}]}`, testCase.action, testCase.resource)
+		_, bpErr := ParseBucketPolicyConfig(strings.NewReader(data), "mybucket")
+
+		data = fmt.Sprintf(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["%v"], "Resource": ["arn:aws:s3:::%v"]}]}`, testCase.action, testCase.resource)
+		// IAM policies of previous releases are accepted by default.
+		if _, err := ParseConfig(strings.NewReader(data)); err != nil {
+			t.Fatalf("case %v: policy: unexpected error: %v", i+1, err)
+		}
+		_, err := ParseConfigWithOptions(strings.NewReader(data), ValidationOptions{RejectUnsupportedResources: true})
+
+		if expectErr := (err != nil); expectErr != testCase.expectErr {
+			t.Fatalf("case %v: policy: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if expectErr := (bpErr != nil); expectErr != testCase.expectErr {
+			t.Fatalf("case %v: bucket policy: expected: %v, got: %v", i+1, testCase.expectErr, bpErr)
+		}
+		if testCase.expectErr {
+			var resourceErr, bpResourceErr ErrUnsupportedResource
+			if !errors.As(err, &resourceErr) || !errors.As(bpErr, &bpResourceErr) {
+				t.Fatalf("case %v: expected ErrUnsupportedResource, got: %v, %v", i+1, err, bpErr)
+			}
+			if err.Error() != bpErr.Error() {
+				t.Fatalf("case %v: expected identical errors, got: %v, %v", i+1, err, bpErr)
+			}
+		}
+	}
+}
@@ -20,20 +20,27 @@ package policy
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
 
 	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/pkg/v3/wildcard"
 )
 
-// ActionSet - set of actions.
+// ActionSet - set of actions. A nil ActionSet is an empty set, all
+// methods but Add accept it.
 type ActionSet map[Action]struct{}
 
-// Clone clones ActionSet structure
+// Clone clones ActionSet structure, a nil set is cloned to a nil set.
 func (actionSet ActionSet) Clone() ActionSet {
+	if actionSet == nil {
+		return nil
+	}
 	return NewActionSet(actionSet.ToSlice()...)
 }
 
-// Add - add action to the set.
+// Add - add action to the set. As for a map, adding to a nil set panics.
 func (actionSet ActionSet) Add(action Action) {
 	actionSet[action] = struct{}{}
 }
@@ -49,6 +56,16 @@ func (actionSet ActionSet) IsEmpty() bool {
 	return len(actionSet) == 0
 }
 
+// hasWildcard - returns whether an action of the set is a pattern.
+func (actionSet ActionSet) hasWildcard() bool {
+	for action := range actionSet {
+		if strings.ContainsAny(string(action), "*?") {
+			return true
+		}
+	}
+	return false
+}
+
 // Match - matches object name with anyone of action pattern in action set.
 func (actionSet ActionSet) Match(action Action) bool {
 	for r := range actionSet {
@@ -69,6 +86,56 @@ func (actionSet ActionSet) Match(action Action) bool {
 	return false
 }
 
+// Expand - returns the actions of universe which the action set matches,
+// as evaluation does, sorted. universe defaults to all supported actions,
+// including the registered ones but not the wildcard families such as
+// "s3:*". As for Match, GetObjectVersion expands to GetObject as well.
+//
+// Expand is the supported way to enumerate the actions of patterns such as
+// "s3:Get*", e.g. to show the actions granted by a statement: an action
+// matches a pattern if and only if it is in the expansion of the pattern.
+func (actionSet ActionSet) Expand(universe ...Action) []Action {
+	if len(universe) == 0 {
+		universe = concreteActions()
+	}
+	var actions []Action
+	for _, action := range universe {
+		if actionSet.Match(action) {
+			actions = append(actions, action)
+		}
+	}
+	sort.Slice(actions, func(i, j int) bool {
+		return actions[i] < actions[j]
+	})
+	return slices.Compact(actions)
+}
+
+// concreteActions - returns the supported S3, admin, KMS and STS actions
+// without wildcards.
+func concreteActions() []Action {
+	freezeActions()
+
+	var actions []Action
+	add := func(action Action) {
+		if !strings.ContainsAny(string(action), "*?") {
+			actions = append(actions, action)
+		}
+	}
+	for action := range supportedActions {
+		add(action)
+	}
+	for action := range supportedAdminActions {
+		add(Action(action))
+	}
+	for action := range supportedKMSActions {
+		add(Action(action))
+	}
+	for action := range supportedSTSActions {
+		add(Action(action))
+	}
+	return actions
+}
+
 // Equals - checks whether given action set is equal to current action set or not.
 func (actionSet ActionSet) Equals(sactionSet ActionSet) bool {
 	// If length of set is not equal to length of given set, the
@@ -87,6 +154,34 @@ func (actionSet ActionSet) Equals(sactionSet ActionSet) bool {
 	return true
 }
 
+// SubsetOf - returns whether every action matched by the set, as per
+// Match, is matched by other, e.g. "s3:GetObject" and "s3:Get*" are subsets
+// of "s3:*". Each action pattern must be a subset of a single pattern of
+// other, see wildcard.Subset, hence patterns covered by several patterns
+// of other together only are conservatively not subsets. As for Match,
+// GetObjectVersion implies GetObject.
+func (actionSet ActionSet) SubsetOf(other ActionSet) bool {
+	for action := range actionSet {
+		if action == GetObjectVersionAction && !other.Match(GetObjectAction) {
+			return false
+		}
+		if action == GetObjectAction && other.Contains(GetObjectVersionAction) {
+			continue
+		}
+		covered := false
+		for o := range other {
+			if wildcard.Subset(string(action), string(o)) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
 // Intersection - returns actions available in both ActionSet.
 func (actionSet ActionSet) Intersection(sset ActionSet) ActionSet {
 	nset := NewActionSet()
@@ -99,12 +194,13 @@ func (actionSet ActionSet) Intersection(sset ActionSet) ActionSet {
 	return nset
 }
 
-// MarshalJSON - encodes ActionSet to JSON data.
+// MarshalJSON - encodes ActionSet to JSON data. Empty sets are invalid,
+// statements omit empty action sets instead.
 func (actionSet ActionSet) MarshalJSON() ([]byte, error) {
 	if len(actionSet) == 0 {
-		return nil, Errorf("empty actions not allowed")
+		return nil, Errorf("%w", ErrEmptyActions)
 	}
-	return json.Marshal(actionSet.ToSlice())
+	return json.Marshal(actionSet.toSortedSlice())
 }
 
 func (actionSet ActionSet) String() string {
@@ -130,6 +226,17 @@ func (actionSet ActionSet) ToSlice() []Action {
 	return actions
 }
 
+// toSortedSlice - returns slice of actions from the action set sorted in
+// lexical order, used where iteration order must be deterministic.
+func (actionSet ActionSet) toSortedSlice() []Action {
+	actions := actionSet.ToSlice()
+	sort.Slice(actions, func(i, j int) bool {
+		return actions[i] < actions[j]
+	})
+
+	return actions
+}
+
 // ToAdminSlice - returns slice of admin actions from the action set.
 func (actionSet ActionSet) ToAdminSlice() []AdminAction {
 	if len(actionSet) == 0 {
@@ -163,13 +270,15 @@ func (actionSet ActionSet) ToKMSSlice() (actions []KMSAction) {
 
 // UnmarshalJSON - decodes JSON data to ActionSet.
 func (actionSet *ActionSet) UnmarshalJSON(data []byte) error {
+	freezeActions()
+
 	var sset set.StringSet
 	if err := json.Unmarshal(data, &sset); err != nil {
 		return err
 	}
 
 	if sset.IsEmpty() {
-		return Errorf("empty actions not allowed")
+		return Errorf("%w", ErrEmptyActions)
 	}
 
 	*actionSet = make(ActionSet)
@@ -184,7 +293,7 @@ func (actionSet *ActionSet) UnmarshalJSON(data []byte) error {
 func (actionSet ActionSet) ValidateAdmin() error {
 	for _, action := range actionSet.ToAdminSlice() {
 		if !action.IsValid() {
-			return Errorf("unsupported admin action '%v'", action)
+			return Errorf("%w", ErrUnsupportedAction{Action: Action(action), Kind: "admin"})
 		}
 	}
 	return nil
@@ -194,7 +303,7 @@ func (actionSet ActionSet) ValidateAdmin() error {
 func (actionSet ActionSet) ValidateSTS() error {
 	for _, action := range actionSet.ToSTSSlice() {
 		if !action.IsValid() {
-			return Errorf("unsupported STS action '%v'", action)
+			return Errorf("%w", ErrUnsupportedAction{Action: Action(action), Kind: "STS"})
 		}
 	}
 	return nil
@@ -204,7 +313,7 @@ func (actionSet ActionSet) ValidateSTS() error {
 func (actionSet ActionSet) ValidateKMS() error {
 	for _, action := range actionSet.ToKMSSlice() {
 		if !action.IsValid() {
-			return Errorf("unsupported KMS action '%v'", action)
+			return Errorf("%w", ErrUnsupportedAction{Action: Action(action), Kind: "KMS"})
 		}
 	}
 	return nil
@@ -212,9 +321,15 @@ func (actionSet ActionSet) ValidateKMS() error {
 
 // Validate checks if all actions are valid
 func (actionSet ActionSet) Validate() error {
-	for _, action := range actionSet.ToSlice() {
-		if !action.IsValid() {
-			return Errorf("unsupported action '%v'", action)
+	return actionSet.validate("", ActionValidationDefault)
+}
+
+// validate checks if all actions of the statement with given sid are
+// valid as per mode.
+func (actionSet ActionSet) validate(sid ID, mode ActionValidation) error {
+	for _, action := range actionSet.toSortedSlice() {
+		if !action.isValid(mode) {
+			return Errorf("%w", ErrUnsupportedAction{Action: action, SID: sid})
 		}
 	}
 	return nil
@@ -20,6 +20,8 @@ package policy
 import (
 	"encoding/json"
 	"reflect"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -63,6 +65,67 @@ func TestActionSetMatches(t *testing.T) {
 	}
 }
 
+func TestActionSetExpand(t *testing.T) {
+	testCases := []struct {
+		set      ActionSet
+		universe []Action
+		expected []Action
+	}{
+		{NewActionSet("s3:Get*"), []Action{GetObjectAction, PutObjectAction, GetBucketPolicyAction}, []Action{GetBucketPolicyAction, GetObjectAction}},
+		{NewActionSet(GetObjectVersionAction), []Action{GetObjectAction, GetObjectVersionAction}, []Action{GetObjectAction, GetObjectVersionAction}},
+		{NewActionSet(GetObjectVersionAction + "*"), []Action{GetObjectAction, GetObjectVersionAction}, []Action{GetObjectVersionAction}},
+		{NewActionSet("s3:GetObject?"), []Action{GetObjectAction, GetObjectVersionAction}, nil},
+		{NewActionSet("admin:*Tier*"), []Action{Action(ListTierAction), Action(SetTierAction), Action(ServerInfoAdminAction)}, []Action{Action(ListTierAction), Action(SetTierAction)}},
+		{NewActionSet(PutObjectAction), []Action{PutObjectAction, PutObjectAction}, []Action{PutObjectAction}},
+		{NewActionSet(), []Action{PutObjectAction}, nil},
+	}
+
+	for i, testCase := range testCases {
+		if result := testCase.set.Expand(testCase.universe...); !reflect.DeepEqual(result, testCase.expected) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+	}
+
+	// The default universe has no wildcard families but registered actions.
+	all := NewActionSet("*").Expand()
+	for _, action := range all {
+		if strings.ContainsAny(string(action), "*?") {
+			t.Fatalf("expected: concrete actions, got: %v", action)
+		}
+	}
+	if !slices.Contains(all, fooBarAction) || !slices.Contains(all, adminFooAction) || !slices.Contains(all, Action(KMSCreateKeyAction)) {
+		t.Fatalf("expected: all supported actions, got: %v", all)
+	}
+}
+
+// TestActionSetExpandMatch checks that a pattern matches an action, as
+// evaluated by statements, if and only if the action is in the expansion
+// of the pattern.
+func TestActionSetExpandMatch(t *testing.T) {
+	patterns := []Action{
+		"*", "s3:*", "s3:Get*", "s3:*Object", "s3:*Object*", "s3:GetObject?", "s3:?etObject",
+		"s3:GetObjectVersion", "s3:GetObjectVersion*", "s3:List*Bucket*", "s3:NoSuch*",
+		"admin:*", "admin:*Tier*", "admin:*BatchJob*", "admin:Server?nfo", "kms:*", "kms:Key*",
+		"sts:*", "foo:*", "foo:Ba?", "*Object*", "s3:GetObject", "admin:FooBar",
+	}
+	universe := concreteActions()
+
+	for _, pattern := range patterns {
+		expanded := NewActionSet(NewActionSet(pattern).Expand()...)
+		statement := NewStatement("", Allow, NewActionSet(pattern), NewResourceSet(NewResource("*")), nil)
+		notStatement := NewStatementWithNotAction("", Deny, NewActionSet(pattern), NewResourceSet(NewResource("*")), nil)
+		for _, action := range universe {
+			matched := statement.matchAction(action)
+			if matched != expanded.Contains(action) {
+				t.Fatalf("%v: %v: expected: %v, got: %v", pattern, action, matched, expanded.Contains(action))
+			}
+			if notStatement.matchAction(action) == matched {
+				t.Fatalf("%v: %v: expected NotAction to apply: %v", pattern, action, !matched)
+			}
+		}
+	}
+}
+
 func TestActionSetIntersection(t *testing.T) {
 	testCases := []struct {
 		set            ActionSet
@@ -83,6 +146,31 @@ func TestActionSetIntersection(t *testing.T) {
 	}
 }
 
+func TestActionSetSubsetOf(t *testing.T) {
+	testCases := []struct {
+		set    ActionSet
+		other  ActionSet
+		subset bool
+	}{
+		{NewActionSet(GetObjectAction, PutObjectAction), NewActionSet("s3:*"), true},
+		{NewActionSet("s3:Get*"), NewActionSet("s3:*"), true},
+		{NewActionSet("s3:*"), NewActionSet("s3:Get*"), false},
+		{NewActionSet(GetObjectAction, PutObjectAction), NewActionSet("s3:Get*"), false},
+		{NewActionSet("s3:Get*"), NewActionSet("s3:GetObject*", "s3:GetBucket*"), false},
+		{NewActionSet(GetObjectAction), NewActionSet(GetObjectVersionAction), true},
+		{NewActionSet(GetObjectVersionAction), NewActionSet("s3:GetObjectV*"), false},
+		{NewActionSet(GetObjectVersionAction), NewActionSet("s3:GetObject*"), true},
+		{NewActionSet("admin:*"), NewActionSet("*"), true},
+		{NewActionSet(), NewActionSet(), true},
+	}
+
+	for i, testCase := range testCases {
+		if subset := testCase.set.SubsetOf(testCase.other); subset != testCase.subset {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.subset, subset)
+		}
+	}
+}
+
 func TestActionSetMarshalJSON(t *testing.T) {
 	testCases := []struct {
 		actionSet      ActionSet
@@ -18,6 +18,8 @@
 package policy
 
 import (
+	"sort"
+
 	"github.com/minio/pkg/v3/policy/condition"
 )
 
@@ -31,8 +33,14 @@ const (
 	// DecommissionAdminAction - allows decomissioning of pools
 	DecommissionAdminAction = "admin:Decommission"
 
+	// DecommissionStatusAdminAction - allows getting the decommissioning
+	// status of pools
+	DecommissionStatusAdminAction = "admin:DecommissionStatus"
+
 	// RebalanceAdminAction - allows rebalancing of pools
 	RebalanceAdminAction = "admin:Rebalance"
+	// RebalanceStatusAdminAction - allows getting the rebalancing status of pools
+	RebalanceStatusAdminAction = "admin:RebalanceStatus"
 	// Service Actions
 
 	// StorageInfoAdminAction - allow listing server info
@@ -55,6 +63,10 @@ const (
 	KMSCreateKeyAdminAction = "admin:KMSCreateKey"
 	// KMSKeyStatusAdminAction - allow getting KMS key status
 	KMSKeyStatusAdminAction = "admin:KMSKeyStatus"
+	// KMSImportKeyAdminAction - allow importing a KMS master key
+	KMSImportKeyAdminAction = "admin:KMSImportKey"
+	// KMSExportKeyAdminAction - allow exporting a KMS master key
+	KMSExportKeyAdminAction = "admin:KMSExportKey"
 	// ServerInfoAdminAction - allow listing server info
 	ServerInfoAdminAction = "admin:ServerInfo"
 	// HealthInfoAdminAction - allow obtaining cluster health information
@@ -182,6 +194,16 @@ const (
 	SetTierAction = "admin:SetTier"
 	// ListTierAction - allow listing remote tiers
 	ListTierAction = "admin:ListTier"
+	// RemoveTierAction - allow removing a remote tier
+	RemoveTierAction = "admin:RemoveTier"
+	// VerifyTierAction - allow verifying the credentials of a remote tier
+	VerifyTierAction = "admin:VerifyTier"
+	// TierStatsAction - allow getting remote tier statistics
+	TierStatsAction = "admin:TierStats"
+	// AllTierActions - allow all remote tier actions. The tier actions are
+	// not prefixed by "Tier", hence the family matches "Tier" anywhere in
+	// the action name.
+	AllTierActions = "admin:*Tier*"
 
 	// Migrate IAM admin Actions
 
@@ -204,6 +226,25 @@ const (
 	// CancelBatchJobAction allow canceling a batch job
 	CancelBatchJobAction = "admin:CancelBatchJob"
 
+	// BatchJobStatusAction allow getting the status of a batch job
+	BatchJobStatusAction = "admin:BatchJobStatus"
+
+	// AllBatchJobActions allow all batch job actions
+	AllBatchJobActions = "admin:*BatchJob*"
+
+	// Identity provider configuration admin Actions
+
+	// ListIDPConfigAction - allow listing identity provider configurations
+	ListIDPConfigAction = "admin:ListIDPConfig"
+	// GetIDPConfigAction - allow getting an identity provider configuration
+	GetIDPConfigAction = "admin:GetIDPConfig"
+	// SetIDPConfigAction - allow adding/editing an identity provider configuration
+	SetIDPConfigAction = "admin:SetIDPConfig"
+	// DeleteIDPConfigAction - allow deleting an identity provider configuration
+	DeleteIDPConfigAction = "admin:DeleteIDPConfig"
+	// AllIDPConfigActions - allow all identity provider configuration actions
+	AllIDPConfigActions = "admin:*IDPConfig"
+
 	// AllAdminActions - provides all admin permissions
 	AllAdminActions = "admin:*"
 )
@@ -220,9 +261,14 @@ var supportedAdminActions = map[AdminAction]struct{}{
 	ConsoleLogAdminAction:            {},
 	KMSCreateKeyAdminAction:          {},
 	KMSKeyStatusAdminAction:          {},
+	KMSImportKeyAdminAction:          {},
+	KMSExportKeyAdminAction:          {},
 	ServerInfoAdminAction:            {},
 	HealthInfoAdminAction:            {},
+	LicenseInfoAdminAction:           {},
 	BandwidthMonitorAction:           {},
+	InspectDataAction:                {},
+	ForceUnlockAdminAction:           {},
 	ServerUpdateAdminAction:          {},
 	ServiceRestartAdminAction:        {},
 	ServiceStopAdminAction:           {},
@@ -258,8 +304,14 @@ var supportedAdminActions = map[AdminAction]struct{}{
 	ReplicationDiff:                  {},
 	SetTierAction:                    {},
 	ListTierAction:                   {},
+	RemoveTierAction:                 {},
+	VerifyTierAction:                 {},
+	TierStatsAction:                  {},
+	AllTierActions:                   {},
 	DecommissionAdminAction:          {},
+	DecommissionStatusAdminAction:    {},
 	RebalanceAdminAction:             {},
+	RebalanceStatusAdminAction:       {},
 	SiteReplicationAddAction:         {},
 	SiteReplicationDisableAction:     {},
 	SiteReplicationInfoAction:        {},
@@ -276,10 +328,34 @@ var supportedAdminActions = map[AdminAction]struct{}{
 	DescribeBatchJobAction: {},
 	StartBatchJobAction:    {},
 	CancelBatchJobAction:   {},
+	BatchJobStatusAction:   {},
+	AllBatchJobActions:     {},
+
+	ListIDPConfigAction:   {},
+	GetIDPConfigAction:    {},
+	SetIDPConfigAction:    {},
+	DeleteIDPConfigAction: {},
+	AllIDPConfigActions:   {},
 
 	AllAdminActions: {},
 }
 
+// SupportedAdminActions - returns all supported admin actions, including
+// the wildcard families and the actions registered by RegisterAction,
+// sorted by name.
+func SupportedAdminActions() []AdminAction {
+	freezeActions()
+
+	actions := make([]AdminAction, 0, len(supportedAdminActions))
+	for action := range supportedAdminActions {
+		actions = append(actions, action)
+	}
+	sort.Slice(actions, func(i, j int) bool {
+		return actions[i] < actions[j]
+	})
+	return actions
+}
+
 // IsValid - checks if action is valid or not.
 func (action AdminAction) IsValid() bool {
 	_, ok := supportedAdminActions[action]
@@ -0,0 +1,186 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+// TestSupportedAdminActionsCanonical fails if the supported admin actions
+// differ from testdata/admin-actions.txt, the canonical names of the admin
+// actions. Add new admin actions to both.
+func TestSupportedAdminActionsCanonical(t *testing.T) {
+	f, err := os.Open("testdata/admin-actions.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	canonical := map[AdminAction]struct{}{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		canonical[AdminAction(line)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, action := range SupportedAdminActions() {
+		if action == AdminAction(adminFooAction) {
+			// Registered by the tests.
+			continue
+		}
+		if _, ok := canonical[action]; !ok {
+			t.Fatalf("%v: missing in testdata/admin-actions.txt", action)
+		}
+		delete(canonical, action)
+	}
+	for action := range canonical {
+		t.Fatalf("%v: unsupported admin action, add it to supportedAdminActions", action)
+	}
+}
+
+func TestAllAdminActionsCoverage(t *testing.T) {
+	policy := Policy{
+		Version: DefaultVersion,
+		Statements: []Statement{
+			NewStatement("", Allow, NewActionSet(AllAdminActions), NewResourceSet(), nil),
+		},
+	}
+	for _, action := range SupportedAdminActions() {
+		if !policy.IsAllowed(Args{Action: Action(action)}) {
+			t.Fatalf("%v: expected allowed by %v", action, AllAdminActions)
+		}
+	}
+}
+
+func TestAdminActionFamilies(t *testing.T) {
+	testCases := []struct {
+		family   Action
+		action   Action
+		expected bool
+	}{
+		{AllTierActions, SetTierAction, true},
+		{AllTierActions, ListTierAction, true},
+		{AllTierActions, RemoveTierAction, true},
+		{AllTierActions, VerifyTierAction, true},
+		{AllTierActions, TierStatsAction, true},
+		{AllTierActions, ListBatchJobsAction, false},
+		{AllBatchJobActions, ListBatchJobsAction, true},
+		{AllBatchJobActions, DescribeBatchJobAction, true},
+		{AllBatchJobActions, StartBatchJobAction, true},
+		{AllBatchJobActions, CancelBatchJobAction, true},
+		{AllBatchJobActions, BatchJobStatusAction, true},
+		{AllBatchJobActions, RebalanceStatusAdminAction, false},
+		{AllIDPConfigActions, ListIDPConfigAction, true},
+		{AllIDPConfigActions, GetIDPConfigAction, true},
+		{AllIDPConfigActions, SetIDPConfigAction, true},
+		{AllIDPConfigActions, DeleteIDPConfigAction, true},
+		{AllIDPConfigActions, ConfigUpdateAdminAction, false},
+	}
+
+	for i, testCase := range testCases {
+		policy := Policy{
+			Version: DefaultVersion,
+			Statements: []Statement{
+				NewStatement("", Allow, NewActionSet(testCase.family), NewResourceSet(), nil),
+			},
+		}
+		if err := policy.Validate(); err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if result := policy.IsAllowed(Args{Action: testCase.action}); result != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+	}
+}
+
+func TestAdminActionIdentityConditions(t *testing.T) {
+	p, err := ParseConfigStrict(strings.NewReader(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Action": ["admin:*"],
+            "Condition": {
+                "ForAnyValue:StringEquals": {"jwt:groups": ["admins", "operators"]},
+                "IpAddress": {"aws:SourceIp": "10.0.0.0/8"},
+                "Bool": {"aws:SecureTransport": "true"}
+            }
+        }
+    ]
+}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		groups         []string
+		sourceIP       string
+		expectedResult bool
+	}{
+		{[]string{"users", "operators"}, "10.1.2.3", true},
+		{[]string{"admins"}, "10.1.2.3", true},
+		{[]string{"users"}, "10.1.2.3", false},
+		{nil, "10.1.2.3", false},
+		{[]string{"admins"}, "192.168.1.1", false},
+	}
+	for i, testCase := range testCases {
+		result := p.IsAllowed(Args{
+			AccountName: "alice",
+			Action:      ServerInfoAdminAction,
+			ConditionValues: map[string][]string{
+				"groups":          testCase.groups,
+				"SourceIp":        {testCase.sourceIP},
+				"SecureTransport": {"true"},
+			},
+		})
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	// The identity keys are supported by all S3, admin and STS actions.
+	for _, keyName := range condition.CommonIdentityKeys {
+		key := keyName.ToKey()
+		for action := range supportedActions {
+			if !IAMActionConditionKeyMap.Lookup(action).Match(key) {
+				t.Fatalf("%v: expected %v to be supported", action, keyName)
+			}
+		}
+		for action := range supportedAdminActions {
+			if !adminActionConditionKeyMap[Action(action)].Match(key) {
+				t.Fatalf("%v: expected %v to be supported", action, keyName)
+			}
+		}
+		for action := range supportedSTSActions {
+			if !stsActionConditionKeyMap[Action(action)].Match(key) {
+				t.Fatalf("%v: expected %v to be supported", action, keyName)
+			}
+		}
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package benchutil compares the correctness and the performance of
+// strategies evaluating a request against a set of IAM policies, such as
+// evaluating the policies one by one or merging them first, on generated
+// or real workloads:
+//
+//	policies, args, expected := benchutil.GenWorkload(10, 100, 1000, 1)
+//	w := benchutil.Workload{Policies: policies, Args: args, Expected: expected}
+//	report := benchutil.CompareStrategies(w, benchutil.Strategies())
+//	if !report.OK() {
+//		...
+//	}
+//	fmt.Print(report)
+package benchutil
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/pkg/v3/policy"
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+// Defaults of Workload.
+const (
+	DefaultMaxRequests = 1_000_000
+	DefaultMaxDuration = time.Second
+)
+
+// Workload - requests evaluated against policies, along with the expected
+// decision of each request.
+type Workload struct {
+	Policies []policy.Policy
+	Args     []policy.Args
+	Expected []bool
+
+	// MaxRequests and MaxDuration - bounds of the measurement of each
+	// strategy, which evaluates the requests repeatedly, in order, until
+	// either bound is reached. Zero values use the defaults.
+	MaxRequests int
+	MaxDuration time.Duration
+}
+
+// Strategy - evaluates args against policies, returning whether the
+// request is allowed.
+type Strategy = func(policies []policy.Policy, args policy.Args) bool
+
+// Serial - evaluates args against each policy, the request is allowed if
+// no policy denies and at least one policy allows it.
+func Serial(policies []policy.Policy, args policy.Args) bool {
+	var allowed bool
+	for _, p := range policies {
+		switch p.EvaluateWithReason(args).Verdict {
+		case policy.VerdictDeny:
+			return false
+		case policy.VerdictAllow:
+			allowed = true
+		}
+	}
+	return allowed
+}
+
+// Merged - evaluates args against the policies merged by
+// policy.MergePolicies, which is the reference of GenWorkload.
+func Merged(policies []policy.Policy, args policy.Args) bool {
+	return policy.MergePolicies(policies...).IsAllowed(args)
+}
+
+// Strategies - returns the strategies of this package, by name.
+func Strategies() map[string]Strategy {
+	return map[string]Strategy{
+		"serial": Serial,
+		"merged": Merged,
+	}
+}
+
+// GenWorkload - returns numPolicies random policies on numBuckets buckets,
+// numRequests random requests and the decision of each request, as
+// returned by Merged. The workload only depends on the arguments.
+func GenWorkload(numPolicies, numBuckets, numRequests int, seed int64) (policies []policy.Policy, args []policy.Args, expected []bool) {
+	r := rand.New(rand.NewSource(seed))
+	numBuckets = max(numBuckets, 1)
+
+	actions := []policy.Action{
+		policy.GetObjectAction,
+		policy.PutObjectAction,
+		policy.DeleteObjectAction,
+		policy.ListBucketAction,
+	}
+	prefixes := []string{"", "photos/", "docs/", "home/alice/"}
+	bucket := func() string {
+		return fmt.Sprintf("bucket-%d", r.Intn(numBuckets))
+	}
+
+	policies = make([]policy.Policy, 0, numPolicies)
+	for i := 0; i < numPolicies; i++ {
+		p := policy.Policy{Version: policy.DefaultVersion}
+		for j := 1 + r.Intn(3); j > 0; j-- {
+			effect := policy.Allow
+			if r.Intn(5) == 0 {
+				effect = policy.Deny
+			}
+
+			b := bucket()
+			if r.Intn(10) == 0 {
+				b = "*"
+			}
+			prefix := prefixes[r.Intn(len(prefixes))]
+
+			// s3:prefix only applies to ListBucket.
+			if prefix != "" && r.Intn(4) == 0 {
+				f, err := condition.NewStringLikeFunc("", condition.S3Prefix.ToKey(), prefix+"*")
+				if err != nil {
+					panic(err)
+				}
+				p.Statements = append(p.Statements, policy.NewStatement("", effect,
+					policy.NewActionSet(policy.ListBucketAction),
+					policy.NewResourceSet(policy.NewResource(b)),
+					condition.NewFunctions(f)))
+				continue
+			}
+
+			actionSet := policy.NewActionSet()
+			for k := 1 + r.Intn(len(actions)); k > 0; k-- {
+				actionSet.Add(actions[r.Intn(len(actions))])
+			}
+			resourceSet := policy.NewResourceSet(
+				policy.NewResource(b),
+				policy.NewResource(b+"/"+prefix+"*"),
+			)
+			p.Statements = append(p.Statements, policy.NewStatement("", effect, actionSet, resourceSet, nil))
+		}
+		policies = append(policies, p)
+	}
+
+	merged := policy.MergePolicies(policies...)
+	args = make([]policy.Args, 0, numRequests)
+	expected = make([]bool, 0, numRequests)
+	for i := 0; i < numRequests; i++ {
+		prefix := prefixes[r.Intn(len(prefixes))]
+		a := policy.Args{
+			AccountName:     fmt.Sprintf("user-%d", r.Intn(100)),
+			Action:          actions[r.Intn(len(actions))],
+			BucketName:      bucket(),
+			ConditionValues: map[string][]string{},
+		}
+		if a.Action == policy.ListBucketAction {
+			a.ConditionValues["prefix"] = []string{prefix}
+		} else {
+			a.ObjectName = fmt.Sprintf("%sobject-%d", prefix, r.Intn(1000))
+		}
+		args = append(args, a)
+		expected = append(expected, merged.IsAllowed(a))
+	}
+	return policies, args, expected
+}
+
+// Result - result of a strategy, see CompareStrategies.
+type Result struct {
+	Name string
+
+	// Mismatches - indexes of the requests of the workload for which the
+	// strategy does not return the expected decision.
+	Mismatches []int
+
+	// Requests - number of requests evaluated by the measurement.
+	Requests    int
+	NsPerOp     int64
+	AllocsPerOp int64
+	BytesPerOp  int64
+}
+
+// OK - checks whether the strategy returned the expected decisions.
+func (r Result) OK() bool {
+	return len(r.Mismatches) == 0
+}
+
+func (r Result) String() string {
+	s := fmt.Sprintf("%s\t%d requests\t%d ns/op\t%d B/op\t%d allocs/op", r.Name, r.Requests, r.NsPerOp, r.BytesPerOp, r.AllocsPerOp)
+	if !r.OK() {
+		s += fmt.Sprintf("\t%d mismatches", len(r.Mismatches))
+	}
+	return s
+}
+
+// Report - results of CompareStrategies, sorted by name.
+type Report struct {
+	Results []Result
+}
+
+// OK - checks whether all strategies returned the expected decisions.
+func (r Report) OK() bool {
+	for _, result := range r.Results {
+		if !result.OK() {
+			return false
+		}
+	}
+	return true
+}
+
+func (r Report) String() string {
+	var b strings.Builder
+	for _, result := range r.Results {
+		fmt.Fprintln(&b, result)
+	}
+	return b.String()
+}
+
+// CompareStrategies - checks the decision of each strategy, by name, for
+// every request of w against the expected decision and measures the
+// strategy, see Workload. Strategies are measured one after another, the
+// measurement is not meaningful if other goroutines are busy.
+func CompareStrategies(w Workload, strategies map[string]Strategy) Report {
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	report := Report{Results: make([]Result, 0, len(names))}
+	for _, name := range names {
+		result := Result{Name: name}
+		strategy := strategies[name]
+		for i, args := range w.Args {
+			if i < len(w.Expected) && strategy(w.Policies, args) != w.Expected[i] {
+				result.Mismatches = append(result.Mismatches, i)
+			}
+		}
+		measure(w, strategy, &result)
+		report.Results = append(report.Results, result)
+	}
+	return report
+}
+
+// measure - evaluates the requests of w using strategy until either bound
+// of w is reached, and sets the measurements of result.
+func measure(w Workload, strategy Strategy, result *Result) {
+	if len(w.Args) == 0 {
+		return
+	}
+	maxRequests := w.MaxRequests
+	if maxRequests <= 0 {
+		maxRequests = DefaultMaxRequests
+	}
+	maxDuration := w.MaxDuration
+	if maxDuration <= 0 {
+		maxDuration = DefaultMaxDuration
+	}
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	var n int
+	for n < maxRequests {
+		strategy(w.Policies, w.Args[n%len(w.Args)])
+		n++
+		// Checking the time is not free, check it every few requests.
+		if n%64 == 0 && time.Since(start) >= maxDuration {
+			break
+		}
+	}
+
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	result.Requests = n
+	result.NsPerOp = elapsed.Nanoseconds() / int64(n)
+	result.AllocsPerOp = int64(after.Mallocs-before.Mallocs) / int64(n)
+	result.BytesPerOp = int64(after.TotalAlloc-before.TotalAlloc) / int64(n)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package benchutil
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/minio/pkg/v3/policy"
+)
+
+func TestGenWorkload(t *testing.T) {
+	policies, args, expected := GenWorkload(20, 10, 500, 1)
+	if len(policies) != 20 || len(args) != 500 || len(expected) != 500 {
+		t.Fatalf("expected: 20 policies and 500 requests, got: %v policies, %v requests, %v decisions", len(policies), len(args), len(expected))
+	}
+	for i, p := range policies {
+		if err := p.Validate(); err != nil {
+			t.Fatalf("policy %v: unexpected error: %v", i, err)
+		}
+	}
+	if !slices.Contains(expected, true) || !slices.Contains(expected, false) {
+		t.Fatalf("expected allowed and denied requests, got: %v", expected)
+	}
+
+	policies2, args2, expected2 := GenWorkload(20, 10, 500, 1)
+	if !reflect.DeepEqual(policies, policies2) || !reflect.DeepEqual(args, args2) || !reflect.DeepEqual(expected, expected2) {
+		t.Fatalf("expected the same workload for the same seed")
+	}
+	policies3, args3, _ := GenWorkload(20, 10, 500, 2)
+	if reflect.DeepEqual(policies, policies3) || reflect.DeepEqual(args, args3) {
+		t.Fatalf("expected another workload for another seed")
+	}
+}
+
+func TestCompareStrategies(t *testing.T) {
+	policies, args, expected := GenWorkload(10, 5, 200, 1)
+	w := Workload{Policies: policies, Args: args, Expected: expected, MaxRequests: 1000, MaxDuration: time.Minute}
+
+	// Broken on every request of bucket-0.
+	var broken []int
+	for i, a := range args {
+		if a.BucketName == "bucket-0" {
+			broken = append(broken, i)
+		}
+	}
+	strategies := Strategies()
+	strategies["broken"] = func(policies []policy.Policy, args policy.Args) bool {
+		allowed := Serial(policies, args)
+		if args.BucketName == "bucket-0" {
+			return !allowed
+		}
+		return allowed
+	}
+
+	report := CompareStrategies(w, strategies)
+	if report.OK() {
+		t.Fatalf("expected the broken strategy to be reported")
+	}
+	names := make([]string, 0, len(report.Results))
+	for _, result := range report.Results {
+		names = append(names, result.Name)
+		if result.Requests != 1000 {
+			t.Fatalf("%v: expected: %v requests, got: %v", result.Name, 1000, result.Requests)
+		}
+		if result.NsPerOp <= 0 {
+			t.Fatalf("%v: expected a duration, got: %v", result.Name, result.NsPerOp)
+		}
+		if result.Name == "broken" {
+			if len(broken) == 0 || !reflect.DeepEqual(result.Mismatches, broken) {
+				t.Fatalf("expected: %v, got: %v", broken, result.Mismatches)
+			}
+		} else if !result.OK() {
+			t.Fatalf("%v: expected no mismatches, got: %v", result.Name, result.Mismatches)
+		}
+	}
+	if !reflect.DeepEqual(names, []string{"broken", "merged", "serial"}) {
+		t.Fatalf("expected results sorted by name, got: %v", names)
+	}
+}
+
+func TestCompareStrategiesDuration(t *testing.T) {
+	policies, args, expected := GenWorkload(5, 5, 10, 1)
+	w := Workload{Policies: policies, Args: args, Expected: expected, MaxDuration: 10 * time.Millisecond}
+	slow := func(policies []policy.Policy, args policy.Args) bool {
+		time.Sleep(50 * time.Microsecond)
+		return Serial(policies, args)
+	}
+
+	start := time.Now()
+	report := CompareStrategies(w, map[string]Strategy{"slow": slow})
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the measurement to be bounded, took: %v", elapsed)
+	}
+	if result := report.Results[0]; !result.OK() || result.Requests >= DefaultMaxRequests {
+		t.Fatalf("expected a time bounded measurement, got: %v", result)
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MaxBucketPolicySize - maximum size of bucket policies in bytes, as
+// encoded by BucketPolicy.Size.
+const MaxBucketPolicySize = 20 * 1024
+
+// countingWriter - counts the bytes written to it.
+type countingWriter int
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	*w += countingWriter(len(p))
+	return len(p), nil
+}
+
+// Size - returns the size of the JSON encoding of the policy, to be
+// checked against MaxBucketPolicySize, or -1 if the policy cannot be
+// encoded, e.g. because it is invalid.
+func (policy BucketPolicy) Size() int {
+	var w countingWriter
+	if err := json.NewEncoder(&w).Encode(policy); err != nil {
+		return -1
+	}
+	// Encode terminates the encoding with a newline.
+	return int(w) - 1
+}
+
+// DiffKind - kind of a BPStatementDiff.
+type DiffKind int
+
+// Statement diff kinds.
+const (
+	DiffAdded DiffKind = iota + 1
+	DiffRemoved
+	DiffChanged
+)
+
+func (k DiffKind) String() string {
+	switch k {
+	case DiffAdded:
+		return "added"
+	case DiffRemoved:
+		return "removed"
+	case DiffChanged:
+		return "changed"
+	}
+	return fmt.Sprintf("DiffKind(%d)", int(k))
+}
+
+// BPStatementDiff - difference of a statement between two bucket
+// policies, see BucketPolicy.DiffStatements. Old is the zero BPStatement
+// for added statements, New for removed statements.
+type BPStatementDiff struct {
+	Kind DiffKind
+	Old  BPStatement
+	New  BPStatement
+}
+
+// diffKey - returns the key by which statements are matched: the SID and
+// its occurrence, or the canonical encoding of statements without SID.
+func diffKey(statement BPStatement, seen map[ID]int) string {
+	if statement.SID == "" {
+		return "\x00" + string(statement.appendCanonical(nil))
+	}
+	n := seen[statement.SID]
+	seen[statement.SID]++
+	return fmt.Sprintf("%s\x00%d", statement.SID, n)
+}
+
+// DiffStatements - returns the statements added, removed and changed by
+// other compared to this policy. Statements are matched by SID, hence
+// statements with a SID in both policies are reported as changed, and
+// statements without SID by their contents, hence they are reported as
+// removed and added, unless only their Description changed. Removed and
+// changed statements are ordered as in this policy, followed by the added
+// statements, ordered as in other.
+func (policy BucketPolicy) DiffStatements(other BucketPolicy) []BPStatementDiff {
+	type entry struct {
+		statement BPStatement
+		matched   bool
+	}
+	newStatements := make(map[string][]*entry, len(other.Statements))
+	added := make([]*entry, 0, len(other.Statements))
+	seen := map[ID]int{}
+	for _, statement := range other.Statements {
+		e := &entry{statement: statement}
+		key := diffKey(statement, seen)
+		newStatements[key] = append(newStatements[key], e)
+		added = append(added, e)
+	}
+
+	var diffs []BPStatementDiff
+	seen = map[ID]int{}
+	for _, statement := range policy.Statements {
+		key := diffKey(statement, seen)
+		var match *entry
+		for _, e := range newStatements[key] {
+			if !e.matched {
+				match = e
+				break
+			}
+		}
+		switch {
+		case match == nil:
+			diffs = append(diffs, BPStatementDiff{Kind: DiffRemoved, Old: statement})
+			continue
+		case !statement.Equals(match.statement) || statement.Description != match.statement.Description:
+			diffs = append(diffs, BPStatementDiff{Kind: DiffChanged, Old: statement, New: match.statement})
+		}
+		match.matched = true
+	}
+
+	for _, e := range added {
+		if !e.matched {
+			diffs = append(diffs, BPStatementDiff{Kind: DiffAdded, New: e.statement})
+		}
+	}
+	return diffs
+}
+
+// actionNames - returns the sorted names of actions, without the "s3:"
+// prefix.
+func actionNames(actions ActionSet) string {
+	names := make([]string, 0, len(actions))
+	for action := range actions {
+		names = append(names, strings.TrimPrefix(string(action), "s3:"))
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// resourcePatterns - returns the sorted patterns of resources.
+func resourcePatterns(resources ResourceSet) string {
+	patterns := make([]string, 0, len(resources))
+	for resource := range resources {
+		patterns = append(patterns, resource.Pattern)
+	}
+	sort.Strings(patterns)
+	return strings.Join(patterns, ", ")
+}
+
+// describeParts - returns the descriptions of the effect, actions,
+// resources, principals, conditions and the Description of statement.
+func (statement BPStatement) describeParts() [6]string {
+	var parts [6]string
+	parts[0] = string(statement.Effect)
+
+	if len(statement.NotActions) > 0 {
+		parts[1] = "all actions but " + actionNames(statement.NotActions)
+	} else {
+		parts[1] = actionNames(statement.Actions)
+	}
+
+	if len(statement.NotResources) > 0 {
+		parts[2] = "on all resources but " + resourcePatterns(statement.NotResources)
+	} else {
+		parts[2] = "on " + resourcePatterns(statement.Resources)
+	}
+
+	principals := statement.Principal.AWS.ToSlice()
+	if len(principals) == 1 {
+		parts[3] = "for principal " + principals[0]
+	} else {
+		parts[3] = "for principals " + strings.Join(principals, ", ")
+	}
+
+	if len(statement.Conditions) > 0 {
+		conditions := make([]string, 0, len(statement.Conditions))
+		for _, f := range statement.Conditions {
+			conditions = append(conditions, fmt.Sprint(f))
+		}
+		sort.Strings(conditions)
+		parts[4] = "when " + strings.Join(conditions, ", ")
+	}
+
+	if statement.Description != "" {
+		parts[5] = fmt.Sprintf("(%q)", statement.Description)
+	}
+	return parts
+}
+
+// describe - returns a description of statement, e.g. "Allow GetObject on
+// mybucket/* for principal *".
+func (statement BPStatement) describe() string {
+	parts := statement.describeParts()
+	s := strings.Join(parts[:4], " ")
+	for _, part := range parts[4:] {
+		if part != "" {
+			s += " " + part
+		}
+	}
+	return s
+}
+
+// Render - returns a compact description of the difference, e.g. "added
+// Allow GetObject on mybucket/* for principal *". Changed statements are
+// described by their changed parts only.
+func (d BPStatementDiff) Render() string {
+	statement := d.New
+	if d.Kind == DiffRemoved {
+		statement = d.Old
+	}
+	prefix := d.Kind.String()
+	if statement.SID != "" {
+		prefix += fmt.Sprintf(" statement '%s':", statement.SID)
+	}
+	if d.Kind != DiffChanged {
+		return prefix + " " + statement.describe()
+	}
+
+	oldParts, newParts := d.Old.describeParts(), d.New.describeParts()
+	var changes []string
+	for i, name := range []string{"effect", "actions", "resources", "principals", "conditions", "description"} {
+		if oldParts[i] != newParts[i] {
+			changes = append(changes, fmt.Sprintf("%s %q -> %q", name, oldParts[i], newParts[i]))
+		}
+	}
+	if len(changes) == 0 {
+		// Equal descriptions, e.g. for conditions differing in values
+		// not shown.
+		return prefix + " " + d.New.describe()
+	}
+	return prefix + " " + strings.Join(changes, "; ")
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func mustParseBucketPolicy(t *testing.T, data string) BucketPolicy {
+	t.Helper()
+	p, err := ParseBucketPolicyConfig(strings.NewReader(data), "mybucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return *p
+}
+
+func TestBucketPolicySize(t *testing.T) {
+	fixtures, err := filepath.Glob("testdata/legacy/bucket-*.json")
+	if err != nil || len(fixtures) == 0 {
+		t.Fatalf("expected bucket policy fixtures, got: %v, %v", fixtures, err)
+	}
+	for _, fixture := range fixtures {
+		data, err := os.ReadFile(fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, err := ParseBucketPolicyConfig(bytes.NewReader(data), "examplebucket")
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", fixture, err)
+		}
+		out, err := json.Marshal(p)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", fixture, err)
+		}
+		if size := p.Size(); size != len(out) {
+			t.Fatalf("%v: expected: %v, got: %v", fixture, len(out), size)
+		}
+
+		// Fixtures do not differ from themselves.
+		if diffs := p.DiffStatements(*p); len(diffs) != 0 {
+			t.Fatalf("%v: expected no differences, got: %v", fixture, diffs)
+		}
+		if diffs := (BucketPolicy{}).DiffStatements(*p); len(diffs) != len(p.Statements) {
+			t.Fatalf("%v: expected: %v, got: %v", fixture, len(p.Statements), diffs)
+		}
+	}
+
+	// Invalid policies cannot be encoded.
+	if size := (BucketPolicy{Version: "1999-01-01"}).Size(); size != -1 {
+		t.Fatalf("expected: %v, got: %v", -1, size)
+	}
+}
+
+func TestBucketPolicySizeLimit(t *testing.T) {
+	p := mustParseBucketPolicy(t, `{"Version": "2012-10-17", "Statement": [{"Sid": "", "Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"}]}`)
+	p.Statements[0].SID = "a"
+	padding := MaxBucketPolicySize - p.Size()
+
+	testCases := []struct {
+		sidLength int
+		withinMax bool
+	}{
+		{padding, true},
+		{padding + 1, false},
+		{padding - 1, true},
+	}
+	for i, testCase := range testCases {
+		p.Statements[0].SID = ID(strings.Repeat("a", 1+testCase.sidLength))
+		out, err := json.Marshal(p)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		size := p.Size()
+		if size != len(out) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, len(out), size)
+		}
+		if withinMax := size <= MaxBucketPolicySize; withinMax != testCase.withinMax {
+			t.Fatalf("case %v: expected: %v, got: %v (%v bytes)", i+1, testCase.withinMax, withinMax, size)
+		}
+	}
+}
+
+func TestBucketPolicyDiffStatements(t *testing.T) {
+	old := mustParseBucketPolicy(t, `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Sid": "PublicRead", "Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"},
+    {"Sid": "Upload", "Effect": "Allow", "Principal": {"AWS": ["arn:aws:iam::123456789012:user/alice", "arn:aws:iam::123456789012:user/bob"]}, "Action": "s3:PutObject", "Resource": "arn:aws:s3:::mybucket/uploads/*"},
+    {"Effect": "Deny", "Principal": "*", "Action": "s3:DeleteObject", "Resource": "arn:aws:s3:::mybucket/*"},
+    {"Effect": "Allow", "Principal": "*", "Action": "s3:ListBucket", "Resource": "arn:aws:s3:::mybucket"}
+  ]
+}`)
+	updated := mustParseBucketPolicy(t, `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Principal": "*", "Action": "s3:ListBucket", "Resource": "arn:aws:s3:::mybucket"},
+    {"Sid": "PublicRead", "Effect": "Allow", "Principal": "*", "Action": ["s3:GetObject", "s3:GetObjectTagging"], "Resource": "arn:aws:s3:::mybucket/public/*"},
+    {"Sid": "Upload", "Effect": "Allow", "Principal": {"AWS": ["arn:aws:iam::123456789012:user/alice", "arn:aws:iam::123456789012:user/bob"]}, "Action": "s3:PutObject", "Resource": "arn:aws:s3:::mybucket/uploads/*"},
+    {"Effect": "Deny", "Principal": "*", "NotAction": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*", "Condition": {"Bool": {"aws:SecureTransport": "false"}}},
+    {"Sid": "Audit", "Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:user/auditor"}, "Action": "s3:GetBucketPolicy", "Resource": "arn:aws:s3:::mybucket"}
+  ]
+}`)
+
+	diffs := old.DiffStatements(updated)
+	var rendered []string
+	for _, d := range diffs {
+		rendered = append(rendered, d.Render())
+	}
+	expected := []string{
+		`changed statement 'PublicRead': actions "GetObject" -> "GetObject, GetObjectTagging"; resources "on mybucket/*" -> "on mybucket/public/*"`,
+		"removed Deny DeleteObject on mybucket/* for principal *",
+		"added Deny all actions but GetObject on mybucket/* for principal * when Bool:aws:SecureTransport:false",
+		"added statement 'Audit': Allow GetBucketPolicy on mybucket for principal arn:aws:iam::123456789012:user/auditor",
+	}
+	if !reflect.DeepEqual(rendered, expected) {
+		t.Fatalf("expected: %q, got: %q", expected, rendered)
+	}
+	kinds := []DiffKind{DiffChanged, DiffRemoved, DiffAdded, DiffAdded}
+	for i, d := range diffs {
+		if d.Kind != kinds[i] {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, kinds[i], d.Kind)
+		}
+	}
+
+	// The reverse diff swaps additions and removals.
+	reverse := updated.DiffStatements(old)
+	if len(reverse) != len(diffs) {
+		t.Fatalf("expected: %v, got: %v", len(diffs), reverse)
+	}
+	if r := reverse[len(reverse)-1].Render(); r != "added Deny DeleteObject on mybucket/* for principal *" {
+		t.Fatalf("unexpected diff: %v", r)
+	}
+	upload := old.Statements[1]
+	if r := (BPStatementDiff{Kind: DiffRemoved, Old: upload}).Render(); r != "removed statement 'Upload': Allow PutObject on mybucket/uploads/* for principals arn:aws:iam::123456789012:user/alice, arn:aws:iam::123456789012:user/bob" {
+		t.Fatalf("unexpected diff: %v", r)
+	}
+}
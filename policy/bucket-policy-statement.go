@@ -18,17 +18,24 @@
 package policy
 
 import (
+	"encoding/json"
 	"strings"
 
 	"github.com/minio/pkg/v3/policy/condition"
 )
 
-// BPStatement - policy statement.
+// BPStatement - policy statement. The zero BPStatement has no effect and
+// is invalid, it encodes to JSON decoding to the zero BPStatement.
+//
+// Description is a MinIO extension annotating the statement, as for
+// Statement. It is ignored by evaluation, Equals and Fingerprint, see
+// BucketPolicy.StripExtensions for AWS compatible documents.
 type BPStatement struct {
 	SID          ID                  `json:"Sid,omitempty"`
+	Description  string              `json:"Description,omitempty"`
 	Effect       Effect              `json:"Effect"`
 	Principal    Principal           `json:"Principal"`
-	Actions      ActionSet           `json:"Action"`
+	Actions      ActionSet           `json:"Action,omitempty"`
 	NotActions   ActionSet           `json:"NotAction,omitempty"`
 	Resources    ResourceSet         `json:"Resource"`
 	NotResources ResourceSet         `json:"NotResource,omitempty"`
@@ -36,9 +43,20 @@ type BPStatement struct {
 }
 
 // IsAllowed - checks given policy args is allowed to continue the Rest API.
+// Statements are not validated here: a statement with both Resource and
+// NotResource, which is invalid, only applies to resources matching
+// Resource but not NotResource, and one with both Action and NotAction
+// only to actions matching Action but not NotAction. A statement with
+// neither Action nor NotAction, or with neither Resource nor NotResource,
+// applies to nothing. Statements of invalid effects never allow, see
+// Effect.
 func (statement BPStatement) IsAllowed(args BucketPolicyArgs) bool {
 	check := func() bool {
-		if !statement.Principal.Match(args.AccountName) {
+		if !statement.Principal.matchArgs(args) {
+			return false
+		}
+
+		if statement.Actions.IsEmpty() && statement.NotActions.IsEmpty() {
 			return false
 		}
 
@@ -48,12 +66,12 @@ func (statement BPStatement) IsAllowed(args BucketPolicyArgs) bool {
 		}
 
 		resource := args.BucketName
-		if args.ObjectName != "" {
-			if !strings.HasPrefix(args.ObjectName, "/") {
+		if objectName := decodeObjectName(args.ObjectName, args.ObjectNameEncoded); objectName != "" {
+			if !strings.HasPrefix(objectName, "/") {
 				resource += "/"
 			}
 
-			resource += args.ObjectName
+			resource += objectName
 		}
 
 		if !statement.Resources.Match(resource, args.ConditionValues) {
@@ -72,43 +90,57 @@ func (statement BPStatement) IsAllowed(args BucketPolicyArgs) bool {
 
 // isValid - checks whether statement is valid or not.
 func (statement BPStatement) isValid() error {
+	freezeActions()
+
 	if !statement.Effect.IsValid() {
-		return Errorf("invalid Effect %v", statement.Effect)
+		return Errorf("%w %v", ErrInvalidEffect, statement.Effect)
+	}
+
+	if err := validateDescription(statement.Description); err != nil {
+		return err
 	}
 
 	if !statement.Principal.IsValid() {
-		return Errorf("invalid Principal %v", statement.Principal)
+		return Errorf("%w %v", ErrInvalidPrincipal, statement.Principal)
 	}
 
 	if len(statement.Actions) == 0 && len(statement.NotActions) == 0 {
-		return Errorf("Action must not be empty")
+		return Errorf("%w", ErrEmptyActions)
+	}
+
+	if len(statement.Actions) > 0 && len(statement.NotActions) > 0 {
+		return Errorf("%w", ErrActionAndNotAction)
 	}
 
 	if len(statement.Resources) == 0 && len(statement.NotResources) == 0 {
-		return Errorf("Resource must not be empty")
+		return Errorf("%w", ErrEmptyResources)
+	}
+
+	if len(statement.Resources) > 0 && len(statement.NotResources) > 0 {
+		return Errorf("%w", ErrResourceAndNotResource)
 	}
 
-	for action := range statement.Actions {
+	for _, action := range statement.Actions.toSortedSlice() {
 		if action.IsObjectAction() {
 			if len(statement.Resources) > 0 && !statement.Resources.ObjectResourceExists() {
-				return Errorf("unsupported Resource found %v for action %v", statement.Resources, action)
+				return Errorf("%w", ErrUnsupportedResource{Action: action, Resources: statement.Resources})
 			}
 			if len(statement.NotResources) > 0 && !statement.NotResources.ObjectResourceExists() {
-				return Errorf("unsupported Resource found %v for action %v", statement.NotResources, action)
+				return Errorf("%w", ErrUnsupportedResource{Action: action, Resources: statement.NotResources})
 			}
 		} else {
 			if len(statement.Resources) > 0 && !statement.Resources.BucketResourceExists() {
-				return Errorf("unsupported Resource found %v for action %v", statement.Resources, action)
+				return Errorf("%w", ErrUnsupportedResource{Action: action, Resources: statement.Resources})
 			}
 			if len(statement.NotResources) > 0 && !statement.NotResources.BucketResourceExists() {
-				return Errorf("unsupported Resource found %v for action %v", statement.NotResources, action)
+				return Errorf("%w", ErrUnsupportedResource{Action: action, Resources: statement.NotResources})
 			}
 		}
 
 		keys := statement.Conditions.Keys()
 		keyDiff := keys.Difference(IAMActionConditionKeyMap.Lookup(action))
 		if !keyDiff.IsEmpty() {
-			return Errorf("unsupported condition keys '%v' used for action '%v'", keyDiff, action)
+			return Errorf("%w", ErrUnsupportedConditionKey{Action: action, Keys: keyDiff})
 		}
 	}
 
@@ -136,7 +168,24 @@ func (statement BPStatement) Validate(bucketName string) error {
 	return nil
 }
 
-// Equals checks if two statements are equal
+// MarshalJSON - encodes BPStatement to JSON data. Statements with both
+// Action and NotAction or both Resource and NotResource, which are
+// invalid, are not encoded.
+func (statement BPStatement) MarshalJSON() ([]byte, error) {
+	if len(statement.Actions) > 0 && len(statement.NotActions) > 0 {
+		return nil, Errorf("%w", ErrActionAndNotAction)
+	}
+	if len(statement.Resources) > 0 && len(statement.NotResources) > 0 {
+		return nil, Errorf("%w", ErrResourceAndNotResource)
+	}
+
+	// subtype to avoid recursive call to MarshalJSON()
+	type subStatement BPStatement
+	return json.Marshal(subStatement(statement))
+}
+
+// Equals checks if two statements are equal, their SID and Description
+// are not compared.
 func (statement BPStatement) Equals(st BPStatement) bool {
 	if statement.Effect != st.Effect {
 		return false
@@ -166,6 +215,7 @@ func (statement BPStatement) Equals(st BPStatement) bool {
 func (statement BPStatement) Clone() BPStatement {
 	return BPStatement{
 		SID:          statement.SID,
+		Description:  statement.Description,
 		Effect:       statement.Effect,
 		Principal:    statement.Principal.Clone(),
 		Actions:      statement.Actions.Clone(),
@@ -19,6 +19,7 @@ package policy
 
 import (
 	"encoding/json"
+	"errors"
 	"net"
 	"reflect"
 	"testing"
@@ -349,6 +350,46 @@ func TestBPStatementIsValid(t *testing.T) {
 	}
 }
 
+func TestBPStatementIsValidErrorMessage(t *testing.T) {
+	func1, err := condition.NewStringEqualsFunc(
+		"",
+		condition.S3XAmzMetadataDirective.ToKey(),
+		"REPLACE",
+	)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	func2, err := condition.NewStringEqualsFunc(
+		"",
+		condition.S3XAmzCopySource.ToKey(),
+		"mybucket/myobject",
+	)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	statement := NewBPStatement("",
+		Allow,
+		NewPrincipal("*"),
+		NewActionSet(PutObjectAction, GetObjectAction),
+		NewResourceSet(NewResource("mybucket/myobject*")),
+		condition.NewFunctions(func1, func2),
+	)
+	expectedErr := "unsupported condition keys '[s3:x-amz-copy-source s3:x-amz-metadata-directive]' used for action 's3:GetObject'"
+
+	// Repeat to catch any map iteration order dependency.
+	for i := 0; i < 10; i++ {
+		err := statement.isValid()
+		if err == nil {
+			t.Fatalf("expected error")
+		}
+		if err.Error() != expectedErr {
+			t.Fatalf("error: expected: %v, got: %v", expectedErr, err)
+		}
+	}
+}
+
 func TestBPStatementUnmarshalJSONAndValidate(t *testing.T) {
 	case1Data := []byte(`{
     "Sid": "SomeId1",
@@ -609,3 +650,84 @@ func TestBPStatementValidate(t *testing.T) {
 		}
 	}
 }
+
+func TestBPStatementResourceAndNotResource(t *testing.T) {
+	both := NewBPStatementWithNotResource("",
+		Allow,
+		NewPrincipal("*"),
+		NewActionSet(GetObjectAction),
+		NewResourceSet(NewResource("mybucket/secret/*")),
+		condition.NewFunctions(),
+	)
+	both.Resources = NewResourceSet(NewResource("mybucket/*"))
+
+	if err := both.isValid(); !errors.Is(err, ErrResourceAndNotResource) {
+		t.Fatalf("expected: %v, got: %v", ErrResourceAndNotResource, err)
+	}
+	if _, err := json.Marshal(both); !errors.Is(err, ErrResourceAndNotResource) {
+		t.Fatalf("expected: %v, got: %v", ErrResourceAndNotResource, err)
+	}
+
+	// Invalid statements are still evaluated, with AND semantics.
+	testCases := []struct {
+		objectName string
+		expected   bool
+	}{
+		{"myobject", true},
+		{"secret/myobject", false},
+	}
+	for i, testCase := range testCases {
+		args := BucketPolicyArgs{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: GetObjectAction, BucketName: "mybucket", ObjectName: testCase.objectName}
+		if got := both.IsAllowed(args); got != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, got)
+		}
+	}
+
+	bothActions := NewBPStatement("",
+		Allow,
+		NewPrincipal("*"),
+		NewActionSet(GetObjectAction),
+		NewResourceSet(NewResource("mybucket/*")),
+		condition.NewFunctions(),
+	)
+	bothActions.NotActions = NewActionSet(PutObjectAction)
+	if err := bothActions.isValid(); !errors.Is(err, ErrActionAndNotAction) {
+		t.Fatalf("expected: %v, got: %v", ErrActionAndNotAction, err)
+	}
+	if _, err := json.Marshal(bothActions); !errors.Is(err, ErrActionAndNotAction) {
+		t.Fatalf("expected: %v, got: %v", ErrActionAndNotAction, err)
+	}
+
+	statement := NewStatement("", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/*")), condition.NewFunctions())
+	statement.NotActions = NewActionSet(PutObjectAction)
+	if err := statement.Validate(); !errors.Is(err, ErrActionAndNotAction) {
+		t.Fatalf("expected: %v, got: %v", ErrActionAndNotAction, err)
+	}
+	if _, err := json.Marshal(statement); !errors.Is(err, ErrActionAndNotAction) {
+		t.Fatalf("expected: %v, got: %v", ErrActionAndNotAction, err)
+	}
+}
+
+func TestBPStatementInvalidNeverMatches(t *testing.T) {
+	// Statements are constructed directly, bypassing validation, as
+	// corrupted stored policies may be.
+	testCases := []BPStatement{
+		// No Action and no NotAction.
+		{Effect: Allow, Principal: NewPrincipal("*"), Resources: NewResourceSet(NewResource("*"))},
+		{Effect: Allow, Principal: NewPrincipal("*"), NotResources: NewResourceSet(NewResource("otherbucket/*"))},
+		// No Resource and no NotResource.
+		{Effect: Allow, Principal: NewPrincipal("*"), Actions: NewActionSet(AllActions)},
+		{Effect: Allow, Principal: NewPrincipal("*"), NotActions: NewActionSet(PutObjectAction)},
+	}
+
+	args := BucketPolicyArgs{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}
+	for i, statement := range testCases {
+		if statement.IsAllowed(args) {
+			t.Fatalf("case %v: expected: false, got: true", i+1)
+		}
+		statement.Effect = Deny
+		if !statement.IsAllowed(args) {
+			t.Fatalf("case %v: expected: true, got: false", i+1)
+		}
+	}
+}
@@ -18,7 +18,9 @@
 package policy
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 )
 
@@ -31,9 +33,30 @@ type BucketPolicyArgs struct {
 	ConditionValues map[string][]string `json:"conditions"`
 	IsOwner         bool                `json:"owner"`
 	ObjectName      string              `json:"object"`
+
+	// ObjectNameEncoded is set if ObjectName is percent-encoded, as in the
+	// x-amz-copy-source header, it is then decoded exactly once before
+	// matching resources.
+	ObjectNameEncoded bool `json:"objectNameEncoded,omitempty"`
+
+	// Region of the deployment, used for the aws:RequestedRegion and
+	// minio:deployment-region condition keys unless they are already
+	// present in ConditionValues.
+	Region string `json:"region,omitempty"`
+
+	// AccountID - account of the requester, principals of the form
+	// "arn:aws:iam::<account>:root" match all requesters of the account.
+	AccountID string `json:"accountId,omitempty"`
+
+	// PrincipalARN - ARN of the requester matched against ARN principals,
+	// e.g. "arn:aws:iam::123456789012:user/alice". If empty, it is
+	// "arn:aws:iam::<AccountID>:user/<AccountName>" if AccountID is set.
+	PrincipalARN string `json:"principalArn,omitempty"`
 }
 
-// BucketPolicy - bucket policy.
+// BucketPolicy - bucket policy. The zero BucketPolicy has no statement and
+// allows no request, it is valid and encodes to JSON decoding to the zero
+// BucketPolicy.
 type BucketPolicy struct {
 	ID         ID `json:"ID,omitempty"`
 	Version    string
@@ -41,7 +64,14 @@ type BucketPolicy struct {
 }
 
 // IsAllowed - checks given policy args is allowed to continue the Rest API.
+// ACL actions are not evaluated, see SetACLActionsAllowed.
 func (policy BucketPolicy) IsAllowed(args BucketPolicyArgs) bool {
+	if args.Action.isACLAction() {
+		return aclActionsAllowed.Load()
+	}
+
+	args.ConditionValues = withRegion(args.ConditionValues, args.Region)
+
 	// Check all deny statements. If any one statement denies, return false.
 	for _, statement := range policy.Statements {
 		if statement.Effect == Deny {
@@ -73,10 +103,20 @@ func (policy BucketPolicy) IsEmpty() bool {
 	return len(policy.Statements) == 0
 }
 
-// isValid - checks if Policy is valid or not.
+// isValid - checks if Policy is valid or not. Like AWS, and unlike IAM
+// policies, SIDs must be unique, see EnsureUniqueSIDs.
 func (policy BucketPolicy) isValid() error {
-	if policy.Version != DefaultVersion && policy.Version != "" {
-		return Errorf("invalid version '%v'", policy.Version)
+	if err := policy.isValidStatements(); err != nil {
+		return err
+	}
+	return policy.checkUniqueSIDs()
+}
+
+// isValidStatements - checks the version and the statements of the
+// policy, but not whether SIDs are unique.
+func (policy BucketPolicy) isValidStatements() error {
+	if policy.Version != DefaultVersion && policy.Version != LegacyVersion && policy.Version != "" {
+		return Errorf("%w '%v'", ErrInvalidVersion, policy.Version)
 	}
 
 	for _, statement := range policy.Statements {
@@ -88,7 +128,59 @@ func (policy BucketPolicy) isValid() error {
 	return nil
 }
 
-// MarshalJSON - encodes Policy to JSON data.
+// checkUniqueSIDs - checks that no two statements share a SID, statements
+// without SID are not checked.
+func (policy BucketPolicy) checkUniqueSIDs() error {
+	seen := make(map[ID]struct{}, len(policy.Statements))
+	for _, statement := range policy.Statements {
+		if statement.SID == "" {
+			continue
+		}
+		if _, ok := seen[statement.SID]; ok {
+			return Errorf("%w '%v'", ErrDuplicateSID, statement.SID)
+		}
+		seen[statement.SID] = struct{}{}
+	}
+	return nil
+}
+
+// EnsureUniqueSIDs - renames the statements sharing the SID of an earlier
+// statement by suffixing the SID with the lowest number, starting from 2,
+// not making it the SID of another statement, e.g. the second "ReadOnly"
+// is renamed to "ReadOnly2", to repair policies decoded with duplicate
+// SIDs. It returns the number of renamed statements.
+func (policy *BucketPolicy) EnsureUniqueSIDs() int {
+	used := make(map[ID]struct{}, len(policy.Statements))
+	for _, statement := range policy.Statements {
+		used[statement.SID] = struct{}{}
+	}
+
+	var renamed int
+	seen := make(map[ID]struct{}, len(policy.Statements))
+	for i, statement := range policy.Statements {
+		if statement.SID == "" {
+			continue
+		}
+		if _, ok := seen[statement.SID]; !ok {
+			seen[statement.SID] = struct{}{}
+			continue
+		}
+		for n := 2; ; n++ {
+			sid := ID(fmt.Sprintf("%s%d", statement.SID, n))
+			if _, ok := used[sid]; !ok {
+				policy.Statements[i].SID = sid
+				used[sid] = struct{}{}
+				seen[sid] = struct{}{}
+				break
+			}
+		}
+		renamed++
+	}
+	return renamed
+}
+
+// MarshalJSON - encodes Policy to JSON data. Invalid policies, including
+// policies with duplicate SIDs, are not encoded.
 func (policy BucketPolicy) MarshalJSON() ([]byte, error) {
 	if err := policy.isValid(); err != nil {
 		return nil, err
@@ -99,6 +191,9 @@ func (policy BucketPolicy) MarshalJSON() ([]byte, error) {
 	return json.Marshal(subPolicy(policy))
 }
 
+// dropDuplicateStatements - removes statements equal to an earlier one,
+// including their SID. The first occurrence is kept and the order of the
+// remaining statements is preserved.
 func (policy *BucketPolicy) dropDuplicateStatements() {
 	dups := make(map[int]struct{})
 	for i := range policy.Statements {
@@ -108,7 +203,8 @@ func (policy *BucketPolicy) dropDuplicateStatements() {
 			continue
 		}
 		for j := i + 1; j < len(policy.Statements); j++ {
-			if !policy.Statements[i].Equals(policy.Statements[j]) {
+			if policy.Statements[i].SID != policy.Statements[j].SID ||
+				!policy.Statements[i].Equals(policy.Statements[j]) {
 				continue
 			}
 
@@ -129,17 +225,41 @@ func (policy *BucketPolicy) dropDuplicateStatements() {
 	policy.Statements = policy.Statements[:c]
 }
 
-// UnmarshalJSON - decodes JSON data to Policy.
+// UnmarshalJSON - decodes JSON data to Policy. Like AWS, documents of
+// LegacyVersion, with a single statement object in place of the array of
+// statements, or with effects in any case, such as "allow", are accepted.
+// Statements are always encoded as an array and effects as "Allow" or
+// "Deny", see ParseBucketPolicyConfigStrict. Statements sharing a SID are
+// accepted, see EnsureUniqueSIDs. Documents nested deeper than MaxDepth are
+// rejected before they are decoded, as by Policy.UnmarshalJSON.
 func (policy *BucketPolicy) UnmarshalJSON(data []byte) error {
+	if err := checkDepth(data); err != nil {
+		return err
+	}
+
 	// subtype to avoid recursive call to UnmarshalJSON()
 	type subPolicy BucketPolicy
 	var sp subPolicy
-	if err := json.Unmarshal(data, &sp); err != nil {
+	err := json.Unmarshal(data, &sp)
+	if isStatementObjectError(err) {
+		// Only documents with a single statement object are rewritten.
+		if data, err = legacyStatements(data); err != nil {
+			return err
+		}
+		sp = subPolicy{}
+		err = json.Unmarshal(data, &sp)
+	}
+	if err != nil {
 		return err
 	}
 
 	p := BucketPolicy(sp)
-	if err := p.isValid(); err != nil {
+	for i := range p.Statements {
+		p.Statements[i].Effect = normalizeEffect(p.Statements[i].Effect)
+	}
+	// Duplicate SIDs are only rejected by Validate, to read existing
+	// configurations.
+	if err := p.isValidStatements(); err != nil {
 		return err
 	}
 
@@ -166,6 +286,8 @@ func (policy BucketPolicy) Validate(bucketName string) error {
 }
 
 // ParseBucketPolicyConfig - parses data in given reader to Policy.
+// Documents nested deeper than MaxDepth are rejected, see
+// BucketPolicy.UnmarshalJSON.
 func ParseBucketPolicyConfig(reader io.Reader, bucketName string) (*BucketPolicy, error) {
 	var policy BucketPolicy
 
@@ -179,6 +301,21 @@ func ParseBucketPolicyConfig(reader io.Reader, bucketName string) (*BucketPolicy
 	return &policy, err
 }
 
+// ParseBucketPolicyConfigStrict - same as ParseBucketPolicyConfig, also
+// rejecting the documents AWS accepts for compatibility only, i.e. of
+// LegacyVersion, with a single statement object or with effects in
+// unusual case.
+func ParseBucketPolicyConfigStrict(reader io.Reader, bucketName string) (*BucketPolicy, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, Errorf("%w", err)
+	}
+	if err := checkNotLegacy(data); err != nil {
+		return nil, Errorf("%w", err)
+	}
+	return ParseBucketPolicyConfig(bytes.NewReader(data), bucketName)
+}
+
 // Equals returns true if the two policies are identical
 func (policy *BucketPolicy) Equals(p BucketPolicy) bool {
 	if policy.ID != p.ID || policy.Version != p.Version {
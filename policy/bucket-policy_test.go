@@ -18,9 +18,13 @@
 package policy
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"net"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/minio/pkg/v3/policy/condition"
@@ -1149,3 +1153,296 @@ func TestBucketPolicyValidate(t *testing.T) {
 		}
 	}
 }
+
+func TestBucketPolicyTenantResource(t *testing.T) {
+	data := []byte(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Principal": "*",
+            "Action": ["s3:GetObject", "s3:PutObject"],
+            "Resource": ["arn:aws:s3:::${jwt:tenant}-*/*"]
+        },
+        {
+            "Effect": "Deny",
+            "Principal": "*",
+            "Action": ["s3:PutObject"],
+            "NotResource": ["arn:aws:s3:::${jwt:tenant}-*/*"]
+        }
+    ]
+}`)
+
+	for _, bucketName := range []string{"acme-data", "globex-data"} {
+		if _, err := ParseBucketPolicyConfig(bytes.NewReader(data), bucketName); err != nil {
+			t.Fatalf("bucket %v: unexpected error: %v", bucketName, err)
+		}
+	}
+
+	policy, err := ParseBucketPolicyConfig(bytes.NewReader(data), "acme-data")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		tenant         string
+		action         Action
+		bucketName     string
+		expectedResult bool
+	}{
+		{"acme", GetObjectAction, "acme-data", true},
+		{"acme", PutObjectAction, "acme-logs", true},
+		{"acme", GetObjectAction, "globex-data", false},
+		{"acme", PutObjectAction, "globex-data", false},
+		{"globex", GetObjectAction, "globex-data", true},
+		{"globex", GetObjectAction, "acme-data", false},
+		{"", GetObjectAction, "acme-data", false},
+	}
+
+	for i, testCase := range testCases {
+		conditionValues := map[string][]string{}
+		if testCase.tenant != "" {
+			conditionValues["tenant"] = []string{testCase.tenant}
+		}
+		result := policy.IsAllowed(BucketPolicyArgs{
+			Action:          testCase.action,
+			BucketName:      testCase.bucketName,
+			ConditionValues: conditionValues,
+			ObjectName:      "myobject",
+		})
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestBucketPolicyIsAllowedRequestedRegion(t *testing.T) {
+	data := []byte(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Principal": "*",
+            "Action": ["s3:PutObject"],
+            "Resource": ["arn:aws:s3:::mybucket/*"],
+            "Condition": {"StringEquals": {"aws:RequestedRegion": "us-east-1"}}
+        }
+    ]
+}`)
+	policy, err := ParseBucketPolicyConfig(bytes.NewReader(data), "mybucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		region         string
+		expectedResult bool
+	}{
+		{"us-east-1", true},
+		{"eu-west-1", false},
+		{"", false},
+	}
+
+	for i, testCase := range testCases {
+		result := policy.IsAllowed(BucketPolicyArgs{
+			Action:     PutObjectAction,
+			BucketName: "mybucket",
+			ObjectName: "myobject",
+			Region:     testCase.region,
+		})
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestBucketPolicyIsAllowedPrincipalARN(t *testing.T) {
+	data := []byte(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Principal": {"AWS": ["alice", "arn:aws:iam::111122223333:user/bob", "arn:aws:iam::444455556666:root", "arn:aws:iam::777788889999:user/team-*"]},
+            "Action": ["s3:GetObject"],
+            "Resource": ["arn:aws:s3:::mybucket/*"]
+        },
+        {
+            "Effect": "Deny",
+            "Principal": {"AWS": ["mallory", "arn:aws:iam::444455556666:user/eve", "arn:aws:iam::*:user/guest"]},
+            "Action": ["s3:GetObject"],
+            "Resource": ["arn:aws:s3:::mybucket/*"]
+        }
+    ]
+}`)
+	policy, err := ParseBucketPolicyConfig(bytes.NewReader(data), "mybucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		accountName    string
+		accountID      string
+		principalARN   string
+		expectedResult bool
+	}{
+		// Plain access keys.
+		{"alice", "", "", true},
+		{"alice", "111122223333", "", true},
+		{"bob", "", "", false},
+		// Full ARNs, built from the account or given.
+		{"bob", "111122223333", "", true},
+		{"bob", "444455556666", "", true},
+		{"", "", "arn:aws:iam::111122223333:user/bob", true},
+		{"bob", "999999999999", "", false},
+		{"", "", "arn:aws:iam::111122223333:user/carol", false},
+		// Account roots match all principals of the account.
+		{"carol", "444455556666", "", true},
+		{"", "", "arn:aws:iam::444455556666:role/reader", true},
+		{"", "", "arn:aws:iam::444455556666:root", true},
+		// Wildcard ARNs.
+		{"team-a", "777788889999", "", true},
+		{"other", "777788889999", "", false},
+		// Deny statements apply to all forms.
+		{"mallory", "444455556666", "", false},
+		{"eve", "444455556666", "", false},
+		{"guest", "444455556666", "", false},
+		{"", "", "arn:aws:iam::777788889999:user/guest", false},
+	}
+
+	for i, testCase := range testCases {
+		result := policy.IsAllowed(BucketPolicyArgs{
+			AccountName:  testCase.accountName,
+			AccountID:    testCase.accountID,
+			PrincipalARN: testCase.principalARN,
+			Action:       GetObjectAction,
+			BucketName:   "mybucket",
+			ObjectName:   "myobject",
+		})
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	// The form of principals is preserved.
+	b, err := json.Marshal(policy)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded BucketPolicy
+	if err = json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !decoded.Statements[0].Principal.Equals(policy.Statements[0].Principal) ||
+		!decoded.Statements[0].Principal.AWS.Contains("arn:aws:iam::444455556666:root") {
+		t.Fatalf("expected: %v, got: %v", policy.Statements[0].Principal, decoded.Statements[0].Principal)
+	}
+}
+
+func TestBucketPolicyDuplicateSIDs(t *testing.T) {
+	statement := func(sid, action string) string {
+		return `{"Sid": "` + sid + `", "Effect": "Allow", "Principal": "*", "Action": "` + action + `", "Resource": "arn:aws:s3:::mybucket/*"}`
+	}
+	document := func(statements ...string) []byte {
+		data := `{"Version": "2012-10-17", "Statement": [`
+		for i, s := range statements {
+			if i > 0 {
+				data += ", "
+			}
+			data += s
+		}
+		return []byte(data + "]}")
+	}
+
+	testCases := []struct {
+		data        []byte
+		expectedErr bool
+	}{
+		{document(statement("A", "s3:GetObject"), statement("B", "s3:PutObject")), false},
+		// Statements without SID.
+		{document(statement("", "s3:GetObject"), statement("", "s3:PutObject")), false},
+		// Equal statements are dropped on decoding.
+		{document(statement("A", "s3:GetObject"), statement("A", "s3:GetObject")), false},
+		{document(statement("A", "s3:GetObject"), statement("A", "s3:PutObject")), true},
+		{document(statement("A", "s3:GetObject"), statement("", "s3:PutObject"), statement("A", "s3:DeleteObject")), true},
+	}
+
+	for i, testCase := range testCases {
+		// Decoding succeeds, to read existing configurations.
+		var policy BucketPolicy
+		if err := json.Unmarshal(testCase.data, &policy); err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+
+		err := policy.Validate("mybucket")
+		if expectErr := (err != nil); expectErr != testCase.expectedErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+		if _, perr := ParseBucketPolicyConfig(bytes.NewReader(testCase.data), "mybucket"); (perr != nil) != testCase.expectedErr {
+			t.Fatalf("case %v: parse error: expected: %v, got: %v", i+1, testCase.expectedErr, perr)
+		}
+		if !testCase.expectedErr {
+			continue
+		}
+		if !errors.Is(err, ErrDuplicateSID) || !strings.Contains(err.Error(), "'A'") {
+			t.Fatalf("case %v: expected: %v naming 'A', got: %v", i+1, ErrDuplicateSID, err)
+		}
+		if _, err = json.Marshal(policy); !errors.Is(err, ErrDuplicateSID) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, ErrDuplicateSID, err)
+		}
+
+		// IAM policies may share SIDs.
+		iamp, err := ParseConfig(bytes.NewReader(bytes.ReplaceAll(testCase.data, []byte(`"Principal": "*", `), nil)))
+		if err != nil || len(iamp.Statements) != len(policy.Statements) {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+	}
+}
+
+func TestBucketPolicyEnsureUniqueSIDs(t *testing.T) {
+	testCases := []struct {
+		sids            []ID
+		expectedSIDs    []ID
+		expectedRenamed int
+	}{
+		{nil, nil, 0},
+		{[]ID{"A", "B", ""}, []ID{"A", "B", ""}, 0},
+		{[]ID{"", ""}, []ID{"", ""}, 0},
+		{[]ID{"A", "A", "A"}, []ID{"A", "A2", "A3"}, 2},
+		// Suffixes do not collide with other SIDs.
+		{[]ID{"A", "A", "A2"}, []ID{"A", "A3", "A2"}, 1},
+		{[]ID{"A2", "A", "A", "A2"}, []ID{"A2", "A", "A3", "A22"}, 2},
+	}
+
+	for i, testCase := range testCases {
+		for run := 0; run < 2; run++ {
+			policy := BucketPolicy{Version: DefaultVersion}
+			for j, sid := range testCase.sids {
+				// Distinct statements, so that they are not dropped.
+				policy.Statements = append(policy.Statements, NewBPStatement(sid, Allow,
+					NewPrincipal("*"),
+					NewActionSet(GetObjectAction),
+					NewResourceSet(NewResource("mybucket/"+strconv.Itoa(j))),
+					nil))
+			}
+			if renamed := policy.EnsureUniqueSIDs(); renamed != testCase.expectedRenamed {
+				t.Fatalf("case %v: expected: %v renamed, got: %v", i+1, testCase.expectedRenamed, renamed)
+			}
+			var sids []ID
+			for _, statement := range policy.Statements {
+				sids = append(sids, statement.SID)
+			}
+			if !reflect.DeepEqual(sids, testCase.expectedSIDs) {
+				t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedSIDs, sids)
+			}
+			if err := policy.Validate("mybucket"); err != nil {
+				t.Fatalf("case %v: unexpected error: %v", i+1, err)
+			}
+			if renamed := policy.EnsureUniqueSIDs(); renamed != 0 {
+				t.Fatalf("case %v: expected no renamed statements, got: %v", i+1, renamed)
+			}
+		}
+	}
+}
@@ -0,0 +1,333 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package bundle exports and imports IAM policies and their attachments
+// in the layout of the AWS GetAccountAuthorizationDetails API, as emitted
+// by "aws iam get-account-authorization-details":
+//
+//	{
+//	  "UserDetailList": [{"UserName": "alice", "AttachedManagedPolicies": [{"PolicyName": "readonly", "PolicyArn": "..."}]}],
+//	  "GroupDetailList": [{"GroupName": "admins", "AttachedManagedPolicies": [...]}],
+//	  "RoleDetailList": [{"RoleName": "ci", "AttachedManagedPolicies": [...]}],
+//	  "Policies": [{
+//	    "PolicyName": "readonly",
+//	    "Arn": "arn:minio:iam:::policy/readonly",
+//	    "DefaultVersionId": "v1",
+//	    "PolicyVersionList": [{"Document": "%7B%22Version%22...", "VersionId": "v1", "IsDefaultVersion": true}]
+//	  }]
+//	}
+//
+// Policy documents are exported URL-encoded, as the AWS API returns them.
+// Imported documents may also be JSON objects, as the AWS CLI prints them,
+// or plain JSON strings. Other fields of the AWS output are ignored.
+package bundle
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/minio/pkg/v3/policy"
+)
+
+// Entity kinds, the prefixes of the entities of attachments, such as
+// "user/alice".
+const (
+	EntityUser  = "user"
+	EntityGroup = "group"
+	EntityRole  = "role"
+)
+
+// Bundle - policies and attachments imported from a bundle.
+type Bundle struct {
+	// Policies - imported policies, by name.
+	Policies map[string]policy.Policy
+
+	// Attachments - names of the imported policies attached to entities,
+	// by entity, such as "user/alice" or "group/admins".
+	Attachments map[string][]string
+
+	// Skipped - policies which are not imported, such as AWS managed
+	// policies and inline policies.
+	Skipped []Skipped
+}
+
+// Skipped - policy of a bundle which is not imported.
+type Skipped struct {
+	Name   string // policy name, or ARN of managed policies
+	Reason string
+}
+
+// document - policy document, see the package documentation.
+type document []byte
+
+// MarshalJSON - encodes the document as URL-encoded JSON string.
+func (d document) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.ReplaceAll(url.QueryEscape(string(d)), "+", "%20"))
+}
+
+// UnmarshalJSON - decodes a JSON object or a JSON string holding the,
+// possibly URL-encoded, JSON document.
+func (d *document) UnmarshalJSON(data []byte) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) {
+		*d = append((*d)[:0], data...)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(strings.TrimSpace(s), "{") {
+		decoded, err := url.QueryUnescape(s)
+		if err != nil {
+			return fmt.Errorf("invalid URL-encoded policy document: %w", err)
+		}
+		s = decoded
+	}
+	*d = document(s)
+	return nil
+}
+
+type attachedPolicy struct {
+	PolicyName string `json:"PolicyName"`
+	PolicyArn  string `json:"PolicyArn"`
+}
+
+type inlinePolicy struct {
+	PolicyName     string   `json:"PolicyName"`
+	PolicyDocument document `json:"PolicyDocument"`
+}
+
+type entityDetail struct {
+	UserName                string           `json:"UserName,omitempty"`
+	GroupName               string           `json:"GroupName,omitempty"`
+	RoleName                string           `json:"RoleName,omitempty"`
+	AttachedManagedPolicies []attachedPolicy `json:"AttachedManagedPolicies"`
+	UserPolicyList          []inlinePolicy   `json:"UserPolicyList,omitempty"`
+	GroupPolicyList         []inlinePolicy   `json:"GroupPolicyList,omitempty"`
+	RolePolicyList          []inlinePolicy   `json:"RolePolicyList,omitempty"`
+}
+
+type policyVersion struct {
+	Document         document `json:"Document"`
+	VersionID        string   `json:"VersionId"`
+	IsDefaultVersion bool     `json:"IsDefaultVersion"`
+}
+
+type managedPolicy struct {
+	PolicyName        string          `json:"PolicyName"`
+	Arn               string          `json:"Arn"`
+	DefaultVersionID  string          `json:"DefaultVersionId"`
+	AttachmentCount   int             `json:"AttachmentCount"`
+	IsAttachable      bool            `json:"IsAttachable"`
+	PolicyVersionList []policyVersion `json:"PolicyVersionList"`
+}
+
+// authorizationDetails - layout of a bundle.
+type authorizationDetails struct {
+	UserDetailList  []entityDetail  `json:"UserDetailList"`
+	GroupDetailList []entityDetail  `json:"GroupDetailList"`
+	RoleDetailList  []entityDetail  `json:"RoleDetailList"`
+	Policies        []managedPolicy `json:"Policies"`
+}
+
+// policyARN - returns the ARN of exported policies.
+func policyARN(name string) string {
+	return "arn:minio:iam:::policy/" + name
+}
+
+// isAWSManaged - checks whether arn is the ARN of an AWS managed policy,
+// such as "arn:aws:iam::aws:policy/ReadOnlyAccess".
+func isAWSManaged(arn string) bool {
+	fields := strings.SplitN(arn, ":", 6)
+	return len(fields) == 6 && fields[2] == "iam" && fields[4] == "aws"
+}
+
+// ExportBundle - returns the bundle of policies, by name, and their
+// attachments, the names of the policies attached to entities, by entity,
+// such as "user/alice", "group/admins" or "role/ci". All attached policies
+// must be exported.
+func ExportBundle(policies map[string]policy.Policy, attachments map[string][]string) ([]byte, error) {
+	details := authorizationDetails{
+		UserDetailList:  []entityDetail{},
+		GroupDetailList: []entityDetail{},
+		RoleDetailList:  []entityDetail{},
+		Policies:        []managedPolicy{},
+	}
+
+	counts := make(map[string]int, len(policies))
+	entities := make([]string, 0, len(attachments))
+	for entity := range attachments {
+		entities = append(entities, entity)
+	}
+	sort.Strings(entities)
+	for _, entity := range entities {
+		kind, name, _ := strings.Cut(entity, "/")
+		if name == "" {
+			return nil, fmt.Errorf("invalid entity '%v'", entity)
+		}
+
+		names := append([]string(nil), attachments[entity]...)
+		sort.Strings(names)
+		detail := entityDetail{AttachedManagedPolicies: make([]attachedPolicy, 0, len(names))}
+		for _, n := range names {
+			if _, ok := policies[n]; !ok {
+				return nil, fmt.Errorf("policy '%v' attached to '%v' is not exported", n, entity)
+			}
+			counts[n]++
+			detail.AttachedManagedPolicies = append(detail.AttachedManagedPolicies, attachedPolicy{PolicyName: n, PolicyArn: policyARN(n)})
+		}
+
+		switch kind {
+		case EntityUser:
+			detail.UserName = name
+			details.UserDetailList = append(details.UserDetailList, detail)
+		case EntityGroup:
+			detail.GroupName = name
+			details.GroupDetailList = append(details.GroupDetailList, detail)
+		case EntityRole:
+			detail.RoleName = name
+			details.RoleDetailList = append(details.RoleDetailList, detail)
+		default:
+			return nil, fmt.Errorf("invalid entity '%v'", entity)
+		}
+	}
+
+	names := make([]string, 0, len(policies))
+	for n := range policies {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		data, err := json.Marshal(policies[n])
+		if err != nil {
+			return nil, fmt.Errorf("policy '%v': %w", n, err)
+		}
+		details.Policies = append(details.Policies, managedPolicy{
+			PolicyName:       n,
+			Arn:              policyARN(n),
+			DefaultVersionID: "v1",
+			AttachmentCount:  counts[n],
+			IsAttachable:     true,
+			PolicyVersionList: []policyVersion{
+				{Document: data, VersionID: "v1", IsDefaultVersion: true},
+			},
+		})
+	}
+
+	return json.MarshalIndent(details, "", "  ")
+}
+
+// defaultVersion - returns the default version of p.
+func defaultVersion(p managedPolicy) (policyVersion, bool) {
+	for _, v := range p.PolicyVersionList {
+		if v.IsDefaultVersion {
+			return v, true
+		}
+	}
+	for _, v := range p.PolicyVersionList {
+		if p.DefaultVersionID != "" && v.VersionID == p.DefaultVersionID {
+			return v, true
+		}
+	}
+	return policyVersion{}, false
+}
+
+// ImportBundle - decodes the policies and attachments of a bundle, as
+// written by ExportBundle or "aws iam get-account-authorization-details".
+// The default version of each policy is imported. AWS managed policies,
+// inline policies, which MinIO does not support, and invalid policies are
+// reported as skipped, as are their attachments.
+func ImportBundle(data []byte) (Bundle, error) {
+	var details authorizationDetails
+	if err := json.Unmarshal(data, &details); err != nil {
+		return Bundle{}, fmt.Errorf("invalid policy bundle: %w", err)
+	}
+
+	bundle := Bundle{
+		Policies:    map[string]policy.Policy{},
+		Attachments: map[string][]string{},
+	}
+	skipped := map[string]bool{}
+	skip := func(name, reason string) {
+		if !skipped[name] {
+			skipped[name] = true
+			bundle.Skipped = append(bundle.Skipped, Skipped{Name: name, Reason: reason})
+		}
+	}
+
+	for _, p := range details.Policies {
+		if p.PolicyName == "" {
+			return Bundle{}, errors.New("invalid policy bundle: policy without name")
+		}
+		if isAWSManaged(p.Arn) {
+			skip(p.Arn, "AWS managed policy")
+			continue
+		}
+		if _, ok := bundle.Policies[p.PolicyName]; ok {
+			return Bundle{}, fmt.Errorf("invalid policy bundle: duplicate policy '%v'", p.PolicyName)
+		}
+		v, ok := defaultVersion(p)
+		if !ok {
+			skip(p.PolicyName, "no default version")
+			continue
+		}
+		iamp, err := policy.ParseConfigWithOptions(bytes.NewReader(v.Document), policy.ValidationOptions{AllowLegacyDocuments: true})
+		if err != nil {
+			skip(p.PolicyName, err.Error())
+			continue
+		}
+		bundle.Policies[p.PolicyName] = *iamp
+	}
+
+	entities := []struct {
+		kind    string
+		details []entityDetail
+	}{
+		{EntityUser, details.UserDetailList},
+		{EntityGroup, details.GroupDetailList},
+		{EntityRole, details.RoleDetailList},
+	}
+	for _, e := range entities {
+		for _, detail := range e.details {
+			name := detail.UserName + detail.GroupName + detail.RoleName
+			entity := e.kind + "/" + name
+			for _, inline := range slices.Concat(detail.UserPolicyList, detail.GroupPolicyList, detail.RolePolicyList) {
+				skip(entity+"/"+inline.PolicyName, "inline policy")
+			}
+			for _, attached := range detail.AttachedManagedPolicies {
+				if isAWSManaged(attached.PolicyArn) {
+					skip(attached.PolicyArn, "AWS managed policy")
+					continue
+				}
+				if _, ok := bundle.Policies[attached.PolicyName]; !ok {
+					skip(attached.PolicyName, "attached policy is not imported")
+					continue
+				}
+				bundle.Attachments[entity] = append(bundle.Attachments[entity], attached.PolicyName)
+			}
+			sort.Strings(bundle.Attachments[entity])
+		}
+	}
+	return bundle, nil
+}
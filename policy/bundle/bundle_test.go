@@ -0,0 +1,202 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundle
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/minio/pkg/v3/policy"
+)
+
+func mustParsePolicy(t *testing.T, data string) policy.Policy {
+	t.Helper()
+	p, err := policy.ParseConfig(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return *p
+}
+
+func TestBundleRoundTrip(t *testing.T) {
+	policies := map[string]policy.Policy{
+		"readonly": policy.DefaultPolicies[1].Definition,
+		"home": mustParsePolicy(t, `{
+  "Version": "2012-10-17",
+  "Statement": [{
+    "Effect": "Allow",
+    "Action": ["s3:GetObject", "s3:PutObject"],
+    "Resource": "arn:aws:s3:::home/${aws:username}/*",
+    "Condition": {"StringEquals": {"aws:UserAgent": "tool/1.0 (a+b & 100%)"}}
+  }]
+}`),
+		"unattached": policy.DefaultPolicies[2].Definition,
+	}
+	attachments := map[string][]string{
+		"user/alice":   {"readonly", "home"},
+		"group/admins": {"home"},
+		"role/ci":      {"readonly"},
+	}
+
+	data, err := ExportBundle(policies, attachments)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Documents are URL-encoded strings.
+	if strings.Contains(string(data), `"Document": {`) || !strings.Contains(string(data), `"Document": "%7B%22`) {
+		t.Fatalf("expected URL-encoded documents, got: %s", data)
+	}
+
+	bundle, err := ImportBundle(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bundle.Policies) != len(policies) {
+		t.Fatalf("expected: %v, got: %v", len(policies), len(bundle.Policies))
+	}
+	for name, p := range policies {
+		if imported := bundle.Policies[name]; !imported.Equals(p) {
+			t.Fatalf("%v: expected: %v, got: %v", name, p, imported)
+		}
+	}
+	expectedAttachments := map[string][]string{
+		"user/alice":   {"home", "readonly"},
+		"group/admins": {"home"},
+		"role/ci":      {"readonly"},
+	}
+	if !reflect.DeepEqual(bundle.Attachments, expectedAttachments) {
+		t.Fatalf("expected: %v, got: %v", expectedAttachments, bundle.Attachments)
+	}
+	if len(bundle.Skipped) != 0 {
+		t.Fatalf("expected no skipped policies, got: %v", bundle.Skipped)
+	}
+
+	// Exports are deterministic.
+	if again, err := ExportBundle(bundle.Policies, bundle.Attachments); err != nil || string(again) != string(data) {
+		t.Fatalf("expected: %s, got: %s, %v", data, again, err)
+	}
+}
+
+func TestExportBundleErrors(t *testing.T) {
+	policies := map[string]policy.Policy{"readonly": policy.DefaultPolicies[1].Definition}
+	testCases := []map[string][]string{
+		{"user/alice": {"missing"}},
+		{"alice": {"readonly"}},
+		{"user/": {"readonly"}},
+		{"account/alice": {"readonly"}},
+	}
+
+	for i, attachments := range testCases {
+		if _, err := ExportBundle(policies, attachments); err == nil {
+			t.Fatalf("case %v: expected an error", i+1)
+		}
+	}
+}
+
+func TestImportBundleAWS(t *testing.T) {
+	data, err := os.ReadFile("testdata/get-account-authorization-details.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle, err := ImportBundle(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	home := mustParsePolicy(t, `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Sid": "ListHome", "Effect": "Allow", "Action": "s3:ListBucket", "Resource": "arn:aws:s3:::home", "Condition": {"StringLike": {"s3:prefix": "${aws:username}/*"}}},
+    {"Sid": "ReadWriteHome", "Effect": "Allow", "Action": ["s3:GetObject", "s3:PutObject", "s3:DeleteObject"], "Resource": "arn:aws:s3:::home/${aws:username}/*"}
+  ]
+}`)
+	backup := mustParsePolicy(t, `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:PutObject", "s3:AbortMultipartUpload"], "Resource": "arn:aws:s3:::backups/*"}]}`)
+	imported, importedBackup := bundle.Policies["home-directory"], bundle.Policies["backup-writer"]
+	if len(bundle.Policies) != 2 || !imported.Equals(home) || !importedBackup.Equals(backup) {
+		t.Fatalf("unexpected policies: %v", bundle.Policies)
+	}
+
+	expectedAttachments := map[string][]string{
+		"user/alice":       {"home-directory"},
+		"user/backup":      {"backup-writer"},
+		"group/developers": {"home-directory"},
+		"role/ci":          {"backup-writer"},
+	}
+	if !reflect.DeepEqual(bundle.Attachments, expectedAttachments) {
+		t.Fatalf("expected: %v, got: %v", expectedAttachments, bundle.Attachments)
+	}
+
+	var skipped []string
+	for _, s := range bundle.Skipped {
+		skipped = append(skipped, s.Name)
+	}
+	expectedSkipped := []string{"arn:aws:iam::aws:policy/ReadOnlyAccess", "ec2-describe", "user/backup/backup-inline"}
+	if !reflect.DeepEqual(skipped, expectedSkipped) {
+		t.Fatalf("expected: %v, got: %v", expectedSkipped, bundle.Skipped)
+	}
+}
+
+func TestImportBundleDocuments(t *testing.T) {
+	doc := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"arn:aws:s3:::a b/*"}]}`
+	encoded, err := json.Marshal(document(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		document  string
+		versions  string
+		expectErr bool
+		skipped   bool
+	}{
+		{doc, "", false, false},
+		{string(encoded), "", false, false},
+		{string(plain), "", false, false},
+		{`"%7B%ZZ"`, "", true, false},
+		{doc, `"DefaultVersionId": "v3", "PolicyVersionList": [{"Document": {}, "VersionId": "v2"}, {"Document": ` + doc + `, "VersionId": "v3"}]`, false, false},
+		{doc, `"DefaultVersionId": "v4", "PolicyVersionList": [{"Document": ` + doc + `, "VersionId": "v3"}]`, false, true},
+	}
+
+	for i, testCase := range testCases {
+		versions := testCase.versions
+		if versions == "" {
+			versions = `"PolicyVersionList": [{"Document": ` + testCase.document + `, "VersionId": "v1", "IsDefaultVersion": true}]`
+		}
+		data := `{"Policies": [{"PolicyName": "p", "Arn": "arn:aws:iam::123456789012:policy/p", ` + versions + `}]}`
+		bundle, err := ImportBundle([]byte(data))
+		if expectErr := err != nil; expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if testCase.expectErr {
+			continue
+		}
+		if skipped := len(bundle.Skipped) != 0; skipped != testCase.skipped {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.skipped, bundle.Skipped)
+		}
+		if !testCase.skipped && bundle.Policies["p"].Statements[0].Resources.String() != "[arn:aws:s3:::a b/*]" {
+			t.Fatalf("case %v: unexpected policy: %v", i+1, bundle.Policies["p"])
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+// BucketPrefix - a bucket, or the objects of a bucket under a prefix if
+// Prefix is not empty, to generate a policy for.
+type BucketPrefix struct {
+	Bucket string
+	Prefix string
+}
+
+// cannedListActions - actions to list the objects of a bucket, scoped to a
+// prefix by the s3:prefix condition.
+var cannedListActions = []Action{
+	ListBucketAction,
+	ListBucketVersionsAction,
+}
+
+// cannedReadActions - actions to read objects along with their versions,
+// tags and attributes.
+var cannedReadActions = []Action{
+	GetObjectAction,
+	GetObjectVersionAction,
+	GetObjectTaggingAction,
+	GetObjectVersionTaggingAction,
+	GetObjectAttributesAction,
+	GetObjectVersionAttributesAction,
+	GetObjectRetentionAction,
+	GetObjectLegalHoldAction,
+}
+
+// cannedWriteActions - actions to upload objects and tag them.
+var cannedWriteActions = []Action{
+	PutObjectAction,
+	PutObjectTaggingAction,
+	PutObjectVersionTaggingAction,
+	AbortMultipartUploadAction,
+	ListMultipartUploadPartsAction,
+}
+
+// cannedDeleteActions - actions to delete objects, their versions and
+// tags.
+var cannedDeleteActions = []Action{
+	DeleteObjectAction,
+	DeleteObjectVersionAction,
+	DeleteObjectTaggingAction,
+	DeleteObjectVersionTaggingAction,
+}
+
+// cannedAccess - access granted by a generated policy.
+type cannedAccess struct {
+	list, read, write, delete bool
+}
+
+// NewReadOnlyPolicy - returns a policy allowing to list and read the
+// objects of the given targets, including object versions, tags and
+// attributes.
+func NewReadOnlyPolicy(targets ...BucketPrefix) Policy {
+	return newCannedPolicy(cannedAccess{list: true, read: true}, targets)
+}
+
+// NewReadWritePolicy - returns a policy allowing to list, read, write and
+// delete the objects of the given targets, including object versions,
+// tags and attributes.
+func NewReadWritePolicy(targets ...BucketPrefix) Policy {
+	return newCannedPolicy(cannedAccess{list: true, read: true, write: true, delete: true}, targets)
+}
+
+// NewWriteOnlyPolicy - returns a policy allowing to upload objects to the
+// given targets, without listing or reading them.
+func NewWriteOnlyPolicy(targets ...BucketPrefix) Policy {
+	return newCannedPolicy(cannedAccess{write: true}, targets)
+}
+
+// NewListOnlyPolicy - returns a policy allowing to list the objects of the
+// given targets, without reading them.
+func NewListOnlyPolicy(targets ...BucketPrefix) Policy {
+	return newCannedPolicy(cannedAccess{list: true}, targets)
+}
+
+// newCannedPolicy - returns a policy granting access to targets. Targets
+// are sorted and duplicates dropped so that the generated policy does not
+// depend on the order of targets. Listing with a prefix is allowed only
+// for prefixes under the target prefix, listing its parent "directories"
+// requires an additional policy.
+func newCannedPolicy(access cannedAccess, targets []BucketPrefix) Policy {
+	targets = slices.Clone(targets)
+	slices.SortFunc(targets, func(a, b BucketPrefix) int {
+		if c := strings.Compare(a.Bucket, b.Bucket); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Prefix, b.Prefix)
+	})
+	targets = slices.Compact(targets)
+
+	p := Policy{Version: DefaultVersion}
+	for _, target := range targets {
+		bucketResources := NewResourceSet(NewResource(target.Bucket))
+		objectResources := NewResourceSet(NewResource(target.Bucket + "/" + target.Prefix + "*"))
+
+		// Clients look up the bucket region before any other request.
+		p.Statements = append(p.Statements, NewStatement("", Allow,
+			NewActionSet(GetBucketLocationAction), bucketResources, condition.NewFunctions()))
+
+		if access.list {
+			conditions := condition.NewFunctions()
+			if target.Prefix != "" {
+				// StringLike on s3:prefix accepts any value.
+				f, _ := condition.NewStringLikeFunc("", condition.S3Prefix.ToKey(), target.Prefix+"*")
+				conditions = condition.NewFunctions(f)
+			}
+			p.Statements = append(p.Statements, NewStatement("", Allow,
+				NewActionSet(cannedListActions...), bucketResources, conditions))
+		}
+
+		var objectActions []Action
+		if access.read {
+			objectActions = append(objectActions, cannedReadActions...)
+		}
+		if access.write {
+			objectActions = append(objectActions, cannedWriteActions...)
+			if access.list && target.Prefix == "" {
+				// Multipart uploads cannot be listed for a prefix only.
+				p.Statements = append(p.Statements, NewStatement("", Allow,
+					NewActionSet(ListBucketMultipartUploadsAction), bucketResources, condition.NewFunctions()))
+			}
+		}
+		if access.delete {
+			objectActions = append(objectActions, cannedDeleteActions...)
+		}
+		if len(objectActions) > 0 {
+			p.Statements = append(p.Statements, NewStatement("", Allow,
+				NewActionSet(objectActions...), objectResources, condition.NewFunctions()))
+		}
+	}
+
+	p.dropDuplicateStatements()
+	return p
+}
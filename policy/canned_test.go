@@ -0,0 +1,125 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestCannedPolicies(t *testing.T) {
+	targets := []BucketPrefix{{Bucket: "photos", Prefix: "2024/"}, {Bucket: "docs"}}
+	policies := map[string]Policy{
+		"readonly":  NewReadOnlyPolicy(targets...),
+		"readwrite": NewReadWritePolicy(targets...),
+		"writeonly": NewWriteOnlyPolicy(targets...),
+		"listonly":  NewListOnlyPolicy(targets...),
+	}
+
+	list := func(bucket, prefix string) Args {
+		return Args{Action: ListBucketAction, BucketName: bucket, ConditionValues: map[string][]string{"prefix": {prefix}}}
+	}
+	object := func(action Action, bucket, object string) Args {
+		return Args{Action: action, BucketName: bucket, ObjectName: object}
+	}
+
+	testCases := []struct {
+		args Args
+		// allowed by readonly, readwrite, writeonly and listonly
+		expected [4]bool
+	}{
+		{Args{Action: GetBucketLocationAction, BucketName: "photos"}, [4]bool{true, true, true, true}},
+		{Args{Action: GetBucketLocationAction, BucketName: "other"}, [4]bool{}},
+		{list("photos", "2024/"), [4]bool{true, true, false, true}},
+		{list("photos", "2024/01/"), [4]bool{true, true, false, true}},
+		{list("photos", ""), [4]bool{}},
+		{list("photos", "2023/"), [4]bool{}},
+		{list("docs", ""), [4]bool{true, true, false, true}},
+		{list("other", ""), [4]bool{}},
+		{Args{Action: ListBucketVersionsAction, BucketName: "docs"}, [4]bool{true, true, false, true}},
+		{Args{Action: ListBucketMultipartUploadsAction, BucketName: "docs"}, [4]bool{false, true, false, false}},
+		{Args{Action: ListBucketMultipartUploadsAction, BucketName: "photos"}, [4]bool{}},
+		{object(GetObjectAction, "photos", "2024/a.jpg"), [4]bool{true, true, false, false}},
+		{object(GetObjectVersionAction, "photos", "2024/a.jpg"), [4]bool{true, true, false, false}},
+		{object(GetObjectTaggingAction, "docs", "a.pdf"), [4]bool{true, true, false, false}},
+		{object(GetObjectAttributesAction, "docs", "a.pdf"), [4]bool{true, true, false, false}},
+		{object(GetObjectAction, "photos", "2023/a.jpg"), [4]bool{}},
+		{object(GetObjectAction, "other", "a.pdf"), [4]bool{}},
+		{object(PutObjectAction, "photos", "2024/a.jpg"), [4]bool{false, true, true, false}},
+		{object(PutObjectTaggingAction, "docs", "a.pdf"), [4]bool{false, true, true, false}},
+		{object(AbortMultipartUploadAction, "docs", "a.pdf"), [4]bool{false, true, true, false}},
+		{object(PutObjectAction, "photos", "2023/a.jpg"), [4]bool{}},
+		{object(DeleteObjectAction, "photos", "2024/a.jpg"), [4]bool{false, true, false, false}},
+		{object(DeleteObjectVersionAction, "docs", "a.pdf"), [4]bool{false, true, false, false}},
+		{object(DeleteObjectAction, "photos", "2023/a.jpg"), [4]bool{}},
+		{object(PutBucketPolicyAction, "docs", ""), [4]bool{}},
+	}
+
+	for name, p := range policies {
+		if err := p.Validate(); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+	}
+	for i, testCase := range testCases {
+		for j, name := range []string{"readonly", "readwrite", "writeonly", "listonly"} {
+			if got := policies[name].IsAllowed(testCase.args); got != testCase.expected[j] {
+				t.Fatalf("case %v: %s: expected: %v, got: %v", i+1, name, testCase.expected[j], got)
+			}
+		}
+	}
+}
+
+func TestCannedPoliciesCanonical(t *testing.T) {
+	p := NewListOnlyPolicy(BucketPrefix{Bucket: "photos", Prefix: "2024/"})
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"Version":"2012-10-17","Statement":[` +
+		`{"Effect":"Allow","Action":["s3:GetBucketLocation"],"Resource":["arn:aws:s3:::photos"]},` +
+		`{"Effect":"Allow","Action":["s3:ListBucket","s3:ListBucketVersions"],"Resource":["arn:aws:s3:::photos"],"Condition":{"StringLike":{"s3:prefix":["2024/*"]}}}]}`
+	if string(data) != expected {
+		t.Fatalf("expected: %s, got: %s", expected, data)
+	}
+
+	targets := []BucketPrefix{{Bucket: "b", Prefix: "x/"}, {Bucket: "a"}, {Bucket: "b", Prefix: "y/"}, {Bucket: "a"}}
+	reordered := []BucketPrefix{{Bucket: "b", Prefix: "y/"}, {Bucket: "a"}, {Bucket: "b", Prefix: "x/"}}
+	for _, newPolicy := range []func(...BucketPrefix) Policy{NewReadOnlyPolicy, NewReadWritePolicy, NewWriteOnlyPolicy, NewListOnlyPolicy} {
+		data1, err := json.Marshal(newPolicy(targets...))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		data2, err := json.Marshal(newPolicy(reordered...))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !bytes.Equal(data1, data2) {
+			t.Fatalf("expected: %s, got: %s", data1, data2)
+		}
+
+		// Parsing the generated policy must yield the same policy.
+		p, err := ParseConfig(bytes.NewReader(data1))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !p.Equals(newPolicy(targets...)) {
+			t.Fatalf("expected: %s, got: %v", data1, p)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// FlattenClaims converts JWT claims into condition values. As with all
+// condition keys the "jwt:" prefix is stripped, i.e. the values of claim
+// "groups" are evaluated for the condition key "jwt:groups".
+//
+//   - strings are used as is
+//   - numbers are formatted without exponent, e.g. 1594690452
+//   - booleans are formatted as "true" or "false"
+//   - arrays, including nested arrays, of the above are flattened into
+//     a list of values
+//   - objects are flattened one level deep using dotted keys, e.g.
+//     "address.country" for the condition key "jwt:address.country"
+//
+// Claims of any other type are skipped, a warning is returned for each of
+// them. The result does not depend on map iteration order.
+func FlattenClaims(claims map[string]interface{}) (map[string][]string, []string) {
+	values := make(map[string][]string, len(claims))
+	var warnings []string
+
+	names := make([]string, 0, len(claims))
+	for name := range claims {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if nested, ok := claims[name].(map[string]interface{}); ok {
+			keys := make([]string, 0, len(nested))
+			for k := range nested {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				if _, ok := nested[k].(map[string]interface{}); ok {
+					warnings = append(warnings, fmt.Sprintf("claim '%s.%s': nested objects are not supported", name, k))
+					continue
+				}
+				flattenClaim(values, &warnings, name+"."+k, nested[k])
+			}
+			continue
+		}
+		flattenClaim(values, &warnings, name, claims[name])
+	}
+
+	return values, warnings
+}
+
+func flattenClaim(values map[string][]string, warnings *[]string, name string, v interface{}) {
+	claimValues, err := claimToStrings(v)
+	if err != nil {
+		*warnings = append(*warnings, fmt.Sprintf("claim '%s': %v", name, err))
+		return
+	}
+	values[name] = claimValues
+}
+
+// claimToStrings - converts a scalar claim or an array of scalar claims
+// to a list of condition values.
+func claimToStrings(v interface{}) ([]string, error) {
+	switch v := v.(type) {
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			evalues, err := claimToStrings(e)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, evalues...)
+		}
+		return values, nil
+	case string:
+		return []string{v}, nil
+	case bool:
+		return []string{strconv.FormatBool(v)}, nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("unsupported number %v", v)
+		}
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}, nil
+	case float32:
+		return claimToStrings(float64(v))
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return []string{v.String()}, nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("unsupported number %v", v)
+		}
+		return claimToStrings(f)
+	case int:
+		return []string{strconv.Itoa(v)}, nil
+	case int64:
+		return []string{strconv.FormatInt(v, 10)}, nil
+	case uint64:
+		return []string{strconv.FormatUint(v, 10)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %T", v)
+	}
+}
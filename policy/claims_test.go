@@ -0,0 +1,181 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// claimsFixture is a real world set of OIDC claims.
+const claimsFixture = `{
+  "exp": 1594690452,
+  "iat": 1594689552,
+  "auth_time": 1594689552,
+  "jti": "18ed05c9-2c69-45d5-a33f-8c94aca99ad5",
+  "iss": "http://localhost:8080/auth/realms/minio",
+  "aud": "account",
+  "sub": "7e5e2f30-1c97-4616-8623-2eae14dee9b1",
+  "typ": "ID",
+  "azp": "account",
+  "nonce": "66ZoLzwJbjdkiedI",
+  "session_state": "3df7b526-5310-4038-9f35-50ecd295a31d",
+  "acr": "1",
+  "upn": "harsha",
+  "address": {},
+  "email_verified": false,
+  "groups": [
+    "offline_access"
+  ],
+  "preferred_username": "harsha",
+  "policy": [
+    "readwrite",
+    "readwrite,readonly",
+    "  readonly",
+    ""
+  ]}`
+
+func TestFlattenClaims(t *testing.T) {
+	testCases := []struct {
+		claims           string
+		expectedResult   map[string][]string
+		expectedWarnings []string
+	}{
+		{claimsFixture, map[string][]string{
+			"exp":                {"1594690452"},
+			"iat":                {"1594689552"},
+			"auth_time":          {"1594689552"},
+			"jti":                {"18ed05c9-2c69-45d5-a33f-8c94aca99ad5"},
+			"iss":                {"http://localhost:8080/auth/realms/minio"},
+			"aud":                {"account"},
+			"sub":                {"7e5e2f30-1c97-4616-8623-2eae14dee9b1"},
+			"typ":                {"ID"},
+			"azp":                {"account"},
+			"nonce":              {"66ZoLzwJbjdkiedI"},
+			"session_state":      {"3df7b526-5310-4038-9f35-50ecd295a31d"},
+			"acr":                {"1"},
+			"upn":                {"harsha"},
+			"email_verified":     {"false"},
+			"groups":             {"offline_access"},
+			"preferred_username": {"harsha"},
+			"policy":             {"readwrite", "readwrite,readonly", "  readonly", ""},
+		}, nil},
+		{`{
+  "exp": 1.594690452e+09,
+  "ratio": 0.25,
+  "big": 1e21,
+  "roles": [["admin", "dev"], ["ops"], 7, true],
+  "address": {"country": "DE", "floors": [1, 2], "geo": {"lat": 52.5}},
+  "empty": null,
+  "objects": [{"a": "b"}]
+}`, map[string][]string{
+			"exp":             {"1594690452"},
+			"ratio":           {"0.25"},
+			"big":             {"1000000000000000000000"},
+			"roles":           {"admin", "dev", "ops", "7", "true"},
+			"address.country": {"DE"},
+			"address.floors":  {"1", "2"},
+		}, []string{
+			"claim 'address.geo': nested objects are not supported",
+			"claim 'empty': unsupported type <nil>",
+			"claim 'objects': unsupported type map[string]interface {}",
+		}},
+	}
+
+	for i, testCase := range testCases {
+		var claims map[string]interface{}
+		if err := json.Unmarshal([]byte(testCase.claims), &claims); err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+
+		result, warnings := FlattenClaims(claims)
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+		if !reflect.DeepEqual(warnings, testCase.expectedWarnings) {
+			t.Fatalf("case %v: warnings: expected: %v, got: %v", i+1, testCase.expectedWarnings, warnings)
+		}
+	}
+}
+
+func TestFlattenClaimsUseNumber(t *testing.T) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(`{"exp": 1594690452, "ratio": 2.5e-1, "big": 1e21}`)))
+	decoder.UseNumber()
+	var claims map[string]interface{}
+	if err := decoder.Decode(&claims); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedResult := map[string][]string{
+		"exp":   {"1594690452"},
+		"ratio": {"0.25"},
+		"big":   {"1000000000000000000000"},
+	}
+	result, warnings := FlattenClaims(claims)
+	if !reflect.DeepEqual(result, expectedResult) || len(warnings) != 0 {
+		t.Fatalf("expected: %v, got: %v %v", expectedResult, result, warnings)
+	}
+}
+
+func TestPolicyIsAllowedFlattenedClaims(t *testing.T) {
+	p, err := ParseConfig(bytes.NewReader([]byte(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Action": ["s3:GetObject"],
+            "Resource": ["arn:aws:s3:::${jwt:preferred_username}/*"],
+            "Condition": {
+                "StringEquals": {"jwt:groups": "offline_access", "jwt:upn": "harsha"}
+            }
+        }
+    ]
+}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var claims map[string]interface{}
+	if err = json.Unmarshal([]byte(claimsFixture), &claims); err != nil {
+		t.Fatal(err)
+	}
+	conditionValues, _ := FlattenClaims(claims)
+
+	testCases := []struct {
+		bucketName     string
+		expectedResult bool
+	}{
+		{"harsha", true},
+		{"john", false},
+	}
+
+	for i, testCase := range testCases {
+		result := p.IsAllowed(Args{
+			Action:          GetObjectAction,
+			BucketName:      testCase.bucketName,
+			ObjectName:      "myobject",
+			ConditionValues: conditionValues,
+		})
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
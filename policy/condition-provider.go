@@ -0,0 +1,139 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+// ConditionValueProvider - returns the values of condition keys which are
+// expensive to compute, such as object tags or geo-IP lookups. Keys are
+// named as in Args.ConditionValues, e.g. "ExistingObjectTag/team", keys
+// without value may be omitted from the result.
+type ConditionValueProvider func(ctx context.Context, keys []string) (map[string][]string, error)
+
+// resourceVariables - returns the names of the policy variables used in
+// the resource pattern, named as in condition values.
+func (r Resource) resourceVariables() []string {
+	var names []string
+	remain := r.Pattern
+	for {
+		i := strings.Index(remain, "${")
+		if i < 0 {
+			return names
+		}
+		remain = remain[i+2:]
+		j := strings.IndexByte(remain, '}')
+		if j < 0 {
+			return names
+		}
+		if key := condition.KeyName(remain[:j]); isResourceVariable(key) {
+			names = append(names, key.Name())
+		}
+		remain = remain[j+1:]
+	}
+}
+
+// ReferencedConditionKeys - returns the sorted names of the condition keys
+// used by the conditions and the resource policy variables of this
+// statement, named as in Args.ConditionValues.
+func (statement Statement) ReferencedConditionKeys() []string {
+	names := make(map[string]struct{})
+	for key := range statement.Conditions.Keys() {
+		names[key.Name()] = struct{}{}
+	}
+	for resource := range statement.Resources {
+		for _, name := range resource.resourceVariables() {
+			names[name] = struct{}{}
+		}
+	}
+
+	keys := make([]string, 0, len(names))
+	for name := range names {
+		keys = append(keys, name)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// missingConditionKeys - adds the names of the keys referenced by this
+// statement without value in conditionValues to missing.
+func (statement Statement) missingConditionKeys(conditionValues map[string][]string, missing map[string]struct{}) {
+	for key := range statement.Conditions.MissingKeys(conditionValues) {
+		missing[key.Name()] = struct{}{}
+	}
+	for resource := range statement.Resources {
+		for _, name := range resource.resourceVariables() {
+			if len(conditionValues[name]) == 0 {
+				missing[name] = struct{}{}
+			}
+		}
+	}
+}
+
+// provideConditionValues - adds the values of the keys referenced by the
+// statements applying to the action of args, and missing in its condition
+// values, from args.ConditionValueProvider. The provider is called at most
+// once, for all missing keys, and then removed from args.
+func (iamp Policy) provideConditionValues(ctx context.Context, args *Args) error {
+	provider := args.ConditionValueProvider
+	args.ConditionValueProvider = nil
+
+	missing := make(map[string]struct{})
+	for _, statement := range iamp.Statements {
+		if statement.matchAction(args.Action) {
+			statement.missingConditionKeys(args.ConditionValues, missing)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(missing))
+	for name := range missing {
+		keys = append(keys, name)
+	}
+	sort.Strings(keys)
+
+	provided, err := provider(ctx, keys)
+	if err != nil {
+		return err
+	}
+	args.ConditionValues = withDefaults(args.ConditionValues, provided)
+	return nil
+}
+
+// IsAllowedCtx - same as IsAllowed, with the condition values referenced
+// by the policy but missing in args fetched by args.ConditionValueProvider
+// first, if set. The provider is only called if a statement applying to
+// the action of args references a missing key. If the provider fails the
+// request is denied, whatever the statements referencing the keys are.
+func (iamp Policy) IsAllowedCtx(ctx context.Context, args Args) bool {
+	if args.ConditionValueProvider != nil {
+		args.NormalizeConditions()
+		if err := iamp.provideConditionValues(ctx, &args); err != nil {
+			return false
+		}
+	}
+
+	return iamp.IsAllowed(args)
+}
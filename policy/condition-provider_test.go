@@ -0,0 +1,165 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStatementReferencedConditionKeys(t *testing.T) {
+	p, err := ParseConfig(strings.NewReader(`{"Version": "2012-10-17", "Statement": [
+		{"Effect": "Allow", "Action": "s3:GetObject", "Resource": ["arn:aws:s3:::${aws:username}/${jwt:sub}/*", "arn:aws:s3:::b/${unknown}"],
+		 "Condition": {"StringEquals": {"s3:ExistingObjectTag/team": "eng", "aws:username": "john"}, "IpAddress": {"aws:SourceIp": "10.0.0.0/8"}}},
+		{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::b/*"}
+	]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"ExistingObjectTag/team", "SourceIp", "sub", "username"}
+	if got := p.Statements[0].ReferencedConditionKeys(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected: %v, got: %v", expected, got)
+	}
+	if got := p.Statements[1].ReferencedConditionKeys(); len(got) != 0 {
+		t.Fatalf("expected no keys, got: %v", got)
+	}
+}
+
+type providerContextKey struct{}
+
+type countingProvider struct {
+	calls  int
+	keys   [][]string
+	values map[string][]string
+	err    error
+}
+
+func (c *countingProvider) provide(ctx context.Context, keys []string) (map[string][]string, error) {
+	if ctx.Value(providerContextKey{}) == nil {
+		return nil, errors.New("context not passed")
+	}
+	c.calls++
+	c.keys = append(c.keys, keys)
+	if c.err != nil {
+		return nil, c.err
+	}
+	values := make(map[string][]string)
+	for _, key := range keys {
+		if v, ok := c.values[key]; ok {
+			values[key] = v
+		}
+	}
+	return values, nil
+}
+
+func TestPolicyIsAllowedCtx(t *testing.T) {
+	tagged, err := ParseConfig(strings.NewReader(`{"Version": "2012-10-17", "Statement": [
+		{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/${aws:username}/*",
+		 "Condition": {"StringEquals": {"s3:ExistingObjectTag/team": "eng"}}},
+		{"Effect": "Deny", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*",
+		 "Condition": {"StringEquals": {"s3:ExistingObjectTag/classification": "secret"}}},
+		{"Effect": "Allow", "Action": "s3:PutObject", "Resource": "arn:aws:s3:::mybucket/*",
+		 "Condition": {"IpAddress": {"aws:SourceIp": "10.0.0.0/8"}}}
+	]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	unconditional := NewReadWritePolicy(BucketPrefix{Bucket: "mybucket"})
+
+	ctx := context.WithValue(context.Background(), providerContextKey{}, true)
+	testCases := []struct {
+		policy          Policy
+		action          Action
+		conditionValues map[string][]string
+		provided        map[string][]string
+		providerErr     error
+		expected        bool
+		expectedKeys    [][]string
+	}{
+		// Single call for the union of the missing keys.
+		{
+			*tagged, GetObjectAction, nil,
+			map[string][]string{"ExistingObjectTag/team": {"eng"}, "username": {"john"}},
+			nil, true,
+			[][]string{{"ExistingObjectTag/classification", "ExistingObjectTag/team", "username"}},
+		},
+		{
+			*tagged, GetObjectAction, nil,
+			map[string][]string{"ExistingObjectTag/team": {"eng"}, "ExistingObjectTag/classification": {"secret"}, "username": {"john"}},
+			nil, false,
+			[][]string{{"ExistingObjectTag/classification", "ExistingObjectTag/team", "username"}},
+		},
+		// Keys present in the condition values are not fetched.
+		{
+			*tagged, GetObjectAction, map[string][]string{"username": {"john"}, "ExistingObjectTag/classification": {"public"}},
+			map[string][]string{"ExistingObjectTag/team": {"eng"}, "username": {"jane"}},
+			nil, true,
+			[][]string{{"ExistingObjectTag/team"}},
+		},
+		// Nothing is fetched if all keys are present.
+		{
+			*tagged, GetObjectAction,
+			map[string][]string{"username": {"john"}, "ExistingObjectTag/team": {"ops"}, "ExistingObjectTag/classification": {"public"}},
+			nil, nil, false, nil,
+		},
+		// Only statements applying to the action are considered.
+		{
+			*tagged, PutObjectAction, nil,
+			map[string][]string{"SourceIp": {"10.1.2.3"}},
+			nil, true,
+			[][]string{{"SourceIp"}},
+		},
+		{*tagged, DeleteObjectAction, nil, nil, nil, false, nil},
+		// Nothing is fetched without conditions.
+		{unconditional, GetObjectAction, nil, nil, nil, true, nil},
+		// Provider errors deny the request.
+		{
+			*tagged, PutObjectAction, nil, nil,
+			errors.New("lookup failed"), false,
+			[][]string{{"SourceIp"}},
+		},
+	}
+
+	for i, testCase := range testCases {
+		provider := &countingProvider{values: testCase.provided, err: testCase.providerErr}
+		args := Args{
+			AccountName:            "john",
+			Action:                 testCase.action,
+			BucketName:             "mybucket",
+			ObjectName:             "john/file",
+			ConditionValues:        testCase.conditionValues,
+			ConditionValueProvider: provider.provide,
+		}
+		if got := testCase.policy.IsAllowedCtx(ctx, args); got != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, got)
+		}
+		if !reflect.DeepEqual(provider.keys, testCase.expectedKeys) {
+			t.Fatalf("case %v: expected keys: %v, got: %v", i+1, testCase.expectedKeys, provider.keys)
+		}
+		if provider.calls > 1 {
+			t.Fatalf("case %v: expected at most one call, got: %v", i+1, provider.calls)
+		}
+		if testCase.conditionValues == nil && args.ConditionValues != nil {
+			t.Fatalf("case %v: condition values of the caller must not be modified", i+1)
+		}
+	}
+}
@@ -73,8 +73,8 @@ func (f booleanFunc) clone() Function {
 }
 
 func newBooleanFunc(key Key, values ValueSet, _ string) (Function, error) {
-	if !key.Is(AWSSecureTransport) {
-		return nil, fmt.Errorf("only %v key is allowed for %v condition", AWSSecureTransport, boolean)
+	if err := checkOperatorKey(boolean, key); err != nil {
+		return nil, err
 	}
 
 	if len(values) != 1 {
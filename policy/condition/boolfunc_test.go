@@ -178,6 +178,11 @@ func TestNewBooleanFunc(t *testing.T) {
 		t.Fatalf("unexpected error. %v\n", err)
 	}
 
+	case3Function, err := newBooleanFunc(SVCIsServiceAccount.ToKey(), NewValueSet(NewBoolValue(true)), "")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
 	testCases := []struct {
 		key            Key
 		values         ValueSet
@@ -186,6 +191,9 @@ func TestNewBooleanFunc(t *testing.T) {
 	}{
 		{AWSSecureTransport.ToKey(), NewValueSet(NewBoolValue(true)), case1Function, false},
 		{AWSSecureTransport.ToKey(), NewValueSet(NewStringValue("false")), case2Function, false},
+		{SVCIsServiceAccount.ToKey(), NewValueSet(NewStringValue("true")), case3Function, false},
+		// Key not allowed for Bool error.
+		{S3Prefix.ToKey(), NewValueSet(NewBoolValue(true)), nil, true},
 		// Multiple values error.
 		{AWSSecureTransport.ToKey(), NewValueSet(NewStringValue("true"), NewStringValue("false")), nil, true},
 		// Invalid boolean string error.
@@ -27,7 +27,10 @@ type dateFunc struct {
 	n     name
 	k     Key
 	value time.Time
-	c     condition
+	// raw is the policy value as written, it is empty if it is the
+	// normalized form of value.
+	raw string
+	c   condition
 }
 
 func (f dateFunc) evaluate(values map[string][]string) bool {
@@ -77,7 +80,11 @@ func (f dateFunc) toMap() map[Key]ValueSet {
 	}
 
 	values := NewValueSet()
-	values.Add(NewStringValue(f.value.Format(time.RFC3339)))
+	if f.raw != "" {
+		values.Add(NewStringValue(f.raw))
+	} else {
+		values.Add(NewStringValue(f.value.Format(time.RFC3339)))
+	}
 
 	return map[Key]ValueSet{
 		f.k: values,
@@ -89,35 +96,44 @@ func (f dateFunc) clone() Function {
 		n:     f.n,
 		k:     f.k,
 		value: f.value,
+		raw:   f.raw,
 		c:     f.c,
 	}
 }
 
-func valueToTime(n string, values ValueSet) (v time.Time, err error) {
+// valueToTime - parses the single value of values, also returning the
+// value as written unless it is in normalized form.
+func valueToTime(n string, values ValueSet) (v time.Time, raw string, err error) {
 	if len(values) != 1 {
-		return v, fmt.Errorf("only one value is allowed for %s condition", n)
+		return v, "", fmt.Errorf("only one value is allowed for %s condition", n)
 	}
 
 	for vs := range values {
 		switch vs.GetType() {
 		case reflect.String:
-			s, err := vs.GetString()
-			if err != nil {
-				return v, err
+			if raw, err = vs.GetString(); err != nil {
+				return v, "", err
 			}
-			if v, err = time.Parse(time.RFC3339, s); err != nil {
-				return v, fmt.Errorf("value %s must be a time.Time string for %s condition: %w", vs, n, err)
+			if v, err = time.Parse(time.RFC3339, raw); err != nil {
+				return v, "", fmt.Errorf("value %s must be a time.Time string for %s condition: %w", vs, n, err)
 			}
 		default:
-			return v, fmt.Errorf("value %s must be a time.Time for %s condition", vs, n)
+			return v, "", fmt.Errorf("value %s must be a time.Time for %s condition", vs, n)
 		}
 	}
 
-	return v, nil
+	if raw == v.Format(time.RFC3339) {
+		raw = ""
+	}
+	return v, raw, nil
 }
 
 func newDateFunc(n string, key Key, values ValueSet, cond condition) (Function, error) {
-	v, err := valueToTime(n, values)
+	if err := checkOperatorKey(n, key); err != nil {
+		return nil, err
+	}
+
+	v, raw, err := valueToTime(n, values)
 	if err != nil {
 		return nil, err
 	}
@@ -126,6 +142,7 @@ func newDateFunc(n string, key Key, values ValueSet, cond condition) (Function,
 		n:     name{name: n},
 		k:     key,
 		value: v,
+		raw:   raw,
 		c:     cond,
 	}, nil
 }
@@ -137,6 +154,9 @@ func newDateEqualsFunc(key Key, values ValueSet, _ string) (Function, error) {
 
 // NewDateEqualsFunc - returns new DateEquals function.
 func NewDateEqualsFunc(key Key, value time.Time) (Function, error) {
+	if err := checkOperatorKey(dateEquals, key); err != nil {
+		return nil, err
+	}
 	return &dateFunc{n: name{name: dateEquals}, k: key, value: value, c: equals}, nil
 }
 
@@ -147,6 +167,9 @@ func newDateNotEqualsFunc(key Key, values ValueSet, _ string) (Function, error)
 
 // NewDateNotEqualsFunc - returns new DateNotEquals function.
 func NewDateNotEqualsFunc(key Key, value time.Time) (Function, error) {
+	if err := checkOperatorKey(dateNotEquals, key); err != nil {
+		return nil, err
+	}
 	return &dateFunc{n: name{name: dateNotEquals}, k: key, value: value, c: notEquals}, nil
 }
 
@@ -157,6 +180,9 @@ func newDateGreaterThanFunc(key Key, values ValueSet, _ string) (Function, error
 
 // NewDateGreaterThanFunc - returns new DateGreaterThan function.
 func NewDateGreaterThanFunc(key Key, value time.Time) (Function, error) {
+	if err := checkOperatorKey(dateGreaterThan, key); err != nil {
+		return nil, err
+	}
 	return &dateFunc{n: name{name: dateGreaterThan}, k: key, value: value, c: greaterThan}, nil
 }
 
@@ -167,6 +193,9 @@ func newDateGreaterThanEqualsFunc(key Key, values ValueSet, _ string) (Function,
 
 // NewDateGreaterThanEqualsFunc - returns new DateGreaterThanEquals function.
 func NewDateGreaterThanEqualsFunc(key Key, value time.Time) (Function, error) {
+	if err := checkOperatorKey(dateGreaterThanEquals, key); err != nil {
+		return nil, err
+	}
 	return &dateFunc{n: name{name: dateGreaterThanEquals}, k: key, value: value, c: greaterThanEquals}, nil
 }
 
@@ -177,6 +206,9 @@ func newDateLessThanFunc(key Key, values ValueSet, _ string) (Function, error) {
 
 // NewDateLessThanFunc - returns new DateLessThan function.
 func NewDateLessThanFunc(key Key, value time.Time) (Function, error) {
+	if err := checkOperatorKey(dateLessThan, key); err != nil {
+		return nil, err
+	}
 	return &dateFunc{n: name{name: dateLessThan}, k: key, value: value, c: lessThan}, nil
 }
 
@@ -187,5 +219,8 @@ func newDateLessThanEqualsFunc(key Key, values ValueSet, _ string) (Function, er
 
 // NewDateLessThanEqualsFunc - returns new DateLessThanEquals function.
 func NewDateLessThanEqualsFunc(key Key, value time.Time) (Function, error) {
+	if err := checkOperatorKey(dateLessThanEquals, key); err != nil {
+		return nil, err
+	}
 	return &dateFunc{n: name{name: dateLessThanEquals}, k: key, value: value, c: lessThanEquals}, nil
 }
@@ -0,0 +1,148 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package condition
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// weekdays - values of DayOfWeekEquals conditions, by time.Weekday.
+var weekdays = [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+// dayOfWeekFunc - DayOfWeekEquals condition function, a MinIO extension.
+// It checks whether the day of week of the request time in a timezone is
+// one of the days "Mon" to "Sun".
+type dayOfWeekFunc struct {
+	k    Key
+	days [7]bool        // by time.Weekday
+	loc  *time.Location // nil is UTC
+}
+
+func (f dayOfWeekFunc) evaluate(values map[string][]string) bool {
+	rvalues := getValuesByKey(values, f.k)
+	if len(rvalues) == 0 {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, rvalues[0])
+	if err != nil {
+		return false
+	}
+
+	if f.loc != nil {
+		t = t.In(f.loc)
+	} else {
+		t = t.UTC()
+	}
+	return f.days[t.Weekday()]
+}
+
+func (f dayOfWeekFunc) key() Key {
+	return f.k
+}
+
+func (f dayOfWeekFunc) name() name {
+	return name{name: dayOfWeekEquals}
+}
+
+func (f dayOfWeekFunc) String() string {
+	return fmt.Sprintf("%v:%v:%v:%v", dayOfWeekEquals, f.k, strings.Join(f.dayNames(), ","), locationName(f.loc))
+}
+
+func (f dayOfWeekFunc) dayNames() []string {
+	var names []string
+	for day, ok := range f.days {
+		if ok {
+			names = append(names, weekdays[day])
+		}
+	}
+	return names
+}
+
+func (f dayOfWeekFunc) toMap() map[Key]ValueSet {
+	if !f.k.IsValid() {
+		return nil
+	}
+
+	values := NewValueSet()
+	for _, day := range f.dayNames() {
+		values.Add(NewStringValue(day))
+	}
+	m := map[Key]ValueSet{
+		f.k: values,
+	}
+	if f.loc != nil {
+		m[timezoneOptionKey] = NewValueSet(NewStringValue(f.loc.String()))
+	}
+	return m
+}
+
+func (f dayOfWeekFunc) clone() Function {
+	clone := f
+	return &clone
+}
+
+// parseWeekday - parses a day "Mon" to "Sun" in any case.
+func parseWeekday(s string) (time.Weekday, bool) {
+	for day, name := range weekdays {
+		if strings.EqualFold(s, name) {
+			return time.Weekday(day), true
+		}
+	}
+	return 0, false
+}
+
+func newDayOfWeekEqualsFunc(key Key, values ValueSet, _ string) (Function, error) {
+	if err := checkOperatorKey(dayOfWeekEquals, key); err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("a value is required for %s condition", dayOfWeekEquals)
+	}
+
+	f := &dayOfWeekFunc{k: key}
+	for v := range values {
+		if v.GetType() != reflect.String {
+			return nil, fmt.Errorf("value %s must be a day of week string for %s condition", v, dayOfWeekEquals)
+		}
+		s, _ := v.GetString()
+		day, ok := parseWeekday(s)
+		if !ok {
+			return nil, fmt.Errorf("value %s must be one of %s for %s condition", s, strings.Join(weekdays[:], ", "), dayOfWeekEquals)
+		}
+		f.days[day] = true
+	}
+	return f, nil
+}
+
+// NewDayOfWeekEqualsFunc - returns new DayOfWeekEquals function on the
+// days of week in loc. A nil loc is UTC.
+func NewDayOfWeekEqualsFunc(key Key, loc *time.Location, days ...time.Weekday) (Function, error) {
+	values := NewValueSet()
+	for _, day := range days {
+		values.Add(NewStringValue(weekdays[day%7]))
+	}
+	f, err := newDayOfWeekEqualsFunc(key, values, "")
+	if err != nil {
+		return nil, err
+	}
+	f.(*dayOfWeekFunc).loc = loc
+	return f, nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package condition
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDayOfWeekFuncEvaluate(t *testing.T) {
+	weekdays := mustParseFunctions(t, `{"DayOfWeekEquals": {"aws:CurrentTime": ["Mon", "Tue", "Wed", "Thu", "Fri"]}}`)
+	tokyo := mustParseFunctions(t, `{"DayOfWeekEquals": {"aws:CurrentTime": "Sat", "minio:Timezone": "Asia/Tokyo"}}`)
+	// Business hours: weekdays from 09:00 to 17:00 in New York.
+	business := mustParseFunctions(t, `{
+  "DayOfWeekEquals": {"aws:CurrentTime": ["Mon", "Tue", "Wed", "Thu", "Fri"], "minio:Timezone": "America/New_York"},
+  "TimeOfDayGreaterThan": {"aws:CurrentTime": "09:00", "minio:Timezone": "America/New_York"},
+  "TimeOfDayLessThan": {"aws:CurrentTime": "17:00", "minio:Timezone": "America/New_York"}
+}`)
+
+	testCases := []struct {
+		functions      Functions
+		currentTime    string
+		expectedResult bool
+	}{
+		// 2026-03-06 is a Friday.
+		{weekdays, "2026-03-06T23:59:59Z", true},
+		{weekdays, "2026-03-07T00:00:00Z", false},
+		{weekdays, "2026-03-08T23:59:59Z", false},
+		{weekdays, "2026-03-09T00:00:00Z", true},
+		{tokyo, "2026-03-06T14:59:59Z", false},
+		{tokyo, "2026-03-06T15:00:00Z", true},
+		{tokyo, "2026-03-07T14:59:59Z", true},
+		{tokyo, "2026-03-07T15:00:00Z", false},
+		{business, "2026-03-06T14:00:00Z", false}, // Fri 09:00 EST, not after 09:00
+		{business, "2026-03-06T14:00:01Z", true},  // Fri 09:00:01 EST
+		{business, "2026-03-06T21:59:59Z", true},  // Fri 16:59:59 EST
+		{business, "2026-03-06T22:00:00Z", false}, // Fri 17:00 EST
+		{business, "2026-03-07T15:00:00Z", false}, // Sat 10:00 EST
+		{business, "2026-03-09T13:30:00Z", true},  // Mon 09:30 EDT
+		{business, "2026-03-09T21:30:00Z", false}, // Mon 17:30 EDT
+		{business, "2026-03-10T03:00:00Z", false}, // Mon 23:00 EDT
+	}
+
+	for i, testCase := range testCases {
+		values := map[string][]string{"CurrentTime": {testCase.currentTime}}
+		if result := testCase.functions.Evaluate(values); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestNewDayOfWeekEqualsFunc(t *testing.T) {
+	f, err := NewDayOfWeekEqualsFunc(AWSCurrentTime.ToKey(), nil, time.Saturday, time.Sunday)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := mustParseFunctions(t, `{"DayOfWeekEquals": {"aws:CurrentTime": ["sun", "SAT"]}}`); !NewFunctions(f).Equals(expected) {
+		t.Fatalf("expected: %v, got: %v", expected, f)
+	}
+	if _, err = NewDayOfWeekEqualsFunc(AWSEpochTime.ToKey(), nil, time.Monday); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
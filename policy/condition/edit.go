@@ -0,0 +1,140 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package condition
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+)
+
+// FunctionInfo - operator, qualifier, key and values of a condition
+// function, see Functions.List and Functions.Upsert.
+type FunctionInfo struct {
+	Operator  string   // e.g. "StringEquals"
+	Qualifier string   // "ForAnyValue", "ForAllValues" or empty
+	Key       string   // e.g. "s3:prefix" or "aws:PrincipalTag/team"
+	Values    []string // Sorted
+	Timezone  string   // TimezoneOption of the function, if any
+}
+
+// List - returns the functions in their order.
+func (functions Functions) List() []FunctionInfo {
+	infos := make([]FunctionInfo, 0, len(functions))
+	for _, f := range functions {
+		n, key := f.name(), f.key()
+		info := FunctionInfo{Operator: n.name, Qualifier: n.qualifier, Key: key.String()}
+		for v := range f.toMap()[key] {
+			info.Values = append(info.Values, v.String())
+		}
+		sort.Strings(info.Values)
+		if loc := timezoneOf(f); loc != nil {
+			info.Timezone = loc.String()
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// index - returns the index of the function of the operator, including
+// its qualifier, and key, -1 if there is none.
+func (functions Functions) index(operator string, key Key) int {
+	for i, f := range functions {
+		if f.name().String() == operator && f.key() == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// Remove - removes the function of the operator, including its qualifier,
+// e.g. "ForAnyValue:StringEquals", and key, returning whether there was
+// one.
+func (functions *Functions) Remove(operator, key string) bool {
+	k, err := parseKey(key)
+	if err != nil {
+		return false
+	}
+	i := functions.index(operator, k)
+	if i < 0 {
+		return false
+	}
+	if len(*functions) == 1 {
+		*functions = nil
+		return true
+	}
+	*functions = linkTimeOfDayWindows(append((*functions)[:i:i], (*functions)[i+1:]...))
+	return true
+}
+
+// Upsert - sets the values of the function of the operator, qualifier and
+// key, replacing the function in place if there is one and appending it
+// otherwise. The function is validated as if it was decoded from JSON,
+// values are given as JSON strings would be, e.g. "10" for numbers. A
+// replaced function keeps its TimezoneOption. Other functions are not
+// changed.
+func (functions *Functions) Upsert(operator, qualifier, key string, values []string) error {
+	n := name{qualifier: qualifier, name: operator}
+	if !n.IsValid() {
+		return fmt.Errorf("invalid condition name '%v'", n)
+	}
+	k, err := parseKey(key)
+	if err != nil {
+		return err
+	}
+	fn, ok := conditionFuncMap[operator]
+	if !ok {
+		return fmt.Errorf("condition %v is not handled", n)
+	}
+
+	valueSet := NewValueSet()
+	for _, v := range values {
+		valueSet.Add(NewStringValue(v))
+	}
+	f, err := fn(k, valueSet, qualifier)
+	if err != nil {
+		return err
+	}
+
+	// The functions may be shared, e.g. with a copy of the statement.
+	updated := slices.Clone(*functions)
+	i := updated.index(n.String(), k)
+	if i < 0 {
+		updated = append(updated, f)
+	} else {
+		if loc := timezoneOf(updated[i]); loc != nil {
+			setTimezone(f, loc)
+		}
+		updated[i] = f
+	}
+	*functions = linkTimeOfDayWindows(updated)
+	return nil
+}
+
+// timezoneOf - returns the timezone of f, nil if f is not a function
+// accepting the timezone option or if its timezone is UTC by default.
+func timezoneOf(f Function) *time.Location {
+	switch fn := f.(type) {
+	case *timeOfDayFunc:
+		return fn.bound.loc
+	case *dayOfWeekFunc:
+		return fn.loc
+	}
+	return nil
+}
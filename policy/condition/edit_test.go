@@ -0,0 +1,176 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package condition
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestFunctionsList(t *testing.T) {
+	functions := mustParseFunctions(t, `{
+  "StringLike": {"s3:prefix": ["home/", "home/*"]},
+  "ForAnyValue:StringEquals": {"jwt:groups": "admins"},
+  "NumericLessThanEquals": {"s3:max-keys": "1000"},
+  "TimeOfDayLessThan": {"aws:CurrentTime": "17:00", "minio:Timezone": "Europe/Berlin"}
+}`)
+
+	expected := []FunctionInfo{
+		{Operator: "NumericLessThanEquals", Key: "s3:max-keys", Values: []string{"1000"}},
+		{Operator: "StringEquals", Qualifier: "ForAnyValue", Key: "jwt:groups", Values: []string{"admins"}},
+		{Operator: "StringLike", Key: "s3:prefix", Values: []string{"home/", "home/*"}},
+		{Operator: "TimeOfDayLessThan", Key: "aws:CurrentTime", Values: []string{"17:00"}, Timezone: "Europe/Berlin"},
+	}
+	list := functions.List()
+	// Decoded functions are not ordered.
+	sort.Slice(list, func(i, j int) bool { return list[i].Operator < list[j].Operator })
+	if !reflect.DeepEqual(list, expected) {
+		t.Fatalf("expected: %+v, got: %+v", expected, list)
+	}
+}
+
+func TestFunctionsEdit(t *testing.T) {
+	functions := mustParseFunctions(t, `{
+  "StringLike": {"s3:prefix": ["home/*"]},
+  "ForAnyValue:StringEquals": {"jwt:groups": ["admins", "users"]},
+  "IpAddress": {"aws:SourceIp": "10.0.0.0/8"},
+  "DayOfWeekEquals": {"aws:CurrentTime": ["Mon", "Tue"], "minio:Timezone": "Asia/Tokyo"}
+}`)
+	shared := functions
+	untouched := func(fs Functions) map[string]json.RawMessage {
+		data, err := json.Marshal(fs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var m map[string]json.RawMessage
+		if err = json.Unmarshal(data, &m); err != nil {
+			t.Fatal(err)
+		}
+		delete(m, "StringLike")
+		delete(m, "NumericLessThan")
+		delete(m, "DayOfWeekEquals")
+		return m
+	}
+	before := untouched(functions)
+
+	values := map[string][]string{
+		"prefix":      {"projects/a"},
+		"groups":      {"users"},
+		"SourceIp":    {"10.1.2.3"},
+		"max-keys":    {"500"},
+		"CurrentTime": {"2026-03-02T01:00:00Z"}, // Monday 10:00 in Tokyo
+	}
+	if functions.Evaluate(values) {
+		t.Fatalf("expected: %v to deny %v", functions, values)
+	}
+
+	if err := functions.Upsert("StringLike", "", "s3:prefix", []string{"home/*", "projects/*"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !functions.Evaluate(values) {
+		t.Fatalf("expected: %v to allow %v", functions, values)
+	}
+	if err := functions.Upsert("NumericLessThan", "", "s3:max-keys", []string{"100"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if functions.Evaluate(values) || len(functions) != 5 {
+		t.Fatalf("expected: %v to deny %v", functions, values)
+	}
+	if !functions.Remove("NumericLessThan", "s3:max-keys") || functions.Remove("NumericLessThan", "s3:max-keys") {
+		t.Fatalf("expected the function to be removed once")
+	}
+	if !functions.Evaluate(values) {
+		t.Fatalf("expected: %v to allow %v", functions, values)
+	}
+
+	// Replaced functions keep their timezone.
+	if err := functions.Upsert("DayOfWeekEquals", "", "aws:CurrentTime", []string{"Sun"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if functions.Evaluate(values) {
+		t.Fatalf("expected: %v to deny %v", functions, values)
+	}
+	values["CurrentTime"] = []string{"2026-03-01T01:00:00Z"} // Sunday 10:00 in Tokyo
+	if !functions.Evaluate(values) {
+		t.Fatalf("expected: %v to allow %v", functions, values)
+	}
+
+	// Untouched functions are encoded as before, the shared functions are
+	// not changed.
+	if after := untouched(functions); !reflect.DeepEqual(after, before) {
+		t.Fatalf("expected: %s, got: %s", before, after)
+	}
+	if shared.Equals(functions) || len(shared) != 4 {
+		t.Fatalf("expected: %v not to be changed", shared)
+	}
+	var decoded Functions
+	data, err := json.Marshal(functions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = json.Unmarshal(data, &decoded); err != nil || !decoded.Equals(functions) {
+		t.Fatalf("expected: %v, got: %v, %v", functions, decoded, err)
+	}
+}
+
+func TestFunctionsUpsertErrors(t *testing.T) {
+	testCases := []struct {
+		operator  string
+		qualifier string
+		key       string
+		values    []string
+	}{
+		{"StringMatches", "", "s3:prefix", []string{"a"}},
+		{"StringEquals", "ForSomeValues", "s3:prefix", []string{"a"}},
+		{"StringEquals", "", "s3:unknown", []string{"a"}},
+		{"IpAddress", "", "s3:prefix", []string{"10.0.0.0/8"}},
+		{"IpAddress", "", "aws:SourceIp", []string{"10.0.0.300/8"}},
+		{"NumericLessThan", "", "s3:max-keys", []string{"ten"}},
+		{"NumericLessThan", "", "s3:max-keys", []string{"1", "2"}},
+		{"Bool", "", "aws:SecureTransport", []string{"yes"}},
+		{"DateGreaterThan", "", "aws:CurrentTime", []string{"yesterday"}},
+	}
+
+	for i, testCase := range testCases {
+		functions := mustParseFunctions(t, `{"StringLike": {"s3:prefix": "home/*"}}`)
+		if err := functions.Upsert(testCase.operator, testCase.qualifier, testCase.key, testCase.values); err == nil {
+			t.Fatalf("case %v: expected an error", i+1)
+		}
+		if len(functions) != 1 {
+			t.Fatalf("case %v: expected no change, got: %v", i+1, functions)
+		}
+	}
+
+	var functions Functions
+	if err := functions.Upsert("TimeOfDayGreaterThan", "", "aws:CurrentTime", []string{"22:00"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := functions.Upsert("TimeOfDayLessThan", "", "aws:CurrentTime", []string{"06:00"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Windows crossing midnight are linked, also after edits.
+	night := map[string][]string{"CurrentTime": {"2026-03-02T23:00:00Z"}}
+	if !functions.Evaluate(night) {
+		t.Fatalf("expected: %v to allow %v", functions, night)
+	}
+	if !functions.Remove("TimeOfDayLessThan", "aws:CurrentTime") || !functions.Remove("TimeOfDayGreaterThan", "aws:CurrentTime") || functions != nil {
+		t.Fatalf("expected no functions, got: %v", functions)
+	}
+}
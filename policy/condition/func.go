@@ -18,9 +18,12 @@
 package condition
 
 import (
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
+	"time"
 )
 
 type condition int
@@ -55,7 +58,8 @@ type Function interface {
 	clone() Function
 }
 
-// Functions - list of functions.
+// Functions - list of functions. Nil Functions have no condition and
+// evaluate to true.
 type Functions []Function
 
 // Evaluate - evaluates all functions with given values map. Each function is evaluated
@@ -70,6 +74,42 @@ func (functions Functions) Evaluate(values map[string][]string) bool {
 	return true
 }
 
+// MissingKey - returns the key of the first function whose key has no
+// value in the given values map. Null functions, which test for the
+// absence of a key, and IfExists functions, which are satisfied if the key
+// is absent, never report their key as missing.
+func (functions Functions) MissingKey(values map[string][]string) (Key, bool) {
+	for _, f := range functions {
+		switch fn := f.(type) {
+		case *nullFunc:
+			continue
+		case *numericFunc:
+			if fn.ifExists {
+				continue
+			}
+		}
+		if len(getValuesByKey(values, f.key())) == 0 {
+			return f.key(), true
+		}
+	}
+
+	return Key{}, false
+}
+
+// MissingKeys - returns the keys used in all functions which have no value
+// in the given values map.
+func (functions Functions) MissingKeys(values map[string][]string) KeySet {
+	keySet := NewKeySet()
+
+	for _, f := range functions {
+		if len(getValuesByKey(values, f.key())) == 0 {
+			keySet.Add(f.key())
+		}
+	}
+
+	return keySet
+}
+
 // Keys - returns list of keys used in all functions.
 func (functions Functions) Keys() KeySet {
 	keySet := NewKeySet()
@@ -81,9 +121,13 @@ func (functions Functions) Keys() KeySet {
 	return keySet
 }
 
-// Clone clones Functions structure
+// Clone clones Functions structure. Every function and its values are
+// copied, the returned Functions shares no state with the receiver.
 func (functions Functions) Clone() Functions {
-	funcs := []Function{}
+	if functions == nil {
+		return nil
+	}
+	funcs := make(Functions, 0, len(functions))
 	for _, f := range functions {
 		funcs = append(funcs, f.clone())
 	}
@@ -164,11 +208,20 @@ var conditionFuncMap = map[string]func(Key, ValueSet, string) (Function, error){
 	dateLessThanEquals:         newDateLessThanEqualsFunc,
 	dateGreaterThan:            newDateGreaterThanFunc,
 	dateGreaterThanEquals:      newDateGreaterThanEqualsFunc,
+	timeOfDayGreaterThan:       newTimeOfDayGreaterThanFunc,
+	timeOfDayLessThan:          newTimeOfDayLessThanFunc,
+	dayOfWeekEquals:            newDayOfWeekEqualsFunc,
 	// Add new conditions here.
 }
 
-// UnmarshalJSON - decodes JSON data to Functions.
+// UnmarshalJSON - decodes JSON data to Functions, null is decoded to nil
+// Functions.
 func (functions *Functions) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*functions = nil
+		return nil
+	}
+
 	// As string kind, int kind then json.Unmarshaler is checked at
 	// https://github.com/golang/go/blob/master/src/encoding/json/decode.go#L618
 	// UnmarshalJSON() is not called for types extending string
@@ -192,6 +245,17 @@ func (functions *Functions) UnmarshalJSON(data []byte) error {
 			return err
 		}
 
+		var loc *time.Location
+		if tz, ok := args[TimezoneOption]; ok {
+			if loc, err = parseTimezone(nameString, tz); err != nil {
+				return err
+			}
+			delete(args, TimezoneOption)
+			if len(args) == 0 {
+				return fmt.Errorf("%s requires a condition key for %v condition", TimezoneOption, n)
+			}
+		}
+
 		for keyString, values := range args {
 			key, err := parseKey(keyString)
 			if err != nil {
@@ -207,12 +271,15 @@ func (functions *Functions) UnmarshalJSON(data []byte) error {
 			if err != nil {
 				return err
 			}
+			if loc != nil && !setTimezone(f, loc) {
+				return fmt.Errorf("%s is not allowed for %v condition", TimezoneOption, n)
+			}
 
 			funcs = append(funcs, f)
 		}
 	}
 
-	*functions = funcs
+	*functions = linkTimeOfDayWindows(funcs)
 
 	return nil
 }
@@ -227,7 +294,74 @@ func (functions *Functions) GobDecode(data []byte) error {
 	return functions.UnmarshalJSON(data)
 }
 
-// NewFunctions - returns new Functions with given function list.
+// NewFunctions - returns new Functions with given function list. The list
+// is copied, hence appending to or replacing elements of the returned
+// Functions never affects the caller's slice.
 func NewFunctions(functions ...Function) Functions {
-	return Functions(functions)
+	return linkTimeOfDayWindows(append(Functions(nil), functions...))
+}
+
+// Condition - operator, key and values of a condition function.
+type Condition struct {
+	// Operator, including its qualifier, e.g. "StringEquals" or
+	// "ForAnyValue:StringLike".
+	Operator string
+	Key      Key
+	Values   []string // Sorted
+}
+
+// Conditions - returns the conditions of functions in their order.
+func (functions Functions) Conditions() []Condition {
+	var conditions []Condition
+	for _, f := range functions {
+		for k, values := range f.toMap() {
+			c := Condition{Operator: f.name().String(), Key: k}
+			for v := range values {
+				c.Values = append(c.Values, v.String())
+			}
+			sort.Strings(c.Values)
+			conditions = append(conditions, c)
+		}
+	}
+	return conditions
+}
+
+// AppendCanonical - appends a canonical encoding of functions to dst. The
+// encoding consists of the sorted, distinct (operator, key, values)
+// triples of functions with sorted values, hence it only depends on the
+// conditions expressed, not on the order or JSON form they were given in.
+// It is stable across releases unless the semantics of conditions change.
+func (functions Functions) AppendCanonical(dst []byte) []byte {
+	var triples []string
+	for _, f := range functions {
+		for k, values := range f.toMap() {
+			var vs []string
+			for v := range values {
+				vs = append(vs, string(appendCanonicalString([]byte{byte(v.GetType())}, v.String())))
+			}
+			sort.Strings(vs)
+
+			triple := appendCanonicalString(nil, f.name().String())
+			triple = appendCanonicalString(triple, k.String())
+			triple = binary.AppendUvarint(triple, uint64(len(vs)))
+			for _, v := range vs {
+				triple = append(triple, v...)
+			}
+			triples = append(triples, string(triple))
+		}
+	}
+	sort.Strings(triples)
+	triples = slices.Compact(triples)
+
+	dst = binary.AppendUvarint(dst, uint64(len(triples)))
+	for _, triple := range triples {
+		dst = appendCanonicalString(dst, triple)
+	}
+	return dst
+}
+
+// appendCanonicalString - appends the length prefixed s to dst.
+func appendCanonicalString(dst []byte, s string) []byte {
+	dst = binary.AppendUvarint(dst, uint64(len(s)))
+	return append(dst, s...)
 }
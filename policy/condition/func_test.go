@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -123,6 +124,87 @@ func TestFunctionsKeys(t *testing.T) {
 	}
 }
 
+func TestFunctionsConditions(t *testing.T) {
+	func1, err := newIPAddressFunc(AWSSourceIP.ToKey(), NewValueSet(NewStringValue("192.168.1.0/24"), NewStringValue("10.0.0.0/8")), "")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	func2, err := newStringLikeFunc(S3XAmzCopySource.ToKey(), NewValueSet(NewStringValue("mybucket/*")), forAnyValue)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	func3, err := NewBoolFunc(AWSSecureTransport.ToKey(), true)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		functions      Functions
+		expectedResult []Condition
+	}{
+		{nil, nil},
+		{NewFunctions(func1, func2, func3), []Condition{
+			{"IpAddress", AWSSourceIP.ToKey(), []string{"10.0.0.0/8", "192.168.1.0/24"}},
+			{"ForAnyValue:StringLike", S3XAmzCopySource.ToKey(), []string{"mybucket/*"}},
+			{"Bool", AWSSecureTransport.ToKey(), []string{"true"}},
+		}},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.functions.Conditions()
+
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestFunctionsClone(t *testing.T) {
+	func1, err := newStringEqualsFunc(S3XAmzCopySource.ToKey(), NewValueSet(NewStringValue("mybucket/myobject")), "")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	func2, err := newIPAddressFunc(AWSSourceIP.ToKey(), NewValueSet(NewStringValue("192.168.1.0/24")), "")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	func3, err := newNullFunc(S3Prefix.ToKey(), NewValueSet(NewBoolValue(true)), "")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	if result := Functions(nil).Clone(); result != nil {
+		t.Fatalf("expected: nil, got: %v\n", result)
+	}
+
+	functions := NewFunctions(func1, func2)
+	expectedResult := functions.String()
+
+	clone := functions.Clone()
+	if clone.String() != expectedResult {
+		t.Fatalf("expected: %v, got: %v\n", expectedResult, clone)
+	}
+
+	clone[0] = func3
+	clone = append(clone, func1)
+	clone[1].(*ipaddrFunc).values[0].IP[0] = 10
+	if result := functions.String(); result != expectedResult {
+		t.Fatalf("expected: %v, got: %v\n", expectedResult, result)
+	}
+
+	// NewFunctions must not share the caller's slice.
+	list := []Function{func1, func2}
+	functions = NewFunctions(list...)
+	functions[0] = func3
+	if list[0] != func1 {
+		t.Fatalf("expected: %v, got: %v\n", func1, list[0])
+	}
+}
+
 func TestFunctionsMarshalJSON(t *testing.T) {
 	func1, err := newStringLikeFunc(S3XAmzMetadataDirective.ToKey(), NewValueSet(NewStringValue("REPL*")), "")
 	if err != nil {
@@ -361,3 +443,114 @@ func TestFunctionsUnmarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestFunctionsMarshalJSONOriginalValues(t *testing.T) {
+	testCases := []struct {
+		data           string
+		expectedResult string
+	}{
+		{
+			`{"IpAddress":{"aws:SourceIp":["10.0.0.1","10.0.0.5/24"]}}`,
+			`{"IpAddress":{"aws:SourceIp":["10.0.0.1","10.0.0.5/24"]}}`,
+		},
+		{
+			`{"NotIpAddress":{"aws:SourceIp":"2001:db8::1/64"}}`,
+			`{"NotIpAddress":{"aws:SourceIp":["2001:db8::1/64"]}}`,
+		},
+		{
+			`{"DateGreaterThan":{"aws:CurrentTime":"2026-01-01T00:00:00.5+02:00"}}`,
+			`{"DateGreaterThan":{"aws:CurrentTime":["2026-01-01T00:00:00.5+02:00"]}}`,
+		},
+		{
+			`{"NumericLessThan":{"s3:max-keys":"010"}}`,
+			`{"NumericLessThan":{"s3:max-keys":["010"]}}`,
+		},
+		{
+			`{"NumericLessThan":{"s3:max-keys":10}}`,
+			`{"NumericLessThan":{"s3:max-keys":[10]}}`,
+		},
+	}
+
+	for i, testCase := range testCases {
+		var functions Functions
+		if err := json.Unmarshal([]byte(testCase.data), &functions); err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+
+		result, err := json.Marshal(functions)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if string(result) != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %s, got: %s", i+1, testCase.expectedResult, result)
+		}
+
+		// Originals survive cloning.
+		if result, err = json.Marshal(functions.Clone()); err != nil || string(result) != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %s, got: %s, %v", i+1, testCase.expectedResult, result, err)
+		}
+	}
+}
+
+func TestFunctionsUnmarshalJSONMalformedValues(t *testing.T) {
+	testCases := []string{
+		`{"IpAddress":{"aws:SourceIp":"10.0.0.256"}}`,
+		`{"IpAddress":{"aws:SourceIp":"10.0.0.1/33"}}`,
+		`{"NotIpAddress":{"aws:SourceIp":"example.com"}}`,
+		`{"DateGreaterThan":{"aws:CurrentTime":"2026-01-01"}}`,
+		`{"DateLessThan":{"aws:CurrentTime":["2026-01-01T00:00:00Z","2027-01-01T00:00:00Z"]}}`,
+		`{"NumericLessThan":{"s3:max-keys":"ten"}}`,
+	}
+
+	for i, testCase := range testCases {
+		var functions Functions
+		if err := json.Unmarshal([]byte(testCase), &functions); err == nil {
+			t.Fatalf("case %v: expected error, got: %v", i+1, functions)
+		}
+	}
+}
+
+func BenchmarkFunctionsEvaluate(b *testing.B) {
+	var IPs []string
+	for i := 0; i < 50; i++ {
+		IPs = append(IPs, fmt.Sprintf(`"10.%d.0.0/16"`, i))
+	}
+	var cidrs Functions
+	if err := json.Unmarshal([]byte(fmt.Sprintf(`{"IpAddress":{"aws:SourceIp":[%s]}}`, strings.Join(IPs, ","))), &cidrs); err != nil {
+		b.Fatal(err)
+	}
+
+	var dateBounds Functions
+	for i := 0; i < 5; i++ {
+		after, err := newDateGreaterThanFunc(AWSCurrentTime.ToKey(), NewValueSet(NewStringValue(fmt.Sprintf("20%02d-01-01T00:00:00Z", i))), "")
+		if err != nil {
+			b.Fatal(err)
+		}
+		before, err := newDateLessThanFunc(AWSCurrentTime.ToKey(), NewValueSet(NewStringValue(fmt.Sprintf("21%02d-01-01T00:00:00Z", i))), "")
+		if err != nil {
+			b.Fatal(err)
+		}
+		dateBounds = append(dateBounds, after, before)
+	}
+
+	values := map[string][]string{
+		"SourceIp":    {"10.49.1.1"},
+		"CurrentTime": {"2026-10-17T00:00:00Z"},
+	}
+	for _, testCase := range []struct {
+		name      string
+		functions Functions
+	}{
+		{"50-cidrs", cidrs},
+		{"10-date-bounds", dateBounds},
+	} {
+		b.Run(testCase.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if !testCase.functions.Evaluate(values) {
+					b.Fatal("expected true")
+				}
+			}
+		})
+	}
+}
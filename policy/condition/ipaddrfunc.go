@@ -33,30 +33,32 @@ type ipaddrFunc struct {
 	n      name
 	k      Key
 	values []*net.IPNet
+	// raw holds the policy values as written, in the order of values,
+	// it is nil if they are the normalized form of values.
+	raw    []string
 	negate bool
 }
 
 func (f ipaddrFunc) eval(values map[string][]string) bool {
 	rvalues := getValuesByKey(values, f.k)
-	IPs := []net.IP{}
+	var matched bool
 	for _, s := range rvalues {
 		IP := net.ParseIP(s)
 		if IP == nil {
 			return false
 		}
 
-		IPs = append(IPs, IP)
-	}
-
-	for _, IP := range IPs {
-		for _, IPNet := range f.values {
-			if IPNet.Contains(IP) {
-				return true
+		if !matched {
+			for _, IPNet := range f.values {
+				if IPNet.Contains(IP) {
+					matched = true
+					break
+				}
 			}
 		}
 	}
 
-	return false
+	return matched
 }
 
 // evaluate() - evaluates to check whether IP address in values map for AWSSourceIP
@@ -97,8 +99,12 @@ func (f ipaddrFunc) toMap() map[Key]ValueSet {
 	}
 
 	values := NewValueSet()
-	for _, value := range f.values {
-		values.Add(NewStringValue(value.String()))
+	for i, value := range f.values {
+		if f.raw != nil {
+			values.Add(NewStringValue(f.raw[i]))
+		} else {
+			values.Add(NewStringValue(value.String()))
+		}
 	}
 
 	return map[Key]ValueSet{
@@ -107,26 +113,39 @@ func (f ipaddrFunc) toMap() map[Key]ValueSet {
 }
 
 func (f ipaddrFunc) clone() Function {
-	values := []*net.IPNet{}
-	for _, value := range f.values {
-		_, IPNet, _ := net.ParseCIDR(value.String())
-		values = append(values, IPNet)
-	}
 	return &ipaddrFunc{
 		n:      f.n,
 		k:      f.k,
-		values: values,
+		values: cloneIPNets(f.values),
+		raw:    append([]string(nil), f.raw...),
 		negate: f.negate,
 	}
 }
 
-func valuesToIPNets(n string, values ValueSet) ([]*net.IPNet, error) {
+// cloneIPNets - returns deep copy of given IP networks so that the caller's
+// slice and the underlying IP/mask bytes are never shared.
+func cloneIPNets(values []*net.IPNet) []*net.IPNet {
+	IPNets := make([]*net.IPNet, 0, len(values))
+	for _, value := range values {
+		IPNets = append(IPNets, &net.IPNet{
+			IP:   append(net.IP(nil), value.IP...),
+			Mask: append(net.IPMask(nil), value.Mask...),
+		})
+	}
+	return IPNets
+}
+
+// valuesToIPNets - parses values into IP networks, also returning the
+// values as written unless they are all in normalized form.
+func valuesToIPNets(n string, values ValueSet) ([]*net.IPNet, []string, error) {
 	IPNets := []*net.IPNet{}
+	raw := []string{}
 	for v := range values {
 		s, err := v.GetString()
 		if err != nil {
-			return nil, fmt.Errorf("value %v must be string representation of CIDR for %v condition", v, n)
+			return nil, nil, fmt.Errorf("value %v must be string representation of CIDR for %v condition", v, n)
 		}
+		raw = append(raw, s)
 
 		// If you specify an IP address without the associated routing prefix, IAM uses the default prefix value of /32.
 		// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_elements_condition_operators.html#Conditions_IPAddress
@@ -137,54 +156,62 @@ func valuesToIPNets(n string, values ValueSet) ([]*net.IPNet, error) {
 		var IPNet *net.IPNet
 		_, IPNet, err = net.ParseCIDR(s)
 		if err != nil {
-			return nil, fmt.Errorf("value %v must be CIDR string for %v condition", s, n)
+			return nil, nil, fmt.Errorf("value %v must be CIDR string for %v condition", s, n)
 		}
 
 		IPNets = append(IPNets, IPNet)
 	}
 
-	return IPNets, nil
+	// Only keep the values as written if they differ from their
+	// normalized form.
+	for i, IPNet := range IPNets {
+		if raw[i] != IPNet.String() {
+			return IPNets, raw, nil
+		}
+	}
+	return IPNets, nil, nil
 }
 
-func newIPAddrFunc(n string, key Key, values []*net.IPNet, negate bool) (Function, error) {
-	if !key.Is(AWSSourceIP) {
-		return nil, fmt.Errorf("only %v key is allowed for %v condition", AWSSourceIP, n)
+func newIPAddrFunc(n string, key Key, values []*net.IPNet, raw []string, negate bool) (Function, error) {
+	if err := checkOperatorKey(n, key); err != nil {
+		return nil, err
 	}
 
 	return &ipaddrFunc{
 		n:      name{name: n},
 		k:      key,
-		values: values,
+		values: cloneIPNets(values),
+		raw:    raw,
 		negate: negate,
 	}, nil
 }
 
 // newIPAddressFunc - returns new IP address function.
 func newIPAddressFunc(key Key, values ValueSet, _ string) (Function, error) {
-	IPNets, err := valuesToIPNets(ipAddress, values)
+	IPNets, raw, err := valuesToIPNets(ipAddress, values)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewIPAddressFunc(key, IPNets...)
+	return newIPAddrFunc(ipAddress, key, IPNets, raw, false)
 }
 
 // NewIPAddressFunc - returns new IP address function.
 func NewIPAddressFunc(key Key, IPNets ...*net.IPNet) (Function, error) {
-	return newIPAddrFunc(ipAddress, key, IPNets, false)
+	return newIPAddrFunc(ipAddress, key, IPNets, nil, false)
 }
 
 // newNotIPAddressFunc - returns new Not IP address function.
 func newNotIPAddressFunc(key Key, values ValueSet, _ string) (Function, error) {
-	IPNets, err := valuesToIPNets(notIPAddress, values)
+	IPNets, raw, err := valuesToIPNets(notIPAddress, values)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewNotIPAddressFunc(key, IPNets...)
+	return newIPAddrFunc(notIPAddress, key, IPNets, raw, true)
 }
 
 // NewNotIPAddressFunc - returns new Not IP address function.
 func NewNotIPAddressFunc(key Key, IPNets ...*net.IPNet) (Function, error) {
-	return newIPAddrFunc(notIPAddress, key, IPNets, true)
+	return newIPAddrFunc(notIPAddress, key, IPNets, nil, true)
 }
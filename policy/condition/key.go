@@ -20,6 +20,7 @@ package condition
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -92,6 +93,8 @@ func (key *Key) UnmarshalJSON(data []byte) error {
 }
 
 func parseKey(s string) (Key, error) {
+	freezeKeys()
+
 	name, variable := s, ""
 	if strings.Contains(s, "/") {
 		tokens := strings.SplitN(s, "/", 2)
@@ -133,7 +136,10 @@ func (set KeySet) Merge(mset KeySet) {
 	}
 }
 
-// Match matches the input key name with current keySet
+// Match matches the input key name with current keySet. A key with a
+// variable, such as "s3:ExistingObjectTag/security", is also matched by
+// its bare key name "s3:ExistingObjectTag" in the set, which acts as a
+// wildcard for all variables of that key name.
 func (set KeySet) Match(key Key) bool {
 	_, ok := set[key]
 	if ok {
@@ -161,13 +167,31 @@ func (set KeySet) Difference(sset KeySet) KeySet {
 	return nset
 }
 
+// Intersection - returns a key set contains keys matched by both key sets.
+// Example:
+//
+//	keySet1 := ["one", "two", "three"]
+//	keySet2 := ["two", "four", "three"]
+//	keySet1.Intersection(keySet2) == ["two", "three"]
+func (set KeySet) Intersection(sset KeySet) KeySet {
+	nset := make(KeySet)
+
+	for k := range set {
+		if sset.Match(k) {
+			nset.Add(k)
+		}
+	}
+
+	return nset
+}
+
 // IsEmpty - returns whether key set is empty or not.
 func (set KeySet) IsEmpty() bool {
 	return len(set) == 0
 }
 
 func (set KeySet) String() string {
-	return fmt.Sprintf("%v", set.ToSlice())
+	return fmt.Sprintf("%v", set.ToSortedSlice())
 }
 
 // ToSlice - returns slice of keys.
@@ -181,6 +205,16 @@ func (set KeySet) ToSlice() []Key {
 	return keys
 }
 
+// ToSortedSlice - returns slice of keys sorted by their string representation.
+func (set KeySet) ToSortedSlice() []Key {
+	keys := set.ToSlice()
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+
+	return keys
+}
+
 // NewKeySet - returns new KeySet contains given keys.
 func NewKeySet(keys ...Key) KeySet {
 	set := make(KeySet)
@@ -87,6 +87,8 @@ func TestKeyName(t *testing.T) {
 	}{
 		{S3XAmzCopySource.ToKey(), "x-amz-copy-source"},
 		{AWSReferer.ToKey(), "Referer"},
+		{AWSRequestedRegion.ToKey(), "RequestedRegion"},
+		{MinIODeploymentRegion.ToKey(), "deployment-region"},
 	}
 
 	for i, testCase := range testCases {
@@ -163,6 +165,48 @@ func TestKeySetDifference(t *testing.T) {
 	}
 }
 
+func TestKeySetIntersection(t *testing.T) {
+	testCases := []struct {
+		set            KeySet
+		setToIntersect KeySet
+		expectedResult KeySet
+	}{
+		{NewKeySet(), NewKeySet(S3XAmzCopySource.ToKey()), NewKeySet()},
+		{NewKeySet(S3Prefix.ToKey(), S3Delimiter.ToKey(), S3MaxKeys.ToKey()), NewKeySet(S3Delimiter.ToKey(), S3MaxKeys.ToKey()), NewKeySet(S3Delimiter.ToKey(), S3MaxKeys.ToKey())},
+		{NewKeySet(NewKey(ExistingObjectTag, "security"), S3Prefix.ToKey()), NewKeySet(ExistingObjectTag.ToKey()), NewKeySet(NewKey(ExistingObjectTag, "security"))},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.set.Intersection(testCase.setToIntersect)
+
+		if !reflect.DeepEqual(testCase.expectedResult, result) {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestKeySetMatch(t *testing.T) {
+	set := NewKeySet(ExistingObjectTag.ToKey(), S3Prefix.ToKey())
+	testCases := []struct {
+		key            Key
+		expectedResult bool
+	}{
+		{S3Prefix.ToKey(), true},
+		{ExistingObjectTag.ToKey(), true},
+		{NewKey(ExistingObjectTag, "security"), true},
+		{NewKey(RequestObjectTag, "security"), false},
+		{S3Delimiter.ToKey(), false},
+	}
+
+	for i, testCase := range testCases {
+		result := set.Match(testCase.key)
+
+		if testCase.expectedResult != result {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
 func TestKeySetIsEmpty(t *testing.T) {
 	testCases := []struct {
 		set            KeySet
@@ -188,6 +232,7 @@ func TestKeySetString(t *testing.T) {
 	}{
 		{NewKeySet(), `[]`},
 		{NewKeySet(S3Delimiter.ToKey()), `[s3:delimiter]`},
+		{NewKeySet(S3Prefix.ToKey(), AWSUsername.ToKey(), S3Delimiter.ToKey()), `[aws:username s3:delimiter s3:prefix]`},
 	}
 
 	for i, testCase := range testCases {
@@ -216,3 +261,21 @@ func TestKeySetToSlice(t *testing.T) {
 		}
 	}
 }
+
+func TestKeySetToSortedSlice(t *testing.T) {
+	testCases := []struct {
+		set            KeySet
+		expectedResult []Key
+	}{
+		{NewKeySet(), []Key{}},
+		{NewKeySet(S3Prefix.ToKey(), AWSUsername.ToKey(), S3Delimiter.ToKey()), []Key{AWSUsername.ToKey(), S3Delimiter.ToKey(), S3Prefix.ToKey()}},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.set.ToSortedSlice()
+
+		if !reflect.DeepEqual(testCase.expectedResult, result) {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
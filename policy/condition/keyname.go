@@ -29,15 +29,16 @@ type KeyName string
 
 // Prefixes to trim from key names.
 var toTrim = map[string]bool{
-	"aws":  true,
-	"jwt":  true,
-	"ldap": true,
-	"sts":  true,
-	"svc":  true,
-	"s3":   true,
+	"aws":   true,
+	"jwt":   true,
+	"ldap":  true,
+	"minio": true,
+	"sts":   true,
+	"svc":   true,
+	"s3":    true,
 }
 
-// Name - returns key name which is stripped value of prefixes "aws:", "s3:", "jwt:" and "ldap:"
+// Name - returns key name which is stripped value of prefixes "aws:", "s3:", "jwt:", "ldap:" and "minio:"
 func (key KeyName) Name() string {
 	idx := strings.IndexByte(string(key), ':')
 	if idx == -1 || !toTrim[string(key[:idx])] {
@@ -59,8 +60,20 @@ func (key KeyName) ToKey() Key {
 // Condition key names.
 const (
 	// S3XAmzCopySource - key representing x-amz-copy-source HTTP header applicable to PutObject API only.
+	// Request values are the raw, percent-encoded header values, they are decoded exactly once
+	// before being compared to the "bucket/object" values of the condition.
+	//
+	// The canonical form policies should target is "bucket/object": header values with or without
+	// a leading '/', percent-encoded or not, and with or without a "?versionId=" suffix evaluate
+	// identically. The version ID is matched by S3XAmzCopySourceVersionID.
 	S3XAmzCopySource KeyName = "s3:x-amz-copy-source"
 
+	// S3XAmzCopySourceVersionID - MinIO specific key representing the version ID of the
+	// "?versionId=" suffix of the x-amz-copy-source HTTP header, applicable to PutObject API only.
+	// It is derived from the S3XAmzCopySource request values unless set, and has no value for
+	// copy sources without version ID.
+	S3XAmzCopySourceVersionID KeyName = "s3:x-amz-copy-source-version-id"
+
 	// S3XAmzServerSideEncryption - key representing x-amz-server-side-encryption HTTP header applicable
 	// to PutObject API only.
 	S3XAmzServerSideEncryption KeyName = "s3:x-amz-server-side-encryption"
@@ -84,6 +97,15 @@ const (
 	// HTTP header for S3 API calls
 	S3XAmzServerSideEncryptionAwsKmsKeyID KeyName = "s3:x-amz-server-side-encryption-aws-kms-key-id"
 
+	// S3XAmzACL - key representing x-amz-acl HTTP header applicable to PutObject and
+	// CreateBucket APIs. MinIO ignores ACLs, clients of owner enforced buckets do not
+	// usually send this header.
+	S3XAmzACL KeyName = "s3:x-amz-acl"
+
+	// S3XAmzObjectOwnership - key representing x-amz-object-ownership HTTP header
+	// applicable to CreateBucket API only.
+	S3XAmzObjectOwnership KeyName = "s3:x-amz-object-ownership"
+
 	// S3LocationConstraint - key representing LocationConstraint XML tag of CreateBucket API only.
 	S3LocationConstraint KeyName = "s3:LocationConstraint"
 
@@ -148,6 +170,13 @@ const (
 	// AWSGroups - groups for any authenticating Access Key.
 	AWSGroups KeyName = "aws:groups"
 
+	// AWSRequestedRegion - key representing the region the request is made to,
+	// in MinIO this value is the configured region of the deployment.
+	AWSRequestedRegion KeyName = "aws:RequestedRegion"
+
+	// MinIODeploymentRegion - MinIO alias of AWSRequestedRegion.
+	MinIODeploymentRegion KeyName = "minio:deployment-region"
+
 	// S3SignatureVersion - identifies the version of AWS Signature that you want to support for authenticated requests.
 	S3SignatureVersion KeyName = "s3:signatureversion"
 
@@ -157,6 +186,10 @@ const (
 	// S3AuthType - optionally use this condition key to restrict incoming requests to use a specific authentication method.
 	S3AuthType KeyName = "s3:authType"
 
+	// S3TLSVersion - TLS version used by the client, e.g. "1.2", for use
+	// with numeric conditions. Requests without TLS have no value.
+	S3TLSVersion KeyName = "s3:TlsVersion"
+
 	// Refer https://docs.aws.amazon.com/AmazonS3/latest/userguide/tagging-and-policies.html
 	ExistingObjectTag    KeyName = "s3:ExistingObjectTag"
 	RequestObjectTagKeys KeyName = "s3:RequestObjectTagKeys"
@@ -216,6 +249,32 @@ const (
 	SVCDurationSeconds KeyName = "svc:DurationSeconds"
 )
 
+const (
+	// SVCParentUser - parent user of the service account or temporary
+	// credential making the request, empty for regular users.
+	SVCParentUser KeyName = "svc:ParentUser"
+
+	// SVCIsServiceAccount - "true" if the request is signed with service account credentials.
+	SVCIsServiceAccount KeyName = "svc:IsServiceAccount"
+
+	// STSRoleArn - ARN of the role assumed by the temporary credential making the request.
+	STSRoleArn KeyName = "sts:RoleArn"
+
+	// STSIsTemporaryCredential - "true" if the request is signed with temporary (STS) credentials.
+	STSIsTemporaryCredential KeyName = "sts:IsTemporaryCredential"
+)
+
+const (
+	// AWSPrincipalTag - key representing the session tags of the principal
+	// making the request, used with the tag key as variable, such as
+	// "aws:PrincipalTag/team".
+	AWSPrincipalTag KeyName = "aws:PrincipalTag"
+
+	// STSTransitiveTagKeys - key representing the session tag keys which
+	// are passed on to chained sessions.
+	STSTransitiveTagKeys KeyName = "sts:TransitiveTagKeys"
+)
+
 // JWTKeys - Supported JWT keys, non-exhaustive list please
 // expand as new claims are standardized.
 var JWTKeys = []KeyName{
@@ -248,13 +307,17 @@ var AllSupportedKeys = []KeyName{
 	S3SignatureVersion,
 	S3AuthType,
 	S3SignatureAge,
+	S3TLSVersion,
 	S3XAmzCopySource,
+	S3XAmzCopySourceVersionID,
 	S3XAmzServerSideEncryption,
 	S3XAmzServerSideEncryptionCustomerAlgorithm,
 	S3XAmzMetadataDirective,
 	S3XAmzStorageClass,
 	S3XAmzServerSideEncryptionAwsKmsKeyID,
 	S3XAmzContentSha256,
+	S3XAmzACL,
+	S3XAmzObjectOwnership,
 	S3LocationConstraint,
 	S3Prefix,
 	S3Delimiter,
@@ -274,6 +337,8 @@ var AllSupportedKeys = []KeyName{
 	AWSUserID,
 	AWSUsername,
 	AWSGroups,
+	AWSRequestedRegion,
+	MinIODeploymentRegion,
 	LDAPUser,
 	LDAPUsername,
 	LDAPGroups,
@@ -304,15 +369,19 @@ var AllSupportedKeys = []KeyName{
 	JWTClientID,
 	STSDurationSeconds,
 	SVCDurationSeconds,
+	SVCParentUser,
+	SVCIsServiceAccount,
+	STSRoleArn,
+	STSIsTemporaryCredential,
+	AWSPrincipalTag,
+	STSTransitiveTagKeys,
 }
 
-// CommonKeys - is list of all common condition keys.
-var CommonKeys = append([]KeyName{
-	S3SignatureVersion,
-	S3AuthType,
-	S3SignatureAge,
-	S3XAmzContentSha256,
-	S3LocationConstraint,
+// CommonIdentityKeys - condition keys of the requester and of the origin
+// of the request, e.g. aws:SourceIp, which are supported by all S3, admin
+// and STS actions. Keys of new identity namespaces are added here so that
+// they are supported by all actions.
+var CommonIdentityKeys = append([]KeyName{
 	AWSReferer,
 	AWSSourceIP,
 	AWSUserAgent,
@@ -323,11 +392,28 @@ var CommonKeys = append([]KeyName{
 	AWSUserID,
 	AWSUsername,
 	AWSGroups,
+	AWSRequestedRegion,
+	MinIODeploymentRegion,
 	LDAPUser,
 	LDAPUsername,
 	LDAPGroups,
+	SVCParentUser,
+	SVCIsServiceAccount,
+	STSRoleArn,
+	STSIsTemporaryCredential,
+	AWSPrincipalTag,
 }, JWTKeys...)
 
+// CommonKeys - is list of all common condition keys.
+var CommonKeys = append([]KeyName{
+	S3SignatureVersion,
+	S3AuthType,
+	S3SignatureAge,
+	S3TLSVersion,
+	S3XAmzContentSha256,
+	S3LocationConstraint,
+}, CommonIdentityKeys...)
+
 // CommonKeysMap is a lookup of CommonKeys.
 var CommonKeysMap map[KeyName]bool
 
@@ -340,25 +426,13 @@ func init() {
 
 // AllSupportedAdminKeys - is list of all admin supported keys.
 var AllSupportedAdminKeys = append([]KeyName{
-	AWSReferer,
-	AWSSourceIP,
-	AWSUserAgent,
-	AWSSecureTransport,
-	AWSCurrentTime,
-	AWSEpochTime,
-	AWSPrincipalType,
-	AWSUserID,
-	AWSUsername,
-	AWSGroups,
-	LDAPUser,
-	LDAPUsername,
-	LDAPGroups,
 	SVCDurationSeconds,
 	// Add new supported condition keys.
-}, JWTKeys...)
+}, CommonIdentityKeys...)
 
 // AllSupportedSTSKeys is the all supported conditions for STS policies
-var AllSupportedSTSKeys = []KeyName{
+var AllSupportedSTSKeys = append([]KeyName{
 	STSDurationSeconds,
+	STSTransitiveTagKeys,
 	// Add new supported condition keys.
-}
+}, CommonIdentityKeys...)
@@ -20,6 +20,7 @@ package condition
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -50,6 +51,11 @@ const (
 	dateGreaterThan            = "DateGreaterThan"
 	dateGreaterThanEquals      = "DateGreaterThanEquals"
 
+	// MinIO extensions
+	timeOfDayGreaterThan = "TimeOfDayGreaterThan"
+	timeOfDayLessThan    = "TimeOfDayLessThan"
+	dayOfWeekEquals      = "DayOfWeekEquals"
+
 	// qualifiers
 	// refer https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_multi-value-conditions.html#reference_policies_multi-key-or-value-conditions
 	forAllValues = "ForAllValues"
@@ -81,6 +87,17 @@ var names = map[string]struct{}{
 	dateLessThanEquals:         {},
 	dateGreaterThan:            {},
 	dateGreaterThanEquals:      {},
+	timeOfDayGreaterThan:       {},
+	timeOfDayLessThan:          {},
+	dayOfWeekEquals:            {},
+}
+
+// extensionNames - condition operators which are MinIO extensions, AWS
+// rejects policies using them.
+var extensionNames = map[string]struct{}{
+	timeOfDayGreaterThan: {},
+	timeOfDayLessThan:    {},
+	dayOfWeekEquals:      {},
 }
 
 var qualifiers = map[string]struct{}{
@@ -88,6 +105,40 @@ var qualifiers = map[string]struct{}{
 	forAnyValue:  {},
 }
 
+// OperatorNames - returns the names of the supported condition operators,
+// such as "StringEquals", sorted.
+func OperatorNames() []string {
+	ops := make([]string, 0, len(names))
+	for n := range names {
+		ops = append(ops, n)
+	}
+	sort.Strings(ops)
+	return ops
+}
+
+// OperatorQualifiers - returns the supported qualifiers of condition
+// operators, such as "ForAnyValue", sorted.
+func OperatorQualifiers() []string {
+	qs := make([]string, 0, len(qualifiers))
+	for q := range qualifiers {
+		qs = append(qs, q)
+	}
+	sort.Strings(qs)
+	return qs
+}
+
+// IsExtensionOperator - returns whether the condition operator, e.g.
+// "TimeOfDayGreaterThan", is a MinIO extension. Policies using such
+// operators are not portable to AWS.
+func IsExtensionOperator(operator string) bool {
+	n, err := parseName(operator)
+	if err != nil {
+		return false
+	}
+	_, found := extensionNames[n.name]
+	return found
+}
+
 type name struct {
 	qualifier string
 	name      string
@@ -111,3 +111,26 @@ func TestNameUnmarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestOperatorNames(t *testing.T) {
+	ops := OperatorNames()
+	if len(ops) != len(names) {
+		t.Fatalf("expected: %v operators, got: %v", len(names), len(ops))
+	}
+	for _, op := range ops {
+		for _, s := range append([]string{op}, qualified(op)...) {
+			if _, err := parseName(s); err != nil {
+				t.Fatalf("%v: unexpected error: %v", s, err)
+			}
+		}
+	}
+}
+
+// qualified - returns op with all supported qualifiers.
+func qualified(op string) []string {
+	var s []string
+	for _, q := range OperatorQualifiers() {
+		s = append(s, q+":"+op)
+	}
+	return s
+}
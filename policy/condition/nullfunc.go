@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 )
 
 // nullFunc - Null condition function. It checks whether Key is not present in given
@@ -79,26 +80,46 @@ func (f nullFunc) clone() Function {
 	}
 }
 
+// parseNullValue - parses a value of a Null condition, i.e. a boolean or
+// a boolean string in any case such as "True".
+func parseNullValue(v Value) (bool, error) {
+	switch v.GetType() {
+	case reflect.Bool:
+		return v.GetBool()
+	case reflect.String:
+		s, _ := v.GetString()
+		value, err := strconv.ParseBool(strings.ToLower(s))
+		if err != nil {
+			return false, fmt.Errorf("value must be a boolean string for Null condition")
+		}
+		return value, nil
+	}
+	return false, fmt.Errorf("value must be a boolean for Null condition")
+}
+
+// newNullFunc - returns a Null function on key. values may have several
+// values of the same boolean, e.g. "true" and true, but not both booleans.
 func newNullFunc(key Key, values ValueSet, _ string) (Function, error) {
-	if len(values) != 1 {
-		return nil, fmt.Errorf("only one value is allowed for Null condition")
+	if len(values) == 0 {
+		return nil, fmt.Errorf("a value is required for Null condition")
 	}
 
+	var found [2]bool
 	var value bool
 	for v := range values {
-		switch v.GetType() {
-		case reflect.Bool:
-			value, _ = v.GetBool()
-		case reflect.String:
-			var err error
-			s, _ := v.GetString()
-			if value, err = strconv.ParseBool(s); err != nil {
-				return nil, fmt.Errorf("value must be a boolean string for Null condition")
-			}
-		default:
-			return nil, fmt.Errorf("value must be a boolean for Null condition")
+		var err error
+		if value, err = parseNullValue(v); err != nil {
+			return nil, err
+		}
+		if value {
+			found[1] = true
+		} else {
+			found[0] = true
 		}
 	}
+	if found[0] && found[1] {
+		return nil, fmt.Errorf("only one value is allowed for Null condition")
+	}
 
 	return &nullFunc{key, value}, nil
 }
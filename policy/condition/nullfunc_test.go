@@ -18,6 +18,7 @@
 package condition
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 )
@@ -197,8 +198,16 @@ func TestNewNullFunc(t *testing.T) {
 	}{
 		{S3Prefix.ToKey(), NewValueSet(NewBoolValue(true)), case1Function, false},
 		{S3Prefix.ToKey(), NewValueSet(NewStringValue("false")), case2Function, false},
+		// Boolean strings in any case.
+		{S3Prefix.ToKey(), NewValueSet(NewStringValue("True")), case1Function, false},
+		{S3Prefix.ToKey(), NewValueSet(NewStringValue("FALSE")), case2Function, false},
+		// Several values of the same boolean.
+		{S3Prefix.ToKey(), NewValueSet(NewStringValue("true"), NewBoolValue(true)), case1Function, false},
 		// Multiple values error.
 		{S3Prefix.ToKey(), NewValueSet(NewBoolValue(true), NewBoolValue(false)), nil, true},
+		{S3Prefix.ToKey(), NewValueSet(NewStringValue("true"), NewStringValue("false")), nil, true},
+		// Missing value error.
+		{S3Prefix.ToKey(), NewValueSet(), nil, true},
 		// Invalid boolean string error.
 		{S3Prefix.ToKey(), NewValueSet(NewStringValue("foo")), nil, true},
 		// Invalid value error.
@@ -220,3 +229,34 @@ func TestNewNullFunc(t *testing.T) {
 		}
 	}
 }
+
+func TestNullFuncMultipleKeys(t *testing.T) {
+	var functions Functions
+	data := `{"Null": {"s3:x-amz-acl": "false", "s3:prefix": ["True"], "aws:SecureTransport": true}}`
+	if err := json.Unmarshal([]byte(data), &functions); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Keys of a Null block are encoded in the same block.
+	expected := `{"Null":{"aws:SecureTransport":[true],"s3:prefix":[true],"s3:x-amz-acl":[false]}}`
+	if result, err := json.Marshal(functions); err != nil || string(result) != expected {
+		t.Fatalf("expected: %v, got: %s, %v", expected, result, err)
+	}
+
+	testCases := []struct {
+		values         map[string][]string
+		expectedResult bool
+	}{
+		{map[string][]string{"x-amz-acl": {"private"}}, true},
+		{map[string][]string{"x-amz-acl": {"private"}, "prefix": {"foo/"}}, false},
+		{map[string][]string{"x-amz-acl": {"private"}, "SecureTransport": {"true"}}, false},
+		{map[string][]string{"delimiter": {"/"}}, false},
+		{map[string][]string{}, false},
+	}
+
+	for i, testCase := range testCases {
+		if result := functions.Evaluate(testCase.values); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
@@ -18,17 +18,29 @@
 package condition
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 )
 
 type numericFunc struct {
-	n        name
-	k        Key
-	value    int
-	c        condition
-	ifExists bool
+	n     name
+	k     Key
+	value int64
+	// decimal is set if the policy value is a decimal number such as
+	// "1.2", which is then stored in fvalue instead of value, and compared
+	// with values parsed as floating point numbers.
+	decimal bool
+	fvalue  float64
+	// raw is the policy value as written if it was a string or number
+	// other than the decimal form of value, it is empty otherwise.
+	// rawNumber is set if it was written as a JSON number.
+	raw       string
+	rawNumber bool
+	c         condition
+	ifExists  bool
 }
 
 func (f numericFunc) evaluate(values map[string][]string) (ret bool) {
@@ -37,24 +49,44 @@ func (f numericFunc) evaluate(values map[string][]string) (ret bool) {
 		return f.ifExists
 	}
 
-	rv, err := strconv.Atoi(rvalues[0])
+	if f.decimal {
+		rv, err := strconv.ParseFloat(rvalues[0], 64)
+		if err != nil {
+			return false
+		}
+		return compareNumbers(f.c, rv, f.fvalue)
+	}
+
+	rv, err := strconv.ParseInt(rvalues[0], 10, 64)
 	if err != nil {
-		return false
+		// Decimal request values, such as TLS version "1.2", are compared
+		// with integer policy values as decimals.
+		frv, err := strconv.ParseFloat(rvalues[0], 64)
+		if err != nil {
+			return false
+		}
+		return compareNumbers(f.c, frv, float64(f.value))
 	}
 
-	switch f.c {
+	return compareNumbers(f.c, rv, f.value)
+}
+
+// compareNumbers - returns whether the request value rv satisfies the
+// condition c with the policy value v.
+func compareNumbers[T int64 | float64](c condition, rv, v T) bool {
+	switch c {
 	case equals:
-		return rv == f.value
+		return rv == v
 	case notEquals:
-		return rv != f.value
+		return rv != v
 	case greaterThan:
-		return rv > f.value
+		return rv > v
 	case greaterThanEquals:
-		return rv >= f.value
+		return rv >= v
 	case lessThan:
-		return rv < f.value
+		return rv < v
 	case lessThanEquals:
-		return rv <= f.value
+		return rv <= v
 	}
 
 	// This never happens.
@@ -70,6 +102,9 @@ func (f numericFunc) name() name {
 }
 
 func (f numericFunc) String() string {
+	if f.decimal {
+		return fmt.Sprintf("%v:%v:%v:%v", f.n, f.ifExists, f.k, f.raw)
+	}
 	return fmt.Sprintf("%v:%v:%v:%v", f.n, f.ifExists, f.k, f.value)
 }
 
@@ -79,7 +114,14 @@ func (f numericFunc) toMap() map[Key]ValueSet {
 	}
 
 	values := NewValueSet()
-	values.Add(NewIntValue(f.value))
+	switch {
+	case f.rawNumber:
+		values.Add(newNumberValue(f.raw))
+	case f.raw != "":
+		values.Add(NewStringValue(f.raw))
+	default:
+		values.Add(NewInt64Value(f.value))
+	}
 
 	return map[Key]ValueSet{
 		f.k: values,
@@ -88,54 +130,105 @@ func (f numericFunc) toMap() map[Key]ValueSet {
 
 func (f numericFunc) clone() Function {
 	return &numericFunc{
-		n:        f.n,
-		k:        f.k,
-		value:    f.value,
-		c:        f.c,
-		ifExists: f.ifExists,
+		n:         f.n,
+		k:         f.k,
+		value:     f.value,
+		decimal:   f.decimal,
+		fvalue:    f.fvalue,
+		raw:       f.raw,
+		rawNumber: f.rawNumber,
+		c:         f.c,
+		ifExists:  f.ifExists,
+	}
+}
+
+// ErrInvalidNumber - the value of a numeric condition is not an integer in
+// the range of int64 nor a finite decimal number.
+type ErrInvalidNumber struct {
+	Operator string
+	Value    string
+	Err      error // e.g. strconv.ErrRange for numbers out of range
+}
+
+func (e ErrInvalidNumber) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("value %s must be a number for %s condition", e.Value, e.Operator)
 	}
+	return fmt.Sprintf("value %s must be a number for %s condition: %v", e.Value, e.Operator, e.Err)
+}
+
+func (e ErrInvalidNumber) Unwrap() error {
+	return e.Err
 }
 
-func valueToInt(n string, values ValueSet) (v int, err error) {
+// parseNumber - parses the single value of values as an integer or,
+// failing that, as a decimal number such as "1.2". Numbers are accepted
+// as JSON numbers or strings, integers out of the range of int64 and
+// decimals out of the range of float64 are rejected.
+func parseNumber(n string, values ValueSet) (f numericFunc, err error) {
 	if len(values) != 1 {
-		return -1, fmt.Errorf("only one value is allowed for %s condition", n)
-	}
-
-	for vs := range values {
-		switch vs.GetType() {
-		case reflect.Int:
-			if v, err = vs.GetInt(); err != nil {
-				return -1, err
-			}
-		case reflect.String:
-			s, err := vs.GetString()
-			if err != nil {
-				return -1, err
-			}
-			if v, err = strconv.Atoi(s); err != nil {
-				return -1, fmt.Errorf("value %s must be a int for %s condition: %w", vs, n, err)
-			}
-		default:
-			return -1, fmt.Errorf("value %s must be a int for %s condition", vs, n)
+		return f, fmt.Errorf("only one value is allowed for %s condition", n)
+	}
+
+	var vs Value
+	for v := range values {
+		vs = v
+	}
+	switch vs.GetType() {
+	case reflect.Int:
+		f.value, err = vs.GetInt64()
+		return f, err
+	case reflect.String:
+	default:
+		return f, ErrInvalidNumber{Operator: n, Value: vs.String()}
+	}
+
+	s := vs.String()
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err == nil {
+		if s != strconv.FormatInt(i, 10) {
+			f.raw, f.rawNumber = s, vs.IsNumber()
 		}
+		f.value = i
+		return f, nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return f, ErrInvalidNumber{Operator: n, Value: s, Err: strconv.ErrRange}
 	}
 
-	return v, nil
+	// Decimal numbers, such as TLS versions, are compared as float64.
+	fv, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			err = strconv.ErrRange
+		} else {
+			err = strconv.ErrSyntax
+		}
+		return f, ErrInvalidNumber{Operator: n, Value: s, Err: err}
+	}
+	if math.IsInf(fv, 0) || math.IsNaN(fv) {
+		return f, ErrInvalidNumber{Operator: n, Value: s, Err: strconv.ErrRange}
+	}
+	f.decimal, f.fvalue = true, fv
+	f.raw, f.rawNumber = s, vs.IsNumber()
+	return f, nil
 }
 
 func newNumericFunc(n string, ifExists bool, key Key, values ValueSet, cond condition) (Function, error) {
-	v, err := valueToInt(n, values)
+	if err := checkOperatorKey(n, key); err != nil {
+		return nil, err
+	}
+
+	f, err := parseNumber(n, values)
 	if err != nil {
 		return nil, err
 	}
 
-	return &numericFunc{
-		n:        name{name: n},
-		k:        key,
-		value:    v,
-		c:        cond,
-		ifExists: ifExists,
-	}, nil
+	f.n = name{name: n}
+	f.k = key
+	f.c = cond
+	f.ifExists = ifExists
+	return &f, nil
 }
 
 // newNumericEqualsFunc - returns new NumericEquals function.
@@ -145,7 +238,10 @@ func newNumericEqualsFunc(key Key, values ValueSet, _ string) (Function, error)
 
 // NewNumericEqualsFunc - returns new NumericEquals function.
 func NewNumericEqualsFunc(key Key, value int) (Function, error) {
-	return &numericFunc{n: name{name: numericEquals}, k: key, value: value, c: equals}, nil
+	if err := checkOperatorKey(numericEquals, key); err != nil {
+		return nil, err
+	}
+	return &numericFunc{n: name{name: numericEquals}, k: key, value: int64(value), c: equals}, nil
 }
 
 // newNumericNotEqualsFunc - returns new NumericNotEquals function.
@@ -155,7 +251,10 @@ func newNumericNotEqualsFunc(key Key, values ValueSet, _ string) (Function, erro
 
 // NewNumericNotEqualsFunc - returns new NumericNotEquals function.
 func NewNumericNotEqualsFunc(key Key, value int) (Function, error) {
-	return &numericFunc{n: name{name: numericNotEquals}, k: key, value: value, c: notEquals}, nil
+	if err := checkOperatorKey(numericNotEquals, key); err != nil {
+		return nil, err
+	}
+	return &numericFunc{n: name{name: numericNotEquals}, k: key, value: int64(value), c: notEquals}, nil
 }
 
 // newNumericGreaterThanFunc - returns new NumericGreaterThan function.
@@ -165,7 +264,10 @@ func newNumericGreaterThanFunc(key Key, values ValueSet, _ string) (Function, er
 
 // NewNumericGreaterThanFunc - returns new NumericGreaterThan function.
 func NewNumericGreaterThanFunc(key Key, value int) (Function, error) {
-	return &numericFunc{n: name{name: numericGreaterThan}, k: key, value: value, c: greaterThan}, nil
+	if err := checkOperatorKey(numericGreaterThan, key); err != nil {
+		return nil, err
+	}
+	return &numericFunc{n: name{name: numericGreaterThan}, k: key, value: int64(value), c: greaterThan}, nil
 }
 
 // newNumericGreaterThanIfExistsFunc - returns new NumericGreaterThanIfExists function.
@@ -175,7 +277,10 @@ func newNumericGreaterThanIfExistsFunc(key Key, values ValueSet, _ string) (Func
 
 // NewNumericGreaterThanIfExistsFunc - returns new NumericGreaterThanIfExists function.
 func NewNumericGreaterThanIfExistsFunc(key Key, value int) (Function, error) {
-	return &numericFunc{n: name{name: numericGreaterThan}, ifExists: true, k: key, value: value, c: greaterThan}, nil
+	if err := checkOperatorKey(numericGreaterThan, key); err != nil {
+		return nil, err
+	}
+	return &numericFunc{n: name{name: numericGreaterThan}, ifExists: true, k: key, value: int64(value), c: greaterThan}, nil
 }
 
 // newNumericGreaterThanEqualsFunc - returns new NumericGreaterThanEquals function.
@@ -185,7 +290,10 @@ func newNumericGreaterThanEqualsFunc(key Key, values ValueSet, _ string) (Functi
 
 // NewNumericGreaterThanEqualsFunc - returns new NumericGreaterThanEquals function.
 func NewNumericGreaterThanEqualsFunc(key Key, value int) (Function, error) {
-	return &numericFunc{n: name{name: numericGreaterThanEquals}, k: key, value: value, c: greaterThanEquals}, nil
+	if err := checkOperatorKey(numericGreaterThanEquals, key); err != nil {
+		return nil, err
+	}
+	return &numericFunc{n: name{name: numericGreaterThanEquals}, k: key, value: int64(value), c: greaterThanEquals}, nil
 }
 
 // newNumericLessThanFunc - returns new NumericLessThan function.
@@ -195,7 +303,10 @@ func newNumericLessThanFunc(key Key, values ValueSet, _ string) (Function, error
 
 // NewNumericLessThanFunc - returns new NumericLessThan function.
 func NewNumericLessThanFunc(key Key, value int) (Function, error) {
-	return &numericFunc{n: name{name: numericLessThan}, k: key, value: value, c: lessThan}, nil
+	if err := checkOperatorKey(numericLessThan, key); err != nil {
+		return nil, err
+	}
+	return &numericFunc{n: name{name: numericLessThan}, k: key, value: int64(value), c: lessThan}, nil
 }
 
 // newNumericLessThanEqualsFunc - returns new NumericLessThanEquals function.
@@ -205,5 +316,8 @@ func newNumericLessThanEqualsFunc(key Key, values ValueSet, _ string) (Function,
 
 // NewNumericLessThanEqualsFunc - returns new NumericLessThanEquals function.
 func NewNumericLessThanEqualsFunc(key Key, value int) (Function, error) {
-	return &numericFunc{n: name{name: numericLessThanEquals}, k: key, value: value, c: lessThanEquals}, nil
+	if err := checkOperatorKey(numericLessThanEquals, key); err != nil {
+		return nil, err
+	}
+	return &numericFunc{n: name{name: numericLessThanEquals}, k: key, value: int64(value), c: lessThanEquals}, nil
 }
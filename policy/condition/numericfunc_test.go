@@ -18,7 +18,10 @@
 package condition
 
 import (
+	"encoding/json"
+	"errors"
 	"reflect"
+	"strconv"
 	"testing"
 )
 
@@ -344,3 +347,86 @@ func TestNumericFuncClone(t *testing.T) {
 		}
 	}
 }
+
+func TestNumericFuncInvalidNumber(t *testing.T) {
+	testCases := []struct {
+		data        string
+		expectedErr error
+	}{
+		{`{"NumericLessThan": {"s3:max-keys": 9223372036854775808}}`, strconv.ErrRange},
+		{`{"NumericLessThan": {"s3:max-keys": -1e999}}`, strconv.ErrRange},
+		{`{"NumericLessThan": {"s3:max-keys": "ten"}}`, strconv.ErrSyntax},
+	}
+
+	for i, testCase := range testCases {
+		var functions Functions
+		err := json.Unmarshal([]byte(testCase.data), &functions)
+		var nerr ErrInvalidNumber
+		if !errors.As(err, &nerr) || nerr.Operator != numericLessThan || !errors.Is(err, testCase.expectedErr) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestNumericFuncDecimal(t *testing.T) {
+	testCases := []struct {
+		data           string
+		values         map[string][]string
+		expectedResult bool
+		expectErr      bool
+	}{
+		{`{"NumericLessThan": {"s3:TlsVersion": "1.2"}}`, map[string][]string{"TlsVersion": {"1.1"}}, true, false},
+		{`{"NumericLessThan": {"s3:TlsVersion": "1.2"}}`, map[string][]string{"TlsVersion": {"1"}}, true, false},
+		{`{"NumericLessThan": {"s3:TlsVersion": "1.2"}}`, map[string][]string{"TlsVersion": {"1.2"}}, false, false},
+		{`{"NumericLessThan": {"s3:TlsVersion": "1.2"}}`, map[string][]string{"TlsVersion": {"1.3"}}, false, false},
+		{`{"NumericLessThan": {"s3:TlsVersion": "1.2"}}`, map[string][]string{"TlsVersion": {"TLS1.3"}}, false, false},
+		{`{"NumericLessThan": {"s3:TlsVersion": "1.2"}}`, map[string][]string{}, false, false},
+		{`{"NumericGreaterThanEquals": {"s3:TlsVersion": "1.3"}}`, map[string][]string{"TlsVersion": {"1.3"}}, true, false},
+		{`{"NumericEquals": {"s3:TlsVersion": "1.0"}}`, map[string][]string{"TlsVersion": {"1"}}, true, false},
+		// Integer policy values compare decimal request values as decimals.
+		{`{"NumericLessThan": {"s3:max-keys": 2}}`, map[string][]string{"max-keys": {"1.5"}}, true, false},
+		{`{"NumericLessThan": {"s3:TlsVersion": "2"}}`, map[string][]string{"TlsVersion": {"1.2"}}, true, false},
+		{`{"NumericLessThan": {"s3:TlsVersion": "1"}}`, map[string][]string{"TlsVersion": {"1.2"}}, false, false},
+		{`{"NumericEquals": {"s3:TlsVersion": "1"}}`, map[string][]string{"TlsVersion": {"1.0"}}, true, false},
+		{`{"NumericLessThan": {"s3:TlsVersion": "2"}}`, map[string][]string{"TlsVersion": {"TLS1.2"}}, false, false},
+		{`{"NumericLessThan": {"s3:TlsVersion": "one"}}`, nil, false, true},
+		{`{"NumericLessThan": {"s3:TlsVersion": "NaN"}}`, nil, false, true},
+		// JSON numbers, large integers and their range.
+		{`{"NumericLessThan": {"s3:TlsVersion": 1.2}}`, map[string][]string{"TlsVersion": {"1.1"}}, true, false},
+		{`{"NumericEquals": {"s3:max-keys": 1e3}}`, map[string][]string{"max-keys": {"1000"}}, true, false},
+		{`{"NumericGreaterThan": {"s3:max-keys": 4294967296}}`, map[string][]string{"max-keys": {"4294967297"}}, true, false},
+		{`{"NumericGreaterThan": {"s3:max-keys": 4294967296}}`, map[string][]string{"max-keys": {"2147483647"}}, false, false},
+		{`{"NumericLessThanEquals": {"s3:max-keys": 9223372036854775807}}`, map[string][]string{"max-keys": {"9223372036854775807"}}, true, false},
+		{`{"NumericLessThan": {"s3:max-keys": 9223372036854775808}}`, nil, false, true},
+		{`{"NumericLessThan": {"s3:max-keys": "-9223372036854775809"}}`, nil, false, true},
+		{`{"NumericLessThan": {"s3:max-keys": 1e400}}`, nil, false, true},
+		{`{"NumericLessThan": {"s3:max-keys": true}}`, nil, false, true},
+	}
+
+	for i, testCase := range testCases {
+		var functions Functions
+		err := json.Unmarshal([]byte(testCase.data), &functions)
+		if expectErr := err != nil; expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		if result := functions.Evaluate(testCase.values); result != testCase.expectedResult {
+			t.Fatalf("case %v: result: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+
+		// Decimal values are encoded as written.
+		data, err := json.Marshal(functions)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		var decoded Functions
+		if err = json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if !decoded.Equals(functions) || decoded.String() != functions.String() {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, functions, decoded)
+		}
+	}
+}
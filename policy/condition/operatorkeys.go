@@ -0,0 +1,184 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package condition
+
+import (
+	"fmt"
+)
+
+// keyClass - class of condition keys accepted by a condition operator.
+type keyClass int
+
+const (
+	// anyKeys - all keys, including the dynamic jwt:, ldap: and svc:
+	// namespaces. String operators compare request values as written,
+	// hence they accept keys of all types.
+	anyKeys keyClass = iota
+
+	// numericKeyClass - keys of type KeyTypeNumeric.
+	numericKeyClass
+
+	// dateKeyClass - keys of type KeyTypeDate.
+	dateKeyClass
+
+	// booleanKeyClass - keys of type KeyTypeBool.
+	booleanKeyClass
+
+	// ipAddressKeyClass - keys of type KeyTypeIPAddress.
+	ipAddressKeyClass
+
+	// currentTimeKeyClass - the aws:CurrentTime key.
+	currentTimeKeyClass
+)
+
+// keyClassTypes - key type of the key classes restricted to a type.
+var keyClassTypes = map[keyClass]KeyType{
+	numericKeyClass:   KeyTypeNumeric,
+	dateKeyClass:      KeyTypeDate,
+	booleanKeyClass:   KeyTypeBool,
+	ipAddressKeyClass: KeyTypeIPAddress,
+}
+
+// keyTypes - types of the supported keys, including registered keys.
+// Keys not listed are of type KeyTypeString.
+var keyTypes = map[KeyName]KeyType{
+	S3MaxKeys:                          KeyTypeNumeric,
+	S3ObjectLockRemainingRetentionDays: KeyTypeNumeric,
+	S3SignatureAge:                     KeyTypeNumeric,
+	S3TLSVersion:                       KeyTypeNumeric,
+	AWSEpochTime:                       KeyTypeNumeric,
+	STSDurationSeconds:                 KeyTypeNumeric,
+	SVCDurationSeconds:                 KeyTypeNumeric,
+	AWSCurrentTime:                     KeyTypeDate,
+	S3ObjectLockRetainUntilDate:        KeyTypeDate,
+	AWSSecureTransport:                 KeyTypeBool,
+	SVCIsServiceAccount:                KeyTypeBool,
+	STSIsTemporaryCredential:           KeyTypeBool,
+	AWSSourceIP:                        KeyTypeIPAddress,
+	STSRoleArn:                         KeyTypeARN,
+}
+
+// keyType - returns the type of the key name.
+func keyType(name KeyName) KeyType {
+	if t, ok := keyTypes[name]; ok {
+		return t
+	}
+	return KeyTypeString
+}
+
+// operatorKeyClasses - key class accepted by each condition operator. This
+// is the only place restricting keys by operator, which keys an action
+// supports is decided by the action condition key maps of the policy
+// package. Operators converting request values, such as numeric and date
+// operators, only accept keys whose values they convert, as AWS does.
+// Previous releases accepted keys of all types for numeric and date
+// operators, hence these are only checked on demand, see CheckOperatorKey.
+var operatorKeyClasses = map[string]keyClass{
+	stringEquals:               anyKeys,
+	stringNotEquals:            anyKeys,
+	stringEqualsIgnoreCase:     anyKeys,
+	stringNotEqualsIgnoreCase:  anyKeys,
+	binaryEquals:               anyKeys,
+	stringLike:                 anyKeys,
+	stringNotLike:              anyKeys,
+	ipAddress:                  ipAddressKeyClass,
+	notIPAddress:               ipAddressKeyClass,
+	null:                       anyKeys,
+	boolean:                    booleanKeyClass,
+	numericEquals:              numericKeyClass,
+	numericNotEquals:           numericKeyClass,
+	numericLessThan:            numericKeyClass,
+	numericLessThanEquals:      numericKeyClass,
+	numericGreaterThan:         numericKeyClass,
+	numericGreaterThanIfExists: numericKeyClass,
+	numericGreaterThanEquals:   numericKeyClass,
+	dateEquals:                 dateKeyClass,
+	dateNotEquals:              dateKeyClass,
+	dateLessThan:               dateKeyClass,
+	dateLessThanEquals:         dateKeyClass,
+	dateGreaterThan:            dateKeyClass,
+	dateGreaterThanEquals:      dateKeyClass,
+	timeOfDayGreaterThan:       currentTimeKeyClass,
+	timeOfDayLessThan:          currentTimeKeyClass,
+	dayOfWeekEquals:            currentTimeKeyClass,
+}
+
+// ErrUnsupportedOperatorKey - the condition key is not accepted by the
+// condition operator, e.g. s3:prefix by IpAddress.
+type ErrUnsupportedOperatorKey struct {
+	Operator string
+	Key      Key
+	Expected string // expected keys, e.g. "date" or "aws:CurrentTime"
+}
+
+func (e ErrUnsupportedOperatorKey) Error() string {
+	return fmt.Sprintf("condition key '%v' is not allowed for %v condition, only %v keys are allowed", e.Key, e.Operator, e.Expected)
+}
+
+// lenientKeyClasses - key classes which are not checked while parsing
+// conditions, since stored policies may use keys of any type for them.
+var lenientKeyClasses = map[keyClass]bool{
+	numericKeyClass: true,
+	dateKeyClass:    true,
+}
+
+// checkOperatorKey - returns an ErrUnsupportedOperatorKey if the condition
+// operator n does not accept key. Keys of the lenient key classes are
+// accepted, see CheckOperatorKey.
+func checkOperatorKey(n string, key Key) error {
+	if lenientKeyClasses[operatorKeyClasses[n]] {
+		return nil
+	}
+	return checkKeyClass(n, key)
+}
+
+// CheckOperatorKey - returns an ErrUnsupportedOperatorKey if the numeric
+// or date condition operator, e.g. "ForAnyValue:NumericLessThan", does not
+// accept key as AWS does, such keys being accepted while parsing
+// conditions. The keys of the other operators are checked while parsing.
+func CheckOperatorKey(operator string, key Key) error {
+	n, err := parseName(operator)
+	if err != nil {
+		return err
+	}
+	if !lenientKeyClasses[operatorKeyClasses[n.name]] {
+		return nil
+	}
+	return checkKeyClass(n.name, key)
+}
+
+// checkKeyClass - returns an ErrUnsupportedOperatorKey if key is not of
+// the key class of the condition operator n.
+func checkKeyClass(n string, key Key) error {
+	var ok bool
+	var expected string
+	switch class := operatorKeyClasses[n]; class {
+	case anyKeys:
+		return nil
+	case currentTimeKeyClass:
+		ok, expected = key.Is(AWSCurrentTime), string(AWSCurrentTime)
+	default:
+		t := keyClassTypes[class]
+		ok, expected = keyType(key.name) == t, t.String()
+	}
+
+	if !ok {
+		return ErrUnsupportedOperatorKey{Operator: n, Key: key, Expected: expected}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package condition
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestOperatorKeyClasses(t *testing.T) {
+	// Every operator must be classified, including new ones.
+	for n := range names {
+		if _, ok := operatorKeyClasses[n]; !ok {
+			t.Fatalf("operator %v has no key class", n)
+		}
+	}
+
+	testCases := []struct {
+		n         string
+		key       Key
+		expectErr bool
+	}{
+		{stringEqualsIgnoreCase, NewKey(JWTPrefUsername, ""), false},
+		{stringNotEqualsIgnoreCase, NewKey(LDAPUsername, ""), false},
+		{stringLike, NewKey(SVCParentUser, ""), false},
+		{null, NewKey(JWTGroups, ""), false},
+		{boolean, NewKey(SVCIsServiceAccount, ""), false},
+		{boolean, NewKey(JWTPrefUsername, ""), true},
+		{ipAddress, NewKey(AWSSourceIP, ""), false},
+		{notIPAddress, NewKey(LDAPUser, ""), true},
+		{ipAddress, NewKey(S3Prefix, ""), true},
+		{numericLessThan, NewKey(S3MaxKeys, ""), false},
+		{numericEquals, NewKey(AWSEpochTime, ""), false},
+		{numericGreaterThanIfExists, NewKey(STSDurationSeconds, ""), false},
+		{numericLessThan, NewKey(S3Prefix, ""), true},
+		{numericEquals, NewKey(AWSCurrentTime, ""), true},
+		{dateGreaterThan, NewKey(AWSCurrentTime, ""), false},
+		{dateLessThan, NewKey(S3ObjectLockRetainUntilDate, ""), false},
+		{dateGreaterThan, NewKey(JWTScope, ""), true},
+		{dateEquals, NewKey(AWSEpochTime, ""), true},
+		{dateEquals, NewKey(ExistingObjectTag, "expiry"), true},
+		{boolean, NewKey(AWSSourceIP, ""), true},
+		{stringEquals, NewKey(S3MaxKeys, ""), false},
+		{stringLike, NewKey(STSRoleArn, ""), false},
+		{stringEquals, NewKey(AWSSourceIP, ""), false},
+		{timeOfDayLessThan, NewKey(S3ObjectLockRetainUntilDate, ""), true},
+	}
+
+	for i, testCase := range testCases {
+		err := checkKeyClass(testCase.n, testCase.key)
+		if expectErr := err != nil; expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		// Numeric and date operators accept keys of any type while
+		// parsing, they are checked on demand.
+		lenient := lenientKeyClasses[operatorKeyClasses[testCase.n]]
+		if err := checkOperatorKey(testCase.n, testCase.key); (err != nil) != (testCase.expectErr && !lenient) {
+			t.Fatalf("case %v: parse error: expected: %v, got: %v", i+1, testCase.expectErr && !lenient, err)
+		}
+		if err := CheckOperatorKey(testCase.n, testCase.key); (err != nil) != (testCase.expectErr && lenient) {
+			t.Fatalf("case %v: check error: expected: %v, got: %v", i+1, testCase.expectErr && lenient, err)
+		}
+	}
+}
+
+func TestOperatorKeyCompatibility(t *testing.T) {
+	testCases := []struct {
+		data          string
+		expectedError *ErrUnsupportedOperatorKey
+	}{
+		{`{"IpAddress": {"s3:prefix": "10.0.0.0/8"}}`, &ErrUnsupportedOperatorKey{ipAddress, NewKey(S3Prefix, ""), "ip"}},
+		{`{"DateGreaterThan": {"jwt:scope": "2026-01-01T00:00:00Z"}}`, &ErrUnsupportedOperatorKey{dateGreaterThan, NewKey(JWTScope, ""), "date"}},
+		{`{"ForAnyValue:NumericLessThan": {"aws:username": 10}}`, &ErrUnsupportedOperatorKey{numericLessThan, NewKey(AWSUsername, ""), "numeric"}},
+		{`{"NumericGreaterThan": {"foo:Tier": 1}}`, &ErrUnsupportedOperatorKey{numericGreaterThan, NewKey("foo:Tier", ""), "numeric"}},
+		{`{"NumericLessThan": {"s3:ExistingObjectTag/size": 10}}`, &ErrUnsupportedOperatorKey{numericLessThan, NewKey(ExistingObjectTag, "size"), "numeric"}},
+		{`{"Bool": {"aws:CurrentTime": "true"}}`, &ErrUnsupportedOperatorKey{boolean, NewKey(AWSCurrentTime, ""), "bool"}},
+		{`{"DayOfWeekEquals": {"aws:EpochTime": "Mon"}}`, &ErrUnsupportedOperatorKey{dayOfWeekEquals, NewKey(AWSEpochTime, ""), "aws:CurrentTime"}},
+		{`{"NumericLessThan": {"s3:max-keys": 10}, "DateLessThan": {"aws:CurrentTime": "2026-01-01T00:00:00Z"}}`, nil},
+		{`{"StringEquals": {"s3:max-keys": "10", "aws:SourceIp": "10.0.0.1", "sts:RoleArn": "arn:minio:iam:::role/x"}}`, nil},
+		{`{"Null": {"aws:SourceIp": "true", "aws:CurrentTime": "false"}}`, nil},
+	}
+
+	for i, testCase := range testCases {
+		var functions Functions
+		err := json.Unmarshal([]byte(testCase.data), &functions)
+		if err == nil {
+			// Numeric and date conditions are only checked on demand.
+			for _, c := range functions.Conditions() {
+				if err = CheckOperatorKey(c.Operator, c.Key); err != nil {
+					break
+				}
+			}
+		} else if testCase.expectedError != nil && lenientKeyClasses[operatorKeyClasses[testCase.expectedError.Operator]] {
+			t.Fatalf("case %v: unexpected parse error: %v", i+1, err)
+		}
+		if testCase.expectedError == nil {
+			if err != nil {
+				t.Fatalf("case %v: unexpected error: %v", i+1, err)
+			}
+			continue
+		}
+		var keyErr ErrUnsupportedOperatorKey
+		if !errors.As(err, &keyErr) || keyErr != *testCase.expectedError {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedError, err)
+		}
+	}
+}
+
+func TestOperatorKeyTypes(t *testing.T) {
+	// Every supported key is accepted by the operators of its type and by
+	// the string and Null operators.
+	for _, name := range AllSupportedKeys {
+		key := NewKey(name, "")
+		for n, class := range operatorKeyClasses {
+			var expected bool
+			switch class {
+			case anyKeys:
+				expected = true
+			case currentTimeKeyClass:
+				expected = name == AWSCurrentTime
+			default:
+				expected = keyClassTypes[class] == keyType(name)
+			}
+			if err := checkKeyClass(n, key); (err == nil) != expected {
+				t.Fatalf("%v %v: expected: %v, got: %v", n, name, expected, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package condition
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// KeyType - type of the values of a condition key, it decides which
+// conditions accept the key.
+type KeyType int
+
+// Condition key types.
+const (
+	// KeyTypeString - string values, accepted by string and Null
+	// conditions, as are all keys, and by Numeric and Date conditions
+	// unless checked by CheckOperatorKey.
+	KeyTypeString KeyType = iota + 1
+
+	// KeyTypeNumeric - numeric values, also accepted by Numeric conditions.
+	KeyTypeNumeric
+
+	// KeyTypeDate - RFC 3339 date values, also accepted by Date
+	// conditions.
+	KeyTypeDate
+
+	// KeyTypeBool - boolean values, also accepted by Bool conditions.
+	KeyTypeBool
+
+	// KeyTypeIPAddress - IP address values, also accepted by IpAddress and
+	// NotIpAddress conditions.
+	KeyTypeIPAddress
+
+	// KeyTypeARN - ARN values, accepted by the same conditions as string
+	// values.
+	KeyTypeARN
+)
+
+// keyTypeNames - names of the key types, by KeyType.
+var keyTypeNames = map[KeyType]string{
+	KeyTypeString:    "string",
+	KeyTypeNumeric:   "numeric",
+	KeyTypeDate:      "date",
+	KeyTypeBool:      "bool",
+	KeyTypeIPAddress: "ip",
+	KeyTypeARN:       "arn",
+}
+
+func (t KeyType) String() string {
+	if n, ok := keyTypeNames[t]; ok {
+		return n
+	}
+	return fmt.Sprintf("KeyType(%d)", int(t))
+}
+
+var (
+	registerMu sync.Mutex
+
+	// keysFrozen is set once a condition key was parsed, keys must not be
+	// registered afterwards.
+	keysFrozen atomic.Bool
+)
+
+// freezeKeys - prevents further registration of condition keys.
+func freezeKeys() {
+	if !keysFrozen.Load() {
+		keysFrozen.Store(true)
+	}
+}
+
+// RegisterKey - registers the condition key name of downstream products,
+// e.g. "foo:Tier", so that it is accepted by policies. It must be called
+// before any policy is parsed, typically from an init function, and
+// panics if it is called afterwards, if name is already supported or if
+// name is not of the form "service:Name".
+//
+// Policy actions only support the registered key if they are registered
+// with it, see policy.RegisterAction.
+func RegisterKey(name KeyName, keyType KeyType) {
+	registerMu.Lock()
+	defer registerMu.Unlock()
+
+	if keysFrozen.Load() {
+		panic(fmt.Sprintf("condition: RegisterKey %v called after policies were parsed", name))
+	}
+	if service, n, ok := strings.Cut(string(name), ":"); !ok || service == "" || n == "" || strings.ContainsAny(n, "/:") {
+		panic(fmt.Sprintf("condition: RegisterKey called with invalid key name %v", name))
+	}
+	if slices.Contains(AllSupportedKeys, name) {
+		panic(fmt.Sprintf("condition: RegisterKey called twice for key %v", name))
+	}
+
+	if _, ok := keyTypeNames[keyType]; !ok {
+		panic(fmt.Sprintf("condition: RegisterKey called with invalid type %v for key %v", keyType, name))
+	}
+	if keyType != KeyTypeString {
+		keyTypes[name] = keyType
+	}
+	AllSupportedKeys = append(AllSupportedKeys, name)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package condition
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// Registration must happen before any key is parsed, hence in init.
+func init() {
+	RegisterKey("foo:Tier", KeyTypeString)
+	RegisterKey("foo:Secure", KeyTypeBool)
+	RegisterKey("foo:ClientIp", KeyTypeIPAddress)
+}
+
+func TestRegisterKey(t *testing.T) {
+	testCases := []struct {
+		data        string
+		expectedErr bool
+	}{
+		{`{"StringEquals": {"foo:Tier": "gold"}}`, false},
+		{`{"Bool": {"foo:Secure": "true"}}`, false},
+		{`{"IpAddress": {"foo:ClientIp": "10.0.0.0/8"}}`, false},
+		{`{"IpAddress": {"aws:SourceIp": "10.0.0.0/8"}}`, false},
+		{`{"Bool": {"foo:Tier": "true"}}`, true},
+		{`{"IpAddress": {"foo:Tier": "10.0.0.0/8"}}`, true},
+		{`{"StringEquals": {"foo:Unknown": "gold"}}`, true},
+	}
+	for i, testCase := range testCases {
+		var functions Functions
+		err := json.Unmarshal([]byte(testCase.data), &functions)
+		if expectErr := err != nil; expectErr != testCase.expectedErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+
+	var functions Functions
+	if err := json.Unmarshal([]byte(`{"StringEquals": {"foo:Tier": "gold"}, "Bool": {"foo:Secure": "true"}}`), &functions); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !functions.Evaluate(map[string][]string{"foo:Tier": {"gold"}, "foo:Secure": {"true"}}) {
+		t.Fatalf("expected functions to be satisfied")
+	}
+	if functions.Evaluate(map[string][]string{"foo:Tier": {"silver"}, "foo:Secure": {"true"}}) {
+		t.Fatalf("expected functions not to be satisfied")
+	}
+}
+
+func TestRegisterKeyPanics(t *testing.T) {
+	// Keys were parsed already, see TestRegisterKey.
+	if _, err := parseKey("foo:Tier"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []func(){
+		func() { RegisterKey("foo:Other", KeyTypeString) },
+	}
+	for i, testCase := range testCases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("case %v: expected panic", i+1)
+				}
+			}()
+			testCase()
+		}()
+	}
+
+	// Invalid registrations panic irrespective of parsed keys.
+	keysFrozen.Store(false)
+	defer keysFrozen.Store(true)
+	testCases = []func(){
+		func() { RegisterKey("foo:Tier", KeyTypeString) },
+		func() { RegisterKey(AWSSourceIP, KeyTypeIPAddress) },
+		func() { RegisterKey("tier", KeyTypeString) },
+		func() { RegisterKey("foo:tier/x", KeyTypeString) },
+		func() { RegisterKey("foo:Level", KeyType(0)) },
+	}
+	for i, testCase := range testCases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("case %v: expected panic", i+1)
+				}
+			}()
+			testCase()
+		}()
+	}
+}
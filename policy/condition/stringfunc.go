@@ -32,7 +32,7 @@ func substitute(values map[string][]string) func(string) string {
 	return func(v string) string {
 		for _, key := range CommonKeys {
 			// Empty values are not supported for policy variables.
-			if rvalues, ok := values[key.Name()]; ok && rvalues[0] != "" {
+			if rvalues := values[key.Name()]; len(rvalues) > 0 && rvalues[0] != "" {
 				v = strings.Replace(v, key.VarName(), rvalues[0], -1)
 			}
 		}
@@ -163,6 +163,11 @@ func valuesToStringSlice(n string, values ValueSet) ([]string, error) {
 	valueStrings := []string{}
 
 	for value := range values {
+		// Numbers are matched as written, e.g. 1000 as "1000".
+		if value.IsNumber() {
+			valueStrings = append(valueStrings, value.String())
+			continue
+		}
 		s, err := value.GetString()
 		if err != nil {
 			return nil, fmt.Errorf("value must be a string for %v condition", n)
@@ -19,6 +19,7 @@ package condition
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -98,6 +99,29 @@ func TestStringEqualsFuncEvaluate(t *testing.T) {
 	}
 }
 
+func TestStringFuncNumberValues(t *testing.T) {
+	testCases := []struct {
+		data           string
+		values         map[string][]string
+		expectedResult bool
+	}{
+		{`{"StringEquals": {"s3:max-keys": 1000}}`, map[string][]string{"max-keys": {"1000"}}, true},
+		{`{"StringEquals": {"s3:max-keys": 1000}}`, map[string][]string{"max-keys": {"100"}}, false},
+		{`{"StringEquals": {"s3:max-keys": [10, "20", 1e3]}}`, map[string][]string{"max-keys": {"1e3"}}, true},
+		{`{"StringLike": {"s3:max-keys": 99999999999999999999}}`, map[string][]string{"max-keys": {"99999999999999999999"}}, true},
+	}
+
+	for i, testCase := range testCases {
+		var functions Functions
+		if err := json.Unmarshal([]byte(testCase.data), &functions); err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if result := functions.Evaluate(testCase.values); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
 func TestStringNotEqualsFuncEvaluate(t *testing.T) {
 	case1Function, err := newStringNotEqualsFunc(S3XAmzCopySource.ToKey(), NewValueSet(NewStringValue("mybucket/myobject")), "")
 	if err != nil {
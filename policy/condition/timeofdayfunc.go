@@ -0,0 +1,286 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package condition
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// TimezoneOption - option of the TimeOfDay and DayOfWeek conditions,
+// given as a second key of the condition, naming the timezone in which
+// the request time is evaluated, e.g.
+//
+//	"TimeOfDayGreaterThan": {"aws:CurrentTime": "09:00", "minio:Timezone": "Europe/Berlin"}
+//
+// The timezone is UTC if the option is not given.
+const TimezoneOption = "minio:Timezone"
+
+// timezoneOptionKey - key under which the timezone option is encoded.
+var timezoneOptionKey = Key{name: TimezoneOption}
+
+// timeOfDayBound - bound of a timeOfDayFunc.
+type timeOfDayBound struct {
+	c     condition // greaterThan or lessThan
+	value time.Duration
+	loc   *time.Location // nil is UTC
+}
+
+// match - checks whether the time of day of t in the timezone of the bound
+// satisfies the bound.
+func (b timeOfDayBound) match(t time.Time) bool {
+	if b.loc != nil {
+		t = t.In(b.loc)
+	} else {
+		t = t.UTC()
+	}
+	h, m, s := t.Clock()
+	d := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second + time.Duration(t.Nanosecond())
+	if b.c == greaterThan {
+		return d > b.value
+	}
+	return d < b.value
+}
+
+// timeOfDayFunc - TimeOfDayGreaterThan and TimeOfDayLessThan condition
+// functions, a MinIO extension. They compare the wall clock time of the
+// request time in a timezone with a time of day "HH:MM".
+//
+// A TimeOfDayGreaterThan and a TimeOfDayLessThan function of the same
+// Functions whose times cross midnight, e.g. "22:00" and "06:00", express
+// the window from 22:00 to 06:00: both evaluate whether the time is in the
+// window, rather than in the empty intersection of both bounds.
+type timeOfDayFunc struct {
+	n     name
+	k     Key
+	bound timeOfDayBound
+
+	// window is the other bound of a window crossing midnight.
+	window *timeOfDayBound
+}
+
+func (f timeOfDayFunc) evaluate(values map[string][]string) bool {
+	rvalues := getValuesByKey(values, f.k)
+	if len(rvalues) == 0 {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, rvalues[0])
+	if err != nil {
+		return false
+	}
+
+	if f.window != nil {
+		return f.bound.match(t) || f.window.match(t)
+	}
+	return f.bound.match(t)
+}
+
+func (f timeOfDayFunc) key() Key {
+	return f.k
+}
+
+func (f timeOfDayFunc) name() name {
+	return f.n
+}
+
+func (f timeOfDayFunc) String() string {
+	return fmt.Sprintf("%v:%v:%v:%v", f.n, f.k, formatTimeOfDay(f.bound.value), locationName(f.bound.loc))
+}
+
+func (f timeOfDayFunc) toMap() map[Key]ValueSet {
+	if !f.k.IsValid() {
+		return nil
+	}
+
+	m := map[Key]ValueSet{
+		f.k: NewValueSet(NewStringValue(formatTimeOfDay(f.bound.value))),
+	}
+	if f.bound.loc != nil {
+		m[timezoneOptionKey] = NewValueSet(NewStringValue(f.bound.loc.String()))
+	}
+	return m
+}
+
+func (f timeOfDayFunc) clone() Function {
+	clone := f
+	if f.window != nil {
+		window := *f.window
+		clone.window = &window
+	}
+	return &clone
+}
+
+// locationName - returns the name of loc, nil is UTC.
+func locationName(loc *time.Location) string {
+	if loc == nil {
+		return time.UTC.String()
+	}
+	return loc.String()
+}
+
+func formatTimeOfDay(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// parseTimeOfDay - parses the single "HH:MM" value of values.
+func parseTimeOfDay(n string, values ValueSet) (time.Duration, error) {
+	if len(values) != 1 {
+		return 0, fmt.Errorf("only one value is allowed for %s condition", n)
+	}
+
+	for v := range values {
+		if v.GetType() != reflect.String {
+			return 0, fmt.Errorf("value %s must be a time of day string for %s condition", v, n)
+		}
+		s, _ := v.GetString()
+		t, err := time.Parse("15:04", s)
+		if err != nil {
+			return 0, fmt.Errorf("value %s must be a time of day 'HH:MM' for %s condition", s, n)
+		}
+		return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+	}
+
+	// This never happens.
+	return 0, nil
+}
+
+// parseTimezone - parses the single value of the timezone option, e.g.
+// "Europe/Berlin".
+func parseTimezone(n string, values ValueSet) (*time.Location, error) {
+	if len(values) != 1 {
+		return nil, fmt.Errorf("only one %s is allowed for %s condition", TimezoneOption, n)
+	}
+
+	for v := range values {
+		s, err := v.GetString()
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a string for %s condition", TimezoneOption, n)
+		}
+		// "" and "Local" would depend on the configuration of the server.
+		if s == "" || s == "Local" {
+			return nil, fmt.Errorf("invalid %s '%s' for %s condition", TimezoneOption, s, n)
+		}
+		loc, err := time.LoadLocation(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s '%s' for %s condition: %w", TimezoneOption, s, n, err)
+		}
+		return loc, nil
+	}
+
+	// This never happens.
+	return nil, nil
+}
+
+func newTimeOfDayFunc(n string, key Key, values ValueSet, cond condition) (Function, error) {
+	if err := checkOperatorKey(n, key); err != nil {
+		return nil, err
+	}
+
+	v, err := parseTimeOfDay(n, values)
+	if err != nil {
+		return nil, err
+	}
+
+	return &timeOfDayFunc{
+		n:     name{name: n},
+		k:     key,
+		bound: timeOfDayBound{c: cond, value: v},
+	}, nil
+}
+
+// newTimeOfDayGreaterThanFunc - returns new TimeOfDayGreaterThan function.
+func newTimeOfDayGreaterThanFunc(key Key, values ValueSet, _ string) (Function, error) {
+	return newTimeOfDayFunc(timeOfDayGreaterThan, key, values, greaterThan)
+}
+
+// NewTimeOfDayGreaterThanFunc - returns new TimeOfDayGreaterThan function
+// on the time of day, e.g. 9*time.Hour, in loc. A nil loc is UTC.
+func NewTimeOfDayGreaterThanFunc(key Key, value time.Duration, loc *time.Location) (Function, error) {
+	if err := checkOperatorKey(timeOfDayGreaterThan, key); err != nil {
+		return nil, err
+	}
+	if value < 0 || value >= 24*time.Hour {
+		return nil, fmt.Errorf("value %v must be a time of day for %s condition", value, timeOfDayGreaterThan)
+	}
+	return &timeOfDayFunc{n: name{name: timeOfDayGreaterThan}, k: key, bound: timeOfDayBound{c: greaterThan, value: value, loc: loc}}, nil
+}
+
+// newTimeOfDayLessThanFunc - returns new TimeOfDayLessThan function.
+func newTimeOfDayLessThanFunc(key Key, values ValueSet, _ string) (Function, error) {
+	return newTimeOfDayFunc(timeOfDayLessThan, key, values, lessThan)
+}
+
+// NewTimeOfDayLessThanFunc - returns new TimeOfDayLessThan function on
+// the time of day, e.g. 17*time.Hour, in loc. A nil loc is UTC.
+func NewTimeOfDayLessThanFunc(key Key, value time.Duration, loc *time.Location) (Function, error) {
+	if err := checkOperatorKey(timeOfDayLessThan, key); err != nil {
+		return nil, err
+	}
+	if value < 0 || value >= 24*time.Hour {
+		return nil, fmt.Errorf("value %v must be a time of day for %s condition", value, timeOfDayLessThan)
+	}
+	return &timeOfDayFunc{n: name{name: timeOfDayLessThan}, k: key, bound: timeOfDayBound{c: lessThan, value: value, loc: loc}}, nil
+}
+
+// setTimezone - sets the timezone of f, if it is a function accepting the
+// timezone option.
+func setTimezone(f Function, loc *time.Location) bool {
+	switch fn := f.(type) {
+	case *timeOfDayFunc:
+		fn.bound.loc = loc
+	case *dayOfWeekFunc:
+		fn.loc = loc
+	default:
+		return false
+	}
+	return true
+}
+
+// linkTimeOfDayWindows - links the TimeOfDayGreaterThan and
+// TimeOfDayLessThan functions of functions whose times cross midnight,
+// see timeOfDayFunc. These functions are replaced by copies, hence
+// functions shared with other Functions are not affected.
+func linkTimeOfDayWindows(functions Functions) Functions {
+	var gts, lts []*timeOfDayFunc
+	for i, f := range functions {
+		fn, ok := f.(*timeOfDayFunc)
+		if !ok {
+			continue
+		}
+		fn = &timeOfDayFunc{n: fn.n, k: fn.k, bound: fn.bound}
+		functions[i] = fn
+		if fn.bound.c == greaterThan {
+			gts = append(gts, fn)
+		} else {
+			lts = append(lts, fn)
+		}
+	}
+
+	for _, gt := range gts {
+		for _, lt := range lts {
+			if lt.k != gt.k || lt.window != nil || lt.bound.value >= gt.bound.value {
+				continue
+			}
+			gtBound, ltBound := gt.bound, lt.bound
+			gt.window, lt.window = &ltBound, &gtBound
+			break
+		}
+	}
+	return functions
+}
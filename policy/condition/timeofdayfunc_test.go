@@ -0,0 +1,235 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package condition
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func mustParseFunctions(t *testing.T, data string) Functions {
+	t.Helper()
+	var functions Functions
+	if err := json.Unmarshal([]byte(data), &functions); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return functions
+}
+
+func TestTimeOfDayFuncEvaluate(t *testing.T) {
+	after := mustParseFunctions(t, `{"TimeOfDayGreaterThan": {"aws:CurrentTime": "23:59"}}`)
+	before := mustParseFunctions(t, `{"TimeOfDayLessThan": {"aws:CurrentTime": "00:01"}}`)
+	midnight := mustParseFunctions(t, `{"TimeOfDayGreaterThan": {"aws:CurrentTime": "00:00"}}`)
+	// From 22:00 to 06:00, crossing midnight.
+	night := mustParseFunctions(t, `{"TimeOfDayGreaterThan": {"aws:CurrentTime": "22:00"}, "TimeOfDayLessThan": {"aws:CurrentTime": "06:00"}}`)
+	// From 09:00 to 17:00.
+	day := mustParseFunctions(t, `{"TimeOfDayGreaterThan": {"aws:CurrentTime": "09:00"}, "TimeOfDayLessThan": {"aws:CurrentTime": "17:00"}}`)
+
+	testCases := []struct {
+		functions      Functions
+		currentTime    string
+		expectedResult bool
+	}{
+		{after, "2026-03-01T23:58:59Z", false},
+		{after, "2026-03-01T23:59:00Z", false},
+		{after, "2026-03-01T23:59:01Z", true},
+		{after, "2026-03-01T23:59:59Z", true},
+		{after, "2026-03-02T00:00:00Z", false},
+		{before, "2026-03-01T23:59:59Z", false},
+		{before, "2026-03-02T00:00:00Z", true},
+		{before, "2026-03-02T00:00:59Z", true},
+		{before, "2026-03-02T00:01:00Z", false},
+		{midnight, "2026-03-02T00:00:00Z", false},
+		{midnight, "2026-03-02T00:00:01Z", true},
+		{midnight, "2026-03-01T23:59:59Z", true},
+		{night, "2026-03-01T21:59:59Z", false},
+		{night, "2026-03-01T22:00:00Z", false},
+		{night, "2026-03-01T22:00:01Z", true},
+		{night, "2026-03-01T23:59:59Z", true},
+		{night, "2026-03-02T00:00:00Z", true},
+		{night, "2026-03-02T05:59:59Z", true},
+		{night, "2026-03-02T06:00:00Z", false},
+		{night, "2026-03-02T12:00:00Z", false},
+		{day, "2026-03-02T08:59:59Z", false},
+		{day, "2026-03-02T09:00:01Z", true},
+		{day, "2026-03-02T16:59:59Z", true},
+		{day, "2026-03-02T17:00:00Z", false},
+		{day, "2026-03-02T23:59:59Z", false},
+		{day, "2026-03-02T00:00:00Z", false},
+		// The request time is converted to UTC.
+		{day, "2026-03-02T10:00:00+05:00", false},
+		{day, "2026-03-02T20:00:00+05:00", true},
+		// Missing and invalid times.
+		{day, "", false},
+		{day, "09:30", false},
+	}
+
+	for i, testCase := range testCases {
+		values := map[string][]string{}
+		if testCase.currentTime != "" {
+			values["CurrentTime"] = []string{testCase.currentTime}
+		}
+		if result := testCase.functions.Evaluate(values); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestTimeOfDayFuncTimezone(t *testing.T) {
+	// Business hours in Berlin, whose offset changes from +01:00 to +02:00
+	// on 2026-03-29 and back on 2026-10-25.
+	hours := mustParseFunctions(t, `{
+  "TimeOfDayGreaterThan": {"aws:CurrentTime": "09:00", "minio:Timezone": "Europe/Berlin"},
+  "TimeOfDayLessThan": {"aws:CurrentTime": "17:00", "minio:Timezone": "Europe/Berlin"}
+}`)
+	// From 01:30 to 02:30 in New York, which does not exist on 2026-03-08.
+	gap := mustParseFunctions(t, `{
+  "TimeOfDayGreaterThan": {"aws:CurrentTime": "01:30", "minio:Timezone": "America/New_York"},
+  "TimeOfDayLessThan": {"aws:CurrentTime": "02:30", "minio:Timezone": "America/New_York"}
+}`)
+
+	testCases := []struct {
+		functions      Functions
+		currentTime    string
+		expectedResult bool
+	}{
+		{hours, "2026-03-28T08:30:00Z", true},
+		{hours, "2026-03-28T15:30:00Z", true},
+		{hours, "2026-03-28T16:00:00Z", false},
+		{hours, "2026-03-30T07:30:00Z", true},
+		{hours, "2026-03-30T15:30:00Z", false},
+		{hours, "2026-03-30T06:30:00Z", false},
+		{hours, "2026-10-26T07:30:00Z", false},
+		{hours, "2026-10-26T15:30:00Z", true},
+		{gap, "2026-03-08T06:45:00Z", true},  // 01:45 EST
+		{gap, "2026-03-08T07:15:00Z", false}, // 03:15 EDT
+		{gap, "2026-03-09T06:15:00Z", true},  // 02:15 EDT
+	}
+
+	for i, testCase := range testCases {
+		values := map[string][]string{"CurrentTime": {testCase.currentTime}}
+		if result := testCase.functions.Evaluate(values); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestTimeOfDayFuncJSON(t *testing.T) {
+	testCases := []struct {
+		data         string
+		expectedData string
+		expectErr    bool
+	}{
+		{`{"TimeOfDayGreaterThan": {"aws:CurrentTime": "09:00"}}`, `{"TimeOfDayGreaterThan":{"aws:CurrentTime":["09:00"]}}`, false},
+		{`{"TimeOfDayLessThan": {"aws:CurrentTime": ["17:30"], "minio:Timezone": "Asia/Kolkata"}}`, `{"TimeOfDayLessThan":{"aws:CurrentTime":["17:30"],"minio:Timezone":["Asia/Kolkata"]}}`, false},
+		{`{"DayOfWeekEquals": {"aws:CurrentTime": ["mon", "Fri"], "minio:Timezone": "UTC"}}`, `{"DayOfWeekEquals":{"aws:CurrentTime":["Fri","Mon"],"minio:Timezone":["UTC"]}}`, false},
+		// Other keys.
+		{`{"TimeOfDayGreaterThan": {"aws:EpochTime": "09:00"}}`, "", true},
+		{`{"DayOfWeekEquals": {"s3:prefix": "Mon"}}`, "", true},
+		// Invalid values.
+		{`{"TimeOfDayGreaterThan": {"aws:CurrentTime": "24:00"}}`, "", true},
+		{`{"TimeOfDayGreaterThan": {"aws:CurrentTime": "9am"}}`, "", true},
+		{`{"TimeOfDayGreaterThan": {"aws:CurrentTime": ["09:00", "10:00"]}}`, "", true},
+		{`{"DayOfWeekEquals": {"aws:CurrentTime": "Monday"}}`, "", true},
+		{`{"DayOfWeekEquals": {"aws:CurrentTime": []}}`, "", true},
+		// Invalid timezones.
+		{`{"TimeOfDayGreaterThan": {"aws:CurrentTime": "09:00", "minio:Timezone": "Mars/Olympus"}}`, "", true},
+		{`{"TimeOfDayGreaterThan": {"aws:CurrentTime": "09:00", "minio:Timezone": "Local"}}`, "", true},
+		{`{"TimeOfDayGreaterThan": {"aws:CurrentTime": "09:00", "minio:Timezone": ["UTC", "Europe/Berlin"]}}`, "", true},
+		{`{"TimeOfDayGreaterThan": {"minio:Timezone": "UTC"}}`, "", true},
+		{`{"DateGreaterThan": {"aws:CurrentTime": "2026-01-01T00:00:00Z", "minio:Timezone": "UTC"}}`, "", true},
+	}
+
+	for i, testCase := range testCases {
+		var functions Functions
+		err := json.Unmarshal([]byte(testCase.data), &functions)
+		if expectErr := err != nil; expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if testCase.expectErr {
+			continue
+		}
+
+		data, err := json.Marshal(functions)
+		if err != nil || string(data) != testCase.expectedData {
+			t.Fatalf("case %v: expected: %v, got: %s, %v", i+1, testCase.expectedData, data, err)
+		}
+		var decoded Functions
+		if err = json.Unmarshal(data, &decoded); err != nil || !decoded.Equals(functions) {
+			t.Fatalf("case %v: expected: %v, got: %v, %v", i+1, functions, decoded, err)
+		}
+	}
+}
+
+func TestNewTimeOfDayFunc(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gt, err := NewTimeOfDayGreaterThanFunc(AWSCurrentTime.ToKey(), 22*time.Hour, loc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lt, err := NewTimeOfDayLessThanFunc(AWSCurrentTime.ToKey(), 6*time.Hour, loc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Windows crossing midnight are formed by the Functions holding both
+	// bounds only.
+	night := NewFunctions(gt, lt)
+	values := map[string][]string{"CurrentTime": {"2026-07-01T23:30:00Z"}} // 01:30 CEST
+	if !night.Evaluate(values) {
+		t.Fatalf("expected: %v to allow %v", night, values)
+	}
+	if NewFunctions(lt).Evaluate(map[string][]string{"CurrentTime": {"2026-07-01T21:30:00Z"}}) {
+		t.Fatalf("expected: %v to deny", lt)
+	}
+	if clone := night.Clone(); !clone.Evaluate(values) {
+		t.Fatalf("expected: %v to allow %v", clone, values)
+	}
+
+	if _, err = NewTimeOfDayGreaterThanFunc(AWSCurrentTime.ToKey(), 24*time.Hour, nil); err == nil {
+		t.Fatalf("expected an error")
+	}
+	if _, err = NewTimeOfDayLessThanFunc(S3Prefix.ToKey(), time.Hour, nil); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestIsExtensionOperator(t *testing.T) {
+	testCases := []struct {
+		operator       string
+		expectedResult bool
+	}{
+		{"TimeOfDayGreaterThan", true},
+		{"TimeOfDayLessThan", true},
+		{"DayOfWeekEquals", true},
+		{"ForAnyValue:DayOfWeekEquals", true},
+		{"DateGreaterThan", false},
+		{"StringEquals", false},
+		{"Unknown", false},
+	}
+
+	for i, testCase := range testCases {
+		if result := IsExtensionOperator(testCase.operator); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
@@ -21,17 +21,75 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
 )
 
 func getValuesByKey(m map[string][]string, key Key) []string {
-	name := key.Name()
-	if values, found := m[http.CanonicalHeaderKey(name)]; found {
-		return values
+	values, found := lookupValues(m, key.Name())
+	switch {
+	case key.Is(S3XAmzCopySource) && len(values) > 0:
+		return decodeCopySources(values)
+	case key.Is(S3XAmzCopySourceVersionID) && !found:
+		sources, _ := lookupValues(m, S3XAmzCopySource.Name())
+		return copySourceVersionIDs(sources)
 	}
-	return m[name]
+	return values
+}
+
+// lookupValues - returns the values of the key name, or of the
+// canonical header key of name.
+func lookupValues(m map[string][]string, name string) ([]string, bool) {
+	values, found := m[http.CanonicalHeaderKey(name)]
+	if !found {
+		values, found = m[name]
+	}
+	return values, found
+}
+
+// splitCopySource - returns the "bucket/object" and the version ID of a
+// x-amz-copy-source header value. The header value is percent-encoded, it
+// is decoded exactly once after dropping the leading '/' and the
+// "?versionId=" suffix. Values which are not validly encoded are used as
+// is.
+func splitCopySource(value string) (source, versionID string) {
+	value = strings.TrimPrefix(value, "/")
+	if i := strings.Index(value, "?versionId="); i >= 0 {
+		value, versionID = value[:i], value[i+len("?versionId="):]
+		versionID, _, _ = strings.Cut(versionID, "&")
+		if v, err := url.QueryUnescape(versionID); err == nil {
+			versionID = v
+		}
+	}
+	if v, err := url.PathUnescape(value); err == nil {
+		value = v
+	}
+	return value, versionID
+}
+
+// decodeCopySources - returns x-amz-copy-source header values in the form
+// used by policies, i.e. "bucket/object", see splitCopySource.
+func decodeCopySources(values []string) []string {
+	decoded := make([]string, len(values))
+	for i, value := range values {
+		decoded[i], _ = splitCopySource(value)
+	}
+	return decoded
+}
+
+// copySourceVersionIDs - returns the version IDs of x-amz-copy-source
+// header values, see splitCopySource. Values without version ID are
+// skipped.
+func copySourceVersionIDs(values []string) []string {
+	var versionIDs []string
+	for _, value := range values {
+		if _, versionID := splitCopySource(value); versionID != "" {
+			versionIDs = append(versionIDs, versionID)
+		}
+	}
+	return versionIDs
 }
 
 // Splits an incoming path into bucket and object components.
@@ -51,12 +109,15 @@ func path2BucketAndObject(path string) (bucket, object string) {
 	return bucket, object
 }
 
-// Value - is enum type of string, int or bool.
+// Value - is enum type of string, int or bool. JSON numbers which are not
+// integers in the range of int64, e.g. 1.5 or 1e3, are string values
+// holding the number as written, they are encoded as JSON numbers again.
 type Value struct {
-	t reflect.Kind
-	s string
-	i int
-	b bool
+	t      reflect.Kind
+	s      string
+	i      int64
+	b      bool
+	number bool // s is a JSON number
 }
 
 // GetBool - gets stored bool value.
@@ -70,8 +131,18 @@ func (v Value) GetBool() (bool, error) {
 	return v.b, err
 }
 
-// GetInt - gets stored int value.
+// GetInt - gets stored int value, an error is returned if it overflows
+// int, see GetInt64.
 func (v Value) GetInt() (int, error) {
+	i, err := v.GetInt64()
+	if err == nil && int64(int(i)) != i {
+		err = fmt.Errorf("int Value %d out of range: %w", i, strconv.ErrRange)
+	}
+	return int(i), err
+}
+
+// GetInt64 - gets stored int value.
+func (v Value) GetInt64() (int64, error) {
 	var err error
 
 	if v.t != reflect.Int {
@@ -97,10 +168,18 @@ func (v Value) GetType() reflect.Kind {
 	return v.t
 }
 
+// IsNumber - returns whether the value was written as a JSON number.
+func (v Value) IsNumber() bool {
+	return v.t == reflect.Int || v.number
+}
+
 // MarshalJSON - encodes Value to JSON data.
 func (v Value) MarshalJSON() ([]byte, error) {
 	switch v.t {
 	case reflect.String:
+		if v.number {
+			return []byte(v.s), nil
+		}
 		return json.Marshal(v.s)
 	case reflect.Int:
 		return json.Marshal(v.i)
@@ -118,6 +197,11 @@ func (v *Value) StoreBool(b bool) {
 
 // StoreInt - stores int value.
 func (v *Value) StoreInt(i int) {
+	v.StoreInt64(int64(i))
+}
+
+// StoreInt64 - stores int value.
+func (v *Value) StoreInt64(i int64) {
 	*v = Value{t: reflect.Int, i: i}
 }
 
@@ -132,7 +216,7 @@ func (v Value) String() string {
 	case reflect.String:
 		return v.s
 	case reflect.Int:
-		return strconv.Itoa(v.i)
+		return strconv.FormatInt(v.i, 10)
 	case reflect.Bool:
 		return strconv.FormatBool(v.b)
 	}
@@ -148,10 +232,16 @@ func (v *Value) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
-	var i int
-	if err := json.Unmarshal(data, &i); err == nil {
-		v.StoreInt(i)
-		return nil
+	if len(data) > 0 && data[0] != '"' {
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err == nil {
+			if i, err := n.Int64(); err == nil {
+				v.StoreInt64(i)
+			} else {
+				*v = newNumberValue(n.String())
+			}
+			return nil
+		}
 	}
 
 	var s string
@@ -163,6 +253,11 @@ func (v *Value) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("unknown json data '%v'", data)
 }
 
+// newNumberValue - returns a string value holding the JSON number s.
+func newNumberValue(s string) Value {
+	return Value{t: reflect.String, s: s, number: true}
+}
+
 // NewBoolValue - returns new bool value.
 func NewBoolValue(b bool) Value {
 	value := &Value{}
@@ -177,6 +272,13 @@ func NewIntValue(i int) Value {
 	return *value
 }
 
+// NewInt64Value - returns new int value.
+func NewInt64Value(i int64) Value {
+	value := &Value{}
+	value.StoreInt64(i)
+	return *value
+}
+
 // NewStringValue - returns new string value.
 func NewStringValue(s string) Value {
 	value := &Value{}
@@ -19,7 +19,10 @@ package condition
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"reflect"
+	"strconv"
 	"testing"
 )
 
@@ -239,8 +242,13 @@ func TestValueUnmarshalJSON(t *testing.T) {
 		{[]byte("7"), NewIntValue(7), false},
 		{[]byte(`"foo"`), NewStringValue("foo"), false},
 		{[]byte("True"), Value{}, true},
-		{[]byte("7.1"), Value{}, true},
 		{[]byte(`["foo"]`), Value{}, true},
+		// Other numbers are kept as written.
+		{[]byte("7.1"), newNumberValue("7.1"), false},
+		{[]byte("9223372036854775807"), NewInt64Value(math.MaxInt64), false},
+		{[]byte("9223372036854775808"), newNumberValue("9223372036854775808"), false},
+		{[]byte("1e400"), newNumberValue("1e400"), false},
+		{[]byte(`"7"`), NewStringValue("7"), false},
 	}
 
 	for i, testCase := range testCases {
@@ -259,3 +267,91 @@ func TestValueUnmarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestValueJSONNumberRoundTrip(t *testing.T) {
+	for i, data := range []string{"1000", "-3", "7.10", "1e3", "9223372036854775808", "1e400", `"1000"`} {
+		var v Value
+		if err := json.Unmarshal([]byte(data), &v); err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		result, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if string(result) != data {
+			t.Fatalf("case %v: expected: %s, got: %s", i+1, data, result)
+		}
+	}
+}
+
+func TestValueGetIntRange(t *testing.T) {
+	v := NewInt64Value(1 << 40)
+	if i, err := v.GetInt64(); err != nil || i != 1<<40 {
+		t.Fatalf("expected: %v, got: %v, %v", int64(1<<40), i, err)
+	}
+	i, err := v.GetInt()
+	if strconv.IntSize == 32 {
+		if !errors.Is(err, strconv.ErrRange) {
+			t.Fatalf("expected: %v, got: %v", strconv.ErrRange, err)
+		}
+	} else if err != nil || int64(i) != 1<<40 {
+		t.Fatalf("expected: %v, got: %v, %v", int64(1<<40), i, err)
+	}
+}
+
+func TestGetValuesByKeyCopySource(t *testing.T) {
+	testCases := []struct {
+		values         map[string][]string
+		expectedResult []string
+	}{
+		{map[string][]string{"x-amz-copy-source": {"mybucket/myobject"}}, []string{"mybucket/myobject"}},
+		{map[string][]string{"X-Amz-Copy-Source": {"/mybucket/myobject"}}, []string{"mybucket/myobject"}},
+		{map[string][]string{"x-amz-copy-source": {"/mybucket/caf%C3%A9/a%2Fb?versionId=1234"}}, []string{"mybucket/café/a/b"}},
+		{map[string][]string{"x-amz-copy-source": {"mybucket/a+b%2Bc"}}, []string{"mybucket/a+b+c"}},
+		{map[string][]string{"x-amz-copy-source": {"mybucket/what%3FversionId=1"}}, []string{"mybucket/what?versionId=1"}},
+		{map[string][]string{"x-amz-copy-source": {"mybucket/100%25"}}, []string{"mybucket/100%"}},
+		{map[string][]string{"x-amz-copy-source": {"mybucket/100%"}}, []string{"mybucket/100%"}},
+		{map[string][]string{}, nil},
+	}
+
+	for i, testCase := range testCases {
+		result := getValuesByKey(testCase.values, S3XAmzCopySource.ToKey())
+
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	// Other keys are not decoded.
+	values := map[string][]string{"prefix": {"caf%C3%A9/"}}
+	if result := getValuesByKey(values, S3Prefix.ToKey()); !reflect.DeepEqual(result, values["prefix"]) {
+		t.Fatalf("expected: %v, got: %v", values["prefix"], result)
+	}
+}
+
+func TestSplitCopySource(t *testing.T) {
+	testCases := []struct {
+		value             string
+		expectedSource    string
+		expectedVersionID string
+	}{
+		{"mybucket/myobject", "mybucket/myobject", ""},
+		{"/mybucket/myobject", "mybucket/myobject", ""},
+		{"/mybucket/my%20object", "mybucket/my object", ""},
+		{"mybucket/my object", "mybucket/my object", ""},
+		{"/mybucket/myobject?versionId=v1", "mybucket/myobject", "v1"},
+		{"/mybucket/my%3Fobject?versionId=a%2Bb", "mybucket/my?object", "a+b"},
+		{"/mybucket/myobject?versionId=v1&x=y", "mybucket/myobject", "v1"},
+		// Decoded exactly once.
+		{"/mybucket/my%2520object", "mybucket/my%20object", ""},
+		// Not validly encoded values are used as is.
+		{"/mybucket/100%", "mybucket/100%", ""},
+	}
+
+	for i, testCase := range testCases {
+		source, versionID := splitCopySource(testCase.value)
+		if source != testCase.expectedSource || versionID != testCase.expectedVersionID {
+			t.Fatalf("case %v: expected: %v, %v, got: %v, %v", i+1, testCase.expectedSource, testCase.expectedVersionID, source, versionID)
+		}
+	}
+}
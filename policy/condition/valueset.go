@@ -20,6 +20,7 @@ package condition
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 )
 
 // ValueSet - unique list of values.
@@ -49,6 +50,9 @@ func (set ValueSet) MarshalJSON() ([]byte, error) {
 	if len(values) == 0 {
 		return nil, fmt.Errorf("invalid value set %v", set)
 	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].String() < values[j].String()
+	})
 
 	return json.Marshal(values)
 }
@@ -0,0 +1,203 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// decisionKey - key of cached verdicts. The fingerprint identifies the
+// policy, hence verdicts of a changed policy are never returned.
+type decisionKey struct {
+	fingerprint [16]byte
+	action      Action
+	resource    string
+}
+
+type decisionEntry struct {
+	key     decisionKey
+	verdict Verdict
+}
+
+// DecisionCache - bounded LRU cache of policy verdicts for requests which
+// do not depend on the request context, see CachedPolicy. It is safe for
+// concurrent use.
+type DecisionCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[decisionKey]*list.Element
+	lru      *list.List // of *decisionEntry, most recently used first
+
+	hits, misses atomic.Uint64
+}
+
+// NewDecisionCache - returns a cache holding the verdicts of up to
+// capacity requests.
+func NewDecisionCache(capacity int) *DecisionCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &DecisionCache{
+		capacity: capacity,
+		entries:  make(map[decisionKey]*list.Element, capacity),
+		lru:      list.New(),
+	}
+}
+
+func (c *DecisionCache) get(key decisionKey) (Verdict, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return VerdictNoMatch, false
+	}
+	c.lru.MoveToFront(e)
+	return e.Value.(*decisionEntry).verdict, true
+}
+
+func (c *DecisionCache) add(key decisionKey, verdict Verdict) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*decisionEntry).verdict = verdict
+		c.lru.MoveToFront(e)
+		return
+	}
+	if c.lru.Len() >= c.capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*decisionEntry).key)
+	}
+	c.entries[key] = c.lru.PushFront(&decisionEntry{key: key, verdict: verdict})
+}
+
+// Len - returns the number of cached verdicts.
+func (c *DecisionCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+// Stats - returns the number of cache hits and misses so far. Requests
+// which cannot be cached are not counted.
+func (c *DecisionCache) Stats() (hits, misses uint64) {
+	return c.hits.Load(), c.misses.Load()
+}
+
+// CachedPolicy - policy whose verdicts are cached in a DecisionCache, see
+// DecisionCache.Policy.
+type CachedPolicy struct {
+	Policy
+
+	cache       *DecisionCache
+	fingerprint [16]byte
+
+	// contextual holds the statements whose outcome depends on the
+	// request context, i.e. on conditions or policy variables.
+	contextual []Statement
+}
+
+// Policy - returns p with its verdicts cached in c. The verdict of a
+// request is cached if no statement with conditions or policy variables in
+// its resources matches the requested action, the evaluation of other
+// requests falls back to p.IsAllowed. Cached verdicts are keyed by the
+// fingerprint of p, hence a changed policy never gets the verdicts of its
+// previous version, which are evicted over time. p must not be modified
+// afterwards.
+func (c *DecisionCache) Policy(p Policy) CachedPolicy {
+	cp := CachedPolicy{
+		Policy:      p,
+		cache:       c,
+		fingerprint: p.Fingerprint(),
+	}
+	for _, statement := range p.Statements {
+		if statement.isContextual() {
+			cp.contextual = append(cp.contextual, statement)
+		}
+	}
+	return cp
+}
+
+// isContextual - returns whether the outcome of this statement depends on
+// the request context in addition to the action and resource.
+func (statement Statement) isContextual() bool {
+	if len(statement.Conditions) > 0 {
+		return true
+	}
+	for r := range statement.Resources {
+		if strings.IndexByte(r.Pattern, '$') >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheable - returns whether the verdict for args only depends on the
+// action and the resource of the request.
+func (cp CachedPolicy) cacheable(args Args) bool {
+	if args.IsOwner || args.DenyOnly {
+		return false
+	}
+	for _, statement := range cp.contextual {
+		if statement.matchAction(args.Action) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsAllowed - checks given policy args is allowed to continue the Rest
+// API, as Policy.IsAllowed does, using cached verdicts where possible.
+func (cp CachedPolicy) IsAllowed(args Args) bool {
+	if cp.cache == nil || !cp.cacheable(args) {
+		return cp.Policy.IsAllowed(args)
+	}
+
+	observer := evaluationObserver.Load()
+	var start time.Time
+	if observer != nil {
+		start = time.Now()
+	}
+
+	key := decisionKey{fingerprint: cp.fingerprint, action: args.Action, resource: requestResource(args)}
+	verdict, ok := cp.cache.get(key)
+	if ok {
+		cp.cache.hits.Add(1)
+	} else {
+		cp.cache.misses.Add(1)
+		args.NormalizeConditions()
+		verdict = cp.evaluate(args, key.resource)
+		cp.cache.add(key, verdict)
+	}
+
+	if observer != nil {
+		if ok {
+			// Observers receive normalized arguments.
+			args.NormalizeConditions()
+		}
+		(*observer)(args.clone(), verdict == VerdictAllow, verdict == VerdictDeny, time.Since(start))
+	}
+	return verdict == VerdictAllow
+}
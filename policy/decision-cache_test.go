@@ -0,0 +1,188 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const decisionCachePolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject", "s3:ListBucket"], "Resource": ["arn:aws:s3:::mybucket", "arn:aws:s3:::mybucket/*"]},
+    {"Effect": "Deny", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/private/*"]},
+    {"Effect": "Allow", "Action": ["s3:PutObject"], "Resource": ["arn:aws:s3:::mybucket/*"], "Condition": {"IpAddress": {"aws:SourceIp": ["10.0.0.0/8"]}}},
+    {"Effect": "Allow", "Action": ["s3:DeleteObject"], "Resource": ["arn:aws:s3:::mybucket/${aws:username}/*"]}
+  ]
+}`
+
+func mustParsePolicy(t testing.TB, data string) Policy {
+	t.Helper()
+	p, err := ParseConfig(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return *p
+}
+
+func TestDecisionCache(t *testing.T) {
+	p := mustParsePolicy(t, decisionCachePolicy)
+	cache := NewDecisionCache(100)
+	cp := cache.Policy(p)
+
+	testCases := []struct {
+		args      Args
+		expected  bool
+		cacheable bool
+	}{
+		{Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "a.txt"}, true, true},
+		{Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "private/a.txt"}, false, true},
+		{Args{Action: GetObjectAction, BucketName: "otherbucket", ObjectName: "a.txt"}, false, true},
+		{Args{Action: ListBucketAction, BucketName: "mybucket"}, true, true},
+		{Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "private%2Fa.txt", ObjectNameEncoded: true}, false, true},
+		// Owners and deny only requests are not cached.
+		{Args{Action: GetObjectAction, BucketName: "otherbucket", ObjectName: "a.txt", IsOwner: true}, true, false},
+		{Args{Action: PutBucketPolicyAction, BucketName: "mybucket", DenyOnly: true}, true, false},
+		// Statements with conditions or policy variables are not cached.
+		{Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "a.txt", ConditionValues: map[string][]string{"SourceIp": {"10.1.2.3"}}}, true, false},
+		{Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "a.txt", ConditionValues: map[string][]string{"SourceIp": {"192.168.1.1"}}}, false, false},
+		{Args{Action: DeleteObjectAction, BucketName: "mybucket", ObjectName: "alice/a.txt", ConditionValues: map[string][]string{"username": {"alice"}}}, true, false},
+		{Args{Action: DeleteObjectAction, BucketName: "mybucket", ObjectName: "alice/a.txt", ConditionValues: map[string][]string{"username": {"bob"}}}, false, false},
+	}
+
+	for round := 0; round < 2; round++ {
+		for i, testCase := range testCases {
+			if result := cp.IsAllowed(testCase.args); result != testCase.expected {
+				t.Fatalf("round %v, case %v: expected: %v, got: %v", round+1, i+1, testCase.expected, result)
+			}
+			if result := p.IsAllowed(testCase.args); result != testCase.expected {
+				t.Fatalf("round %v, case %v: uncached: expected: %v, got: %v", round+1, i+1, testCase.expected, result)
+			}
+			if result := cp.cacheable(testCase.args); result != testCase.cacheable {
+				t.Fatalf("round %v, case %v: cacheable: expected: %v, got: %v", round+1, i+1, testCase.cacheable, result)
+			}
+		}
+	}
+
+	// The encoded object name shares the entry of the decoded name.
+	hits, misses := cache.Stats()
+	if hits != 6 || misses != 4 || cache.Len() != 4 {
+		t.Fatalf("expected: 6 hits, 4 misses and 4 entries, got: %v, %v, %v", hits, misses, cache.Len())
+	}
+}
+
+func TestDecisionCachePolicyChange(t *testing.T) {
+	cache := NewDecisionCache(100)
+	allow := mustParsePolicy(t, decisionCachePolicy)
+	deny := mustParsePolicy(t, strings.Replace(decisionCachePolicy, `"arn:aws:s3:::mybucket/private/*"`, `"arn:aws:s3:::mybucket/*"`, 1))
+	args := Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "a.txt"}
+
+	// Flip the policy back and forth, each version must get its own
+	// verdict, cached or not.
+	for i := 0; i < 4; i++ {
+		if !cache.Policy(allow).IsAllowed(args) {
+			t.Fatalf("round %v: expected allowed", i+1)
+		}
+		if cache.Policy(deny).IsAllowed(args) {
+			t.Fatalf("round %v: expected a stale allow to be ignored", i+1)
+		}
+	}
+
+	// A policy modified in place gets a new fingerprint once cached again.
+	modified := mustParsePolicy(t, decisionCachePolicy)
+	if !cache.Policy(modified).IsAllowed(args) {
+		t.Fatalf("expected allowed")
+	}
+	modified.Statements[0].Effect = Deny
+	if cache.Policy(modified).IsAllowed(args) {
+		t.Fatalf("expected a stale allow to be ignored")
+	}
+}
+
+func TestDecisionCacheEviction(t *testing.T) {
+	cache := NewDecisionCache(2)
+	cp := cache.Policy(mustParsePolicy(t, decisionCachePolicy))
+
+	for _, object := range []string{"a", "b", "a", "c"} {
+		cp.IsAllowed(Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: object})
+	}
+	if cache.Len() != 2 {
+		t.Fatalf("expected: 2 entries, got: %v", cache.Len())
+	}
+	// "b" was the least recently used and has been evicted.
+	for i, object := range []string{"a", "c", "b"} {
+		key := decisionKey{fingerprint: cp.fingerprint, action: GetObjectAction, resource: "mybucket/" + object}
+		if _, ok := cache.get(key); ok != (i < 2) {
+			t.Fatalf("%v: expected cached: %v", object, i < 2)
+		}
+	}
+}
+
+func TestDecisionCacheConcurrent(t *testing.T) {
+	cache := NewDecisionCache(16)
+	allow := mustParsePolicy(t, decisionCachePolicy)
+	deny := mustParsePolicy(t, strings.Replace(decisionCachePolicy, `"arn:aws:s3:::mybucket/private/*"`, `"arn:aws:s3:::mybucket/*"`, 1))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				p, expected := allow, true
+				if (g+i)%2 == 1 {
+					p, expected = deny, false
+				}
+				args := Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: fmt.Sprintf("obj-%d", i%32)}
+				if result := cache.Policy(p).IsAllowed(args); result != expected {
+					errs <- fmt.Errorf("goroutine %v, request %v: expected: %v, got: %v", g, i, expected, result)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+}
+
+func BenchmarkDecisionCache(b *testing.B) {
+	p := mustParsePolicy(b, decisionCachePolicy)
+	args := Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "photos/2025/a.jpg"}
+
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			p.IsAllowed(args)
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		cp := NewDecisionCache(1024).Policy(p)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			cp.IsAllowed(args)
+		}
+	})
+}
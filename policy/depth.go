@@ -0,0 +1,87 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import "sync/atomic"
+
+// DefaultMaxDepth - default maximum nesting depth of the JSON objects and
+// arrays of policy documents, see SetMaxDepth. Valid policies are nested at
+// most 6 levels deep.
+const DefaultMaxDepth = 20
+
+var maxDepth atomic.Int64
+
+func init() {
+	maxDepth.Store(DefaultMaxDepth)
+}
+
+// SetMaxDepth - sets the maximum nesting depth of policy and bucket policy
+// documents, deeper documents are rejected with ErrMaxDepthExceeded before
+// they are decoded, which bounds the recursion of the JSON decoder. Zero
+// or a negative depth means unlimited, encoding/json then still rejects
+// documents nested more than 10000 levels deep.
+func SetMaxDepth(depth int) {
+	maxDepth.Store(int64(depth))
+}
+
+// MaxDepth - returns the maximum nesting depth of policy documents, see
+// SetMaxDepth.
+func MaxDepth() int {
+	return int(maxDepth.Load())
+}
+
+// jsonDepth - returns the maximum nesting depth of the objects and arrays
+// of the JSON document data, which need not be valid.
+func jsonDepth(data []byte) int {
+	var depth, deepest int
+	var inString, escaped bool
+	for _, c := range data {
+		switch {
+		case escaped:
+			escaped = false
+		case inString:
+			switch c {
+			case '\\':
+				escaped = true
+			case '"':
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			depth++
+			deepest = max(deepest, depth)
+		case c == '}' || c == ']':
+			depth--
+		}
+	}
+	return deepest
+}
+
+// checkDepth - returns ErrMaxDepthExceeded if the JSON document data is
+// nested deeper than MaxDepth.
+func checkDepth(data []byte) error {
+	limit := MaxDepth()
+	if limit <= 0 {
+		return nil
+	}
+	if depth := jsonDepth(data); depth > limit {
+		return Errorf("%w", ErrMaxDepthExceeded{Depth: depth, MaxDepth: limit})
+	}
+	return nil
+}
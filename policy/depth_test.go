@@ -0,0 +1,135 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// deepPolicy - returns a policy document whose condition values are
+// nested depth arrays deep.
+func deepPolicy(depth int, principal bool) string {
+	p := ""
+	if principal {
+		p = `"Principal": "*", `
+	}
+	return `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", ` + p + `"Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*", "Condition": {"StringEquals": {"aws:username": ` +
+		strings.Repeat("[", depth) + `"private"` + strings.Repeat("]", depth) + `}}}]}`
+}
+
+func TestMaxDepth(t *testing.T) {
+	testCases := []struct {
+		data          string
+		expectedDepth int // zero if the document is not too deep
+	}{
+		{deepPolicy(1, false), 0},
+		{deepPolicy(16, false), 21},
+		{deepPolicy(10000, false), 10005},
+		{deepPolicy(20000, false), 20005},
+		// Invalid JSON is rejected, too.
+		{strings.Repeat("[", 20000), 20000},
+	}
+
+	for i, testCase := range testCases {
+		var expectedErr error
+		if testCase.expectedDepth > 0 {
+			expectedErr = ErrMaxDepthExceeded{Depth: testCase.expectedDepth, MaxDepth: DefaultMaxDepth}
+		}
+		bucketData := strings.Replace(testCase.data, `"Effect"`, `"Principal": "*", "Effect"`, 1)
+
+		var p Policy
+		errs := []error{json.Unmarshal([]byte(testCase.data), &p)}
+		_, err := ParseConfig(strings.NewReader(testCase.data))
+		errs = append(errs, err)
+		_, err = ParseConfigWithOptions(strings.NewReader(testCase.data), ValidationOptions{AllowLegacyDocuments: true})
+		errs = append(errs, err)
+		_, err = ParseBucketPolicyConfig(strings.NewReader(bucketData), "mybucket")
+		errs = append(errs, err)
+
+		for _, err := range errs {
+			if testCase.expectedDepth > 10000 {
+				// Deeper documents are rejected by encoding/json before
+				// UnmarshalJSON is called.
+				if err == nil {
+					t.Fatalf("case %v: expected an error, got: %v", i+1, err)
+				}
+				continue
+			}
+			var depthErr ErrMaxDepthExceeded
+			if !errors.As(err, &depthErr) {
+				depthErr = ErrMaxDepthExceeded{}
+			}
+			if (expectedErr == nil && depthErr != ErrMaxDepthExceeded{}) || (expectedErr != nil && depthErr != expectedErr) {
+				t.Fatalf("case %v: expected: %v, got: %v", i+1, expectedErr, err)
+			}
+			if expectedErr != nil && !errors.Is(err, ErrPolicyTooDeep) {
+				t.Fatalf("case %v: expected: %v, got: %v", i+1, ErrPolicyTooDeep, err)
+			}
+		}
+	}
+}
+
+func TestSetMaxDepth(t *testing.T) {
+	defer SetMaxDepth(DefaultMaxDepth)
+
+	if MaxDepth() != DefaultMaxDepth {
+		t.Fatalf("expected: %v, got: %v", DefaultMaxDepth, MaxDepth())
+	}
+
+	// Deeper documents are decoded, and rejected as invalid policies.
+	SetMaxDepth(0)
+	if _, err := ParseConfig(strings.NewReader(deepPolicy(100, false))); err == nil || errors.Is(err, ErrPolicyTooDeep) {
+		t.Fatalf("expected an invalid policy, got: %v", err)
+	}
+
+	SetMaxDepth(6)
+	if _, err := ParseConfig(strings.NewReader(deepPolicy(1, false))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := ParseConfig(strings.NewReader(deepPolicy(2, false))); !errors.Is(err, ErrPolicyTooDeep) {
+		t.Fatalf("expected: %v, got: %v", ErrPolicyTooDeep, err)
+	}
+	if _, err := ParseBucketPolicyConfig(strings.NewReader(deepPolicy(2, true)), "mybucket"); !errors.Is(err, ErrPolicyTooDeep) {
+		t.Fatalf("expected: %v, got: %v", ErrPolicyTooDeep, err)
+	}
+}
+
+// BenchmarkMaxDepth shows that checking the depth of a policy document is
+// negligible compared to decoding it.
+func BenchmarkMaxDepth(b *testing.B) {
+	data := testPolicyDoc(1)
+
+	b.Run("jsonDepth", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			jsonDepth(data)
+		}
+	})
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var p Policy
+			if err := json.Unmarshal(data, &p); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
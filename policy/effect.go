@@ -17,7 +17,9 @@
 
 package policy
 
-// Effect - policy statement effect Allow or Deny.
+// Effect - policy statement effect, Allow or Deny. No other effects
+// exist: statements of other effects are invalid, they neither allow nor
+// deny any request, even if they are evaluated without being validated.
 type Effect string
 
 const (
@@ -25,16 +27,30 @@ const (
 	Allow Effect = "Allow"
 
 	// Deny - deny effect.
-	Deny = "Deny"
+	Deny Effect = "Deny"
 )
 
-// IsAllowed - returns if given check is allowed or not.
+// ParseEffect - parses an effect in any case, such as "allow", returning
+// Allow or Deny.
+func ParseEffect(s string) (Effect, error) {
+	if effect := normalizeEffect(Effect(s)); effect.IsValid() {
+		return effect, nil
+	}
+	return "", Errorf("%w %v", ErrInvalidEffect, s)
+}
+
+// IsAllowed - returns whether a statement of this effect allows a request
+// the statement applies to if b, i.e. b for Allow and !b for Deny. Invalid
+// effects never allow.
 func (effect Effect) IsAllowed(b bool) bool {
-	if effect == Allow {
+	switch effect {
+	case Allow:
 		return b
+	case Deny:
+		return !b
 	}
 
-	return !b
+	return false
 }
 
 // IsValid - checks if Effect is valid or not
@@ -18,6 +18,7 @@
 package policy
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -31,6 +32,10 @@ func TestEffectIsAllowed(t *testing.T) {
 		{Allow, true, true},
 		{Deny, false, true},
 		{Deny, true, false},
+		{Effect("foo"), false, false},
+		{Effect("foo"), true, false},
+		{Effect("allow"), true, false},
+		{Effect(""), false, false},
 	}
 
 	for i, testCase := range testCases {
@@ -61,3 +66,85 @@ func TestEffectIsValid(t *testing.T) {
 		}
 	}
 }
+
+func TestParseEffect(t *testing.T) {
+	testCases := []struct {
+		s              string
+		expectedEffect Effect
+		expectErr      bool
+	}{
+		{"Allow", Allow, false},
+		{"allow", Allow, false},
+		{"DENY", Deny, false},
+		{"Deny", Deny, false},
+		{"", "", true},
+		{"Permit", "", true},
+		{" Allow", "", true},
+	}
+
+	for i, testCase := range testCases {
+		effect, err := ParseEffect(testCase.s)
+		if expectErr := err != nil; expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidEffect) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, ErrInvalidEffect, err)
+		}
+		if effect != testCase.expectedEffect {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedEffect, effect)
+		}
+	}
+}
+
+func TestInvalidEffectStatements(t *testing.T) {
+	// Statements built programmatically, without validation.
+	bogus := NewStatement("", Effect("foo"), NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/*")), nil)
+	other := NewStatement("", Effect("foo"), NewActionSet(PutObjectAction), NewResourceSet(NewResource("mybucket/*")), nil)
+	allow := NewStatement("", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/*")), nil)
+	args := Args{AccountName: "alice", Action: GetObjectAction, BucketName: "mybucket", ObjectName: "object"}
+
+	// A statement not applying to the request does not allow it either.
+	if bogus.IsAllowed(args) || other.IsAllowed(args) {
+		t.Fatalf("expected statements of invalid effect not to allow")
+	}
+
+	testCases := []struct {
+		statements     []Statement
+		expectedResult Verdict
+	}{
+		{[]Statement{bogus}, VerdictNoMatch},
+		{[]Statement{other}, VerdictNoMatch},
+		{[]Statement{bogus, allow}, VerdictAllow},
+	}
+	for i, testCase := range testCases {
+		p := MergePolicies(Policy{Version: DefaultVersion, Statements: testCase.statements})
+		if result := p.EvaluateWithReason(args).Verdict; result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+		if _, err := p.EvaluateStrict(args); !errors.Is(err, ErrInvalidEffect) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, ErrInvalidEffect, err)
+		}
+		if err := p.Validate(); !errors.Is(err, ErrInvalidEffect) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, ErrInvalidEffect, err)
+		}
+	}
+
+	p := Policy{Version: DefaultVersion, Statements: []Statement{allow}}
+	if verdict, err := p.EvaluateStrict(args); err != nil || verdict != VerdictAllow {
+		t.Fatalf("expected: %v, got: %v, %v", VerdictAllow, verdict, err)
+	}
+
+	// Access matrices do not count them as allowing.
+	matrix := AccessMatrix(Policy{Version: DefaultVersion, Statements: []Statement{bogus}}, []string{"mybucket"}, nil)
+	if access := matrix["mybucket"]; access.CanRead {
+		t.Fatalf("expected no access, got: %+v", access)
+	}
+
+	bp := BucketPolicy{Version: DefaultVersion, Statements: []BPStatement{
+		NewBPStatement("", Effect("foo"), NewPrincipal("*"), NewActionSet(PutObjectAction), NewResourceSet(NewResource("mybucket/*")), nil),
+	}}
+	bpArgs := BucketPolicyArgs{AccountName: "alice", Action: GetObjectAction, BucketName: "mybucket", ObjectName: "object"}
+	if bp.Statements[0].IsAllowed(bpArgs) || bp.IsAllowed(bpArgs) {
+		t.Fatalf("expected statements of invalid effect not to allow")
+	}
+}
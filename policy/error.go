@@ -18,9 +18,127 @@
 package policy
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+
+	"github.com/minio/pkg/v3/policy/condition"
 )
 
+// Validation errors, all validation failures wrap one of these
+// sentinels or one of the Err* types below so that callers can use
+// errors.Is and errors.As instead of matching error messages.
+var (
+	ErrInvalidVersion     = errors.New("invalid version")
+	ErrInvalidEffect      = errors.New("invalid Effect")
+	ErrInvalidPrincipal   = errors.New("invalid principal")
+	ErrInvalidSID         = errors.New("invalid SID")
+	ErrDuplicateSID       = errors.New("duplicate SID")
+	ErrInvalidDescription = errors.New("invalid Description")
+	ErrEmptyStatements    = errors.New("Statement must not be empty")
+	ErrEmptyActions       = errors.New("Action must not be empty")
+	ErrEmptyResources     = errors.New("Resource must not be empty")
+	ErrDuplicateResource  = errors.New("duplicate resource")
+	ErrBucketNameMismatch = errors.New("bucket name does not match")
+
+	ErrActionAndNotAction     = errors.New("Action and NotAction must not be both set")
+	ErrResourceAndNotResource = errors.New("Resource and NotResource must not be both set")
+
+	ErrPolicyTooLarge    = errors.New("policy document too large")
+	ErrPolicyTooDeep     = errors.New("policy document nested too deeply")
+	ErrTooManyStatements = errors.New("too many statements")
+
+	ErrTooManyConditionOperators = errors.New("too many condition operators")
+	ErrTooManyConditionValues    = errors.New("too many condition values")
+
+	ErrSessionPolicyPrincipal = errors.New("Principal must not be set in session policies")
+	ErrSessionPolicyNotAction = errors.New("NotAction must not be used in session policies")
+)
+
+// ErrUnsupportedAction - action is not supported.
+type ErrUnsupportedAction struct {
+	Action Action
+	// Kind of the action, e.g. "admin", "STS" or "KMS", empty for S3 actions.
+	Kind string
+	// SID of the statement using the action, if known.
+	SID ID
+}
+
+func (e ErrUnsupportedAction) Error() string {
+	msg := fmt.Sprintf("unsupported action '%v'", e.Action)
+	if e.Kind != "" {
+		msg = fmt.Sprintf("unsupported %v action '%v'", e.Kind, e.Action)
+	}
+	if e.SID != "" {
+		msg += fmt.Sprintf(" in statement '%v'", e.SID)
+	}
+	return msg
+}
+
+// ErrMaxDepthExceeded - policy document is nested deeper than allowed, see
+// SetMaxDepth. It wraps ErrPolicyTooDeep.
+type ErrMaxDepthExceeded struct {
+	Depth    int
+	MaxDepth int
+}
+
+func (e ErrMaxDepthExceeded) Error() string {
+	return fmt.Sprintf("%v: %d levels exceed %d levels", ErrPolicyTooDeep, e.Depth, e.MaxDepth)
+}
+
+// Unwrap - returns ErrPolicyTooDeep.
+func (e ErrMaxDepthExceeded) Unwrap() error { return ErrPolicyTooDeep }
+
+// ErrMixedActions - statement actions belong to services with different
+// resource semantics, e.g. "s3" and "admin".
+type ErrMixedActions struct {
+	Namespaces []string
+	// SID of the statement, if known.
+	SID ID
+}
+
+func (e ErrMixedActions) Error() string {
+	msg := fmt.Sprintf("actions of services '%v' must not be mixed", strings.Join(e.Namespaces, "', '"))
+	if e.SID != "" {
+		msg += fmt.Sprintf(" in statement '%v'", e.SID)
+	}
+	return msg
+}
+
+// ErrUnsupportedConditionKey - condition keys are not supported by action.
+type ErrUnsupportedConditionKey struct {
+	Action Action
+	Keys   condition.KeySet
+}
+
+func (e ErrUnsupportedConditionKey) Error() string {
+	return fmt.Sprintf("unsupported condition keys '%v' used for action '%v'", e.Keys, e.Action)
+}
+
+// ErrUnsupportedResource - resources do not apply to action.
+type ErrUnsupportedResource struct {
+	Action    Action
+	Resources ResourceSet
+}
+
+func (e ErrUnsupportedResource) Error() string {
+	return fmt.Sprintf("unsupported Resource found %v for action %v", e.Resources, e.Action)
+}
+
+// ErrMalformedResource - resource is malformed or not allowed where it is used.
+type ErrMalformedResource struct {
+	Resource string
+	// Reason is an optional explanation of why the resource is malformed.
+	Reason string
+}
+
+func (e ErrMalformedResource) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("invalid resource '%v'", e.Resource)
+	}
+	return fmt.Sprintf("invalid resource '%v' - %v", e.Resource, e.Reason)
+}
+
 // Error is the generic type for any error happening during policy
 // parsing.
 type Error struct {
@@ -0,0 +1,197 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// isTypedError - returns whether err wraps one of the exported sentinels
+// or typed errors of the package. New ones must be added here.
+func isTypedError(err error) bool {
+	for _, sentinel := range []error{
+		ErrInvalidVersion,
+		ErrInvalidEffect,
+		ErrInvalidPrincipal,
+		ErrInvalidSID,
+		ErrDuplicateSID,
+		ErrInvalidDescription,
+		ErrEmptyStatements,
+		ErrEmptyActions,
+		ErrEmptyResources,
+		ErrDuplicateResource,
+		ErrBucketNameMismatch,
+		ErrActionAndNotAction,
+		ErrResourceAndNotResource,
+		ErrPolicyTooLarge,
+		ErrPolicyTooDeep,
+		ErrTooManyStatements,
+		ErrTooManyConditionOperators,
+		ErrTooManyConditionValues,
+		ErrSessionPolicyPrincipal,
+		ErrSessionPolicyNotAction,
+		ErrUnsupportedACL,
+	} {
+		if errors.Is(err, sentinel) {
+			return true
+		}
+	}
+
+	var unsupportedAction ErrUnsupportedAction
+	var maxDepthExceeded ErrMaxDepthExceeded
+	var mixedActions ErrMixedActions
+	var unsupportedConditionKey ErrUnsupportedConditionKey
+	var unsupportedResource ErrUnsupportedResource
+	var malformedResource ErrMalformedResource
+	return errors.As(err, &unsupportedAction) ||
+		errors.As(err, &maxDepthExceeded) ||
+		errors.As(err, &mixedActions) ||
+		errors.As(err, &unsupportedConditionKey) ||
+		errors.As(err, &unsupportedResource) ||
+		errors.As(err, &malformedResource)
+}
+
+func TestPolicyValidationErrorsAreTyped(t *testing.T) {
+	testCases := []struct {
+		data     string
+		expected error
+	}{
+		{`{"Version": "2011-10-17", "Statement": []}`, ErrInvalidVersion},
+		{`{"Statement": [{"Effect": "Maybe", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"}]}`, ErrInvalidEffect},
+		{`{"Statement": [{"Effect": "Allow", "Action": [], "Resource": "arn:aws:s3:::mybucket/*"}]}`, ErrEmptyActions},
+		{`{"Statement": [{"Effect": "Allow", "Action": "s3:GetObject"}]}`, ErrEmptyResources},
+		{`{"Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "NotAction": "s3:PutObject", "Resource": "arn:aws:s3:::mybucket/*"}]}`, ErrActionAndNotAction},
+		{`{"Statement": [{"Effect": "Allow", "Action": "s3:Foo", "Resource": "arn:aws:s3:::mybucket/*"}]}`, ErrUnsupportedAction{}},
+		{`{"Statement": [{"Effect": "Allow", "Action": "admin:Foo", "Resource": "arn:aws:s3:::mybucket/*"}]}`, ErrUnsupportedAction{}},
+		{`{"Statement": [{"Effect": "Allow", "Action": "kms:Foo", "Resource": "arn:aws:s3:::mybucket/*"}]}`, ErrUnsupportedAction{}},
+		{`{"Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*", "Condition": {"StringEquals": {"s3:x-amz-copy-source": "mybucket/myobject"}}}]}`, ErrUnsupportedConditionKey{}},
+		{`{"Statement": [{"Effect": "Allow", "Action": "admin:CreateUser", "Condition": {"StringEquals": {"s3:x-amz-copy-source": "mybucket/myobject"}}}]}`, ErrUnsupportedConditionKey{}},
+		{`{"Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "mybucket/*"}]}`, ErrMalformedResource{}},
+		{`{"Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::/*"}]}`, ErrMalformedResource{}},
+		{`{"Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:minio:kms:::mykey"}]}`, ErrMalformedResource{}},
+		{`{"Statement": [{"Effect": "Allow", "Action": ["s3:GetObject", "admin:ServerInfo"], "Resource": "arn:aws:s3:::mybucket/*"}]}`, ErrMixedActions{}},
+		{`{"Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*", "Description": "` + strings.Repeat("a", MaxStatementDescriptionLength+1) + `"}]}`, ErrInvalidDescription},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseConfig(strings.NewReader(testCase.data))
+		if err == nil {
+			t.Fatalf("case %v: expected error, got nil", i+1)
+		}
+		if !isTypedError(err) {
+			t.Fatalf("case %v: expected typed error, got: %v", i+1, err)
+		}
+		if !errorMatches(err, testCase.expected) {
+			t.Fatalf("case %v: expected: %T (%v), got: %v", i+1, testCase.expected, testCase.expected, err)
+		}
+	}
+
+	// Policies without statements are only rejected on demand.
+	_, err := ParseConfigWithOptions(strings.NewReader(`{"Version": "2012-10-17", "Statement": []}`), ValidationOptions{RejectEmptyStatements: true})
+	if !isTypedError(err) || !errors.Is(err, ErrEmptyStatements) {
+		t.Fatalf("expected: %v, got: %v", ErrEmptyStatements, err)
+	}
+	if _, err := ParseConfig(strings.NewReader(`{"Version": "2012-10-17", "Statement": []}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// JSON decoding replaces invalid UTF-8, hence invalid SID is only
+	// reachable through the API.
+	statement := NewStatement(ID("\xff"), Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/*")), nil)
+	if err := statement.isValid(); !errors.Is(err, ErrInvalidSID) {
+		t.Fatalf("expected: %v, got: %v", ErrInvalidSID, err)
+	}
+}
+
+func TestBucketPolicyValidationErrorsAreTyped(t *testing.T) {
+	testCases := []struct {
+		data     string
+		expected error
+	}{
+		{`{"Version": "2011-10-17", "Statement": []}`, ErrInvalidVersion},
+		{`{"Statement": [{"Effect": "Maybe", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"}]}`, ErrInvalidEffect},
+		{`{"Statement": [{"Effect": "Allow", "Principal": "foo", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"}]}`, ErrInvalidPrincipal},
+		{`{"Statement": [{"Effect": "Allow", "Principal": {"AWS": []}, "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"}]}`, ErrInvalidPrincipal},
+		{`{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": [], "Resource": "arn:aws:s3:::mybucket/*"}]}`, ErrEmptyActions},
+		{`{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject"}]}`, ErrEmptyResources},
+		{`{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "NotAction": "s3:PutObject", "Resource": "arn:aws:s3:::mybucket/*"}]}`, ErrActionAndNotAction},
+		{`{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*", "NotResource": "arn:aws:s3:::mybucket/secret/*"}]}`, ErrResourceAndNotResource},
+		{`{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket"}]}`, ErrUnsupportedResource{}},
+		{`{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:ListBucket", "Resource": "arn:aws:s3:::mybucket/*"}]}`, ErrUnsupportedResource{}},
+		{`{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*", "Condition": {"StringEquals": {"s3:x-amz-copy-source": "mybucket/myobject"}}}]}`, ErrUnsupportedConditionKey{}},
+		{`{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::yourbucket/*"}]}`, ErrBucketNameMismatch},
+		{`{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "mybucket/*"}]}`, ErrMalformedResource{}},
+		{`{"Statement": [{"Sid": "A", "Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"}, {"Sid": "A", "Effect": "Deny", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"}]}`, ErrDuplicateSID},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseBucketPolicyConfig(strings.NewReader(testCase.data), "mybucket")
+		if err == nil {
+			t.Fatalf("case %v: expected error, got nil", i+1)
+		}
+		if !isTypedError(err) {
+			t.Fatalf("case %v: expected typed error, got: %v", i+1, err)
+		}
+		if !errorMatches(err, testCase.expected) {
+			t.Fatalf("case %v: expected: %T (%v), got: %v", i+1, testCase.expected, testCase.expected, err)
+		}
+	}
+}
+
+func TestErrorMessages(t *testing.T) {
+	testCases := []struct {
+		err            error
+		expectedResult string
+	}{
+		{Errorf("%w '%v'", ErrInvalidVersion, "2011-10-17"), "invalid version '2011-10-17'"},
+		{Errorf("%w", ErrUnsupportedAction{Action: "s3:Bar"}), "unsupported action 's3:Bar'"},
+		{Errorf("%w", ErrUnsupportedAction{Action: "admin:Bar", Kind: "admin"}), "unsupported admin action 'admin:Bar'"},
+		{Errorf("%w", ErrMalformedResource{Resource: "arn:aws:s3:::/*", Reason: "starts with '/' will not match a bucket"}), "invalid resource 'arn:aws:s3:::/*' - starts with '/' will not match a bucket"},
+	}
+
+	for i, testCase := range testCases {
+		if result := testCase.err.Error(); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+// errorMatches - returns whether err wraps expected, comparing typed errors
+// by type only.
+func errorMatches(err, expected error) bool {
+	switch expected.(type) {
+	case ErrUnsupportedAction:
+		var e ErrUnsupportedAction
+		return errors.As(err, &e)
+	case ErrMixedActions:
+		var e ErrMixedActions
+		return errors.As(err, &e)
+	case ErrUnsupportedConditionKey:
+		var e ErrUnsupportedConditionKey
+		return errors.As(err, &e)
+	case ErrUnsupportedResource:
+		var e ErrUnsupportedResource
+		return errors.As(err, &e)
+	case ErrMalformedResource:
+		var e ErrMalformedResource
+		return errors.As(err, &e)
+	}
+	return errors.Is(err, expected)
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import "fmt"
+
+// Verdict - result of evaluating a single policy against Args.
+type Verdict int
+
+// Verdict values.
+const (
+	// VerdictNoMatch - no statement of the policy applies.
+	VerdictNoMatch Verdict = iota
+	// VerdictAllow - the policy allows the request.
+	VerdictAllow
+	// VerdictDeny - a 'Deny' statement of the policy applies.
+	VerdictDeny
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case VerdictAllow:
+		return "Allow"
+	case VerdictDeny:
+		return "Deny"
+	default:
+		return "NoMatch"
+	}
+}
+
+// Reason - explains the verdict of a policy, see EvaluateWithReason.
+type Reason struct {
+	Verdict Verdict
+
+	// Statement is the index of the statement which decided the verdict,
+	// -1 if no statement applied or the verdict was decided by
+	// Args.IsOwner or Args.DenyOnly.
+	Statement int
+
+	// MissingKey is the condition key without value in ConditionValues
+	// which decided the verdict under Args.StrictConditionContext, i.e.
+	// of the denying statement, or of the first 'Allow' statement skipped
+	// for lack of context if no statement applied.
+	MissingKey string
+
+	// Description is the Description of the statement which decided the
+	// verdict, if any.
+	Description string
+}
+
+func (r Reason) String() string {
+	var s string
+	if r.Statement >= 0 {
+		s = fmt.Sprintf("%v by statement %d", r.Verdict, r.Statement)
+		if r.Description != "" {
+			s += fmt.Sprintf(" (%q)", r.Description)
+		}
+	} else {
+		s = r.Verdict.String()
+	}
+	if r.MissingKey != "" {
+		s += ": missing context key " + r.MissingKey
+	}
+	return s
+}
+
+// EvaluateWithReason - returns the verdict of this policy for args along
+// with the statement and, if any, the missing condition key which decided
+// it. The evaluation observer is not notified.
+func (iamp Policy) EvaluateWithReason(args Args) Reason {
+	args.NormalizeConditions()
+
+	return iamp.evaluateWithReason(args, requestResource(args))
+}
+
+// EvaluateStrict - returns the verdict of this policy for args, as
+// EvaluateWithReason does, or an error if a statement is of an invalid
+// effect rather than ignoring the statement, e.g. for policies built
+// programmatically and not validated.
+func (iamp Policy) EvaluateStrict(args Args) (Verdict, error) {
+	for i, statement := range iamp.Statements {
+		if !statement.Effect.IsValid() {
+			return VerdictNoMatch, Errorf("statement %d: %w '%v'", i, ErrInvalidEffect, statement.Effect)
+		}
+	}
+	return iamp.EvaluateWithReason(args).Verdict, nil
+}
+
+// evaluate - returns the verdict of this policy for args, resource must be
+// the value of requestResource(args) and args must be normalized.
+func (iamp Policy) evaluate(args Args, resource string) Verdict {
+	return iamp.evaluateWithReason(args, resource).Verdict
+}
+
+func (iamp Policy) evaluateWithReason(args Args, resource string) Reason {
+	// Check all deny statements. If any one statement applies, deny.
+	for i, statement := range iamp.Statements {
+		if statement.Effect != Deny {
+			continue
+		}
+		if ok, missingKey := statement.matchWithMissingKey(args, resource); ok {
+			return Reason{Verdict: VerdictDeny, Statement: i, MissingKey: missingKey, Description: statement.Description}
+		}
+	}
+
+	// Applied any 'Deny' only policies, if we have
+	// reached here it means that there were no 'Deny'
+	// policies - this function mainly used for
+	// specific scenarios where we only want to validate
+	// 'Deny' only policies.
+	if args.DenyOnly {
+		return Reason{Verdict: VerdictAllow, Statement: -1}
+	}
+
+	// For owner, its allowed by default.
+	if args.IsOwner {
+		return Reason{Verdict: VerdictAllow, Statement: -1}
+	}
+
+	// Check all allow statements. If any one statement applies, allow.
+	reason := Reason{Verdict: VerdictNoMatch, Statement: -1}
+	for i, statement := range iamp.Statements {
+		if statement.Effect != Allow {
+			continue
+		}
+		ok, missingKey := statement.matchWithMissingKey(args, resource)
+		if ok {
+			return Reason{Verdict: VerdictAllow, Statement: i, Description: statement.Description}
+		}
+		if reason.MissingKey == "" {
+			reason.MissingKey = missingKey
+		}
+	}
+
+	return reason
+}
+
+// EvaluateEach - evaluates args against each of the named policies
+// without merging them, which allows attributing the decision to
+// individual policies. Args are normalized and the request resource is
+// built only once for all policies.
+func EvaluateEach(policies map[string]Policy, args Args) map[string]Verdict {
+	args.NormalizeConditions()
+	resource := requestResource(args)
+
+	verdicts := make(map[string]Verdict, len(policies))
+	for name, p := range policies {
+		verdicts[name] = p.evaluate(args, resource)
+	}
+	return verdicts
+}
+
+// CombineVerdicts - returns the decision for verdicts returned by
+// EvaluateEach, which is the same as evaluating the merged policies using
+// MergePolicies, i.e. the request is allowed if no policy denies and at
+// least one policy allows it. Note that for no policies at all the request
+// is not allowed, even for Args with DenyOnly or IsOwner set.
+func CombineVerdicts(verdicts map[string]Verdict) bool {
+	var allowed bool
+	for _, v := range verdicts {
+		switch v {
+		case VerdictDeny:
+			return false
+		case VerdictAllow:
+			allowed = true
+		}
+	}
+	return allowed
+}
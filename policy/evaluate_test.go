@@ -0,0 +1,269 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+func TestEvaluateEach(t *testing.T) {
+	func1, err := condition.NewStringEqualsFunc("", condition.AWSUsername.ToKey(), "john")
+	if err != nil {
+		t.Fatalf("unexpected error. %v", err)
+	}
+
+	policies := map[string]Policy{
+		"readwrite": {
+			Version: DefaultVersion,
+			Statements: []Statement{
+				NewStatement("", Allow, NewActionSet(GetObjectAction, PutObjectAction), NewResourceSet(NewResource("mybucket/*")), condition.NewFunctions()),
+			},
+		},
+		"readonly": {
+			Version: DefaultVersion,
+			Statements: []Statement{
+				NewStatement("", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("*")), condition.NewFunctions()),
+			},
+		},
+		"denyjohn": {
+			Version: DefaultVersion,
+			Statements: []Statement{
+				NewStatement("", Deny, NewActionSet(PutObjectAction), NewResourceSet(NewResource("*")), condition.NewFunctions(func1)),
+			},
+		},
+	}
+
+	testCases := []struct {
+		args           Args
+		expectedResult map[string]Verdict
+		allowed        bool
+	}{
+		{
+			Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"},
+			map[string]Verdict{"readwrite": VerdictAllow, "readonly": VerdictAllow, "denyjohn": VerdictNoMatch},
+			true,
+		},
+		{
+			Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject"},
+			map[string]Verdict{"readwrite": VerdictAllow, "readonly": VerdictNoMatch, "denyjohn": VerdictNoMatch},
+			true,
+		},
+		{
+			Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject", ConditionValues: map[string][]string{"username": {"john"}}},
+			map[string]Verdict{"readwrite": VerdictAllow, "readonly": VerdictNoMatch, "denyjohn": VerdictDeny},
+			false,
+		},
+		{
+			Args{Action: PutObjectAction, BucketName: "yourbucket", ObjectName: "myobject"},
+			map[string]Verdict{"readwrite": VerdictNoMatch, "readonly": VerdictNoMatch, "denyjohn": VerdictNoMatch},
+			false,
+		},
+	}
+
+	for i, testCase := range testCases {
+		result := EvaluateEach(policies, testCase.args)
+
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+		if allowed := CombineVerdicts(result); allowed != testCase.allowed {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.allowed, allowed)
+		}
+	}
+}
+
+func TestCombineVerdictsMatchesMergePolicies(t *testing.T) {
+	func1, err := condition.NewStringEqualsFunc("", condition.AWSUsername.ToKey(), "john")
+	if err != nil {
+		t.Fatalf("unexpected error. %v", err)
+	}
+	func2, err := condition.NewStringLikeFunc("", condition.S3Prefix.ToKey(), "photos/*")
+	if err != nil {
+		t.Fatalf("unexpected error. %v", err)
+	}
+
+	effects := []Effect{Allow, Deny}
+	actionSets := []ActionSet{
+		NewActionSet(GetObjectAction),
+		NewActionSet(PutObjectAction, GetObjectAction),
+		NewActionSet(ListBucketAction),
+		NewActionSet(AllActions),
+	}
+	resourceSets := []ResourceSet{
+		NewResourceSet(NewResource("*")),
+		NewResourceSet(NewResource("mybucket/*")),
+		NewResourceSet(NewResource("mybucket")),
+		NewResourceSet(NewResource("yourbucket/photos/*"), NewResource("yourbucket")),
+	}
+	conditions := []condition.Functions{
+		nil,
+		condition.NewFunctions(func1),
+		condition.NewFunctions(func2),
+	}
+	argsList := []Args{
+		{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"},
+		{Action: PutObjectAction, BucketName: "yourbucket", ObjectName: "photos/1.jpg"},
+		{Action: ListBucketAction, BucketName: "yourbucket", ConditionValues: map[string][]string{"prefix": {"photos/"}}},
+		{Action: ListBucketAction, BucketName: "mybucket", ConditionValues: map[string][]string{"username": {"john"}}},
+		{Action: DeleteObjectAction, BucketName: "mybucket", ObjectName: "myobject", ConditionValues: map[string][]string{"username": {"john"}}},
+		{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject", IsOwner: true},
+		{Action: GetObjectAction, BucketName: "yourbucket", ObjectName: "myobject", DenyOnly: true, ConditionValues: map[string][]string{"username": {"john"}}},
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		policies := make(map[string]Policy)
+		inputs := []Policy{}
+		for j := 1 + r.Intn(4); j > 0; j-- {
+			p := Policy{Version: DefaultVersion}
+			for k := 1 + r.Intn(3); k > 0; k-- {
+				p.Statements = append(p.Statements, NewStatement(
+					"",
+					effects[r.Intn(len(effects))],
+					actionSets[r.Intn(len(actionSets))],
+					resourceSets[r.Intn(len(resourceSets))],
+					conditions[r.Intn(len(conditions))],
+				))
+			}
+			policies[fmt.Sprintf("policy%d", j)] = p
+			inputs = append(inputs, p)
+		}
+
+		for _, args := range argsList {
+			expectedResult := MergePolicies(inputs...).IsAllowed(args)
+			verdicts := EvaluateEach(policies, args)
+			if result := CombineVerdicts(verdicts); result != expectedResult {
+				t.Fatalf("case %v: args %+v: expected: %v, got: %v (%v)", i+1, args, expectedResult, result, verdicts)
+			}
+		}
+	}
+}
+
+func TestEvaluateStrictConditionContext(t *testing.T) {
+	operators := []struct {
+		operator string
+		key      string
+		value    string
+		present  string
+		// Whether the condition is satisfied if the key is present or
+		// absent, without StrictConditionContext.
+		satisfiedPresent bool
+		satisfiedAbsent  bool
+		// Whether the operator is defined for absent keys.
+		exempt bool
+	}{
+		{"StringEquals", "aws:username", "john", "john", true, false, false},
+		{"StringNotEquals", "aws:username", "john", "john", false, true, false},
+		{"StringLike", "aws:username", "jo*", "john", true, false, false},
+		{"StringNotLike", "aws:username", "jo*", "john", false, true, false},
+		{"ForAnyValue:StringEquals", "aws:username", "john", "john", true, false, false},
+		{"IpAddress", "aws:SourceIp", "10.0.0.0/8", "10.1.2.3", true, false, false},
+		{"NotIpAddress", "aws:SourceIp", "10.0.0.0/8", "10.1.2.3", false, true, false},
+		{"Bool", "aws:SecureTransport", "true", "true", true, false, false},
+		{"NumericLessThan", "s3:max-keys", "10", "5", true, false, false},
+		{"NumericGreaterThanIfExists", "s3:max-keys", "1", "5", true, true, true},
+		{"DateLessThan", "aws:CurrentTime", "2030-01-01T00:00:00Z", "2025-01-01T00:00:00Z", true, false, false},
+		{"Null", "aws:username", "true", "john", false, true, true},
+		{"Null", "aws:username", "false", "john", true, false, true},
+	}
+
+	for _, op := range operators {
+		for _, missing := range []bool{false, true} {
+			for _, effect := range []Effect{Allow, Deny} {
+				for _, strict := range []bool{false, true} {
+					name := fmt.Sprintf("%s %s: missing: %v, strict: %v", effect, op.operator, missing, strict)
+
+					// A 'Deny' statement is evaluated along with a 'Allow'
+					// statement without conditions.
+					statements := fmt.Sprintf(`{"Effect": %q, "Action": "s3:ListBucket", "Resource": "arn:aws:s3:::mybucket", "Condition": {%q: {%q: %q}}}`,
+						effect, op.operator, op.key, op.value)
+					if effect == Deny {
+						statements += `, {"Effect": "Allow", "Action": "s3:ListBucket", "Resource": "arn:aws:s3:::mybucket"}`
+					}
+					p, err := ParseConfig(strings.NewReader(fmt.Sprintf(`{"Version": "2012-10-17", "Statement": [%s]}`, statements)))
+					if err != nil {
+						t.Fatalf("%s: unexpected error: %v", name, err)
+					}
+
+					keyName := strings.SplitN(op.key, ":", 2)[1]
+					conditionValues := map[string][]string{}
+					if !missing {
+						conditionValues[keyName] = []string{op.present}
+					}
+
+					applies, expectedMissingKey := op.satisfiedPresent, ""
+					switch {
+					case !missing:
+					case strict && !op.exempt:
+						applies, expectedMissingKey = effect == Deny, op.key
+					default:
+						applies = op.satisfiedAbsent
+					}
+					expectedVerdict := VerdictNoMatch
+					switch {
+					case effect == Deny && applies:
+						expectedVerdict = VerdictDeny
+					case effect == Deny, applies:
+						expectedVerdict = VerdictAllow
+					}
+
+					args := Args{
+						Action:                 ListBucketAction,
+						BucketName:             "mybucket",
+						ConditionValues:        conditionValues,
+						StrictConditionContext: strict,
+					}
+					reason := p.EvaluateWithReason(args)
+					if reason.Verdict != expectedVerdict {
+						t.Fatalf("%s: expected: %v, got: %v", name, expectedVerdict, reason)
+					}
+					if reason.MissingKey != expectedMissingKey {
+						t.Fatalf("%s: expected missing key: %q, got: %q", name, expectedMissingKey, reason.MissingKey)
+					}
+					if allowed := p.IsAllowed(args); allowed != (expectedVerdict == VerdictAllow) {
+						t.Fatalf("%s: expected: %v, got: %v", name, expectedVerdict == VerdictAllow, allowed)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestReasonString(t *testing.T) {
+	testCases := []struct {
+		reason   Reason
+		expected string
+	}{
+		{Reason{Verdict: VerdictNoMatch, Statement: -1}, "NoMatch"},
+		{Reason{Verdict: VerdictAllow, Statement: 0}, "Allow by statement 0"},
+		{Reason{Verdict: VerdictDeny, Statement: 2, MissingKey: "aws:SourceIp"}, "Deny by statement 2: missing context key aws:SourceIp"},
+		{Reason{Verdict: VerdictNoMatch, Statement: -1, MissingKey: "aws:SourceIp"}, "NoMatch: missing context key aws:SourceIp"},
+	}
+
+	for i, testCase := range testCases {
+		if got := testCase.reason.String(); got != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, got)
+		}
+	}
+}
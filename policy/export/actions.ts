@@ -0,0 +1,207 @@
+// Code generated by github.com/minio/pkg/v3/policy; DO NOT EDIT.
+
+// s3Actions - S3 actions and their descriptions.
+export const s3Actions = {
+  "s3:*": "All S3 actions",
+  "s3:AbortMultipartUpload": "Abort a multipart upload",
+  "s3:BypassGovernanceRetention": "Bypass governance mode retention",
+  "s3:CreateBucket": "Create a bucket",
+  "s3:DeleteBucket": "Delete an empty bucket",
+  "s3:DeleteBucketCors": "Delete the bucket CORS configuration",
+  "s3:DeleteBucketPolicy": "Delete the bucket policy",
+  "s3:DeleteObject": "Delete an object",
+  "s3:DeleteObjectTagging": "Delete the tags of an object",
+  "s3:DeleteObjectVersion": "Delete a specific version of an object",
+  "s3:DeleteObjectVersionTagging": "Delete the tags of a specific version of an object",
+  "s3:ForceDeleteBucket": "Delete a bucket along with its objects",
+  "s3:GetBucketAcl": "Get the ACL of a bucket, ACLs are ignored by MinIO",
+  "s3:GetBucketCors": "Get the bucket CORS configuration",
+  "s3:GetBucketLocation": "Get the region of a bucket",
+  "s3:GetBucketNotification": "Get the bucket notification configuration",
+  "s3:GetBucketObjectLockConfiguration": "Get the bucket object lock configuration",
+  "s3:GetBucketPolicy": "Get the bucket policy",
+  "s3:GetBucketPolicyStatus": "Get whether the bucket policy makes a bucket public",
+  "s3:GetBucketTagging": "Get the tags of a bucket",
+  "s3:GetBucketVersioning": "Get the versioning state of a bucket",
+  "s3:GetEncryptionConfiguration": "Get the bucket default encryption configuration",
+  "s3:GetLifecycleConfiguration": "Get the bucket lifecycle configuration",
+  "s3:GetObject": "Read an object and its metadata",
+  "s3:GetObjectAcl": "Get the ACL of an object, ACLs are ignored by MinIO",
+  "s3:GetObjectAttributes": "Get the attributes of an object",
+  "s3:GetObjectLegalHold": "Get the legal hold of an object",
+  "s3:GetObjectRetention": "Get the retention of an object",
+  "s3:GetObjectTagging": "Get the tags of an object",
+  "s3:GetObjectVersion": "Read a specific version of an object",
+  "s3:GetObjectVersionAcl": "Get the ACL of a specific version of an object",
+  "s3:GetObjectVersionAttributes": "Get the attributes of a specific version of an object",
+  "s3:GetObjectVersionForReplication": "Read object versions for replication",
+  "s3:GetObjectVersionTagging": "Get the tags of a specific version of an object",
+  "s3:GetReplicationConfiguration": "Get the bucket replication configuration",
+  "s3:HeadBucket": "Check whether a bucket exists, unused by MinIO",
+  "s3:ListAllMyBuckets": "List all buckets",
+  "s3:ListBucket": "List the objects of a bucket",
+  "s3:ListBucketMultipartUploads": "List the multipart uploads in progress of a bucket",
+  "s3:ListBucketVersions": "List the object versions of a bucket",
+  "s3:ListMultipartUploadParts": "List the uploaded parts of a multipart upload",
+  "s3:ListenBucketNotification": "Listen to the events of a bucket",
+  "s3:ListenNotification": "Listen to the events of all buckets",
+  "s3:PutBucketAcl": "Set the ACL of a bucket, ACLs are ignored by MinIO",
+  "s3:PutBucketCors": "Set the bucket CORS configuration",
+  "s3:PutBucketNotification": "Set the bucket notification configuration",
+  "s3:PutBucketObjectLockConfiguration": "Set the bucket object lock configuration",
+  "s3:PutBucketPolicy": "Set the bucket policy",
+  "s3:PutBucketTagging": "Set the tags of a bucket",
+  "s3:PutBucketVersioning": "Set the versioning state of a bucket",
+  "s3:PutEncryptionConfiguration": "Set the bucket default encryption configuration",
+  "s3:PutLifecycleConfiguration": "Set the bucket lifecycle configuration",
+  "s3:PutObject": "Upload an object",
+  "s3:PutObjectAcl": "Set the ACL of an object, ACLs are ignored by MinIO",
+  "s3:PutObjectFanOut": "Upload an object to multiple keys at once",
+  "s3:PutObjectLegalHold": "Set the legal hold of an object",
+  "s3:PutObjectRetention": "Set the retention of an object",
+  "s3:PutObjectTagging": "Set the tags of an object",
+  "s3:PutObjectVersionAcl": "Set the ACL of a specific version of an object",
+  "s3:PutObjectVersionTagging": "Set the tags of a specific version of an object",
+  "s3:PutReplicationConfiguration": "Set the bucket replication configuration",
+  "s3:ReplicateDelete": "Replicate deletes to a bucket",
+  "s3:ReplicateObject": "Replicate objects to a bucket",
+  "s3:ReplicateTags": "Replicate object tags to a bucket",
+  "s3:ResetBucketReplicationState": "Reset the replication state of a bucket to replicate existing objects again",
+  "s3:RestoreObject": "Restore a transitioned object",
+} as const;
+
+export type S3Action = keyof typeof s3Actions;
+
+// adminActions - Admin actions and their descriptions.
+export const adminActions = {
+  "admin:*": "All admin actions",
+  "admin:*BatchJob*": "All batch job actions",
+  "admin:*IDPConfig": "All identity provider configuration actions",
+  "admin:*Tier*": "All remote tier actions",
+  "admin:AddUserToGroup": "Add users to groups",
+  "admin:AttachUserOrGroupPolicy": "Attach policies to users and groups",
+  "admin:BandwidthMonitor": "Monitor replication bandwidth",
+  "admin:BatchJobStatus": "Get the status of batch jobs",
+  "admin:CancelBatchJob": "Cancel batch jobs",
+  "admin:ConfigUpdate": "Manage the server configuration",
+  "admin:ConsoleLog": "Stream server logs",
+  "admin:CreatePolicy": "Create policies",
+  "admin:CreateServiceAccount": "Create service accounts",
+  "admin:CreateUser": "Create users",
+  "admin:DataUsageInfo": "Get data usage information",
+  "admin:Decommission": "Decommission server pools",
+  "admin:DecommissionStatus": "Get the decommissioning status of server pools",
+  "admin:DeleteIDPConfig": "Delete identity provider configurations",
+  "admin:DeletePolicy": "Delete policies",
+  "admin:DeleteUser": "Delete users",
+  "admin:DescribeBatchJob": "Get the definition of batch jobs",
+  "admin:DisableGroup": "Disable groups",
+  "admin:DisableUser": "Disable users",
+  "admin:EnableGroup": "Enable groups",
+  "admin:EnableUser": "Enable users",
+  "admin:ExportBucketMetadata": "Export bucket metadata",
+  "admin:ExportIAM": "Export IAM data",
+  "admin:ForceUnlock": "Force unlock locks",
+  "admin:GetBucketQuota": "Get bucket quotas",
+  "admin:GetBucketTarget": "Get bucket replication targets",
+  "admin:GetGroup": "Get group information",
+  "admin:GetIDPConfig": "Get identity provider configurations",
+  "admin:GetPolicy": "Get policies",
+  "admin:GetUser": "Get user information",
+  "admin:Heal": "Heal buckets and objects",
+  "admin:ImportBucketMetadata": "Import bucket metadata",
+  "admin:ImportIAM": "Import IAM data",
+  "admin:InspectData": "Download raw files of the backend",
+  "admin:KMSCreateKey": "Create a KMS master key",
+  "admin:KMSExportKey": "Export a KMS master key",
+  "admin:KMSImportKey": "Import a KMS master key",
+  "admin:KMSKeyStatus": "Get the status of a KMS key",
+  "admin:LicenseInfo": "Get license information",
+  "admin:ListBatchJobs": "List batch jobs",
+  "admin:ListGroups": "List groups",
+  "admin:ListIDPConfig": "List identity provider configurations",
+  "admin:ListServiceAccounts": "List service accounts",
+  "admin:ListTemporaryAccounts": "List temporary accounts",
+  "admin:ListTier": "List remote tiers",
+  "admin:ListUserPolicies": "List the policies of users",
+  "admin:ListUsers": "List users",
+  "admin:OBDInfo": "Get cluster health information",
+  "admin:Profiling": "Profile servers",
+  "admin:Prometheus": "Get Prometheus metrics",
+  "admin:Rebalance": "Rebalance server pools",
+  "admin:RebalanceStatus": "Get the rebalancing status of server pools",
+  "admin:RemoveServiceAccount": "Remove service accounts",
+  "admin:RemoveTier": "Remove remote tiers",
+  "admin:RemoveUserFromGroup": "Remove users from groups",
+  "admin:ReplicationDiff": "List the unreplicated objects of a bucket",
+  "admin:ServerInfo": "Get server information",
+  "admin:ServerTrace": "Trace server calls",
+  "admin:ServerUpdate": "Update the server binaries",
+  "admin:ServiceFreeze": "Freeze and unfreeze S3 API calls",
+  "admin:ServiceRestart": "Restart servers",
+  "admin:ServiceStop": "Stop servers",
+  "admin:SetBucketQuota": "Set bucket quotas",
+  "admin:SetBucketTarget": "Set bucket replication targets",
+  "admin:SetIDPConfig": "Add and edit identity provider configurations",
+  "admin:SetTier": "Add and edit remote tiers",
+  "admin:SiteReplicationAdd": "Add sites to site replication",
+  "admin:SiteReplicationDisable": "Disable site replication",
+  "admin:SiteReplicationInfo": "Get site replication information",
+  "admin:SiteReplicationOperation": "Apply site replication changes of peer sites",
+  "admin:SiteReplicationRemove": "Remove sites from site replication",
+  "admin:SiteReplicationResync": "Resync data to a replicated site",
+  "admin:StartBatchJob": "Start batch jobs",
+  "admin:StorageInfo": "Get storage information",
+  "admin:TierStats": "Get remote tier statistics",
+  "admin:TopLocksInfo": "List the oldest locks",
+  "admin:UpdatePolicyAssociation": "Attach and detach policies of users and groups",
+  "admin:UpdateServiceAccount": "Update service accounts",
+  "admin:VerifyTier": "Verify the credentials of remote tiers",
+} as const;
+
+export type AdminAction = keyof typeof adminActions;
+
+// kmsActions - KMS actions and their descriptions.
+export const kmsActions = {
+  "kms:*": "All KMS actions",
+  "kms:API": "List the KMS API endpoints",
+  "kms:AssignPolicy": "Assign KMS policies to identities",
+  "kms:AuditLog": "Stream the KMS audit log",
+  "kms:CreateKey": "Create KMS keys",
+  "kms:DeleteIdentity": "Delete KMS identities",
+  "kms:DeleteKey": "Delete KMS keys",
+  "kms:DeletePolicy": "Delete KMS policies",
+  "kms:DescribeIdentity": "Describe KMS identities",
+  "kms:DescribePolicy": "Describe KMS policies",
+  "kms:DescribeSelfIdentity": "Describe the own KMS identity",
+  "kms:ErrorLog": "Stream the KMS error log",
+  "kms:GetPolicy": "Get KMS policies",
+  "kms:ImportKey": "Import KMS keys",
+  "kms:KeyStatus": "Get the status of KMS keys",
+  "kms:ListIdentities": "List KMS identities",
+  "kms:ListKeys": "List KMS keys",
+  "kms:ListPolicies": "List KMS policies",
+  "kms:Metrics": "Get KMS metrics",
+  "kms:SetPolicy": "Create and update KMS policies",
+  "kms:Status": "Get the KMS status",
+  "kms:Version": "Get the KMS version",
+} as const;
+
+export type KMSAction = keyof typeof kmsActions;
+
+// stsActions - STS actions and their descriptions.
+export const stsActions = {
+  "sts:AssumeRoleWithWebIdentity": "Get temporary credentials for a web identity token",
+  "sts:TagSession": "Pass session tags to temporary credentials",
+} as const;
+
+export type STSAction = keyof typeof stsActions;
+
+// wildcardActions - Wildcard actions and their descriptions.
+export const wildcardActions = {
+  "*": "All actions",
+} as const;
+
+export type WildcardAction = keyof typeof wildcardActions;
+
+export type Action = S3Action | AdminAction | KMSAction | STSAction | WildcardAction;
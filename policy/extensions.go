@@ -0,0 +1,69 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"unicode/utf8"
+)
+
+// MaxStatementDescriptionLength - maximum number of characters of the
+// Description of statements.
+const MaxStatementDescriptionLength = 1024
+
+// validateDescription - checks the Description of a statement.
+func validateDescription(description string) error {
+	if n := utf8.RuneCountInString(description); n > MaxStatementDescriptionLength {
+		return Errorf("%w: %d characters, at most %d are allowed", ErrInvalidDescription, n, MaxStatementDescriptionLength)
+	}
+	if !utf8.ValidString(description) {
+		return Errorf("%w: not valid UTF-8", ErrInvalidDescription)
+	}
+	return nil
+}
+
+// StripExtensions - returns a copy of this policy without the MinIO
+// extension fields, i.e. the Description of statements, e.g. to export
+// AWS compatible documents. MinIO extension condition operators are not
+// removed, see Lint.
+func (iamp Policy) StripExtensions() Policy {
+	if len(iamp.Statements) == 0 {
+		return iamp
+	}
+	statements := make([]Statement, len(iamp.Statements))
+	for i, statement := range iamp.Statements {
+		statement.Description = ""
+		statements[i] = statement
+	}
+	iamp.Statements = statements
+	return iamp
+}
+
+// StripExtensions - returns a copy of this policy without the MinIO
+// extension fields, as Policy.StripExtensions does.
+func (policy BucketPolicy) StripExtensions() BucketPolicy {
+	if len(policy.Statements) == 0 {
+		return policy
+	}
+	statements := make([]BPStatement, len(policy.Statements))
+	for i, statement := range policy.Statements {
+		statement.Description = ""
+		statements[i] = statement
+	}
+	policy.Statements = statements
+	return policy
+}
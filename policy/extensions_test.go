@@ -0,0 +1,161 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const describedPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "Migration",
+      "Description": "temporary grant for the migration, remove after Q3",
+      "Effect": "Allow",
+      "Action": ["s3:GetObject"],
+      "Resource": ["arn:aws:s3:::mybucket/*"]
+    },
+    {
+      "Effect": "Deny",
+      "Description": "never delete",
+      "Action": ["s3:DeleteObject"],
+      "Resource": ["arn:aws:s3:::mybucket/*"]
+    }
+  ]
+}`
+
+func TestStatementDescription(t *testing.T) {
+	p := mustParsePolicy(t, describedPolicy)
+	if p.Statements[0].Description != "temporary grant for the migration, remove after Q3" {
+		t.Fatalf("unexpected description: %q", p.Statements[0].Description)
+	}
+
+	// Descriptions are preserved.
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	decoded := mustParsePolicy(t, string(data))
+	for i := range p.Statements {
+		if decoded.Statements[i].Description != p.Statements[i].Description {
+			t.Fatalf("statement %v: expected: %q, got: %q", i, p.Statements[i].Description, decoded.Statements[i].Description)
+		}
+	}
+
+	// Descriptions are ignored by evaluation, Equals and Fingerprint.
+	stripped := p.StripExtensions()
+	if !p.Equals(stripped) || p.Fingerprint() != stripped.Fingerprint() {
+		t.Fatalf("expected equal policies")
+	}
+	if p.Statements[0].Description == "" {
+		t.Fatalf("expected the policy not to be changed")
+	}
+	args := Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}
+	if !p.IsAllowed(args) || !stripped.IsAllowed(args) {
+		t.Fatalf("expected allowed")
+	}
+	if data, err = json.Marshal(stripped); err != nil || bytes.Contains(data, []byte("Description")) {
+		t.Fatalf("expected no description, got: %s, %v", data, err)
+	}
+
+	// Descriptions are reported by tooling.
+	if reason := p.EvaluateWithReason(args).String(); reason != `Allow by statement 0 ("temporary grant for the migration, remove after Q3")` {
+		t.Fatalf("unexpected reason: %v", reason)
+	}
+	merged, provenance := MergePoliciesNamed(map[string]Policy{"migration": p})
+	args.Action = DeleteObjectAction
+	if explanation := provenance.Explain(merged.EvaluateWithReason(args)); explanation != `Deny by statement 1 of policy 'migration' ("never delete")` {
+		t.Fatalf("unexpected explanation: %v", explanation)
+	}
+	warnings := p.Lint()
+	if len(warnings) != 2 || !strings.Contains(warnings[0], "statement 'Migration': 'Description'") || len(stripped.Lint()) != 0 {
+		t.Fatalf("unexpected warnings: %v", warnings)
+	}
+}
+
+func TestBPStatementDescription(t *testing.T) {
+	data := strings.ReplaceAll(describedPolicy, `"Effect"`, `"Principal": "*", "Effect"`)
+	p, err := ParseBucketPolicyConfig(strings.NewReader(data), "mybucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	encoded, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	decoded, err := ParseBucketPolicyConfig(bytes.NewReader(encoded), "mybucket")
+	if err != nil || decoded.Statements[1].Description != "never delete" {
+		t.Fatalf("expected the description to be preserved, got: %v, %v", decoded, err)
+	}
+
+	stripped := p.StripExtensions()
+	if !p.Equals(stripped) || p.Fingerprint() != stripped.Fingerprint() || p.Statements[1].Description == "" {
+		t.Fatalf("expected equal policies")
+	}
+	if encoded, err = json.Marshal(stripped); err != nil || bytes.Contains(encoded, []byte("Description")) {
+		t.Fatalf("expected no description, got: %s, %v", encoded, err)
+	}
+
+	// Changes of the description only are reported by DiffStatements.
+	changed := mustParseBucketPolicy(t, strings.ReplaceAll(data, "remove after Q3", "remove after Q4"))
+	diffs := p.DiffStatements(changed)
+	if len(diffs) != 1 || diffs[0].Kind != DiffChanged {
+		t.Fatalf("expected a changed statement, got: %v", diffs)
+	}
+	if rendered := diffs[0].Render(); rendered != `changed statement 'Migration': description "(\"temporary grant for the migration, remove after Q3\")" -> "(\"temporary grant for the migration, remove after Q4\")"` {
+		t.Fatalf("unexpected rendering: %v", rendered)
+	}
+	if len(p.Lint()) != 2 {
+		t.Fatalf("expected warnings, got: %v", p.Lint())
+	}
+}
+
+func TestStatementDescriptionLength(t *testing.T) {
+	testCases := []struct {
+		description string
+		expectedErr bool
+	}{
+		{"", false},
+		{strings.Repeat("a", MaxStatementDescriptionLength), false},
+		// Characters, not bytes, are counted.
+		{strings.Repeat("ä", MaxStatementDescriptionLength), false},
+		{strings.Repeat("a", MaxStatementDescriptionLength+1), true},
+	}
+
+	for i, testCase := range testCases {
+		statement := NewStatement("", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/*")), nil)
+		statement.Description = testCase.description
+		err := Policy{Version: DefaultVersion, Statements: []Statement{statement}}.Validate()
+		if (err != nil) != testCase.expectedErr || (err != nil && !errors.Is(err, ErrInvalidDescription)) {
+			t.Fatalf("case %v: expected error: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+
+		bpStatement := NewBPStatement("", Allow, NewPrincipal("*"), NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/*")), nil)
+		bpStatement.Description = testCase.description
+		err = BucketPolicy{Version: DefaultVersion, Statements: []BPStatement{bpStatement}}.Validate("mybucket")
+		if (err != nil) != testCase.expectedErr || (err != nil && !errors.Is(err, ErrInvalidDescription)) {
+			t.Fatalf("case %v: bucket policy: expected error: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"bytes"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/minio/pkg/v3/wildcard"
+)
+
+// objectPattern - resource pattern of a statement, with its policy
+// variables substituted, as matched by FilterAllowedObjects.
+type objectPattern struct {
+	pattern string
+	// prefix - index of the literal prefix of the pattern, up to its
+	// first wildcard, in objectMatcher.prefixes.
+	prefix int
+	// prefixOnly - whether the pattern is its prefix followed by a single
+	// '*', i.e. it matches all resources with the prefix.
+	prefixOnly bool
+}
+
+// objectMatcher - resource patterns of the statements of a policy which
+// apply to an action and condition values, by effect. The literal prefixes
+// of the patterns are shared, hence each distinct prefix is compared once
+// per resource.
+type objectMatcher struct {
+	prefixes []string
+	deny     []objectPattern
+	allow    []objectPattern
+}
+
+// add - adds the resource patterns of statement, whose policy variables
+// are substituted with conditionValues.
+func (m *objectMatcher) add(statement Statement, conditionValues map[string][]string) {
+	patterns := &m.allow
+	if statement.Effect == Deny {
+		patterns = &m.deny
+	}
+
+	for r := range statement.Resources {
+		if r.isAccessPoint() {
+			continue
+		}
+		pattern := r.Pattern
+		if strings.IndexByte(pattern, '$') >= 0 {
+			buf := smallBufPool.Get().(*bytes.Buffer)
+			buf.Reset()
+			r.substitute(buf, conditionValues)
+			pattern = buf.String()
+			smallBufPool.Put(buf)
+		}
+
+		prefix := pattern
+		if i := strings.IndexAny(pattern, "*?"); i >= 0 {
+			prefix = pattern[:i]
+		}
+		p := objectPattern{
+			pattern:    pattern,
+			prefix:     -1,
+			prefixOnly: len(pattern) == len(prefix)+1 && pattern[len(prefix)] == '*',
+		}
+		for i, existing := range m.prefixes {
+			if existing == prefix {
+				p.prefix = i
+				break
+			}
+		}
+		if p.prefix < 0 {
+			p.prefix = len(m.prefixes)
+			m.prefixes = append(m.prefixes, prefix)
+		}
+		*patterns = append(*patterns, p)
+	}
+}
+
+// matchObject - returns whether any of the patterns matches resource, as
+// Resource.Match does. hasPrefix tells whether resource has each of the
+// prefixes of the matcher, cleaned is the cleaned resource or empty if it
+// does not differ from the resource.
+func matchObject(patterns []objectPattern, hasPrefix []bool, resource, cleaned string) bool {
+	for _, p := range patterns {
+		if hasPrefix[p.prefix] && (p.prefixOnly || wildcard.Match(p.pattern, resource)) {
+			return true
+		}
+		if cleaned != "" && cleaned == p.pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// isCleanPath - returns whether p is certainly returned as is by
+// path.Clean, i.e. it has no empty, "." or ".." elements and no trailing
+// slash. It is cheaper than comparing p with its cleaned path.
+func isCleanPath(p string) bool {
+	if p == "" || p == "/" {
+		return p == "/"
+	}
+	elem := 0
+	for i := 0; i <= len(p); i++ {
+		if i < len(p) && p[i] != '/' {
+			continue
+		}
+		switch p[elem:i] {
+		case "":
+			if i > 0 {
+				return false
+			}
+		case ".", "..":
+			return false
+		}
+		elem = i + 1
+	}
+	return true
+}
+
+// objectResource - returns the resource of the object of bucket as
+// matched against statement resources, as requestResource does.
+func objectResource(bucket, object string) string {
+	switch {
+	case object == "":
+		return bucket + "/"
+	case object[0] == '/':
+		return bucket + object
+	}
+	return bucket + "/" + object
+}
+
+// objectEvaluator - evaluates a policy for the action and the normalized
+// condition values of args against objects of the bucket of args.
+// Statements not applying to the action or whose conditions are not
+// satisfied, which does not depend on the object, are discarded once for
+// all objects.
+type objectEvaluator struct {
+	iamp Policy
+	args Args
+
+	// perObject - whether each object is evaluated as IsAllowed does,
+	// since resources are not matched, or not as for objects.
+	perObject bool
+	m         objectMatcher
+	hasPrefix []bool
+}
+
+func newObjectEvaluator(iamp Policy, args Args) *objectEvaluator {
+	e := &objectEvaluator{iamp: iamp, args: args}
+	if args.Action.ignoresResources() || args.Action.IsAccountLevel() || args.Action.namespace() == "kms" {
+		e.perObject = true
+		return e
+	}
+
+	for _, statement := range iamp.Statements {
+		if statement.Effect != Allow && statement.Effect != Deny {
+			continue
+		}
+		if !statement.matchAction(args.Action) {
+			continue
+		}
+		if ok, _ := statement.evaluateConditions(args); !ok {
+			continue
+		}
+		e.m.add(statement, args.ConditionValues)
+	}
+	e.hasPrefix = make([]bool, len(e.m.prefixes))
+	return e
+}
+
+// evaluate - returns the verdict for the object, as Policy.evaluate does
+// for the args of the evaluator with the object name.
+func (e *objectEvaluator) evaluate(object string) Verdict {
+	if e.perObject {
+		args := e.args
+		args.ObjectName = object
+		return e.iamp.evaluate(args, requestResource(args))
+	}
+
+	resource := objectResource(e.args.BucketName, decodeObjectName(object, e.args.ObjectNameEncoded))
+	for j, prefix := range e.m.prefixes {
+		e.hasPrefix[j] = strings.HasPrefix(resource, prefix)
+	}
+	// Resources are matched by the cleaned path as well, which only
+	// differs from the resource, hence matching more than it, if the
+	// resource is not clean.
+	var cleaned string
+	if !isCleanPath(resource) {
+		if cleaned = path.Clean(resource); cleaned == "." || cleaned == resource {
+			cleaned = ""
+		}
+	}
+
+	switch {
+	case matchObject(e.m.deny, e.hasPrefix, resource, cleaned):
+		return VerdictDeny
+	case e.args.DenyOnly || e.args.IsOwner:
+		return VerdictAllow
+	case matchObject(e.m.allow, e.hasPrefix, resource, cleaned):
+		return VerdictAllow
+	}
+	return VerdictNoMatch
+}
+
+// FilterAllowedObjects - returns whether the action is allowed on each of
+// the objects of bucket, e.g. the keys of a listing page, as IsAllowed
+// would for Args with the action, the bucket, the object and
+// conditionValues. Statements not applying to the action or whose
+// conditions are not satisfied, which does not depend on the object, are
+// discarded once for all objects, the resource patterns of the remaining
+// statements are grouped by their literal prefix, which is compared once
+// per object and prefix and decides patterns such as "bucket/prefix/*"
+// without wildcard matching. Object names must not be percent-encoded.
+// The evaluation observer is not notified.
+func (iamp Policy) FilterAllowedObjects(action Action, bucket string, objects []string, conditionValues map[string][]string) []bool {
+	args := Args{Action: action, BucketName: bucket, ConditionValues: conditionValues}
+	args.NormalizeConditions()
+
+	allowed := make([]bool, len(objects))
+	e := newObjectEvaluator(iamp, args)
+	if !e.perObject && len(e.m.allow) == 0 {
+		return allowed
+	}
+	for i, object := range objects {
+		allowed[i] = e.evaluate(object) == VerdictAllow
+	}
+	return allowed
+}
+
+// IsAllowedBatch - returns whether each of the objects is allowed, as
+// IsAllowed returns for base with ObjectName set to the object, e.g. for
+// the keys of a DeleteObjects request. The condition values of base are
+// normalized once, and statements are matched as by FilterAllowedObjects.
+// The evaluation observer, if any, is notified for each object, the time
+// spent evaluating the batch being spread evenly over the objects.
+func (iamp Policy) IsAllowedBatch(base Args, objectNames []string) []bool {
+	observer := evaluationObserver.Load()
+	var start time.Time
+	if observer != nil {
+		start = time.Now()
+	}
+
+	args := base
+	args.NormalizeConditions()
+	e := newObjectEvaluator(iamp, args)
+
+	allowed := make([]bool, len(objectNames))
+	var verdicts []Verdict
+	if observer != nil {
+		verdicts = make([]Verdict, len(objectNames))
+	}
+	for i, object := range objectNames {
+		verdict := e.evaluate(object)
+		allowed[i] = verdict == VerdictAllow
+		if verdicts != nil {
+			verdicts[i] = verdict
+		}
+	}
+
+	if observer != nil && len(objectNames) > 0 {
+		dur := time.Since(start) / time.Duration(len(objectNames))
+		for i, object := range objectNames {
+			// Observers receive normalized arguments.
+			args := args
+			args.ObjectName = object
+			(*observer)(args.clone(), allowed[i], verdicts[i] == VerdictDeny, dur)
+		}
+	}
+	return allowed
+}
@@ -0,0 +1,249 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+// genListing - returns n random object names, including names with
+// leading, double and trailing slashes, dot segments and wildcard
+// characters.
+func genListing(n int, seed int64) []string {
+	segments := []string{"photos", "2024", "2025", "private", "public", "a", "b", "..", ".", "", "x?", "*", "ä", "alice", "bob"}
+	rnd := rand.New(rand.NewSource(seed))
+	objects := make([]string, n)
+	for i := range objects {
+		parts := make([]string, 1+rnd.Intn(4))
+		for j := range parts {
+			parts[j] = segments[rnd.Intn(len(segments))]
+		}
+		objects[i] = strings.Join(parts, "/")
+		if rnd.Intn(8) == 0 {
+			objects[i] += "/"
+		}
+	}
+	return objects
+}
+
+func TestFilterAllowedObjects(t *testing.T) {
+	testCases := []struct {
+		policy          string
+		action          Action
+		conditionValues map[string][]string
+	}{
+		// Single prefix.
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/photos/*"}]}`, GetObjectAction, nil},
+		// Overlapping allow and deny prefixes.
+		{`{"Version": "2012-10-17", "Statement": [
+			{"Effect": "Allow", "Action": "s3:GetObject", "Resource": ["arn:aws:s3:::mybucket/photos/*", "arn:aws:s3:::mybucket/public/*", "arn:aws:s3:::mybucket/a"]},
+			{"Effect": "Deny", "Action": "s3:GetObject", "Resource": ["arn:aws:s3:::mybucket/photos/private/*", "arn:aws:s3:::mybucket/photos/2024"]},
+			{"Effect": "Allow", "Action": "s3:*", "Resource": "arn:aws:s3:::mybucket/*/2025/*"},
+			{"Effect": "Deny", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*/x?/*"}
+		]}`, GetObjectAction, nil},
+		// Wildcard bucket, NotAction, single character wildcards.
+		{`{"Version": "2012-10-17", "Statement": [
+			{"Effect": "Allow", "NotAction": "s3:PutObject", "Resource": ["arn:aws:s3:::*/a/?", "arn:aws:s3:::my*/b*"]},
+			{"Effect": "Deny", "Action": "s3:PutObject", "Resource": "arn:aws:s3:::mybucket/*"}
+		]}`, GetObjectAction, nil},
+		// Policy variables and conditions.
+		{`{"Version": "2012-10-17", "Statement": [
+			{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/${aws:username}/*"},
+			{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/public/*", "Condition": {"StringEquals": {"aws:username": "alice"}}},
+			{"Effect": "Deny", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*/private/*", "Condition": {"StringEquals": {"aws:username": "bob"}}}
+		]}`, GetObjectAction, map[string][]string{"username": {"alice"}}},
+		{`{"Version": "2012-10-17", "Statement": [
+			{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/${aws:username}/*"},
+			{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/public/*", "Condition": {"StringEquals": {"aws:username": "alice"}}},
+			{"Effect": "Deny", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*/private/*", "Condition": {"StringEquals": {"aws:username": "bob"}}}
+		]}`, GetObjectAction, map[string][]string{"username": {"bob"}}},
+		// Other buckets and actions.
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:PutObject", "Resource": ["arn:aws:s3:::mybucket/*", "arn:aws:s3:::otherbucket/*"]}]}`, GetObjectAction, nil},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::otherbucket/*"}]}`, GetObjectAction, nil},
+		// Resources do not apply to admin actions.
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "admin:*"}]}`, ServerInfoAdminAction, nil},
+	}
+
+	objects := genListing(10000, 1)
+	for i, testCase := range testCases {
+		p := mustParsePolicy(t, testCase.policy)
+		result := p.FilterAllowedObjects(testCase.action, "mybucket", objects, testCase.conditionValues)
+		if len(result) != len(objects) {
+			t.Fatalf("case %v: expected: %v results, got: %v", i+1, len(objects), len(result))
+		}
+		var allowed int
+		for j, object := range objects {
+			expected := p.IsAllowed(Args{
+				Action:          testCase.action,
+				BucketName:      "mybucket",
+				ObjectName:      object,
+				ConditionValues: testCase.conditionValues,
+			})
+			if result[j] != expected {
+				t.Fatalf("case %v: object %q: expected: %v, got: %v", i+1, object, expected, result[j])
+			}
+			if expected {
+				allowed++
+			}
+		}
+		if i < 5 && (allowed == 0 || allowed == len(objects)) {
+			t.Fatalf("case %v: expected some objects to be allowed, got: %v", i+1, allowed)
+		}
+	}
+}
+
+func TestIsAllowedBatch(t *testing.T) {
+	mixed := mustParsePolicy(t, `{"Version": "2012-10-17", "Statement": [
+		{"Effect": "Allow", "Action": ["s3:DeleteObject", "s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/photos/*", "arn:aws:s3:::mybucket/${aws:username}/*"]},
+		{"Effect": "Deny", "Action": "s3:DeleteObject", "Resource": ["arn:aws:s3:::mybucket/photos/2024/*", "arn:aws:s3:::mybucket/*/private/*"]},
+		{"Effect": "Deny", "Action": "s3:*", "Resource": "arn:aws:s3:::mybucket/public/*", "Condition": {"StringNotEquals": {"aws:username": "alice"}}},
+		{"Effect": "Allow", "Action": "s3:DeleteObject", "Resource": "arn:aws:s3:::mybucket/public/*", "Condition": {"StringEquals": {"s3:versionid": "v1"}}}
+	]}`)
+	kms := mustParsePolicy(t, `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "kms:*", "Resource": "arn:minio:kms:::mykey*"}]}`)
+
+	testCases := []struct {
+		policy Policy
+		base   Args
+	}{
+		{mixed, Args{Action: DeleteObjectAction, BucketName: "mybucket"}},
+		{mixed, Args{Action: DeleteObjectAction, BucketName: "mybucket", ConditionValues: map[string][]string{"username": {"alice"}}, VersionID: "v1"}},
+		{mixed, Args{Action: DeleteObjectAction, BucketName: "mybucket", ConditionValues: map[string][]string{"username": {"bob"}}}},
+		{mixed, Args{Action: DeleteObjectAction, BucketName: "mybucket", DenyOnly: true}},
+		{mixed, Args{Action: DeleteObjectAction, BucketName: "mybucket", IsOwner: true}},
+		{mixed, Args{Action: DeleteObjectAction, BucketName: "mybucket", StrictConditionContext: true}},
+		{mixed, Args{Action: DeleteObjectAction, BucketName: "mybucket", ObjectNameEncoded: true}},
+		{mixed, Args{Action: GetObjectAction, BucketName: "otherbucket"}},
+		{kms, Args{Action: Action(KMSDeleteKeyAction), BucketName: "mykey"}},
+	}
+
+	objects := genListing(1000, 2)
+	objects = append(objects, "photos%2F2024%2Fa", "public%2Fa", "bob%2Fprivate%2Fa")
+	for i, testCase := range testCases {
+		result := testCase.policy.IsAllowedBatch(testCase.base, objects)
+		if len(result) != len(objects) {
+			t.Fatalf("case %v: expected: %v results, got: %v", i+1, len(objects), len(result))
+		}
+		for j, object := range objects {
+			args := testCase.base
+			args.ObjectName = object
+			if expected := testCase.policy.IsAllowed(args); result[j] != expected {
+				t.Fatalf("case %v: object %q: expected: %v, got: %v", i+1, object, expected, result[j])
+			}
+		}
+	}
+}
+
+func TestIsAllowedBatchObserver(t *testing.T) {
+	p := mustParsePolicy(t, `{"Version": "2012-10-17", "Statement": [
+		{"Effect": "Allow", "Action": "s3:DeleteObject", "Resource": "arn:aws:s3:::mybucket/*"},
+		{"Effect": "Deny", "Action": "s3:DeleteObject", "Resource": "arn:aws:s3:::mybucket/private/*"}
+	]}`)
+
+	type observation struct {
+		object       string
+		allowed      bool
+		explicitDeny bool
+		region       []string
+	}
+	var observed []observation
+	SetEvaluationObserver(func(args *Args, allowed bool, explicitDeny bool, _ time.Duration) {
+		observed = append(observed, observation{args.ObjectName, allowed, explicitDeny, args.ConditionValues[condition.AWSRequestedRegion.Name()]})
+	})
+	defer SetEvaluationObserver(nil)
+
+	// Observers receive normalized arguments.
+	base := Args{Action: DeleteObjectAction, BucketName: "mybucket", Region: "us-east-1"}
+	p.IsAllowedBatch(base, []string{"a", "private/b"})
+	expected := []observation{{"a", true, false, []string{"us-east-1"}}, {"private/b", false, true, []string{"us-east-1"}}}
+	if fmt.Sprint(observed) != fmt.Sprint(expected) {
+		t.Fatalf("expected: %v, got: %v", expected, observed)
+	}
+}
+
+// BenchmarkFilterAllowedObjects compares FilterAllowedObjects with calling
+// IsAllowed for each key of a listing page.
+func BenchmarkFilterAllowedObjects(b *testing.B) {
+	p, err := ParseConfig(strings.NewReader(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/photos/*"}]}`))
+	if err != nil {
+		b.Fatalf("unexpected error: %v", err)
+	}
+	objects := make([]string, 1000)
+	for i := range objects {
+		objects[i] = fmt.Sprintf("photos/2024/%04d.jpg", i)
+	}
+
+	b.Run("IsAllowed", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, object := range objects {
+				p.IsAllowed(Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: object})
+			}
+		}
+	})
+	b.Run("FilterAllowedObjects", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			p.FilterAllowedObjects(GetObjectAction, "mybucket", objects, nil)
+		}
+	})
+}
+
+// BenchmarkIsAllowedBatch compares IsAllowedBatch with calling IsAllowed
+// for each key of a DeleteObjects request.
+func BenchmarkIsAllowedBatch(b *testing.B) {
+	p, err := ParseConfig(strings.NewReader(`{"Version": "2012-10-17", "Statement": [
+		{"Effect": "Allow", "Action": "s3:DeleteObject", "Resource": ["arn:aws:s3:::mybucket/photos/*", "arn:aws:s3:::mybucket/${aws:username}/*"]},
+		{"Effect": "Deny", "Action": "s3:DeleteObject", "Resource": "arn:aws:s3:::mybucket/photos/2024/*"}
+	]}`))
+	if err != nil {
+		b.Fatalf("unexpected error: %v", err)
+	}
+	objects := make([]string, 1000)
+	for i := range objects {
+		objects[i] = fmt.Sprintf("photos/%d/%04d.jpg", 2023+i%3, i)
+	}
+	base := Args{
+		Action:          DeleteObjectAction,
+		BucketName:      "mybucket",
+		ConditionValues: map[string][]string{"username": {"alice"}},
+		Region:          "us-east-1",
+	}
+
+	b.Run("IsAllowed", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, object := range objects {
+				args := base
+				args.ObjectName = object
+				p.IsAllowed(args)
+			}
+		}
+	})
+	b.Run("IsAllowedBatch", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			p.IsAllowedBatch(base, objects)
+		}
+	})
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"encoding/binary"
+	"slices"
+	"sort"
+
+	"github.com/zeebo/xxh3"
+)
+
+// Magic prefixes of the canonical encodings, the trailing digit is the
+// version of the encoding.
+const (
+	policyFingerprintMagic       = "minio-iam-policy-1"
+	bucketPolicyFingerprintMagic = "minio-bucket-policy-1"
+)
+
+// appendString - appends the length prefixed s to dst.
+func appendString(dst []byte, s string) []byte {
+	dst = binary.AppendUvarint(dst, uint64(len(s)))
+	return append(dst, s...)
+}
+
+// appendStrings - appends the sorted, length prefixed strings of ss to
+// dst, prefixed by their number.
+func appendStrings(dst []byte, ss []string) []byte {
+	sort.Strings(ss)
+	dst = binary.AppendUvarint(dst, uint64(len(ss)))
+	for _, s := range ss {
+		dst = appendString(dst, s)
+	}
+	return dst
+}
+
+func (actionSet ActionSet) appendCanonical(dst []byte) []byte {
+	actions := make([]string, 0, len(actionSet))
+	for action := range actionSet {
+		actions = append(actions, string(action))
+	}
+	return appendStrings(dst, actions)
+}
+
+func (resourceSet ResourceSet) appendCanonical(dst []byte) []byte {
+	resources := make([]string, 0, len(resourceSet))
+	for resource := range resourceSet {
+		resources = append(resources, resource.String())
+	}
+	return appendStrings(dst, resources)
+}
+
+// appendStatements - appends the sorted, distinct statement encodings to
+// dst, as statements apply irrespective of their order.
+func appendStatements(dst []byte, statements []string) []byte {
+	sort.Strings(statements)
+	return appendStrings(dst, slices.Compact(statements))
+}
+
+// Fingerprint - returns a hash of the semantics of the policy, suitable
+// for cache keys and change detection. It is the XXH3-128 hash of a
+// canonical encoding of the policy ID, the version and the statements:
+// their SID, effect, actions, resources and conditions, including the Not*
+// forms. Sets are sorted and statements are unordered, hence policies
+// differing only in JSON formatting or ordering have the same fingerprint.
+//
+// Fingerprints are stable across releases unless the semantics of
+// policies change.
+func (iamp Policy) Fingerprint() [16]byte {
+	statements := make([]string, 0, len(iamp.Statements))
+	for _, statement := range iamp.Statements {
+		b := appendString(nil, string(statement.SID))
+		b = appendString(b, string(statement.Effect))
+		b = statement.Actions.appendCanonical(b)
+		b = statement.NotActions.appendCanonical(b)
+		b = statement.Resources.appendCanonical(b)
+		b = statement.Conditions.AppendCanonical(b)
+		statements = append(statements, string(b))
+	}
+
+	b := appendString(nil, policyFingerprintMagic)
+	b = appendString(b, string(iamp.ID))
+	b = appendString(b, iamp.Version)
+	b = appendStatements(b, statements)
+	return xxh3.Hash128(b).Bytes()
+}
+
+// Fingerprint - returns a hash of the semantics of the bucket policy, as
+// Policy.Fingerprint does. Statement principals are part of the
+// fingerprint.
+func (policy BucketPolicy) Fingerprint() [16]byte {
+	statements := make([]string, 0, len(policy.Statements))
+	for _, statement := range policy.Statements {
+		statements = append(statements, string(statement.appendCanonical(nil)))
+	}
+
+	b := appendString(nil, bucketPolicyFingerprintMagic)
+	b = appendString(b, string(policy.ID))
+	b = appendString(b, policy.Version)
+	b = appendStatements(b, statements)
+	return xxh3.Hash128(b).Bytes()
+}
+
+// appendCanonical - appends the canonical encoding of the statement, as
+// hashed by BucketPolicy.Fingerprint, to dst.
+func (statement BPStatement) appendCanonical(dst []byte) []byte {
+	b := appendString(dst, string(statement.SID))
+	b = appendString(b, string(statement.Effect))
+	b = appendStrings(b, statement.Principal.AWS.ToSlice())
+	b = statement.Actions.appendCanonical(b)
+	b = statement.NotActions.appendCanonical(b)
+	b = statement.Resources.appendCanonical(b)
+	b = statement.NotResources.appendCanonical(b)
+	return statement.Conditions.AppendCanonical(b)
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+const fingerprintFixturePolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "read",
+      "Effect": "Allow",
+      "Action": ["s3:GetObject", "s3:ListBucket"],
+      "Resource": ["arn:aws:s3:::mybucket", "arn:aws:s3:::mybucket/*"],
+      "Condition": {
+        "IpAddress": {"aws:SourceIp": ["192.168.1.0/24", "10.0.0.0/8"]},
+        "StringLike": {"aws:Referer": ["docs/*", "home/*"]}
+      }
+    },
+    {
+      "Effect": "Deny",
+      "NotAction": ["s3:GetObject"],
+      "Resource": ["arn:aws:s3:::mybucket/private/*"]
+    }
+  ]
+}`
+
+const fingerprintFixtureBucketPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "public-read",
+      "Effect": "Allow",
+      "Principal": {"AWS": ["*"]},
+      "Action": ["s3:GetObject"],
+      "Resource": ["arn:aws:s3:::mybucket/*"],
+      "Condition": {"StringEquals": {"s3:ExistingObjectTag/public": ["yes"]}}
+    },
+    {
+      "Effect": "Deny",
+      "Principal": {"AWS": ["arn:aws:iam::111122223333:root"]},
+      "Action": ["s3:PutObject"],
+      "NotResource": ["arn:aws:s3:::mybucket/uploads/*"]
+    }
+  ]
+}`
+
+func mustParseFingerprint(t *testing.T, data string) [16]byte {
+	t.Helper()
+	p, err := ParseConfig(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return p.Fingerprint()
+}
+
+func mustParseBucketFingerprint(t *testing.T, data string) [16]byte {
+	t.Helper()
+	p, err := ParseBucketPolicyConfig(strings.NewReader(data), "mybucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return p.Fingerprint()
+}
+
+// The golden fingerprints pin the canonical encoding, they must only
+// change along with the semantics of policies.
+func TestPolicyFingerprintGolden(t *testing.T) {
+	testCases := []struct {
+		fingerprint [16]byte
+		expected    string
+	}{
+		{Policy{}.Fingerprint(), "44e2cff9b5b239dc2f1ca7b24b99797c"},
+		{mustParseFingerprint(t, fingerprintFixturePolicy), "e06bd48fce46680d9c1bec46e4f04f23"},
+		{mustParseBucketFingerprint(t, fingerprintFixtureBucketPolicy), "1c5e1b96e06d5cba113e0bf1f90cb4e2"},
+	}
+
+	for i, testCase := range testCases {
+		if result := hex.EncodeToString(testCase.fingerprint[:]); result != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+	}
+}
+
+func TestPolicyFingerprint(t *testing.T) {
+	reformatted := `{"Statement":[{"Effect":"Deny","Resource":"arn:aws:s3:::mybucket/private/*","NotAction":"s3:GetObject"},
+	{"Condition":{"StringLike":{"aws:Referer":["home/*","docs/*"]},"IpAddress":{"aws:SourceIp":["10.0.0.0/8","192.168.1.0/24"]}},
+	"Resource":["arn:aws:s3:::mybucket/*","arn:aws:s3:::mybucket"],"Action":["s3:ListBucket","s3:GetObject"],"Effect":"Allow","Sid":"read"}],
+	"Version":"2012-10-17"}`
+
+	testCases := []struct {
+		policy      string
+		expectEqual bool
+	}{
+		{fingerprintFixturePolicy, true},
+		{reformatted, true},
+		// Duplicate statements are dropped by parsing.
+		{strings.Replace(reformatted, `"Statement":[`, `"Statement":[{"Effect":"Deny","Resource":"arn:aws:s3:::mybucket/private/*","NotAction":"s3:GetObject"},`, 1), true},
+		{strings.Replace(fingerprintFixturePolicy, `"Sid": "read"`, `"Sid": "list"`, 1), false},
+		{strings.Replace(fingerprintFixturePolicy, `"Effect": "Deny"`, `"Effect": "Allow"`, 1), false},
+		{strings.Replace(fingerprintFixturePolicy, `"NotAction"`, `"Action"`, 1), false},
+		{strings.Replace(fingerprintFixturePolicy, `"s3:ListBucket"`, `"s3:ListBucketVersions"`, 1), false},
+		{strings.Replace(fingerprintFixturePolicy, `"arn:aws:s3:::mybucket/private/*"`, `"arn:aws:s3:::mybucket/public/*"`, 1), false},
+		{strings.Replace(fingerprintFixturePolicy, `"10.0.0.0/8"`, `"10.0.0.0/16"`, 1), false},
+		{strings.Replace(fingerprintFixturePolicy, `"StringLike"`, `"StringNotLike"`, 1), false},
+		{strings.Replace(fingerprintFixturePolicy, `"aws:Referer"`, `"aws:UserAgent"`, 1), false},
+		{strings.Replace(fingerprintFixturePolicy, `"Version": "2012-10-17"`, `"Id": "readers", "Version": "2012-10-17"`, 1), false},
+	}
+
+	expected := mustParseFingerprint(t, fingerprintFixturePolicy)
+	for i, testCase := range testCases {
+		if result := mustParseFingerprint(t, testCase.policy); (result == expected) != testCase.expectEqual {
+			t.Fatalf("case %v: expected equal: %v, got: %x, %x", i+1, testCase.expectEqual, expected, result)
+		}
+	}
+}
+
+func TestBucketPolicyFingerprint(t *testing.T) {
+	reformatted := `{"Version":"2012-10-17","Statement":[
+	{"NotResource":"arn:aws:s3:::mybucket/uploads/*","Action":"s3:PutObject","Principal":{"AWS":"arn:aws:iam::111122223333:root"},"Effect":"Deny"},
+	{"Condition":{"StringEquals":{"s3:ExistingObjectTag/public":"yes"}},"Resource":"arn:aws:s3:::mybucket/*","Action":"s3:GetObject","Principal":"*","Effect":"Allow","Sid":"public-read"}]}`
+
+	testCases := []struct {
+		policy      string
+		expectEqual bool
+	}{
+		{fingerprintFixtureBucketPolicy, true},
+		{reformatted, true},
+		{strings.Replace(fingerprintFixtureBucketPolicy, `"arn:aws:iam::111122223333:root"`, `"arn:aws:iam::444455556666:root"`, 1), false},
+		{strings.Replace(fingerprintFixtureBucketPolicy, `"NotResource"`, `"Resource"`, 1), false},
+		{strings.Replace(fingerprintFixtureBucketPolicy, `["yes"]`, `["no"]`, 1), false},
+	}
+
+	expected := mustParseBucketFingerprint(t, fingerprintFixtureBucketPolicy)
+	for i, testCase := range testCases {
+		if result := mustParseBucketFingerprint(t, testCase.policy); (result == expected) != testCase.expectEqual {
+			t.Fatalf("case %v: expected equal: %v, got: %x, %x", i+1, testCase.expectEqual, expected, result)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// FuzzParseConfig checks that numeric condition values either fail to
+// parse or survive an encoding round trip unchanged.
+func FuzzParseConfig(f *testing.F) {
+	for _, seed := range []string{
+		"1000", "-1", "0", "1.5", "1e3", "-0.0", `"1000"`, `"1.2"`,
+		"2147483648", "4294967296", "9223372036854775807", "9223372036854775808",
+		"-9223372036854775809", "1e308", "1e309", "-1e400", "5e-324", "1e-400",
+		"123456789012345678901234567890", `"NaN"`, `"Inf"`, `"0x10"`, "true", "[]", "{}",
+	} {
+		f.Add("NumericLessThan", seed)
+		f.Add("StringEquals", seed)
+	}
+
+	f.Fuzz(func(t *testing.T, operator, value string) {
+		data := fmt.Sprintf(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:ListBucket",
+			"Resource": "arn:aws:s3:::mybucket", "Condition": {%q: {"s3:max-keys": %s}}}]}`, operator, value)
+		p, err := ParseConfig(bytes.NewReader([]byte(data)))
+		if err != nil {
+			return
+		}
+
+		encoded, err := json.Marshal(p)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", data, err)
+		}
+		decoded, err := ParseConfig(bytes.NewReader(encoded))
+		if err != nil {
+			t.Fatalf("%s: encoded as %s: unexpected error: %v", data, encoded, err)
+		}
+		if !decoded.Equals(*p) {
+			t.Fatalf("%s: encoded as %s: expected: %v, got: %v", data, encoded, p, decoded)
+		}
+
+		args := Args{Action: ListBucketAction, BucketName: "mybucket", ConditionValues: map[string][]string{"max-keys": {value}}}
+		if decoded.IsAllowed(args) != p.IsAllowed(args) {
+			t.Fatalf("%s: encoded as %s: verdicts differ", data, encoded)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import "fmt"
+
+// Guard - organization-wide restrictions applied on top of the policies
+// of users, e.g. "never allow s3:ForceDeleteBucket". A guard consists of
+// the 'Deny' statements of its policies only, 'Allow' statements are
+// ignored. The zero Guard denies nothing.
+type Guard struct {
+	statements []Statement
+	warnings   []string
+}
+
+// NewGuard - returns a guard of the 'Deny' statements of policies, which
+// must be valid. Guard.Lint lists the ignored 'Allow' statements.
+func NewGuard(policies ...Policy) (Guard, error) {
+	var guard Guard
+	for i, p := range policies {
+		if err := p.Validate(); err != nil {
+			return Guard{}, Errorf("guard policy %d: %w", i+1, err)
+		}
+		for j, statement := range p.Statements {
+			if statement.Effect != Deny {
+				guard.warnings = append(guard.warnings, fmt.Sprintf("guard policy %d: %s: 'Allow' statements of guards are ignored", i+1, statementName(j, statement.SID)))
+				continue
+			}
+			guard.statements = append(guard.statements, statement.Clone())
+		}
+	}
+	return guard, nil
+}
+
+// Lint - returns warnings about the policies of the guard, i.e. their
+// 'Allow' statements which are ignored.
+func (guard Guard) Lint() []string {
+	return append([]string(nil), guard.warnings...)
+}
+
+// Check - returns whether the guard denies args and, if so, the first
+// 'Deny' statement which applies. Its cost only depends on the number of
+// statements of the guard.
+func (guard Guard) Check(args Args) (denied bool, by Statement) {
+	if len(guard.statements) == 0 {
+		return false, Statement{}
+	}
+	args.NormalizeConditions()
+	resource := requestResource(args)
+	for _, statement := range guard.statements {
+		if statement.match(args, resource) {
+			return true, statement.Clone()
+		}
+	}
+	return false, Statement{}
+}
+
+// EvaluateWithGuard - returns whether userPolicy allows args and guard
+// does not deny it. The guard is checked first, requests it denies are
+// rejected without evaluating userPolicy, nor notifying the evaluation
+// observer.
+func EvaluateWithGuard(guard Guard, userPolicy Policy, args Args) bool {
+	if denied, _ := guard.Check(args); denied {
+		return false
+	}
+	return userPolicy.IsAllowed(args)
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+const guardPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Action": "s3:*", "Resource": "arn:aws:s3:::*"},
+    {"Sid": "NoForceDelete", "Effect": "Deny", "Action": "s3:ForceDeleteBucket", "Resource": "arn:aws:s3:::*"}
+  ]
+}`
+
+const guardAuditPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Sid": "KeepAudit", "Effect": "Deny", "Action": ["s3:DeleteObject", "s3:PutObject"], "Resource": "arn:aws:s3:::audit/*", "Condition": {"NotIpAddress": {"aws:SourceIp": "10.0.0.0/8"}}}
+  ]
+}`
+
+func TestGuard(t *testing.T) {
+	guard, err := NewGuard(mustParsePolicy(t, guardPolicy), mustParsePolicy(t, guardAuditPolicy))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedWarnings := []string{"guard policy 1: statement 1: 'Allow' statements of guards are ignored"}
+	if warnings := guard.Lint(); !reflect.DeepEqual(warnings, expectedWarnings) {
+		t.Fatalf("expected: %v, got: %v", expectedWarnings, warnings)
+	}
+
+	userPolicy := mustParsePolicy(t, `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:*", "Resource": "arn:aws:s3:::*"}]}`)
+	sourceIP := func(ip string) map[string][]string {
+		return map[string][]string{"SourceIp": {ip}}
+	}
+
+	testCases := []struct {
+		args           Args
+		expectedDenied bool
+		expectedBy     ID
+		expectedResult bool
+	}{
+		{Args{Action: ForceDeleteBucketAction, BucketName: "mybucket"}, true, "NoForceDelete", false},
+		{Args{Action: ForceDeleteBucketAction, BucketName: "mybucket", IsOwner: true}, true, "NoForceDelete", false},
+		{Args{Action: DeleteBucketAction, BucketName: "mybucket"}, false, "", true},
+		{Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "a"}, false, "", true},
+		{Args{Action: PutObjectAction, BucketName: "audit", ObjectName: "log", ConditionValues: sourceIP("192.168.1.1")}, true, "KeepAudit", false},
+		{Args{Action: PutObjectAction, BucketName: "audit", ObjectName: "log", ConditionValues: sourceIP("10.1.1.1")}, false, "", true},
+		{Args{Action: GetObjectAction, BucketName: "audit", ObjectName: "log", ConditionValues: sourceIP("192.168.1.1")}, false, "", true},
+		// 'Allow' statements of the guard are ignored.
+		{Args{Action: ListBucketAction, BucketName: "mybucket"}, false, "", true},
+	}
+
+	for i, testCase := range testCases {
+		denied, by := guard.Check(testCase.args)
+		if denied != testCase.expectedDenied || by.SID != testCase.expectedBy {
+			t.Fatalf("case %v: expected: %v %v, got: %v %v", i+1, testCase.expectedDenied, testCase.expectedBy, denied, by.SID)
+		}
+		if result := EvaluateWithGuard(guard, userPolicy, testCase.args); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+		if result := EvaluateWithGuard(guard, Policy{}, testCase.args); result {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, false, result)
+		}
+	}
+
+	// The zero Guard denies nothing.
+	if denied, _ := (Guard{}).Check(testCases[0].args); denied {
+		t.Fatalf("expected: %v, got: %v", false, denied)
+	}
+
+	if _, err := NewGuard(Policy{Version: "2012-10-18"}); !errors.Is(err, ErrInvalidVersion) {
+		t.Fatalf("expected: %v, got: %v", ErrInvalidVersion, err)
+	}
+}
+
+// guardOf - returns a guard of n 'Deny' statements on distinct buckets.
+func guardOf(tb testing.TB, n int) Guard {
+	var p Policy
+	p.Version = DefaultVersion
+	for i := 0; i < n; i++ {
+		p.Statements = append(p.Statements, NewStatement("", Deny, NewActionSet(ForceDeleteBucketAction), NewResourceSet(NewResource(fmt.Sprintf("bucket%d", i))), nil))
+	}
+	guard, err := NewGuard(p)
+	if err != nil {
+		tb.Fatalf("unexpected error: %v", err)
+	}
+	return guard
+}
+
+// BenchmarkGuardCheck shows that checking a guard scales with its
+// statements only: the size of the user policy does not matter for the
+// requests the guard denies.
+func BenchmarkGuardCheck(b *testing.B) {
+	args := Args{Action: ForceDeleteBucketAction, BucketName: "other"}
+	for _, n := range []int{1, 10, 100} {
+		guard := guardOf(b, n)
+		b.Run(fmt.Sprintf("guard-%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				guard.Check(args)
+			}
+		})
+	}
+
+	guard := guardOf(b, 1)
+	denied := Args{Action: ForceDeleteBucketAction, BucketName: "bucket0"}
+	for _, n := range []int{10, 1000} {
+		var userPolicy Policy
+		userPolicy.Version = DefaultVersion
+		for i := 0; i < n; i++ {
+			userPolicy.Statements = append(userPolicy.Statements, NewStatement("", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource(fmt.Sprintf("bucket%d/*", i))), nil))
+		}
+		b.Run(fmt.Sprintf("denied-user-policy-%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				EvaluateWithGuard(guard, userPolicy, denied)
+			}
+		})
+	}
+}
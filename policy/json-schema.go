@@ -0,0 +1,193 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+// jsonSchemaDraft - JSON Schema dialect of JSONSchema.
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// schema - JSON Schema object.
+type schema map[string]interface{}
+
+// oneOrMany - returns the schema of item or of a non-empty array of
+// items, as accepted by sets such as ActionSet.
+func oneOrMany(item schema) schema {
+	return schema{"anyOf": []schema{
+		item,
+		{"type": "array", "items": item, "minItems": 1},
+	}}
+}
+
+// alternation - returns a regular expression alternation of all
+// literal strings.
+func alternation(literals []string) string {
+	quoted := make([]string, 0, len(literals))
+	for _, s := range literals {
+		quoted = append(quoted, regexp.QuoteMeta(s))
+	}
+	return "(" + strings.Join(quoted, "|") + ")"
+}
+
+// JSONSchema - returns a JSON Schema (draft 2020-12) of policy documents,
+// e.g. for the validation and auto-completion of policy editors. The
+// schema is generated from the supported actions, condition operators and
+// condition keys, including those registered by RegisterAction and
+// condition.RegisterKey, hence it must be called after registration.
+//
+// The schema checks the structure of policy documents only: a document
+// satisfying it may still fail Validate, e.g. if a condition key is not
+// supported by an action of its statement.
+func JSONSchema() []byte {
+	freezeActions()
+
+	// Actions, sorted for stable output.
+	var actions []string
+	namespaces := map[string]struct{}{}
+	addAction := func(a Action) {
+		actions = append(actions, string(a))
+		if ns := a.namespace(); ns != "" {
+			namespaces[ns] = struct{}{}
+		}
+	}
+	for a := range supportedActions {
+		addAction(a)
+	}
+	for a := range supportedAdminActions {
+		addAction(Action(a))
+	}
+	for a := range supportedKMSActions {
+		addAction(Action(a))
+	}
+	for a := range supportedSTSActions {
+		addAction(Action(a))
+	}
+	sort.Strings(actions)
+	services := make([]string, 0, len(namespaces))
+	for ns := range namespaces {
+		services = append(services, ns)
+	}
+	sort.Strings(services)
+
+	// Condition keys by namespace, a key may be followed by a variable,
+	// such as "s3:ExistingObjectTag/team".
+	keysByNamespace := map[string][]string{}
+	for _, key := range condition.AllSupportedKeys {
+		ns, name, _ := strings.Cut(string(key), ":")
+		keysByNamespace[ns] = append(keysByNamespace[ns], name)
+	}
+	conditionKeys := schema{}
+	for ns, names := range keysByNamespace {
+		sort.Strings(names)
+		conditionKeys["^"+regexp.QuoteMeta(ns)+":"+alternation(names)+"(/.+)?$"] = schema{"$ref": "#/$defs/conditionValues"}
+	}
+	// Option of the MinIO extension operators, see condition.TimezoneOption.
+	conditionKeys["^"+regexp.QuoteMeta(condition.TimezoneOption)+"$"] = schema{"$ref": "#/$defs/conditionValues"}
+
+	// Resource ARN prefixes, sorted for stable output.
+	var resourcePatterns []schema
+	for _, prefix := range []string{ResourceARNPrefix, ResourceARNKMSPrefix} {
+		pattern := "^" + regexp.QuoteMeta(prefix) + ".*$"
+		if prefix == ResourceARNPrefix {
+			// S3 resources must not start with '/'.
+			pattern = "^" + regexp.QuoteMeta(prefix) + "([^/].*)?$"
+		}
+		resourcePatterns = append(resourcePatterns, schema{"pattern": pattern})
+	}
+	// Access points are accepted but never match, see ResourceARNAccessPoint.
+	resourcePatterns = append(resourcePatterns, schema{"pattern": "^arn:[^:]+:(s3|s3-object-lambda):[^:]+:[^:]+:accesspoint/.+$"})
+
+	s := schema{
+		"$schema":              jsonSchemaDraft,
+		"title":                "MinIO policy",
+		"type":                 "object",
+		"additionalProperties": false,
+		"properties": schema{
+			"ID":      schema{"type": "string"},
+			"Version": schema{"enum": []string{DefaultVersion, ""}},
+			"Statement": schema{
+				"anyOf": []schema{
+					{"type": "array", "items": schema{"$ref": "#/$defs/statement"}},
+					{"type": "null"},
+				},
+			},
+		},
+		"$defs": schema{
+			"statement": schema{
+				"type":                 "object",
+				"additionalProperties": false,
+				"required":             []string{"Effect"},
+				"oneOf": []schema{
+					{"required": []string{"Action"}},
+					{"required": []string{"NotAction"}},
+				},
+				"properties": schema{
+					"Sid":         schema{"type": "string"},
+					"Description": schema{"type": "string", "maxLength": MaxStatementDescriptionLength},
+					"Effect":      schema{"enum": []Effect{Allow, Deny}},
+					"Action":      schema{"$ref": "#/$defs/actions"},
+					"NotAction":   schema{"$ref": "#/$defs/actions"},
+					"Resource":    schema{"$ref": "#/$defs/resources"},
+					"Condition":   schema{"$ref": "#/$defs/condition"},
+				},
+			},
+			"actions": oneOrMany(schema{"$ref": "#/$defs/action"}),
+			"action": schema{
+				"type": "string",
+				"anyOf": []schema{
+					{"enum": append(actions, "*")},
+					// Wildcard patterns of the actions of a service, such as "s3:Get*".
+					{"pattern": "^" + alternation(services) + `:[A-Za-z0-9_\-*?]*[*?][A-Za-z0-9_\-*?]*$`},
+				},
+			},
+			"resources": oneOrMany(schema{"$ref": "#/$defs/resource"}),
+			"resource": schema{
+				"type":  "string",
+				"anyOf": resourcePatterns,
+			},
+			"condition": schema{
+				"type":          "object",
+				"minProperties": 1,
+				"propertyNames": schema{
+					"pattern": "^(" + alternation(condition.OperatorQualifiers()) + ":)?" + alternation(condition.OperatorNames()) + "$",
+				},
+				"additionalProperties": schema{
+					"type":                 "object",
+					"patternProperties":    conditionKeys,
+					"additionalProperties": false,
+				},
+			},
+			"conditionValues": oneOrMany(schema{"$ref": "#/$defs/conditionValue"}),
+			"conditionValue":  schema{"type": []string{"string", "number", "boolean"}},
+		},
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		// The schema consists of strings, slices and maps only.
+		panic(err)
+	}
+	return data
+}
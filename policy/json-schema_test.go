@@ -0,0 +1,158 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+func compileJSONSchema(t *testing.T) *jsonschema.Schema {
+	t.Helper()
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(JSONSchema()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource("policy.json", doc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sch, err := c.Compile("policy.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return sch
+}
+
+func validateJSONSchema(sch *jsonschema.Schema, data []byte) error {
+	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	return sch.Validate(inst)
+}
+
+// TestJSONSchemaFixtures validates the policies of the test corpus, which
+// are all valid, against the schema.
+func TestJSONSchemaFixtures(t *testing.T) {
+	sch := compileJSONSchema(t)
+
+	fixtures := map[string][]byte{
+		"decisionCachePolicy":      []byte(decisionCachePolicy),
+		"fingerprintFixturePolicy": []byte(fingerprintFixturePolicy),
+	}
+	files, err := filepath.Glob("testdata/policies/*.json")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("expected policy fixtures, got: %v, %v", files, err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		fixtures[file] = data
+	}
+	for _, p := range DefaultPolicies {
+		data, err := json.Marshal(p.Definition)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		fixtures[p.Name] = data
+	}
+	canned := NewReadWritePolicy(BucketPrefix{Bucket: "mybucket", Prefix: "photos/"})
+	if fixtures["readwrite canned"], err = json.Marshal(canned); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for name, data := range fixtures {
+		if _, err := ParseConfig(bytes.NewReader(data)); err != nil {
+			t.Fatalf("%v: unexpected error: %v", name, err)
+		}
+		if err := validateJSONSchema(sch, data); err != nil {
+			t.Fatalf("%v: unexpected error: %v", name, err)
+		}
+	}
+}
+
+// instanceLocations - returns the locations of the values failing
+// validation, such as "/Statement/0/Effect".
+func instanceLocations(err error) []string {
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) {
+		return nil
+	}
+	var locations []string
+	var walk func(*jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		locations = append(locations, "/"+strings.Join(e.InstanceLocation, "/"))
+		for _, cause := range e.Causes {
+			walk(cause)
+		}
+	}
+	walk(verr)
+	return locations
+}
+
+func TestJSONSchemaInvalid(t *testing.T) {
+	sch := compileJSONSchema(t)
+
+	testCases := []struct {
+		data     string
+		location string
+	}{
+		// Unknown fields are ignored by ParseConfig.
+		{`{"Version": "2012-10-17", "Statment": []}`, "/"},
+		{`{"Version": "2012-10-18", "Statement": []}`, "/Version"},
+		{`{"Statement": [{"Effect": "Alow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"}]}`, "/Statement/0/Effect"},
+		{`{"Statement": [{"Effect": "Allow", "Action": ["s3:ListBucket", "s3:GetObjekt"], "Resource": "arn:aws:s3:::mybucket/*"}]}`, "/Statement/0/Action/1"},
+		{`{"Statement": [{"Effect": "Allow", "Action": "bar:*", "Resource": "arn:aws:s3:::mybucket/*"}]}`, "/Statement/0/Action"},
+		{`{"Statement": [{"Effect": "Allow", "Resource": "arn:aws:s3:::mybucket/*"}]}`, "/Statement/0"},
+		{`{"Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "NotAction": "s3:PutObject", "Resource": "arn:aws:s3:::mybucket/*"}]}`, "/Statement/0"},
+		{`{"Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "mybucket/*"}]}`, "/Statement/0/Resource"},
+		{`{"Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::/mybucket"}]}`, "/Statement/0/Resource"},
+		{`{"Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*", "Condition": {"StringEqulas": {"s3:prefix": "a"}}}]}`, "/Statement/0/Condition"},
+		{`{"Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*", "Condition": {"ForSomeValues:StringEquals": {"s3:prefix": "a"}}}]}`, "/Statement/0/Condition"},
+		{`{"Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*", "Condition": {"StringEquals": {"s3:prefixx": "a"}}}]}`, "/Statement/0/Condition/StringEquals"},
+		{`{"Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*", "Condition": {"StringEquals": {"s3:prefix": []}}}]}`, "/Statement/0/Condition/StringEquals/s3:prefix"},
+		{`{"Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*", "Condition": {"StringEquals": {"s3:prefix": {"a": "b"}}}}]}`, "/Statement/0/Condition/StringEquals/s3:prefix"},
+	}
+
+	for i, testCase := range testCases {
+		if _, err := ParseConfig(strings.NewReader(testCase.data)); err == nil && i > 0 {
+			t.Fatalf("case %v: expected an invalid policy", i+1)
+		}
+		err := validateJSONSchema(sch, []byte(testCase.data))
+		if err == nil {
+			t.Fatalf("case %v: expected validation error", i+1)
+		}
+		var found bool
+		for _, location := range instanceLocations(err) {
+			found = found || location == testCase.location
+		}
+		if !found {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.location, instanceLocations(err))
+		}
+	}
+}
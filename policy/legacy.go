@@ -0,0 +1,106 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// LegacyVersion - version of policies written before 2012-10-17, which AWS
+// still accepts. Policies of this version are evaluated as DefaultVersion
+// ones and keep their version when encoded.
+const LegacyVersion = "2008-10-17"
+
+// errStatementObject - error of documents with a single statement object
+// in strict mode.
+var errStatementObject = errors.New("'Statement' must be an array of statements")
+
+// legacyStatements - returns data with a single statement object in place
+// of the array of statements wrapped into an array. Other documents are
+// returned as is.
+func legacyStatements(data []byte) ([]byte, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	for k, v := range doc {
+		// Field names match case-insensitively, as decoding does.
+		if strings.EqualFold(k, "Statement") && bytes.HasPrefix(bytes.TrimSpace(v), []byte("{")) {
+			doc[k] = append(append([]byte("["), v...), ']')
+			return json.Marshal(doc)
+		}
+	}
+	return data, nil
+}
+
+// isStatementObjectError - returns whether err is the error of decoding a
+// document with a single statement object in place of the array of
+// statements, see legacyStatements.
+func isStatementObjectError(err error) bool {
+	var typeErr *json.UnmarshalTypeError
+	return errors.As(err, &typeErr) && typeErr.Value == "object" && strings.EqualFold(typeErr.Field, "Statement")
+}
+
+// checkNotLegacy - returns an error if data is a document accepted in
+// compatibility mode only, i.e. of LegacyVersion, with a single statement
+// object or with effects in unusual case such as "allow".
+func checkNotLegacy(data []byte) error {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	for k, v := range doc {
+		switch {
+		case strings.EqualFold(k, "Version"):
+			var version string
+			if json.Unmarshal(v, &version) == nil && version == LegacyVersion {
+				return Errorf("%w '%v'", ErrInvalidVersion, version)
+			}
+		case strings.EqualFold(k, "Statement"):
+			if bytes.HasPrefix(bytes.TrimSpace(v), []byte("{")) {
+				return Errorf("%w", errStatementObject)
+			}
+			var statements []struct{ Effect Effect }
+			if json.Unmarshal(v, &statements) != nil {
+				// Reported when decoding the document.
+				continue
+			}
+			for _, statement := range statements {
+				if !statement.Effect.IsValid() && normalizeEffect(statement.Effect).IsValid() {
+					return Errorf("%w %v", ErrInvalidEffect, statement.Effect)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// normalizeEffect - returns Allow or Deny for effects in any case, such as
+// "allow", other effects are returned as is.
+func normalizeEffect(effect Effect) Effect {
+	switch {
+	case strings.EqualFold(string(effect), string(Allow)):
+		return Allow
+	case strings.EqualFold(string(effect), string(Deny)):
+		return Deny
+	}
+	return effect
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// checkGolden - compares the indented JSON data with the golden file of
+// fixture, or updates it with -update.
+func checkGolden(t *testing.T, fixture string, data []byte) {
+	t.Helper()
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "  "); err != nil {
+		t.Fatalf("%v: unexpected error: %v", fixture, err)
+	}
+	indented.WriteByte('\n')
+
+	golden := strings.TrimSuffix(fixture, ".json") + ".golden"
+	if *update {
+		if err := os.WriteFile(golden, indented.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("%v: unexpected error: %v", fixture, err)
+	}
+	if !bytes.Equal(indented.Bytes(), expected) {
+		t.Fatalf("%v: expected: %s, got: %s", fixture, expected, indented.Bytes())
+	}
+}
+
+// TestLegacyBucketPolicies checks the bucket policies of AWS documents of
+// the 2008-10-17 era: they are accepted by default and rejected in strict
+// mode, their version is kept and the rest is normalized.
+func TestLegacyBucketPolicies(t *testing.T) {
+	fixtures, err := filepath.Glob("testdata/legacy/bucket-*.json")
+	if err != nil || len(fixtures) == 0 {
+		t.Fatalf("expected legacy fixtures, got: %v, %v", fixtures, err)
+	}
+
+	for _, fixture := range fixtures {
+		data, err := os.ReadFile(fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ParseBucketPolicyConfigStrict(bytes.NewReader(data), "examplebucket"); err == nil {
+			t.Fatalf("%v: expected an error in strict mode", fixture)
+		}
+		p, err := ParseBucketPolicyConfig(bytes.NewReader(data), "examplebucket")
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", fixture, err)
+		}
+		if p.Version != LegacyVersion {
+			t.Fatalf("%v: expected: %v, got: %v", fixture, LegacyVersion, p.Version)
+		}
+
+		out, err := json.Marshal(p)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", fixture, err)
+		}
+		checkGolden(t, fixture, out)
+
+		// The normalized document round-trips and only keeps its legacy
+		// version.
+		p2, err := ParseBucketPolicyConfig(bytes.NewReader(out), "examplebucket")
+		if err != nil || !p.Equals(*p2) {
+			t.Fatalf("%v: expected: %v, got: %v, %v", fixture, p, p2, err)
+		}
+		if _, err := ParseBucketPolicyConfigStrict(bytes.NewReader(out), "examplebucket"); !errors.Is(err, ErrInvalidVersion) {
+			t.Fatalf("%v: expected: %v, got: %v", fixture, ErrInvalidVersion, err)
+		}
+	}
+}
+
+func TestLegacyPolicies(t *testing.T) {
+	fixtures, err := filepath.Glob("testdata/legacy/iam-*.json")
+	if err != nil || len(fixtures) == 0 {
+		t.Fatalf("expected legacy fixtures, got: %v, %v", fixtures, err)
+	}
+
+	opts := ValidationOptions{AllowLegacyDocuments: true}
+	for _, fixture := range fixtures {
+		data, err := os.ReadFile(fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Compatibility mode is opt-in for IAM policies.
+		if _, err := ParseConfig(bytes.NewReader(data)); err == nil {
+			t.Fatalf("%v: expected an error in strict mode", fixture)
+		}
+		p, err := ParseConfigWithOptions(bytes.NewReader(data), opts)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", fixture, err)
+		}
+
+		out, err := json.Marshal(p)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", fixture, err)
+		}
+		checkGolden(t, fixture, out)
+
+		p2, err := ParseConfigWithOptions(bytes.NewReader(out), opts)
+		if err != nil || !p.Equals(*p2) {
+			t.Fatalf("%v: expected: %v, got: %v, %v", fixture, p, p2, err)
+		}
+		if err := p.Validate(); !errors.Is(err, ErrInvalidVersion) {
+			t.Fatalf("%v: expected: %v, got: %v", fixture, ErrInvalidVersion, err)
+		}
+	}
+}
+
+func TestLegacyStrict(t *testing.T) {
+	testCases := []struct {
+		data        string
+		expectedErr error
+	}{
+		{`{"Version": "2008-10-17", "Statement": []}`, ErrInvalidVersion},
+		{`{"Version": "2012-10-17", "Statement": {"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::examplebucket/*"}}`, errStatementObject},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "ALLOW", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::examplebucket/*"}]}`, ErrInvalidEffect},
+		// Other invalid effects are rejected in both modes.
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allowed", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::examplebucket/*"}]}`, ErrInvalidEffect},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::examplebucket/*"}]}`, nil},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseBucketPolicyConfigStrict(strings.NewReader(testCase.data), "examplebucket")
+		if !errors.Is(err, testCase.expectedErr) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+		_, err = ParseBucketPolicyConfig(strings.NewReader(testCase.data), "examplebucket")
+		if (err != nil) != (i == 3) {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+// statementName - returns how the i-th statement with sid is referred to
+// in lint warnings.
+func statementName(i int, sid ID) string {
+	if sid != "" {
+		return fmt.Sprintf("statement '%s'", sid)
+	}
+	return fmt.Sprintf("statement %d", i+1)
+}
+
+// versionActions - actions which always target an object version, the
+// s3:versionid condition key always has a value for them.
+var versionActions = NewActionSet(
+	DeleteObjectVersionAction,
+	DeleteObjectVersionTaggingAction,
+	GetObjectVersionAction,
+	GetObjectVersionAttributesAction,
+	GetObjectVersionTaggingAction,
+	PutObjectVersionTaggingAction,
+	GetObjectVersionForReplicationAction,
+	GetObjectVersionACLAction,
+	PutObjectVersionACLAction,
+)
+
+// versionConditionWarnings - returns warnings about conditions on the
+// s3:versionid key of a statement which never match since all its actions
+// target an object version.
+func versionConditionWarnings(name string, actions ActionSet, conditions condition.Functions) []string {
+	if len(actions) == 0 {
+		return nil
+	}
+	for action := range actions {
+		if !versionActions.Contains(action) {
+			return nil
+		}
+	}
+
+	var warnings []string
+	for _, c := range conditions.Conditions() {
+		if c.Key.Is(condition.S3VersionID) && c.Operator == "Null" && len(c.Values) == 1 && c.Values[0] == "true" {
+			warnings = append(warnings, fmt.Sprintf("%s: condition 'Null' of '%s' never matches, the actions always target an object version", name, c.Key))
+		}
+	}
+	return warnings
+}
+
+// extensionConditionWarnings - returns warnings about the condition
+// operators of a statement which are MinIO extensions.
+func extensionConditionWarnings(name string, conditions condition.Functions) []string {
+	var warnings []string
+	seen := map[string]bool{}
+	for _, c := range conditions.Conditions() {
+		if !seen[c.Operator] && condition.IsExtensionOperator(c.Operator) {
+			seen[c.Operator] = true
+			warnings = append(warnings, fmt.Sprintf("%s: condition '%s' is a MinIO extension, the policy is not portable to AWS", name, c.Operator))
+		}
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
+// conditionKeyTypeWarnings - returns warnings about the numeric and date
+// condition operators of a statement used with keys of another type,
+// which AWS rejects, see condition.CheckOperatorKey.
+func conditionKeyTypeWarnings(name string, conditions condition.Functions) []string {
+	var warnings []string
+	for _, c := range conditions.Conditions() {
+		var keyErr condition.ErrUnsupportedOperatorKey
+		if errors.As(condition.CheckOperatorKey(c.Operator, c.Key), &keyErr) {
+			warnings = append(warnings, fmt.Sprintf("%s: condition '%s' of '%s' expects %s keys, the policy is not portable to AWS", name, c.Operator, c.Key, keyErr.Expected))
+		}
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
+// accountLevelWarnings - returns warnings about the account level actions
+// of a statement none of whose resources matches the account, see
+// Action.IsAccountLevel.
+func accountLevelWarnings(name string, actions ActionSet, resources ResourceSet) []string {
+	if len(resources) == 0 || resources.matchesAccount() {
+		return nil
+	}
+	var warnings []string
+	for _, action := range actions.toSortedSlice() {
+		if action.IsAccountLevel() {
+			warnings = append(warnings, fmt.Sprintf("%s: action '%s' applies to the account and never matches the resources, use 'arn:aws:s3:::*'", name, action))
+		}
+	}
+	return warnings
+}
+
+// descriptionWarnings - returns a warning about the Description of a
+// statement, which is a MinIO extension, if it is set.
+func descriptionWarnings(name, description string) []string {
+	if description == "" {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s: 'Description' (%q) is a MinIO extension, the policy is not portable to AWS, see StripExtensions", name, description)}
+}
+
+// Lint - returns warnings about statements of the policy which are valid
+// but have no effect in MinIO.
+func (iamp Policy) Lint() []string {
+	var warnings []string
+	for i, statement := range iamp.Statements {
+		if namespaces := statement.actionNamespaces(); len(namespaces) > 1 {
+			warnings = append(warnings, fmt.Sprintf("%s: actions of services '%s' are mixed, resources only apply to some of them", statementName(i, statement.SID), strings.Join(namespaces, "', '")))
+		}
+		for _, resource := range statement.Resources.accessPoints() {
+			warnings = append(warnings, fmt.Sprintf("%s: resource '%s' is an unsupported resource type and never matches", statementName(i, statement.SID), resource))
+		}
+		for _, resource := range statement.Resources.toSortedSlice() {
+			if reason := resource.invalidBucketName(); reason != "" {
+				warnings = append(warnings, fmt.Sprintf("%s: resource '%s' never matches, %s", statementName(i, statement.SID), resource, reason))
+			}
+		}
+		warnings = append(warnings, versionConditionWarnings(statementName(i, statement.SID), statement.Actions, statement.Conditions)...)
+		warnings = append(warnings, accountLevelWarnings(statementName(i, statement.SID), statement.Actions, statement.Resources)...)
+		warnings = append(warnings, extensionConditionWarnings(statementName(i, statement.SID), statement.Conditions)...)
+		warnings = append(warnings, conditionKeyTypeWarnings(statementName(i, statement.SID), statement.Conditions)...)
+		warnings = append(warnings, descriptionWarnings(statementName(i, statement.SID), statement.Description)...)
+	}
+	return warnings
+}
+
+// Lint - returns warnings about statements of the policy which are valid
+// but have no effect, or an unexpected one, in MinIO.
+func (policy BucketPolicy) Lint() []string {
+	var warnings []string
+	for i, statement := range policy.Statements {
+		name := statementName(i, statement.SID)
+
+		for _, action := range statement.Actions.toSortedSlice() {
+			if action.isACLAction() {
+				warnings = append(warnings, fmt.Sprintf("%s: action '%s' is ignored, ACLs are not supported on owner enforced buckets", name, action))
+			}
+		}
+
+		for _, key := range statement.Conditions.Keys().ToSortedSlice() {
+			if key.Is(condition.S3XAmzACL) {
+				warnings = append(warnings, fmt.Sprintf("%s: condition key '%s' only has a value if the request sends an ACL, which clients of owner enforced buckets usually do not", name, key))
+			}
+		}
+		warnings = append(warnings, versionConditionWarnings(name, statement.Actions, statement.Conditions)...)
+		warnings = append(warnings, extensionConditionWarnings(name, statement.Conditions)...)
+		warnings = append(warnings, conditionKeyTypeWarnings(name, statement.Conditions)...)
+		warnings = append(warnings, descriptionWarnings(name, statement.Description)...)
+	}
+	return warnings
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+// Object lock retention modes.
+const (
+	ObjectLockGovernance = "GOVERNANCE"
+	ObjectLockCompliance = "COMPLIANCE"
+)
+
+// ObjectLockInfo - object lock state of an object version.
+type ObjectLockInfo struct {
+	// Mode is the retention mode, ObjectLockGovernance, ObjectLockCompliance
+	// or empty if the version has no retention.
+	Mode string
+	// RetainUntil is the end of the retention period.
+	RetainUntil time.Time
+	// LegalHold is set if a legal hold is placed on the version.
+	LegalHold bool
+	// BypassGovernance is set if the request asks to bypass governance
+	// retention, i.e. x-amz-bypass-governance-retention is true.
+	BypassGovernance bool
+}
+
+// LockDecision - result of CheckObjectLockDelete.
+type LockDecision int
+
+// LockDecision values.
+const (
+	// LockDecisionDeniedPolicy - s3:DeleteObjectVersion is not allowed.
+	LockDecisionDeniedPolicy LockDecision = iota
+	// LockDecisionDeniedLegalHold - the version is under legal hold.
+	LockDecisionDeniedLegalHold
+	// LockDecisionDeniedRetention - the version is under retention which
+	// is not bypassed.
+	LockDecisionDeniedRetention
+	// LockDecisionAllowedNoLock - the version is not locked.
+	LockDecisionAllowedNoLock
+	// LockDecisionAllowedWithBypass - the version is under governance
+	// retention which is bypassed.
+	LockDecisionAllowedWithBypass
+)
+
+func (d LockDecision) String() string {
+	switch d {
+	case LockDecisionDeniedLegalHold:
+		return "DeniedLegalHold"
+	case LockDecisionDeniedRetention:
+		return "DeniedRetention"
+	case LockDecisionAllowedNoLock:
+		return "AllowedNoLock"
+	case LockDecisionAllowedWithBypass:
+		return "AllowedWithBypass"
+	default:
+		return "DeniedPolicy"
+	}
+}
+
+// IsAllowed - returns whether the delete may proceed.
+func (d LockDecision) IsAllowed() bool {
+	return d == LockDecisionAllowedNoLock || d == LockDecisionAllowedWithBypass
+}
+
+// CheckObjectLockDelete - checks whether args, for the object version
+// locked as per lock, may be permanently deleted. args.Action is ignored,
+// s3:DeleteObjectVersion and, for governance retention, also
+// s3:BypassGovernanceRetention are evaluated.
+//
+// The object lock condition keys are populated from lock, overriding any
+// value in args.ConditionValues, as MinIO does:
+//   - s3:object-lock-mode is set to the retention mode, if any.
+//   - s3:object-lock-retain-until-date is set to the end of the retention
+//     period in RFC 3339 format in UTC, if any.
+//   - s3:object-lock-remaining-retention-days is set to the number of days
+//     until the end of an active retention period, a partial day counts as
+//     a full day.
+//   - s3:object-lock-legal-hold is set to "ON" or "OFF".
+//
+// A legal hold can never be bypassed, neither can compliance retention.
+// Governance retention is bypassed only if lock.BypassGovernance is set and
+// s3:BypassGovernanceRetention is allowed.
+func CheckObjectLockDelete(p Policy, args Args, lock ObjectLockInfo) LockDecision {
+	return checkObjectLockDelete(p, args, lock, time.Now())
+}
+
+func checkObjectLockDelete(p Policy, args Args, lock ObjectLockInfo, now time.Time) LockDecision {
+	retained := lock.Mode != "" && lock.RetainUntil.After(now)
+
+	conditionValues := make(map[string][]string, len(args.ConditionValues)+4)
+	for key, values := range args.ConditionValues {
+		conditionValues[key] = values
+	}
+	if lock.Mode != "" {
+		conditionValues[condition.S3ObjectLockMode.ToKey().Name()] = []string{lock.Mode}
+	}
+	if !lock.RetainUntil.IsZero() {
+		conditionValues[condition.S3ObjectLockRetainUntilDate.ToKey().Name()] = []string{lock.RetainUntil.UTC().Format(time.RFC3339)}
+	}
+	if retained {
+		days := math.Ceil(lock.RetainUntil.Sub(now).Hours() / 24)
+		conditionValues[condition.S3ObjectLockRemainingRetentionDays.ToKey().Name()] = []string{strconv.Itoa(int(days))}
+	}
+	legalHold := "OFF"
+	if lock.LegalHold {
+		legalHold = "ON"
+	}
+	conditionValues[condition.S3ObjectLockLegalHold.ToKey().Name()] = []string{legalHold}
+	args.ConditionValues = conditionValues
+
+	args.Action = DeleteObjectVersionAction
+	if !p.IsAllowed(args) {
+		return LockDecisionDeniedPolicy
+	}
+
+	switch {
+	case lock.LegalHold:
+		return LockDecisionDeniedLegalHold
+	case !retained:
+		return LockDecisionAllowedNoLock
+	case lock.Mode != ObjectLockGovernance || !lock.BypassGovernance:
+		return LockDecisionDeniedRetention
+	}
+
+	args.Action = BypassGovernanceRetentionAction
+	if !p.IsAllowed(args) {
+		return LockDecisionDeniedRetention
+	}
+	return LockDecisionAllowedWithBypass
+}
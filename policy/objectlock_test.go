@@ -0,0 +1,108 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckObjectLockDelete(t *testing.T) {
+	bypassPolicy, err := ParseConfig(strings.NewReader(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Action": ["s3:DeleteObjectVersion"],
+            "Resource": ["arn:aws:s3:::mybucket/*"]
+        },
+        {
+            "Effect": "Allow",
+            "Action": ["s3:BypassGovernanceRetention"],
+            "Resource": ["arn:aws:s3:::mybucket/*"],
+            "Condition": {"NumericLessThanEquals": {"s3:object-lock-remaining-retention-days": "30"}}
+        }
+    ]
+}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	deletePolicy, err := ParseConfig(strings.NewReader(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Action": ["s3:DeleteObjectVersion"],
+            "Resource": ["arn:aws:s3:::mybucket/*"]
+        }
+    ]
+}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	args := Args{
+		AccountName: "Q3AM3UQ867SPQQA43P2F",
+		Action:      GetObjectAction,
+		BucketName:  "mybucket",
+		ObjectName:  "myobject",
+	}
+
+	testCases := []struct {
+		policy         *Policy
+		lock           ObjectLockInfo
+		expectedResult LockDecision
+	}{
+		// Not locked.
+		{deletePolicy, ObjectLockInfo{}, LockDecisionAllowedNoLock},
+		{deletePolicy, ObjectLockInfo{Mode: ObjectLockCompliance, RetainUntil: now.Add(-time.Hour)}, LockDecisionAllowedNoLock},
+		{deletePolicy, ObjectLockInfo{Mode: ObjectLockGovernance, RetainUntil: now}, LockDecisionAllowedNoLock},
+		// Governance retention.
+		{deletePolicy, ObjectLockInfo{Mode: ObjectLockGovernance, RetainUntil: now.AddDate(0, 0, 10)}, LockDecisionDeniedRetention},
+		{deletePolicy, ObjectLockInfo{Mode: ObjectLockGovernance, RetainUntil: now.AddDate(0, 0, 10), BypassGovernance: true}, LockDecisionDeniedRetention},
+		{bypassPolicy, ObjectLockInfo{Mode: ObjectLockGovernance, RetainUntil: now.AddDate(0, 0, 10)}, LockDecisionDeniedRetention},
+		{bypassPolicy, ObjectLockInfo{Mode: ObjectLockGovernance, RetainUntil: now.AddDate(0, 0, 10), BypassGovernance: true}, LockDecisionAllowedWithBypass},
+		{bypassPolicy, ObjectLockInfo{Mode: ObjectLockGovernance, RetainUntil: now.AddDate(0, 0, 30), BypassGovernance: true}, LockDecisionAllowedWithBypass},
+		// A partial day counts as a full day.
+		{bypassPolicy, ObjectLockInfo{Mode: ObjectLockGovernance, RetainUntil: now.AddDate(0, 0, 30).Add(time.Minute), BypassGovernance: true}, LockDecisionDeniedRetention},
+		// Compliance retention is never bypassed.
+		{deletePolicy, ObjectLockInfo{Mode: ObjectLockCompliance, RetainUntil: now.AddDate(0, 0, 10)}, LockDecisionDeniedRetention},
+		{bypassPolicy, ObjectLockInfo{Mode: ObjectLockCompliance, RetainUntil: now.AddDate(0, 0, 10), BypassGovernance: true}, LockDecisionDeniedRetention},
+		// Legal hold is never bypassed.
+		{bypassPolicy, ObjectLockInfo{LegalHold: true}, LockDecisionDeniedLegalHold},
+		{bypassPolicy, ObjectLockInfo{Mode: ObjectLockGovernance, RetainUntil: now.AddDate(0, 0, 10), LegalHold: true, BypassGovernance: true}, LockDecisionDeniedLegalHold},
+		// No delete permission.
+		{&Policy{Version: DefaultVersion}, ObjectLockInfo{}, LockDecisionDeniedPolicy},
+	}
+
+	for i, testCase := range testCases {
+		result := checkObjectLockDelete(*testCase.policy, args, testCase.lock, now)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+		if result.IsAllowed() != (result == LockDecisionAllowedNoLock || result == LockDecisionAllowedWithBypass) {
+			t.Fatalf("case %v: unexpected IsAllowed for %v", i+1, result)
+		}
+	}
+
+	if args.ConditionValues != nil {
+		t.Fatalf("args must not be modified, got: %v", args.ConditionValues)
+	}
+}
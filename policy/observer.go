@@ -0,0 +1,83 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"maps"
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// EvaluationObserver - receives the outcome of every Policy.IsAllowed call.
+// args is a copy of the normalized arguments, explicitDeny is set if a
+// 'Deny' statement applied and dur is the time spent evaluating the policy.
+type EvaluationObserver func(args *Args, allowed bool, explicitDeny bool, dur time.Duration)
+
+var evaluationObserver atomic.Pointer[EvaluationObserver]
+
+// SetEvaluationObserver - sets the observer notified on every
+// Policy.IsAllowed call, including calls on policies returned by
+// MergePolicies. Passing nil removes the observer. The observer is called
+// synchronously and must be safe for concurrent use.
+func SetEvaluationObserver(observer EvaluationObserver) {
+	if observer == nil {
+		evaluationObserver.Store(nil)
+		return
+	}
+	evaluationObserver.Store(&observer)
+}
+
+// clone - returns a deep copy of args.
+func (a Args) clone() *Args {
+	a.Groups = slices.Clone(a.Groups)
+	if a.ConditionValues != nil {
+		conditionValues := make(map[string][]string, len(a.ConditionValues))
+		for key, values := range a.ConditionValues {
+			conditionValues[key] = slices.Clone(values)
+		}
+		a.ConditionValues = conditionValues
+	}
+	if a.Claims != nil {
+		a.Claims = cloneClaimValue(a.Claims).(map[string]interface{})
+	}
+	a.ObjectTags = maps.Clone(a.ObjectTags)
+	a.RequestTags = maps.Clone(a.RequestTags)
+	return &a
+}
+
+func cloneClaimValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := maps.Clone(v)
+		for key, value := range m {
+			m[key] = cloneClaimValue(value)
+		}
+		return m
+	case []interface{}:
+		s := slices.Clone(v)
+		for i, value := range s {
+			s[i] = cloneClaimValue(value)
+		}
+		return s
+	case []string:
+		return slices.Clone(v)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+func TestEvaluationObserver(t *testing.T) {
+	allowPolicy, err := ParseConfig(strings.NewReader(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Action": ["s3:*"],
+            "Resource": ["arn:aws:s3:::mybucket/*"]
+        }
+    ]
+}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	denyPolicy, err := ParseConfig(strings.NewReader(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Deny",
+            "Action": ["s3:DeleteObject"],
+            "Resource": ["arn:aws:s3:::mybucket/*"]
+        }
+    ]
+}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mergedPolicy := MergePolicies(*allowPolicy, *denyPolicy)
+
+	type observation struct {
+		args         *Args
+		allowed      bool
+		explicitDeny bool
+		dur          time.Duration
+	}
+	var observed []observation
+	SetEvaluationObserver(func(args *Args, allowed bool, explicitDeny bool, dur time.Duration) {
+		observed = append(observed, observation{args, allowed, explicitDeny, dur})
+		// Mutations must not leak into the caller's args.
+		args.ConditionValues["observer"] = []string{"modified"}
+	})
+	defer SetEvaluationObserver(nil)
+
+	testCases := []struct {
+		policy               Policy
+		action               Action
+		expectedAllowed      bool
+		expectedExplicitDeny bool
+	}{
+		{*allowPolicy, GetObjectAction, true, false},
+		{*allowPolicy, DeleteObjectAction, true, false},
+		{*denyPolicy, GetObjectAction, false, false},
+		{*denyPolicy, DeleteObjectAction, false, true},
+		{mergedPolicy, GetObjectAction, true, false},
+		{mergedPolicy, DeleteObjectAction, false, true},
+		{mergedPolicy, Action(ServerInfoAdminAction), false, false},
+	}
+
+	for i, testCase := range testCases {
+		observed = nil
+		conditionValues := map[string][]string{}
+		args := Args{
+			AccountName:     "Q3AM3UQ867SPQQA43P2F",
+			Action:          testCase.action,
+			BucketName:      "mybucket",
+			ObjectName:      "myobject",
+			Region:          "us-east-1",
+			ConditionValues: conditionValues,
+		}
+		allowed := testCase.policy.IsAllowed(args)
+		if allowed != testCase.expectedAllowed {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedAllowed, allowed)
+		}
+		if len(observed) != 1 {
+			t.Fatalf("case %v: expected 1 observation, got: %v", i+1, len(observed))
+		}
+		o := observed[0]
+		if o.allowed != testCase.expectedAllowed {
+			t.Fatalf("case %v: allowed: expected: %v, got: %v", i+1, testCase.expectedAllowed, o.allowed)
+		}
+		if o.explicitDeny != testCase.expectedExplicitDeny {
+			t.Fatalf("case %v: explicitDeny: expected: %v, got: %v", i+1, testCase.expectedExplicitDeny, o.explicitDeny)
+		}
+		if o.args.Action != testCase.action || o.args.AccountName != args.AccountName {
+			t.Fatalf("case %v: unexpected args: %v", i+1, o.args)
+		}
+		if o.dur < 0 {
+			t.Fatalf("case %v: unexpected duration: %v", i+1, o.dur)
+		}
+		if region := o.args.ConditionValues[condition.AWSRequestedRegion.Name()]; !reflect.DeepEqual(region, []string{"us-east-1"}) {
+			t.Fatalf("case %v: expected normalized args, got: %v", i+1, o.args.ConditionValues)
+		}
+		if _, ok := conditionValues["observer"]; ok {
+			t.Fatalf("case %v: observer modified caller's args", i+1)
+		}
+	}
+
+	// IsAllowedActions enumerates actions and is not observed.
+	observed = nil
+	mergedPolicy.IsAllowedActions("mybucket", "myobject", nil)
+	if len(observed) != 0 {
+		t.Fatalf("expected no observations, got: %v", len(observed))
+	}
+
+	SetEvaluationObserver(nil)
+	if !allowPolicy.IsAllowed(Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}) {
+		t.Fatal("expected allowed")
+	}
+	if len(observed) != 0 {
+		t.Fatalf("expected no observations, got: %v", len(observed))
+	}
+}
+
+func TestArgsClone(t *testing.T) {
+	args := Args{
+		Groups:          []string{"admins"},
+		ConditionValues: map[string][]string{"username": {"alice"}},
+		Claims:          map[string]interface{}{"groups": []interface{}{"admins"}},
+		ObjectTags:      map[string]string{"project": "alpha"},
+		RequestTags:     map[string]string{"team": "storage"},
+	}
+
+	clone := args.clone()
+	clone.Groups[0] = "modified"
+	clone.ConditionValues["username"][0] = "modified"
+	clone.Claims["groups"].([]interface{})[0] = "modified"
+	clone.ObjectTags["project"] = "modified"
+	clone.RequestTags["team"] = "modified"
+	clone.RequestTags["new"] = "modified"
+
+	expected := Args{
+		Groups:          []string{"admins"},
+		ConditionValues: map[string][]string{"username": {"alice"}},
+		Claims:          map[string]interface{}{"groups": []interface{}{"admins"}},
+		ObjectTags:      map[string]string{"project": "alpha"},
+		RequestTags:     map[string]string{"team": "storage"},
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Fatalf("expected: %v, got: %v", expected, args)
+	}
+}
+
+func BenchmarkPolicyIsAllowed(b *testing.B) {
+	policy, err := ParseConfig(strings.NewReader(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Action": ["s3:GetObject", "s3:PutObject"],
+            "Resource": ["arn:aws:s3:::mybucket/*"]
+        },
+        {
+            "Effect": "Deny",
+            "Action": ["s3:DeleteObject"],
+            "Resource": ["arn:aws:s3:::mybucket/*"]
+        }
+    ]
+}`))
+	if err != nil {
+		b.Fatalf("unexpected error: %v", err)
+	}
+	args := Args{
+		AccountName:     "Q3AM3UQ867SPQQA43P2F",
+		Action:          GetObjectAction,
+		BucketName:      "mybucket",
+		ObjectName:      "myobject",
+		ConditionValues: map[string][]string{},
+	}
+
+	b.Run("observer-unset", func(b *testing.B) {
+		SetEvaluationObserver(nil)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			policy.IsAllowed(args)
+		}
+	})
+	b.Run("observer-set", func(b *testing.B) {
+		SetEvaluationObserver(func(*Args, bool, bool, time.Duration) {})
+		defer SetEvaluationObserver(nil)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			policy.IsAllowed(args)
+		}
+	})
+}
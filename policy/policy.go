@@ -18,11 +18,18 @@
 package policy
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/pkg/v3/policy/condition"
 )
 
 // DefaultVersion - default policy version as per AWS S3 specification.
@@ -40,6 +47,192 @@ type Args struct {
 	ObjectName      string                 `json:"object"`
 	Claims          map[string]interface{} `json:"claims"`
 	DenyOnly        bool                   `json:"denyOnly"` // only applies deny
+
+	// ObjectNameEncoded is set if ObjectName is percent-encoded, as in the
+	// x-amz-copy-source header, it is then decoded exactly once before
+	// matching resources.
+	ObjectNameEncoded bool `json:"objectNameEncoded,omitempty"`
+
+	// Region of the deployment, used for the aws:RequestedRegion and
+	// minio:deployment-region condition keys unless they are already
+	// present in ConditionValues.
+	Region string `json:"region,omitempty"`
+
+	// Identity which signed the request, used for the svc:ParentUser,
+	// sts:RoleArn, svc:IsServiceAccount and sts:IsTemporaryCredential
+	// condition keys unless they are already present in ConditionValues.
+	ParentUser            string `json:"parentUser,omitempty"`
+	RoleARN               string `json:"roleArn,omitempty"`
+	IsServiceAccount      bool   `json:"isServiceAccount,omitempty"`
+	IsTemporaryCredential bool   `json:"isTemporaryCredential,omitempty"`
+
+	// StrictConditionContext makes evaluation fail closed if a condition
+	// key used by a statement has no value in ConditionValues: an 'Allow'
+	// statement then does not apply and a 'Deny' statement applies,
+	// whatever the condition operator. Null and IfExists operators are
+	// exempt since they are defined for absent keys. Policy variables and
+	// bucket policies are not affected.
+	StrictConditionContext bool `json:"strictConditionContext,omitempty"`
+
+	// ObjectTags are the tags of the existing object, used for the
+	// s3:ExistingObjectTag/<key> condition keys. RequestTags are the tags
+	// set by the request, e.g. by PutObject, used for the
+	// s3:RequestObjectTag/<key> and s3:RequestObjectTagKeys condition keys;
+	// an empty non-nil map is a request without tags. As for the identity
+	// fields, values already present in ConditionValues take precedence.
+	//
+	// Setting these keys directly in ConditionValues is deprecated, it
+	// keeps working for existing callers.
+	ObjectTags  map[string]string `json:"objectTags,omitempty"`
+	RequestTags map[string]string `json:"requestTags,omitempty"`
+
+	// VersionID of the object version targeted by the request, used for
+	// the s3:versionid condition key unless it is already present in
+	// ConditionValues. S3 uses "null" for the version of objects written
+	// while versioning was not enabled.
+	VersionID string `json:"versionId,omitempty"`
+
+	// ConditionValueProvider, if set, is used by Policy.IsAllowedCtx to
+	// fetch condition values referenced by the policy but missing in
+	// ConditionValues.
+	ConditionValueProvider ConditionValueProvider `json:"-"`
+}
+
+// withDefaults returns conditionValues with the keys of defaults added
+// unless they are already present, conditionValues is copied instead of
+// modified.
+func withDefaults(conditionValues, defaults map[string][]string) map[string][]string {
+	var missing bool
+	for key := range defaults {
+		if _, ok := conditionValues[key]; !ok {
+			missing = true
+			break
+		}
+	}
+	if !missing {
+		return conditionValues
+	}
+
+	values := make(map[string][]string, len(conditionValues)+len(defaults))
+	for k, v := range conditionValues {
+		values[k] = v
+	}
+	for k, v := range defaults {
+		if _, ok := values[k]; !ok {
+			values[k] = v
+		}
+	}
+	return values
+}
+
+// withRegion returns conditionValues with the region condition keys
+// populated from region, conditionValues is copied instead of modified.
+func withRegion(conditionValues map[string][]string, region string) map[string][]string {
+	if region == "" {
+		return conditionValues
+	}
+
+	return withDefaults(conditionValues, map[string][]string{
+		condition.AWSRequestedRegion.Name():    {region},
+		condition.MinIODeploymentRegion.Name(): {region},
+	})
+}
+
+// withTags returns conditionValues with the object tag condition keys
+// populated from objectTags and requestTags, nil maps are ignored.
+// conditionValues is copied instead of modified.
+func withTags(conditionValues map[string][]string, objectTags, requestTags map[string]string) map[string][]string {
+	if objectTags == nil && requestTags == nil {
+		return conditionValues
+	}
+
+	tags := make(map[string][]string, len(objectTags)+len(requestTags)+1)
+	for k, v := range objectTags {
+		tags[condition.NewKey(condition.ExistingObjectTag, k).Name()] = []string{v}
+	}
+	if requestTags != nil {
+		keys := make([]string, 0, len(requestTags))
+		for k, v := range requestTags {
+			tags[condition.NewKey(condition.RequestObjectTag, k).Name()] = []string{v}
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		tags[condition.RequestObjectTagKeys.Name()] = keys
+	}
+	return withDefaults(conditionValues, tags)
+}
+
+// NormalizeConditions populates ConditionValues with the condition keys
+// derived from Region, the tags, VersionID and the identity fields of
+// Args. Values already present in ConditionValues take precedence and
+// ConditionValues is copied instead of modified, hence calling it more
+// than once is harmless.
+//
+// The boolean identity keys are only set when true, use the Null
+// condition to match requests signed with permanent credentials.
+func (a *Args) NormalizeConditions() {
+	a.ConditionValues = withRegion(a.ConditionValues, a.Region)
+	a.ConditionValues = withTags(a.ConditionValues, a.ObjectTags, a.RequestTags)
+	if a.VersionID != "" {
+		a.ConditionValues = withDefaults(a.ConditionValues, map[string][]string{
+			condition.S3VersionID.Name(): {a.VersionID},
+		})
+	}
+
+	if a.ParentUser == "" && a.RoleARN == "" && !a.IsServiceAccount && !a.IsTemporaryCredential {
+		return
+	}
+
+	identity := make(map[string][]string, 4)
+	if a.ParentUser != "" {
+		identity[condition.SVCParentUser.Name()] = []string{a.ParentUser}
+	}
+	if a.RoleARN != "" {
+		identity[condition.STSRoleArn.Name()] = []string{a.RoleARN}
+	}
+	if a.IsServiceAccount {
+		identity[condition.SVCIsServiceAccount.Name()] = []string{"true"}
+	}
+	if a.IsTemporaryCredential {
+		identity[condition.STSIsTemporaryCredential.Name()] = []string{"true"}
+	}
+	a.ConditionValues = withDefaults(a.ConditionValues, identity)
+}
+
+// Values of the s3:signatureversion condition key.
+const (
+	SignatureVersionV2 = "AWS"
+	SignatureVersionV4 = "AWS4-HMAC-SHA256"
+)
+
+// Values of the s3:authType condition key.
+const (
+	AuthTypeHeader      = "REST-HEADER"
+	AuthTypeQueryString = "REST-QUERY-STRING"
+	AuthTypePOST        = "POST"
+)
+
+// PopulateAuthConditions populates ConditionValues with the
+// s3:signatureversion, s3:authType and s3:TlsVersion condition keys of
+// the request, e.g. SignatureVersionV4, AuthTypeQueryString for presigned
+// URLs and 1.3. Empty values and a zero TLS version, for requests without
+// TLS, are not set. As for NormalizeConditions, values already present in
+// ConditionValues take precedence and ConditionValues is copied instead
+// of modified.
+func (a *Args) PopulateAuthConditions(sigVersion, authType string, tlsVersion float64) {
+	auth := make(map[string][]string, 3)
+	if sigVersion != "" {
+		auth[condition.S3SignatureVersion.Name()] = []string{sigVersion}
+	}
+	if authType != "" {
+		auth[condition.S3AuthType.Name()] = []string{authType}
+	}
+	if tlsVersion > 0 {
+		auth[condition.S3TLSVersion.Name()] = []string{strconv.FormatFloat(tlsVersion, 'f', -1, 64)}
+	}
+	if len(auth) > 0 {
+		a.ConditionValues = withDefaults(a.ConditionValues, auth)
+	}
 }
 
 // GetValuesFromClaims returns the list of values for the input claimName.
@@ -110,7 +303,8 @@ func (a Args) GetRoleArn() string {
 	return ""
 }
 
-// Policy - iam bucket iamp.
+// Policy - iam bucket iamp. The zero Policy has no statement and allows
+// no request, it is valid and encodes to JSON decoding to the zero Policy.
 type Policy struct {
 	ID         ID `json:"ID,omitempty"`
 	Version    string
@@ -127,83 +321,169 @@ func (iamp Policy) MatchResource(resource string) bool {
 	return false
 }
 
-// IsAllowedActions returns all supported actions for this policy.
-func (iamp Policy) IsAllowedActions(bucketName, objectName string, conditionValues map[string][]string) ActionSet {
-	actionSet := make(ActionSet)
-	for action := range supportedActions {
-		if iamp.IsAllowed(Args{
-			BucketName:      bucketName,
-			ObjectName:      objectName,
-			Action:          action,
-			ConditionValues: conditionValues,
-		}) {
-			actionSet.Add(action)
+// isPrunableBucket - checks whether bucket can be matched literally against
+// resource patterns.
+func isPrunableBucket(bucket string) bool {
+	return bucket != "" && !strings.ContainsAny(bucket, "*?/$")
+}
+
+// ReferencesBucket - checks whether any statement of this policy has a
+// resource referring exactly to bucket, see PruneBucket.
+func (iamp Policy) ReferencesBucket(bucket string) bool {
+	if !isPrunableBucket(bucket) {
+		return false
+	}
+	for _, statement := range iamp.Statements {
+		if statement.Resources.referencesBucket(bucket) {
+			return true
 		}
 	}
-	for action := range supportedAdminActions {
-		admAction := Action(action)
-		if iamp.IsAllowed(Args{
-			BucketName:      bucketName,
-			ObjectName:      objectName,
-			Action:          admAction,
-			ConditionValues: conditionValues,
-			// checks mainly for actions that can have explicit
-			// deny, while without it are implicitly enabled.
-			DenyOnly: action == CreateServiceAccountAdminAction || action == CreateUserAdminAction,
-		}) {
-			actionSet.Add(admAction)
+	return false
+}
+
+// PruneBucket - removes the resources referring exactly to bucket from all
+// statements, typically after the bucket has been deleted. Only patterns
+// whose bucket part equals bucket, i.e. "bucket", "bucket/*" or
+// "bucket/prefix/*", are removed, patterns like "bucket*" or "*" which also
+// cover other buckets are kept. Statements left without resources are
+// dropped unless they are admin, STS or KMS statements for which resources
+// are optional. Returns the number of dropped statements and whether the
+// policy was modified.
+func (iamp *Policy) PruneBucket(bucket string) (removedStatements int, modified bool) {
+	if !isPrunableBucket(bucket) {
+		return 0, false
+	}
+
+	statements := make([]Statement, 0, len(iamp.Statements))
+	for _, statement := range iamp.Statements {
+		if !statement.Resources.referencesBucket(bucket) {
+			statements = append(statements, statement)
+			continue
+		}
+
+		modified = true
+		statement.Resources = statement.Resources.withoutBucket(bucket)
+		if len(statement.Resources) == 0 && !statement.isAdmin() && !statement.isSTS() && !statement.isKMS() {
+			removedStatements++
+			continue
 		}
+		statements = append(statements, statement)
+	}
+
+	if modified {
+		iamp.Statements = statements
+	}
+	return removedStatements, modified
+}
+
+// IsAllowedActions returns all supported actions for this policy.
+func (iamp Policy) IsAllowedActions(bucketName, objectName string, conditionValues map[string][]string) ActionSet {
+	args := Args{
+		BucketName:      bucketName,
+		ObjectName:      objectName,
+		ConditionValues: conditionValues,
 	}
-	for action := range supportedKMSActions {
-		kmsAction := Action(action)
-		if iamp.IsAllowed(Args{
-			BucketName:      bucketName,
-			ObjectName:      objectName,
-			Action:          kmsAction,
-			ConditionValues: conditionValues,
-		}) {
-			actionSet.Add(kmsAction)
+	args.NormalizeConditions()
+	resource := requestResource(args)
+
+	actionSet := make(ActionSet)
+	for action := range iamp.candidateActions() {
+		args.Action = action
+		// checks mainly for actions that can have explicit
+		// deny, while without it are implicitly enabled.
+		args.DenyOnly = isDenyOnlyAdminAction(action)
+		if iamp.evaluate(args, resource) == VerdictAllow {
+			actionSet.Add(action)
 		}
 	}
 
 	return actionSet
 }
 
-// IsAllowed - checks given policy args is allowed to continue the Rest API.
-func (iamp Policy) IsAllowed(args Args) bool {
-	// Check all deny statements. If any one statement denies, return false.
+// isDenyOnlyAdminAction - returns whether action is allowed by
+// IsAllowedActions unless it is denied.
+func isDenyOnlyAdminAction(action Action) bool {
+	return action == CreateServiceAccountAdminAction || action == CreateUserAdminAction
+}
+
+// candidateActions - returns the supported S3, admin and KMS actions which
+// IsAllowedActions may allow, i.e. the actions an 'Allow' statement applies
+// to and the deny-only admin actions. Only statements with wildcards or
+// NotAction are expanded against all supported actions.
+func (iamp Policy) candidateActions() ActionSet {
+	freezeActions()
+
+	candidates := NewActionSet(CreateServiceAccountAdminAction, CreateUserAdminAction)
 	for _, statement := range iamp.Statements {
-		if statement.Effect == Deny {
-			if !statement.IsAllowed(args) {
-				return false
+		if statement.Effect != Allow {
+			continue
+		}
+		if statement.NotActions.IsEmpty() && !statement.Actions.hasWildcard() {
+			for action := range statement.Actions {
+				if isSupportedAction(action) {
+					candidates.Add(action)
+				}
+				// GetObjectVersion implies GetObject, see ActionSet.Match.
+				if action == GetObjectVersionAction {
+					candidates.Add(GetObjectAction)
+				}
+			}
+			continue
+		}
+
+		for action := range supportedActions {
+			if statement.matchAction(action) {
+				candidates.Add(action)
+			}
+		}
+		for action := range supportedAdminActions {
+			if statement.matchAction(Action(action)) {
+				candidates.Add(Action(action))
+			}
+		}
+		for action := range supportedKMSActions {
+			if statement.matchAction(Action(action)) {
+				candidates.Add(Action(action))
 			}
 		}
 	}
+	return candidates
+}
 
-	// Applied any 'Deny' only policies, if we have
-	// reached here it means that there were no 'Deny'
-	// policies - this function mainly used for
-	// specific scenarios where we only want to validate
-	// 'Deny' only policies.
-	if args.DenyOnly {
+// isSupportedAction - returns whether action is a supported S3, admin or
+// KMS action.
+func isSupportedAction(action Action) bool {
+	if _, ok := supportedActions[action]; ok {
 		return true
 	}
-
-	// For owner, its allowed by default.
-	if args.IsOwner {
+	if _, ok := supportedAdminActions[AdminAction(action)]; ok {
 		return true
 	}
+	_, ok := supportedKMSActions[KMSAction(action)]
+	return ok
+}
 
-	// Check all allow statements. If any one statement allows, return true.
-	for _, statement := range iamp.Statements {
-		if statement.Effect == Allow {
-			if statement.IsAllowed(args) {
-				return true
-			}
-		}
+// IsAllowed - checks given policy args is allowed to continue the Rest API.
+func (iamp Policy) IsAllowed(args Args) bool {
+	observer := evaluationObserver.Load()
+	if observer == nil {
+		return iamp.isAllowed(args) == VerdictAllow
 	}
 
-	return false
+	start := time.Now()
+	// Observers receive normalized arguments.
+	args.NormalizeConditions()
+	verdict := iamp.evaluate(args, requestResource(args))
+	(*observer)(args.clone(), verdict == VerdictAllow, verdict == VerdictDeny, time.Since(start))
+	return verdict == VerdictAllow
+}
+
+// isAllowed - returns the verdict of this policy for args without
+// notifying the evaluation observer.
+func (iamp Policy) isAllowed(args Args) Verdict {
+	args.NormalizeConditions()
+
+	return iamp.evaluate(args, requestResource(args))
 }
 
 // IsEmpty - returns whether policy is empty or not.
@@ -213,14 +493,98 @@ func (iamp Policy) IsEmpty() bool {
 
 // isValid - checks if Policy is valid or not.
 func (iamp Policy) isValid() error {
-	if iamp.Version != DefaultVersion && iamp.Version != "" {
-		return Errorf("invalid version '%v'", iamp.Version)
+	return iamp.ValidateWithOptions(ValidationOptions{})
+}
+
+// ActionValidation - controls how statement actions are validated.
+type ActionValidation int
+
+// Action validation modes.
+const (
+	// ActionValidationDefault - action must match a supported action,
+	// wildcards are accepted if they match any supported action.
+	ActionValidationDefault ActionValidation = iota
+
+	// ActionValidationStrict - action must be a supported action or a
+	// prefix wildcard such as "s3:Get*" matching a supported action.
+	ActionValidationStrict
+
+	// ActionValidationPermissive - any "service:Name" shaped action is
+	// accepted for forward compatibility, unknown actions are matched
+	// literally.
+	ActionValidationPermissive
+)
+
+// ValidationOptions - options for policy validation.
+type ValidationOptions struct {
+	ActionValidation ActionValidation
+
+	// RejectUnsupportedResources - reject access point resources instead
+	// of ignoring them, see ResourceARNAccessPoint, and object actions,
+	// e.g. "s3:GetObject", granted on bucket resources only, as bucket
+	// policies do. A policy whose statements all use access points only
+	// is always rejected.
+	RejectUnsupportedResources bool
+
+	// AllowMixedActions - accept statements mixing actions of several
+	// services, e.g. "s3:GetObject" and "admin:ServerInfo", as previous
+	// releases did. Lint warns about such statements, resources are
+	// matched as per the requested action.
+	AllowMixedActions bool
+
+	// RejectInvalidBucketNames - reject S3 resources whose bucket segment
+	// cannot match any bucket as per the S3 bucket naming rules, e.g.
+	// "My_Bucket/*", instead of only warning about them in Lint. Wildcards
+	// may stand for any valid part of a name and the bucket segment of
+	// resources with policy variables is not checked.
+	RejectInvalidBucketNames bool
+
+	// RejectConditionKeyTypes - reject numeric and date condition
+	// operators with keys of another type, e.g. NumericLessThan of
+	// "aws:Referer", as AWS does, instead of only warning about them in
+	// Lint, see condition.CheckOperatorKey. Bool and IpAddress operators
+	// with keys of another type are always rejected.
+	RejectConditionKeyTypes bool
+
+	// AllowLegacyDocuments - accept LegacyVersion and, when parsing with
+	// ParseConfigWithOptions, a single statement object in place of the
+	// array of statements and effects in any case, such as "allow", as AWS
+	// does. The statements are encoded as an array, effects as "Allow" or
+	// "Deny", and the version is kept. Validate rejects policies of
+	// LegacyVersion.
+	AllowLegacyDocuments bool
+
+	// RejectEmptyStatements - reject policies without statements with
+	// ErrEmptyStatements, as AWS does, instead of accepting them as
+	// policies which allow nothing, see IsEmpty.
+	RejectEmptyStatements bool
+}
+
+// ValidateWithOptions - validates all statements as per opts. Unlike
+// bucket policies, statements may share a SID, as AWS allows it for IAM
+// policies.
+func (iamp Policy) ValidateWithOptions(opts ValidationOptions) error {
+	if iamp.Version != DefaultVersion && iamp.Version != "" && (iamp.Version != LegacyVersion || !opts.AllowLegacyDocuments) {
+		return Errorf("%w '%v'", ErrInvalidVersion, iamp.Version)
+	}
+	if opts.RejectEmptyStatements && len(iamp.Statements) == 0 {
+		return Errorf("%w", ErrEmptyStatements)
 	}
 
+	inert := 0
 	for _, statement := range iamp.Statements {
-		if err := statement.isValid(); err != nil {
+		if err := statement.isValidWithOptions(opts); err != nil {
 			return err
 		}
+		if statement.isInert() {
+			inert++
+		}
+	}
+	if inert > 0 && inert == len(iamp.Statements) {
+		return Errorf("%w", ErrMalformedResource{
+			Resource: iamp.Statements[0].Resources.accessPoints()[0].String(),
+			Reason:   "no statement applies, access points are not supported",
+		})
 	}
 	return nil
 }
@@ -241,7 +605,103 @@ func MergePolicies(inputs ...Policy) Policy {
 	return merged
 }
 
-func (iamp *Policy) dropDuplicateStatements() {
+// StatementSource - a statement of a named policy, see Provenance.
+type StatementSource struct {
+	Policy    string // Name of the policy
+	Statement int    // Index of the statement in the policy
+}
+
+func (s StatementSource) String() string {
+	return fmt.Sprintf("statement %d of policy '%s'", s.Statement, s.Policy)
+}
+
+// Provenance - maps each statement of a policy merged by MergePoliciesNamed,
+// by index, to the statements it was merged from: more than one if equal
+// statements of several policies were collapsed into one. Sources are
+// sorted by policy name and statement index.
+type Provenance [][]StatementSource
+
+// Sources - returns the statements statement was merged from, nil if it
+// is out of range, e.g. -1 for a Reason without statement.
+func (p Provenance) Sources(statement int) []StatementSource {
+	if statement < 0 || statement >= len(p) {
+		return nil
+	}
+	return p[statement]
+}
+
+// Explain - describes reason, as returned by EvaluateWithReason for the
+// merged policy, in terms of the source policies, e.g. "Deny by statement
+// 2 of policy 'deny-prod-writes'".
+func (p Provenance) Explain(reason Reason) string {
+	sources := p.Sources(reason.Statement)
+	if len(sources) == 0 {
+		return reason.String()
+	}
+	names := make([]string, len(sources))
+	for i, source := range sources {
+		names[i] = source.String()
+	}
+	s := fmt.Sprintf("%v by %s", reason.Verdict, strings.Join(names, ", "))
+	if reason.Description != "" {
+		s += fmt.Sprintf(" (%q)", reason.Description)
+	}
+	if reason.MissingKey != "" {
+		s += ": missing context key " + reason.MissingKey
+	}
+	return s
+}
+
+// MergePoliciesNamed - merges the named policies as MergePolicies does,
+// along with the provenance of each statement of the merged policy. The
+// policies are merged in the order of their names, so that the result does
+// not depend on the iteration order of inputs.
+func MergePoliciesNamed(inputs map[string]Policy) (Policy, Provenance) {
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var merged Policy
+	var sources []StatementSource
+	for _, name := range names {
+		p := inputs[name]
+		if merged.Version == "" {
+			merged.Version = p.Version
+		}
+		for i, st := range p.Statements {
+			merged.Statements = append(merged.Statements, st.Clone())
+			sources = append(sources, StatementSource{Policy: name, Statement: i})
+		}
+	}
+
+	kept := merged.dropDuplicateStatements()
+	var provenance Provenance
+	if len(merged.Statements) > 0 {
+		provenance = make(Provenance, len(merged.Statements))
+	}
+	for i, source := range sources {
+		provenance[kept[i]] = append(provenance[kept[i]], source)
+	}
+	return merged, provenance
+}
+
+// SortStatements - sorts the statements of the policy by less, statements
+// which are equal as per less keep their order. Statements are otherwise
+// kept in the order they were written, evaluation does not depend on it.
+func (iamp *Policy) SortStatements(less func(a, b Statement) bool) {
+	sort.SliceStable(iamp.Statements, func(i, j int) bool {
+		return less(iamp.Statements[i], iamp.Statements[j])
+	})
+}
+
+// dropDuplicateStatements - removes statements equal to an earlier one,
+// including their SID. The first occurrence is kept and the order of the
+// remaining statements is preserved. It returns the index, after removal,
+// of the statement each statement was kept as.
+func (iamp *Policy) dropDuplicateStatements() []int {
+	kept := make([]int, len(iamp.Statements))
 	dups := make(map[int]struct{})
 	for i := range iamp.Statements {
 		if _, ok := dups[i]; ok {
@@ -249,30 +709,47 @@ func (iamp *Policy) dropDuplicateStatements() {
 			// compare with it.
 			continue
 		}
+		kept[i] = i
 		for j := i + 1; j < len(iamp.Statements); j++ {
-			if !iamp.Statements[i].Equals(iamp.Statements[j]) {
+			if iamp.Statements[i].SID != iamp.Statements[j].SID ||
+				!iamp.Statements[i].Equals(iamp.Statements[j]) {
 				continue
 			}
 
 			// save duplicate statement index for removal.
 			dups[j] = struct{}{}
+			kept[j] = i
 		}
 	}
 
 	// remove duplicate items from the slice.
 	var c int
+	index := make([]int, len(iamp.Statements))
 	for i := range iamp.Statements {
 		if _, ok := dups[i]; ok {
 			continue
 		}
+		index[i] = c
 		iamp.Statements[c] = iamp.Statements[i]
 		c++
 	}
 	iamp.Statements = iamp.Statements[:c]
+
+	for i := range kept {
+		kept[i] = index[kept[i]]
+	}
+	return kept
 }
 
-// UnmarshalJSON - decodes JSON data to Iamp.
+// UnmarshalJSON - decodes JSON data to Iamp. Documents nested deeper than
+// MaxDepth are rejected before they are decoded, which bounds the
+// recursion of the decoder. encoding/json itself rejects documents nested
+// deeper than 10000 levels before UnmarshalJSON is called.
 func (iamp *Policy) UnmarshalJSON(data []byte) error {
+	if err := checkDepth(data); err != nil {
+		return err
+	}
+
 	// subtype to avoid recursive call to UnmarshalJSON()
 	type subPolicy Policy
 	var sp subPolicy
@@ -291,7 +768,8 @@ func (iamp Policy) Validate() error {
 	return iamp.isValid()
 }
 
-// ParseConfig - parses data in given reader to Iamp.
+// ParseConfig - parses data in given reader to Iamp. Documents nested
+// deeper than MaxDepth are rejected, see Policy.UnmarshalJSON.
 func ParseConfig(reader io.Reader) (*Policy, error) {
 	var iamp Policy
 
@@ -304,6 +782,116 @@ func ParseConfig(reader io.Reader) (*Policy, error) {
 	return &iamp, iamp.Validate()
 }
 
+// ParseConfigWithOptions - parses data in given reader to Iamp, validating
+// it as per opts. Documents nested deeper than MaxDepth are rejected, see
+// Policy.UnmarshalJSON.
+func ParseConfigWithOptions(reader io.Reader, opts ValidationOptions) (*Policy, error) {
+	var iamp Policy
+
+	var data []byte
+	if opts.AllowLegacyDocuments {
+		var err error
+		if data, err = io.ReadAll(reader); err != nil {
+			return nil, Errorf("%w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	decode := func(reader io.Reader) error {
+		decoder := json.NewDecoder(reader)
+		decoder.DisallowUnknownFields()
+		return decoder.Decode(&iamp)
+	}
+	err := decode(reader)
+	if err != nil && opts.AllowLegacyDocuments && isStatementObjectError(err) {
+		// Only documents with a single statement object are rewritten.
+		if data, err = legacyStatements(data); err != nil {
+			return nil, Errorf("%w", err)
+		}
+		iamp = Policy{}
+		err = decode(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, Errorf("%w", err)
+	}
+
+	if opts.AllowLegacyDocuments {
+		for i := range iamp.Statements {
+			iamp.Statements[i].Effect = normalizeEffect(iamp.Statements[i].Effect)
+		}
+		// Statements may only differ by the case of their effect.
+		iamp.dropDuplicateStatements()
+	}
+	return &iamp, iamp.ValidateWithOptions(opts)
+}
+
+// ParseConfigStrict - parses data in given reader to Iamp, rejecting any
+// action which is not a supported action or a prefix wildcard of one, and
+// any resource whose bucket name can never match a bucket and any
+// condition operator with a key of another type.
+func ParseConfigStrict(reader io.Reader) (*Policy, error) {
+	return ParseConfigWithOptions(reader, ValidationOptions{
+		ActionValidation:         ActionValidationStrict,
+		RejectInvalidBucketNames: true,
+		RejectConditionKeyTypes:  true,
+	})
+}
+
+// ReadPolicy - reads a policy written by WriteJSON, it is equivalent to
+// ParseConfig.
+func ReadPolicy(reader io.Reader) (*Policy, error) {
+	return ParseConfig(reader)
+}
+
+// WriteJSON - writes the JSON encoding of this policy to w, encoding one
+// statement at a time instead of building the whole document in memory.
+// The output is identical to json.Marshal of the policy.
+func (iamp Policy) WriteJSON(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	bw.WriteByte('{')
+	if iamp.ID != "" {
+		bw.WriteString(`"ID":`)
+		if err := writeJSONValue(bw, iamp.ID); err != nil {
+			return err
+		}
+		bw.WriteByte(',')
+	}
+	bw.WriteString(`"Version":`)
+	if err := writeJSONValue(bw, iamp.Version); err != nil {
+		return err
+	}
+	bw.WriteString(`,"Statement":`)
+	if iamp.Statements == nil {
+		bw.WriteString("null")
+	} else {
+		bw.WriteByte('[')
+		for i, statement := range iamp.Statements {
+			if i > 0 {
+				bw.WriteByte(',')
+			}
+			if err := writeJSONValue(bw, statement); err != nil {
+				return err
+			}
+		}
+		bw.WriteByte(']')
+	}
+	bw.WriteByte('}')
+
+	return bw.Flush()
+}
+
+// writeJSONValue - writes the JSON encoding of v to w, without the newline
+// added by json.Encoder.
+func writeJSONValue(w *bufio.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
 // Equals returns true if the two policies are identical
 func (iamp *Policy) Equals(p Policy) bool {
 	if iamp.ID != p.ID || iamp.Version != p.Version {
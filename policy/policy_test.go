@@ -20,7 +20,14 @@ package policy
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net"
+	"net/url"
+	"reflect"
+	"runtime"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -123,6 +130,120 @@ func TestPolicyIsAllowedActions(t *testing.T) {
 	}
 }
 
+// isAllowedActionsReference - evaluates all supported actions, as
+// IsAllowedActions did before only evaluating candidate actions.
+func isAllowedActionsReference(iamp Policy, bucketName, objectName string, conditionValues map[string][]string) ActionSet {
+	actionSet := make(ActionSet)
+	for action := range supportedActions {
+		if iamp.isAllowed(Args{
+			BucketName:      bucketName,
+			ObjectName:      objectName,
+			Action:          action,
+			ConditionValues: conditionValues,
+		}) == VerdictAllow {
+			actionSet.Add(action)
+		}
+	}
+	for action := range supportedAdminActions {
+		if iamp.isAllowed(Args{
+			BucketName:      bucketName,
+			ObjectName:      objectName,
+			Action:          Action(action),
+			ConditionValues: conditionValues,
+			DenyOnly:        isDenyOnlyAdminAction(Action(action)),
+		}) == VerdictAllow {
+			actionSet.Add(Action(action))
+		}
+	}
+	for action := range supportedKMSActions {
+		if iamp.isAllowed(Args{
+			BucketName:      bucketName,
+			ObjectName:      objectName,
+			Action:          Action(action),
+			ConditionValues: conditionValues,
+		}) == VerdictAllow {
+			actionSet.Add(Action(action))
+		}
+	}
+	return actionSet
+}
+
+func TestPolicyIsAllowedActionsDifferential(t *testing.T) {
+	policies := []string{
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:CreateBucket", "Resource": "arn:aws:s3:::*", "Condition": {"StringLike": {"s3:LocationConstraint": "us-east-1"}}}, {"Effect": "Deny", "Action": "s3:CreateBucket", "Resource": "arn:aws:s3:::*", "Condition": {"StringNotLike": {"s3:LocationConstraint": "us-east-1"}}}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObjectVersion", "s3:ListBucket"], "Resource": ["arn:aws:s3:::mybucket", "arn:aws:s3:::mybucket/*"]}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:*", "Resource": "arn:aws:s3:::${aws:username}/*"}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "NotAction": ["s3:Delete*", "admin:*"], "Resource": "arn:aws:s3:::*"}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["admin:*Tier*", "admin:ServerInfo"]}, {"Effect": "Deny", "Action": ["admin:CreateUser"]}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["kms:*"], "Resource": ["arn:minio:kms:::my-key*"]}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Deny", "Action": ["s3:*"], "Resource": ["arn:aws:s3:::*"]}]}`,
+	}
+	var fixtures []Policy
+	for i, data := range policies {
+		p, err := ParseConfig(strings.NewReader(data))
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		fixtures = append(fixtures, *p)
+	}
+	for _, p := range DefaultPolicies {
+		fixtures = append(fixtures, p.Definition)
+	}
+	// Invalid, but evaluated as well.
+	fixtures = append(fixtures, Policy{}, Policy{
+		Version: DefaultVersion,
+		Statements: []Statement{
+			NewStatement("", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket")), condition.NewFunctions()),
+			NewStatement("", Allow, NewActionSet(AssumeRoleWithWebIdentityAction, PutObjectAction), NewResourceSet(NewResource("mybucket/a/*")), condition.NewFunctions()),
+		},
+	})
+
+	requests := []struct {
+		bucketName, objectName string
+		conditionValues        map[string][]string
+	}{
+		{"", "", nil},
+		{"mybucket", "", nil},
+		{"mybucket", "a/b.txt", nil},
+		{"otherbucket", "b.txt", nil},
+		{"testbucket", "", map[string][]string{"LocationConstraint": {"us-east-1"}}},
+		{"testbucket", "", map[string][]string{"LocationConstraint": {"us-east-2"}}},
+		{"alice", "photo.jpg", map[string][]string{"username": {"alice"}}},
+	}
+
+	for i, p := range fixtures {
+		for j, r := range requests {
+			expected := isAllowedActionsReference(p, r.bucketName, r.objectName, r.conditionValues)
+			if result := p.IsAllowedActions(r.bucketName, r.objectName, r.conditionValues); !result.Equals(expected) {
+				t.Fatalf("case %v, request %v: expected: %v, got: %v", i+1, j+1, expected, result)
+			}
+		}
+	}
+}
+
+func BenchmarkPolicyIsAllowedActions(b *testing.B) {
+	policy := Policy{
+		Version: DefaultVersion,
+		Statements: []Statement{
+			NewStatement("", Allow, NewActionSet(GetObjectAction, ListBucketAction), NewResourceSet(NewResource("mybucket"), NewResource("mybucket/*")), condition.NewFunctions()),
+			NewStatement("", Deny, NewActionSet(DeleteObjectAction), NewResourceSet(NewResource("mybucket/*")), condition.NewFunctions()),
+		},
+	}
+
+	b.Run("candidates", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			policy.IsAllowedActions("mybucket", "", nil)
+		}
+	})
+	b.Run("all-actions", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			isAllowedActionsReference(policy, "mybucket", "", nil)
+		}
+	})
+}
+
 func TestPolicyIsAllowed(t *testing.T) {
 	case1Policy := Policy{
 		Version: DefaultVersion,
@@ -283,6 +404,497 @@ func TestPolicyIsAllowed(t *testing.T) {
 	}
 }
 
+func TestPolicyIsAllowedRequestedRegion(t *testing.T) {
+	for _, key := range []condition.KeyName{condition.AWSRequestedRegion, condition.MinIODeploymentRegion} {
+		data := []byte(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Action": ["s3:GetObject", "s3:PutObject"],
+            "Resource": ["arn:aws:s3:::mybucket/*"]
+        },
+        {
+            "Effect": "Deny",
+            "Action": ["s3:PutObject"],
+            "Resource": ["arn:aws:s3:::mybucket/*"],
+            "Condition": {"StringNotEquals": {"` + string(key) + `": "us-east-1"}}
+        }
+    ]
+}`)
+		policy, err := ParseConfig(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", key, err)
+		}
+
+		testCases := []struct {
+			args           Args
+			expectedResult bool
+		}{
+			{Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject", Region: "us-east-1"}, true},
+			{Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject", Region: "eu-west-1"}, false},
+			{Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, false},
+			{Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, true},
+			{Args{
+				Action:          PutObjectAction,
+				BucketName:      "mybucket",
+				ObjectName:      "myobject",
+				ConditionValues: map[string][]string{key.Name(): {"us-east-1"}},
+			}, true},
+			// Explicit condition values take precedence over Region.
+			{Args{
+				Action:          PutObjectAction,
+				BucketName:      "mybucket",
+				ObjectName:      "myobject",
+				ConditionValues: map[string][]string{key.Name(): {"eu-west-1"}},
+				Region:          "us-east-1",
+			}, false},
+		}
+
+		for i, testCase := range testCases {
+			conditionValues := testCase.args.ConditionValues
+			result := policy.IsAllowed(testCase.args)
+
+			if result != testCase.expectedResult {
+				t.Fatalf("%v: case %v: expected: %v, got: %v", key, i+1, testCase.expectedResult, result)
+			}
+			if len(conditionValues) != len(testCase.args.ConditionValues) {
+				t.Fatalf("%v: case %v: condition values must not be modified", key, i+1)
+			}
+		}
+	}
+
+	// Region keys are common keys, accepted by all actions.
+	for _, action := range []Action{PutObjectAction, ListBucketAction, AbortMultipartUploadAction} {
+		if !IAMActionConditionKeyMap.Lookup(action).Match(condition.AWSRequestedRegion.ToKey()) {
+			t.Fatalf("%v: expected %v to be supported", action, condition.AWSRequestedRegion)
+		}
+	}
+	if !adminActionConditionKeyMap[ServerInfoAdminAction].Match(condition.MinIODeploymentRegion.ToKey()) {
+		t.Fatalf("expected %v to be supported for admin actions", condition.MinIODeploymentRegion)
+	}
+}
+
+func TestPolicyIsAllowedIdentity(t *testing.T) {
+	data := []byte(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Action": ["s3:GetObject"],
+            "Resource": ["arn:aws:s3:::mybucket/*"],
+            "Condition": {"StringEquals": {"svc:ParentUser": "alice"}, "Bool": {"svc:IsServiceAccount": "true"}}
+        },
+        {
+            "Effect": "Allow",
+            "Action": ["s3:PutObject"],
+            "Resource": ["arn:aws:s3:::mybucket/*"],
+            "Condition": {"Null": {"sts:IsTemporaryCredential": "true"}}
+        },
+        {
+            "Effect": "Allow",
+            "Action": ["s3:ListBucket"],
+            "Resource": ["arn:aws:s3:::mybucket"],
+            "Condition": {"StringLike": {"sts:RoleArn": "arn:minio:iam:::role/readers-*"}}
+        },
+        {
+            "Effect": "Deny",
+            "Action": ["admin:ServerInfo"],
+            "Condition": {"Bool": {"sts:IsTemporaryCredential": "true"}}
+        },
+        {
+            "Effect": "Allow",
+            "Action": ["admin:ServerInfo"]
+        }
+    ]
+}`)
+	policy, err := ParseConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		args           Args
+		expectedResult bool
+	}{
+		// Only service accounts of alice may read, not alice herself.
+		{Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject", ParentUser: "alice", IsServiceAccount: true}, true},
+		{Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject", AccountName: "alice"}, false},
+		{Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject", ParentUser: "bob", IsServiceAccount: true}, false},
+		{Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject", ParentUser: "alice", IsTemporaryCredential: true}, false},
+		// Only permanent credentials may write.
+		{Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, true},
+		{Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject", IsServiceAccount: true}, true},
+		{Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject", IsTemporaryCredential: true}, false},
+		// Only sessions of reader roles may list.
+		{Args{Action: ListBucketAction, BucketName: "mybucket", RoleARN: "arn:minio:iam:::role/readers-1", IsTemporaryCredential: true}, true},
+		{Args{Action: ListBucketAction, BucketName: "mybucket", RoleARN: "arn:minio:iam:::role/writers-1", IsTemporaryCredential: true}, false},
+		{Args{Action: ListBucketAction, BucketName: "mybucket"}, false},
+		// Temporary credentials are denied admin access.
+		{Args{Action: Action(ServerInfoAdminAction)}, true},
+		{Args{Action: Action(ServerInfoAdminAction), IsTemporaryCredential: true}, false},
+	}
+
+	for i, testCase := range testCases {
+		result := policy.IsAllowed(testCase.args)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestPolicyIsAllowedObjectNameEncoding(t *testing.T) {
+	data := []byte(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Action": ["s3:GetObject"],
+            "Resource": ["arn:aws:s3:::mybucket/caf\u00e9/*", "arn:aws:s3:::mybucket/a+b/*", "arn:aws:s3:::mybucket/100%/*"]
+        }
+    ]
+}`)
+	policy, err := ParseConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		objectName     string
+		encoded        bool
+		expectedResult bool
+	}{
+		// Raw names are compared byte by byte.
+		{"caf\u00e9/photo.jpg", false, true},
+		{"cafe\u0301/photo.jpg", false, false}, // NFD form is a different key.
+		{"caf%C3%A9/photo.jpg", false, false},
+		{"a+b/photo.jpg", false, true},
+		{"100%/photo.jpg", false, true},
+		// Encoded names are decoded exactly once.
+		{"caf%C3%A9/photo.jpg", true, true},
+		{"cafe%CC%81/photo.jpg", true, false},
+		{"caf%C3%A9%2Fphoto.jpg", true, true},
+		{"caf%25C3%25A9/photo.jpg", true, false},
+		{"a+b/photo.jpg", true, true},
+		{"a%2Bb/photo.jpg", true, true},
+		{"a%20b/photo.jpg", true, false},
+		{"100%25/photo.jpg", true, true},
+		// Invalid encoding is matched as is.
+		{"100%/photo.jpg", true, true},
+	}
+
+	for i, testCase := range testCases {
+		result := policy.IsAllowed(Args{
+			Action:            GetObjectAction,
+			BucketName:        "mybucket",
+			ObjectName:        testCase.objectName,
+			ObjectNameEncoded: testCase.encoded,
+		})
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestArgsNormalizeConditions(t *testing.T) {
+	conditionValues := map[string][]string{"ParentUser": {"carol"}}
+	args := Args{
+		ConditionValues:       conditionValues,
+		Region:                "us-east-1",
+		ParentUser:            "alice",
+		RoleARN:               "arn:minio:iam:::role/readers",
+		IsTemporaryCredential: true,
+	}
+
+	args.NormalizeConditions()
+	expectedResult := map[string][]string{
+		"ParentUser":            {"carol"},
+		"RoleArn":               {"arn:minio:iam:::role/readers"},
+		"IsTemporaryCredential": {"true"},
+		"RequestedRegion":       {"us-east-1"},
+		"deployment-region":     {"us-east-1"},
+	}
+	if !reflect.DeepEqual(args.ConditionValues, expectedResult) {
+		t.Fatalf("expected: %v, got: %v", expectedResult, args.ConditionValues)
+	}
+	if len(conditionValues) != 1 {
+		t.Fatalf("condition values must not be modified, got: %v", conditionValues)
+	}
+
+	args.NormalizeConditions()
+	if !reflect.DeepEqual(args.ConditionValues, expectedResult) {
+		t.Fatalf("expected: %v, got: %v", expectedResult, args.ConditionValues)
+	}
+
+	args = Args{}
+	args.NormalizeConditions()
+	if args.ConditionValues != nil {
+		t.Fatalf("expected: nil, got: %v", args.ConditionValues)
+	}
+}
+
+func TestArgsPopulateAuthConditions(t *testing.T) {
+	args := Args{ConditionValues: map[string][]string{"authType": {AuthTypePOST}}}
+	args.PopulateAuthConditions(SignatureVersionV4, AuthTypeHeader, 1.3)
+	expectedResult := map[string][]string{
+		"signatureversion": {SignatureVersionV4},
+		"authType":         {AuthTypePOST},
+		"TlsVersion":       {"1.3"},
+	}
+	if !reflect.DeepEqual(args.ConditionValues, expectedResult) {
+		t.Fatalf("expected: %v, got: %v", expectedResult, args.ConditionValues)
+	}
+
+	args = Args{}
+	args.PopulateAuthConditions("", "", 0)
+	if args.ConditionValues != nil {
+		t.Fatalf("expected: nil, got: %v", args.ConditionValues)
+	}
+}
+
+func TestArgsTags(t *testing.T) {
+	p := mustParsePolicy(t, `{"Version": "2012-10-17", "Statement": [
+		{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*",
+		 "Condition": {"StringEquals": {"s3:ExistingObjectTag/team": "eng"}}},
+		{"Effect": "Allow", "Action": "s3:PutObject", "Resource": "arn:aws:s3:::mybucket/*",
+		 "Condition": {"StringEquals": {"s3:RequestObjectTag/team": "eng"},
+		               "ForAllValues:StringEquals": {"s3:RequestObjectTagKeys": ["team", "project"]}}}
+	]}`)
+
+	testCases := []struct {
+		args     Args
+		legacy   map[string][]string
+		expected bool
+	}{
+		{Args{Action: GetObjectAction, ObjectTags: map[string]string{"team": "eng", "project": "x"}}, map[string][]string{"ExistingObjectTag/team": {"eng"}, "ExistingObjectTag/project": {"x"}}, true},
+		{Args{Action: GetObjectAction, ObjectTags: map[string]string{"team": "ops"}}, map[string][]string{"ExistingObjectTag/team": {"ops"}}, false},
+		{Args{Action: GetObjectAction, ObjectTags: map[string]string{}}, nil, false},
+		{Args{Action: PutObjectAction, RequestTags: map[string]string{"team": "eng"}}, map[string][]string{"RequestObjectTag/team": {"eng"}, "RequestObjectTagKeys": {"team"}}, true},
+		{Args{Action: PutObjectAction, RequestTags: map[string]string{"team": "eng", "cost": "1"}}, map[string][]string{"RequestObjectTag/team": {"eng"}, "RequestObjectTag/cost": {"1"}, "RequestObjectTagKeys": {"cost", "team"}}, false},
+		{Args{Action: PutObjectAction, RequestTags: map[string]string{}}, map[string][]string{"RequestObjectTagKeys": {}}, false},
+	}
+
+	for i, testCase := range testCases {
+		args := testCase.args
+		args.BucketName, args.ObjectName = "mybucket", "a.txt"
+		legacy := Args{Action: args.Action, BucketName: "mybucket", ObjectName: "a.txt", ConditionValues: testCase.legacy}
+
+		if result := p.IsAllowed(args); result != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+		if result := p.IsAllowed(legacy); result != testCase.expected {
+			t.Fatalf("case %v: legacy: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+
+		args.NormalizeConditions()
+		if !reflect.DeepEqual(args.ConditionValues, testCase.legacy) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.legacy, args.ConditionValues)
+		}
+	}
+
+	// Values already present in ConditionValues take precedence, per key.
+	args := Args{
+		Action:          GetObjectAction,
+		BucketName:      "mybucket",
+		ObjectName:      "a.txt",
+		ObjectTags:      map[string]string{"team": "eng", "project": "x"},
+		ConditionValues: map[string][]string{"ExistingObjectTag/team": {"ops"}},
+	}
+	if p.IsAllowed(args) {
+		t.Fatalf("expected the ConditionValues tag to take precedence")
+	}
+	args.NormalizeConditions()
+	args.NormalizeConditions()
+	expected := map[string][]string{"ExistingObjectTag/team": {"ops"}, "ExistingObjectTag/project": {"x"}}
+	if !reflect.DeepEqual(args.ConditionValues, expected) {
+		t.Fatalf("expected: %v, got: %v", expected, args.ConditionValues)
+	}
+}
+
+func TestArgsVersionID(t *testing.T) {
+	p := mustParsePolicy(t, `{"Version": "2012-10-17", "Statement": [
+		{"Effect": "Allow", "Action": "s3:GetObjectVersion", "Resource": "arn:aws:s3:::mybucket/*",
+		 "Condition": {"StringEquals": {"s3:versionid": "3HL4kqtJlcpXroDTDmJ"}}},
+		{"Effect": "Allow", "Action": "s3:GetObjectVersion", "Resource": "arn:aws:s3:::mybucket/*",
+		 "Condition": {"StringLike": {"s3:versionid": "archive-*"}}},
+		{"Effect": "Allow", "Action": "s3:DeleteObjectVersion", "Resource": "arn:aws:s3:::mybucket/*",
+		 "Condition": {"StringEquals": {"s3:versionid": "null"}}}
+	]}`)
+
+	testCases := []struct {
+		action    Action
+		versionID string
+		expected  bool
+	}{
+		// Pinned version.
+		{GetObjectVersionAction, "3HL4kqtJlcpXroDTDmJ", true},
+		{GetObjectVersionAction, "3HL4kqtJlcpXroDTDmK", false},
+		// Wildcard version pattern.
+		{GetObjectVersionAction, "archive-2024", true},
+		{GetObjectVersionAction, "", false},
+		// The null version of unversioned objects.
+		{DeleteObjectVersionAction, "null", true},
+		{DeleteObjectVersionAction, "3HL4kqtJlcpXroDTDmJ", false},
+	}
+
+	for i, testCase := range testCases {
+		args := Args{Action: testCase.action, BucketName: "mybucket", ObjectName: "a.txt", VersionID: testCase.versionID}
+		if result := p.IsAllowed(args); result != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+	}
+
+	// A version already present in ConditionValues takes precedence.
+	args := Args{VersionID: "null", ConditionValues: map[string][]string{"versionid": {"v1"}}}
+	args.NormalizeConditions()
+	if expected := map[string][]string{"versionid": {"v1"}}; !reflect.DeepEqual(args.ConditionValues, expected) {
+		t.Fatalf("expected: %v, got: %v", expected, args.ConditionValues)
+	}
+}
+
+func TestPolicyLintVersionConditions(t *testing.T) {
+	p := mustParsePolicy(t, `{"Version": "2012-10-17", "Statement": [
+		{"Sid": "Never", "Effect": "Allow", "Action": ["s3:GetObjectVersion", "s3:DeleteObjectVersion"], "Resource": "arn:aws:s3:::mybucket/*",
+		 "Condition": {"Null": {"s3:versionid": "true"}}},
+		{"Sid": "Latest", "Effect": "Allow", "Action": ["s3:GetObject", "s3:GetObjectVersion"], "Resource": "arn:aws:s3:::mybucket/*",
+		 "Condition": {"Null": {"s3:versionid": "true"}}},
+		{"Sid": "Versioned", "Effect": "Allow", "Action": "s3:GetObjectVersion", "Resource": "arn:aws:s3:::mybucket/*",
+		 "Condition": {"Null": {"s3:versionid": "false"}}}
+	]}`)
+
+	expectedWarnings := []string{
+		"statement 'Never': condition 'Null' of 's3:versionid' never matches, the actions always target an object version",
+	}
+	if warnings := p.Lint(); !reflect.DeepEqual(warnings, expectedWarnings) {
+		t.Fatalf("expected: %q, got: %q", expectedWarnings, warnings)
+	}
+}
+
+func TestPolicyAccountLevelActions(t *testing.T) {
+	testCases := []struct {
+		resource       string
+		expectedResult bool
+	}{
+		{"arn:aws:s3:::*", true},
+		{"arn:aws:s3:::*/", true},
+		{"arn:aws:s3:::*/*", true},
+		{"arn:aws:s3:::**", true},
+		{"arn:aws:s3:::mybucket", false},
+		{"arn:aws:s3:::mybucket/*", false},
+		{"arn:aws:s3:::my*", false},
+		{"arn:aws:s3:::*bucket", false},
+		{"arn:aws:s3:::*/photos/*", false},
+		// Single character bucket patterns match "/", but not the account.
+		{"arn:aws:s3:::?", false},
+		{"arn:aws:s3:::?*", false},
+		{"arn:aws:s3:::${aws:username}", false},
+	}
+
+	for i, testCase := range testCases {
+		p := mustParsePolicy(t, `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:ListAllMyBuckets"], "Resource": ["`+testCase.resource+`"]}]}`)
+		for _, bucket := range []string{"", "mybucket"} {
+			result := p.IsAllowed(Args{
+				AccountName:     "Q3AM3UQ867SPQQA43P2F",
+				Action:          ListAllMyBucketsAction,
+				BucketName:      bucket,
+				ConditionValues: map[string][]string{"username": {"mybucket"}},
+			})
+			if result != testCase.expectedResult {
+				t.Fatalf("case %v: bucket %q: expected: %v, got: %v", i+1, bucket, testCase.expectedResult, result)
+			}
+		}
+
+		warned := false
+		for _, warning := range p.Lint() {
+			warned = warned || strings.Contains(warning, "applies to the account")
+		}
+		if warned == testCase.expectedResult {
+			t.Fatalf("case %v: expected a warning: %v, got: %q", i+1, !testCase.expectedResult, p.Lint())
+		}
+	}
+
+	if !Action(ListAllMyBucketsAction).IsAccountLevel() || Action(ListBucketAction).IsAccountLevel() || Action(ServerInfoAdminAction).IsAccountLevel() {
+		t.Fatalf("unexpected account level actions")
+	}
+
+	// Statements granting bucket actions too are only warned about the
+	// account level actions.
+	p := mustParsePolicy(t, `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:ListAllMyBuckets", "s3:ListBucket"], "Resource": ["arn:aws:s3:::mybucket"]}]}`)
+	expectedWarnings := []string{"statement 1: action 's3:ListAllMyBuckets' applies to the account and never matches the resources, use 'arn:aws:s3:::*'"}
+	if warnings := p.Lint(); !reflect.DeepEqual(warnings, expectedWarnings) {
+		t.Fatalf("expected: %q, got: %q", expectedWarnings, warnings)
+	}
+}
+
+func TestPolicyAuthConditions(t *testing.T) {
+	p, err := ParseConfig(strings.NewReader(`{"Version": "2012-10-17", "Statement": [
+		{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"},
+		{"Effect": "Deny", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*",
+		 "Condition": {"StringEquals": {"s3:authType": "REST-QUERY-STRING"}}},
+		{"Effect": "Deny", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*",
+		 "Condition": {"StringEquals": {"s3:signatureversion": "AWS"}}},
+		{"Effect": "Deny", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*",
+		 "Condition": {"NumericLessThan": {"s3:TlsVersion": "1.2"}}}
+	]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		sigVersion string
+		authType   string
+		tlsVersion float64
+		expected   bool
+	}{
+		{SignatureVersionV4, AuthTypeHeader, 1.3, true},
+		{SignatureVersionV4, AuthTypeHeader, 1.2, true},
+		{SignatureVersionV4, AuthTypePOST, 1.2, true},
+		// Presigned URLs are denied.
+		{SignatureVersionV4, AuthTypeQueryString, 1.3, false},
+		// SigV2 is denied.
+		{SignatureVersionV2, AuthTypeHeader, 1.3, false},
+		// TLS versions before 1.2 are denied.
+		{SignatureVersionV4, AuthTypeHeader, 1.1, false},
+		{SignatureVersionV4, AuthTypeHeader, 1, false},
+		// Requests without TLS have no TLS version.
+		{SignatureVersionV4, AuthTypeHeader, 0, true},
+	}
+	for i, testCase := range testCases {
+		args := Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}
+		args.PopulateAuthConditions(testCase.sigVersion, testCase.authType, testCase.tlsVersion)
+		if got := p.IsAllowed(args); got != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestPolicyAuthConditionsIntegerTLSVersion(t *testing.T) {
+	p := mustParsePolicy(t, `{"Version": "2012-10-17", "Statement": [
+		{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"},
+		{"Effect": "Deny", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*",
+		 "Condition": {"NumericLessThan": {"s3:TlsVersion": "2"}}}
+	]}`)
+
+	testCases := []struct {
+		tlsVersion float64
+		expected   bool
+	}{
+		{1.2, false},
+		{1.3, false},
+		{2, true},
+		{0, true},
+	}
+	for i, testCase := range testCases {
+		args := Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}
+		args.PopulateAuthConditions(SignatureVersionV4, AuthTypeHeader, testCase.tlsVersion)
+		if got := p.IsAllowed(args); got != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
 func TestPolicyIsEmpty(t *testing.T) {
 	case1Policy := Policy{
 		Version: DefaultVersion,
@@ -1551,3 +2163,1013 @@ func TestMergePolicies(t *testing.T) {
 		}
 	}
 }
+
+func TestPolicyInvalidBucketNames(t *testing.T) {
+	data := `{"Version": "2012-10-17", "Statement": [
+		{"Sid": "Typo", "Effect": "Allow", "Action": "s3:GetObject", "Resource": ["arn:aws:s3:::My_Bucket/*", "arn:aws:s3:::Prod-*/*", "arn:aws:s3:::prod-*/*"]},
+		{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::${aws:username}-Home/*"}
+	]}`
+
+	// Invalid bucket names are only warned about by default.
+	p := mustParsePolicy(t, data)
+	expectedWarnings := []string{
+		"statement 'Typo': resource 'arn:aws:s3:::My_Bucket/*' never matches, bucket names cannot contain 'M'",
+		"statement 'Typo': resource 'arn:aws:s3:::Prod-*/*' never matches, bucket names cannot contain 'P'",
+	}
+	if warnings := p.Lint(); !reflect.DeepEqual(warnings, expectedWarnings) {
+		t.Fatalf("expected: %q, got: %q", expectedWarnings, warnings)
+	}
+
+	_, err := ParseConfigStrict(strings.NewReader(data))
+	var merr ErrMalformedResource
+	if !errors.As(err, &merr) || merr.Resource != "arn:aws:s3:::My_Bucket/*" {
+		t.Fatalf("expected a malformed resource error, got: %v", err)
+	}
+
+	valid := strings.NewReplacer("My_Bucket", "my-bucket", "Prod-", "prod-").Replace(data)
+	if _, err := ParseConfigStrict(strings.NewReader(valid)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestMergePoliciesNamed(t *testing.T) {
+	inputs := map[string]Policy{
+		"readwrite": mustParsePolicy(t, `{"Version": "2012-10-17", "Statement": [
+			{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::prod/*"},
+			{"Effect": "Allow", "Action": "s3:PutObject", "Resource": "arn:aws:s3:::prod/*"}
+		]}`),
+		"readonly": mustParsePolicy(t, `{"Version": "2012-10-17", "Statement": [
+			{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::prod/*"}
+		]}`),
+		"deny-prod-writes": mustParsePolicy(t, `{"Version": "2012-10-17", "Statement": [
+			{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::prod/*"},
+			{"Effect": "Deny", "Action": "s3:PutObject", "Resource": "arn:aws:s3:::prod/*"}
+		]}`),
+	}
+
+	merged, provenance := MergePoliciesNamed(inputs)
+	expected := Provenance{
+		{{"deny-prod-writes", 0}, {"readonly", 0}, {"readwrite", 0}},
+		{{"deny-prod-writes", 1}},
+		{{"readwrite", 1}},
+	}
+	if !reflect.DeepEqual(provenance, expected) {
+		t.Fatalf("expected: %v, got: %v", expected, provenance)
+	}
+	if len(merged.Statements) != len(provenance) {
+		t.Fatalf("expected: %v statements, got: %v", len(provenance), len(merged.Statements))
+	}
+	if unnamed := MergePolicies(inputs["deny-prod-writes"], inputs["readonly"], inputs["readwrite"]); !merged.Equals(unnamed) {
+		t.Fatalf("expected: %v, got: %v", unnamed, merged)
+	}
+
+	// The result does not depend on the map iteration order.
+	for i := 0; i < 10; i++ {
+		m, p := MergePoliciesNamed(inputs)
+		if !reflect.DeepEqual(m, merged) || !reflect.DeepEqual(p, provenance) {
+			t.Fatalf("round %v: merged policies differ", i+1)
+		}
+	}
+
+	args := Args{Action: PutObjectAction, BucketName: "prod", ObjectName: "a.txt"}
+	reason := merged.EvaluateWithReason(args)
+	if s := provenance.Explain(reason); s != "Deny by statement 1 of policy 'deny-prod-writes'" {
+		t.Fatalf("unexpected explanation: %v", s)
+	}
+	args.Action = GetObjectAction
+	reason = merged.EvaluateWithReason(args)
+	if s := provenance.Explain(reason); s != "Allow by statement 0 of policy 'deny-prod-writes', statement 0 of policy 'readonly', statement 0 of policy 'readwrite'" {
+		t.Fatalf("unexpected explanation: %v", s)
+	}
+	if s := provenance.Explain(Reason{Verdict: VerdictNoMatch, Statement: -1}); s != "NoMatch" {
+		t.Fatalf("unexpected explanation: %v", s)
+	}
+
+	if merged, provenance := MergePoliciesNamed(nil); len(merged.Statements) != 0 || provenance != nil {
+		t.Fatalf("expected an empty policy, got: %v, %v", merged, provenance)
+	}
+}
+
+func TestDropDuplicateStatements(t *testing.T) {
+	getObject := NewStatement("", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/*")), condition.NewFunctions())
+	getObjectSID := NewStatement("ReadOnly", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/*")), condition.NewFunctions())
+	notGetObject := NewStatementWithNotAction("", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/*")), condition.NewFunctions())
+	notPutObject := NewStatementWithNotAction("", Allow, NewActionSet(PutObjectAction), NewResourceSet(NewResource("mybucket/*")), condition.NewFunctions())
+	listBucket := NewStatement("", Allow, NewActionSet(ListBucketAction), NewResourceSet(NewResource("mybucket")), condition.NewFunctions())
+
+	// sameStatements also compares SIDs, unlike Policy.Equals.
+	sameStatements := func(a, b []Statement) bool {
+		return slices.EqualFunc(a, b, func(x, y Statement) bool {
+			return x.SID == y.SID && x.Equals(y)
+		})
+	}
+
+	testCases := []struct {
+		statements []Statement
+		expected   []Statement
+	}{
+		{[]Statement{getObject, getObject}, []Statement{getObject}},
+		// Statements differing only in SID are kept.
+		{[]Statement{getObject, getObjectSID, getObjectSID}, []Statement{getObject, getObjectSID}},
+		// Statements differing only in NotAction are kept.
+		{[]Statement{notGetObject, notPutObject, notGetObject}, []Statement{notGetObject, notPutObject}},
+		{[]Statement{getObject, notGetObject}, []Statement{getObject, notGetObject}},
+		// The first occurrence is kept, order is preserved.
+		{
+			[]Statement{listBucket, getObject, listBucket, notGetObject, getObject, getObjectSID},
+			[]Statement{listBucket, getObject, notGetObject, getObjectSID},
+		},
+	}
+
+	for i, testCase := range testCases {
+		p := Policy{Version: DefaultVersion, Statements: slices.Clone(testCase.statements)}
+		p.dropDuplicateStatements()
+		if !sameStatements(p.Statements, testCase.expected) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, p.Statements)
+		}
+
+		// Merging the statements one by one.
+		var inputs []Policy
+		for _, statement := range testCase.statements {
+			inputs = append(inputs, Policy{Version: DefaultVersion, Statements: []Statement{statement}})
+		}
+		if merged := MergePolicies(inputs...); !sameStatements(merged.Statements, testCase.expected) {
+			t.Fatalf("case %v: merge: expected: %v, got: %v", i+1, testCase.expected, merged.Statements)
+		}
+	}
+
+	data := []byte(`{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Action": "s3:ListBucket", "Resource": "arn:aws:s3:::mybucket"},
+    {"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"},
+    {"Effect": "Allow", "Action": "s3:ListBucket", "Resource": "arn:aws:s3:::mybucket"},
+    {"Effect": "Allow", "NotAction": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"},
+    {"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"},
+    {"Sid": "ReadOnly", "Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"}
+  ]
+}`)
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Statement{listBucket, getObject, notGetObject, getObjectSID}
+	if !sameStatements(p.Statements, expected) {
+		t.Fatalf("unmarshal: expected: %v, got: %v", expected, p.Statements)
+	}
+}
+
+func TestPolicySortStatements(t *testing.T) {
+	deny := NewStatement("b", Deny, NewActionSet(DeleteObjectAction), NewResourceSet(NewResource("mybucket/*")), condition.NewFunctions())
+	allow1 := NewStatement("c", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/*")), condition.NewFunctions())
+	allow2 := NewStatement("a", Allow, NewActionSet(ListBucketAction), NewResourceSet(NewResource("mybucket")), condition.NewFunctions())
+
+	p := Policy{Version: DefaultVersion, Statements: []Statement{allow1, deny, allow2}}
+	p.SortStatements(func(a, b Statement) bool {
+		return a.Effect == Deny && b.Effect != Deny
+	})
+	expected := Policy{Version: DefaultVersion, Statements: []Statement{deny, allow1, allow2}}
+	if !p.Equals(expected) {
+		t.Fatalf("expected: %v, got: %v", expected, p)
+	}
+
+	p.SortStatements(func(a, b Statement) bool {
+		return a.SID < b.SID
+	})
+	expected = Policy{Version: DefaultVersion, Statements: []Statement{allow2, deny, allow1}}
+	if !p.Equals(expected) {
+		t.Fatalf("expected: %v, got: %v", expected, p)
+	}
+}
+
+func TestPolicyUnmarshalJSONIndependentConditions(t *testing.T) {
+	data := []byte(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Sid": "1",
+            "Effect": "Allow",
+            "Action": "s3:GetObject",
+            "Resource": "arn:aws:s3:::mybucket/*",
+            "Condition": {"StringEquals": {"aws:username": ["john"]}}
+        },
+        {
+            "Sid": "2",
+            "Effect": "Allow",
+            "Action": "s3:ListBucket",
+            "Resource": "arn:aws:s3:::mybucket",
+            "Condition": {"StringEquals": {"aws:username": ["john"]}}
+        }
+    ]
+}`)
+
+	p, err := ParseConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error. %v", err)
+	}
+
+	expectedConditions := p.Statements[1].Conditions.String()
+	expectedActions := p.Statements[1].Actions.String()
+
+	fn, err := condition.NewStringEqualsFunc("", condition.AWSUsername.ToKey(), "jane")
+	if err != nil {
+		t.Fatalf("unexpected error. %v", err)
+	}
+	p.Statements[0].Conditions[0] = fn
+	p.Statements[0].Conditions = append(p.Statements[0].Conditions, fn)
+	p.Statements[0].Actions.Add(PutObjectAction)
+
+	if result := p.Statements[1].Conditions.String(); result != expectedConditions {
+		t.Fatalf("expected: %v, got: %v", expectedConditions, result)
+	}
+	if result := p.Statements[1].Actions.String(); result != expectedActions {
+		t.Fatalf("expected: %v, got: %v", expectedActions, result)
+	}
+
+	clone := p.Statements[1].Clone()
+	clone.Conditions[0] = fn
+	if result := p.Statements[1].Conditions.String(); result != expectedConditions {
+		t.Fatalf("expected: %v, got: %v", expectedConditions, result)
+	}
+}
+
+func TestParseConfigActionValidation(t *testing.T) {
+	policyWithAction := func(action string) []byte {
+		return []byte(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Sid": "test",
+            "Effect": "Allow",
+            "Action": ["` + action + `"],
+            "Resource": ["arn:aws:s3:::mybucket/*"]
+        }
+    ]
+}`)
+	}
+
+	testCases := []struct {
+		action           string
+		strictErr        bool
+		defaultErr       bool
+		permissiveErr    bool
+		allowsGetObject  bool
+		allowsPutObject  bool
+		allowsFutureCall bool
+	}{
+		{"s3:GetObject", false, false, false, true, false, false},
+		{"s3:*", false, false, false, true, true, true},
+		{"s3:Get*", false, false, false, true, false, false},
+		{"s3:Get*ject", true, false, false, true, false, false},
+		{"s3:Get?bject", true, false, false, true, false, false},
+		{"s3:GetObjcet", true, true, false, false, false, false},
+		{"s3:Foo*", true, true, false, false, false, false},
+		{"s3:FutureCall", true, true, false, false, false, true},
+		{"s3:Future Call", true, true, true, false, false, false},
+		{"FutureCall", true, true, true, false, false, false},
+		{"s3:", true, true, true, false, false, false},
+	}
+
+	for i, testCase := range testCases {
+		for _, mode := range []struct {
+			validation ActionValidation
+			expectErr  bool
+		}{
+			{ActionValidationStrict, testCase.strictErr},
+			{ActionValidationDefault, testCase.defaultErr},
+			{ActionValidationPermissive, testCase.permissiveErr},
+		} {
+			p, err := ParseConfigWithOptions(bytes.NewReader(policyWithAction(testCase.action)), ValidationOptions{ActionValidation: mode.validation})
+			expectErr := (err != nil)
+
+			if expectErr != mode.expectErr {
+				t.Fatalf("case %v: mode %v: error: expected: %v, got: %v (%v)", i+1, mode.validation, mode.expectErr, expectErr, err)
+			}
+
+			if expectErr {
+				var e ErrUnsupportedAction
+				if !errors.As(err, &e) || e.Action != Action(testCase.action) || e.SID != "test" {
+					t.Fatalf("case %v: mode %v: unexpected error: %v", i+1, mode.validation, err)
+				}
+				continue
+			}
+
+			for _, check := range []struct {
+				action         Action
+				expectedResult bool
+			}{
+				{GetObjectAction, testCase.allowsGetObject},
+				{PutObjectAction, testCase.allowsPutObject},
+				{"s3:FutureCall", testCase.allowsFutureCall},
+			} {
+				result := p.IsAllowed(Args{Action: check.action, BucketName: "mybucket", ObjectName: "myobject"})
+				if result != check.expectedResult {
+					t.Fatalf("case %v: mode %v: %v: expected: %v, got: %v", i+1, mode.validation, check.action, check.expectedResult, result)
+				}
+			}
+		}
+	}
+
+	// ParseConfig validates as per default mode, ParseConfigStrict as per strict mode.
+	if _, err := ParseConfig(bytes.NewReader(policyWithAction("s3:Get*ject"))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := ParseConfigStrict(bytes.NewReader(policyWithAction("s3:Get*ject"))); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestPolicyPruneBucket(t *testing.T) {
+	testCases := []struct {
+		policy            string
+		bucket            string
+		expectedPolicy    string
+		expectedRemoved   int
+		expectedModified  bool
+		expectedReference bool
+	}{
+		// Bucket and object resources of the bucket are removed, the statement is dropped.
+		{
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:*"], "Resource": ["arn:aws:s3:::mybucket", "arn:aws:s3:::mybucket/*"]}]}`,
+			"mybucket",
+			`{"Version": "2012-10-17", "Statement": []}`,
+			1, true, true,
+		},
+		// Other buckets are kept.
+		{
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:*"], "Resource": ["arn:aws:s3:::mybucket/*", "arn:aws:s3:::otherbucket/*"]}]}`,
+			"mybucket",
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:*"], "Resource": ["arn:aws:s3:::otherbucket/*"]}]}`,
+			0, true, true,
+		},
+		// Prefix and object patterns within the bucket are removed.
+		{
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/photos/*", "arn:aws:s3:::mybucket/a/b/c.txt", "arn:aws:s3:::mybucket/*/x?"]}]}`,
+			"mybucket",
+			`{"Version": "2012-10-17", "Statement": []}`,
+			1, true, true,
+		},
+		// Wildcards covering the bucket are kept.
+		{
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:*"], "Resource": ["arn:aws:s3:::mybucket*", "arn:aws:s3:::mybucket*/*", "arn:aws:s3:::*", "arn:aws:s3:::my?ucket/*", "arn:aws:s3:::*/mybucket/*"]}]}`,
+			"mybucket",
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:*"], "Resource": ["arn:aws:s3:::mybucket*", "arn:aws:s3:::mybucket*/*", "arn:aws:s3:::*", "arn:aws:s3:::my?ucket/*", "arn:aws:s3:::*/mybucket/*"]}]}`,
+			0, false, false,
+		},
+		// Buckets sharing a prefix are not the same bucket.
+		{
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:*"], "Resource": ["arn:aws:s3:::mybucket2", "arn:aws:s3:::mybucket2/*", "arn:aws:s3:::mybucke/*"]}]}`,
+			"mybucket",
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:*"], "Resource": ["arn:aws:s3:::mybucket2", "arn:aws:s3:::mybucket2/*", "arn:aws:s3:::mybucke/*"]}]}`,
+			0, false, false,
+		},
+		// Policy variables are not expanded.
+		{
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:*"], "Resource": ["arn:aws:s3:::${aws:username}/*"]}]}`,
+			"mybucket",
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:*"], "Resource": ["arn:aws:s3:::${aws:username}/*"]}]}`,
+			0, false, false,
+		},
+		// Deny statements are pruned just like allow statements, other statements are kept in order.
+		{
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:*"], "Resource": ["arn:aws:s3:::*"]}, {"Effect": "Deny", "Action": ["s3:DeleteObject"], "Resource": ["arn:aws:s3:::mybucket/*"]}, {"Effect": "Allow", "Action": ["s3:ListBucket"], "Resource": ["arn:aws:s3:::otherbucket"]}]}`,
+			"mybucket",
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:*"], "Resource": ["arn:aws:s3:::*"]}, {"Effect": "Allow", "Action": ["s3:ListBucket"], "Resource": ["arn:aws:s3:::otherbucket"]}]}`,
+			1, true, true,
+		},
+		// Admin statements are kept without resources.
+		{
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["admin:SetBucketTarget"], "Resource": ["arn:aws:s3:::mybucket"]}]}`,
+			"mybucket",
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["admin:SetBucketTarget"]}]}`,
+			0, true, true,
+		},
+		// KMS resources are not buckets.
+		{
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["kms:Status"], "Resource": ["arn:minio:kms:::mybucket"]}]}`,
+			"mybucket",
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["kms:Status"], "Resource": ["arn:minio:kms:::mybucket"]}]}`,
+			0, false, false,
+		},
+		// Invalid bucket names never match.
+		{
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:*"], "Resource": ["arn:aws:s3:::*"]}]}`,
+			"*",
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:*"], "Resource": ["arn:aws:s3:::*"]}]}`,
+			0, false, false,
+		},
+		{
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:*"], "Resource": ["arn:aws:s3:::mybucket/*"]}]}`,
+			"",
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:*"], "Resource": ["arn:aws:s3:::mybucket/*"]}]}`,
+			0, false, false,
+		},
+	}
+
+	for i, testCase := range testCases {
+		policy, err := ParseConfig(strings.NewReader(testCase.policy))
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		var expectedPolicy Policy
+		if err = json.Unmarshal([]byte(testCase.expectedPolicy), &expectedPolicy); err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+
+		if reference := policy.ReferencesBucket(testCase.bucket); reference != testCase.expectedReference {
+			t.Fatalf("case %v: reference: expected: %v, got: %v", i+1, testCase.expectedReference, reference)
+		}
+
+		original := *policy
+		originalResources := make([]ResourceSet, 0, len(original.Statements))
+		for _, statement := range original.Statements {
+			originalResources = append(originalResources, statement.Resources.Clone())
+		}
+
+		removed, modified := policy.PruneBucket(testCase.bucket)
+		if removed != testCase.expectedRemoved {
+			t.Fatalf("case %v: removed: expected: %v, got: %v", i+1, testCase.expectedRemoved, removed)
+		}
+		if modified != testCase.expectedModified {
+			t.Fatalf("case %v: modified: expected: %v, got: %v", i+1, testCase.expectedModified, modified)
+		}
+		if len(policy.Statements) != len(expectedPolicy.Statements) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, expectedPolicy.Statements, policy.Statements)
+		}
+		for j := range policy.Statements {
+			if !policy.Statements[j].Equals(expectedPolicy.Statements[j]) {
+				t.Fatalf("case %v: expected: %v, got: %v", i+1, expectedPolicy.Statements, policy.Statements)
+			}
+		}
+		if policy.ReferencesBucket(testCase.bucket) {
+			t.Fatalf("case %v: pruned policy still references bucket", i+1)
+		}
+		for j, statement := range original.Statements {
+			if !statement.Resources.Equals(originalResources[j]) {
+				t.Fatalf("case %v: copy of the policy was modified: %v", i+1, statement.Resources)
+			}
+		}
+
+		// Pruning is idempotent.
+		if removed, modified = policy.PruneBucket(testCase.bucket); removed != 0 || modified {
+			t.Fatalf("case %v: expected no changes, got: %v, %v", i+1, removed, modified)
+		}
+	}
+}
+
+func TestPolicyWriteJSON(t *testing.T) {
+	func1, err := condition.NewStringLikeFunc(
+		"",
+		condition.S3Prefix.ToKey(),
+		"<home>/${aws:username}/&*",
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []Policy{
+		{},
+		{Version: DefaultVersion, Statements: []Statement{}},
+		{
+			ID:      "MyPolicyForMyBucket",
+			Version: DefaultVersion,
+			Statements: []Statement{
+				NewStatement(
+					"",
+					Allow,
+					NewActionSet(GetObjectAction, PutObjectAction),
+					NewResourceSet(NewResource("mybucket/myobject*")),
+					condition.NewFunctions(),
+				),
+			},
+		},
+		{
+			Version: DefaultVersion,
+			Statements: []Statement{
+				NewStatement(
+					"",
+					Allow,
+					NewActionSet(ListBucketAction),
+					NewResourceSet(NewResource("mybucket")),
+					condition.NewFunctions(func1),
+				),
+				NewStatement(
+					"DenyDelete",
+					Deny,
+					NewActionSet(DeleteObjectAction),
+					NewResourceSet(NewResource("mybucket/*"), NewResource("yourbucket/*")),
+					condition.NewFunctions(),
+				),
+			},
+		},
+	}
+
+	for i, testCase := range testCases {
+		expectedResult, err := json.Marshal(testCase)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+
+		var buf bytes.Buffer
+		if err = testCase.WriteJSON(&buf); err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if !bytes.Equal(buf.Bytes(), expectedResult) {
+			t.Fatalf("case %v: expected: %s, got: %s", i+1, expectedResult, buf.Bytes())
+		}
+
+		if len(testCase.Statements) == 0 {
+			continue
+		}
+		policy, err := ReadPolicy(&buf)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+		if !policy.Equals(testCase) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase, policy)
+		}
+	}
+
+	if err = testCases[2].WriteJSON(errWriter{}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write error")
+}
+
+func BenchmarkPolicyWriteJSON(b *testing.B) {
+	const statements = 50000
+	policy := Policy{Version: DefaultVersion, Statements: make([]Statement, 0, statements)}
+	for i := 0; i < statements; i++ {
+		policy.Statements = append(policy.Statements, NewStatement(
+			ID(fmt.Sprintf("statement%d", i)),
+			Allow,
+			NewActionSet(GetObjectAction, PutObjectAction),
+			NewResourceSet(NewResource(fmt.Sprintf("bucket%d/*", i))),
+			condition.NewFunctions(),
+		))
+	}
+
+	allocated := func(b *testing.B, f func()) {
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		for i := 0; i < b.N; i++ {
+			f()
+		}
+		runtime.ReadMemStats(&after)
+		b.ReportMetric(float64(after.TotalAlloc-before.TotalAlloc)/float64(b.N), "alloc-bytes/op")
+	}
+
+	b.Run("json.Marshal", func(b *testing.B) {
+		allocated(b, func() {
+			data, err := json.Marshal(policy)
+			if err != nil {
+				b.Fatal(err)
+			}
+			io.Discard.Write(data)
+		})
+	})
+	b.Run("WriteJSON", func(b *testing.B) {
+		allocated(b, func() {
+			if err := policy.WriteJSON(io.Discard); err != nil {
+				b.Fatal(err)
+			}
+		})
+	})
+}
+
+func TestPolicyAccessPointResources(t *testing.T) {
+	mixed := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "AccessPoint",
+      "Effect": "Allow",
+      "Action": "s3:GetObject",
+      "Resource": "arn:aws:s3:us-east-1:123456789012:accesspoint/my-ap/object/*"
+    },
+    {
+      "Sid": "ObjectLambda",
+      "Effect": "Deny",
+      "Action": "s3:*",
+      "Resource": "arn:aws:s3-object-lambda:us-east-1:123456789012:accesspoint/my-olap"
+    },
+    {
+      "Sid": "Mixed",
+      "Effect": "Allow",
+      "Action": ["s3:GetObject", "s3:PutObject"],
+      "Resource": [
+        "arn:aws:s3:::mybucket/*",
+        "arn:aws:s3:us-east-1:123456789012:accesspoint/my-ap/object/*"
+      ]
+    },
+    {
+      "Effect": "Deny",
+      "Action": "s3:PutObject",
+      "Resource": "arn:aws:s3:::mybucket/locked/*"
+    }
+  ]
+}`
+
+	p, err := ParseConfig(strings.NewReader(mixed))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedWarnings := []string{
+		"statement 'AccessPoint': resource 'arn:aws:s3:us-east-1:123456789012:accesspoint/my-ap/object/*' is an unsupported resource type and never matches",
+		"statement 'ObjectLambda': resource 'arn:aws:s3-object-lambda:us-east-1:123456789012:accesspoint/my-olap' is an unsupported resource type and never matches",
+		"statement 'Mixed': resource 'arn:aws:s3:us-east-1:123456789012:accesspoint/my-ap/object/*' is an unsupported resource type and never matches",
+	}
+	if warnings := p.Lint(); !reflect.DeepEqual(warnings, expectedWarnings) {
+		t.Fatalf("expected: %q, got: %q", expectedWarnings, warnings)
+	}
+
+	testCases := []struct {
+		action         Action
+		bucketName     string
+		objectName     string
+		expectedResult bool
+	}{
+		{GetObjectAction, "mybucket", "myobject", true},
+		{PutObjectAction, "mybucket", "myobject", true},
+		{PutObjectAction, "mybucket", "locked/myobject", false},
+		{GetObjectAction, "my-ap", "myobject", false},
+		{GetObjectAction, "accesspoint", "my-ap/object/myobject", false},
+		{DeleteObjectAction, "mybucket", "myobject", false},
+	}
+	for i, testCase := range testCases {
+		result := p.IsAllowed(Args{
+			AccountName: "Q3AM3UQ867SPQQA43P2F",
+			Action:      testCase.action,
+			BucketName:  testCase.bucketName,
+			ObjectName:  testCase.objectName,
+		})
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	// Round-trip keeps the access point resources.
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), `"arn:aws:s3-object-lambda:us-east-1:123456789012:accesspoint/my-olap"`) {
+		t.Fatalf("expected access point resource in %s", data)
+	}
+
+	// Strict validation rejects access points.
+	if _, err = ParseConfigWithOptions(strings.NewReader(mixed), ValidationOptions{RejectUnsupportedResources: true}); !errors.As(err, &ErrMalformedResource{}) {
+		t.Fatalf("expected malformed resource error, got: %v", err)
+	}
+
+	// A policy with access points only has no meaningful statement.
+	onlyAccessPoints := `{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:us-east-1:123456789012:accesspoint/my-ap/object/*"}
+  ]}`
+	if _, err = ParseConfig(strings.NewReader(onlyAccessPoints)); !errors.As(err, &ErrMalformedResource{}) {
+		t.Fatalf("expected malformed resource error, got: %v", err)
+	}
+
+	// Other regional ARNs are still malformed.
+	for _, resource := range []string{
+		"arn:aws:s3:us-east-1:123456789012:bucket/mybucket",
+		"arn:aws:s3:us-east-1::accesspoint/my-ap",
+		"arn:aws:s3:us-east-1:123456789012:accesspoint/",
+	} {
+		data := fmt.Sprintf(`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": "s3:GetObject", "Resource": ["arn:aws:s3:::mybucket/*", %q]}
+  ]}`, resource)
+		if _, err = ParseConfig(strings.NewReader(data)); err == nil {
+			t.Fatalf("%s: expected error", resource)
+		}
+	}
+}
+
+func TestPolicyStringOperatorKeyMatrix(t *testing.T) {
+	operators := []string{
+		"StringEquals",
+		"StringNotEquals",
+		"StringEqualsIgnoreCase",
+		"StringNotEqualsIgnoreCase",
+		"StringLike",
+		"StringNotLike",
+		"BinaryEquals",
+		"ForAnyValue:StringEqualsIgnoreCase",
+		"ForAllValues:StringNotEqualsIgnoreCase",
+	}
+	statements := []struct {
+		action   string
+		resource string
+		keys     []condition.KeyName
+	}{
+		{"s3:GetObject", "arn:aws:s3:::mybucket/*", []condition.KeyName{
+			condition.AWSUsername,
+			condition.JWTPrefUsername,
+			condition.JWTGroups,
+			condition.LDAPUser,
+			condition.LDAPUsername,
+			condition.LDAPGroups,
+			condition.SVCParentUser,
+		}},
+		{"s3:ListBucket", "arn:aws:s3:::mybucket", []condition.KeyName{
+			condition.S3Prefix,
+			condition.JWTPrefUsername,
+			condition.LDAPUsername,
+			condition.SVCParentUser,
+		}},
+		{"admin:ServerInfo", "", []condition.KeyName{
+			condition.JWTPrefUsername,
+			condition.LDAPUsername,
+			condition.SVCParentUser,
+		}},
+		{"sts:AssumeRoleWithWebIdentity", "", []condition.KeyName{
+			condition.AWSPrincipalTag,
+		}},
+	}
+
+	for _, operator := range operators {
+		for _, statement := range statements {
+			for _, key := range statement.keys {
+				resource := ""
+				if statement.resource != "" {
+					resource = fmt.Sprintf(`"Resource": "%s",`, statement.resource)
+				}
+				data := fmt.Sprintf(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "%s", %s "Condition": {"%s": {"%s": "dXNlcg=="}}}]}`,
+					statement.action, resource, operator, key)
+				p, err := ParseConfig(strings.NewReader(data))
+				if err != nil {
+					t.Fatalf("%v %v %v: unexpected error: %v", operator, statement.action, key, err)
+				}
+				if err = p.Validate(); err != nil {
+					t.Fatalf("%v %v %v: unexpected error: %v", operator, statement.action, key, err)
+				}
+			}
+		}
+	}
+}
+
+func TestPolicyIsAllowedNullMultipleKeys(t *testing.T) {
+	p := mustParsePolicy(t, `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Action": "s3:PutObject", "Resource": "arn:aws:s3:::mybucket/*", "Condition": {"Null": {"s3:x-amz-acl": "false", "s3:x-amz-server-side-encryption": "True"}}},
+    {"Effect": "Deny", "Action": "s3:PutObject", "Resource": "arn:aws:s3:::mybucket/*", "Condition": {"Null": {"s3:x-amz-acl": "FALSE", "s3:x-amz-storage-class": ["false", false]}}}
+  ]
+}`)
+
+	testCases := []struct {
+		conditionValues map[string][]string
+		expectedResult  bool
+	}{
+		// Allowed with an ACL and without encryption header.
+		{map[string][]string{"x-amz-acl": {"private"}}, true},
+		// Not allowed without ACL or with an encryption header.
+		{map[string][]string{}, false},
+		{map[string][]string{"x-amz-acl": {"private"}, "x-amz-server-side-encryption": {"AES256"}}, false},
+		// Denied with both an ACL and a storage class only.
+		{map[string][]string{"x-amz-acl": {"private"}, "x-amz-storage-class": {"STANDARD"}}, false},
+		{map[string][]string{"x-amz-storage-class": {"STANDARD"}}, false},
+	}
+
+	for i, testCase := range testCases {
+		result := p.IsAllowed(Args{
+			AccountName:     "Q3AM3UQ867SPQQA43P2F",
+			Action:          PutObjectAction,
+			BucketName:      "mybucket",
+			ObjectName:      "myobject",
+			ConditionValues: testCase.conditionValues,
+		})
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	// The Deny statement applies irrespective of the Allow statement.
+	deny := p.Statements[1]
+	testCases = []struct {
+		conditionValues map[string][]string
+		expectedResult  bool
+	}{
+		{map[string][]string{"x-amz-acl": {"private"}, "x-amz-storage-class": {"STANDARD"}}, true},
+		{map[string][]string{"x-amz-acl": {"private"}}, false},
+		{map[string][]string{"x-amz-storage-class": {"STANDARD"}}, false},
+	}
+	for i, testCase := range testCases {
+		if result := deny.Conditions.Evaluate(testCase.conditionValues); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestPolicyBusinessHours(t *testing.T) {
+	p := mustParsePolicy(t, `{"Version": "2012-10-17", "Statement": [
+		{"Sid": "BusinessHours", "Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*",
+		 "Condition": {
+		  "DayOfWeekEquals": {"aws:CurrentTime": ["Mon", "Tue", "Wed", "Thu", "Fri"], "minio:Timezone": "Europe/Berlin"},
+		  "TimeOfDayGreaterThan": {"aws:CurrentTime": "08:59", "minio:Timezone": "Europe/Berlin"},
+		  "TimeOfDayLessThan": {"aws:CurrentTime": "17:00", "minio:Timezone": "Europe/Berlin"}
+		 }},
+		{"Sid": "NoNightlyWrites", "Effect": "Deny", "Action": "s3:PutObject", "Resource": "arn:aws:s3:::mybucket/*",
+		 "Condition": {"TimeOfDayGreaterThan": {"aws:CurrentTime": "23:00"}, "TimeOfDayLessThan": {"aws:CurrentTime": "01:00"}}},
+		{"Effect": "Allow", "Action": "s3:PutObject", "Resource": "arn:aws:s3:::mybucket/*"}
+	]}`)
+
+	testCases := []struct {
+		action         Action
+		currentTime    string
+		expectedResult bool
+	}{
+		{GetObjectAction, "2026-03-02T08:00:00Z", true},  // Mon 09:00 CET
+		{GetObjectAction, "2026-03-02T07:59:00Z", false}, // Mon 08:59 CET
+		{GetObjectAction, "2026-03-02T15:59:59Z", true},  // Mon 16:59:59 CET
+		{GetObjectAction, "2026-03-02T16:00:00Z", false}, // Mon 17:00 CET
+		{GetObjectAction, "2026-03-07T10:00:00Z", false}, // Sat 11:00 CET
+		{GetObjectAction, "2026-03-06T22:59:59Z", false}, // Fri 23:59:59 CET
+		{GetObjectAction, "2026-03-30T07:30:00Z", true},  // Mon 09:30 CEST
+		{GetObjectAction, "2026-03-30T15:30:00Z", false}, // Mon 17:30 CEST
+		{PutObjectAction, "2026-03-02T22:59:59Z", true},
+		{PutObjectAction, "2026-03-02T23:00:01Z", false},
+		{PutObjectAction, "2026-03-02T23:59:59Z", false},
+		{PutObjectAction, "2026-03-03T00:00:00Z", false},
+		{PutObjectAction, "2026-03-03T00:59:59Z", false},
+		{PutObjectAction, "2026-03-03T01:00:00Z", true},
+	}
+
+	for i, testCase := range testCases {
+		result := p.IsAllowed(Args{
+			AccountName:     "Q3AM3UQ867SPQQA43P2F",
+			Action:          testCase.action,
+			BucketName:      "mybucket",
+			ObjectName:      "myobject",
+			ConditionValues: map[string][]string{"CurrentTime": {testCase.currentTime}},
+		})
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	expectedWarnings := []string{
+		"statement 'BusinessHours': condition 'DayOfWeekEquals' is a MinIO extension, the policy is not portable to AWS",
+		"statement 'BusinessHours': condition 'TimeOfDayGreaterThan' is a MinIO extension, the policy is not portable to AWS",
+		"statement 'BusinessHours': condition 'TimeOfDayLessThan' is a MinIO extension, the policy is not portable to AWS",
+		"statement 'NoNightlyWrites': condition 'TimeOfDayGreaterThan' is a MinIO extension, the policy is not portable to AWS",
+		"statement 'NoNightlyWrites': condition 'TimeOfDayLessThan' is a MinIO extension, the policy is not portable to AWS",
+	}
+	if warnings := p.Lint(); !reflect.DeepEqual(warnings, expectedWarnings) {
+		t.Fatalf("expected: %q, got: %q", expectedWarnings, warnings)
+	}
+}
+
+func TestPolicyConditionOperatorKeyType(t *testing.T) {
+	testCases := []struct {
+		condition       string
+		expectErr       bool
+		expectStrictErr bool
+	}{
+		{`"IpAddress": {"s3:prefix": "10.0.0.0/8"}`, true, true},
+		{`"Bool": {"aws:UserAgent": "true"}`, true, true},
+		// Numeric and date operators of previous releases accept keys of
+		// any type by default.
+		{`"DateGreaterThan": {"jwt:scope": "2026-01-01T00:00:00Z"}`, false, true},
+		{`"NumericLessThan": {"aws:Referer": 10}`, false, true},
+		{`"NumericLessThan": {"s3:ExistingObjectTag/size": 10}`, false, true},
+		{`"IpAddress": {"aws:SourceIp": "10.0.0.0/8"}`, false, false},
+		{`"DateGreaterThan": {"aws:CurrentTime": "2026-01-01T00:00:00Z"}`, false, false},
+		{`"NumericLessThan": {"aws:EpochTime": 1767225600}`, false, false},
+		{`"StringEquals": {"aws:SourceIp": "10.0.0.1"}`, false, false},
+	}
+
+	for i, testCase := range testCases {
+		data := fmt.Sprintf(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*", "Condition": {%s}}]}`, testCase.condition)
+		p, err := ParseConfig(strings.NewReader(data))
+		if expectErr := err != nil; expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if err == nil {
+			// Accepted mismatches are reported by Lint.
+			if warned := len(p.Lint()) > 0; warned != testCase.expectStrictErr {
+				t.Fatalf("case %v: warnings: expected: %v, got: %v", i+1, testCase.expectStrictErr, p.Lint())
+			}
+		}
+		_, err = ParseConfigStrict(strings.NewReader(data))
+		if expectErr := err != nil; expectErr != testCase.expectStrictErr {
+			t.Fatalf("case %v: strict error: expected: %v, got: %v", i+1, testCase.expectStrictErr, err)
+		}
+		var keyErr condition.ErrUnsupportedOperatorKey
+		if testCase.expectStrictErr && !errors.As(err, &keyErr) {
+			t.Fatalf("case %v: expected: %T, got: %v", i+1, keyErr, err)
+		}
+
+		// Bucket policies accept the same key types.
+		data = fmt.Sprintf(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*", "Condition": {%s}}]}`, testCase.condition)
+		_, err = ParseBucketPolicyConfig(strings.NewReader(data), "mybucket")
+		if expectErr := err != nil; expectErr != testCase.expectErr {
+			t.Fatalf("case %v: bucket policy error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+func TestCopySourceConditionVariants(t *testing.T) {
+	const statements = `[
+    {
+        "Effect": "Allow",
+        "Action": "s3:PutObject",
+        "Resource": "arn:aws:s3:::dstbucket/*",
+        "Condition": {"StringLike": {"s3:x-amz-copy-source": "srcbucket/allowed/*"}}
+    },
+    {
+        "Effect": "Deny",
+        "Action": "s3:PutObject",
+        "Resource": "arn:aws:s3:::dstbucket/*",
+        "Condition": {"StringEquals": {"s3:x-amz-copy-source-version-id": "denied-version"}}
+    }
+]`
+	iamp := mustParsePolicy(t, `{"Version": "2012-10-17", "Statement": `+statements+`}`)
+	bp, err := ParseBucketPolicyConfig(strings.NewReader(`{"Version": "2012-10-17", "Statement": `+
+		strings.ReplaceAll(statements, `"Effect"`, `"Principal": "*", "Effect"`)+`}`), "dstbucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Each source, in all forms of the header value, evaluates identically.
+	sources := []struct {
+		canonical string
+		expected  bool
+	}{
+		{"srcbucket/allowed/my object+1.txt", true},
+		{"srcbucket/other/my object+1.txt", false},
+	}
+	variants := []func(string) string{
+		func(s string) string { return s },
+		func(s string) string { return "/" + s },
+		func(s string) string { return (&url.URL{Path: s}).EscapedPath() },
+		func(s string) string { return "/" + (&url.URL{Path: s}).EscapedPath() },
+		func(s string) string { return "/" + (&url.URL{Path: s}).EscapedPath() + "?versionId=v1" },
+		func(s string) string { return s + "?versionId=v1" },
+	}
+
+	for i, source := range sources {
+		for j, variant := range variants {
+			for _, header := range []string{"x-amz-copy-source", "X-Amz-Copy-Source"} {
+				value := variant(source.canonical)
+				conditionValues := map[string][]string{header: {value}}
+
+				allowed := iamp.IsAllowed(Args{
+					Action:          PutObjectAction,
+					BucketName:      "dstbucket",
+					ObjectName:      "myobject",
+					ConditionValues: conditionValues,
+				})
+				if allowed != source.expected {
+					t.Fatalf("case %v.%v: %v: %q: expected: %v, got: %v", i+1, j+1, header, value, source.expected, allowed)
+				}
+				allowed = bp.IsAllowed(BucketPolicyArgs{
+					Action:          PutObjectAction,
+					BucketName:      "dstbucket",
+					ObjectName:      "myobject",
+					ConditionValues: conditionValues,
+				})
+				if allowed != source.expected {
+					t.Fatalf("case %v.%v: bucket policy: %v: %q: expected: %v, got: %v", i+1, j+1, header, value, source.expected, allowed)
+				}
+			}
+		}
+	}
+
+	// The version ID is matched by s3:x-amz-copy-source-version-id.
+	versions := []struct {
+		value    string
+		expected bool
+	}{
+		{"/srcbucket/allowed/myobject?versionId=denied-version", false},
+		{"srcbucket/allowed/myobject?versionId=denied%2Dversion", false},
+		{"/srcbucket/allowed/myobject?versionId=other-version", true},
+		{"/srcbucket/allowed/myobject", true},
+	}
+	for i, version := range versions {
+		args := Args{
+			Action:          PutObjectAction,
+			BucketName:      "dstbucket",
+			ObjectName:      "myobject",
+			ConditionValues: map[string][]string{"x-amz-copy-source": {version.value}},
+		}
+		if allowed := iamp.IsAllowed(args); allowed != version.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, version.expected, allowed)
+		}
+		bpArgs := BucketPolicyArgs{
+			Action:          PutObjectAction,
+			BucketName:      "dstbucket",
+			ObjectName:      "myobject",
+			ConditionValues: args.ConditionValues,
+		}
+		if allowed := bp.IsAllowed(bpArgs); allowed != version.expected {
+			t.Fatalf("case %v: bucket policy: expected: %v, got: %v", i+1, version.expected, allowed)
+		}
+	}
+}
@@ -19,12 +19,14 @@ package policy
 
 import (
 	"encoding/json"
+	"strings"
 
 	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/pkg/v3/wildcard"
 )
 
-// Principal - policy principal.
+// Principal - policy principal. The zero Principal matches no principal
+// and is invalid in a statement.
 type Principal struct {
 	AWS set.StringSet
 }
@@ -44,10 +46,15 @@ func (p Principal) Intersection(principal Principal) set.StringSet {
 	return p.AWS.Intersection(principal.AWS)
 }
 
-// MarshalJSON - encodes Principal to JSON data.
+// MarshalJSON - encodes Principal to JSON data. The zero Principal is
+// encoded as an empty object, which decodes to the zero Principal.
 func (p Principal) MarshalJSON() ([]byte, error) {
+	if p.AWS == nil {
+		return []byte("{}"), nil
+	}
+
 	if !p.IsValid() {
-		return nil, Errorf("invalid principal %v", p)
+		return nil, Errorf("%w %v", ErrInvalidPrincipal, p)
 	}
 
 	// subtype to avoid recursive call to MarshalJSON()
@@ -67,6 +74,72 @@ func (p Principal) Match(principal string) bool {
 	return false
 }
 
+// iamARNPrefix - prefix of the ARN built for requesters, see
+// BucketPolicyArgs.PrincipalARN.
+const iamARNPrefix = "arn:aws:iam::"
+
+// requesterARN - returns the ARN of the requester of args, empty if it is
+// unknown.
+func requesterARN(args BucketPolicyArgs) string {
+	if args.PrincipalARN != "" {
+		return args.PrincipalARN
+	}
+	if args.AccountID == "" || args.AccountName == "" {
+		return ""
+	}
+	return iamARNPrefix + args.AccountID + ":user/" + args.AccountName
+}
+
+// requesterAccountID - returns the account ID of the requester of args,
+// empty if it is unknown.
+func requesterAccountID(args BucketPolicyArgs) string {
+	if args.AccountID != "" {
+		return args.AccountID
+	}
+	// arn:partition:iam::account:resource
+	if fields := strings.SplitN(args.PrincipalARN, ":", 6); len(fields) == 6 && fields[0] == "arn" {
+		return fields[4]
+	}
+	return ""
+}
+
+// matchArgs - matches the requester of args with Principal. Entries of the
+// form "arn:partition:iam::account:resource" are matched against the ARN
+// of the requester, and "arn:partition:iam::account:root" entries against
+// its account, i.e. they match any principal of the account. Other entries
+// are matched against the access key of the requester. Wildcards are
+// supported as by Match.
+func (p Principal) matchArgs(args BucketPolicyArgs) bool {
+	var arn, accountID string
+	var arnBuilt bool
+	for pattern := range p.AWS {
+		if !strings.HasPrefix(pattern, "arn:") {
+			if wildcard.MatchSimple(pattern, args.AccountName) {
+				return true
+			}
+			continue
+		}
+
+		if !arnBuilt {
+			arn, accountID, arnBuilt = requesterARN(args), requesterAccountID(args), true
+		}
+		if arn == "" && accountID == "" {
+			continue
+		}
+		if arn != "" && wildcard.MatchSimple(pattern, arn) {
+			return true
+		}
+		if account, ok := strings.CutSuffix(pattern, ":root"); ok && accountID != "" {
+			if fields := strings.SplitN(account, ":", 5); len(fields) == 5 && fields[2] == "iam" &&
+				wildcard.MatchSimple(fields[4], accountID) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // UnmarshalJSON - decodes JSON data to Principal.
 func (p *Principal) UnmarshalJSON(data []byte) error {
 	// subtype to avoid recursive call to UnmarshalJSON()
@@ -80,7 +153,7 @@ func (p *Principal) UnmarshalJSON(data []byte) error {
 		}
 
 		if s != "*" {
-			return Errorf("invalid principal '%v'", s)
+			return Errorf("%w '%v'", ErrInvalidPrincipal, s)
 		}
 
 		sp.AWS = set.CreateStringSet("*")
@@ -91,8 +164,12 @@ func (p *Principal) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// Clone clones Principal structure
+// Clone clones Principal structure, the zero Principal is cloned to the
+// zero Principal.
 func (p Principal) Clone() Principal {
+	if p.AWS == nil {
+		return Principal{}
+	}
 	return NewPrincipal(p.AWS.ToSlice()...)
 }
 
@@ -0,0 +1,329 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+// PolicySummary - counts describing a policy without revealing its
+// buckets, objects or principals, for inclusion in support bundles.
+type PolicySummary struct {
+	Statements int
+	Allow      int
+	Deny       int
+
+	// Number of actions of Action and NotAction by family, e.g. "s3" or
+	// "admin". Actions of all services, i.e. "*", are counted under "*".
+	ActionsByFamily map[string]int
+
+	// Sorted, distinct fingerprints of the bucket names or patterns of
+	// S3 resources, other than "*". Fingerprints are salted with a salt
+	// random per process: they match across the summaries of a process
+	// only.
+	Buckets []string
+
+	// Sorted, distinct condition operators, e.g. "StringLike".
+	ConditionOperators []string
+
+	// Whether any action or resource has a wildcard.
+	Wildcards bool
+
+	// Whether any statement applies to all principals, bucket policies
+	// only.
+	PublicPrincipal bool
+}
+
+// summarySalt - salt of the bucket fingerprints of summaries.
+var summarySalt = func() []byte {
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		panic(err)
+	}
+	return salt
+}()
+
+// summary - accumulates the statements of a PolicySummary.
+type summary struct {
+	PolicySummary
+	buckets   set.StringSet
+	operators set.StringSet
+}
+
+func newSummary() *summary {
+	return &summary{
+		PolicySummary: PolicySummary{ActionsByFamily: map[string]int{}},
+		buckets:       set.NewStringSet(),
+		operators:     set.NewStringSet(),
+	}
+}
+
+func (s *summary) addStatement(effect Effect, actionSets []ActionSet, resourceSets []ResourceSet, conditions condition.Functions) {
+	s.Statements++
+	switch effect {
+	case Allow:
+		s.Allow++
+	case Deny:
+		s.Deny++
+	}
+	for _, actions := range actionSets {
+		for action := range actions {
+			family := action.namespace()
+			if family == "" {
+				family = "*"
+			}
+			s.ActionsByFamily[family]++
+			s.Wildcards = s.Wildcards || strings.Contains(string(action), "*")
+		}
+	}
+	for _, resources := range resourceSets {
+		for resource := range resources {
+			s.Wildcards = s.Wildcards || strings.ContainsAny(resource.Pattern, "*?")
+			if !resource.isS3() {
+				continue
+			}
+			if bucket, _, _ := strings.Cut(resource.Pattern, "/"); bucket != "*" {
+				s.buckets.Add(pseudonym(summarySalt, bucket))
+			}
+		}
+	}
+	for _, c := range conditions.Conditions() {
+		s.operators.Add(c.Operator)
+	}
+}
+
+func (s *summary) result() PolicySummary {
+	s.Buckets = s.buckets.ToSlice()
+	s.ConditionOperators = s.operators.ToSlice()
+	return s.PolicySummary
+}
+
+// Summarize - returns a summary of the policy.
+func (iamp Policy) Summarize() PolicySummary {
+	s := newSummary()
+	for _, statement := range iamp.Statements {
+		s.addStatement(statement.Effect, []ActionSet{statement.Actions, statement.NotActions}, []ResourceSet{statement.Resources}, statement.Conditions)
+	}
+	return s.result()
+}
+
+// Summarize - returns a summary of the bucket policy.
+func (policy BucketPolicy) Summarize() PolicySummary {
+	s := newSummary()
+	for _, statement := range policy.Statements {
+		s.addStatement(statement.Effect, []ActionSet{statement.Actions, statement.NotActions}, []ResourceSet{statement.Resources, statement.NotResources}, statement.Conditions)
+		s.PublicPrincipal = s.PublicPrincipal || statement.Principal.AWS.Contains("*")
+	}
+	return s.result()
+}
+
+// RedactOptions - options of Redact.
+type RedactOptions struct {
+	// Salt of the pseudonyms. The same names have the same pseudonyms with
+	// the same salt, which should be random, e.g. one per support bundle,
+	// and not be part of the bundle: pseudonyms cannot be reversed
+	// without it.
+	Salt []byte
+}
+
+// Pseudonym - returns the pseudonym of name, e.g. of a bucket name. It is
+// a valid bucket name.
+func (opts RedactOptions) Pseudonym(name string) string {
+	return pseudonym(opts.Salt, name)
+}
+
+// pseudonym - returns the first 16 hexadecimal digits of the HMAC-SHA256
+// of name keyed with salt.
+func pseudonym(salt []byte, name string) string {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(name))
+	return hex.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// redactPattern - replaces the literal parts of pattern with their
+// pseudonyms, keeping the wildcards '*' and '?' and the policy variables
+// such as "${aws:username}".
+func (opts RedactOptions) redactPattern(pattern string) string {
+	var sb strings.Builder
+	for pattern != "" {
+		var token string
+		i := strings.IndexAny(pattern, "*?$")
+		switch {
+		case i < 0:
+			sb.WriteString(opts.Pseudonym(pattern))
+			return sb.String()
+		case i > 0:
+			token, pattern = pattern[:i], pattern[i:]
+			sb.WriteString(opts.Pseudonym(token))
+			continue
+		case strings.HasPrefix(pattern, "${"):
+			if end := strings.IndexByte(pattern, '}'); end > 0 {
+				token = pattern[:end+1]
+				break
+			}
+			token = pattern[:1]
+		default:
+			token = pattern[:1]
+		}
+		sb.WriteString(token)
+		pattern = pattern[len(token):]
+	}
+	return sb.String()
+}
+
+// redactPath - redacts each segment of the '/' separated path.
+func (opts RedactOptions) redactPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = opts.redactPattern(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// redactResources - redacts the bucket names and object key patterns of
+// S3 resources, the key names of KMS resources and the account and name
+// of access points.
+func (opts RedactOptions) redactResources(resources ResourceSet) ResourceSet {
+	if resources == nil {
+		return nil
+	}
+	redacted := NewResourceSet()
+	for resource := range resources {
+		switch resource.Type {
+		case ResourceARNS3:
+			resource.Pattern = opts.redactPath(resource.Pattern)
+		case ResourceARNKMS:
+			resource.Pattern = opts.redactPattern(resource.Pattern)
+		case ResourceARNAccessPoint:
+			fields := strings.SplitN(resource.Pattern, ":", 6)
+			fields[4] = opts.redactPattern(fields[4])
+			fields[5] = "accesspoint/" + opts.redactPath(strings.TrimPrefix(fields[5], "accesspoint/"))
+			resource.Pattern = strings.Join(fields, ":")
+		}
+		redacted.Add(resource)
+	}
+	return redacted
+}
+
+// redactedConditionKeys - keys whose values are object names or prefixes.
+var redactedConditionKeys = []condition.KeyName{
+	condition.S3Prefix,
+	condition.S3XAmzCopySource,
+}
+
+// redactConditions - redacts the values of the conditions on
+// redactedConditionKeys as paths, other conditions are kept.
+func (opts RedactOptions) redactConditions(conditions condition.Functions) condition.Functions {
+	var redact bool
+	doc := map[string]map[string][]string{}
+	for _, c := range conditions.Conditions() {
+		values := c.Values
+		for _, name := range redactedConditionKeys {
+			if c.Key.Is(name) {
+				redact = true
+				values = make([]string, len(c.Values))
+				for i, v := range c.Values {
+					values[i] = opts.redactPath(v)
+				}
+			}
+		}
+		if doc[c.Operator] == nil {
+			doc[c.Operator] = map[string][]string{}
+		}
+		doc[c.Operator][c.Key.String()] = values
+	}
+	if !redact {
+		return conditions.Clone()
+	}
+
+	// The redacted values are valid values of the same operators and keys.
+	var redacted condition.Functions
+	data, err := json.Marshal(doc)
+	if err == nil {
+		err = json.Unmarshal(data, &redacted)
+	}
+	if err != nil {
+		return conditions.Clone()
+	}
+	return redacted
+}
+
+// redactPrincipal - redacts the account and name of principal ARNs, such as
+// "arn:aws:iam::123456789012:user/alice", and account IDs. The resource
+// type, e.g. "user", and the wildcard principal "*" are kept.
+func (opts RedactOptions) redactPrincipal(principal string) string {
+	fields := strings.SplitN(principal, ":", 6)
+	if len(fields) != 6 || fields[0] != "arn" {
+		return opts.redactPattern(principal)
+	}
+	fields[4] = opts.redactPattern(fields[4])
+	if resourceType, name, ok := strings.Cut(fields[5], "/"); ok {
+		fields[5] = resourceType + "/" + opts.redactPath(name)
+	}
+	return strings.Join(fields, ":")
+}
+
+// Redact - returns a copy of the policy with stable pseudonyms in place of
+// its bucket names, object key patterns and KMS key names, including the
+// values of the s3:prefix and s3:x-amz-copy-source conditions. Wildcards,
+// policy variables, path separators and the structure of the policy are
+// kept, so that the redacted policy is valid and evaluates requests for
+// the pseudonyms as the policy does for the names. The policy ID and the
+// statement SIDs are removed.
+func (iamp Policy) Redact(opts RedactOptions) Policy {
+	redacted := Policy{Version: iamp.Version}
+	for _, statement := range iamp.Statements {
+		statement = statement.Clone()
+		statement.SID = ""
+		statement.Resources = opts.redactResources(statement.Resources)
+		statement.Conditions = opts.redactConditions(statement.Conditions)
+		redacted.Statements = append(redacted.Statements, statement)
+	}
+	return redacted
+}
+
+// Redact - returns a copy of the bucket policy redacted as Policy.Redact
+// does, with the principals redacted as well. The redacted bucket policy
+// is valid for the pseudonym of the bucket name.
+func (policy BucketPolicy) Redact(opts RedactOptions) BucketPolicy {
+	redacted := BucketPolicy{Version: policy.Version}
+	for _, statement := range policy.Statements {
+		statement = statement.Clone()
+		statement.SID = ""
+		if statement.Principal.AWS != nil {
+			principals := set.NewStringSet()
+			for principal := range statement.Principal.AWS {
+				principals.Add(opts.redactPrincipal(principal))
+			}
+			statement.Principal.AWS = principals
+		}
+		statement.Resources = opts.redactResources(statement.Resources)
+		statement.NotResources = opts.redactResources(statement.NotResources)
+		statement.Conditions = opts.redactConditions(statement.Conditions)
+		redacted.Statements = append(redacted.Statements, statement)
+	}
+	return redacted
+}
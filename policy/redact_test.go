@@ -0,0 +1,159 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const redactPolicy = `{
+  "Version": "2012-10-17",
+  "Id": "project-phoenix",
+  "Statement": [
+    {"Sid": "Home", "Effect": "Allow", "Action": ["s3:GetObject", "s3:PutObject"], "Resource": "arn:aws:s3:::phoenix-data/home/${aws:username}/*"},
+    {"Sid": "List", "Effect": "Allow", "Action": "s3:ListBucket", "Resource": "arn:aws:s3:::phoenix-data", "Condition": {"StringLike": {"s3:prefix": ["home/${aws:username}/*", "shared/?"]}}},
+    {"Effect": "Deny", "NotAction": "s3:GetObject", "Resource": "arn:aws:s3:::phoenix-*/archive/*", "Condition": {"IpAddress": {"aws:SourceIp": "10.0.0.0/8"}}},
+    {"Effect": "Allow", "Action": "kms:*", "Resource": "arn:minio:kms:::phoenix-key"},
+    {"Effect": "Allow", "Action": "admin:ServerInfo"}
+  ]
+}`
+
+func TestPolicyRedact(t *testing.T) {
+	p := mustParsePolicy(t, redactPolicy)
+	opts := RedactOptions{Salt: []byte("bundle-1")}
+
+	redacted := p.Redact(opts)
+	if err := redacted.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := json.Marshal(redacted)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, name := range []string{"phoenix", "project", "home", "shared", "archive", "Sid"} {
+		if strings.Contains(string(data), name) {
+			t.Fatalf("expected %v to be redacted, got: %s", name, data)
+		}
+	}
+	for _, kept := range []string{"${aws:username}/*", "10.0.0.0/8", "s3:ListBucket", `/?"`} {
+		if !strings.Contains(string(data), kept) {
+			t.Fatalf("expected %v to be kept, got: %s", kept, data)
+		}
+	}
+
+	// Pseudonyms are consistent with the same salt.
+	if again := p.Redact(opts); !redacted.Equals(again) {
+		t.Fatalf("expected: %v, got: %v", redacted, again)
+	}
+	if other := p.Redact(RedactOptions{Salt: []byte("bundle-2")}); redacted.Equals(other) {
+		t.Fatalf("expected other pseudonyms with another salt, got: %v", other)
+	}
+
+	// Requests for the pseudonyms evaluate as requests for the names.
+	bucket, home := opts.Pseudonym("phoenix-data"), opts.Pseudonym("home")
+	testCases := []struct {
+		args     Args
+		expected bool
+	}{
+		{Args{Action: GetObjectAction, BucketName: bucket, ObjectName: home + "/alice/report", ConditionValues: map[string][]string{"username": {"alice"}}}, true},
+		{Args{Action: GetObjectAction, BucketName: bucket, ObjectName: home + "/bob/report", ConditionValues: map[string][]string{"username": {"alice"}}}, false},
+		{Args{Action: GetObjectAction, BucketName: "phoenix-data", ObjectName: "home/alice/report", ConditionValues: map[string][]string{"username": {"alice"}}}, false},
+		{Args{Action: ListBucketAction, BucketName: bucket, ConditionValues: map[string][]string{"prefix": {home + "/alice/"}, "username": {"alice"}}}, true},
+		{Args{Action: ListBucketAction, BucketName: bucket, ConditionValues: map[string][]string{"prefix": {"home/alice/"}, "username": {"alice"}}}, false},
+	}
+	for i, testCase := range testCases {
+		if result := redacted.IsAllowed(testCase.args); result != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+	}
+}
+
+func TestBucketPolicyRedact(t *testing.T) {
+	bp, err := ParseBucketPolicyConfig(strings.NewReader(`{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::phoenix-data/public/*"},
+    {"Effect": "Allow", "Principal": {"AWS": ["arn:aws:iam::123456789012:user/alice", "210987654321"]}, "Action": "s3:*", "NotResource": "arn:aws:s3:::phoenix-data/secret/*"}
+  ]
+}`), "phoenix-data")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	opts := RedactOptions{Salt: []byte("bundle-1")}
+
+	redacted := bp.Redact(opts)
+	if err := redacted.Validate(opts.Pseudonym("phoenix-data")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := json.Marshal(redacted)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, name := range []string{"phoenix", "public", "secret", "alice", "123456789012", "210987654321"} {
+		if strings.Contains(string(data), name) {
+			t.Fatalf("expected %v to be redacted, got: %s", name, data)
+		}
+	}
+	expected := "arn:aws:iam::" + opts.Pseudonym("123456789012") + ":user/" + opts.Pseudonym("alice")
+	if !redacted.Statements[1].Principal.AWS.Contains(expected) || !redacted.Statements[0].Principal.AWS.Contains("*") {
+		t.Fatalf("expected: %v, got: %v", expected, redacted.Statements[1].Principal)
+	}
+	if again := bp.Redact(opts); !redacted.Equals(again) {
+		t.Fatalf("expected: %v, got: %v", redacted, again)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	p := mustParsePolicy(t, redactPolicy)
+	summary := p.Summarize()
+	expected := PolicySummary{
+		Statements:         5,
+		Allow:              4,
+		Deny:               1,
+		ActionsByFamily:    map[string]int{"s3": 4, "kms": 1, "admin": 1},
+		Buckets:            summary.Buckets,
+		ConditionOperators: []string{"IpAddress", "StringLike"},
+		Wildcards:          true,
+	}
+	if !reflect.DeepEqual(summary, expected) {
+		t.Fatalf("expected: %+v, got: %+v", expected, summary)
+	}
+	// "phoenix-data" and "phoenix-*".
+	if len(summary.Buckets) != 2 || strings.Contains(strings.Join(summary.Buckets, ","), "phoenix") {
+		t.Fatalf("expected: 2 fingerprints, got: %v", summary.Buckets)
+	}
+	// Fingerprints are consistent and summaries of redacted policies
+	// describe the same policy.
+	if again := p.Summarize(); !reflect.DeepEqual(summary, again) {
+		t.Fatalf("expected: %+v, got: %+v", summary, again)
+	}
+	redacted := p.Redact(RedactOptions{Salt: []byte("bundle-1")}).Summarize()
+	if redacted.Statements != 5 || !reflect.DeepEqual(redacted.ActionsByFamily, summary.ActionsByFamily) || len(redacted.Buckets) != 2 {
+		t.Fatalf("expected: %+v, got: %+v", summary, redacted)
+	}
+
+	bp := BucketPolicy{Statements: []BPStatement{
+		NewBPStatement("", Allow, NewPrincipal("*"), NewActionSet(GetObjectAction), NewResourceSet(NewResource("examplebucket/*")), nil),
+	}}
+	if summary := bp.Summarize(); !summary.PublicPrincipal || !summary.Wildcards || summary.ActionsByFamily["s3"] != 1 {
+		t.Fatalf("expected: public principal, got: %+v", summary)
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+// ReduceOptions - options of ReduceFromUsageWithOptions.
+type ReduceOptions struct {
+	// PrefixDepth is the maximum number of '/' separated segments of the
+	// prefixes granting objects: an object is granted by the prefix of at
+	// most PrefixDepth segments of its name, excluding the object itself,
+	// e.g. "mybucket/photos/*" for "mybucket/photos/2024/a.jpg" and depth
+	// 1, provided the statement allowing it grants the whole prefix. With
+	// the default of 0, objects are granted by bucket, e.g. "mybucket/*".
+	PrefixDepth int
+}
+
+// ReduceReport - describes the changes of ReduceFromUsageWithOptions, by
+// index of statement of the current policy or of observed request.
+type ReduceReport struct {
+	// Unused are the 'Allow' statements which allowed no observed
+	// request, they are dropped.
+	Unused []int
+
+	// Narrowed are the 'Allow' statements without conditions which are
+	// replaced by statements granting the observed requests they allowed.
+	Narrowed []int
+
+	// Kept are the 'Allow' statements with conditions which allowed an
+	// observed request, they are kept as is.
+	Kept []int
+
+	// NotAllowed are the observed requests the current policy does not
+	// allow, they are not granted either.
+	NotAllowed []int
+}
+
+// ReduceFromUsage - returns the smallest policy allowing the observed
+// requests allowed by current, see ReduceFromUsageWithOptions.
+func ReduceFromUsage(current Policy, observed []Args) Policy {
+	reduced, _ := ReduceFromUsageWithOptions(current, observed, ReduceOptions{})
+	return reduced
+}
+
+// ReduceFromUsageWithOptions - returns a policy allowing the observed
+// requests allowed by current, e.g. an access log, and no request
+// current does not allow. The 'Deny' statements of current are kept.
+// 'Allow' statements with conditions are kept as is if they allowed an
+// observed request, the other ones are replaced by statements granting
+// the observed actions on the observed resources, objects being
+// generalized to prefixes as per opts. 'Allow' statements which allowed no
+// observed request are dropped.
+func ReduceFromUsageWithOptions(current Policy, observed []Args, opts ReduceOptions) (Policy, ReduceReport) {
+	var report ReduceReport
+
+	kept := make(map[int]bool)
+	narrowed := make(map[int]bool)
+	// Granted actions by namespace and resource.
+	grants := make(map[[2]string]ActionSet)
+	grantResources := make(map[[2]string]ResourceSet)
+	for i, args := range observed {
+		args.NormalizeConditions()
+		resource := requestResource(args)
+		reason := current.evaluateWithReason(args, resource)
+		switch {
+		case reason.Verdict != VerdictAllow:
+			report.NotAllowed = append(report.NotAllowed, i)
+			continue
+		case reason.Statement < 0:
+			// Allowed by Args.IsOwner or Args.DenyOnly.
+			continue
+		}
+
+		statement := current.Statements[reason.Statement]
+		if len(statement.Conditions) > 0 {
+			kept[reason.Statement] = true
+			continue
+		}
+		narrowed[reason.Statement] = true
+
+		resources := statement.reducedResources(args, resource, opts.PrefixDepth)
+		key := [2]string{args.Action.namespace(), resources.String()}
+		if grants[key] == nil {
+			grants[key] = NewActionSet()
+			grantResources[key] = resources
+		}
+		grants[key].Add(args.Action)
+	}
+
+	reduced := Policy{ID: current.ID, Version: current.Version}
+	for i, statement := range current.Statements {
+		switch {
+		case statement.Effect == Deny:
+			reduced.Statements = append(reduced.Statements, statement.Clone())
+		case kept[i]:
+			reduced.Statements = append(reduced.Statements, statement.Clone())
+			report.Kept = append(report.Kept, i)
+		case narrowed[i]:
+			report.Narrowed = append(report.Narrowed, i)
+		default:
+			report.Unused = append(report.Unused, i)
+		}
+	}
+
+	// Resources granted the same actions are granted by one statement.
+	keys := make([][2]string, 0, len(grants))
+	for key := range grants {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	byActions := make(map[string]int)
+	for _, key := range keys {
+		actions := grants[key]
+		actionsKey := key[0] + " " + actions.String()
+		if j, ok := byActions[actionsKey]; ok {
+			for r := range grantResources[key] {
+				reduced.Statements[j].Resources.Add(r)
+			}
+			continue
+		}
+		byActions[actionsKey] = len(reduced.Statements)
+		reduced.Statements = append(reduced.Statements, NewStatement("", Allow, actions, grantResources[key].Clone(), condition.NewFunctions()))
+	}
+	return reduced, report
+}
+
+// reducedResources - returns the resources granting the request of args,
+// allowed by this statement without conditions, and no resource this
+// statement does not grant. resource must be the value of
+// requestResource(args).
+func (statement Statement) reducedResources(args Args, resource string, depth int) ResourceSet {
+	objectName := strings.TrimPrefix(decodeObjectName(args.ObjectName, args.ObjectNameEncoded), "/")
+	switch {
+	case args.Action.ignoresResources():
+		return NewResourceSet()
+	case args.Action.IsAccountLevel():
+		matched := NewResourceSet()
+		for r := range statement.Resources {
+			if r.matchesAccount() {
+				matched.Add(r)
+			}
+		}
+		return matched
+	case args.Action.namespace() == "kms", args.BucketName == "", strings.ContainsAny(args.BucketName+objectName, "*?$"):
+		// The matching resources of the statement, resources cannot be
+		// built for KMS requests, requests without bucket or whose names
+		// would be patterns.
+		matched := NewResourceSet()
+		for _, r := range statement.Resources.toSortedSlice() {
+			if r.Match(resource, args.ConditionValues) {
+				matched.Add(r)
+			}
+		}
+		if len(matched) == 0 {
+			// KMS statements without resources, or KMS requests without
+			// resource, are allowed irrespective of resources.
+			return statement.Resources.Clone()
+		}
+		return matched
+	}
+
+	if objectName == "" {
+		return NewResourceSet(NewResource(args.BucketName))
+	}
+
+	// From the most general prefix to the object itself.
+	segments := strings.Split(objectName, "/")
+	for n := min(depth, len(segments)-1); n >= 0 && n < len(segments); n++ {
+		prefix := strings.Join(append([]string{args.BucketName}, segments[:n]...), "/") + "/"
+		for r := range statement.Resources {
+			if r.grantsPrefix(prefix) {
+				return NewResourceSet(NewResource(prefix + "*"))
+			}
+		}
+	}
+	return NewResourceSet(NewResource(args.BucketName + "/" + objectName))
+}
+
+// grantsPrefix - returns whether this resource matches all objects whose
+// name starts with prefix, i.e. is a literal prefix of prefix followed by
+// '*'.
+func (r Resource) grantsPrefix(prefix string) bool {
+	if r.Type != ResourceARNS3 {
+		return false
+	}
+	literal, ok := strings.CutSuffix(r.Pattern, "*")
+	return ok && !strings.ContainsAny(literal, "*?$") && strings.HasPrefix(prefix, literal)
+}
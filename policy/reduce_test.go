@@ -0,0 +1,140 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+const reduceFixturePolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Action": "s3:*", "Resource": ["arn:aws:s3:::mybucket", "arn:aws:s3:::mybucket/*"]},
+    {"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::shared/*", "Condition": {"IpAddress": {"aws:SourceIp": "10.0.0.0/8"}}},
+    {"Effect": "Allow", "Action": "s3:PutObject", "Resource": "arn:aws:s3:::unused/*"},
+    {"Effect": "Deny", "Action": "s3:DeleteObject", "Resource": "arn:aws:s3:::mybucket/locked/*"},
+    {"Effect": "Allow", "Action": "s3:GetObject", "Resource": ["arn:aws:s3:::exact/report.csv", "arn:aws:s3:::exact/logs/*"]},
+    {"Effect": "Allow", "Action": ["admin:ServerInfo", "admin:ListUsers"]}
+  ]
+}`
+
+func reduceFixtureObserved() []Args {
+	sourceIP := func(ip string) map[string][]string {
+		return map[string][]string{"SourceIp": {ip}}
+	}
+	return []Args{
+		{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "photos/2024/a.jpg"},
+		{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "photos/2024/b.jpg"},
+		{Action: ListBucketAction, BucketName: "mybucket"},
+		{Action: GetObjectAction, BucketName: "shared", ObjectName: "x.txt", ConditionValues: sourceIP("10.1.2.3")},
+		{Action: GetObjectAction, BucketName: "shared", ObjectName: "y.txt", ConditionValues: sourceIP("192.168.0.1")},
+		{Action: DeleteObjectAction, BucketName: "mybucket", ObjectName: "locked/x"},
+		{Action: GetObjectAction, BucketName: "exact", ObjectName: "report.csv"},
+		{Action: GetObjectAction, BucketName: "exact", ObjectName: "logs/2024/01/a.log"},
+		{Action: ServerInfoAdminAction},
+	}
+}
+
+func TestReduceFromUsage(t *testing.T) {
+	current := mustParsePolicy(t, reduceFixturePolicy)
+	observed := reduceFixtureObserved()
+
+	grant := func(actions ActionSet, resources ...Resource) Statement {
+		return NewStatement("", Allow, actions, NewResourceSet(resources...), condition.NewFunctions())
+	}
+	testCases := []struct {
+		depth  int
+		grants []Statement
+	}{
+		{0, []Statement{
+			grant(NewActionSet(GetObjectAction), NewResource("exact/logs/*"), NewResource("exact/report.csv")),
+			grant(NewActionSet(GetObjectAction, PutObjectAction), NewResource("mybucket/*")),
+			grant(NewActionSet(ListBucketAction), NewResource("mybucket")),
+		}},
+		{1, []Statement{
+			grant(NewActionSet(GetObjectAction), NewResource("exact/logs/*"), NewResource("exact/report.csv")),
+			grant(NewActionSet(GetObjectAction, PutObjectAction), NewResource("mybucket/photos/*")),
+			grant(NewActionSet(ListBucketAction), NewResource("mybucket")),
+		}},
+		{5, []Statement{
+			grant(NewActionSet(GetObjectAction), NewResource("exact/logs/2024/01/*"), NewResource("exact/report.csv")),
+			grant(NewActionSet(GetObjectAction, PutObjectAction), NewResource("mybucket/photos/2024/*")),
+			grant(NewActionSet(ListBucketAction), NewResource("mybucket")),
+		}},
+	}
+
+	for i, testCase := range testCases {
+		reduced, report := ReduceFromUsageWithOptions(current, observed, ReduceOptions{PrefixDepth: testCase.depth})
+
+		expectedReport := ReduceReport{Unused: []int{2}, Narrowed: []int{0, 4, 5}, Kept: []int{1}, NotAllowed: []int{4, 5}}
+		if !reflect.DeepEqual(report, expectedReport) {
+			t.Fatalf("case %v: expected: %+v, got: %+v", i+1, expectedReport, report)
+		}
+
+		// Deny and kept statements, then the grants sorted by namespace.
+		expected := Policy{Version: DefaultVersion, Statements: append([]Statement{
+			current.Statements[1],
+			current.Statements[3],
+			grant(NewActionSet(ServerInfoAdminAction)),
+		}, testCase.grants...)}
+		if !expected.Equals(reduced) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, expected, reduced)
+		}
+		if err := reduced.Validate(); err != nil {
+			t.Fatalf("case %v: unexpected error: %v", i+1, err)
+		}
+
+		// The reduced policy allows the observed requests current allows.
+		for j, args := range observed {
+			if current.IsAllowed(args) != reduced.IsAllowed(args) {
+				t.Fatalf("case %v: request %v: expected: %v, got: %v", i+1, j+1, current.IsAllowed(args), reduced.IsAllowed(args))
+			}
+		}
+	}
+}
+
+// TestReduceFromUsageSubset checks a sample of requests: the reduced policy
+// allows no request the current policy does not allow.
+func TestReduceFromUsageSubset(t *testing.T) {
+	current := mustParsePolicy(t, reduceFixturePolicy)
+	observed := reduceFixtureObserved()
+
+	actions := []Action{GetObjectAction, PutObjectAction, DeleteObjectAction, ListBucketAction, ServerInfoAdminAction, ListUsersAdminAction}
+	buckets := []string{"", "mybucket", "shared", "unused", "exact", "other"}
+	objects := []string{"", "photos/2024/a.jpg", "photos/x", "photos", "locked/x", "report.csv", "logs/2024/a.log", "a*b"}
+	ips := []string{"10.1.2.3", "192.168.0.1"}
+
+	for _, depth := range []int{0, 1, 2, 10} {
+		reduced, _ := ReduceFromUsageWithOptions(current, observed, ReduceOptions{PrefixDepth: depth})
+		for _, action := range actions {
+			for _, bucket := range buckets {
+				for _, object := range objects {
+					for _, ip := range ips {
+						args := Args{Action: action, BucketName: bucket, ObjectName: object, ConditionValues: map[string][]string{"SourceIp": {ip}}}
+						if reduced.IsAllowed(args) && !current.IsAllowed(args) {
+							t.Fatalf("depth %v: unexpectedly allowed: %+v", depth, args)
+						}
+					}
+				}
+			}
+		}
+	}
+}
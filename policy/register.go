@@ -0,0 +1,139 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+// ActionOptions - options of actions registered by RegisterAction.
+type ActionOptions struct {
+	// Namespace - service of the action, e.g. "foo" for "foo:Bar", it must
+	// be the prefix of the action if set. Actions of the "admin", "sts" and
+	// "kms" services follow the rules of the built-in actions of these
+	// services, actions of other services apply to S3 resources.
+	Namespace string
+
+	// ObjectAction - the action applies to objects, statements granting
+	// it need an object resource.
+	ObjectAction bool
+
+	// ConditionKeys - condition keys supported by the action in addition
+	// to the common keys, condition.CommonKeys for S3 actions, see
+	// condition.AllSupportedAdminKeys and condition.CommonIdentityKeys for
+	// admin and STS actions, and condition.RegisterKey for new keys.
+	ConditionKeys []condition.KeyName
+
+	// Description - human readable description of the action, see
+	// ActionMetadata.
+	Description string
+}
+
+var (
+	registerMu sync.Mutex
+
+	// actionsFrozen is set once a policy was parsed or validated, actions
+	// must not be registered afterwards.
+	actionsFrozen atomic.Bool
+
+	// registeredActions - actions registered by RegisterAction, which are
+	// not built-in actions.
+	registeredActions = map[Action]struct{}{}
+)
+
+// freezeActions - prevents further registration of actions.
+func freezeActions() {
+	if !actionsFrozen.Load() {
+		actionsFrozen.Store(true)
+	}
+}
+
+// RegisterAction - registers an action of downstream products, e.g.
+// "foo:Bar", so that policies granting it are valid. The action is then
+// validated, matched and looked up in IAMActionConditionKeyMap as the
+// built-in actions are.
+//
+// RegisterAction must be called before any policy is parsed or validated,
+// typically from an init function. It panics if it is called afterwards,
+// if the action is already supported or if it is not of the form
+// "service:Name".
+func RegisterAction(a Action, opts ActionOptions) {
+	registerMu.Lock()
+	defer registerMu.Unlock()
+
+	if actionsFrozen.Load() {
+		panic(fmt.Sprintf("policy: RegisterAction %v called after policies were parsed", a))
+	}
+	service, name, ok := strings.Cut(string(a), ":")
+	if !ok || !isActionToken(service) || !isActionToken(name) || strings.ContainsAny(string(a), "*?") {
+		panic(fmt.Sprintf("policy: RegisterAction called with invalid action %v", a))
+	}
+	if opts.Namespace != "" && opts.Namespace != service {
+		panic(fmt.Sprintf("policy: RegisterAction called with namespace %v for action %v", opts.Namespace, a))
+	}
+
+	keys := condition.NewKeySet()
+	for _, keyName := range opts.ConditionKeys {
+		keys.Add(keyName.ToKey())
+	}
+
+	doc := actionDoc{description: opts.Description, resourceType: ActionResourceNone, extension: true}
+	switch service {
+	case "admin":
+		if AdminAction(a).IsValid() {
+			panic(fmt.Sprintf("policy: RegisterAction called twice for action %v", a))
+		}
+		for _, keyName := range condition.AllSupportedAdminKeys {
+			keys.Add(keyName.ToKey())
+		}
+		supportedAdminActions[AdminAction(a)] = struct{}{}
+		adminActionConditionKeyMap[a] = keys
+	case "sts":
+		if STSAction(a).IsValid() {
+			panic(fmt.Sprintf("policy: RegisterAction called twice for action %v", a))
+		}
+		for _, keyName := range condition.CommonIdentityKeys {
+			keys.Add(keyName.ToKey())
+		}
+		supportedSTSActions[STSAction(a)] = struct{}{}
+		stsActionConditionKeyMap[a] = keys
+	case "kms":
+		if KMSAction(a).IsValid() {
+			panic(fmt.Sprintf("policy: RegisterAction called twice for action %v", a))
+		}
+		supportedKMSActions[KMSAction(a)] = struct{}{}
+	default:
+		if _, ok := supportedActions[a]; ok {
+			panic(fmt.Sprintf("policy: RegisterAction called twice for action %v", a))
+		}
+		supportedActions[a] = struct{}{}
+		doc.resourceType = ActionResourceBucket
+		if opts.ObjectAction {
+			supportedObjectActions[a] = struct{}{}
+			doc.resourceType = ActionResourceObject
+		}
+		IAMActionConditionKeyMap[a] = keys
+	}
+	actionDocs[a] = doc
+	registeredActions[a] = struct{}{}
+}
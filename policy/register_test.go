@@ -0,0 +1,139 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+const (
+	fooBarAction   Action            = "foo:Bar"
+	fooListAction  Action            = "foo:List"
+	adminFooAction Action            = "admin:FooBar"
+	fooTierKey     condition.KeyName = "foo:Tier"
+	fooUnknownKey                    = "foo:Unknown"
+)
+
+// Registration must happen before any policy is parsed, hence in init.
+func init() {
+	condition.RegisterKey(fooTierKey, condition.KeyTypeString)
+	RegisterAction(fooBarAction, ActionOptions{Namespace: "foo", ObjectAction: true, ConditionKeys: []condition.KeyName{fooTierKey}, Description: "Bar an object"})
+	RegisterAction(fooListAction, ActionOptions{Description: "List foo"})
+	RegisterAction(adminFooAction, ActionOptions{Description: "Foo bar"})
+}
+
+func TestRegisterAction(t *testing.T) {
+	for _, action := range []Action{fooBarAction, fooListAction, "foo:*"} {
+		if !action.IsValid() {
+			t.Fatalf("%v: expected valid action", action)
+		}
+	}
+	if !fooBarAction.IsObjectAction() || fooListAction.IsObjectAction() {
+		t.Fatalf("unexpected object actions")
+	}
+	if !IAMActionConditionKeyMap.Lookup(fooBarAction).Match(fooTierKey.ToKey()) {
+		t.Fatalf("expected %v to support %v", fooBarAction, fooTierKey)
+	}
+	if IAMActionConditionKeyMap.Lookup(GetObjectAction).Match(fooTierKey.ToKey()) {
+		t.Fatalf("expected %v not to support %v", GetObjectAction, fooTierKey)
+	}
+	if !AdminAction(adminFooAction).IsValid() {
+		t.Fatalf("expected valid admin action")
+	}
+
+	testCases := []struct {
+		policy      string
+		expectedErr bool
+	}{
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "foo:Bar", "Resource": "arn:aws:s3:::mybucket/*", "Condition": {"StringEquals": {"foo:Tier": "gold"}}}]}`, false},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "foo:*", "Resource": "arn:aws:s3:::mybucket"}]}`, false},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "admin:FooBar"}]}`, false},
+		// Object actions only need an object resource on demand, see below.
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "foo:Bar", "Resource": "arn:aws:s3:::mybucket"}]}`, false},
+		// Registered keys are only supported by the actions registered with them.
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "foo:List", "Resource": "arn:aws:s3:::mybucket", "Condition": {"StringEquals": {"foo:Tier": "gold"}}}]}`, true},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*", "Condition": {"StringEquals": {"foo:Tier": "gold"}}}]}`, true},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "foo:Bar", "Resource": "arn:aws:s3:::mybucket/*", "Condition": {"StringEquals": {"` + fooUnknownKey + `": "gold"}}}]}`, true},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "foo:Baz", "Resource": "arn:aws:s3:::mybucket/*"}]}`, true},
+	}
+	for i, testCase := range testCases {
+		_, err := ParseConfig(strings.NewReader(testCase.policy))
+		if expectErr := err != nil; expectErr != testCase.expectedErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+
+	if _, err := ParseConfigWithOptions(strings.NewReader(testCases[3].policy), ValidationOptions{RejectUnsupportedResources: true}); err == nil {
+		t.Fatalf("expected %v to need an object resource", fooBarAction)
+	}
+
+	p, err := ParseConfig(strings.NewReader(testCases[0].policy))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	argsCases := []struct {
+		args     Args
+		expected bool
+	}{
+		{Args{Action: fooBarAction, BucketName: "mybucket", ObjectName: "myobject", ConditionValues: map[string][]string{"foo:Tier": {"gold"}}}, true},
+		{Args{Action: fooBarAction, BucketName: "mybucket", ObjectName: "myobject", ConditionValues: map[string][]string{"foo:Tier": {"silver"}}}, false},
+		{Args{Action: fooBarAction, BucketName: "yourbucket", ObjectName: "myobject", ConditionValues: map[string][]string{"foo:Tier": {"gold"}}}, false},
+		{Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject", ConditionValues: map[string][]string{"foo:Tier": {"gold"}}}, false},
+	}
+	for i, testCase := range argsCases {
+		if got := p.IsAllowed(testCase.args); got != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestRegisterActionPanics(t *testing.T) {
+	// Policies were parsed already, see TestRegisterAction.
+	if _, err := ParseConfig(strings.NewReader(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "foo:Bar", "Resource": "arn:aws:s3:::mybucket/*"}]}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []func(){
+		func() { RegisterAction("foo:Baz", ActionOptions{}) },
+		func() { condition.RegisterKey("foo:Other", condition.KeyTypeString) },
+	}
+	for i, testCase := range testCases {
+		func() {
+			defer func() {
+				r := recover()
+				if r == nil {
+					t.Fatalf("case %v: expected panic", i+1)
+				}
+				if !strings.Contains(r.(string), "after policies were parsed") {
+					t.Fatalf("case %v: unexpected panic: %v", i+1, r)
+				}
+			}()
+			testCase()
+		}()
+	}
+
+	var unsupported ErrUnsupportedAction
+	err := NewStatement("", Allow, NewActionSet("foo:Baz"), NewResourceSet(NewResource("mybucket/*")), nil).Validate()
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("expected unsupported action, got: %v", err)
+	}
+}
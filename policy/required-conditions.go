@@ -0,0 +1,148 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+// RequirementCategory - whether the client can satisfy a requirement.
+type RequirementCategory string
+
+const (
+	// RequirementRequest - the condition key is set from the request, e.g.
+	// from a header, which the client or signer controls.
+	RequirementRequest RequirementCategory = "request"
+
+	// RequirementContext - the condition key is set from the context of
+	// the request, e.g. the source IP, the time or the identity, which the
+	// client does not control.
+	RequirementContext RequirementCategory = "context"
+)
+
+// requestKeys - condition keys of the requests, all other keys are
+// context keys.
+var requestKeys = map[condition.KeyName]struct{}{
+	condition.S3XAmzCopySource:                            {},
+	condition.S3XAmzCopySourceVersionID:                   {},
+	condition.S3XAmzServerSideEncryption:                  {},
+	condition.S3XAmzServerSideEncryptionCustomerAlgorithm: {},
+	condition.S3XAmzMetadataDirective:                     {},
+	condition.S3XAmzContentSha256:                         {},
+	condition.S3XAmzStorageClass:                          {},
+	condition.S3XAmzServerSideEncryptionAwsKmsKeyID:       {},
+	condition.S3XAmzACL:                                   {},
+	condition.S3XAmzObjectOwnership:                       {},
+	condition.S3LocationConstraint:                        {},
+	condition.S3Prefix:                                    {},
+	condition.S3Delimiter:                                 {},
+	condition.S3VersionID:                                 {},
+	condition.S3MaxKeys:                                   {},
+	condition.S3ObjectLockRemainingRetentionDays:          {},
+	condition.S3ObjectLockMode:                            {},
+	condition.S3ObjectLockRetainUntilDate:                 {},
+	condition.S3ObjectLockLegalHold:                       {},
+	condition.AWSReferer:                                  {},
+	condition.AWSUserAgent:                                {},
+	condition.RequestObjectTagKeys:                        {},
+	condition.RequestObjectTag:                            {},
+}
+
+// Requirement - condition of a statement applying to a request, see
+// Policy.RequiredConditionsFor.
+type Requirement struct {
+	Key      string   // Condition key, e.g. "s3:x-amz-server-side-encryption"
+	Operator string   // Condition operator, e.g. "StringEquals"
+	Values   []string // Values of the condition, sorted
+
+	// Effect of the statement: the conditions of an 'Allow' statement
+	// must all be satisfied for it to allow the request, a request
+	// satisfying all conditions of a 'Deny' statement is denied.
+	Effect Effect
+
+	// Statement is the index of the statement in the policy.
+	Statement int
+
+	Category RequirementCategory
+}
+
+// RequiredConditionsFor - returns the conditions which decide whether a
+// request for action on bucket and object is allowed, for example to know
+// the headers a presigned URL must include. The request is allowed if all
+// requirements of one of the 'Allow' statements are satisfied and not all
+// requirements of any 'Deny' statement are. Requirements of 'Allow'
+// statements are omitted if an 'Allow' statement without conditions
+// applies, since the request is then allowed whatever its conditions.
+//
+// The analysis is conservative: requirements are listed per statement,
+// they are not solved, and resources with policy variables are assumed not
+// to match. Requirements are sorted by statement, key and operator.
+func (iamp Policy) RequiredConditionsFor(action Action, bucket, object string) []Requirement {
+	args := Args{Action: action, BucketName: bucket, ObjectName: object}
+	resource := requestResource(args)
+
+	var unconditional bool
+	for _, statement := range iamp.Statements {
+		if statement.Effect == Allow && len(statement.Conditions) == 0 && statement.matchActionResource(args, resource) {
+			unconditional = true
+		}
+	}
+
+	var requirements []Requirement
+	for i, statement := range iamp.Statements {
+		if (unconditional && statement.Effect == Allow) || !statement.matchActionResource(args, resource) {
+			continue
+		}
+		for _, c := range statement.Conditions.Conditions() {
+			r := Requirement{
+				Key:       c.Key.String(),
+				Operator:  c.Operator,
+				Values:    c.Values,
+				Effect:    statement.Effect,
+				Statement: i,
+				Category:  RequirementContext,
+			}
+			if isRequestKey(c.Key) {
+				r.Category = RequirementRequest
+			}
+			requirements = append(requirements, r)
+		}
+	}
+
+	sort.SliceStable(requirements, func(i, j int) bool {
+		ri, rj := requirements[i], requirements[j]
+		if ri.Statement != rj.Statement {
+			return ri.Statement < rj.Statement
+		}
+		if ri.Key != rj.Key {
+			return ri.Key < rj.Key
+		}
+		return ri.Operator < rj.Operator
+	})
+	return requirements
+}
+
+// isRequestKey - returns whether the value of key is set from the request.
+func isRequestKey(key condition.Key) bool {
+	name, _, _ := strings.Cut(key.String(), "/")
+	_, ok := requestKeys[condition.KeyName(name)]
+	return ok
+}
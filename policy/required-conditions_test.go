@@ -0,0 +1,111 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPolicyRequiredConditionsForSSEKMS(t *testing.T) {
+	p := mustParsePolicy(t, `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": ["s3:PutObject"],
+      "Resource": ["arn:aws:s3:::secure/*"],
+      "Condition": {
+        "StringEquals": {"s3:x-amz-server-side-encryption": "aws:kms"},
+        "IpAddress": {"aws:SourceIp": "10.0.0.0/8"}
+      }
+    },
+    {
+      "Effect": "Deny",
+      "Action": ["s3:PutObject"],
+      "Resource": ["arn:aws:s3:::secure/*"],
+      "Condition": {"Bool": {"aws:SecureTransport": "false"}}
+    },
+    {
+      "Effect": "Allow",
+      "Action": ["s3:GetObject"],
+      "Resource": ["arn:aws:s3:::secure/*"]
+    }
+  ]
+}`)
+
+	testCases := []struct {
+		action   Action
+		bucket   string
+		object   string
+		expected []Requirement
+	}{
+		{PutObjectAction, "secure", "a.txt", []Requirement{
+			{Key: "aws:SourceIp", Operator: "IpAddress", Values: []string{"10.0.0.0/8"}, Effect: Allow, Statement: 0, Category: RequirementContext},
+			{Key: "s3:x-amz-server-side-encryption", Operator: "StringEquals", Values: []string{"aws:kms"}, Effect: Allow, Statement: 0, Category: RequirementRequest},
+			{Key: "aws:SecureTransport", Operator: "Bool", Values: []string{"false"}, Effect: Deny, Statement: 1, Category: RequirementContext},
+		}},
+		// No statement applies to other buckets.
+		{PutObjectAction, "other", "a.txt", nil},
+		// An 'Allow' statement without conditions applies.
+		{GetObjectAction, "secure", "a.txt", nil},
+	}
+
+	for i, testCase := range testCases {
+		if result := p.RequiredConditionsFor(testCase.action, testCase.bucket, testCase.object); !reflect.DeepEqual(result, testCase.expected) {
+			t.Fatalf("case %v: expected: %+v, got: %+v", i+1, testCase.expected, result)
+		}
+	}
+}
+
+func TestPolicyRequiredConditionsForObjectTag(t *testing.T) {
+	p := mustParsePolicy(t, `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": ["s3:PutObject", "s3:PutObjectTagging"],
+      "Resource": ["arn:aws:s3:::uploads/*"],
+      "Condition": {
+        "StringEquals": {"s3:RequestObjectTag/department": ["finance", "hr"]},
+        "ForAllValues:StringLike": {"s3:RequestObjectTagKeys": ["department", "project-*"]}
+      }
+    },
+    {
+      "Effect": "Allow",
+      "Action": ["s3:PutObject"],
+      "Resource": ["arn:aws:s3:::uploads/public/*"],
+      "Condition": {"StringEquals": {"s3:x-amz-acl": "public-read"}}
+    }
+  ]
+}`)
+
+	expected := []Requirement{
+		{Key: "s3:RequestObjectTag/department", Operator: "StringEquals", Values: []string{"finance", "hr"}, Effect: Allow, Statement: 0, Category: RequirementRequest},
+		{Key: "s3:RequestObjectTagKeys", Operator: "ForAllValues:StringLike", Values: []string{"department", "project-*"}, Effect: Allow, Statement: 0, Category: RequirementRequest},
+	}
+	if result := p.RequiredConditionsFor(PutObjectAction, "uploads", "reports/q1.pdf"); !reflect.DeepEqual(result, expected) {
+		t.Fatalf("expected: %+v, got: %+v", expected, result)
+	}
+
+	// Requirements of either statement allow public uploads.
+	expected = append(expected, Requirement{Key: "s3:x-amz-acl", Operator: "StringEquals", Values: []string{"public-read"}, Effect: Allow, Statement: 1, Category: RequirementRequest})
+	if result := p.RequiredConditionsFor(PutObjectAction, "uploads", "public/logo.png"); !reflect.DeepEqual(result, expected) {
+		t.Fatalf("expected: %+v, got: %+v", expected, result)
+	}
+}
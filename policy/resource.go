@@ -20,6 +20,7 @@ package policy
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"path"
 	"strings"
 
@@ -47,6 +48,14 @@ const (
 
 	// ResourceARNKMS is the ARN prefix type for MinIO KMS resources.
 	ResourceARNKMS
+
+	// ResourceARNAccessPoint is the type of AWS S3 access point and
+	// object lambda access point ARNs, e.g.
+	// "arn:aws:s3:us-east-1:123456789012:accesspoint/my-ap/object/*". Such
+	// resources are accepted so that policies copied from AWS parse, but
+	// MinIO has no access points and they never match any request. The
+	// Pattern of these resources is the whole ARN.
+	ResourceARNAccessPoint
 )
 
 // ARNTypeToPrefix maps the type to prefix string
@@ -83,12 +92,27 @@ func (r Resource) isS3() bool {
 	return r.Type == ResourceARNS3
 }
 
+func (r Resource) isAccessPoint() bool {
+	return r.Type == ResourceARNAccessPoint
+}
+
 func (r Resource) isBucketPattern() bool {
-	return !strings.Contains(r.Pattern, "/") || r.Pattern == "*"
+	return !r.isAccessPoint() && (!strings.Contains(r.Pattern, "/") || r.Pattern == "*")
 }
 
 func (r Resource) isObjectPattern() bool {
-	return strings.Contains(r.Pattern, "/") || strings.Contains(r.Pattern, "*")
+	return !r.isAccessPoint() && (strings.Contains(r.Pattern, "/") || strings.Contains(r.Pattern, "*"))
+}
+
+// isExactBucket - checks whether this is an S3 resource whose bucket part
+// is exactly bucket, i.e. "bucket", "bucket/" or "bucket/<pattern>", but not
+// a pattern like "bucket*" or "*" which merely covers bucket.
+func (r Resource) isExactBucket(bucket string) bool {
+	if !r.isS3() {
+		return false
+	}
+	bucketPattern, _, _ := strings.Cut(r.Pattern, "/")
+	return bucketPattern == bucket
 }
 
 // IsValid - checks whether Resource is valid or not.
@@ -112,6 +136,58 @@ func (r Resource) IsValid() bool {
 	return r.Pattern != ""
 }
 
+// SubsetOf - returns whether every resource matched by r is matched by
+// other as well, e.g. "mybucket/app/*" is a subset of "mybucket/*" and of
+// "*". The cleaned path equality of Match is not considered, i.e.
+// resources which are not clean, such as "mybucket/app/./x", may be matched
+// by r only. Resources of different types are never subsets, access point
+// resources, which match no resource, always are.
+//
+// SubsetOf is conservatively false if other has policy variables and
+// differs from r, as other may then match anything or nothing, or if the
+// patterns interleave too many wildcards to be compared, see
+// wildcard.Subset. Policy variables of r are compared as '*'.
+func (r Resource) SubsetOf(other Resource) bool {
+	switch {
+	case r.isAccessPoint() || r == other:
+		return true
+	case r.Type != other.Type || other.isAccessPoint() || strings.Contains(other.Pattern, "${"):
+		return false
+	}
+	return wildcard.Subset(variablesAsWildcards(r.Pattern), other.Pattern)
+}
+
+// variablesAsWildcards - returns the pattern with its policy variables
+// replaced with '*', which matches any of their values.
+func variablesAsWildcards(pattern string) string {
+	var sb strings.Builder
+	for {
+		start := strings.Index(pattern, "${")
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(pattern[start:], '}')
+		if end < 0 {
+			break
+		}
+		sb.WriteString(pattern[:start])
+		sb.WriteByte('*')
+		pattern = pattern[start+end+1:]
+	}
+	sb.WriteString(pattern)
+	return sb.String()
+}
+
+// matchesAccount - returns whether the resource matches the account, as
+// the resource of account level actions, see Action.IsAccountLevel.
+func (r Resource) matchesAccount() bool {
+	if r.Type != ResourceARNS3 {
+		return false
+	}
+	bucket, object, _ := strings.Cut(r.Pattern, "/")
+	return bucket != "" && strings.Trim(bucket, "*") == "" && strings.Trim(object, "*") == ""
+}
+
 // MatchResource matches object name with resource pattern only.
 func (r Resource) MatchResource(resource string) bool {
 	return r.Match(resource, nil)
@@ -119,13 +195,13 @@ func (r Resource) MatchResource(resource string) bool {
 
 // Match - matches object name with resource pattern, including specific conditionals.
 func (r Resource) Match(resource string, conditionValues map[string][]string) bool {
+	if r.isAccessPoint() {
+		return false
+	}
+
 	// Happy path, with no replacements
-	idx := strings.IndexByte(r.Pattern, '$')
-	if idx < 0 {
-		if cp := path.Clean(resource); cp != "." && cp == r.Pattern {
-			return true
-		}
-		return wildcard.Match(r.Pattern, resource)
+	if strings.IndexByte(r.Pattern, '$') < 0 {
+		return matchPattern(r.Pattern, resource)
 	}
 
 	// Use a small buffer
@@ -133,12 +209,41 @@ func (r Resource) Match(resource string, conditionValues map[string][]string) bo
 	defer smallBufPool.Put(pat)
 	pat.Reset()
 
+	r.substitute(pat, conditionValues)
+	return matchPattern(pat.String(), resource)
+}
+
+// matchPattern - matches resource with a resource pattern whose policy
+// variables have been substituted.
+func matchPattern(pattern, resource string) bool {
+	if cp := path.Clean(resource); cp != "." && cp == pattern {
+		return true
+	}
+	return wildcard.Match(pattern, resource)
+}
+
+// substitute writes the resource pattern into pat, replacing all known
+// policy variables, in the bucket as well as the object segment, with
+// their values from conditionValues. Values with wildcard characters,
+// which would match the resources of other values, e.g. a username "*",
+// are not substituted, like values with '/' in the bucket segment.
+func (r Resource) substitute(pat *bytes.Buffer, conditionValues map[string][]string) {
+	idx := strings.IndexByte(r.Pattern, '$')
+	if idx < 0 {
+		pat.WriteString(r.Pattern)
+		return
+	}
+
 	// Do replacement of known keys.
 	pat.WriteString(r.Pattern[:idx])
+	inBucket := strings.IndexByte(r.Pattern[:idx], '/') < 0
 	remain := r.Pattern[idx:]
 	for len(remain) > 0 {
 		val := remain[0]
 		if val != '$' || len(remain) < 3 {
+			if val == '/' {
+				inBucket = false
+			}
 			pat.WriteByte(val)
 			remain = remain[1:]
 			continue
@@ -154,8 +259,12 @@ func (r Resource) Match(resource string, conditionValues map[string][]string) bo
 
 		ckey := condition.KeyName(remain[2:keyEnds])
 
-		// Only replace keys we know
-		if rvalues, ok := conditionValues[ckey.Name()]; condition.CommonKeysMap[ckey] && ok && rvalues[0] != "" {
+		// Only replace keys we know, a value must never be allowed
+		// to escape the bucket segment of the pattern nor to widen it.
+		rvalues := conditionValues[ckey.Name()]
+		if len(rvalues) > 0 && isResourceVariable(ckey) && rvalues[0] != "" &&
+			!strings.ContainsAny(rvalues[0], "*?") &&
+			(!inBucket || !strings.Contains(rvalues[0], "/")) {
 			pat.WriteString(rvalues[0])
 		} else {
 			// Write without replacing...
@@ -165,17 +274,70 @@ func (r Resource) Match(resource string, conditionValues map[string][]string) bo
 		}
 		remain = remain[keyEnds+1:]
 	}
-	pattern := pat.String()
-	if cp := path.Clean(resource); cp != "." && cp == pattern {
-		return true
+}
+
+// isResourceVariable returns whether the key name may be substituted in
+// resource patterns, apart from common keys, including those with a
+// variable such as ${aws:PrincipalTag/team}, any JWT claim is allowed to
+// support custom claims such as ${jwt:tenant}.
+func isResourceVariable(key condition.KeyName) bool {
+	name, _, _ := strings.Cut(string(key), "/")
+	return condition.CommonKeysMap[condition.KeyName(name)] || strings.HasPrefix(string(key), "jwt:")
+}
+
+// bucketHasVariable returns whether the bucket segment of the resource
+// pattern contains a policy variable.
+func (r Resource) bucketHasVariable() bool {
+	bucket, _, _ := strings.Cut(r.Pattern, "/")
+	return strings.Contains(bucket, "${")
+}
+
+// invalidBucketName - returns why the literal characters of the bucket
+// segment of an S3 resource pattern can never match an S3 bucket name,
+// which is 3 to 63 lowercase letters, digits, dots and hyphens starting
+// and ending with a letter or digit, or "" if they can. Wildcards may stand
+// for any valid part of a name, patterns with policy variables are not
+// checked.
+func (r Resource) invalidBucketName() string {
+	if !r.isS3() || r.bucketHasVariable() {
+		return ""
 	}
-	return wildcard.Match(pattern, resource)
+	bucket, _, _ := strings.Cut(r.Pattern, "/")
+	if bucket == "" {
+		return ""
+	}
+
+	for _, c := range bucket {
+		switch {
+		case c == '*' || c == '?':
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '.', c == '-':
+		default:
+			return fmt.Sprintf("bucket names cannot contain '%c'", c)
+		}
+	}
+	if bucket[0] == '.' || bucket[0] == '-' {
+		return "bucket names must start with a letter or digit"
+	}
+	if c := bucket[len(bucket)-1]; c == '.' || c == '-' {
+		return "bucket names must end with a letter or digit"
+	}
+
+	// The shortest name matched by the pattern has no character in place
+	// of each '*'.
+	minLen := len(bucket) - strings.Count(bucket, "*")
+	if minLen > 63 {
+		return "bucket names must be at most 63 characters long"
+	}
+	if minLen < 3 && !strings.Contains(bucket, "*") {
+		return "bucket names must be at least 3 characters long"
+	}
+	return ""
 }
 
 // MarshalJSON - encodes Resource to JSON data.
 func (r Resource) MarshalJSON() ([]byte, error) {
 	if !r.IsValid() {
-		return nil, Errorf("invalid resource %v", r)
+		return nil, Errorf("%w", ErrMalformedResource{Resource: r.String()})
 	}
 
 	return json.Marshal(r.String())
@@ -205,7 +367,7 @@ func (r *Resource) UnmarshalJSON(data []byte) error {
 // Validate - validates Resource.
 func (r Resource) Validate() error {
 	if !r.IsValid() {
-		return Errorf("invalid resource")
+		return Errorf("%w", ErrMalformedResource{Resource: r.String()})
 	}
 	return nil
 }
@@ -213,7 +375,13 @@ func (r Resource) Validate() error {
 // ValidateBucket - validates that given bucketName is matched by Resource.
 func (r Resource) ValidateBucket(bucketName string) error {
 	if !r.IsValid() {
-		return Errorf("invalid resource")
+		return Errorf("%w", ErrMalformedResource{Resource: r.String()})
+	}
+
+	// Bucket name is only known at evaluation time when the bucket
+	// segment contains a policy variable, e.g. `${jwt:tenant}-*/*`.
+	if r.bucketHasVariable() {
+		return nil
 	}
 
 	// For the resource to match the bucket, there are two cases:
@@ -227,7 +395,7 @@ func (r Resource) ValidateBucket(bucketName string) error {
 	if !wildcard.Match(r.Pattern, bucketName) &&
 		!wildcard.MatchAsPatternPrefix(r.Pattern, bucketName+"/") {
 
-		return Errorf("bucket name does not match")
+		return Errorf("%w", ErrBucketNameMismatch)
 	}
 
 	return nil
@@ -244,16 +412,34 @@ func parseResource(s string) (Resource, error) {
 		}
 	}
 	if r.Type == unknownARN {
-		return r, Errorf("invalid resource '%v'", s)
+		if isAccessPointARN(s) {
+			return Resource{Pattern: s, Type: ResourceARNAccessPoint}, nil
+		}
+		return r, Errorf("%w", ErrMalformedResource{Resource: s})
 	}
 
 	if strings.HasPrefix(r.Pattern, "/") {
-		return r, Errorf("invalid resource '%v' - starts with '/' will not match a bucket", s)
+		return r, Errorf("%w", ErrMalformedResource{Resource: s, Reason: "starts with '/' will not match a bucket"})
 	}
 
 	return r, nil
 }
 
+// isAccessPointARN - checks whether s is an S3 access point or object lambda
+// access point ARN, i.e. "arn:<partition>:s3:<region>:<account>:accesspoint/<name>..."
+// or the same with the "s3-object-lambda" service.
+func isAccessPointARN(s string) bool {
+	fields := strings.SplitN(s, ":", 6)
+	if len(fields) != 6 || fields[0] != "arn" || fields[1] == "" {
+		return false
+	}
+	if fields[2] != "s3" && fields[2] != "s3-object-lambda" {
+		return false
+	}
+	name, ok := strings.CutPrefix(fields[5], "accesspoint/")
+	return ok && fields[3] != "" && fields[4] != "" && name != ""
+}
+
 // NewResource - creates new resource with the default ARN type of S3.
 func NewResource(pattern string) Resource {
 	return Resource{
@@ -19,7 +19,10 @@ package policy
 
 import (
 	"encoding/json"
+	"math/rand"
+	"path"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -111,6 +114,38 @@ func TestResourceIsValid(t *testing.T) {
 	}
 }
 
+func TestResourceInvalidBucketName(t *testing.T) {
+	testCases := []struct {
+		resource Resource
+		expected string
+	}{
+		{NewResource("*"), ""},
+		{NewResource("*/*"), ""},
+		{NewResource("mybucket/*"), ""},
+		{NewResource("my.bucket-01/photos/*"), ""},
+		{NewResource("prod-*"), ""},
+		{NewResource("prod-*/*"), ""},
+		{NewResource("a*"), ""},
+		{NewResource("ab?"), ""},
+		{NewResource("${aws:username}/*"), ""},
+		{NewResource("${aws:username}_Home/*"), ""},
+		{NewKMSResource("My_Key"), ""},
+		{NewResource("My_Bucket/*"), "bucket names cannot contain 'M'"},
+		{NewResource("my_bucket"), "bucket names cannot contain '_'"},
+		{NewResource("Prod-*"), "bucket names cannot contain 'P'"},
+		{NewResource("-prod*/*"), "bucket names must start with a letter or digit"},
+		{NewResource("*prod./*"), "bucket names must end with a letter or digit"},
+		{NewResource("ab/*"), "bucket names must be at least 3 characters long"},
+		{NewResource(strings.Repeat("a", 64) + "*"), "bucket names must be at most 63 characters long"},
+	}
+
+	for i, testCase := range testCases {
+		if result := testCase.resource.invalidBucketName(); result != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+	}
+}
+
 func TestResourceMatch(t *testing.T) {
 	// Only test with valid resources (specifically, resources must not start
 	// with '/')
@@ -145,6 +180,75 @@ func TestResourceMatch(t *testing.T) {
 	}
 }
 
+func TestResourceMatchWithVariables(t *testing.T) {
+	conditionValues := map[string][]string{
+		"username": {"john"},
+		"tenant":   {"acme"},
+		"groups":   {"a/b"},
+	}
+
+	testCases := []struct {
+		resource       Resource
+		objectName     string
+		expectedResult bool
+	}{
+		{NewResource("mybucket/${aws:username}/*"), "mybucket/john/myobject", true},
+		{NewResource("mybucket/${aws:username}/*"), "mybucket/jane/myobject", false},
+		{NewResource("${aws:username}-*/*"), "john-photos/myobject", true},
+		{NewResource("${aws:username}-*/*"), "jane-photos/myobject", false},
+		{NewResource("${jwt:tenant}-*/*"), "acme-data/myobject", true},
+		{NewResource("${jwt:tenant}-*/*"), "globex-data/myobject", false},
+		{NewResource("${jwt:tenant}-*"), "acme-data", true},
+		// Unknown keys are never replaced.
+		{NewResource("${foo:tenant}-*/*"), "acme-data/myobject", false},
+		// Values with '/' must not be substituted in the bucket segment.
+		{NewResource("${aws:groups}/*"), "a/b/myobject", false},
+		{NewResource("mybucket/${aws:groups}/*"), "mybucket/a/b/myobject", true},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.resource.Match(testCase.objectName, conditionValues)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestResourceMatchWithWildcardVariables(t *testing.T) {
+	testCases := []struct {
+		resource        Resource
+		conditionValues map[string][]string
+		objectName      string
+		expectedResult  bool
+	}{
+		{NewResource("mybucket/${jwt:tenant}/*"), map[string][]string{"tenant": {"*"}}, "mybucket/acme/myobject", false},
+		{NewResource("mybucket/${jwt:tenant}/*"), map[string][]string{"tenant": {"ac?e"}}, "mybucket/acme/myobject", false},
+		{NewResource("mybucket/${aws:username}*"), map[string][]string{"username": {"j*"}}, "mybucket/john", false},
+		{NewResource("${jwt:tenant}-*/*"), map[string][]string{"tenant": {"*"}}, "acme-data/myobject", false},
+		{NewResource("mybucket/${jwt:tenant}/*"), map[string][]string{"tenant": {"acme"}}, "mybucket/acme/myobject", true},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.resource.Match(testCase.objectName, testCase.conditionValues)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	p := mustParsePolicy(t, `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/${jwt:sub}/*"}]}`)
+	args := Args{
+		Action:          GetObjectAction,
+		BucketName:      "mybucket",
+		ObjectName:      "alice/myobject",
+		ConditionValues: map[string][]string{"sub": {"*"}},
+	}
+	if p.IsAllowed(args) {
+		t.Fatalf("expected claim value '*' not to match other prefixes")
+	}
+}
+
 func TestResourceMarshalJSON(t *testing.T) {
 	// Only test with valid resources (specifically, resources must not start
 	// with '/')
@@ -248,6 +352,11 @@ func TestResourceValidateBucket(t *testing.T) {
 		// corner cases for the given patterns and buckets.
 		{NewResource("mybucket*a/myobject*"), "mybucket", false},
 		{NewResource("mybucket*a/myobject*"), "mybucket22", false},
+
+		// Bucket segment with a policy variable is only known at
+		// evaluation time.
+		{NewResource("${jwt:tenant}-*/*"), "yourbucket", false},
+		{NewResource("mybucket/${aws:username}/*"), "yourbucket", true},
 	}
 
 	for i, testCase := range testCases {
@@ -259,3 +368,90 @@ func TestResourceValidateBucket(t *testing.T) {
 		}
 	}
 }
+
+func TestResourceSubsetOf(t *testing.T) {
+	testCases := []struct {
+		resource Resource
+		other    Resource
+		subset   bool
+	}{
+		{NewResource("mybucket/app/*"), NewResource("mybucket/*"), true},
+		{NewResource("mybucket/*"), NewResource("*"), true},
+		{NewResource("mybucket/*"), NewResource("mybucket/app/*"), false},
+		{NewResource("mybucket"), NewResource("mybucket*"), true},
+		{NewResource("mybucket/a?c"), NewResource("mybucket/a*"), true},
+		{NewResource("mybucket/a*"), NewResource("mybucket/a?*"), false},
+		{NewResource("mybucket/${aws:username}/*"), NewResource("mybucket/*"), true},
+		{NewResource("mybucket/${aws:username}/*"), NewResource("mybucket/${aws:username}/*"), true},
+		{NewResource("mybucket/alice/*"), NewResource("mybucket/${aws:username}/*"), false},
+		{NewResource("*"), NewKMSResource("*"), false},
+		{NewKMSResource("my-key*"), NewKMSResource("*"), true},
+	}
+
+	for i, testCase := range testCases {
+		if subset := testCase.resource.SubsetOf(testCase.other); subset != testCase.subset {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.subset, subset)
+		}
+	}
+
+	set := NewResourceSet(NewResource("mybucket/a/*"), NewResource("mybucket/b"))
+	if !set.SubsetOf(NewResourceSet(NewResource("mybucket/*"))) || set.SubsetOf(NewResourceSet(NewResource("mybucket/a/*"))) {
+		t.Fatalf("unexpected resource set subsets")
+	}
+	if !NewResourceSet().SubsetOf(NewResourceSet()) {
+		t.Fatalf("expected the empty set to be a subset")
+	}
+}
+
+// TestResourceSubsetOfProperty checks that the resources matched by random
+// patterns are matched by the patterns they are subsets of.
+func TestResourceSubsetOfProperty(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	tokens := []string{"mybucket", "a", "b", "/", "*", "?", "?", "*"}
+	genPattern := func() string {
+		var sb strings.Builder
+		for n := 1 + rnd.Intn(5); n > 0; n-- {
+			sb.WriteString(tokens[rnd.Intn(len(tokens))])
+		}
+		return sb.String()
+	}
+	// genMatching returns a random resource matched by pattern.
+	genMatching := func(pattern string) string {
+		var sb strings.Builder
+		for _, c := range pattern {
+			switch c {
+			case '*':
+				for n := rnd.Intn(4); n > 0; n-- {
+					sb.WriteByte("ab/x"[rnd.Intn(4)])
+				}
+			case '?':
+				sb.WriteByte("ab/x"[rnd.Intn(4)])
+			default:
+				sb.WriteRune(c)
+			}
+		}
+		return sb.String()
+	}
+
+	var subsets int
+	for i := 0; i < 20000; i++ {
+		r, other := NewResource(genPattern()), NewResource(genPattern())
+		if !r.SubsetOf(other) {
+			continue
+		}
+		subsets++
+		for j := 0; j < 50; j++ {
+			resource := genMatching(r.Pattern)
+			if path.Clean(resource) != resource {
+				// Not clean resources may be matched by r only.
+				continue
+			}
+			if !r.MatchResource(resource) || !other.MatchResource(resource) {
+				t.Fatalf("%q is a subset of %q, but %q is matched by %v, %v", r.Pattern, other.Pattern, resource, r.MatchResource(resource), other.MatchResource(resource))
+			}
+		}
+	}
+	if subsets < 1000 {
+		t.Fatalf("expected more subsets, got: %v", subsets)
+	}
+}
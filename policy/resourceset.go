@@ -25,7 +25,8 @@ import (
 	"github.com/minio/minio-go/v7/pkg/set"
 )
 
-// ResourceSet - set of resources in policy statement.
+// ResourceSet - set of resources in policy statement. A nil ResourceSet is
+// an empty set, all methods but Add accept it.
 type ResourceSet map[Resource]struct{}
 
 // BucketResourceExists - checks if at least one bucket resource exists in the set.
@@ -50,6 +51,58 @@ func (resourceSet ResourceSet) ObjectResourceExists() bool {
 	return false
 }
 
+// accessPoints - returns the access point resources of the set, see
+// ResourceARNAccessPoint.
+func (resourceSet ResourceSet) accessPoints() []Resource {
+	var resources []Resource
+	for resource := range resourceSet {
+		if resource.isAccessPoint() {
+			resources = append(resources, resource)
+		}
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].Pattern < resources[j].Pattern
+	})
+	return resources
+}
+
+// withoutAccessPoints - returns a copy of the set without access point
+// resources.
+func (resourceSet ResourceSet) withoutAccessPoints() ResourceSet {
+	resources := make(ResourceSet, len(resourceSet))
+	for resource := range resourceSet {
+		if !resource.isAccessPoint() {
+			resources.Add(resource)
+		}
+	}
+	return resources
+}
+
+// referencesBucket - checks if at least one resource of the set refers
+// exactly to bucket.
+func (resourceSet ResourceSet) referencesBucket(bucket string) bool {
+	for resource := range resourceSet {
+		if resource.isExactBucket(bucket) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withoutBucket - returns a copy of the set without the resources which
+// refer exactly to bucket.
+func (resourceSet ResourceSet) withoutBucket(bucket string) ResourceSet {
+	pruned := make(ResourceSet, len(resourceSet))
+	for resource := range resourceSet {
+		if !resource.isExactBucket(bucket) {
+			pruned.Add(resource)
+		}
+	}
+
+	return pruned
+}
+
 // Add - adds resource to resource set.
 func (resourceSet ResourceSet) Add(resource Resource) {
 	resourceSet[resource] = struct{}{}
@@ -91,6 +144,9 @@ func (resourceSet ResourceSet) MarshalJSON() ([]byte, error) {
 	for resource := range resourceSet {
 		resources = append(resources, resource)
 	}
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].String() < resources[j].String()
+	})
 
 	return json.Marshal(resources)
 }
@@ -105,6 +161,38 @@ func (resourceSet ResourceSet) MatchResource(resource string) bool {
 	return false
 }
 
+// SubsetOf - returns whether each resource of the set is a subset of a
+// resource of other, see Resource.SubsetOf. Resources covered by several
+// resources of other together only, such as "mybucket/a*" by "mybucket/a"
+// and "mybucket/a?*", are conservatively not subsets. The empty set is a
+// subset of any set.
+func (resourceSet ResourceSet) SubsetOf(other ResourceSet) bool {
+	for r := range resourceSet {
+		covered := false
+		for o := range other {
+			if r.SubsetOf(o) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAccount - returns whether any resource of the set matches the
+// account, see Action.IsAccountLevel.
+func (resourceSet ResourceSet) matchesAccount() bool {
+	for r := range resourceSet {
+		if r.matchesAccount() {
+			return true
+		}
+	}
+	return false
+}
+
 // Match - matches object name with anyone of resource pattern in resource set.
 func (resourceSet ResourceSet) Match(resource string, conditionValues map[string][]string) bool {
 	for r := range resourceSet {
@@ -141,7 +229,7 @@ func (resourceSet *ResourceSet) UnmarshalJSON(data []byte) error {
 		}
 
 		if _, found := (*resourceSet)[resource]; found {
-			return Errorf("duplicate resource '%v' found", s)
+			return Errorf("%w '%v' found", ErrDuplicateResource, s)
 		}
 
 		resourceSet.Add(resource)
@@ -154,7 +242,7 @@ func (resourceSet *ResourceSet) UnmarshalJSON(data []byte) error {
 func (resourceSet ResourceSet) ValidateS3() error {
 	for resource := range resourceSet {
 		if !resource.isS3() {
-			return Errorf("resource '%v' type is not S3", resource)
+			return Errorf("%w", ErrMalformedResource{Resource: resource.String(), Reason: "type is not S3"})
 		}
 		if err := resource.Validate(); err != nil {
 			return err
@@ -168,7 +256,7 @@ func (resourceSet ResourceSet) ValidateS3() error {
 func (resourceSet ResourceSet) ValidateKMS() error {
 	for resource := range resourceSet {
 		if !resource.isKMS() {
-			return Errorf("resource '%v' type is not KMS", resource)
+			return Errorf("%w", ErrMalformedResource{Resource: resource.String(), Reason: "type is not KMS"})
 		}
 		if err := resource.Validate(); err != nil {
 			return err
@@ -199,8 +287,20 @@ func (resourceSet ResourceSet) ToSlice() []Resource {
 	return resources
 }
 
-// Clone clones ResourceSet structure
+// toSortedSlice - returns the resources sorted by their string form.
+func (resourceSet ResourceSet) toSortedSlice() []Resource {
+	resources := resourceSet.ToSlice()
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].String() < resources[j].String()
+	})
+	return resources
+}
+
+// Clone clones ResourceSet structure, a nil set is cloned to a nil set.
 func (resourceSet ResourceSet) Clone() ResourceSet {
+	if resourceSet == nil {
+		return nil
+	}
 	return NewResourceSet(resourceSet.ToSlice()...)
 }
 
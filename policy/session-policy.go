@@ -0,0 +1,138 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// SessionPolicyLimits - limits of session policies, the policies passed
+// to the STS AssumeRole* APIs, see ParseSessionPolicyWithLimits.
+type SessionPolicyLimits struct {
+	// MaxSize - maximum size of a session policy document in characters,
+	// including whitespace, zero means unlimited.
+	MaxSize int
+
+	// MaxDepth - maximum nesting depth of the JSON objects and arrays of
+	// a session policy document, zero means unlimited. Valid policies are
+	// nested at most 6 levels deep.
+	MaxDepth int
+
+	// RejectNotAction - reject statements with NotAction. AWS accepts them
+	// in session policies, hence they are accepted by default.
+	RejectNotAction bool
+}
+
+// DefaultSessionPolicyLimits - limits of ParseSessionPolicy. The maximum
+// size is the AWS limit of 2048 characters.
+var DefaultSessionPolicyLimits = SessionPolicyLimits{
+	MaxSize:  2048,
+	MaxDepth: 8,
+}
+
+// hasPrincipal - returns whether a statement of the JSON document data has
+// a Principal or NotPrincipal field, false if data is not a policy
+// document, which is then rejected when it is decoded.
+func hasPrincipal(data []byte) bool {
+	var doc struct {
+		Statement []map[string]json.RawMessage
+	}
+	if json.Unmarshal(data, &doc) != nil {
+		return false
+	}
+	for _, statement := range doc.Statement {
+		for field := range statement {
+			// Fields are decoded case-insensitively.
+			if strings.EqualFold(field, "Principal") || strings.EqualFold(field, "NotPrincipal") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ParseSessionPolicy - parses the session policy in reader as per
+// DefaultSessionPolicyLimits, see ParseSessionPolicyWithLimits.
+func ParseSessionPolicy(reader io.Reader) (*Policy, error) {
+	return ParseSessionPolicyWithLimits(reader, DefaultSessionPolicyLimits)
+}
+
+// ParseSessionPolicyWithLimits - parses the session policy in reader as
+// ParseConfig does. Additionally:
+//
+//   - documents larger than limits.MaxSize characters are rejected with
+//     ErrPolicyTooLarge, as AWS does, characters being counted before the
+//     document is decoded or packed into a session token.
+//   - documents nested deeper than limits.MaxDepth are rejected with
+//     ErrMaxDepthExceeded before they are decoded.
+//   - statements with a Principal or NotPrincipal are rejected with
+//     ErrSessionPolicyPrincipal, as the principal of a session policy is
+//     the session, ParseConfig rejects them as unknown fields.
+//   - statements with NotAction are rejected with
+//     ErrSessionPolicyNotAction if limits.RejectNotAction is set.
+func ParseSessionPolicyWithLimits(reader io.Reader, limits SessionPolicyLimits) (*Policy, error) {
+	if limits.MaxSize > 0 {
+		// Characters are at most utf8.UTFMax bytes.
+		reader = io.LimitReader(reader, int64(limits.MaxSize)*utf8.UTFMax+1)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, Errorf("%w", err)
+	}
+
+	if n := utf8.RuneCount(data); limits.MaxSize > 0 && n > limits.MaxSize {
+		return nil, Errorf("%w: %d characters exceed %d characters", ErrPolicyTooLarge, n, limits.MaxSize)
+	}
+	if depth := jsonDepth(data); limits.MaxDepth > 0 && depth > limits.MaxDepth {
+		return nil, Errorf("%w", ErrMaxDepthExceeded{Depth: depth, MaxDepth: limits.MaxDepth})
+	}
+	if hasPrincipal(data) {
+		return nil, Errorf("%w", ErrSessionPolicyPrincipal)
+	}
+
+	iamp, err := ParseConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if limits.RejectNotAction {
+		for _, statement := range iamp.Statements {
+			if !statement.NotActions.IsEmpty() {
+				return nil, Errorf("%w", ErrSessionPolicyNotAction)
+			}
+		}
+	}
+	return iamp, nil
+}
+
+// FitsSessionPolicyLimit - returns whether the policy fits the maximum
+// size of DefaultSessionPolicyLimits and its estimated size, the number of
+// characters of its JSON encoding, which has no whitespace, or -1 if the
+// policy cannot be encoded, e.g. because it is invalid. Token issuers may check it before embedding the policy in a
+// session token.
+func (iamp Policy) FitsSessionPolicyLimit() (bool, int) {
+	data, err := json.Marshal(iamp)
+	if err != nil {
+		return false, -1
+	}
+	n := utf8.RuneCount(data)
+	return DefaultSessionPolicyLimits.MaxSize <= 0 || n <= DefaultSessionPolicyLimits.MaxSize, n
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// sessionPolicyOfSize - returns a valid session policy document of size
+// characters, without whitespace.
+func sessionPolicyOfSize(t *testing.T, size int) []byte {
+	t.Helper()
+	p := Policy{Version: DefaultVersion, Statements: []Statement{
+		NewStatement("", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/")), nil),
+	}}
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Characters, not bytes, are counted.
+	pad := strings.Repeat("ä", size-len([]rune(string(data))))
+	p.Statements[0].Resources = NewResourceSet(NewResource("mybucket/" + pad))
+	if data, err = json.Marshal(p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return data
+}
+
+func TestParseSessionPolicySize(t *testing.T) {
+	maxSize := DefaultSessionPolicyLimits.MaxSize
+	testCases := []struct {
+		size        int
+		expectedErr error
+	}{
+		{maxSize - 1, nil},
+		{maxSize, nil},
+		{maxSize + 1, ErrPolicyTooLarge},
+		{4 * maxSize, ErrPolicyTooLarge},
+	}
+
+	for i, testCase := range testCases {
+		data := sessionPolicyOfSize(t, testCase.size)
+		p, err := ParseSessionPolicy(bytes.NewReader(data))
+		if !errors.Is(err, testCase.expectedErr) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err != nil {
+			// The same document is accepted without size limit.
+			if _, err = ParseSessionPolicyWithLimits(bytes.NewReader(data), SessionPolicyLimits{}); err != nil {
+				t.Fatalf("case %v: unexpected error: %v", i+1, err)
+			}
+			continue
+		}
+
+		fits, size := p.FitsSessionPolicyLimit()
+		if !fits || size != testCase.size {
+			t.Fatalf("case %v: expected: %v, got: %v, %v", i+1, testCase.size, fits, size)
+		}
+	}
+
+	// Whitespace is counted.
+	data := append(sessionPolicyOfSize(t, maxSize), ' ')
+	if _, err := ParseSessionPolicy(bytes.NewReader(data)); !errors.Is(err, ErrPolicyTooLarge) {
+		t.Fatalf("expected: %v, got: %v", ErrPolicyTooLarge, err)
+	}
+
+	p := mustParsePolicy(t, string(sessionPolicyOfSize(t, maxSize+1)))
+	if fits, size := p.FitsSessionPolicyLimit(); fits || size != maxSize+1 {
+		t.Fatalf("expected: %v, got: %v, %v", maxSize+1, fits, size)
+	}
+	p.Statements[0].NotActions = NewActionSet(PutObjectAction)
+	if fits, size := p.FitsSessionPolicyLimit(); fits || size != -1 {
+		t.Fatalf("expected an invalid policy, got: %v, %v", fits, size)
+	}
+}
+
+func TestParseSessionPolicy(t *testing.T) {
+	testCases := []struct {
+		policy      string
+		limits      SessionPolicyLimits
+		expectedErr error
+	}{
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*", "Condition": {"IpAddress": {"aws:SourceIp": ["10.0.0.0/8"]}}}]}`, DefaultSessionPolicyLimits, nil},
+		// Brackets in strings are not nesting.
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/[[[[[[\"{{{{/*"}]}`, DefaultSessionPolicyLimits, nil},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*", "Condition": {"IpAddress": {"aws:SourceIp": [[[["10.0.0.0/8"]]]]}}}]}`, DefaultSessionPolicyLimits, ErrPolicyTooDeep},
+		{`[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[`, DefaultSessionPolicyLimits, ErrPolicyTooDeep},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"}]}`, DefaultSessionPolicyLimits, ErrSessionPolicyPrincipal},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Deny", "NotPrincipal": {"AWS": ["arn:aws:iam::123456789012:root"]}, "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"}]}`, DefaultSessionPolicyLimits, ErrSessionPolicyPrincipal},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"}]}`, DefaultSessionPolicyLimits, ErrSessionPolicyPrincipal},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "NotAction": "s3:DeleteObject", "Resource": "arn:aws:s3:::mybucket/*"}]}`, DefaultSessionPolicyLimits, nil},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "NotAction": "s3:DeleteObject", "Resource": "arn:aws:s3:::mybucket/*"}]}`, SessionPolicyLimits{RejectNotAction: true}, ErrSessionPolicyNotAction},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseSessionPolicyWithLimits(strings.NewReader(testCase.policy), testCase.limits)
+		if !errors.Is(err, testCase.expectedErr) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+
+	// Invalid policies are rejected as by ParseConfig.
+	if _, err := ParseSessionPolicy(strings.NewReader(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject"}]}`)); !errors.Is(err, ErrEmptyResources) {
+		t.Fatalf("expected: %v, got: %v", ErrEmptyResources, err)
+	}
+}
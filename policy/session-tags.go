@@ -0,0 +1,37 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+// ArgsFromSessionTags - returns the condition values for the session tags
+// of a principal, i.e. the value of each tag under its
+// "aws:PrincipalTag/<key>" condition key, to be merged into
+// Args.ConditionValues of every request made in the session.
+func ArgsFromSessionTags(tags map[string]string) map[string][]string {
+	conditionValues := make(map[string][]string, len(tags))
+	for key, value := range tags {
+		if key == "" {
+			continue
+		}
+		conditionValues[condition.NewKey(condition.AWSPrincipalTag, key).Name()] = []string{value}
+	}
+	return conditionValues
+}
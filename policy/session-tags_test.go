@@ -0,0 +1,124 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestArgsFromSessionTags(t *testing.T) {
+	testCases := []struct {
+		tags           map[string]string
+		expectedResult map[string][]string
+	}{
+		{nil, map[string][]string{}},
+		{map[string]string{"team": "storage", "cost-center": "", "": "ignored"}, map[string][]string{
+			"PrincipalTag/team":        {"storage"},
+			"PrincipalTag/cost-center": {""},
+		}},
+	}
+
+	for i, testCase := range testCases {
+		result := ArgsFromSessionTags(testCase.tags)
+
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestPolicyIsAllowedSessionTags(t *testing.T) {
+	policy, err := ParseConfig(strings.NewReader(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Action": ["s3:PutObject"],
+            "Resource": ["arn:aws:s3:::bucket-${aws:PrincipalTag/team}/*"]
+        },
+        {
+            "Effect": "Allow",
+            "Action": ["s3:GetObject"],
+            "Resource": ["arn:aws:s3:::shared/*"],
+            "Condition": {"StringEquals": {"aws:PrincipalTag/department": "engineering"}}
+        }
+    ]
+}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		tags           map[string]string
+		action         Action
+		bucket         string
+		expectedResult bool
+	}{
+		{map[string]string{"team": "storage"}, PutObjectAction, "bucket-storage", true},
+		{map[string]string{"team": "storage"}, PutObjectAction, "bucket-compute", false},
+		{map[string]string{"team": "compute"}, PutObjectAction, "bucket-compute", true},
+		{map[string]string{"Team": "storage"}, PutObjectAction, "bucket-storage", false},
+		{map[string]string{"team": ""}, PutObjectAction, "bucket-", false},
+		// Tags must not escape the bucket segment.
+		{map[string]string{"team": "storage/x"}, PutObjectAction, "bucket-storage", false},
+		{map[string]string{"department": "engineering"}, GetObjectAction, "shared", true},
+		{map[string]string{"department": "sales"}, GetObjectAction, "shared", false},
+		{nil, GetObjectAction, "shared", false},
+	}
+
+	for i, testCase := range testCases {
+		result := policy.IsAllowed(Args{
+			AccountName:     "Q3AM3UQ867SPQQA43P2F",
+			Action:          testCase.action,
+			BucketName:      testCase.bucket,
+			ObjectName:      "x/myobject",
+			ConditionValues: ArgsFromSessionTags(testCase.tags),
+		})
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestSessionTagsValidation(t *testing.T) {
+	testCases := []struct {
+		data      string
+		expectErr bool
+	}{
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["sts:TagSession"], "Condition": {"StringEquals": {"aws:PrincipalTag/team": "storage"}, "ForAllValues:StringEquals": {"sts:TransitiveTagKeys": ["team"]}}}]}`, false},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["sts:AssumeRoleWithWebIdentity", "sts:TagSession"], "Condition": {"StringEquals": {"aws:PrincipalTag/team": "storage"}}}]}`, false},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["sts:AssumeRoleWithWebIdentity"], "Condition": {"StringLike": {"sts:TransitiveTagKeys": "team*"}}}]}`, false},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:PutObject"], "Resource": ["arn:aws:s3:::mybucket/*"], "Condition": {"StringEquals": {"aws:PrincipalTag/team": "storage"}}}]}`, false},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["admin:ServerInfo"], "Condition": {"StringEquals": {"aws:PrincipalTag/team": "storage"}}}]}`, false},
+		// Transitive tag keys only apply to STS actions.
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:PutObject"], "Resource": ["arn:aws:s3:::mybucket/*"], "Condition": {"StringEquals": {"sts:TransitiveTagKeys": "team"}}}]}`, true},
+		// Identity keys apply to STS actions, S3 keys do not.
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["sts:TagSession"], "Condition": {"StringEquals": {"aws:username": "alice"}}}]}`, false},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["sts:TagSession"], "Condition": {"StringEquals": {"s3:prefix": "alice"}}}]}`, true},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseConfig(strings.NewReader(testCase.data))
+		if expectErr := (err != nil); expectErr != testCase.expectErr {
+			t.Fatalf("case %v: expected error: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
@@ -19,20 +19,29 @@ package policy
 
 import (
 	"bytes"
+	"encoding/json"
+	"net/url"
 	"strings"
 	"sync"
 
 	"github.com/minio/pkg/v3/policy/condition"
 )
 
-// Statement - iam policy statement.
+// Statement - iam policy statement. The zero Statement has no effect and
+// is invalid, it encodes to JSON decoding to the zero Statement.
+//
+// Description is a MinIO extension annotating the statement, e.g. "grant
+// for the migration, remove after Q3". It is ignored by evaluation, Equals
+// and Fingerprint, see Policy.StripExtensions for AWS compatible
+// documents.
 type Statement struct {
-	SID        ID                  `json:"Sid,omitempty"`
-	Effect     Effect              `json:"Effect"`
-	Actions    ActionSet           `json:"Action"`
-	NotActions ActionSet           `json:"NotAction,omitempty"`
-	Resources  ResourceSet         `json:"Resource,omitempty"`
-	Conditions condition.Functions `json:"Condition,omitempty"`
+	SID         ID                  `json:"Sid,omitempty"`
+	Description string              `json:"Description,omitempty"`
+	Effect      Effect              `json:"Effect"`
+	Actions     ActionSet           `json:"Action,omitempty"`
+	NotActions  ActionSet           `json:"NotAction,omitempty"`
+	Resources   ResourceSet         `json:"Resource,omitempty"`
+	Conditions  condition.Functions `json:"Condition,omitempty"`
 }
 
 // smallBufPool should always return a non-nil *bytes.Buffer
@@ -41,48 +50,127 @@ var smallBufPool = sync.Pool{
 }
 
 // IsAllowed - checks given policy args is allowed to continue the Rest API.
+// Statements of invalid effects never allow, see Effect.
 func (statement Statement) IsAllowed(args Args) bool {
-	check := func() bool {
-		if (!statement.Actions.Match(args.Action) && !statement.Actions.IsEmpty()) ||
-			statement.NotActions.Match(args.Action) {
-			return false
-		}
-		resource := smallBufPool.Get().(*bytes.Buffer)
-		defer smallBufPool.Put(resource)
-		resource.Reset()
-
-		resource.WriteString(args.BucketName)
-		if args.ObjectName != "" {
-			if !strings.HasPrefix(args.ObjectName, "/") {
-				resource.WriteByte('/')
-			}
+	if !statement.matchAction(args.Action) {
+		return statement.Effect.IsAllowed(false)
+	}
+
+	return statement.Effect.IsAllowed(statement.match(args, requestResource(args)))
+}
 
-			resource.WriteString(args.ObjectName)
-		} else {
+// requestResource - returns the resource of the request as matched against
+// statement resources, i.e. "bucket/object" or "bucket/".
+func requestResource(args Args) string {
+	resource := smallBufPool.Get().(*bytes.Buffer)
+	defer smallBufPool.Put(resource)
+	resource.Reset()
+
+	resource.WriteString(args.BucketName)
+	if objectName := decodeObjectName(args.ObjectName, args.ObjectNameEncoded); objectName != "" {
+		if !strings.HasPrefix(objectName, "/") {
 			resource.WriteByte('/')
 		}
 
-		if statement.isKMS() {
-			if resource.Len() == 1 && resource.String() == "/" || len(statement.Resources) == 0 {
-				// In previous MinIO versions, KMS statements ignored Resources, so if len(statement.Resources) == 0,
-				// allow backward compatibility by not trying to Match.
+		resource.WriteString(objectName)
+	} else {
+		resource.WriteByte('/')
+	}
 
-				// When resource is "/", this allows evaluating KMS statements while explicitly excluding Resource,
-				// by passing Args with empty BucketName and ObjectName. This is useful when doing a
-				// two-phase authorization of a request.
-				return statement.Conditions.Evaluate(args.ConditionValues)
-			}
-		}
+	return resource.String()
+}
+
+// decodeObjectName - returns the object name as matched against statement
+// resources. Object names are compared byte by byte like S3 does, no unicode
+// normalization is applied, i.e. NFC and NFD forms of a name are distinct.
+// If encoded is set, name is percent-decoded exactly once, '+' is kept as
+// is. A name which is not validly encoded is used as is.
+func decodeObjectName(name string, encoded bool) string {
+	if !encoded {
+		return name
+	}
+	if decoded, err := url.PathUnescape(name); err == nil {
+		return decoded
+	}
+	return name
+}
+
+// matchAction - returns whether action is matched by this statement. A
+// statement with neither Action nor NotAction, which is invalid but may be
+// constructed directly or stored by older versions, matches no action.
+func (statement Statement) matchAction(action Action) bool {
+	if statement.Actions.IsEmpty() && statement.NotActions.IsEmpty() {
+		return false
+	}
+	return (statement.Actions.Match(action) || statement.Actions.IsEmpty()) &&
+		!statement.NotActions.Match(action)
+}
 
-		// For some admin statements, resource match can be ignored.
-		if !statement.Resources.Match(resource.String(), args.ConditionValues) && !statement.isAdmin() && !statement.isSTS() {
-			return false
+// match - returns whether this statement applies to args, irrespective of
+// its effect. resource must be the value of requestResource(args).
+func (statement Statement) match(args Args, resource string) bool {
+	ok, _ := statement.matchWithMissingKey(args, resource)
+	return ok
+}
+
+// matchWithMissingKey - same as match, additionally returns the condition
+// key missing from args if the result was decided by
+// Args.StrictConditionContext.
+func (statement Statement) matchWithMissingKey(args Args, resource string) (bool, string) {
+	if !statement.matchActionResource(args, resource) {
+		return false, ""
+	}
+
+	return statement.evaluateConditions(args)
+}
+
+// matchActionResource - returns whether this statement applies to the
+// action and resource of args, irrespective of its conditions. resource
+// must be the value of requestResource(args).
+func (statement Statement) matchActionResource(args Args, resource string) bool {
+	if !statement.matchAction(args.Action) {
+		return false
+	}
+
+	// Resource matching is decided by the requested action rather than
+	// by the statement, so that a statement matching actions of several
+	// services, e.g. "*", does not skip resource matching for S3 actions.
+	switch {
+	case args.Action.ignoresResources():
+		// Resources do not apply to admin and STS actions.
+		return true
+	case args.Action.IsAccountLevel():
+		// The request resource, if any, is not matched.
+		return statement.Resources.matchesAccount()
+	case args.Action.namespace() == "kms":
+		if resource == "/" || len(statement.Resources) == 0 {
+			// In previous MinIO versions, KMS statements ignored Resources, so if len(statement.Resources) == 0,
+			// allow backward compatibility by not trying to Match.
+
+			// When resource is "/", this allows evaluating KMS statements while explicitly excluding Resource,
+			// by passing Args with empty BucketName and ObjectName. This is useful when doing a
+			// two-phase authorization of a request.
+			return true
 		}
+	}
+
+	// Statements without resources, which are invalid for S3 actions, match
+	// no resource.
+	return statement.Resources.Match(resource, args.ConditionValues)
+}
 
-		return statement.Conditions.Evaluate(args.ConditionValues)
+// evaluateConditions - returns whether the conditions of this statement
+// are satisfied by args. With Args.StrictConditionContext a missing
+// condition key fails closed, i.e. satisfies 'Deny' statements and does not
+// satisfy 'Allow' statements, and is returned as well.
+func (statement Statement) evaluateConditions(args Args) (bool, string) {
+	if args.StrictConditionContext {
+		if key, missing := statement.Conditions.MissingKey(args.ConditionValues); missing {
+			return statement.Effect == Deny, key.String()
+		}
 	}
 
-	return statement.Effect.IsAllowed(check())
+	return statement.Conditions.Evaluate(args.ConditionValues), ""
 }
 
 func (statement Statement) isAdmin() bool {
@@ -112,25 +200,66 @@ func (statement Statement) isKMS() bool {
 	return false
 }
 
+// actionNamespaces - returns the sorted services of the statement actions,
+// "*" which applies to all services is ignored.
+func (statement Statement) actionNamespaces() []string {
+	var namespaces []string
+	for _, action := range statement.Actions.toSortedSlice() {
+		// Actions are sorted, hence actions of a service are adjacent.
+		if ns := action.namespace(); ns != "" && (len(namespaces) == 0 || namespaces[len(namespaces)-1] != ns) {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
 // isValid - checks whether statement is valid or not.
 func (statement Statement) isValid() error {
+	return statement.isValidWithOptions(ValidationOptions{})
+}
+
+// isValidWithOptions - checks whether statement is valid or not, validating
+// actions as per opts.
+func (statement Statement) isValidWithOptions(opts ValidationOptions) error {
+	freezeActions()
+
 	if !statement.Effect.IsValid() {
-		return Errorf("invalid Effect %v", statement.Effect)
+		return Errorf("%w %v", ErrInvalidEffect, statement.Effect)
+	}
+
+	if err := validateDescription(statement.Description); err != nil {
+		return err
 	}
 
 	if len(statement.Actions) == 0 && len(statement.NotActions) == 0 {
-		return Errorf("Action must not be empty")
+		return Errorf("%w", ErrEmptyActions)
+	}
+
+	if len(statement.Actions) > 0 && len(statement.NotActions) > 0 {
+		return Errorf("%w", ErrActionAndNotAction)
+	}
+
+	if namespaces := statement.actionNamespaces(); len(namespaces) > 1 && !opts.AllowMixedActions {
+		return Errorf("%w", ErrMixedActions{Namespaces: namespaces, SID: statement.SID})
+	}
+
+	if opts.RejectConditionKeyTypes {
+		for _, c := range statement.Conditions.Conditions() {
+			if err := condition.CheckOperatorKey(c.Operator, c.Key); err != nil {
+				return Errorf("%w", err)
+			}
+		}
 	}
 
 	if statement.isAdmin() {
 		if err := statement.Actions.ValidateAdmin(); err != nil {
 			return err
 		}
-		for action := range statement.Actions {
+		for _, action := range statement.Actions.toSortedSlice() {
 			keys := statement.Conditions.Keys()
 			keyDiff := keys.Difference(adminActionConditionKeyMap[action])
 			if !keyDiff.IsEmpty() {
-				return Errorf("unsupported condition keys '%v' used for action '%v'", keyDiff, action)
+				return Errorf("%w", ErrUnsupportedConditionKey{Action: action, Keys: keyDiff})
 			}
 		}
 		return nil
@@ -140,11 +269,11 @@ func (statement Statement) isValid() error {
 		if err := statement.Actions.ValidateSTS(); err != nil {
 			return err
 		}
-		for action := range statement.Actions {
+		for _, action := range statement.Actions.toSortedSlice() {
 			keys := statement.Conditions.Keys()
 			keyDiff := keys.Difference(stsActionConditionKeyMap[action])
 			if !keyDiff.IsEmpty() {
-				return Errorf("unsupported condition keys '%v' used for action '%v'", keyDiff, action)
+				return Errorf("%w", ErrUnsupportedConditionKey{Action: action, Keys: keyDiff})
 			}
 		}
 		return nil
@@ -158,42 +287,89 @@ func (statement Statement) isValid() error {
 	}
 
 	if !statement.SID.IsValid() {
-		return Errorf("invalid SID %v", statement.SID)
+		return Errorf("%w %v", ErrInvalidSID, statement.SID)
 	}
 
 	if len(statement.Resources) == 0 {
-		return Errorf("Resource must not be empty")
+		return Errorf("%w", ErrEmptyResources)
+	}
+
+	// Access points never match, only the other resources are validated.
+	resources := statement.Resources
+	if accessPoints := resources.accessPoints(); len(accessPoints) > 0 {
+		if opts.RejectUnsupportedResources {
+			return Errorf("%w", ErrMalformedResource{Resource: accessPoints[0].String(), Reason: "access points are not supported"})
+		}
+		resources = resources.withoutAccessPoints()
 	}
 
-	if err := statement.Resources.ValidateS3(); err != nil {
+	if err := resources.ValidateS3(); err != nil {
 		return err
 	}
 
-	if err := statement.Actions.Validate(); err != nil {
+	if opts.RejectInvalidBucketNames {
+		for _, resource := range resources.toSortedSlice() {
+			if reason := resource.invalidBucketName(); reason != "" {
+				return Errorf("%w", ErrMalformedResource{Resource: resource.String(), Reason: reason})
+			}
+		}
+	}
+
+	if err := statement.Actions.validate(statement.SID, opts.ActionValidation); err != nil {
 		return err
 	}
 
-	for action := range statement.Actions {
-		if !statement.Resources.ObjectResourceExists() && !statement.Resources.BucketResourceExists() {
-			return Errorf("unsupported Resource found %v for action %v", statement.Resources, action)
+	for _, action := range statement.Actions.toSortedSlice() {
+		if len(resources) > 0 {
+			if !resources.ObjectResourceExists() && !resources.BucketResourceExists() {
+				return Errorf("%w", ErrUnsupportedResource{Action: action, Resources: statement.Resources})
+			}
+
+			// Object actions must have an object resource, as in bucket
+			// policies. Previous releases accepted them, hence on demand.
+			if opts.RejectUnsupportedResources && action.isObjectOnlyAction() && !resources.ObjectResourceExists() {
+				return Errorf("%w", ErrUnsupportedResource{Action: action, Resources: statement.Resources})
+			}
 		}
 
 		keys := statement.Conditions.Keys()
 		keyDiff := keys.Difference(IAMActionConditionKeyMap.Lookup(action))
 		if !keyDiff.IsEmpty() {
-			return Errorf("unsupported condition keys '%v' used for action '%v'", keyDiff, action)
+			return Errorf("%w", ErrUnsupportedConditionKey{Action: action, Keys: keyDiff})
 		}
 	}
 
 	return nil
 }
 
+// isInert - returns whether the statement never applies because all its
+// resources are access points, see ResourceARNAccessPoint.
+func (statement Statement) isInert() bool {
+	if statement.isAdmin() || statement.isSTS() || statement.isKMS() {
+		return false
+	}
+	return len(statement.Resources) > 0 && len(statement.Resources.withoutAccessPoints()) == 0
+}
+
 // Validate - validates Statement is for given bucket or not.
 func (statement Statement) Validate() error {
 	return statement.isValid()
 }
 
-// Equals checks if two statements are equal
+// MarshalJSON - encodes Statement to JSON data. Statements with both
+// Action and NotAction, which are invalid, are not encoded.
+func (statement Statement) MarshalJSON() ([]byte, error) {
+	if len(statement.Actions) > 0 && len(statement.NotActions) > 0 {
+		return nil, Errorf("%w", ErrActionAndNotAction)
+	}
+
+	// subtype to avoid recursive call to MarshalJSON()
+	type subStatement Statement
+	return json.Marshal(subStatement(statement))
+}
+
+// Equals checks if two statements are equal, their SID and Description
+// are not compared.
 func (statement Statement) Equals(st Statement) bool {
 	if statement.Effect != st.Effect {
 		return false
@@ -216,12 +392,13 @@ func (statement Statement) Equals(st Statement) bool {
 // Clone clones Statement structure
 func (statement Statement) Clone() Statement {
 	return Statement{
-		SID:        statement.SID,
-		Effect:     statement.Effect,
-		Actions:    statement.Actions.Clone(),
-		NotActions: statement.NotActions.Clone(),
-		Resources:  statement.Resources.Clone(),
-		Conditions: statement.Conditions.Clone(),
+		SID:         statement.SID,
+		Description: statement.Description,
+		Effect:      statement.Effect,
+		Actions:     statement.Actions.Clone(),
+		NotActions:  statement.NotActions.Clone(),
+		Resources:   statement.Resources.Clone(),
+		Conditions:  statement.Conditions.Clone(),
 	}
 }
 
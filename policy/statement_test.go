@@ -19,6 +19,7 @@ package policy
 
 import (
 	"encoding/json"
+	"errors"
 	"net"
 	"reflect"
 	"testing"
@@ -304,6 +305,63 @@ func TestStatementIsValid(t *testing.T) {
 	}
 }
 
+func TestStatementIsValidErrorMessage(t *testing.T) {
+	func1, err := condition.NewStringEqualsFunc(
+		"",
+		condition.S3XAmzMetadataDirective.ToKey(),
+		"REPLACE",
+	)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	func2, err := condition.NewStringEqualsFunc(
+		"",
+		condition.S3XAmzCopySource.ToKey(),
+		"mybucket/myobject",
+	)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		statement   Statement
+		expectedErr string
+	}{
+		{NewStatement("",
+			Allow,
+			NewActionSet(ListBucketAction, GetObjectAction),
+			NewResourceSet(NewResource("mybucket/myobject*")),
+			condition.NewFunctions(func1, func2),
+		), "unsupported condition keys '[s3:x-amz-copy-source s3:x-amz-metadata-directive]' used for action 's3:GetObject'"},
+		{NewStatement("",
+			Allow,
+			NewActionSet(DeleteUserAdminAction, CreateUserAdminAction),
+			nil,
+			condition.NewFunctions(func2, func1),
+		), "unsupported condition keys '[s3:x-amz-copy-source s3:x-amz-metadata-directive]' used for action 'admin:CreateUser'"},
+		{NewStatement("",
+			Allow,
+			NewActionSet(PutObjectAction, "s3:Foo", "s3:Bar"),
+			NewResourceSet(NewResource("mybucket/myobject*")),
+			condition.NewFunctions(),
+		), "unsupported action 's3:Bar'"},
+	}
+
+	for i, testCase := range testCases {
+		// Repeat to catch any map iteration order dependency.
+		for j := 0; j < 10; j++ {
+			err := testCase.statement.isValid()
+			if err == nil {
+				t.Fatalf("case %v: expected error", i+1)
+			}
+			if err.Error() != testCase.expectedErr {
+				t.Fatalf("case %v: error: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+			}
+		}
+	}
+}
+
 func TestStatementUnmarshalJSONAndValidate(t *testing.T) {
 	case1Data := []byte(`{
     "Sid": "SomeId1",
@@ -519,3 +577,120 @@ func TestStatementValidate(t *testing.T) {
 		}
 	}
 }
+
+func TestStatementMixedActions(t *testing.T) {
+	mixed := NewStatement("mixed",
+		Allow,
+		NewActionSet(GetObjectAction, "admin:ServerInfo"),
+		NewResourceSet(NewResource("mybucket/*")),
+		condition.NewFunctions(),
+	)
+	permissiveMixed := NewStatement("",
+		Allow,
+		NewActionSet(GetObjectAction, "s3tables:GetTable"),
+		NewResourceSet(NewResource("mybucket/*")),
+		condition.NewFunctions(),
+	)
+	all := NewStatement("",
+		Allow,
+		NewActionSet("*"),
+		NewResourceSet(NewResource("mybucket/*")),
+		condition.NewFunctions(),
+	)
+
+	validationCases := []struct {
+		statement Statement
+		opts      ValidationOptions
+		expectErr bool
+	}{
+		{mixed, ValidationOptions{}, true},
+		{permissiveMixed, ValidationOptions{ActionValidation: ActionValidationPermissive}, true},
+		{permissiveMixed, ValidationOptions{ActionValidation: ActionValidationPermissive, AllowMixedActions: true}, false},
+		// "*" applies to all services, it is not mixed.
+		{all, ValidationOptions{}, false},
+	}
+	for i, testCase := range validationCases {
+		err := testCase.statement.isValidWithOptions(testCase.opts)
+		if expectErr := err != nil; expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		var mixedErr ErrMixedActions
+		if testCase.expectErr && !errors.As(err, &mixedErr) {
+			t.Fatalf("case %v: expected ErrMixedActions, got: %v", i+1, err)
+		}
+	}
+
+	expectedErr := "actions of services 'admin', 's3' must not be mixed in statement 'mixed'"
+	if err := mixed.Validate(); err == nil || err.Error() != expectedErr {
+		t.Fatalf("expected: %v, got: %v", expectedErr, err)
+	}
+
+	// Resources are matched as per the requested action: admin and STS
+	// actions ignore resources, S3 actions do not, even if the statement
+	// also matches admin actions.
+	testCases := []struct {
+		statement Statement
+		args      Args
+		expected  bool
+	}{
+		{mixed, Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, true},
+		{mixed, Args{Action: GetObjectAction, BucketName: "yourbucket", ObjectName: "myobject"}, false},
+		{mixed, Args{Action: "admin:ServerInfo"}, true},
+		{all, Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, true},
+		{all, Args{Action: GetObjectAction, BucketName: "yourbucket", ObjectName: "myobject"}, false},
+		{all, Args{Action: PutObjectAction, BucketName: "yourbucket", ObjectName: "myobject"}, false},
+		{all, Args{Action: "admin:ServerInfo"}, true},
+		{all, Args{Action: Action(AssumeRoleWithWebIdentityAction)}, true},
+	}
+	for i, testCase := range testCases {
+		if got := testCase.statement.IsAllowed(testCase.args); got != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, got)
+		}
+	}
+
+	p := Policy{Version: DefaultVersion, Statements: []Statement{mixed, all}}
+	expectedWarnings := []string{"statement 'mixed': actions of services 'admin', 's3' are mixed, resources only apply to some of them"}
+	if warnings := p.Lint(); !reflect.DeepEqual(warnings, expectedWarnings) {
+		t.Fatalf("expected: %v, got: %v", expectedWarnings, warnings)
+	}
+}
+
+func TestStatementInvalidNeverMatches(t *testing.T) {
+	// Statements are constructed directly, bypassing validation, as
+	// corrupted stored policies may be.
+	testCases := []struct {
+		statement Statement
+		args      Args
+	}{
+		// No Action and no NotAction.
+		{Statement{Effect: Allow, Resources: NewResourceSet(NewResource("*"))}, Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}},
+		{Statement{Effect: Allow}, Args{Action: Action(ServerInfoAdminAction)}},
+		{Statement{Effect: Allow}, Args{Action: Action(AssumeRoleWithWebIdentityAction)}},
+		// No Resource for S3 actions.
+		{Statement{Effect: Allow, Actions: NewActionSet(AllActions)}, Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}},
+		{Statement{Effect: Allow, NotActions: NewActionSet(PutObjectAction)}, Args{Action: ListBucketAction, BucketName: "mybucket"}},
+	}
+
+	for i, testCase := range testCases {
+		if testCase.statement.IsAllowed(testCase.args) {
+			t.Fatalf("case %v: expected: false, got: true", i+1)
+		}
+		if testCase.statement.match(testCase.args, requestResource(testCase.args)) {
+			t.Fatalf("case %v: expected no match", i+1)
+		}
+
+		// Deny statements do not deny either.
+		deny := testCase.statement
+		deny.Effect = Deny
+		if !deny.IsAllowed(testCase.args) {
+			t.Fatalf("case %v: expected: true, got: false", i+1)
+		}
+		policy := Policy{Version: DefaultVersion, Statements: []Statement{
+			NewStatement("", Allow, NewActionSet("*"), NewResourceSet(NewResource("*")), nil),
+			deny,
+		}}
+		if !policy.IsAllowed(testCase.args) {
+			t.Fatalf("case %v: policy: expected: true, got: false", i+1)
+		}
+	}
+}
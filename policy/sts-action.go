@@ -27,6 +27,8 @@ type STSAction string
 const (
 	// AssumeRoleWithWebIdentityAction - STS action for AssumeRoleWithWebIdentity call
 	AssumeRoleWithWebIdentityAction = "sts:AssumeRoleWithWebIdentity"
+	// TagSessionAction - STS action for passing session tags
+	TagSessionAction = "sts:TagSession"
 	// AllSTSActions - select all STS actions
 	AllSTSActions = "*"
 )
@@ -34,6 +36,7 @@ const (
 // List of all supported sts actions.
 var supportedSTSActions = map[STSAction]struct{}{
 	AssumeRoleWithWebIdentityAction: {},
+	TagSessionAction:                {},
 
 	AllSTSActions: {},
 }
@@ -50,9 +53,20 @@ func createSTSActionConditionKeyMap() map[Action]condition.KeySet {
 		allSupportedSTSKeys = append(allSupportedSTSKeys, keyName.ToKey())
 	}
 
+	identityKeys := []condition.Key{}
+	for _, keyName := range condition.CommonIdentityKeys {
+		identityKeys = append(identityKeys, keyName.ToKey())
+	}
+
 	return ActionConditionKeyMap{
-		AllSTSActions:                   condition.NewKeySet(allSupportedSTSKeys...),
-		AssumeRoleWithWebIdentityAction: condition.NewKeySet([]condition.Key{condition.STSDurationSeconds.ToKey()}...),
+		AllSTSActions: condition.NewKeySet(allSupportedSTSKeys...),
+		AssumeRoleWithWebIdentityAction: condition.NewKeySet(append([]condition.Key{
+			condition.STSDurationSeconds.ToKey(),
+			condition.STSTransitiveTagKeys.ToKey(),
+		}, identityKeys...)...),
+		TagSessionAction: condition.NewKeySet(append([]condition.Key{
+			condition.STSTransitiveTagKeys.ToKey(),
+		}, identityKeys...)...),
 	}
 }
 
@@ -0,0 +1,255 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+// DefaultTemporalWindow - window of TemporalReport within which statements
+// are reported as expiring.
+const DefaultTemporalWindow = 7 * 24 * time.Hour
+
+// TemporalIssueKind - kind of TemporalIssue.
+type TemporalIssueKind string
+
+// TemporalIssueKind values.
+const (
+	// TemporalExpired - the statement allowed requests until Boundary
+	// and no longer allows any.
+	TemporalExpired TemporalIssueKind = "expired"
+	// TemporalExpiring - the statement stops allowing requests at
+	// Boundary, within the window.
+	TemporalExpiring TemporalIssueKind = "expiring"
+	// TemporalUnsatisfiable - the time conditions of the statement
+	// contradict each other, it never allows any request. Boundary is the
+	// zero time.
+	TemporalUnsatisfiable TemporalIssueKind = "unsatisfiable"
+)
+
+// TemporalIssue - 'Allow' statement whose conditions on the request time
+// no longer, or soon no longer, allow any request.
+type TemporalIssue struct {
+	Statement int // Index of the statement
+	SID       ID
+	Kind      TemporalIssueKind
+	Boundary  time.Time
+}
+
+// TemporalReport - returns the 'Allow' statements of the policy whose
+// conditions on aws:CurrentTime and aws:EpochTime no longer allow any
+// request at now, or stop allowing requests within DefaultTemporalWindow.
+func (iamp Policy) TemporalReport(now time.Time) []TemporalIssue {
+	return iamp.TemporalReportWithWindow(now, DefaultTemporalWindow)
+}
+
+// TemporalReportWithWindow - same as TemporalReport, statements are
+// reported as expiring within window.
+//
+// The date operators, and the numeric operators on aws:EpochTime, are
+// considered. A condition with several values is satisfied if any value
+// allows the request time, or all values for the ForAllValues qualifier.
+// Other conditions are assumed to be satisfiable.
+func (iamp Policy) TemporalReportWithWindow(now time.Time, window time.Duration) []TemporalIssue {
+	var issues []TemporalIssue
+	for i, statement := range iamp.Statements {
+		if statement.Effect != Allow {
+			continue
+		}
+		allowed := allowedTimes(statement.Conditions.Conditions())
+		issue := TemporalIssue{Statement: i, SID: statement.SID}
+		switch {
+		case len(allowed) == 0:
+			issue.Kind = TemporalUnsatisfiable
+		case len(allowed.intersect(timeIntervals{{lo: timeBound{t: now}, hi: timeBound{inf: true}}})) == 0:
+			issue.Kind = TemporalExpired
+			issue.Boundary = allowed.end().t
+		default:
+			end := allowed.end()
+			if end.inf || end.t.After(now.Add(window)) {
+				continue
+			}
+			issue.Kind = TemporalExpiring
+			issue.Boundary = end.t
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// timeBound - bound of a timeInterval, open bounds exclude t. Infinite
+// bounds ignore t.
+type timeBound struct {
+	t    time.Time
+	open bool
+	inf  bool
+}
+
+// timeInterval - non-empty interval of time.
+type timeInterval struct {
+	lo, hi timeBound
+}
+
+// timeIntervals - union of intervals of time.
+type timeIntervals []timeInterval
+
+var allTime = timeIntervals{{lo: timeBound{inf: true}, hi: timeBound{inf: true}}}
+
+// allowedTimes - returns the request times satisfying the conditions.
+func allowedTimes(conditions []condition.Condition) timeIntervals {
+	allowed := allTime
+	for _, c := range conditions {
+		if !c.Key.Is(condition.AWSCurrentTime) && !c.Key.Is(condition.AWSEpochTime) {
+			continue
+		}
+		qualifier, operator, found := strings.Cut(c.Operator, ":")
+		if !found {
+			qualifier, operator = "", c.Operator
+		}
+		operator = strings.TrimSuffix(operator, "IfExists")
+		if !strings.HasPrefix(operator, "Date") && !(strings.HasPrefix(operator, "Numeric") && c.Key.Is(condition.AWSEpochTime)) {
+			continue
+		}
+
+		var values timeIntervals
+		if qualifier == "ForAllValues" {
+			values = allTime
+		}
+		for _, v := range c.Values {
+			t, ok := parseTimeValue(v)
+			if !ok {
+				// Never satisfied by any time.
+				if qualifier == "ForAllValues" {
+					values = nil
+				}
+				continue
+			}
+			intervals := operatorIntervals(strings.TrimPrefix(strings.TrimPrefix(operator, "Date"), "Numeric"), t)
+			if qualifier == "ForAllValues" {
+				values = values.intersect(intervals)
+			} else {
+				values = append(values, intervals...)
+			}
+		}
+		allowed = allowed.intersect(values)
+	}
+	return allowed
+}
+
+// parseTimeValue - parses v as RFC3339 time or as epoch seconds.
+func parseTimeValue(v string) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, true
+	}
+	if secs, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.Unix(secs, 0), true
+	}
+	return time.Time{}, false
+}
+
+// operatorIntervals - returns the times satisfying the comparison op, such
+// as "LessThan", with t.
+func operatorIntervals(op string, t time.Time) timeIntervals {
+	inf := timeBound{inf: true}
+	switch op {
+	case "Equals":
+		return timeIntervals{{lo: timeBound{t: t}, hi: timeBound{t: t}}}
+	case "NotEquals":
+		return timeIntervals{{lo: inf, hi: timeBound{t: t, open: true}}, {lo: timeBound{t: t, open: true}, hi: inf}}
+	case "LessThan":
+		return timeIntervals{{lo: inf, hi: timeBound{t: t, open: true}}}
+	case "LessThanEquals":
+		return timeIntervals{{lo: inf, hi: timeBound{t: t}}}
+	case "GreaterThan":
+		return timeIntervals{{lo: timeBound{t: t, open: true}, hi: inf}}
+	case "GreaterThanEquals":
+		return timeIntervals{{lo: timeBound{t: t}, hi: inf}}
+	}
+	return allTime
+}
+
+// intersect - returns the intersection of both unions of intervals.
+func (a timeIntervals) intersect(b timeIntervals) timeIntervals {
+	var res timeIntervals
+	for _, x := range a {
+		for _, y := range b {
+			i := timeInterval{lo: maxLo(x.lo, y.lo), hi: minHi(x.hi, y.hi)}
+			if !i.empty() {
+				res = append(res, i)
+			}
+		}
+	}
+	return res
+}
+
+// end - returns the upper bound of the union, which must not be empty.
+func (a timeIntervals) end() timeBound {
+	end := a[0].hi
+	for _, i := range a[1:] {
+		if i.hi.inf || (!end.inf && (i.hi.t.After(end.t) || (i.hi.t.Equal(end.t) && !i.hi.open))) {
+			end = i.hi
+		}
+	}
+	return end
+}
+
+func (i timeInterval) empty() bool {
+	if i.lo.inf || i.hi.inf {
+		return false
+	}
+	if i.lo.t.Equal(i.hi.t) {
+		return i.lo.open || i.hi.open
+	}
+	return i.lo.t.After(i.hi.t)
+}
+
+// maxLo - returns the greater, i.e. the more restrictive, lower bound.
+func maxLo(a, b timeBound) timeBound {
+	switch {
+	case a.inf:
+		return b
+	case b.inf:
+		return a
+	case a.t.Equal(b.t):
+		a.open = a.open || b.open
+		return a
+	case a.t.After(b.t):
+		return a
+	}
+	return b
+}
+
+// minHi - returns the lesser, i.e. the more restrictive, upper bound.
+func minHi(a, b timeBound) timeBound {
+	switch {
+	case a.inf:
+		return b
+	case b.inf:
+		return a
+	case a.t.Equal(b.t):
+		a.open = a.open || b.open
+		return a
+	case a.t.Before(b.t):
+		return a
+	}
+	return b
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+const temporalPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Sid": "Expired", "Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::a/*", "Condition": {"DateLessThan": {"aws:CurrentTime": "2026-01-01T00:00:00Z"}}},
+    {"Sid": "Expiring", "Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::b/*", "Condition": {"DateLessThanEquals": {"aws:CurrentTime": "2026-03-03T00:00:00Z"}}},
+    {"Sid": "Later", "Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::c/*", "Condition": {"DateLessThan": {"aws:CurrentTime": "2027-01-01T00:00:00Z"}}},
+    {"Sid": "Window", "Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::d/*", "Condition": {"DateGreaterThan": {"aws:CurrentTime": "2025-01-01T00:00:00Z"}, "DateLessThan": {"aws:CurrentTime": "2025-06-01T00:00:00Z"}}},
+    {"Sid": "Contradiction", "Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::e/*", "Condition": {"DateGreaterThan": {"aws:CurrentTime": "2027-01-01T00:00:00Z"}, "DateLessThan": {"aws:CurrentTime": "2026-01-01T00:00:00Z"}}},
+    {"Sid": "Epoch", "Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::f/*", "Condition": {"NumericLessThan": {"aws:EpochTime": "1772409600"}}},
+    {"Sid": "Deny", "Effect": "Deny", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::g/*", "Condition": {"DateLessThan": {"aws:CurrentTime": "2020-01-01T00:00:00Z"}}},
+    {"Sid": "Future", "Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::h/*", "Condition": {"DateGreaterThan": {"aws:CurrentTime": "2027-01-01T00:00:00Z"}, "IpAddress": {"aws:SourceIp": "10.0.0.0/8"}}},
+    {"Sid": "Point", "Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::i/*", "Condition": {"DateEquals": {"aws:CurrentTime": "2026-03-01T00:00:00Z"}}}
+  ]
+}`
+
+func TestTemporalReport(t *testing.T) {
+	p := mustParsePolicy(t, temporalPolicy)
+	date := func(s string) time.Time {
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	testCases := []struct {
+		now      time.Time
+		expected []TemporalIssue
+	}{
+		{date("2026-03-01T00:00:00Z"), []TemporalIssue{
+			{0, "Expired", TemporalExpired, date("2026-01-01T00:00:00Z")},
+			{1, "Expiring", TemporalExpiring, date("2026-03-03T00:00:00Z")},
+			{3, "Window", TemporalExpired, date("2025-06-01T00:00:00Z")},
+			{4, "Contradiction", TemporalUnsatisfiable, time.Time{}},
+			{5, "Epoch", TemporalExpiring, time.Unix(1772409600, 0)},
+			{8, "Point", TemporalExpiring, date("2026-03-01T00:00:00Z")},
+		}},
+		// At the boundary, "less than" is expired, "less than or equals"
+		// is not.
+		{date("2026-03-03T00:00:00Z"), []TemporalIssue{
+			{0, "Expired", TemporalExpired, date("2026-01-01T00:00:00Z")},
+			{1, "Expiring", TemporalExpiring, date("2026-03-03T00:00:00Z")},
+			{3, "Window", TemporalExpired, date("2025-06-01T00:00:00Z")},
+			{4, "Contradiction", TemporalUnsatisfiable, time.Time{}},
+			{5, "Epoch", TemporalExpired, time.Unix(1772409600, 0)},
+			{8, "Point", TemporalExpired, date("2026-03-01T00:00:00Z")},
+		}},
+		{date("2026-12-30T00:00:00Z"), []TemporalIssue{
+			{0, "Expired", TemporalExpired, date("2026-01-01T00:00:00Z")},
+			{1, "Expiring", TemporalExpired, date("2026-03-03T00:00:00Z")},
+			{2, "Later", TemporalExpiring, date("2027-01-01T00:00:00Z")},
+			{3, "Window", TemporalExpired, date("2025-06-01T00:00:00Z")},
+			{4, "Contradiction", TemporalUnsatisfiable, time.Time{}},
+			{5, "Epoch", TemporalExpired, time.Unix(1772409600, 0)},
+			{8, "Point", TemporalExpired, date("2026-03-01T00:00:00Z")},
+		}},
+		{date("2024-01-01T00:00:00Z"), []TemporalIssue{
+			{4, "Contradiction", TemporalUnsatisfiable, time.Time{}},
+		}},
+	}
+
+	for i, testCase := range testCases {
+		if result := p.TemporalReport(testCase.now); !reflect.DeepEqual(result, testCase.expected) {
+			t.Fatalf("case %v: expected: %+v, got: %+v", i+1, testCase.expected, result)
+		}
+	}
+
+	// Expiring statements are reported within the window only.
+	now := date("2026-12-01T00:00:00Z")
+	if result := p.TemporalReportWithWindow(now, 60*24*time.Hour); len(result) != 7 || result[2].SID != "Later" {
+		t.Fatalf("expected: statement 'Later' expiring, got: %+v", result)
+	}
+	if result := p.TemporalReportWithWindow(now, time.Hour); len(result) != 6 {
+		t.Fatalf("expected: 6 issues, got: %+v", result)
+	}
+}
+
+func TestAllowedTimesValues(t *testing.T) {
+	key := condition.AWSCurrentTime.ToKey()
+	t1, t2 := "2026-01-01T00:00:00Z", "2026-06-01T00:00:00Z"
+	date := func(s string) time.Time {
+		v, _ := time.Parse(time.RFC3339, s)
+		return v
+	}
+
+	testCases := []struct {
+		conditions  []condition.Condition
+		expectedEnd *timeBound
+	}{
+		// Any value may allow the request time.
+		{[]condition.Condition{{Operator: "DateLessThan", Key: key, Values: []string{t1, t2}}}, &timeBound{t: date(t2), open: true}},
+		{[]condition.Condition{{Operator: "ForAnyValue:DateLessThan", Key: key, Values: []string{t1, t2}}}, &timeBound{t: date(t2), open: true}},
+		// All values must allow the request time.
+		{[]condition.Condition{{Operator: "ForAllValues:DateLessThan", Key: key, Values: []string{t1, t2}}}, &timeBound{t: date(t1), open: true}},
+		{[]condition.Condition{{Operator: "ForAllValues:DateLessThan", Key: key, Values: []string{t2, "invalid"}}}, nil},
+		{[]condition.Condition{{Operator: "DateLessThan", Key: key, Values: []string{"invalid"}}}, nil},
+		// Mixed operators.
+		{[]condition.Condition{
+			{Operator: "DateGreaterThanEquals", Key: key, Values: []string{t1}},
+			{Operator: "DateLessThanEquals", Key: key, Values: []string{t1}},
+		}, &timeBound{t: date(t1)}},
+		{[]condition.Condition{
+			{Operator: "DateGreaterThan", Key: key, Values: []string{t1}},
+			{Operator: "DateLessThanEquals", Key: key, Values: []string{t1}},
+		}, nil},
+		{[]condition.Condition{
+			{Operator: "DateNotEquals", Key: key, Values: []string{t1}},
+			{Operator: "NumericLessThanEquals", Key: condition.AWSEpochTime.ToKey(), Values: []string{"1767225600"}},
+		}, &timeBound{t: time.Unix(1767225600, 0), open: true}},
+		// Conditions on other keys are ignored.
+		{[]condition.Condition{{Operator: "DateLessThan", Key: condition.S3ObjectLockRetainUntilDate.ToKey(), Values: []string{t1}}}, &timeBound{inf: true}},
+	}
+
+	for i, testCase := range testCases {
+		allowed := allowedTimes(testCase.conditions)
+		if testCase.expectedEnd == nil {
+			if len(allowed) != 0 {
+				t.Fatalf("case %v: expected: unsatisfiable, got: %+v", i+1, allowed)
+			}
+			continue
+		}
+		if len(allowed) == 0 {
+			t.Fatalf("case %v: expected: %+v, got: unsatisfiable", i+1, *testCase.expectedEnd)
+		}
+		if result := allowed.end(); result.inf != testCase.expectedEnd.inf || result.open != testCase.expectedEnd.open || !result.t.Equal(testCase.expectedEnd.t) {
+			t.Fatalf("case %v: expected: %+v, got: %+v", i+1, *testCase.expectedEnd, result)
+		}
+	}
+}
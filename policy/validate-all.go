@@ -0,0 +1,149 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"sync"
+)
+
+// ValidationLimits - limits and options of ValidateAll.
+type ValidationLimits struct {
+	// MaxSize - maximum size of a policy document in bytes, zero means
+	// unlimited.
+	MaxSize int
+
+	// MaxStatements - maximum number of statements of a policy, zero
+	// means unlimited.
+	MaxStatements int
+
+	// MaxConditionOperators - maximum number of condition operators of a
+	// statement, such as "StringEquals", zero means unlimited.
+	MaxConditionOperators int
+
+	// MaxConditionValues - maximum number of values of a condition key of
+	// an operator, zero means unlimited.
+	MaxConditionValues int
+
+	// Options - validation options of each policy.
+	Options ValidationOptions
+
+	// FailFast - stop validating once a policy is invalid. Policies which
+	// were not validated then map to the error of the canceled context.
+	FailFast bool
+}
+
+// validateDoc - parses and validates the policy document doc as per
+// limits.
+func validateDoc(doc []byte, limits ValidationLimits) error {
+	if limits.MaxSize > 0 && len(doc) > limits.MaxSize {
+		return Errorf("%w: %d bytes exceed %d bytes", ErrPolicyTooLarge, len(doc), limits.MaxSize)
+	}
+
+	p, err := ParseConfigWithOptions(bytes.NewReader(doc), limits.Options)
+	if err != nil {
+		return err
+	}
+
+	if limits.MaxStatements > 0 && len(p.Statements) > limits.MaxStatements {
+		return Errorf("%w: %d statements exceed %d statements", ErrTooManyStatements, len(p.Statements), limits.MaxStatements)
+	}
+	return validateConditionLimits(p.Statements, limits)
+}
+
+// validateConditionLimits - checks the number of condition operators and
+// values of statements against limits.
+func validateConditionLimits(statements []Statement, limits ValidationLimits) error {
+	if limits.MaxConditionOperators <= 0 && limits.MaxConditionValues <= 0 {
+		return nil
+	}
+	for _, statement := range statements {
+		operators := map[string]struct{}{}
+		for _, c := range statement.Conditions.Conditions() {
+			operators[c.Operator] = struct{}{}
+			if limits.MaxConditionValues > 0 && len(c.Values) > limits.MaxConditionValues {
+				return Errorf("%w: %d values of '%s' exceed %d values", ErrTooManyConditionValues, len(c.Values), c.Key, limits.MaxConditionValues)
+			}
+		}
+		if limits.MaxConditionOperators > 0 && len(operators) > limits.MaxConditionOperators {
+			return Errorf("%w: %d operators exceed %d operators", ErrTooManyConditionOperators, len(operators), limits.MaxConditionOperators)
+		}
+	}
+	return nil
+}
+
+// ValidateAll - parses and validates the policy documents docs, keyed by
+// policy name, using up to parallelism goroutines, or GOMAXPROCS if
+// parallelism is not positive. It returns the error of each policy by
+// name, nil for valid policies.
+func ValidateAll(docs map[string][]byte, limits ValidationLimits, parallelism int) map[string]error {
+	if parallelism <= 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+	parallelism = min(parallelism, len(docs))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	names := make(chan string)
+	go func() {
+		defer close(names)
+		for name := range docs {
+			select {
+			case names <- name:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var mu sync.Mutex
+	errs := make(map[string]error, len(docs))
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range names {
+				if ctx.Err() != nil {
+					continue
+				}
+				err := validateDoc(docs[name], limits)
+
+				mu.Lock()
+				errs[name] = err
+				mu.Unlock()
+
+				if err != nil && limits.FailFast {
+					cancel()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Policies skipped after a failure report the cancellation.
+	for name := range docs {
+		if _, ok := errs[name]; !ok {
+			errs[name] = ctx.Err()
+		}
+	}
+	return errs
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func testPolicyDoc(i int) []byte {
+	return []byte(fmt.Sprintf(`{"Version": "2012-10-17", "Statement": [{"Sid": "s%d", "Effect": "Allow", "Action": ["s3:GetObject", "s3:PutObject"], "Resource": ["arn:aws:s3:::bucket%d/*"], "Condition": {"IpAddress": {"aws:SourceIp": ["10.%d.%d.0/24"]}}}]}`, i, i, i/256, i%256))
+}
+
+func TestValidateAll(t *testing.T) {
+	docs := map[string][]byte{
+		"invalid-json":   []byte(`{"Version": "2012-10-17", "Statement": [`),
+		"invalid-action": []byte(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:Foo", "Resource": "arn:aws:s3:::mybucket/*"}]}`),
+		"invalid-effect": []byte(`{"Version": "2012-10-17", "Statement": [{"Effect": "Maybe", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"}]}`),
+		"too-many":       []byte(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::a/*"}, {"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::b/*"}, {"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::c/*"}]}`),
+		"too-large":      []byte(fmt.Sprintf(`{"Version": "2012-10-17", "Statement": [{"Sid": "%0512d", "Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::a/*"}]}`, 0)),
+		"access-point":   []byte(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": ["arn:aws:s3:::a/*", "arn:aws:s3:us-east-1:123456789012:accesspoint/my-ap/object/*"]}]}`),
+	}
+	for i := 0; i < 100; i++ {
+		docs[fmt.Sprintf("valid%d", i)] = testPolicyDoc(i)
+	}
+	limits := ValidationLimits{MaxSize: 512, MaxStatements: 2}
+
+	for _, parallelism := range []int{0, 1, 4, len(docs) * 2} {
+		errs := ValidateAll(docs, limits, parallelism)
+		if len(errs) != len(docs) {
+			t.Fatalf("parallelism %v: expected: %v errors, got: %v", parallelism, len(docs), len(errs))
+		}
+		for name, err := range errs {
+			if expectErr := name[:5] != "valid" && name != "access-point"; expectErr != (err != nil) {
+				t.Fatalf("parallelism %v: %v: unexpected error: %v", parallelism, name, err)
+			}
+		}
+		if !errors.Is(errs["too-large"], ErrPolicyTooLarge) {
+			t.Fatalf("expected: %v, got: %v", ErrPolicyTooLarge, errs["too-large"])
+		}
+		if !errors.Is(errs["too-many"], ErrTooManyStatements) {
+			t.Fatalf("expected: %v, got: %v", ErrTooManyStatements, errs["too-many"])
+		}
+		var unsupported ErrUnsupportedAction
+		if !errors.As(errs["invalid-action"], &unsupported) {
+			t.Fatalf("expected: %T, got: %v", unsupported, errs["invalid-action"])
+		}
+	}
+
+	// Policies are validated as per the validation options.
+	limits.Options.RejectUnsupportedResources = true
+	var malformed ErrMalformedResource
+	if err := ValidateAll(docs, limits, 4)["access-point"]; !errors.As(err, &malformed) {
+		t.Fatalf("expected: %T, got: %v", malformed, err)
+	}
+
+	if errs := ValidateAll(nil, limits, 4); len(errs) != 0 {
+		t.Fatalf("expected no errors, got: %v", errs)
+	}
+}
+
+func TestValidateAllFailFast(t *testing.T) {
+	docs := make(map[string][]byte)
+	for i := 0; i < 500; i++ {
+		docs[fmt.Sprintf("valid%d", i)] = testPolicyDoc(i)
+		docs[fmt.Sprintf("invalid%d", i)] = []byte(`{"Version": "2012-10-17", "Statement": [{"Effect": "Maybe", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"}]}`)
+	}
+
+	errs := ValidateAll(docs, ValidationLimits{FailFast: true}, 1)
+	if len(errs) != len(docs) {
+		t.Fatalf("expected: %v errors, got: %v", len(docs), len(errs))
+	}
+
+	// Validation stops after the first invalid policy.
+	var invalid, canceled int
+	for name, err := range errs {
+		switch {
+		case errors.Is(err, context.Canceled):
+			canceled++
+		case errors.Is(err, ErrInvalidEffect):
+			invalid++
+		case err != nil:
+			t.Fatalf("%v: unexpected error: %v", name, err)
+		}
+	}
+	if invalid != 1 {
+		t.Fatalf("expected: 1 invalid policy, got: %v", invalid)
+	}
+	if canceled < 499 {
+		t.Fatalf("expected: at least 499 canceled policies, got: %v", canceled)
+	}
+}
+
+func TestValidateAllConditionLimits(t *testing.T) {
+	docs := map[string][]byte{
+		"values": []byte(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*",
+			"Condition": {"IpAddress": {"aws:SourceIp": ["10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"]}}}]}`),
+		"operators": []byte(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*",
+			"Condition": {"IpAddress": {"aws:SourceIp": "10.0.0.0/8"}, "StringEquals": {"aws:username": "alice"}, "Bool": {"aws:SecureTransport": "true"}}}]}`),
+		"valid": []byte(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*",
+			"Condition": {"IpAddress": {"aws:SourceIp": ["10.0.0.0/8", "172.16.0.0/12"]}, "StringEquals": {"aws:username": "alice", "aws:userid": "alice"}}}]}`),
+	}
+
+	errs := ValidateAll(docs, ValidationLimits{MaxConditionOperators: 2, MaxConditionValues: 2}, 0)
+	if !errors.Is(errs["values"], ErrTooManyConditionValues) || !errors.Is(errs["operators"], ErrTooManyConditionOperators) || errs["valid"] != nil {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	for name, err := range ValidateAll(docs, ValidationLimits{}, 0) {
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", name, err)
+		}
+	}
+}
+
+func BenchmarkValidateAll(b *testing.B) {
+	const policies = 5000
+	docs := make(map[string][]byte, policies)
+	for i := 0; i < policies; i++ {
+		docs[fmt.Sprintf("policy%d", i)] = testPolicyDoc(i)
+	}
+
+	for _, parallelism := range []int{1, 0} {
+		name := "serial"
+		if parallelism == 0 {
+			name = "parallel"
+		}
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for name, err := range ValidateAll(docs, ValidationLimits{}, parallelism) {
+					if err != nil {
+						b.Fatalf("%v: %v", name, err)
+					}
+				}
+			}
+		})
+	}
+}
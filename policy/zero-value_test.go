@@ -0,0 +1,181 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+// zeroValueArg - returns the argument passed for a parameter of type t when
+// calling methods on zero values, the zero value of t except for
+// interfaces which need a usable implementation.
+func zeroValueArg(t reflect.Type) reflect.Value {
+	switch t {
+	case reflect.TypeOf((*io.Writer)(nil)).Elem():
+		return reflect.ValueOf(io.Discard)
+	case reflect.TypeOf((*context.Context)(nil)).Elem():
+		return reflect.ValueOf(context.Background())
+	}
+	if t.Kind() == reflect.Func {
+		return reflect.MakeFunc(t, func([]reflect.Value) []reflect.Value {
+			results := make([]reflect.Value, t.NumOut())
+			for i := range results {
+				results[i] = reflect.Zero(t.Out(i))
+			}
+			return results
+		})
+	}
+	return reflect.Zero(t)
+}
+
+// callZeroValueMethods - calls all exported methods of v with zero value
+// arguments and reports the ones which panic.
+func callZeroValueMethods(t *testing.T, v reflect.Value, skip map[string]bool) {
+	t.Helper()
+	for i := 0; i < v.NumMethod(); i++ {
+		name := v.Type().Method(i).Name
+		if skip[name] {
+			continue
+		}
+		method := v.Method(i)
+		args := make([]reflect.Value, method.Type().NumIn())
+		for j := range args {
+			args[j] = zeroValueArg(method.Type().In(j))
+		}
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("%v.%v: unexpected panic: %v", v.Type(), name, r)
+				}
+			}()
+			if method.Type().IsVariadic() {
+				method.CallSlice(args)
+			} else {
+				method.Call(args)
+			}
+		}()
+	}
+}
+
+func TestZeroValueMethods(t *testing.T) {
+	// Add inserts into the set in place, as for a nil map it panics on a
+	// nil set.
+	sets := map[string]bool{"Add": true}
+
+	testCases := []struct {
+		value interface{}
+		skip  map[string]bool
+	}{
+		{new(Policy), nil},
+		{new(Statement), nil},
+		{new(BucketPolicy), nil},
+		{new(BPStatement), nil},
+		{new(ActionSet), sets},
+		{new(ResourceSet), sets},
+		{new(Principal), nil},
+		{new(Resource), nil},
+		{new(Args), nil},
+		{new(BucketPolicyArgs), nil},
+		{new(condition.Functions), nil},
+	}
+
+	for _, testCase := range testCases {
+		// Value receiver methods are called on the zero value, pointer
+		// receiver methods on a pointer to a zero value.
+		callZeroValueMethods(t, reflect.ValueOf(testCase.value).Elem(), testCase.skip)
+		callZeroValueMethods(t, reflect.New(reflect.TypeOf(testCase.value).Elem()), testCase.skip)
+	}
+}
+
+func TestZeroValueJSONRoundTrip(t *testing.T) {
+	testCases := []struct {
+		value  interface{}
+		equals func(a, b interface{}) bool
+	}{
+		{Policy{}, func(a, b interface{}) bool { p := a.(Policy); return p.Equals(b.(Policy)) }},
+		{Statement{}, func(a, b interface{}) bool { return a.(Statement).Equals(b.(Statement)) }},
+		{
+			Statement{Effect: Deny, NotActions: NewActionSet(GetObjectAction), Resources: NewResourceSet(NewResource("mybucket/*"))},
+			func(a, b interface{}) bool { return a.(Statement).Equals(b.(Statement)) },
+		},
+		{BucketPolicy{}, func(a, b interface{}) bool { p := a.(BucketPolicy); return p.Equals(b.(BucketPolicy)) }},
+		{BPStatement{}, func(a, b interface{}) bool { return a.(BPStatement).Equals(b.(BPStatement)) }},
+		{Principal{}, func(a, b interface{}) bool { return a.(Principal).Equals(b.(Principal)) }},
+		{ResourceSet(nil), func(a, b interface{}) bool { return a.(ResourceSet).Equals(b.(ResourceSet)) }},
+		// Nil condition.Functions are encoded as {}, which is rejected as
+		// an empty condition, statements omit them.
+	}
+
+	for i, testCase := range testCases {
+		data, err := json.Marshal(testCase.value)
+		if err != nil {
+			t.Fatalf("case %v: %T: unexpected error: %v", i+1, testCase.value, err)
+		}
+		decoded := reflect.New(reflect.TypeOf(testCase.value))
+		if err = json.Unmarshal(data, decoded.Interface()); err != nil {
+			t.Fatalf("case %v: %T: %s: unexpected error: %v", i+1, testCase.value, data, err)
+		}
+		if got := decoded.Elem().Interface(); !testCase.equals(testCase.value, got) {
+			t.Fatalf("case %v: %T: %s: expected: %v, got: %v", i+1, testCase.value, data, testCase.value, got)
+		}
+	}
+}
+
+func TestZeroValueActionSet(t *testing.T) {
+	var nilSet ActionSet
+	emptySet := NewActionSet()
+	for _, action := range []Action{GetObjectAction, AllActions, "s3:*", ""} {
+		if nilSet.Match(action) != emptySet.Match(action) {
+			t.Fatalf("%v: expected: %v, got: %v", action, emptySet.Match(action), nilSet.Match(action))
+		}
+		if nilSet.Contains(action) != emptySet.Contains(action) {
+			t.Fatalf("%v: expected: %v, got: %v", action, emptySet.Contains(action), nilSet.Contains(action))
+		}
+	}
+	if !nilSet.Equals(emptySet) || !emptySet.Equals(nilSet) {
+		t.Fatalf("expected nil and empty action sets to be equal")
+	}
+	if s := fmt.Sprint(nilSet); s != fmt.Sprint(emptySet) {
+		t.Fatalf("expected: %v, got: %v", emptySet, s)
+	}
+}
+
+func TestZeroValuePolicyDenies(t *testing.T) {
+	if (Policy{}).IsAllowed(Args{Action: GetObjectAction, BucketName: "mybucket"}) {
+		t.Fatalf("expected the zero Policy to deny")
+	}
+	if (BucketPolicy{}).IsAllowed(BucketPolicyArgs{Action: GetObjectAction, BucketName: "mybucket"}) {
+		t.Fatalf("expected the zero BucketPolicy to deny")
+	}
+	if err := (Policy{}).Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := (BucketPolicy{}).Validate("mybucket"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := (Statement{}).Validate(); err == nil {
+		t.Fatalf("expected the zero Statement to be invalid")
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package quick
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+// backupSuffix separates the config file name from the backup timestamp,
+// e.g. "config.json.bak-20240101T150405.000000000Z".
+const backupSuffix = ".bak-"
+
+// backupTimeFormat is fixed width so that backups sort by name in the
+// order they were taken.
+const backupTimeFormat = "20060102T150405.000000000Z"
+
+// Option configures a Config created by NewConfig.
+type Option func(*config)
+
+// KeepBackups makes Save keep the previous content of the config file in
+// up to n timestamped backups next to it, older backups are removed. With
+// n <= 0, the default, only a single "<file>.old" copy is kept.
+func KeepBackups(n int) Option {
+	return func(d *config) {
+		d.backups = n
+	}
+}
+
+// CorruptConfigError is returned by Load when the config file exists but
+// cannot be decoded, e.g. because it was truncated.
+type CorruptConfigError struct {
+	Filename        string
+	BackupAvailable bool
+	Err             error
+}
+
+func (e *CorruptConfigError) Error() string {
+	if e.BackupAvailable {
+		return fmt.Sprintf("config file '%s' is corrupt, a backup is available: %v", e.Filename, e.Err)
+	}
+	return fmt.Sprintf("config file '%s' is corrupt: %v", e.Filename, e.Err)
+}
+
+func (e *CorruptConfigError) Unwrap() error {
+	return e.Err
+}
+
+// backupFiles returns the backups of filename, most recent first. The
+// "<file>.old" copy, if any, comes after all timestamped backups.
+func backupFiles(filename string) ([]string, error) {
+	matches, err := filepath.Glob(globEscape(filename) + backupSuffix + "*")
+	if err != nil {
+		return nil, err
+	}
+	backups := matches[:0]
+	for _, match := range matches {
+		ts := strings.TrimPrefix(match, filename+backupSuffix)
+		if _, err := time.Parse(backupTimeFormat, ts); err == nil {
+			backups = append(backups, match)
+		}
+	}
+	slices.Sort(backups)
+	slices.Reverse(backups)
+
+	if fi, err := os.Stat(filename + ".old"); err == nil && fi.Mode().IsRegular() {
+		backups = append(backups, filename+".old")
+	}
+	return backups, nil
+}
+
+// globEscape escapes the glob meta characters in name.
+func globEscape(name string) string {
+	if runtime.GOOS == "windows" {
+		// '\' is the path separator on windows and cannot escape.
+		return strings.NewReplacer("*", "[*]", "?", "[?]", "[", "[[]").Replace(name)
+	}
+	return strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`).Replace(name)
+}
+
+// backupFile saves data as a new timestamped backup of filename and
+// removes all but the keep most recent ones.
+func backupFile(filename string, data []byte, keep int) error {
+	name := filename + backupSuffix + time.Now().UTC().Format(backupTimeFormat)
+	if err := writeFile(name, data); err != nil {
+		return err
+	}
+
+	backups, err := backupFiles(filename)
+	if err != nil {
+		return err
+	}
+	// Never prune the "<file>.old" copy, it is not ours to rotate.
+	backups = slices.DeleteFunc(backups, func(backup string) bool {
+		return backup == filename+".old"
+	})
+	for _, backup := range backups[min(keep, len(backups)):] {
+		if err := os.Remove(backup); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Restore replaces the config file with one of its backups, version 0
+// being the most recent backup, 1 the one before it and so on.
+func Restore(filename string, version int) error {
+	backups, err := backupFiles(filename)
+	if err != nil {
+		return err
+	}
+	if version < 0 || version >= len(backups) {
+		return fmt.Errorf("backup version %d of config file '%s' not found, %d available", version, filename, len(backups))
+	}
+
+	data, err := os.ReadFile(backups[version])
+	if err != nil {
+		return err
+	}
+	return writeFile(filename, data)
+}
+
+// hasBackup returns whether filename has any backup to restore.
+func hasBackup(filename string) bool {
+	backups, err := backupFiles(filename)
+	return err == nil && len(backups) > 0
+}
+
+// writeFile atomically replaces the file named by filename with data. The
+// data is written and synced to a temporary file in the same directory,
+// which is then renamed over filename, so a crash leaves either the old
+// or the new content, never a partial one.
+func writeFile(filename string, data []byte) error {
+	tmpName, err := writeTempFile(filename, data)
+	if err != nil {
+		return err
+	}
+	if err = renameFile(tmpName, filename); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	syncDir(filepath.Dir(filename))
+	return nil
+}
+
+// writeTempFile writes data to a new synced temporary file next to
+// filename and returns its name.
+func writeTempFile(filename string, data []byte) (string, error) {
+	dir := filepath.Dir(filename)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp(dir, "$tmpfile."+filepath.Base(filename)+".")
+	if err != nil {
+		return "", err
+	}
+	if err = f.Chmod(0o600); err == nil {
+		if _, err = f.Write(data); err == nil {
+			err = f.Sync()
+		}
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// renameFile renames oldpath over newpath. On windows the rename fails
+// while another process, e.g. a virus scanner, holds newpath open, so it
+// is retried for a short while before giving up.
+func renameFile(oldpath, newpath string) error {
+	err := os.Rename(oldpath, newpath)
+	if err == nil || runtime.GOOS != "windows" {
+		return err
+	}
+	if fi, serr := os.Stat(newpath); serr == nil && fi.IsDir() {
+		return err
+	}
+	for i := 0; i < 10 && errors.Is(err, os.ErrPermission); i++ {
+		time.Sleep(10 * time.Millisecond)
+		err = os.Rename(oldpath, newpath)
+	}
+	return err
+}
+
+// syncDir flushes the directory entry of a renamed file to disk, errors
+// are ignored as not all platforms support syncing directories.
+func syncDir(dir string) {
+	if runtime.GOOS == "windows" {
+		return
+	}
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+}
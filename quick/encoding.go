@@ -186,5 +186,12 @@ func loadFileConfig(filename string, v interface{}) error {
 	}
 
 	// Unmarshal file's content
-	return toUnmarshaller(filepath.Ext(filename))(fileData, v)
+	if err = toUnmarshaller(filepath.Ext(filename))(fileData, v); err != nil {
+		return &CorruptConfigError{
+			Filename:        filename,
+			BackupAvailable: hasBackup(filename),
+			Err:             err,
+		}
+	}
+	return nil
 }
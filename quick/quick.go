@@ -26,7 +26,6 @@ import (
 	"sync"
 
 	"github.com/fatih/structs"
-	"github.com/minio/pkg/v3/safe"
 	etcd "go.etcd.io/etcd/client/v3"
 )
 
@@ -46,6 +45,9 @@ type config struct {
 	data interface{}
 	clnt *etcd.Client
 	lock *sync.RWMutex
+
+	// backups is the number of timestamped backups kept by Save.
+	backups int
 }
 
 // Version returns the current config file format version
@@ -79,6 +81,10 @@ func (d config) Save(filename string) error {
 		if !os.IsNotExist(err) {
 			return err
 		}
+	} else if d.backups > 0 {
+		if err = backupFile(filename, oldData, d.backups); err != nil {
+			return err
+		}
 	} else {
 		// Save read data to the backup file.
 		backupFilename := filename + ".old"
@@ -93,7 +99,8 @@ func (d config) Save(filename string) error {
 
 // Load - loads config from file and merge with currently set values
 // File content format is guessed from the file name extension, if not
-// available, consider that we have JSON.
+// available, consider that we have JSON. A file which cannot be decoded
+// is reported as *CorruptConfigError.
 func (d config) Load(filename string) error {
 	d.lock.Lock()
 	defer d.lock.Unlock()
@@ -172,21 +179,6 @@ func CheckData(data interface{}) error {
 	return nil
 }
 
-// writeFile writes data to a file named by filename.
-// If the file does not exist, writeFile creates it;
-// otherwise writeFile truncates it before writing.
-func writeFile(filename string, data []byte) error {
-	safeFile, err := safe.CreateFile(filename)
-	if err != nil {
-		return err
-	}
-	_, err = safeFile.Write(data)
-	if err != nil {
-		return err
-	}
-	return safeFile.Close()
-}
-
 // GetVersion - extracts the version information.
 func GetVersion(filename string, clnt *etcd.Client) (version string, err error) {
 	var qc Config
@@ -200,8 +192,8 @@ func GetVersion(filename string, clnt *etcd.Client) (version string, err error)
 }
 
 // LoadConfig - loads json config from filename for the a given struct data
-func LoadConfig(filename string, clnt *etcd.Client, data interface{}) (qc Config, err error) {
-	qc, err = NewConfig(data, clnt)
+func LoadConfig(filename string, clnt *etcd.Client, data interface{}, opts ...Option) (qc Config, err error) {
+	qc, err = NewConfig(data, clnt, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -209,12 +201,12 @@ func LoadConfig(filename string, clnt *etcd.Client, data interface{}) (qc Config
 }
 
 // SaveConfig - saves given configuration data into given file as JSON.
-func SaveConfig(data interface{}, filename string, clnt *etcd.Client) (err error) {
+func SaveConfig(data interface{}, filename string, clnt *etcd.Client, opts ...Option) (err error) {
 	if err = CheckData(data); err != nil {
 		return err
 	}
 	var qc Config
-	qc, err = NewConfig(data, clnt)
+	qc, err = NewConfig(data, clnt, opts...)
 	if err != nil {
 		return err
 	}
@@ -223,7 +215,7 @@ func SaveConfig(data interface{}, filename string, clnt *etcd.Client) (err error
 
 // NewConfig loads config from etcd client if provided, otherwise loads from a local filename.
 // fails when all else fails.
-func NewConfig(data interface{}, clnt *etcd.Client) (cfg Config, err error) {
+func NewConfig(data interface{}, clnt *etcd.Client, opts ...Option) (cfg Config, err error) {
 	if err := CheckData(data); err != nil {
 		return nil, err
 	}
@@ -232,5 +224,8 @@ func NewConfig(data interface{}, clnt *etcd.Client) (cfg Config, err error) {
 	d.data = data
 	d.clnt = clnt
 	d.lock = new(sync.RWMutex)
+	for _, opt := range opts {
+		opt(d)
+	}
 	return d, nil
 }
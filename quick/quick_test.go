@@ -20,10 +20,13 @@ package quick
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
 	"runtime"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -503,3 +506,127 @@ func TestDeepDiff(t *testing.T) {
 	//		fmt.Printf("DeepDiff[%d]: %s=%v\n", i, field.Name(), field.Value())
 	//	}
 }
+
+func TestSavePartialWrite(t *testing.T) {
+	type myStruct struct {
+		Version string
+		User    string
+	}
+	filename := filepath.Join(t.TempDir(), "config.json")
+	saveMe := myStruct{"1", "guest"}
+	if err := SaveConfig(&saveMe, filename, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate a crash after writing the temporary file but before
+	// renaming it over the config file.
+	tmpName, err := writeTempFile(filename, []byte(`{"Version": "2", "Us`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpName)
+
+	loadMe := myStruct{}
+	if _, err = LoadConfig(filename, nil, &loadMe); err != nil {
+		t.Fatal(err)
+	}
+	if loadMe != saveMe {
+		t.Fatalf("Expected %v, got %v", saveMe, loadMe)
+	}
+}
+
+func TestLoadCorruptRestore(t *testing.T) {
+	type myStruct struct {
+		Version string
+		User    string
+	}
+	filename := filepath.Join(t.TempDir(), "config.json")
+	saveMe := myStruct{"1", "guest"}
+	if err := SaveConfig(&saveMe, filename, nil, KeepBackups(2)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without any backup, the corrupt file cannot be recovered.
+	if err := os.Truncate(filename, 10); err != nil {
+		t.Fatal(err)
+	}
+	var cerr *CorruptConfigError
+	_, err := LoadConfig(filename, nil, &myStruct{})
+	if !errors.As(err, &cerr) {
+		t.Fatalf("Expected CorruptConfigError, got %v", err)
+	}
+	if cerr.BackupAvailable {
+		t.Fatal("Expected no backup to be available")
+	}
+	if err = Restore(filename, 0); err == nil {
+		t.Fatal("Unexpected restore without backup to succeed")
+	}
+
+	if err = SaveConfig(&saveMe, filename, nil, KeepBackups(2)); err != nil {
+		t.Fatal(err)
+	}
+	if err = SaveConfig(&myStruct{"2", "admin"}, filename, nil, KeepBackups(2)); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Truncate(filename, 10); err != nil {
+		t.Fatal(err)
+	}
+	_, err = LoadConfig(filename, nil, &myStruct{})
+	if !errors.As(err, &cerr) {
+		t.Fatalf("Expected CorruptConfigError, got %v", err)
+	}
+	if !cerr.BackupAvailable {
+		t.Fatal("Expected a backup to be available")
+	}
+
+	if err = Restore(filename, 0); err != nil {
+		t.Fatal(err)
+	}
+	loadMe := myStruct{}
+	if _, err = LoadConfig(filename, nil, &loadMe); err != nil {
+		t.Fatal(err)
+	}
+	if loadMe != saveMe {
+		t.Fatalf("Expected %v, got %v", saveMe, loadMe)
+	}
+}
+
+func TestSaveBackupRotation(t *testing.T) {
+	type myStruct struct {
+		Version string
+	}
+	filename := filepath.Join(t.TempDir(), "config.json")
+	for i := 1; i <= 5; i++ {
+		if err := SaveConfig(&myStruct{strconv.Itoa(i)}, filename, nil, KeepBackups(3)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	backups, err := backupFiles(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 3 {
+		t.Fatalf("Expected 3 backups, got %v", backups)
+	}
+	if _, err = os.Stat(filename + ".old"); !os.IsNotExist(err) {
+		t.Fatalf("Expected no .old file with backups enabled, got %v", err)
+	}
+
+	// Backups are ordered most recent first.
+	for version, expected := range []string{"4", "3", "2"} {
+		if err = Restore(filename, version); err != nil {
+			t.Fatal(err)
+		}
+		got, err := GetVersion(filename, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != expected {
+			t.Fatalf("Expected version '%v', got '%v'", expected, got)
+		}
+	}
+	if err = Restore(filename, 3); err == nil {
+		t.Fatal("Unexpected restore of pruned backup to succeed")
+	}
+}
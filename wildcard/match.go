@@ -17,6 +17,8 @@
 
 package wildcard
 
+import "encoding/binary"
+
 // MatchSimple - finds whether the text matches/satisfies the pattern string.
 // supports '*' wildcard in the pattern and ? for single characters.
 // Only difference to Match is that `?` at the end is optional,
@@ -97,3 +99,128 @@ func MatchAsPatternPrefix(pattern, text string) bool {
 	}
 	return len(text) <= len(pattern)
 }
+
+// maxSubsetStates - maximum number of states Subset explores before it
+// gives up.
+const maxSubsetStates = 1 << 14
+
+// Subset - finds whether every name matching pattern a, as per Match, also
+// matches pattern b. Both patterns are compiled into automata over the
+// characters used in the patterns and a character standing for all others,
+// the names of a are then traced through the determinized automaton of b,
+// which is exact for '*' and '?'. Patterns interleaving many '*' and '?'
+// may require too many states, Subset is then conservatively false.
+func Subset(a, b string) bool {
+	if a == b || b == "*" {
+		return true
+	}
+
+	// Characters of the patterns, and one which is in neither.
+	var used [256]bool
+	var alphabet []byte
+	for _, p := range []string{a, b} {
+		for i := 0; i < len(p); i++ {
+			if c := p[i]; c != '*' && c != '?' && !used[c] {
+				used[c] = true
+				alphabet = append(alphabet, c)
+			}
+		}
+	}
+	for c := 0; c < len(used); c++ {
+		if !used[c] {
+			alphabet = append(alphabet, byte(c))
+			break
+		}
+	}
+
+	// States of b are the indexes of its next pattern character, with
+	// len(b) accepting, sets of states are bitmaps.
+	words := len(b)/64 + 1
+	closure := func(set []uint64) []uint64 {
+		for i := 0; i < len(b); i++ {
+			if set[i/64]&(1<<(i%64)) != 0 && b[i] == '*' {
+				set[(i+1)/64] |= 1 << ((i + 1) % 64)
+			}
+		}
+		return set
+	}
+	step := func(set []uint64, c byte) []uint64 {
+		next := make([]uint64, words)
+		empty := true
+		for i := 0; i < len(b); i++ {
+			if set[i/64]&(1<<(i%64)) == 0 {
+				continue
+			}
+			switch b[i] {
+			case '*':
+				next[i/64] |= 1 << (i % 64)
+			case '?', c:
+				next[(i+1)/64] |= 1 << ((i + 1) % 64)
+			default:
+				continue
+			}
+			empty = false
+		}
+		if empty {
+			return nil
+		}
+		return closure(next)
+	}
+	key := func(i int, set []uint64) string {
+		k := make([]byte, 0, 8*(words+1))
+		k = binary.LittleEndian.AppendUint64(k, uint64(i))
+		for _, w := range set {
+			k = binary.LittleEndian.AppendUint64(k, w)
+		}
+		return string(k)
+	}
+
+	type state struct {
+		i   int // index of the next pattern character of a
+		set []uint64
+	}
+	start := make([]uint64, words)
+	start[0] = 1
+	pending := []state{{0, closure(start)}}
+	seen := map[string]bool{key(0, pending[0].set): true}
+	push := func(i int, set []uint64) {
+		if k := key(i, set); !seen[k] {
+			seen[k] = true
+			pending = append(pending, state{i, set})
+		}
+	}
+	for len(pending) > 0 {
+		if len(seen) > maxSubsetStates {
+			return false
+		}
+		s := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if s.i == len(a) {
+			// A name of a ends here, it must be accepted by b.
+			if s.set[len(b)/64]&(1<<(len(b)%64)) == 0 {
+				return false
+			}
+			continue
+		}
+
+		next, chars := s.i+1, alphabet
+		switch a[s.i] {
+		case '*':
+			// Matches the empty string or any character repeatedly.
+			push(s.i+1, s.set)
+			next = s.i
+		case '?':
+		default:
+			chars = []byte{a[s.i]}
+		}
+		for _, c := range chars {
+			set := step(s.set, c)
+			if set == nil {
+				// Any name of a continuing with c is not matched by b.
+				return false
+			}
+			push(next, set)
+		}
+	}
+	return true
+}
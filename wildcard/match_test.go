@@ -829,3 +829,72 @@ func TestMatchAsPatternPrefix(t *testing.T) {
 		}
 	}
 }
+
+func TestSubset(t *testing.T) {
+	testCases := []struct {
+		a, b   string
+		subset bool
+	}{
+		{"mybucket/app/*", "mybucket/*", true},
+		{"mybucket/*", "*", true},
+		{"mybucket/*", "mybucket/app/*", false},
+		{"mybucket/app/x", "mybucket/app/?", true},
+		{"mybucket/app/?", "mybucket/app/x", false},
+		{"mybucket/??", "mybucket/?*", true},
+		{"mybucket/?*", "mybucket/??", false},
+		{"mybucket/*?", "mybucket/?*", true},
+		{"a*b*c", "a*c", true},
+		{"a*c", "a*b*c", false},
+		{"*a*", "*", true},
+		{"", "*", true},
+		{"", "?", false},
+		{"*", "", false},
+		{"s3:Get*", "s3:*", true},
+		{"s3:*", "s3:Get*", false},
+		{"*/*/x", "*/x", true},
+		{"*/x", "*/*/x", false},
+	}
+
+	for i, testCase := range testCases {
+		if subset := Subset(testCase.a, testCase.b); subset != testCase.subset {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.subset, subset)
+		}
+	}
+}
+
+// TestSubsetExhaustive compares Subset with matching all short names for
+// all short patterns.
+func TestSubsetExhaustive(t *testing.T) {
+	expand := func(alphabet string, maxLen int) []string {
+		all := []string{""}
+		for prev := all; maxLen > 0; maxLen-- {
+			var next []string
+			for _, s := range prev {
+				for _, c := range alphabet {
+					next = append(next, s+string(c))
+				}
+			}
+			all = append(all, next...)
+			prev = next
+		}
+		return all
+	}
+	patterns := expand("ab*?", 3)
+	names := expand("abc", 6)
+
+	for _, a := range patterns {
+		for _, b := range patterns {
+			// Short patterns have short counterexamples, if any.
+			subset := true
+			for _, name := range names {
+				if Match(a, name) && !Match(b, name) {
+					subset = false
+					break
+				}
+			}
+			if got := Subset(a, b); got != subset {
+				t.Fatalf("Subset(%q, %q): expected: %v, got: %v", a, b, subset, got)
+			}
+		}
+	}
+}